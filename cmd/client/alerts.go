@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var alertEvaluateCmd = &cobra.Command{
+	Use:   "evaluate",
+	Short: "Evaluate the alert rules now",
+	Long: `Evaluate the server's alert rules now instead of waiting for the next
+periodic evaluation. Alerts that start or stop firing are announced as usual.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.EvaluateAlerts(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate alerts: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+var alertRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the alert rules",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		rules, err := c.GetAlertRules(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get alert rules: %w", err)
+		}
+		return printResult(rules)
+	},
+}
+
+func init() {
+	alertCmd.AddCommand(alertEvaluateCmd)
+	alertCmd.AddCommand(alertRulesCmd)
+}
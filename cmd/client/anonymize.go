@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/example/inventory-v3/pkg/anonymize"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// anonymizeOutput replaces the identifiers in everything printResult prints.
+var anonymizeOutput bool
+
+var anonymizeCmd = &cobra.Command{
+	Use:   "anonymize [file]",
+	Short: "Replace serials, MACs and IPs in JSON inventory data",
+	Long: `Replace the serial numbers, asset tags, UUIDs, MAC and IP addresses in
+JSON inventory data, such as an export, a discovery snapshot or a Redfish
+capture, with stand-ins of the same shape, so that it can be shared with
+developers or vendors. Reads the file, or standard input, which may hold one
+JSON document or several, e.g. one per line.
+
+The stand-ins are keyed hashes: the same key gives the same stand-in for an
+identifier in every file, so devices still match across files. The key is
+read from INVENTORY_V3_ANONYMIZE_KEY or anonymize_key in the config file;
+keep it to yourself.
+
+To anonymize what another command prints, use --anonymize, e.g.
+
+  device list -o json --anonymize > devices.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := newAnonymizer()
+		if err != nil {
+			return err
+		}
+		var in io.Reader = os.Stdin
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+		return a.Stream(in, os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(anonymizeCmd)
+
+	rootCmd.PersistentFlags().BoolVar(&anonymizeOutput, "anonymize", false, "replace serials, MACs and IPs in the output (key from INVENTORY_V3_ANONYMIZE_KEY)")
+}
+
+func newAnonymizer() (*anonymize.Anonymizer, error) {
+	a, err := anonymize.New(viper.GetString("anonymize_key"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: set INVENTORY_V3_ANONYMIZE_KEY or anonymize_key in the config file", err)
+	}
+	return a, nil
+}
+
+// anonymized returns data with its identifiers replaced, as the JSON it
+// prints as.
+func anonymized(data interface{}) (interface{}, error) {
+	a, err := newAnonymizer()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return a.Value(doc), nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file|dir|->",
+	Short: "Create or update resources from YAML documents",
+	Long: `Create or update the resources described by Kubernetes-style YAML
+documents, as export writes them:
+
+  apiVersion: v1
+  kind: Device
+  metadata:
+    name: node-x1000c0s0b0n0
+    labels:
+      rack: x1000
+  spec:
+    deviceType: Node
+    ...
+
+A document updates the resource with its metadata.uid, else the one with its
+metadata.name, else creates one. Supported kinds: ` + strings.Join(client.DeclarativeKinds(), ", ") + `.
+
+-f takes a file, a directory (its .yaml, .yml and .json files, by name), or -
+for standard input, and may be repeated. Use --dry-run to see what would
+change without changing it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		files, _ := cmd.Flags().GetStringSlice("filename")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if len(files) == 0 {
+			return fmt.Errorf("-f is required")
+		}
+		docs, err := readDocuments(files)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		applier := c.NewApplier(dryRun)
+		results := make([]client.ApplyResult, 0, len(docs))
+		for _, doc := range docs {
+			result, err := applier.Apply(ctx, doc)
+			if err != nil {
+				return fmt.Errorf("failed to apply %s %s: %w", doc.Kind, doc.Metadata.Name, err)
+			}
+			results = append(results, result)
+			if tableOutput() {
+				suffix := ""
+				if dryRun {
+					suffix = " (dry run)"
+				}
+				fmt.Printf("%s/%s %s%s\n", strings.ToLower(result.Kind), result.Name, result.Action, suffix)
+			}
+		}
+		if tableOutput() {
+			return nil
+		}
+		return printResult(results)
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export resources as YAML documents",
+	Long: `Write the inventory's resources as a multi-document YAML stream that apply
+reads back. Status, which the server observes, is left out, as are retired
+devices. Use --kind to export some kinds only, and -o json for a JSON array.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		kinds, _ := cmd.Flags().GetStringSlice("kind")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		docs, err := c.Export(ctx, kinds)
+		if err != nil {
+			return fmt.Errorf("failed to export resources: %w", err)
+		}
+		if output == "table" || output == "yaml" {
+			return client.EncodeDocuments(os.Stdout, docs)
+		}
+		return printResult(docs)
+	},
+}
+
+// readDocuments reads the documents of each file, directory, or - for
+// standard input.
+func readDocuments(paths []string) ([]client.Document, error) {
+	var docs []client.Document
+	for _, path := range paths {
+		if path == "-" {
+			read, err := client.DecodeDocuments(os.Stdin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read standard input: %w", err)
+			}
+			docs = append(docs, read...)
+			continue
+		}
+		files, err := documentFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			read, err := readDocumentFile(file)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, read...)
+		}
+	}
+	return docs, nil
+}
+
+// documentFiles returns path, or the YAML and JSON files of the directory
+// path, by name.
+func documentFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func readDocumentFile(file string) ([]client.Document, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	docs, err := client.DecodeDocuments(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return docs, nil
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(exportCmd)
+
+	applyCmd.Flags().StringSliceP("filename", "f", nil, "File, directory, or - for standard input (repeatable)")
+	applyCmd.Flags().Bool("dry-run", false, "Show what would change without changing anything")
+	exportCmd.Flags().StringSlice("kind", nil, "Kinds to export (default all: "+strings.Join(client.DeclarativeKinds(), ", ")+")")
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/attestation"
+
+	"github.com/spf13/cobra"
+)
+
+var attestationStatement bool
+
+var discoverysnapshotAttestationCmd = &cobra.Command{
+	Use:   "attestation [uid]",
+	Short: "Print the in-toto attestation of a DiscoverySnapshot",
+	Long: `Print the attestation of the collection run behind a snapshot: a DSSE envelope
+holding an in-toto Statement of what was collected, from which BMC, when and
+by which collector version, whose subject is the SHA-256 digest of the
+snapshot's signed content. Collectors with a --signing-key sign it with that
+key. Save the envelope to verify it with in-toto tooling, or print the
+statement with --statement.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetDiscoverySnapshot(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get DiscoverySnapshot: %w", err)
+		}
+		envelope := item.Spec.Attestation
+		if envelope == nil {
+			return fmt.Errorf("snapshot %s has no attestation", args[0])
+		}
+		if !attestationStatement {
+			return printResult(envelope)
+		}
+		statement, err := attestation.Decode(envelope)
+		if err != nil {
+			return err
+		}
+		return printResult(statement)
+	},
+}
+
+func init() {
+	discoverysnapshotAttestationCmd.Flags().BoolVar(&attestationStatement, "statement", false, "Print the statement instead of the envelope")
+	discoverysnapshotCmd.AddCommand(discoverysnapshotAttestationCmd)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var deviceBOMCmd = &cobra.Command{
+	Use:   "bom [uid]",
+	Short: "Export the hardware bill of materials of a node or rack",
+	Long: `Export the hardware bill of materials of a device, usually a node or
+rack, and everything in it as CycloneDX JSON. Use --file to write it to a file.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		file, _ := cmd.Flags().GetString("file")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.GetDeviceBOM(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to export BOM: %w", err)
+		}
+
+		if file == "" {
+			return printResult(result)
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode BOM: %w", err)
+		}
+		if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write BOM: %w", err)
+		}
+		fmt.Printf("Wrote BOM for %s to %s\n", args[0], file)
+		return nil
+	},
+}
+
+func init() {
+	deviceCmd.AddCommand(deviceBOMCmd)
+
+	deviceBOMCmd.Flags().String("file", "", "Write the BOM to this file instead of printing it")
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const bulkPatchHelp = `A CSV header names the columns: uid or serialNumber identify the device,
+and the others what to set: labels.KEY, properties.KEY, or assetTag, row,
+rack, rackOffset or rackOffsetUnits for those properties. Empty cells leave
+their field unchanged.
+
+  serialNumber,assetTag,rack,rackOffset,labels.role
+  SN12345,AT-0042,x1000,17,compute
+
+A JSON patch is an array of rows, whose null values remove labels and
+properties:
+
+  [{"serialNumber": "SN12345", "labels": {"role": null}, "properties": {"asset_tag": "AT-0042"}}]
+
+Properties set by a bulk patch are kept when discovery, which replaces what
+the BMC reports, runs again. Rows that fail are reported with the others;
+the rest are applied.`
+
+func init() {
+	// device patch -f patches many devices at once
+	patchOne := devicePatchCmd.RunE
+	devicePatchCmd.Use = "patch [uid] | patch -f file"
+	devicePatchCmd.Short = "Patch a Device, or many from a CSV or JSON file"
+	devicePatchCmd.Long += `
+
+Bulk patches:
+  # Labels and properties of many devices, by uid or serial number
+  client device patch -f updates.csv [--dry-run]
+
+-f takes a CSV file, a JSON array (.json), or - for standard input, with
+one device per row.
+
+` + bulkPatchHelp
+	devicePatchCmd.Args = cobra.MaximumNArgs(1)
+	devicePatchCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if file, _ := cmd.Flags().GetString("filename"); file != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("-f patches the devices its rows name; drop the uid argument")
+			}
+			return runBulkPatch(cmd, file)
+		}
+		if len(args) != 1 {
+			return fmt.Errorf("accepts a uid, or -f for a bulk patch")
+		}
+		return patchOne(cmd, args)
+	}
+	devicePatchCmd.Flags().StringP("filename", "f", "", "Bulk patch file (CSV, or JSON with a .json extension), or - for standard input")
+	devicePatchCmd.Flags().Bool("dry-run", false, "With -f, show what would change without changing it")
+}
+
+// runBulkPatch uploads the bulk patch in file, or standard input for -, and
+// prints what each row changed.
+func runBulkPatch(cmd *cobra.Command, file string) error {
+	c, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var in io.Reader
+	contentType := "text/csv"
+	if file == "-" {
+		// Standard input has no extension: a JSON patch starts with [.
+		buffered := bufio.NewReader(os.Stdin)
+		for {
+			start, err := buffered.Peek(1)
+			if err != nil {
+				break
+			}
+			if strings.TrimSpace(string(start)) == "" {
+				buffered.ReadByte()
+				continue
+			}
+			if start[0] == '[' {
+				contentType = "application/json"
+			}
+			break
+		}
+		in = buffered
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to open bulk patch: %w", err)
+		}
+		defer f.Close()
+		if strings.EqualFold(filepath.Ext(file), ".json") {
+			contentType = "application/json"
+		}
+		in = f
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := c.PatchDevices(ctx, in, contentType, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to patch devices: %w", err)
+	}
+	if !tableOutput() {
+		return printResult(result)
+	}
+	for _, entry := range result.Entries {
+		device := entry.UID
+		if device == "" {
+			device = entry.SerialNumber
+		}
+		switch {
+		case entry.Error != "":
+			fmt.Printf("line %d %s: failed: %s\n", entry.Line, device, entry.Error)
+		case len(entry.Changed) == 0:
+			fmt.Printf("line %d %s: unchanged\n", entry.Line, device)
+		default:
+			fmt.Printf("line %d %s: %s\n", entry.Line, device, strings.Join(entry.Changed, ", "))
+		}
+	}
+	suffix := ""
+	if result.DryRun {
+		suffix = " (dry run)"
+	}
+	fmt.Printf("%d rows: %d applied, %d unchanged, %d failed%s\n", result.Rows, result.Applied, result.Unchanged, result.Failed, suffix)
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", result.Failed, result.Rows)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/cache"
+	"github.com/example/inventory-v3/pkg/query"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheFile   string
+	cacheMaxAge time.Duration
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Copy the inventory's devices into the local cache",
+	Long: `Copy the inventory's devices, retired ones included, into a local SQLite
+cache, replacing what it held, so the cache commands can look them up with no
+connection to the server.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		local, err := openCache()
+		if err != nil {
+			return err
+		}
+		defer local.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		info, err := local.Sync(ctx, c, serverURL)
+		if err != nil {
+			return fmt.Errorf("failed to sync cache: %w", err)
+		}
+		return printResult(info)
+	},
+}
+
+// Cache commands
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Look up devices in the local cache, offline",
+	Long: `Look up devices in the local cache that sync fills, with no connection to
+the server. Answers are as old as the last sync, which each command reports
+on standard error, warning when it is older than --max-age.`,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show where the cache is, and when and from where it was synced",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		local, err := openCache()
+		if err != nil {
+			return err
+		}
+		defer local.Close()
+
+		info, err := local.Info(context.Background(), cacheMaxAge)
+		if err != nil {
+			return fmt.Errorf("failed to read cache: %w", err)
+		}
+		return printResult(info)
+	},
+}
+
+var cacheBySerialCmd = &cobra.Command{
+	Use:   "by-serial [serial]",
+	Short: "Find a cached device by serial number, with its parents and location",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		local, err := openCachedInventory()
+		if err != nil {
+			return err
+		}
+		defer local.Close()
+
+		result, err := local.BySerial(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to look up serial number: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+var cacheByMACCmd = &cobra.Command{
+	Use:   "by-mac [mac]",
+	Short: "Find the cached devices and node that own a MAC address",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		local, err := openCachedInventory()
+		if err != nil {
+			return err
+		}
+		defer local.Close()
+
+		result, err := local.ByMAC(context.Background(), args[0])
+		if err != nil {
+			return fmt.Errorf("failed to look up MAC: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+var cacheRackCmd = &cobra.Command{
+	Use:   "rack [rack]",
+	Short: "List the cached devices in a rack",
+	Long: `List the cached devices in a rack: those whose rack property names it, and
+the devices within them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		includeRetired, _ := cmd.Flags().GetBool("include-retired")
+
+		local, err := openCachedInventory()
+		if err != nil {
+			return err
+		}
+		defer local.Close()
+
+		items, err := local.Rack(context.Background(), args[0], includeRetired)
+		if err != nil {
+			return fmt.Errorf("failed to list rack: %w", err)
+		}
+		if items, err = sortDevices(cmd, items); err != nil {
+			return err
+		}
+		return printDevices(cmd, items)
+	},
+}
+
+var cacheQueryCmd = &cobra.Command{
+	Use:   "query [expression]",
+	Short: "List the cached devices satisfying a filter expression",
+	Long: `List the cached devices satisfying a filter expression, written as for
+device query, e.g.
+
+  cache query "deviceType = Node && rack = x1000"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		includeRetired, _ := cmd.Flags().GetBool("include-retired")
+		expr, err := query.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid expression: %w", err)
+		}
+
+		local, err := openCachedInventory()
+		if err != nil {
+			return err
+		}
+		defer local.Close()
+
+		items, err := local.Query(context.Background(), expr, includeRetired)
+		if err != nil {
+			return fmt.Errorf("failed to query cache: %w", err)
+		}
+		if items, err = sortDevices(cmd, items); err != nil {
+			return err
+		}
+		return printDevices(cmd, items)
+	},
+}
+
+// openCache opens the cache file, by default in the user's cache directory.
+func openCache() (*cache.Cache, error) {
+	path := cacheFile
+	if path == "" {
+		var err error
+		if path, err = cache.DefaultPath(); err != nil {
+			return nil, fmt.Errorf("failed to find the cache directory: %w", err)
+		}
+	}
+	return cache.Open(path)
+}
+
+// openCachedInventory opens the cache for a lookup, and reports on standard
+// error how old its answers are.
+func openCachedInventory() (*cache.Cache, error) {
+	local, err := openCache()
+	if err != nil {
+		return nil, err
+	}
+	info, err := local.Info(context.Background(), cacheMaxAge)
+	if err != nil {
+		local.Close()
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+	if info.SyncedAt == nil {
+		local.Close()
+		return nil, cache.ErrNotSynced
+	}
+	if info.Stale {
+		fmt.Fprintf(os.Stderr, "WARNING: the cache is stale: synced from %s %s ago (%s), over --max-age %s\n",
+			info.Server, info.Age, info.SyncedAt.Local().Format(time.RFC3339), cacheMaxAge)
+	} else {
+		fmt.Fprintf(os.Stderr, "Cached inventory synced from %s %s ago\n", info.Server, info.Age)
+	}
+	return local, nil
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheBySerialCmd)
+	cacheCmd.AddCommand(cacheByMACCmd)
+	cacheCmd.AddCommand(cacheRackCmd)
+	cacheCmd.AddCommand(cacheQueryCmd)
+
+	syncCmd.Flags().StringVar(&cacheFile, "cache-file", "", "Cache file (default: inventory_v3/devices.db in the user's cache directory)")
+	cacheCmd.PersistentFlags().StringVar(&cacheFile, "cache-file", "", "Cache file (default: inventory_v3/devices.db in the user's cache directory)")
+	cacheCmd.PersistentFlags().DurationVar(&cacheMaxAge, "max-age", cache.DefaultMaxAge, "Warn when the cache was synced longer ago")
+	cacheRackCmd.Flags().Bool("include-retired", false, "Include retired and decommissioned devices")
+	cacheQueryCmd.Flags().Bool("include-retired", false, "Include retired and decommissioned devices")
+	addDeviceTableFlags(cacheRackCmd)
+	addDeviceTableFlags(cacheQueryCmd)
+}
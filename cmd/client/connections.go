@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var connectionInferCmd = &cobra.Command{
+	Use:   "infer",
+	Short: "Infer switch port cabling now",
+	Long: `Infer which switch port each node NIC is cabled to, from the LLDP neighbors
+and MAC address tables switches report, without waiting for the server's
+periodic run. Links found are recorded as connections; inferred connections
+no longer found are marked Stale. Manual connections are never changed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.InferCabling(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to infer cabling: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+func init() {
+	connectionCmd.AddCommand(connectionInferCmd)
+}
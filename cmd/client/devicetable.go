@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/devicetable"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	// device list prints a table of its own, sorted by the server
+	deviceListCmd.Long = `List all devices, as a table of their UID, name, type, serial number and
+phase, or with -o wide also their manufacturer, part number, firmware,
+location and when discovery last saw them. --columns picks the columns, and
+--sort orders the list on the server, e.g.
+
+  device list --columns serial,type,firmware,location --sort location,-lastSeen
+
+A device's location is its rack and offset, or its nearest listed
+ancestor's.`
+	deviceListCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		sortBy, _ := cmd.Flags().GetString("sort")
+		items, err := c.ListDevices(ctx, client.DeviceListOptions{Sort: sortBy})
+		if err != nil {
+			return fmt.Errorf("failed to list devices: %w", err)
+		}
+
+		return printDevices(cmd, items)
+	}
+	addDeviceTableFlags(deviceListCmd)
+}
+
+// addDeviceTableFlags adds the --columns and --sort flags of a command
+// listing devices.
+func addDeviceTableFlags(cmd *cobra.Command) {
+	cmd.Flags().String("columns", "", "Comma-separated table columns: "+strings.Join(devicetable.Names(), ", ")+", labels.KEY or properties.KEY (default: "+strings.Join(devicetable.Default, ",")+"; -o wide: "+strings.Join(devicetable.Wide, ",")+")")
+	cmd.Flags().String("sort", "", "Comma-separated columns to sort by, each descending with a leading -, e.g. type,-lastSeen")
+}
+
+// printDevices prints a device list as a table of the --columns, or of the
+// default or wide columns, or as printResult does for the other formats.
+func printDevices(cmd *cobra.Command, items []device.Device) error {
+	if !tableOutput() {
+		return printResult(items)
+	}
+	names, _ := cmd.Flags().GetString("columns")
+	if names == "" {
+		names = strings.Join(devicetable.Default, ",")
+		if output == "wide" {
+			names = strings.Join(devicetable.Wide, ",")
+		}
+	}
+	columns, err := devicetable.Parse(names)
+	if err != nil {
+		return err
+	}
+	return devicetable.Write(os.Stdout, pointers(items), columns)
+}
+
+// sortDevices orders a device list by --sort, for lists the server does not
+// sort, such as the cache's.
+func sortDevices(cmd *cobra.Command, items []device.Device) ([]device.Device, error) {
+	order, _ := cmd.Flags().GetString("sort")
+	if order == "" {
+		return items, nil
+	}
+	devices := pointers(items)
+	byUID := make(map[string]*device.Device, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+	}
+	if err := devicetable.Sort(devices, order, func(uid string) *device.Device { return byUID[uid] }); err != nil {
+		return nil, fmt.Errorf("invalid --sort: %w", err)
+	}
+	sorted := make([]device.Device, len(devices))
+	for i, d := range devices {
+		sorted[i] = *d
+	}
+	return sorted, nil
+}
+
+func pointers(items []device.Device) []*device.Device {
+	devices := make([]*device.Device, len(items))
+	for i := range items {
+		devices[i] = &items[i]
+	}
+	return devices
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// GitOps commands
+var gitopsCmd = &cobra.Command{
+	Use:   "gitops",
+	Short: "Show and run the server's GitOps sync",
+	Long: `Show and run the server's sync with its GitOps repository, a git repository
+of the YAML documents apply reads (see the server's --gitops-repo).`,
+}
+
+var gitopsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the GitOps configuration and the last sync's drift",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		status, err := c.GetGitOpsStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get GitOps status: %w", err)
+		}
+		return printResult(status)
+	},
+}
+
+var gitopsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync with the GitOps repository now",
+	Long: `Pull the GitOps repository and bring the inventory in step with it now,
+printing the drift found: resources created or configured from their
+documents, and those whose documents were removed (pruned, or orphaned when
+the server does not prune). Use --report-only to report drift without
+correcting it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		reportOnly, _ := cmd.Flags().GetBool("report-only")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.SyncGitOps(ctx, reportOnly)
+		if err != nil {
+			return fmt.Errorf("failed to sync: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gitopsCmd)
+	gitopsCmd.AddCommand(gitopsStatusCmd)
+	gitopsCmd.AddCommand(gitopsSyncCmd)
+
+	gitopsSyncCmd.Flags().Bool("report-only", false, "Report drift without correcting it")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var groupMembersCmd = &cobra.Command{
+	Use:   "members [uid-or-name]",
+	Short: "List the devices a group covers",
+	Long: `List the devices a group covers: its listed members, the devices its selector
+matches and, with includeChildren, everything they contain. Members that name
+no device are listed as unresolved.
+
+Reports are scoped to a group with --group, and device lists with the API's
+group query parameter.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		includeRetired, _ := cmd.Flags().GetBool("include-retired")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.GetGroupMembers(ctx, args[0], includeRetired)
+		if err != nil {
+			return fmt.Errorf("failed to get group members: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupMembersCmd)
+
+	groupMembersCmd.Flags().Bool("include-retired", false, "Include retired and decommissioned devices")
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var deviceAsOfCmd = &cobra.Command{
+	Use:   "as-of [time]",
+	Short: "Show the inventory as it was at a past time",
+	Long: `Reconstruct the inventory at a past time from the stored discovery
+snapshots. The time is an RFC 3339 timestamp or a date (YYYY-MM-DD), which
+means the end of that day in UTC, e.g.
+
+  device as-of 2026-03-03 --node x1000c0s7b0n0 --type DIMM`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		at, err := time.Parse(time.RFC3339, args[0])
+		if err != nil {
+			day, dayErr := time.Parse("2006-01-02", args[0])
+			if dayErr != nil {
+				return fmt.Errorf("invalid time %q: use an RFC 3339 timestamp or YYYY-MM-DD", args[0])
+			}
+			at = day.Add(24*time.Hour - time.Second)
+		}
+		node, _ := cmd.Flags().GetString("node")
+		deviceType, _ := cmd.Flags().GetString("type")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		inventory, err := c.GetInventoryAsOf(ctx, at, node, deviceType)
+		if err != nil {
+			return fmt.Errorf("failed to get inventory: %w", err)
+		}
+
+		return printResult(inventory)
+	},
+}
+
+var discoverysnapshotDiffCmd = &cobra.Command{
+	Use:   "diff [from-uid] [to-uid]",
+	Short: "Compare the device lists of two discovery snapshots",
+	Long: `Compare the device lists of two discovery snapshots, matching devices by
+Redfish URI: the devices added, removed and changed from the first to the
+second. Devices under a subtree one snapshot did not collect are listed as
+uncollected rather than added or removed. The snapshots need not have been
+reconciled.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		diff, err := c.DiffDiscoverySnapshots(ctx, args[0], args[1])
+		if err != nil {
+			return fmt.Errorf("failed to diff snapshots: %w", err)
+		}
+
+		return printResult(diff)
+	},
+}
+
+var discoverysnapshotDevicesCmd = &cobra.Command{
+	Use:   "devices [uid]",
+	Short: "List the devices a discovery snapshot created, updated and retired",
+	Long: `List the devices reconciling a discovery snapshot created, updated and
+retired, as they are now, so what a collection produced can be inspected
+straight away. Devices deleted since are listed by UID.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		devices, err := c.GetDiscoverySnapshotDevices(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get the snapshot's devices: %w", err)
+		}
+
+		return printResult(devices)
+	},
+}
+
+func init() {
+	deviceCmd.AddCommand(deviceAsOfCmd)
+	discoverysnapshotCmd.AddCommand(discoverysnapshotDiffCmd)
+	discoverysnapshotCmd.AddCommand(discoverysnapshotDevicesCmd)
+
+	deviceAsOfCmd.Flags().String("node", "", "Limit to a node (serial number or device UID) and its components")
+	deviceAsOfCmd.Flags().String("type", "", "Limit to one device type, e.g. DIMM")
+}
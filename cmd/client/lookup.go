@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var deviceByMACCmd = &cobra.Command{
+	Use:   "by-mac [mac]",
+	Short: "Find the devices and node that own a MAC address",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.GetDevicesByMAC(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to look up MAC: %w", err)
+		}
+
+		return printResult(result)
+	},
+}
+
+var deviceBySerialCmd = &cobra.Command{
+	Use:   "by-serial [serial]",
+	Short: "Find a device by serial number, with its parents and location",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.GetDevicesBySerial(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to look up serial number: %w", err)
+		}
+
+		return printResult(result)
+	},
+}
+
+func init() {
+	deviceCmd.AddCommand(deviceByMACCmd)
+	deviceCmd.AddCommand(deviceBySerialCmd)
+}
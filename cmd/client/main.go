@@ -14,6 +14,13 @@
 // Generated commands for each resource:
 //   - client device [list|get|create|update|patch|delete]
 //   - client discoverysnapshot [list|get|create|update|patch|delete]
+//   - client bmcendpoint [list|get|create|update|patch|delete]
+//   - client serviceevent [list|get|create|update|patch|delete]
+//   - client maintenancewindow [list|get|create|update|patch|delete]
+//   - client group [list|get|create|update|patch|delete]
+//   - client connection [list|get|create|update|patch|delete]
+//   - client pendingchange [list|get|create|update|patch|delete]
+//   - client alert [list|get|create|update|patch|delete]
 //
 // Global flags (available for all commands):
 //
@@ -123,6 +130,13 @@ func init() {
 	// Add resource commands
 	rootCmd.AddCommand(deviceCmd)
 	rootCmd.AddCommand(discoverysnapshotCmd)
+	rootCmd.AddCommand(bmcendpointCmd)
+	rootCmd.AddCommand(serviceeventCmd)
+	rootCmd.AddCommand(maintenancewindowCmd)
+	rootCmd.AddCommand(groupCmd)
+	rootCmd.AddCommand(connectionCmd)
+	rootCmd.AddCommand(pendingchangeCmd)
+	rootCmd.AddCommand(alertCmd)
 
 }
 
@@ -276,10 +290,10 @@ var deviceCreateCmd = &cobra.Command{
 
 Examples:
   # Create from stdin
-  echo '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}}' | client device create
+  echo '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}, "provenance": {"{"key":"value"}": "value"}}' | client device create
 
   # Create with --spec flag
-  client device create --spec '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}}'
+  client device create --spec '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}, "provenance": {"{"key":"value"}": "value"}}'
 
 Spec fields:
   deviceType (string) [required]
@@ -289,6 +303,7 @@ Spec fields:
   parentID (string)
   parentSerialNumber (string)
   properties (map[string]json.RawMessage)
+  provenance (map[string]device.PropertyProvenance)
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		c, err := getClient()
@@ -332,10 +347,10 @@ var deviceUpdateCmd = &cobra.Command{
 
 Examples:
   # Update from stdin
-  echo '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}}' | client device update <uid>
+  echo '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}, "provenance": {"{"key":"value"}": "value"}}' | client device update <uid>
 
   # Update with --spec flag
-  client device update <uid> --spec '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}}'
+  client device update <uid> --spec '{"deviceType": "example-value", "manufacturer": "example-value", "partNumber": "example-value", "serialNumber": "example-value", "parentID": "example-value", "parentSerialNumber": "example-value", "properties": {"{"key":"value"}": "value"}, "provenance": {"{"key":"value"}": "value"}}'
 
 Spec fields:
   deviceType (string) [required]
@@ -345,6 +360,7 @@ Spec fields:
   parentID (string)
   parentSerialNumber (string)
   properties (map[string]json.RawMessage)
+  provenance (map[string]device.PropertyProvenance)
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -622,13 +638,22 @@ var discoverysnapshotCreateCmd = &cobra.Command{
 
 Examples:
   # Create from stdin
-  echo '{"rawData": "[]"}' | client discoverysnapshot create
+  echo '{"rawData": "[]", "bmcAddress": "192.168.1.1", "profile": "example-value", "priority": "example-value", "decommissioned": true, "rawCapture": "[]", "coverage": "{}", "signature": "{}", "provenance": "{}", "attestation": "{}"}' | client discoverysnapshot create
 
   # Create with --spec flag
-  client discoverysnapshot create --spec '{"rawData": "[]"}'
+  client discoverysnapshot create --spec '{"rawData": "[]", "bmcAddress": "192.168.1.1", "profile": "example-value", "priority": "example-value", "decommissioned": true, "rawCapture": "[]", "coverage": "{}", "signature": "{}", "provenance": "{}", "attestation": "{}"}'
 
 Spec fields:
   rawData (json.RawMessage) [required]
+  bmcAddress (string)
+  profile (string)
+  priority (string)
+  decommissioned (bool)
+  rawCapture (json.RawMessage)
+  coverage (*discoverysnapshot.Coverage)
+  signature (*discoverysnapshot.Signature)
+  provenance (*discoverysnapshot.Provenance)
+  attestation (*discoverysnapshot.Envelope)
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		c, err := getClient()
@@ -672,13 +697,22 @@ var discoverysnapshotUpdateCmd = &cobra.Command{
 
 Examples:
   # Update from stdin
-  echo '{"rawData": "[]"}' | client discoverysnapshot update <uid>
+  echo '{"rawData": "[]", "bmcAddress": "192.168.1.1", "profile": "example-value", "priority": "example-value", "decommissioned": true, "rawCapture": "[]", "coverage": "{}", "signature": "{}", "provenance": "{}", "attestation": "{}"}' | client discoverysnapshot update <uid>
 
   # Update with --spec flag
-  client discoverysnapshot update <uid> --spec '{"rawData": "[]"}'
+  client discoverysnapshot update <uid> --spec '{"rawData": "[]", "bmcAddress": "192.168.1.1", "profile": "example-value", "priority": "example-value", "decommissioned": true, "rawCapture": "[]", "coverage": "{}", "signature": "{}", "provenance": "{}", "attestation": "{}"}'
 
 Spec fields:
   rawData (json.RawMessage) [required]
+  bmcAddress (string)
+  profile (string)
+  priority (string)
+  decommissioned (bool)
+  rawCapture (json.RawMessage)
+  coverage (*discoverysnapshot.Coverage)
+  signature (*discoverysnapshot.Signature)
+  provenance (*discoverysnapshot.Provenance)
+  attestation (*discoverysnapshot.Envelope)
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -898,3 +932,2407 @@ func init() {
 	discoverysnapshotPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
 	discoverysnapshotPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
 }
+
+// BMCEndpoint commands
+var bmcendpointCmd = &cobra.Command{
+	Use:   "bmcendpoint",
+	Short: "Manage bmcendpoints",
+	Long:  `Create, read, update, patch, and delete bmcendpoints.`,
+}
+
+var bmcendpointListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all bmcendpoints",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetBMCEndpoints(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list bmcendpoints: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var bmcendpointGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a BMCEndpoint by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetBMCEndpoint(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get BMCEndpoint: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var bmcendpointCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new BMCEndpoint",
+	Long: `Create a new BMCEndpoint.
+
+Examples:
+  # Create from stdin
+  echo '{"address": "192.168.1.1", "profile": "example-value", "backend": "example-value", "protocol": "example-value", "probeOrder": ["["item1","item2"]"], "disabled": true}' | client bmcendpoint create
+
+  # Create with --spec flag
+  client bmcendpoint create --spec '{"address": "192.168.1.1", "profile": "example-value", "backend": "example-value", "protocol": "example-value", "probeOrder": ["["item1","item2"]"], "disabled": true}'
+
+Spec fields:
+  address (string) [required]
+  profile (string)
+  backend (string)
+  protocol (string)
+  probeOrder ([]string)
+  disabled (bool)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateBMCEndpointRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateBMCEndpoint(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create BMCEndpoint: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var bmcendpointUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing BMCEndpoint",
+	Long: `Update an existing BMCEndpoint.
+
+Examples:
+  # Update from stdin
+  echo '{"address": "192.168.1.1", "profile": "example-value", "backend": "example-value", "protocol": "example-value", "probeOrder": ["["item1","item2"]"], "disabled": true}' | client bmcendpoint update <uid>
+
+  # Update with --spec flag
+  client bmcendpoint update <uid> --spec '{"address": "192.168.1.1", "profile": "example-value", "backend": "example-value", "protocol": "example-value", "probeOrder": ["["item1","item2"]"], "disabled": true}'
+
+Spec fields:
+  address (string) [required]
+  profile (string)
+  backend (string)
+  protocol (string)
+  probeOrder ([]string)
+  disabled (bool)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateBMCEndpointRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateBMCEndpoint(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update BMCEndpoint: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var bmcendpointPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a BMCEndpoint",
+	Long: `Patch an existing BMCEndpoint spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client bmcendpoint patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client bmcendpoint patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client bmcendpoint patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client bmcendpoint patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchBMCEndpoint(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch BMCEndpoint: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var bmcendpointDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a BMCEndpoint",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteBMCEndpoint(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete BMCEndpoint: %w", err)
+		}
+
+		fmt.Printf("BMCEndpoint %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	bmcendpointCmd.AddCommand(bmcendpointListCmd)
+	bmcendpointCmd.AddCommand(bmcendpointGetCmd)
+	bmcendpointCmd.AddCommand(bmcendpointCreateCmd)
+	bmcendpointCmd.AddCommand(bmcendpointUpdateCmd)
+	bmcendpointCmd.AddCommand(bmcendpointPatchCmd)
+	bmcendpointCmd.AddCommand(bmcendpointDeleteCmd)
+
+	// Add spec flag for create and update commands
+	bmcendpointCreateCmd.Flags().String("spec", "", "BMCEndpoint specification in JSON format")
+	bmcendpointUpdateCmd.Flags().String("spec", "", "BMCEndpoint specification in JSON format")
+
+	// Add patch command flags
+	bmcendpointPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	bmcendpointPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	bmcendpointPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	bmcendpointPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	bmcendpointPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	bmcendpointPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
+
+// ServiceEvent commands
+var serviceeventCmd = &cobra.Command{
+	Use:   "serviceevent",
+	Short: "Manage serviceevents",
+	Long:  `Create, read, update, patch, and delete serviceevents.`,
+}
+
+var serviceeventListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all serviceevents",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetServiceEvents(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list serviceevents: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var serviceeventGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a ServiceEvent by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetServiceEvent(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get ServiceEvent: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var serviceeventCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new ServiceEvent",
+	Long: `Create a new ServiceEvent.
+
+Examples:
+  # Create from stdin
+  echo '{"ticketID": "example-value", "vendorCase": "example-value", "deviceIDs": ["["item1","item2"]"], "summary": "example-value", "openedAt": "{}", "closedAt": "{}", "resolution": "example-value", "replacement": "{}"}' | client serviceevent create
+
+  # Create with --spec flag
+  client serviceevent create --spec '{"ticketID": "example-value", "vendorCase": "example-value", "deviceIDs": ["["item1","item2"]"], "summary": "example-value", "openedAt": "{}", "closedAt": "{}", "resolution": "example-value", "replacement": "{}"}'
+
+Spec fields:
+  ticketID (string)
+  vendorCase (string)
+  deviceIDs ([]string) [required]
+  summary (string)
+  openedAt (*time.Time)
+  closedAt (*time.Time)
+  resolution (string)
+  replacement (*serviceevent.Replacement)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateServiceEventRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateServiceEvent(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create ServiceEvent: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var serviceeventUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing ServiceEvent",
+	Long: `Update an existing ServiceEvent.
+
+Examples:
+  # Update from stdin
+  echo '{"ticketID": "example-value", "vendorCase": "example-value", "deviceIDs": ["["item1","item2"]"], "summary": "example-value", "openedAt": "{}", "closedAt": "{}", "resolution": "example-value", "replacement": "{}"}' | client serviceevent update <uid>
+
+  # Update with --spec flag
+  client serviceevent update <uid> --spec '{"ticketID": "example-value", "vendorCase": "example-value", "deviceIDs": ["["item1","item2"]"], "summary": "example-value", "openedAt": "{}", "closedAt": "{}", "resolution": "example-value", "replacement": "{}"}'
+
+Spec fields:
+  ticketID (string)
+  vendorCase (string)
+  deviceIDs ([]string) [required]
+  summary (string)
+  openedAt (*time.Time)
+  closedAt (*time.Time)
+  resolution (string)
+  replacement (*serviceevent.Replacement)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateServiceEventRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateServiceEvent(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update ServiceEvent: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var serviceeventPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a ServiceEvent",
+	Long: `Patch an existing ServiceEvent spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client serviceevent patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client serviceevent patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client serviceevent patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client serviceevent patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchServiceEvent(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch ServiceEvent: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var serviceeventDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a ServiceEvent",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteServiceEvent(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete ServiceEvent: %w", err)
+		}
+
+		fmt.Printf("ServiceEvent %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	serviceeventCmd.AddCommand(serviceeventListCmd)
+	serviceeventCmd.AddCommand(serviceeventGetCmd)
+	serviceeventCmd.AddCommand(serviceeventCreateCmd)
+	serviceeventCmd.AddCommand(serviceeventUpdateCmd)
+	serviceeventCmd.AddCommand(serviceeventPatchCmd)
+	serviceeventCmd.AddCommand(serviceeventDeleteCmd)
+
+	// Add spec flag for create and update commands
+	serviceeventCreateCmd.Flags().String("spec", "", "ServiceEvent specification in JSON format")
+	serviceeventUpdateCmd.Flags().String("spec", "", "ServiceEvent specification in JSON format")
+
+	// Add patch command flags
+	serviceeventPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	serviceeventPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	serviceeventPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	serviceeventPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	serviceeventPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	serviceeventPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
+
+// MaintenanceWindow commands
+var maintenancewindowCmd = &cobra.Command{
+	Use:   "maintenancewindow",
+	Short: "Manage maintenancewindows",
+	Long:  `Create, read, update, patch, and delete maintenancewindows.`,
+}
+
+var maintenancewindowListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all maintenancewindows",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetMaintenanceWindows(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list maintenancewindows: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var maintenancewindowGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a MaintenanceWindow by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetMaintenanceWindow(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get MaintenanceWindow: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var maintenancewindowCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new MaintenanceWindow",
+	Long: `Create a new MaintenanceWindow.
+
+Examples:
+  # Create from stdin
+  echo '{"summary": "example-value", "start": "{}", "end": "{}", "repeat": "example-value", "endpointIDs": ["["item1","item2"]"], "rackIDs": ["["item1","item2"]"]}' | client maintenancewindow create
+
+  # Create with --spec flag
+  client maintenancewindow create --spec '{"summary": "example-value", "start": "{}", "end": "{}", "repeat": "example-value", "endpointIDs": ["["item1","item2"]"], "rackIDs": ["["item1","item2"]"]}'
+
+Spec fields:
+  summary (string)
+  start (time.Time) [required]
+  end (time.Time) [required]
+  repeat (string)
+  endpointIDs ([]string)
+  rackIDs ([]string)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateMaintenanceWindowRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateMaintenanceWindow(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create MaintenanceWindow: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var maintenancewindowUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing MaintenanceWindow",
+	Long: `Update an existing MaintenanceWindow.
+
+Examples:
+  # Update from stdin
+  echo '{"summary": "example-value", "start": "{}", "end": "{}", "repeat": "example-value", "endpointIDs": ["["item1","item2"]"], "rackIDs": ["["item1","item2"]"]}' | client maintenancewindow update <uid>
+
+  # Update with --spec flag
+  client maintenancewindow update <uid> --spec '{"summary": "example-value", "start": "{}", "end": "{}", "repeat": "example-value", "endpointIDs": ["["item1","item2"]"], "rackIDs": ["["item1","item2"]"]}'
+
+Spec fields:
+  summary (string)
+  start (time.Time) [required]
+  end (time.Time) [required]
+  repeat (string)
+  endpointIDs ([]string)
+  rackIDs ([]string)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateMaintenanceWindowRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateMaintenanceWindow(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update MaintenanceWindow: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var maintenancewindowPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a MaintenanceWindow",
+	Long: `Patch an existing MaintenanceWindow spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client maintenancewindow patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client maintenancewindow patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client maintenancewindow patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client maintenancewindow patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchMaintenanceWindow(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch MaintenanceWindow: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var maintenancewindowDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a MaintenanceWindow",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteMaintenanceWindow(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete MaintenanceWindow: %w", err)
+		}
+
+		fmt.Printf("MaintenanceWindow %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	maintenancewindowCmd.AddCommand(maintenancewindowListCmd)
+	maintenancewindowCmd.AddCommand(maintenancewindowGetCmd)
+	maintenancewindowCmd.AddCommand(maintenancewindowCreateCmd)
+	maintenancewindowCmd.AddCommand(maintenancewindowUpdateCmd)
+	maintenancewindowCmd.AddCommand(maintenancewindowPatchCmd)
+	maintenancewindowCmd.AddCommand(maintenancewindowDeleteCmd)
+
+	// Add spec flag for create and update commands
+	maintenancewindowCreateCmd.Flags().String("spec", "", "MaintenanceWindow specification in JSON format")
+	maintenancewindowUpdateCmd.Flags().String("spec", "", "MaintenanceWindow specification in JSON format")
+
+	// Add patch command flags
+	maintenancewindowPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	maintenancewindowPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	maintenancewindowPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	maintenancewindowPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	maintenancewindowPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	maintenancewindowPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
+
+// Group commands
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage groups",
+	Long:  `Create, read, update, patch, and delete groups.`,
+}
+
+var groupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all groups",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetGroups(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list groups: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var groupGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a Group by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetGroup(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get Group: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var groupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new Group",
+	Long: `Create a new Group.
+
+Examples:
+  # Create from stdin
+  echo '{"description": "Example description", "members": ["["item1","item2"]"], "selector": {"{"key":"value"}": "value"}, "deviceType": "example-value", "includeChildren": true}' | client group create
+
+  # Create with --spec flag
+  client group create --spec '{"description": "Example description", "members": ["["item1","item2"]"], "selector": {"{"key":"value"}": "value"}, "deviceType": "example-value", "includeChildren": true}'
+
+Spec fields:
+  description (string)
+  members ([]string)
+  selector (map[string]string)
+  deviceType (string)
+  includeChildren (bool)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateGroupRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateGroup(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create Group: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var groupUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing Group",
+	Long: `Update an existing Group.
+
+Examples:
+  # Update from stdin
+  echo '{"description": "Example description", "members": ["["item1","item2"]"], "selector": {"{"key":"value"}": "value"}, "deviceType": "example-value", "includeChildren": true}' | client group update <uid>
+
+  # Update with --spec flag
+  client group update <uid> --spec '{"description": "Example description", "members": ["["item1","item2"]"], "selector": {"{"key":"value"}": "value"}, "deviceType": "example-value", "includeChildren": true}'
+
+Spec fields:
+  description (string)
+  members ([]string)
+  selector (map[string]string)
+  deviceType (string)
+  includeChildren (bool)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateGroupRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateGroup(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update Group: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var groupPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a Group",
+	Long: `Patch an existing Group spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client group patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client group patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client group patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client group patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchGroup(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch Group: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var groupDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a Group",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteGroup(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete Group: %w", err)
+		}
+
+		fmt.Printf("Group %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	groupCmd.AddCommand(groupListCmd)
+	groupCmd.AddCommand(groupGetCmd)
+	groupCmd.AddCommand(groupCreateCmd)
+	groupCmd.AddCommand(groupUpdateCmd)
+	groupCmd.AddCommand(groupPatchCmd)
+	groupCmd.AddCommand(groupDeleteCmd)
+
+	// Add spec flag for create and update commands
+	groupCreateCmd.Flags().String("spec", "", "Group specification in JSON format")
+	groupUpdateCmd.Flags().String("spec", "", "Group specification in JSON format")
+
+	// Add patch command flags
+	groupPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	groupPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	groupPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	groupPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	groupPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	groupPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
+
+// Connection commands
+var connectionCmd = &cobra.Command{
+	Use:   "connection",
+	Short: "Manage connections",
+	Long:  `Create, read, update, patch, and delete connections.`,
+}
+
+var connectionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all connections",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetConnections(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list connections: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var connectionGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a Connection by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetConnection(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get Connection: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var connectionCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new Connection",
+	Long: `Create a new Connection.
+
+Examples:
+  # Create from stdin
+  echo '{"switchID": "example-value", "switchPort": "example-value", "nicID": "example-value", "nodeID": "example-value", "macAddress": "192.168.1.1", "method": "example-value"}' | client connection create
+
+  # Create with --spec flag
+  client connection create --spec '{"switchID": "example-value", "switchPort": "example-value", "nicID": "example-value", "nodeID": "example-value", "macAddress": "192.168.1.1", "method": "example-value"}'
+
+Spec fields:
+  switchID (string) [required]
+  switchPort (string) [required]
+  nicID (string)
+  nodeID (string)
+  macAddress (string)
+  method (string) [required]
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateConnectionRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateConnection(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create Connection: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var connectionUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing Connection",
+	Long: `Update an existing Connection.
+
+Examples:
+  # Update from stdin
+  echo '{"switchID": "example-value", "switchPort": "example-value", "nicID": "example-value", "nodeID": "example-value", "macAddress": "192.168.1.1", "method": "example-value"}' | client connection update <uid>
+
+  # Update with --spec flag
+  client connection update <uid> --spec '{"switchID": "example-value", "switchPort": "example-value", "nicID": "example-value", "nodeID": "example-value", "macAddress": "192.168.1.1", "method": "example-value"}'
+
+Spec fields:
+  switchID (string) [required]
+  switchPort (string) [required]
+  nicID (string)
+  nodeID (string)
+  macAddress (string)
+  method (string) [required]
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateConnectionRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateConnection(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update Connection: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var connectionPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a Connection",
+	Long: `Patch an existing Connection spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client connection patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client connection patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client connection patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client connection patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchConnection(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch Connection: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var connectionDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a Connection",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteConnection(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete Connection: %w", err)
+		}
+
+		fmt.Printf("Connection %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	connectionCmd.AddCommand(connectionListCmd)
+	connectionCmd.AddCommand(connectionGetCmd)
+	connectionCmd.AddCommand(connectionCreateCmd)
+	connectionCmd.AddCommand(connectionUpdateCmd)
+	connectionCmd.AddCommand(connectionPatchCmd)
+	connectionCmd.AddCommand(connectionDeleteCmd)
+
+	// Add spec flag for create and update commands
+	connectionCreateCmd.Flags().String("spec", "", "Connection specification in JSON format")
+	connectionUpdateCmd.Flags().String("spec", "", "Connection specification in JSON format")
+
+	// Add patch command flags
+	connectionPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	connectionPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	connectionPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	connectionPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	connectionPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	connectionPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
+
+// PendingChange commands
+var pendingchangeCmd = &cobra.Command{
+	Use:   "pendingchange",
+	Short: "Manage pendingchanges",
+	Long:  `Create, read, update, patch, and delete pendingchanges.`,
+}
+
+var pendingchangeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all pendingchanges",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetPendingChanges(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list pendingchanges: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var pendingchangeGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a PendingChange by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetPendingChange(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get PendingChange: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var pendingchangeCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new PendingChange",
+	Long: `Create a new PendingChange.
+
+Examples:
+  # Create from stdin
+  echo '{"action": "example-value", "summary": "example-value", "devices": ["["item1","item2"]"], "detachEndpoints": ["["item1","item2"]"], "serial": "{}"}' | client pendingchange create
+
+  # Create with --spec flag
+  client pendingchange create --spec '{"action": "example-value", "summary": "example-value", "devices": ["["item1","item2"]"], "detachEndpoints": ["["item1","item2"]"], "serial": "{}"}'
+
+Spec fields:
+  action (string) [required]
+  summary (string)
+  devices ([]string)
+  detachEndpoints ([]string)
+  serial (*pendingchange.SerialRewrite)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreatePendingChangeRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreatePendingChange(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create PendingChange: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var pendingchangeUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing PendingChange",
+	Long: `Update an existing PendingChange.
+
+Examples:
+  # Update from stdin
+  echo '{"action": "example-value", "summary": "example-value", "devices": ["["item1","item2"]"], "detachEndpoints": ["["item1","item2"]"], "serial": "{}"}' | client pendingchange update <uid>
+
+  # Update with --spec flag
+  client pendingchange update <uid> --spec '{"action": "example-value", "summary": "example-value", "devices": ["["item1","item2"]"], "detachEndpoints": ["["item1","item2"]"], "serial": "{}"}'
+
+Spec fields:
+  action (string) [required]
+  summary (string)
+  devices ([]string)
+  detachEndpoints ([]string)
+  serial (*pendingchange.SerialRewrite)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdatePendingChangeRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdatePendingChange(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update PendingChange: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var pendingchangePatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a PendingChange",
+	Long: `Patch an existing PendingChange spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client pendingchange patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client pendingchange patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client pendingchange patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client pendingchange patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchPendingChange(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch PendingChange: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var pendingchangeDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a PendingChange",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeletePendingChange(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete PendingChange: %w", err)
+		}
+
+		fmt.Printf("PendingChange %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	pendingchangeCmd.AddCommand(pendingchangeListCmd)
+	pendingchangeCmd.AddCommand(pendingchangeGetCmd)
+	pendingchangeCmd.AddCommand(pendingchangeCreateCmd)
+	pendingchangeCmd.AddCommand(pendingchangeUpdateCmd)
+	pendingchangeCmd.AddCommand(pendingchangePatchCmd)
+	pendingchangeCmd.AddCommand(pendingchangeDeleteCmd)
+
+	// Add spec flag for create and update commands
+	pendingchangeCreateCmd.Flags().String("spec", "", "PendingChange specification in JSON format")
+	pendingchangeUpdateCmd.Flags().String("spec", "", "PendingChange specification in JSON format")
+
+	// Add patch command flags
+	pendingchangePatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	pendingchangePatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	pendingchangePatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	pendingchangePatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	pendingchangePatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	pendingchangePatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
+
+// Alert commands
+var alertCmd = &cobra.Command{
+	Use:   "alert",
+	Short: "Manage alerts",
+	Long:  `Create, read, update, patch, and delete alerts.`,
+}
+
+var alertListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all alerts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		items, err := c.GetAlerts(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list alerts: %w", err)
+		}
+
+		return printOutput(items)
+	},
+}
+
+var alertGetCmd = &cobra.Command{
+	Use:   "get [uid]",
+	Short: "Get a Alert by UID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.GetAlert(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get Alert: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var alertCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new Alert",
+	Long: `Create a new Alert.
+
+Examples:
+  # Create from stdin
+  echo '{"rule": "example-value", "severity": "example-value", "subject": "example-value", "subjectName": "example-name"}' | client alert create
+
+  # Create with --spec flag
+  client alert create --spec '{"rule": "example-value", "severity": "example-value", "subject": "example-value", "subjectName": "example-name"}'
+
+Spec fields:
+  rule (string) [required]
+  severity (string)
+  subject (string) [required]
+  subjectName (string)
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.CreateAlertRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.CreateAlert(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create Alert: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var alertUpdateCmd = &cobra.Command{
+	Use:   "update [uid]",
+	Short: "Update an existing Alert",
+	Long: `Update an existing Alert.
+
+Examples:
+  # Update from stdin
+  echo '{"rule": "example-value", "severity": "example-value", "subject": "example-value", "subjectName": "example-name"}' | client alert update <uid>
+
+  # Update with --spec flag
+  client alert update <uid> --spec '{"rule": "example-value", "severity": "example-value", "subject": "example-value", "subjectName": "example-name"}'
+
+Spec fields:
+  rule (string) [required]
+  severity (string)
+  subject (string) [required]
+  subjectName (string)
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		// Read request from flags or stdin
+		reqJSON, _ := cmd.Flags().GetString("spec")
+		var req client.UpdateAlertRequest
+
+		if reqJSON == "" {
+			// Read from stdin if no spec provided
+			decoder := json.NewDecoder(os.Stdin)
+			if err := decoder.Decode(&req); err != nil {
+				return fmt.Errorf("failed to decode request from stdin: %w", err)
+			}
+		} else {
+			// Parse request from JSON string
+			if err := json.Unmarshal([]byte(reqJSON), &req); err != nil {
+				return fmt.Errorf("failed to parse request JSON: %w", err)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.UpdateAlert(ctx, args[0], req)
+		if err != nil {
+			return fmt.Errorf("failed to update Alert: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var alertPatchCmd = &cobra.Command{
+	Use:   "patch [uid]",
+	Short: "Patch a Alert",
+	Long: `Patch an existing Alert spec using various patch formats.
+
+IMPORTANT: Only the spec portion of the resource can be patched.
+Metadata (name, labels, annotations) and status are managed by the API.
+
+Examples:
+  # JSON Merge Patch (simple merge) - patch spec fields
+  client alert patch <uid> --spec '{"manufacturer":"Intel","model":"Updated Model"}'
+
+  # Shorthand patch (dot notation - most convenient)
+  client alert patch <uid> --set manufacturer=Intel --set model="Updated Model" --unset customField
+
+  # JSON Patch (RFC 6902 - most powerful)
+  client alert patch <uid> --json-patch '[
+    {"op":"replace","path":"/manufacturer","value":"Intel"},
+    {"op":"add","path":"/properties/newField","value":"newValue"}
+  ]'
+
+  # From stdin (JSON Merge Patch format)
+  echo '{"manufacturer":"AMD","partNumber":"RYZEN-9000"}' | client alert patch <uid>
+
+Patch Formats:
+  --spec        JSON Merge Patch (RFC 7386) - simple object merge
+  --set/--unset Shorthand patch - dot notation for convenience
+  --json-patch  JSON Patch (RFC 6902) - operation-based patches
+  stdin         JSON Merge Patch format
+
+Shorthand Operations (spec fields only):
+  --set field=value     Set a spec field value (supports dot notation)
+  --unset field         Remove a spec field (supports dot notation)
+  --add field=value     Add to spec array field (field must end with '.-')
+  --remove field=value  Remove from spec array field
+
+Note: All patch operations target the resource spec only.
+Attempts to patch metadata or status fields will be ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		uid := args[0]
+
+		// Get patch flags
+		specPatch, _ := cmd.Flags().GetString("spec")
+		jsonPatch, _ := cmd.Flags().GetString("json-patch")
+		setPairs, _ := cmd.Flags().GetStringArray("set")
+		unsetFields, _ := cmd.Flags().GetStringArray("unset")
+		addPairs, _ := cmd.Flags().GetStringArray("add")
+		removePairs, _ := cmd.Flags().GetStringArray("remove")
+
+		var patchData []byte
+		var contentType string
+
+		// Determine patch format and build patch data
+		if jsonPatch != "" {
+			// JSON Patch (RFC 6902)
+			patchData = []byte(jsonPatch)
+			contentType = "application/json-patch+json"
+		} else if len(setPairs) > 0 || len(unsetFields) > 0 || len(addPairs) > 0 || len(removePairs) > 0 {
+			// Shorthand patch - convert to JSON Merge Patch
+			patch := make(map[string]interface{})
+
+			// Process --set flags
+			for _, setPair := range setPairs {
+				parts := strings.SplitN(setPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --set format: %s (expected field=value)", setPair)
+				}
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --unset flags
+			for _, field := range unsetFields {
+				setNestedField(patch, field, nil)
+			}
+
+			// Process --add flags (add to arrays)
+			for _, addPair := range addPairs {
+				parts := strings.SplitN(addPair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --add format: %s (expected field=value)", addPair)
+				}
+				// For arrays, we'll use JSON Merge Patch append syntax if possible
+				// Otherwise convert to JSON Patch
+				setNestedField(patch, parts[0], parts[1])
+			}
+
+			// Process --remove flags
+			for _, removePair := range removePairs {
+				parts := strings.SplitN(removePair, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid --remove format: %s (expected field=value)", removePair)
+				}
+				// Remove operations are complex and might need JSON Patch
+				// For now, we'll handle simple cases
+				return fmt.Errorf("--remove operations require --json-patch format")
+			}
+
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal shorthand patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		} else if specPatch != "" {
+			// JSON Merge Patch from --spec
+			patchData = []byte(specPatch)
+			contentType = "application/merge-patch+json"
+		} else {
+			// Read from stdin (default to JSON Merge Patch)
+			decoder := json.NewDecoder(os.Stdin)
+			var patch interface{}
+			if err := decoder.Decode(&patch); err != nil {
+				return fmt.Errorf("failed to decode patch from stdin: %w", err)
+			}
+			patchBytes, err := json.Marshal(patch)
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			patchData = patchBytes
+			contentType = "application/merge-patch+json"
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		item, err := c.PatchAlert(ctx, uid, patchData, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to patch Alert: %w", err)
+		}
+
+		return printOutput(item)
+	},
+}
+
+var alertDeleteCmd = &cobra.Command{
+	Use:   "delete [uid]",
+	Short: "Delete a Alert",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := c.DeleteAlert(ctx, args[0]); err != nil {
+			return fmt.Errorf("failed to delete Alert: %w", err)
+		}
+
+		fmt.Printf("Alert %s deleted successfully\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	alertCmd.AddCommand(alertListCmd)
+	alertCmd.AddCommand(alertGetCmd)
+	alertCmd.AddCommand(alertCreateCmd)
+	alertCmd.AddCommand(alertUpdateCmd)
+	alertCmd.AddCommand(alertPatchCmd)
+	alertCmd.AddCommand(alertDeleteCmd)
+
+	// Add spec flag for create and update commands
+	alertCreateCmd.Flags().String("spec", "", "Alert specification in JSON format")
+	alertUpdateCmd.Flags().String("spec", "", "Alert specification in JSON format")
+
+	// Add patch command flags
+	alertPatchCmd.Flags().String("spec", "", "JSON Merge Patch specification")
+	alertPatchCmd.Flags().String("json-patch", "", "JSON Patch operations (RFC 6902)")
+	alertPatchCmd.Flags().StringArray("set", nil, "Set field value using dot notation (field=value)")
+	alertPatchCmd.Flags().StringArray("unset", nil, "Unset field using dot notation")
+	alertPatchCmd.Flags().StringArray("add", nil, "Add value to array field (field=value)")
+	alertPatchCmd.Flags().StringArray("remove", nil, "Remove value from array field (field=value)")
+}
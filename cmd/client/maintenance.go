@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var maintenancewindowActiveCmd = &cobra.Command{
+	Use:   "active",
+	Short: "Show what the active maintenance windows cover",
+	Long: `Show the maintenance windows active now and the BMC addresses they cover.
+The collector agent does not collect from these addresses, and the
+missing-devices report leaves out the devices they report.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.GetActiveMaintenance(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get active maintenance: %w", err)
+		}
+		return printResult(result)
+	},
+}
+
+func init() {
+	maintenancewindowCmd.AddCommand(maintenancewindowActiveCmd)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var deviceImportManifestCmd = &cobra.Command{
+	Use:   "import-manifest [file.csv]",
+	Short: "Import a vendor shipping manifest as expected devices",
+	Long: `Create an Expected device for each serial number in a vendor shipping
+manifest. The CSV header must name a serial number column; part number,
+manufacturer, device type and MAC address columns are optional.
+
+When discovery later finds a matching serial number, the device is marked
+Received, and Installed once it is linked to its parent.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		deviceType, _ := cmd.Flags().GetString("device-type")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open manifest: %w", err)
+		}
+		defer f.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.ImportManifest(ctx, name, deviceType, f, dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to import manifest: %w", err)
+		}
+
+		return printResult(result)
+	},
+}
+
+func init() {
+	deviceCmd.AddCommand(deviceImportManifestCmd)
+
+	deviceImportManifestCmd.Flags().String("name", "", "Manifest name recorded on the devices (default: the file name)")
+	deviceImportManifestCmd.Flags().String("device-type", "", "Device type for rows without one (default Node)")
+	deviceImportManifestCmd.Flags().Bool("dry-run", false, "Show what would be created without creating it")
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/render"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputTemplate string
+	outputJSONPath string
+)
+
+func init() {
+	rootCmd.PersistentFlags().Lookup("output").Usage = "output format: table, wide, json, yaml"
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go template applied to the JSON output, e.g. '{{.metadata.name}}'")
+	rootCmd.PersistentFlags().StringVar(&outputJSONPath, "jsonpath", "", "JSONPath of the output values to print one per line, e.g. '[*].spec.serialNumber'")
+
+	// getClient builds its clients on http.DefaultClient; retry their
+	// requests through restarts and brief outages of the server
+	http.DefaultClient = client.WithRetry(nil)
+
+	// Once every command is added
+	cobra.OnInitialize(printGeneratedWithResult)
+}
+
+// generatedVerbs name the generated resource commands that print with
+// printOutput.
+var generatedVerbs = map[string]bool{"list": true, "get": true, "create": true, "update": true, "patch": true}
+
+// printingWithResult is set once printGeneratedWithResult has run.
+var printingWithResult bool
+
+// printGeneratedWithResult has the generated resource commands print through
+// printResult, all but device list, which prints with printDevices.
+func printGeneratedWithResult() {
+	if printingWithResult {
+		return
+	}
+	printingWithResult = true
+	for _, resourceCmd := range []*cobra.Command{
+		deviceCmd, discoverysnapshotCmd, bmcendpointCmd, serviceeventCmd, maintenancewindowCmd,
+		groupCmd, connectionCmd, pendingchangeCmd, alertCmd,
+	} {
+		for _, cmd := range resourceCmd.Commands() {
+			if generatedVerbs[cmd.Name()] && cmd != deviceListCmd {
+				printWithResult(cmd)
+			}
+		}
+	}
+}
+
+// tableOutput reports whether commands with a table view should print it.
+func tableOutput() bool {
+	return (output == "table" || output == "wide") && outputTemplate == "" && outputJSONPath == "" && !anonymizeOutput
+}
+
+// printResult prints data as printOutput does, and also as YAML, with
+// --template or --jsonpath, and anonymized with --anonymize.
+func printResult(data interface{}) error {
+	if anonymizeOutput {
+		var err error
+		if data, err = anonymized(data); err != nil {
+			return err
+		}
+	}
+	printer, err := render.New(outputTemplate, outputJSONPath)
+	if err != nil {
+		return err
+	}
+	if printer != nil {
+		return printer.Print(os.Stdout, data)
+	}
+	switch output {
+	case "yaml":
+		return client.EncodeYAML(os.Stdout, data)
+	case "wide":
+		// Only some commands have a wide table; the others print as in a table
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+	return printOutput(data)
+}
+
+// printWithResult has a generated command print through printResult. It
+// prints with printOutput, so when printResult would print otherwise the
+// command is run with JSON output, which is captured and printed again.
+func printWithResult(cmd *cobra.Command) {
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if output != "yaml" && outputTemplate == "" && outputJSONPath == "" && !anonymizeOutput {
+			// printOutput has no wide tables; the plain one is the same
+			if output == "wide" {
+				output = "table"
+				defer func() { output = "wide" }()
+			}
+			return run(cmd, args)
+		}
+		format, tmpl, jsonPath, anonymize := output, outputTemplate, outputJSONPath, anonymizeOutput
+		output, outputTemplate, outputJSONPath, anonymizeOutput = "json", "", "", false
+		out, err := captureStdout(func() error { return run(cmd, args) })
+		output, outputTemplate, outputJSONPath, anonymizeOutput = format, tmpl, jsonPath, anonymize
+		if err != nil || !json.Valid(out) {
+			os.Stdout.Write(out)
+			return err
+		}
+		return printResult(json.RawMessage(out))
+	}
+}
+
+// captureStdout returns what fn writes to standard output.
+func captureStdout(fn func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	captured := make(chan []byte)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		r.Close()
+		captured <- buf.Bytes()
+	}()
+	err = fn()
+	os.Stdout = stdout
+	w.Close()
+	return <-captured, err
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/example/inventory-v3/pkg/properties"
+)
+
+var propertyCmd = &cobra.Command{
+	Use:   "property",
+	Short: "Browse the device property registry",
+	Long: `Browse the registry of device property keys: the type of each value, its
+unit, what it means and the device types that carry it. Sites register their
+own keys with the server's --property-schema file.`,
+}
+
+var propertyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registered device properties",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		deviceType, _ := cmd.Flags().GetString("device-type")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		schemas, err := c.ListProperties(ctx, deviceType)
+		if err != nil {
+			return fmt.Errorf("failed to list properties: %w", err)
+		}
+		if !tableOutput() {
+			return printResult(schemas)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "KEY\tTYPE\tUNIT\tDEVICE TYPES\tDESCRIPTION")
+		for _, s := range schemas {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Key, s.Type, s.Unit, deviceTypes(s), s.Description)
+		}
+		return tw.Flush()
+	},
+}
+
+var propertyGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show the schema of a device property",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		schema, err := c.GetProperty(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get property: %w", err)
+		}
+		return printResult(schema)
+	},
+}
+
+var devicePropertiesCmd = &cobra.Command{
+	Use:   "properties [uid]",
+	Short: "Show a device's properties with their units and descriptions",
+	Long: `Show a device's properties with their units and descriptions from the
+property registry. Values of the wrong type, and properties the registry does
+not expect on the device's type, are flagged.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		props, err := c.GetDeviceProperties(ctx, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get device properties: %w", err)
+		}
+		if !tableOutput() {
+			return printResult(props)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "KEY\tVALUE\tDESCRIPTION")
+		for _, p := range props {
+			description := "(not registered)"
+			if p.Schema != nil {
+				description = p.Schema.Description
+			}
+			if p.Reported != "" {
+				description += " [reported " + p.Reported + "]"
+			}
+			if p.Problem != "" {
+				description += " [" + p.Problem + "]"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Key, truncate(p.Display, 60), description)
+		}
+		return tw.Flush()
+	},
+}
+
+var reportPropertiesCmd = &cobra.Command{
+	Use:   "properties",
+	Short: "Audit the property keys devices carry against the registry",
+	Long: `Audit the property keys of the active devices against the property
+registry: keys nobody has registered, values of the wrong type and keys on
+device types the registry does not list for them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetPropertyUsageReport(ctx, group)
+		if err != nil {
+			return fmt.Errorf("failed to get property usage report: %w", err)
+		}
+		return printResult(report)
+	},
+}
+
+// deviceTypes lists the device types of a schema, "any" when unrestricted.
+func deviceTypes(s properties.Schema) string {
+	if len(s.DeviceTypes) == 0 {
+		return "any"
+	}
+	return strings.Join(s.DeviceTypes, ",")
+}
+
+// truncate shortens s to at most n characters for table output.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
+
+func init() {
+	rootCmd.AddCommand(propertyCmd)
+	propertyCmd.AddCommand(propertyListCmd)
+	propertyCmd.AddCommand(propertyGetCmd)
+	deviceCmd.AddCommand(devicePropertiesCmd)
+	reportCmd.AddCommand(reportPropertiesCmd)
+
+	propertyListCmd.Flags().String("device-type", "", "List only the properties of this device type")
+	reportPropertiesCmd.Flags().String("group", "", "Cover only the devices of this group (UID or name)")
+}
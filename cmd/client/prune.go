@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/spf13/cobra"
+)
+
+var devicePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete or retire devices matching a selector",
+	Long: `Delete or retire devices matching a selector, e.g.
+
+  device prune --selector "deviceType=Drive AND missing>90d" --action retire
+
+Without --token the command only previews the matched devices and prints a
+preview token. Re-run it with --token <previewToken> to apply the prune.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		selector, _ := cmd.Flags().GetString("selector")
+		action, _ := cmd.Flags().GetString("action")
+		includeChildren, _ := cmd.Flags().GetBool("include-children")
+		token, _ := cmd.Flags().GetString("token")
+		if selector == "" {
+			return fmt.Errorf("--selector is required")
+		}
+		req := prune.Request{Selector: selector, Action: action, IncludeChildren: includeChildren}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		var result *prune.Result
+		if token == "" {
+			result, err = c.PreviewPrune(ctx, req)
+		} else {
+			result, err = c.ApplyPrune(ctx, req, token)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to prune devices: %w", err)
+		}
+
+		return printResult(result)
+	},
+}
+
+var deviceDecommissionCmd = &cobra.Command{
+	Use:   "decommission [uid]",
+	Short: "Decommission a node or rack and everything in it",
+	Long: `Retire a node or rack and all its descendants, and disable the BMC
+endpoints that no longer report an active node. Use --dry-run to preview.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.DecommissionDevice(ctx, args[0], dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to decommission device: %w", err)
+		}
+
+		return printResult(result)
+	},
+}
+
+var deviceRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename every device by the server's naming strategy",
+	Long: `Rename every device by the naming strategy the server is configured
+with (--device-naming), e.g. to migrate devices named by Redfish URI to
+serial-based names. Names are kept unique. Use --dry-run to preview.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := c.RenameDevices(ctx, dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to rename devices: %w", err)
+		}
+
+		return printResult(result)
+	},
+}
+
+func init() {
+	deviceCmd.AddCommand(devicePruneCmd)
+	deviceCmd.AddCommand(deviceDecommissionCmd)
+	deviceCmd.AddCommand(deviceRenameCmd)
+
+	devicePruneCmd.Flags().String("selector", "", "Device selector, e.g. \"endpoint=bmc-x1000 AND missing>30d\"")
+	devicePruneCmd.Flags().String("action", prune.ActionRetire, "Action to apply: delete or retire")
+	devicePruneCmd.Flags().Bool("include-children", false, "Also prune all descendants of matched devices")
+	devicePruneCmd.Flags().String("token", "", "Preview token from a previous dry run; applies the prune")
+
+	deviceDecommissionCmd.Flags().Bool("dry-run", false, "Show what would be retired and detached without changing anything")
+	deviceRenameCmd.Flags().Bool("dry-run", false, "Show the renames without applying them")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var deviceQueryCmd = &cobra.Command{
+	Use:   "query [expression]",
+	Short: "List the devices satisfying a filter expression",
+	Long: `List the devices satisfying a filter expression, evaluated by the server, e.g.
+
+  device query "deviceType == 'DIMM' AND CapacityMiB >= 65536"
+  device query "deviceType = Node && gpuCount == 0"
+  device query "labels.role == 'login' OR cpuCores < 32"
+
+Expressions compare names with values using ==, !=, <, <=, > and >=, and
+combine comparisons with AND, OR, NOT and parentheses. Values are numbers,
+true, false, and strings, quoted or bare words. A name is a device
+field (deviceType, manufacturer, serialNumber, phase, ...), a node summary
+field (totalMemoryGiB, cpuCores, gpuCount, ...), completeness, a property, or
+labels.KEY; case, underscores and dashes are ignored, so CapacityMiB finds the
+capacity_mib property. Numbers compare numerically; a device lacking a name
+never satisfies a comparison with it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		group, _ := cmd.Flags().GetString("group")
+		includeRetired, _ := cmd.Flags().GetBool("include-retired")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		sortBy, _ := cmd.Flags().GetString("sort")
+		opts := client.DeviceListOptions{Where: args[0], Group: group, Sort: sortBy, IncludeRetired: includeRetired}
+		items, err := c.ListDevices(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to query devices: %w", err)
+		}
+		return printDevices(cmd, items)
+	},
+}
+
+func init() {
+	deviceCmd.AddCommand(deviceQueryCmd)
+
+	deviceQueryCmd.Flags().String("group", "", "Query only the devices of this group (UID or name)")
+	deviceQueryCmd.Flags().Bool("include-retired", false, "Include retired and decommissioned devices")
+	addDeviceTableFlags(deviceQueryCmd)
+}
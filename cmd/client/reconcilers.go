@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// Reconciler commands
+var reconcilerCmd = &cobra.Command{
+	Use:   "reconciler",
+	Short: "Show and control the server's background processing",
+	Long: `Show what the server's reconcilers are doing, and pause or resume them,
+without reading the server logs.`,
+}
+
+var reconcilerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what each reconciler is doing",
+	Long: `Show, for each reconciler, whether it is enabled, how many resources it has
+reconciled and failed to, what it is reconciling now, and its last error;
+and how many snapshots wait in each priority lane.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		status, err := c.GetReconcilerStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get reconciler status: %w", err)
+		}
+		return printResult(status)
+	},
+}
+
+var reconcilerEnableCmd = &cobra.Command{
+	Use:   "enable <kind>",
+	Short: "Resume a paused reconciler",
+	Long: `Resume the reconciler of a resource kind, e.g. DiscoverySnapshot. It then
+reconciles the resources that changed while it was paused.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setReconcilerEnabled(args[0], true)
+	},
+}
+
+var reconcilerDisableCmd = &cobra.Command{
+	Use:   "disable <kind>",
+	Short: "Pause a reconciler",
+	Long: `Pause the reconciler of a resource kind, e.g. DiscoverySnapshot, until it is
+enabled again. Resources created or changed meanwhile are left alone, and
+reconciled when it is enabled. Paused reconcilers resume when the server
+restarts.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setReconcilerEnabled(args[0], false)
+	},
+}
+
+func setReconcilerEnabled(kind string, enabled bool) error {
+	c, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	status, err := c.SetReconcilerEnabled(ctx, kind, enabled)
+	if err != nil {
+		return fmt.Errorf("failed to set reconciler %s: %w", kind, err)
+	}
+	return printResult(status)
+}
+
+func init() {
+	rootCmd.AddCommand(reconcilerCmd)
+	reconcilerCmd.AddCommand(reconcilerStatusCmd)
+	reconcilerCmd.AddCommand(reconcilerEnableCmd)
+	reconcilerCmd.AddCommand(reconcilerDisableCmd)
+}
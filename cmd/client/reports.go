@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/example/inventory-v3/pkg/reports"
+)
+
+// Report commands
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Show fleet reports",
+	Long:  `Show reports computed from the device inventory.`,
+}
+
+var reportDriveEnduranceCmd = &cobra.Command{
+	Use:   "drive-endurance",
+	Short: "Show drive wear across the fleet",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetDriveEnduranceReport(ctx, threshold, group)
+		if err != nil {
+			return fmt.Errorf("failed to get drive endurance report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportConsistencyCmd = &cobra.Command{
+	Use:   "consistency",
+	Short: "Show fleet consistency violations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetConsistencyReport(ctx, refresh)
+		if err != nil {
+			return fmt.Errorf("failed to get consistency report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportNewHardwareCmd = &cobra.Command{
+	Use:   "new-hardware",
+	Short: "Show devices first seen recently",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		days, _ := cmd.Flags().GetInt("days")
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetNewHardwareReport(ctx, days, group)
+		if err != nil {
+			return fmt.Errorf("failed to get new hardware report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportMissingDevicesCmd = &cobra.Command{
+	Use:   "missing-devices",
+	Short: "Show devices that have stopped being reported",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		days, _ := cmd.Flags().GetInt("days")
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetMissingDevicesReport(ctx, days, group)
+		if err != nil {
+			return fmt.Errorf("failed to get missing devices report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportFirmwareComplianceCmd = &cobra.Command{
+	Use:   "firmware-compliance",
+	Short: "Show devices whose firmware differs from the baseline",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetFirmwareComplianceReport(ctx, group)
+		if err != nil {
+			return fmt.Errorf("failed to get firmware compliance report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportFirmwareVersionsCmd = &cobra.Command{
+	Use:   "firmware-versions",
+	Short: "Count the firmware versions of each device model",
+	Long: `Count the firmware versions of each device model across the fleet, from the
+devices' firmware and BIOS versions and the firmware inventory of nodes.
+
+For example, the BIOS versions of every node model:
+
+  report firmware-versions --device-type Node --firmware BIOS`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		var filter reports.FirmwareVersionFilter
+		filter.DeviceType, _ = cmd.Flags().GetString("device-type")
+		filter.Manufacturer, _ = cmd.Flags().GetString("manufacturer")
+		filter.Model, _ = cmd.Flags().GetString("model")
+		filter.Firmware, _ = cmd.Flags().GetString("firmware")
+		filter.Version, _ = cmd.Flags().GetString("version")
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetFirmwareVersionsReport(ctx, filter, group)
+		if err != nil {
+			return fmt.Errorf("failed to get firmware versions report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportCompletenessCmd = &cobra.Command{
+	Use:   "completeness",
+	Short: "Show how complete each node's inventory data is",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetCompletenessReport(ctx, threshold, group)
+		if err != nil {
+			return fmt.Errorf("failed to get completeness report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportPowerCapacityCmd = &cobra.Command{
+	Use:   "power-capacity",
+	Short: "Show the power capacity of the nodes in each rack",
+	Long: `Show the power capacity of the nodes in each rack, for rack power budgeting.
+Each node is planned at its enforced power limit, or else the power capacity
+its chassis reports, the most it was measured drawing, or the rated output of
+its power supplies, in that order. Racks whose Rack device has a
+power_budget_watts property show their headroom.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		group, _ := cmd.Flags().GetString("group")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		report, err := c.GetPowerCapacityReport(ctx, group)
+		if err != nil {
+			return fmt.Errorf("failed to get power capacity report: %w", err)
+		}
+
+		return printResult(report)
+	},
+}
+
+var reportFleetStatsCmd = &cobra.Command{
+	Use:   "fleet-stats",
+	Short: "Show how the fleet has grown and churned",
+	Long: `Show the fleet's size and the changes applied to it, from the statistics the
+server records every day: the active devices by type, the nodes present, and
+the devices reconciled snapshots created, updated and retired. --period week
+or month rolls the days up, with each period's growth.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		period, _ := cmd.Flags().GetString("period")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		record, _ := cmd.Flags().GetBool("record")
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if record {
+			if _, err := c.RecordFleetStats(ctx); err != nil {
+				return fmt.Errorf("failed to record fleet stats: %w", err)
+			}
+		}
+		series, err := c.GetFleetStats(ctx, period, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to get fleet stats: %w", err)
+		}
+
+		return printResult(series)
+	},
+}
+
+var reportSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Render the scheduled reports and deliver them now",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := getClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		bundle, err := c.RunScheduledReports(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to run scheduled reports: %w", err)
+		}
+
+		return printResult(bundle)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportDriveEnduranceCmd)
+	reportCmd.AddCommand(reportConsistencyCmd)
+	reportCmd.AddCommand(reportNewHardwareCmd)
+	reportCmd.AddCommand(reportMissingDevicesCmd)
+	reportCmd.AddCommand(reportFirmwareComplianceCmd)
+	reportCmd.AddCommand(reportFirmwareVersionsCmd)
+	reportCmd.AddCommand(reportCompletenessCmd)
+	reportCmd.AddCommand(reportPowerCapacityCmd)
+	reportCmd.AddCommand(reportFleetStatsCmd)
+	reportCmd.AddCommand(reportSendCmd)
+
+	reportDriveEnduranceCmd.Flags().Float64("threshold", 0, "Percentage used at which a drive counts as worn (default 80)")
+	reportConsistencyCmd.Flags().Bool("refresh", false, "Run a new check instead of returning the latest one")
+	reportNewHardwareCmd.Flags().Int("days", 0, "Days to look back (default: the server's report period)")
+	reportMissingDevicesCmd.Flags().Int("days", 0, "Days without a report before a device counts as missing (default 7)")
+	reportCompletenessCmd.Flags().Float64("threshold", 0, "Score below which a node counts as incomplete (default 80)")
+	reportFirmwareVersionsCmd.Flags().String("device-type", "", "Count only devices of this type")
+	reportFirmwareVersionsCmd.Flags().String("manufacturer", "", "Count only devices of this manufacturer")
+	reportFirmwareVersionsCmd.Flags().String("model", "", "Count only devices of this model (or part number)")
+	reportFirmwareVersionsCmd.Flags().String("firmware", "", "Count only this firmware, e.g. BIOS")
+	reportFirmwareVersionsCmd.Flags().String("version", "", "Keep only the models running this version")
+	reportFleetStatsCmd.Flags().String("period", "", "Roll the days up by day, week or month (default day)")
+	reportFleetStatsCmd.Flags().String("from", "", "First day to show (YYYY-MM-DD)")
+	reportFleetStatsCmd.Flags().String("to", "", "Last day to show (YYYY-MM-DD)")
+	reportFleetStatsCmd.Flags().Bool("record", false, "Record today's statistics first")
+
+	for _, cmd := range []*cobra.Command{reportDriveEnduranceCmd, reportNewHardwareCmd, reportMissingDevicesCmd, reportFirmwareComplianceCmd, reportFirmwareVersionsCmd, reportCompletenessCmd, reportPowerCapacityCmd} {
+		cmd.Flags().String("group", "", "Cover only the devices of this group (UID or name)")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var discoverysnapshotApproveCmd = &cobra.Command{
+	Use:   "approve [uid]",
+	Short: "Apply a discovery snapshot held for approval",
+	Long: `Apply a discovery snapshot the server held because it would flag missing,
+retire or replace an unusual share of its BMC's or the fleet's devices.
+Check what it reports first, e.g. with "discoverysnapshot diff".`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reviewSnapshot(cmd, args[0], true)
+	},
+}
+
+var discoverysnapshotRejectCmd = &cobra.Command{
+	Use:   "reject [uid]",
+	Short: "Discard a discovery snapshot held for approval",
+	Long: `Discard a discovery snapshot the server held for approval. The inventory is
+left as it was, and the snapshot is marked Rejected.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reviewSnapshot(cmd, args[0], false)
+	},
+}
+
+var pendingchangeApproveCmd = &cobra.Command{
+	Use:   "approve [uid]",
+	Short: "Approve a pending change",
+	Long: `Approve a destructive change, such as a mass retirement or a serial number
+rewrite, that the server's change-control policy held for sign-off. The
+reconciler then applies it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reviewChange(cmd, args[0], true)
+	},
+}
+
+var pendingchangeRejectCmd = &cobra.Command{
+	Use:   "reject [uid]",
+	Short: "Reject a pending change",
+	Long:  `Reject a pending change. It is never applied.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reviewChange(cmd, args[0], false)
+	},
+}
+
+func reviewSnapshot(cmd *cobra.Command, uid string, approve bool) error {
+	c, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	by, _ := cmd.Flags().GetString("by")
+	reason, _ := cmd.Flags().GetString("reason")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	snapshot, err := c.ReviewDiscoverySnapshot(ctx, uid, approve, by, reason)
+	if err != nil {
+		return fmt.Errorf("failed to review the snapshot: %w", err)
+	}
+
+	return printResult(snapshot)
+}
+
+func reviewChange(cmd *cobra.Command, uid string, approve bool) error {
+	c, err := getClient()
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	by, _ := cmd.Flags().GetString("by")
+	reason, _ := cmd.Flags().GetString("reason")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	change, err := c.ReviewPendingChange(ctx, uid, approve, by, reason)
+	if err != nil {
+		return fmt.Errorf("failed to review the pending change: %w", err)
+	}
+
+	return printResult(change)
+}
+
+func init() {
+	discoverysnapshotCmd.AddCommand(discoverysnapshotApproveCmd)
+	discoverysnapshotCmd.AddCommand(discoverysnapshotRejectCmd)
+	pendingchangeCmd.AddCommand(pendingchangeApproveCmd)
+	pendingchangeCmd.AddCommand(pendingchangeRejectCmd)
+
+	for _, cmd := range []*cobra.Command{discoverysnapshotApproveCmd, discoverysnapshotRejectCmd, pendingchangeApproveCmd, pendingchangeRejectCmd} {
+		cmd.Flags().String("by", "", "Who made the decision")
+		cmd.Flags().String("reason", "", "Why, recorded on the snapshot")
+	}
+}
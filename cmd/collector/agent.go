@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/telemetry"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Collect from every registered BMC endpoint on a schedule",
+	Long: `Run as a long-lived agent, e.g. a container or DaemonSet. Every interval the
+agent collects from each enabled BMCEndpoint registered in the inventory API.
+
+It serves /healthz (the process is up) and /readyz (the inventory API answered
+the last endpoint listing) on --listen. On SIGTERM or SIGINT it stops starting
+collections, reports not ready, and waits up to --shutdown-timeout for the
+collections in progress.
+
+Each collection is recorded on its BMCEndpoint. An endpoint that fails is
+retried after one interval, then after twice as long for each further failure,
+up to --max-backoff. Endpoints covered by an active MaintenanceWindow are not
+collected until it ends.
+
+Large fleets can be split across replicas: give each the same --shard-count
+and its own --shard-index, and each collects only the endpoints it owns. With
+--shard-index=-1 the index is the ordinal at the end of the host name, as in
+the pods of a StatefulSet (collector-0, collector-1, ...).
+
+Sites may opt in to sending the maintainers anonymous usage statistics by
+setting --telemetry-endpoint. Every --telemetry-interval the agent sends its
+version, the number of collections by BMC vendor, protocol and failure class,
+and its fleet size rounded to a bucket; never addresses, names, serial numbers
+or error messages. Each report is logged as it is sent.
+
+Every flag can be set from the environment, e.g. INVENTORY_COLLECTOR_SERVER,
+INVENTORY_COLLECTOR_INTERVAL, INVENTORY_COLLECTOR_LISTEN.`,
+	Args: cobra.NoArgs,
+	Run:  executeAgent,
+}
+
+func init() {
+	agentCmd.Flags().String("listen", ":9090", "Address to serve /healthz and /readyz on")
+	agentCmd.Flags().Duration("interval", collector.DefaultAgentInterval, "How often to collect from every endpoint")
+	agentCmd.Flags().Duration("max-backoff", collector.DefaultMaxBackoff, "Longest wait before retrying an endpoint that keeps failing")
+	agentCmd.Flags().Int("shard-count", 1, "Number of agents splitting the endpoints")
+	agentCmd.Flags().Int("shard-index", 0, "This agent's shard, 0 to shard-count-1 (-1: the host name's ordinal)")
+	agentCmd.Flags().Duration("shutdown-timeout", 30*time.Second, "How long to wait for collections in progress on shutdown")
+	agentCmd.Flags().String("telemetry-endpoint", "", "URL to send anonymous usage statistics to (default: none are sent)")
+	agentCmd.Flags().Duration("telemetry-interval", telemetry.DefaultInterval, "How often to send usage statistics")
+	bindFlags(agentCmd.Flags())
+}
+
+// executeAgent runs the agent until SIGTERM or SIGINT.
+func executeAgent(cmd *cobra.Command, args []string) {
+	opts, err := collectOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Agent Failed: %v\n", err)
+		os.Exit(1)
+	}
+	if err := loadPlugins(); err != nil {
+		fmt.Fprintf(os.Stderr, "Agent Failed: %v\n", err)
+		os.Exit(1)
+	}
+	resolveServer(&opts)
+	if opts.Priority == "" {
+		opts.Priority = discoverysnapshot.PriorityScheduled
+	}
+
+	// One API client for every collection, so they share the circuit breaker
+	if opts, err = opts.ShareAPIClient(); err != nil {
+		fmt.Fprintf(os.Stderr, "Agent Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	shard, err := agentShard()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Agent Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	agent := collector.NewAgent(opts, viper.GetDuration("interval"), viper.GetInt("concurrency"), shard)
+	if maxBackoff := viper.GetDuration("max_backoff"); maxBackoff > 0 {
+		agent.MaxBackoff = maxBackoff
+	}
+	if endpoint := viper.GetString("telemetry_endpoint"); endpoint != "" {
+		agent.Telemetry = telemetry.New(endpoint, viper.GetDuration("telemetry_interval"), opts.TLSPolicy)
+		fmt.Printf("Sending anonymous usage statistics to %s every %s\n", endpoint, agent.Telemetry.Interval)
+	}
+	server := &http.Server{
+		Addr:              viper.GetString("listen"),
+		Handler:           agent.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Agent Failed: health server: %v\n", err)
+			os.Exit(1)
+		}
+	}()
+	fmt.Printf("Agent collecting shard %s every %s; health endpoints on %s\n", shard, agent.Interval, server.Addr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	grace := viper.GetDuration("shutdown_timeout")
+	runErr := agent.Run(ctx, grace)
+	fmt.Println("Agent shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Agent stopped uncleanly: %v\n", runErr)
+		os.Exit(1)
+	}
+}
+
+// agentShard reads the shard flags.
+func agentShard() (collector.Shard, error) {
+	shard := collector.Shard{Index: viper.GetInt("shard_index"), Count: viper.GetInt("shard_count")}
+	if shard.Count > 1 && shard.Index == -1 {
+		index, err := collector.ShardIndexFromHostname()
+		if err != nil {
+			return shard, err
+		}
+		shard.Index = index
+	}
+	return shard, shard.Validate()
+}
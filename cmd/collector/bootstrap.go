@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/render"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var bootstrapCredsCmd = &cobra.Command{
+	Use:   "bootstrap-creds",
+	Short: "Replace the factory login of new BMCs with the site account",
+	Long: `Log into each BMC with its factory login and set up the site account the
+collector logs in with: the account is created, or its password reset if it
+exists, with a random password of --password-length characters. The new login
+is tested, and the change is recorded in the BMC's BMCEndpoint, if one is
+registered. The BMCs are given with --ip and --hosts-file, as for collection.
+
+The new password is written to the credential store once the BMC has taken
+it, so that a BMC refusing it keeps its stored login:
+
+  --credential-store system  the operating system's credential store
+  otherwise                  the credentials map of the config file (--config,
+                             or collector.yaml in the user config directory)
+
+BMCs that refuse the factory login keep their stored credential, so the
+command can be rerun over a rack after adding BMCs to it. The passwords are
+never printed, and the factory login is left as it is.
+
+The factory password is best given as INVENTORY_COLLECTOR_FACTORY_PASSWORD.`,
+	Args: cobra.NoArgs,
+	Run:  executeBootstrapCreds,
+}
+
+func init() {
+	flags := bootstrapCredsCmd.Flags()
+	flags.String("account", "", "User name of the site account (required)")
+	flags.String("role", collector.DefaultBootstrapRole, "Redfish role of the site account")
+	flags.Int("password-length", collector.DefaultPasswordLength, "Length of the generated passwords")
+	flags.String("factory-username", collector.DefaultUsername, "User name of the factory login")
+	flags.String("factory-password", "", "Password of the factory login (required)")
+	bindFlags(flags)
+	// Not bound: the root command binds its own --ip and --hosts-file
+	flags.StringSlice("ip", nil, "BMC address; repeat for several")
+	flags.String("hosts-file", "", "File listing BMC addresses, one per line")
+	rootCmd.AddCommand(bootstrapCredsCmd)
+}
+
+// executeBootstrapCreds sets up the site account on every BMC given and
+// exits nonzero if any failed.
+func executeBootstrapCreds(cmd *cobra.Command, args []string) {
+	fail := func(err error) {
+		fmt.Fprintf(os.Stderr, "Bootstrap Failed: %v\n", err)
+		os.Exit(1)
+	}
+	opts, err := collectOptions()
+	if err != nil {
+		fail(err)
+	}
+	addresses, _ := cmd.Flags().GetStringSlice("ip")
+	if path, _ := cmd.Flags().GetString("hosts-file"); path != "" {
+		hosts, err := collector.ReadHostsFile(path)
+		if err != nil {
+			fail(err)
+		}
+		addresses = append(addresses, hosts...)
+	}
+	if len(addresses) == 0 {
+		fail(errors.New("no BMCs given; use --ip or --hosts-file"))
+	}
+	factory := collector.Credential{
+		Username: viper.GetString("factory_username"),
+		Password: viper.GetString("factory_password"),
+	}
+	if factory.Password == "" {
+		fail(errors.New("no factory password given; use --factory-password or INVENTORY_COLLECTOR_FACTORY_PASSWORD"))
+	}
+	storeName, store, err := credentialWriter()
+	if err != nil {
+		fail(err)
+	}
+	printer, err := render.New(viper.GetString("template"), viper.GetString("jsonpath"))
+	if err != nil {
+		fail(err)
+	}
+	resolveServer(&opts)
+
+	summary, err := opts.Bootstrap(context.Background(), addresses, collector.BootstrapOptions{
+		Factory:        factory,
+		Account:        viper.GetString("account"),
+		Role:           viper.GetString("role"),
+		PasswordLength: viper.GetInt("password_length"),
+		Store:          store,
+		StoreName:      storeName,
+		Concurrency:    viper.GetInt("concurrency"),
+	})
+	if err != nil {
+		fail(err)
+	}
+	if printer != nil {
+		if err := printer.Print(os.Stdout, summary); err != nil {
+			fail(err)
+		}
+	} else {
+		fmt.Println("Credential bootstrap finished:")
+		summary.Print(os.Stdout)
+	}
+	if summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// credentialWriter returns the store --credential-store names, and its name.
+// The environment cannot be written, so the default is the config file.
+func credentialWriter() (string, collector.CredentialWriter, error) {
+	switch name := viper.GetString("credential_store"); name {
+	case collector.CredentialStoreSystem:
+		return name, collector.SystemCredentials{}, nil
+	case "", collector.CredentialStoreConfig:
+		path := firstOf(cfgFile, viper.ConfigFileUsed())
+		if path == "" {
+			dir, err := collector.ConfigDir()
+			if err != nil {
+				return "", nil, err
+			}
+			path = filepath.Join(dir, "collector.yaml")
+		}
+		return collector.CredentialStoreConfig, &configCredentials{path: path}, nil
+	case collector.CredentialStoreEnv:
+		return "", nil, errors.New("new passwords cannot be stored in the environment; use --credential-store system or config")
+	case collector.CredentialStoreVault:
+		return "", nil, errors.New("new passwords are not written to Vault; use --credential-store system or config, and move them to Vault")
+	default:
+		return "", nil, fmt.Errorf("unknown credential store %q (valid: %v)", name, collector.CredentialStores)
+	}
+}
+
+// configCredentials writes credentials into the credentials map of a config
+// file, keeping its other settings.
+type configCredentials struct {
+	path string
+	// mu serializes the BMCs' rewrites of the file.
+	mu sync.Mutex
+}
+
+// Store sets the address's entry of the credentials map.
+func (s *configCredentials) Store(address string, cred collector.Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// BMC addresses contain dots, viper's default key delimiter
+	v := viper.NewWithOptions(viper.KeyDelimiter("::"))
+	v.SetConfigFile(s.path)
+	if filepath.Ext(s.path) == "" {
+		v.SetConfigType("yaml")
+	}
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	v.Set("credentials::"+address, map[string]string{"username": cred.Username, "password": cred.Password})
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	v.SetConfigPermissions(0o600)
+	if err := v.WriteConfigAs(s.path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return os.Chmod(s.path, 0o600)
+}
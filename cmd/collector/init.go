@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/signing"
+	"github.com/example/inventory-v3/pkg/tlspolicy"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Walk through configuring the collector for a new site",
+	Long: `Interactively configure the collector: check that the inventory API answers,
+probe an example BMC and test a login on it, choose a collection profile and
+a snapshot signing key, and write the answers to the config file (--config,
+or collector.yaml in the user config directory).
+
+Defaults are shown in brackets and come from the current flags, environment
+and config file, so init can be rerun to change one setting.`,
+	Args: cobra.NoArgs,
+	Run:  executeInit,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
+
+// prompter asks questions on stdout and reads the answers from stdin.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask returns the answer to a question, or def for an empty answer.
+func (p *prompter) ask(question, def string) string {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Fprintln(os.Stderr, "\nInit Failed: no answer")
+		os.Exit(1)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer
+	}
+	return def
+}
+
+// confirm asks a yes or no question.
+func (p *prompter) confirm(question string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		switch strings.ToLower(p.ask(question+" ("+hint+")", "")) {
+		case "":
+			return def
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+	}
+}
+
+// secret asks for a password, without echoing it when stdin is a terminal.
+func (p *prompter) secret(question string) string {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		if stty("-echo") == nil {
+			defer func() {
+				stty("echo")
+				fmt.Fprintln(p.out)
+			}()
+		}
+	}
+	return p.ask(question, "")
+}
+
+func stty(arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// executeInit runs the bring-up wizard and writes the config file.
+func executeInit(cmd *cobra.Command, args []string) {
+	p := &prompter{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	settings := map[string]interface{}{}
+	fmt.Println("Collector bring-up. Press Enter to keep the value in brackets.")
+
+	policy, err := tlspolicy.Parse(viper.GetString("tls_min_version"), viper.GetString("tls_cipher_suites"), viper.GetBool("tls_fips"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Init Failed: %v\n", err)
+		os.Exit(1)
+	}
+	opts := collector.CollectOptions{
+		TLSPolicy: policy,
+		// One quick attempt, so that a wrong answer is reported at once
+		APIRetry: fabricaclient.RetryPolicy{MaxRetries: -1, Timeout: 10 * time.Second},
+	}
+
+	// 1. The inventory API
+	fmt.Println("\n1. Inventory API")
+	server := viper.GetString("server")
+	if !viper.IsSet("server") {
+		if discovered, source, err := collector.DiscoverServer(context.Background(), viper.GetString("instance_data_file"), viper.GetString("metadata_url")); err == nil {
+			fmt.Printf("Found the inventory API URL in %s.\n", source)
+			server = discovered
+		}
+	}
+	for {
+		opts.Server = p.ask("Inventory API URL", server)
+		opts.CAFile = p.ask("CA file for the API certificate (empty: system trust store)", viper.GetString("ca_file"))
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		endpoints, err := opts.CheckAPI(ctx)
+		cancel()
+		if err == nil {
+			fmt.Printf("OK: the inventory API answered; %d BMC endpoints are registered.\n", endpoints)
+			break
+		}
+		fmt.Printf("FAILED: %v\n", err)
+		if p.confirm("Keep these settings anyway?", false) {
+			break
+		}
+		server = opts.Server
+	}
+	settings["server"] = opts.Server
+	if opts.CAFile != "" {
+		settings["ca_file"] = opts.CAFile
+	}
+
+	// 2. An example BMC
+	fmt.Println("\n2. Example BMC")
+	var address string
+	for {
+		address = p.ask("Address of a BMC to test with", firstSetting("ip"))
+		probe, err := opts.ProbeBMC(address)
+		if err != nil {
+			fmt.Printf("FAILED: %v\n", err)
+			if p.confirm("Skip the BMC checks?", false) {
+				address = ""
+				break
+			}
+			continue
+		}
+		fmt.Printf("OK: %s %s, Redfish %s.\n", firstOf(probe.Vendor, "unknown vendor"), probe.Product, firstOf(probe.RedfishVersion, "version unknown"))
+		if probe.TrustedTLS {
+			fmt.Println("Its certificate is trusted.")
+			opts.VerifyBMCTLS = p.confirm("Verify BMC certificates?", true)
+		} else {
+			fmt.Printf("Its certificate is not trusted (%s), so BMC certificates will not be verified.\n", probe.TLSError)
+		}
+		break
+	}
+	settings["verify_bmc_tls"] = opts.VerifyBMCTLS
+
+	// 3. The BMC login
+	fmt.Println("\n3. BMC login")
+	cred := collector.Credential{Username: viper.GetString("username"), Password: viper.GetString("password")}
+	for attempt := 1; ; attempt++ {
+		cred.Username = p.ask("Username", firstOf(cred.Username, "root"))
+		password := p.secret("Password (empty: keep the configured one)")
+		if password != "" {
+			cred.Password = password
+		}
+		if address == "" {
+			break
+		}
+		auth, err := opts.CheckCredential(address, cred)
+		if err == nil {
+			fmt.Printf("OK: %s accepted the login (%s auth).\n", address, auth)
+			break
+		}
+		fmt.Printf("FAILED: %v\n", err)
+		if attempt >= 3 && p.confirm("Keep this login anyway?", false) {
+			break
+		}
+	}
+	if p.confirm("Store the login in the config file? (it is written readable only by you)", true) {
+		settings["username"] = cred.Username
+		settings["password"] = cred.Password
+	} else {
+		fmt.Println("Give the login with --username and COLLECTOR_BMC_PASSWORD, or with --credential-store.")
+	}
+
+	// 4. The collection profile
+	fmt.Println("\n4. Collection profile")
+	fmt.Println("  quick: the node, its serial numbers and NIC MAC addresses")
+	fmt.Println("  full:  every mapped component")
+	fmt.Println("  deep:  full, plus BIOS attributes and a raw capture of every resource read")
+	for {
+		profile := p.ask("Profile", firstOf(viper.GetString("profile"), bmcendpoint.ProfileFull))
+		if bmcendpoint.ValidProfile(profile) {
+			settings["profile"] = profile
+			break
+		}
+		fmt.Printf("Choose one of %v.\n", bmcendpoint.Profiles)
+	}
+
+	// 5. Snapshot signing
+	fmt.Println("\n5. Snapshot signing")
+	for {
+		path := p.ask("PEM private key to sign snapshots with (empty: unsigned)", viper.GetString("signing_key"))
+		if path == "" {
+			break
+		}
+		signer, err := signing.LoadSigner(path)
+		if err != nil {
+			fmt.Printf("FAILED: %v\n", err)
+			continue
+		}
+		fmt.Printf("OK: key %s. The server verifies it once its public key is in --snapshot-trusted-keys.\n", signer.KeyID())
+		settings["signing_key"] = path
+		break
+	}
+
+	// 6. The config file
+	fmt.Println()
+	path := cfgFile
+	if path == "" {
+		dir, err := collector.ConfigDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Init Failed: %v\n", err)
+			os.Exit(1)
+		}
+		path = filepath.Join(dir, "collector.yaml")
+	}
+	path = p.ask("Write the config to", path)
+	if _, err := os.Stat(path); err == nil && !p.confirm(path+" exists. Replace it?", false) {
+		fmt.Println("Nothing written.")
+		return
+	}
+	if err := writeConfig(path, settings); err != nil {
+		fmt.Fprintf(os.Stderr, "Init Failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %s.\n", path)
+	if address != "" {
+		fmt.Printf("Collect from the example BMC with: collector --ip %s\n", address)
+	}
+}
+
+// writeConfig writes the settings to a config file, YAML unless the path
+// ends in .toml or .json. Only its owner can read it, since it may hold a
+// BMC password.
+func writeConfig(path string, settings map[string]interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigPermissions(0o600)
+	for key, value := range settings {
+		v.Set(key, value)
+	}
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// WriteConfigAs keeps the mode of a file it replaces
+	return os.Chmod(path, 0o600)
+}
+
+// firstOf returns the first of its arguments that is not empty.
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
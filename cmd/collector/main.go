@@ -1,26 +1,371 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
 	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/fixtures"
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/render"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/s3"
+	"github.com/example/inventory-v3/pkg/signing"
+	"github.com/example/inventory-v3/pkg/tlspolicy"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "collector",
 	Short: "Gathers hardware inventory via Redfish and posts it to the OpenCHAMI API.",
-	Run:   executeGatherAndPost,
+	Long: `Gathers hardware inventory via Redfish and posts it to the OpenCHAMI API.
+
+Settings are read from flags, INVENTORY_COLLECTOR_* environment variables (e.g.
+INVENTORY_COLLECTOR_IP, INVENTORY_COLLECTOR_CA_FILE), and a config file, in
+that order of precedence: --config, or collector.yaml (or collector.toml or
+collector.json) in the user config directory (~/.config/inventory-v3 on Linux,
+~/Library/Application Support/inventory-v3 on macOS, %AppData%\inventory-v3 on
+Windows). The file takes every flag under its name in snake_case, and may
+hold BMC logins besides:
+
+  server: https://inventory.example.com
+  ip: [10.0.0.5, 10.0.0.6]
+  concurrency: 8
+  ca_file: /etc/pki/site-ca.pem
+  tls_min_version: "1.3"
+  username: root
+  password: changeme
+  credentials:
+    10.0.0.5: {username: admin, password: secret}
+
+INVENTORY_COLLECTOR_CREDENTIALS holds the same map as JSON. A fleet with many
+logins is better given a --credentials-file of its own, mapping addresses and
+networks to logins; a BMC takes the login of its address, else of the
+narrowest network holding it, else the file's default:
+
+  default: {username: root, password: changeme}
+  bmcs:
+    10.0.0.0/24: {username: ADMIN, password: rack1}
+    10.0.1.17: {username: Administrator, password: odd-one}
+
+The credentials map of the config file takes networks the same way.
+
+A BMC's login is taken from --username and --password, then from the
+--credentials-file, then from COLLECTOR_BMC_USERNAME and COLLECTOR_BMC_PASSWORD
+(or the --credential-store), then from the config file; a missing user name is
+root. No password is built in: a BMC without one is not collected.
+
+--credential-store vault reads each BMC's login from the username and password
+fields of a HashiCorp Vault secret (KV version 1 or 2) at --vault-path, with
+VAULT_ADDR, VAULT_TOKEN (or the vault CLI's ~/.vault-token), VAULT_NAMESPACE
+and VAULT_CACERT as the vault CLI reads them. Vault is then the only source of
+BMC passwords: a BMC without a secret is not collected, and a password given
+with --password, COLLECTOR_BMC_PASSWORD, the config file or --credentials-file
+is refused.
+
+  collector --credential-store vault --vault-path 'bmc/data/{{.Hostname}}'`,
+	Run: executeGatherAndPost,
 }
 
-var bmcIP string
+var cfgFile string
+
+// bmcLogin is the BMC login of --username and --password.
+var bmcLogin collector.Credential
+
+// Deep-walk mode flags
+var deepWalk bool
+var deepWalkDepth int
+var deepWalkMax int
 
 func init() {
-	// Define the --ip flag for the BMC IP
-	rootCmd.Flags().StringVarP(&bmcIP, "ip", "i", "", "The IP address of the BMC to gather inventory from (required)")
-	rootCmd.MarkFlagRequired("ip")
+	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Config file, YAML, TOML or JSON by its extension (default: collector.yaml in the user config directory)")
+
+	// The BMCs to collect from: --ip, repeatable, and --hosts-file
+	rootCmd.Flags().StringSliceP("ip", "i", nil, "IP address of a BMC to gather inventory from; repeat or comma-separate for several")
+	rootCmd.Flags().String("hosts-file", "", "File of BMC addresses to gather inventory from, one per line (# starts a comment)")
+
+	// Scan mode finds the BMCs of networks by probing them for Redfish
+	rootCmd.Flags().StringSlice("scan", nil, "Networks (CIDR, e.g. 10.0.0.0/24) to scan for Redfish endpoints and gather inventory from every one that answers")
+	rootCmd.Flags().Int("scan-port", collector.DefaultScanPort, "HTTPS port --scan probes for a Redfish service root")
+	rootCmd.Flags().Int("scan-concurrency", collector.DefaultScanConcurrency, "How many addresses --scan probes at once")
+	rootCmd.Flags().Duration("scan-timeout", collector.ProbeTimeout, "How long --scan waits for each address to answer")
+
+	// Backend selects what kind of equipment the BMC address points at
+	rootCmd.Flags().String("backend", "", fmt.Sprintf("Collector backend to use %v (default: the BMCEndpoint's, else %s)", collector.Backends(), collector.DefaultBackend))
+	rootCmd.Flags().String("profile", "", "Collection profile: quick, full, or deep (default: the BMCEndpoint's, else full)")
+	rootCmd.Flags().String("protocol", "", fmt.Sprintf("Management protocol of the BMC %v (default: the BMCEndpoint's, else detected)", bmcendpoint.Protocols))
+
+	// Deep-walk mode crawls the whole Redfish tree and reports unmapped resource types
+	rootCmd.Flags().BoolVar(&deepWalk, "deep-walk", false, "Crawl every Redfish link and report unmapped resource types instead of posting inventory")
+	rootCmd.Flags().IntVar(&deepWalkDepth, "deep-walk-depth", collector.DefaultDeepWalkDepth, "Maximum link depth to follow in deep-walk mode")
+	rootCmd.Flags().IntVar(&deepWalkMax, "deep-walk-max", collector.DefaultDeepWalkMaxVisited, "Maximum number of resources to visit in deep-walk mode")
+
+	// Decommission mode retires a BMC's devices instead of collecting it
+	rootCmd.Flags().Bool("decommission", false, "Publish a deletion snapshot for the decommissioned BMC at --ip, retiring every device it reported, instead of collecting it")
+
+	// Wait mode follows the snapshot until the server has reconciled it
+	rootCmd.Flags().Bool("wait", false, "Wait for the server to reconcile the snapshot, printing its status, and fail unless it was applied")
+	rootCmd.Flags().Duration("wait-timeout", collector.DefaultWaitTimeout, "How long --wait waits for the server to reconcile the snapshot")
+
+	// Record mode saves the Redfish tree read as a sanitized fixture
+	rootCmd.Flags().String("record", "", "Directory or S3 bucket URL to record the Redfish tree read to, as a fixture for mockredfish --fixture and the quirk tests")
+	rootCmd.Flags().String("record-name", "", "Name of the recorded fixture (default: vendor-model-firmware of the BMC)")
+	rootCmd.Flags().Bool("record-anonymize", false, "Replace serial numbers, UUIDs and addresses in the recorded fixture with random stand-ins")
+
+	// Where to post, how to log in to BMCs, which certificates to trust, and
+	// where plugins live; shared with the agent
+	rootCmd.PersistentFlags().String("server", collector.InventoryAPIHost, "Inventory API URL (discovered from the cloud-init meta-data when not set)")
+	rootCmd.PersistentFlags().Bool("discover-server", true, "Find the inventory API URL in the cloud-init meta-data when --server is not set")
+	rootCmd.PersistentFlags().String("instance-data-file", collector.DefaultInstanceDataFile, "cloud-init instance data to read the inventory API URL from")
+	rootCmd.PersistentFlags().String("metadata-url", collector.DefaultMetadataURL, "Metadata endpoint to ask for the inventory API URL")
+	rootCmd.PersistentFlags().StringVar(&bmcLogin.Username, "username", "", fmt.Sprintf("BMC user name, for every BMC (default: from the credential store, else %s)", collector.DefaultUsername))
+	rootCmd.PersistentFlags().StringVar(&bmcLogin.Password, "password", "", "BMC password, for every BMC; visible to other local users, so prefer COLLECTOR_BMC_PASSWORD")
+	rootCmd.PersistentFlags().String("credentials-file", "", "YAML, TOML or JSON file mapping BMC addresses and networks (CIDR) to their logins, with a default")
+	rootCmd.PersistentFlags().String("credential-store", "", fmt.Sprintf("Where BMC logins are looked up %v (default: the environment, then the config file)", collector.CredentialStores))
+	rootCmd.PersistentFlags().String("vault-addr", "", "Vault URL of --credential-store vault (default: VAULT_ADDR)")
+	rootCmd.PersistentFlags().String("vault-path", collector.DefaultVaultPath, "Vault secret path of each BMC, a template over its .Address, .Host, .IP and .Hostname")
+	rootCmd.PersistentFlags().String("ca-file", "", "PEM file of CA certificates to trust besides the system trust store")
+	rootCmd.PersistentFlags().Bool("verify-bmc-tls", false, "Verify BMC certificates instead of accepting any")
+	rootCmd.PersistentFlags().String("tls-min-version", "", "Oldest TLS version for BMC and API connections: 1.2 or 1.3 (default 1.2)")
+	rootCmd.PersistentFlags().String("tls-cipher-suites", "", "Comma-separated TLS 1.2 cipher suites to allow (default: Go's secure suites)")
+	rootCmd.PersistentFlags().Bool("tls-fips", false, "Allow only FIPS 140-3 approved TLS; requires GODEBUG=fips140=on")
+	rootCmd.PersistentFlags().Int("api-retries", fabricaclient.DefaultMaxRetries, "Retries of a failed or throttled inventory API call (-1 disables retries)")
+	rootCmd.PersistentFlags().Duration("api-timeout", 2*time.Minute, "Timeout of each inventory API call attempt")
+	rootCmd.PersistentFlags().Duration("reprobe-interval", collector.DefaultReprobeInterval, "How often to probe the Redfish subtrees a BMCEndpoint is known to lack (negative: every collection)")
+	rootCmd.PersistentFlags().String("redfish-proxy", "", "URL of a caching Redfish proxy (collector proxy) to read BMCs through (default: read them directly)")
+	rootCmd.PersistentFlags().Duration("task-timeout", collector.DefaultTaskTimeout, "How long to follow the task monitor of a BMC answering 202 Accepted")
+	rootCmd.PersistentFlags().Duration("max-clock-skew", collector.DefaultMaxClockSkew, "How far a BMC's clock may be off before its BMC device is flagged clock_skewed")
+	rootCmd.PersistentFlags().String("priority", "", fmt.Sprintf("Reconciliation priority of the snapshots %v (default: interactive for a single collection, scheduled for batches and the agent)", discoverysnapshot.Priorities))
+	rootCmd.PersistentFlags().String("signing-key", "", "PEM private key (Ed25519, ECDSA or RSA; e.g. the mTLS client key) to sign snapshots with")
+	rootCmd.PersistentFlags().String("property-policy", "", "JSON file of the device properties to allow, deny or hash before posting (as the server's --property-policy)")
+	rootCmd.PersistentFlags().StringSlice("publish", nil, fmt.Sprintf("Where to publish snapshots %v (default: %s)", collector.PublishTargets, collector.PublishAPI))
+	rootCmd.PersistentFlags().String("publish-dir", "", "Directory the file target writes snapshots to")
+	rootCmd.PersistentFlags().String("publish-s3-url", "", "S3 bucket URL and key prefix the s3 target writes snapshots to (credentials from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN)")
+	rootCmd.PersistentFlags().String("publish-s3-region", "us-east-1", "Region used to sign S3 requests")
+	rootCmd.PersistentFlags().StringSlice("kafka-brokers", nil, "Kafka brokers (host:port) the kafka target produces to")
+	rootCmd.PersistentFlags().String("kafka-topic", "", "Kafka topic the kafka target produces snapshots to")
+	rootCmd.PersistentFlags().Bool("kafka-tls", false, "Connect to the Kafka brokers with TLS")
+	rootCmd.PersistentFlags().String("plugin-dir", collector.DefaultPluginDir(), "Directory of collector plugin executables, each registered as a backend named after the file")
+	rootCmd.PersistentFlags().Int("concurrency", collector.DefaultAgentConcurrency, "How many BMCs to collect from at once")
+	rootCmd.PersistentFlags().String("template", "", "Print the run summary (or import result) through this Go template, e.g. '{{.snapshot}}'; progress goes to stderr")
+	rootCmd.PersistentFlags().String("jsonpath", "", "Print the values of this JSONPath in the run summary (or import result), one per line, e.g. '.deviceTypes.DIMM'")
+
+	rootCmd.AddCommand(agentCmd)
+	bindFlags(rootCmd.Flags())
+	bindFlags(rootCmd.PersistentFlags())
+}
+
+// bindFlags binds flags to viper keys. Flags are kebab-case and config keys
+// (and INVENTORY_COLLECTOR_* variables) snake_case.
+func bindFlags(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		viper.BindPFlag(strings.ReplaceAll(f.Name, "-", "_"), f)
+	})
+}
+
+// initConfig reads the config file and the INVENTORY_COLLECTOR_* environment.
+// The file's format follows its extension; a file without one is YAML.
+func initConfig() {
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+		if filepath.Ext(cfgFile) == "" {
+			viper.SetConfigType("yaml")
+		}
+	} else if dir, err := collector.ConfigDir(); err == nil {
+		viper.AddConfigPath(dir)
+		viper.SetConfigName("collector")
+	}
+	viper.SetEnvPrefix("INVENTORY_COLLECTOR")
+	viper.AutomaticEnv()
+
+	var notFound viper.ConfigFileNotFoundError
+	if err := viper.ReadInConfig(); err == nil {
+		fmt.Printf("Using config file: %s\n", viper.ConfigFileUsed())
+	} else if cfgFile != "" || !errors.As(err, &notFound) {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to read config file %s: %v\n", firstOf(cfgFile, viper.ConfigFileUsed()), err)
+	}
+}
+
+// collectOptions builds the collector options from the flags and config.
+func collectOptions() (collector.CollectOptions, error) {
+	config := collector.ConfigCredentials{
+		Default: collector.Credential{
+			Username: viper.GetString("username"),
+			Password: viper.GetString("password"),
+		},
+	}
+	// The environment cannot hold a map, so it carries the credentials as JSON
+	if raw := os.Getenv("INVENTORY_COLLECTOR_CREDENTIALS"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &config.Addresses); err != nil {
+			return collector.CollectOptions{}, fmt.Errorf("failed to parse INVENTORY_COLLECTOR_CREDENTIALS: %w", err)
+		}
+	} else if err := viper.UnmarshalKey("credentials", &config.Addresses); err != nil {
+		return collector.CollectOptions{}, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	policy, err := tlspolicy.Parse(viper.GetString("tls_min_version"), viper.GetString("tls_cipher_suites"), viper.GetBool("tls_fips"))
+	if err != nil {
+		return collector.CollectOptions{}, err
+	}
+	store, err := collector.NewCredentialStore(viper.GetString("credential_store"), config, collector.VaultOptions{
+		Addr:      viper.GetString("vault_addr"),
+		Path:      viper.GetString("vault_path"),
+		TLSPolicy: policy,
+	})
+	if err != nil {
+		return collector.CollectOptions{}, err
+	}
+	// A credentials file names each BMC's login, so it wins over the stores
+	if path := viper.GetString("credentials_file"); path != "" {
+		if viper.GetString("credential_store") == collector.CredentialStoreVault {
+			return collector.CollectOptions{}, errors.New("the vault credential store takes BMC passwords only from Vault; drop --credentials-file")
+		}
+		file, err := collector.LoadCredentialsFile(path)
+		if err != nil {
+			return collector.CollectOptions{}, err
+		}
+		store = collector.ChainCredentials(file, store)
+	}
+	// A login given on the command line wins over every store
+	store = collector.WithLogin(store, bmcLogin)
+	var signer *signing.Signer
+	if path := viper.GetString("signing_key"); path != "" {
+		if signer, err = signing.LoadSigner(path); err != nil {
+			return collector.CollectOptions{}, err
+		}
+	}
+	var propertyPolicy *redact.Policy
+	if path := viper.GetString("property_policy"); path != "" {
+		if propertyPolicy, err = redact.LoadPolicy(path); err != nil {
+			return collector.CollectOptions{}, err
+		}
+	}
+	opts := collector.CollectOptions{
+		Backend:        viper.GetString("backend"),
+		Profile:        viper.GetString("profile"),
+		Protocol:       viper.GetString("protocol"),
+		Server:         viper.GetString("server"),
+		Credentials:    store,
+		CAFile:         viper.GetString("ca_file"),
+		VerifyBMCTLS:   viper.GetBool("verify_bmc_tls"),
+		TLSPolicy:      policy,
+		RedfishProxy:   viper.GetString("redfish_proxy"),
+		Signer:         signer,
+		PropertyPolicy: propertyPolicy,
+		Priority:       viper.GetString("priority"),
+		APIRetry: fabricaclient.RetryPolicy{
+			MaxRetries: viper.GetInt("api_retries"),
+			Timeout:    viper.GetDuration("api_timeout"),
+		},
+		ReprobeInterval: viper.GetDuration("reprobe_interval"),
+		TaskTimeout:     viper.GetDuration("task_timeout"),
+		MaxClockSkew:    viper.GetDuration("max_clock_skew"),
+		Publish: collector.PublishOptions{
+			Targets: listSetting("publish"),
+			Dir:     viper.GetString("publish_dir"),
+			S3: s3.Bucket{
+				URL:             viper.GetString("publish_s3_url"),
+				Region:          viper.GetString("publish_s3_region"),
+				AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+				SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			},
+			KafkaBrokers: listSetting("kafka_brokers"),
+			KafkaTopic:   viper.GetString("kafka_topic"),
+			KafkaTLS:     viper.GetBool("kafka_tls"),
+		},
+	}
+	if location := viper.GetString("record"); location != "" {
+		opts.Record = collector.RecordOptions{
+			Store: fixtures.NewStore(location, s3.Bucket{
+				Region:          viper.GetString("publish_s3_region"),
+				AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+				SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			}),
+			Name:      viper.GetString("record_name"),
+			Anonymize: viper.GetBool("record_anonymize"),
+		}
+	}
+	if err := opts.Publish.Validate(); err != nil {
+		return collector.CollectOptions{}, err
+	}
+	if !bmcendpoint.ValidProtocol(opts.Protocol) {
+		return collector.CollectOptions{}, fmt.Errorf("unknown protocol %q (valid: %v)", opts.Protocol, bmcendpoint.Protocols)
+	}
+	if !discoverysnapshot.ValidPriority(opts.Priority) {
+		return collector.CollectOptions{}, fmt.Errorf("unknown priority %q (valid: %v)", opts.Priority, discoverysnapshot.Priorities)
+	}
+	if slices.Contains(opts.Publish.Targets, collector.PublishStdout) {
+		// Progress goes to stderr so that stdout carries only snapshots
+		opts.Publish.Stdout = os.Stdout
+		os.Stdout = os.Stderr
+	}
+	return opts, nil
+}
+
+// resolveServer discovers the inventory API URL unless it was configured.
+func resolveServer(opts *collector.CollectOptions) {
+	if viper.IsSet("server") || !viper.GetBool("discover_server") {
+		return
+	}
+	server, source, err := collector.DiscoverServer(context.Background(), viper.GetString("instance_data_file"), viper.GetString("metadata_url"))
+	if err != nil {
+		fmt.Printf("Inventory API URL not discovered, using %s: %v\n", opts.Server, err)
+		return
+	}
+	fmt.Printf("Using inventory API %s from %s\n", server, source)
+	opts.Server = server
+}
+
+// listSetting returns a list setting, which the environment gives
+// comma-separated.
+func listSetting(key string) []string {
+	var list []string
+	for _, value := range viper.GetStringSlice(key) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// firstSetting returns the first item of a list setting, or "".
+func firstSetting(key string) string {
+	if list := listSetting(key); len(list) > 0 {
+		return list[0]
+	}
+	return ""
+}
+
+// loadPlugins registers the plugins in the plugin directory as backends.
+func loadPlugins() error {
+	pluginDir := viper.GetString("plugin_dir")
+	if pluginDir == "" {
+		return nil
+	}
+	names, err := collector.LoadPlugins(pluginDir)
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		fmt.Printf("Loaded collector plugins: %v\n", names)
+	}
+	return nil
 }
 
 func main() {
@@ -30,15 +375,216 @@ func main() {
 	}
 }
 
+// summaryPrinter returns the printer of --template or --jsonpath, or nil.
+// When there is one, progress goes to stderr and the returned writer is
+// stdout. Snapshots published to stdout leave no room for a summary.
+func summaryPrinter(opts collector.CollectOptions) (*render.Printer, *os.File, error) {
+	printer, err := render.New(viper.GetString("template"), viper.GetString("jsonpath"))
+	if err != nil || printer == nil {
+		return nil, os.Stdout, err
+	}
+	if opts.Publish.Stdout != nil {
+		return nil, nil, fmt.Errorf("--template and --jsonpath cannot be combined with the %s publish target", collector.PublishStdout)
+	}
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return printer, stdout, nil
+}
+
 // executeGatherAndPost is the main function logic triggered by cobra.
 func executeGatherAndPost(cmd *cobra.Command, args []string) {
-	fmt.Printf("Starting inventory collection for BMC IP: %s\n", bmcIP)
+	opts, err := collectOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Collection Failed: %v\n", err)
+		os.Exit(1)
+	}
+	printer, stdout, err := summaryPrinter(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Collection Failed: %v\n", err)
+		os.Exit(1)
+	}
+	scan := listSetting("scan")
+	if (deepWalk || viper.GetBool("decommission")) && len(scan) > 0 {
+		fmt.Fprintln(os.Stderr, "Collection Failed: --deep-walk and --decommission take a single BMC, not --scan")
+		os.Exit(1)
+	}
+	addresses, err := bmcAddresses(scan, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Collection Failed: %v\n", err)
+		os.Exit(1)
+	}
+	if len(addresses) == 0 {
+		if len(scan) > 0 {
+			fmt.Fprintf(os.Stderr, "Collection Failed: no Redfish endpoint answered in %s\n", strings.Join(scan, ", "))
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "Collection Failed: --ip (or INVENTORY_COLLECTOR_IP), --hosts-file or --scan is required")
+		os.Exit(1)
+	}
+	if viper.GetBool("wait") && !opts.Publish.APIOnly() {
+		fmt.Fprintf(os.Stderr, "Collection Failed: --wait needs the %s publish target alone\n", collector.PublishAPI)
+		os.Exit(1)
+	}
+	// Someone running the collector by hand on one BMC is waiting on the
+	// result; a batch should not hold up other interactive snapshots
+	if opts.Priority == "" {
+		opts.Priority = discoverysnapshot.PriorityInteractive
+		if len(addresses) > 1 {
+			opts.Priority = discoverysnapshot.PriorityScheduled
+		}
+	}
+	if (deepWalk || viper.GetBool("decommission")) && len(addresses) > 1 {
+		fmt.Fprintln(os.Stderr, "Collection Failed: --deep-walk and --decommission take a single BMC")
+		os.Exit(1)
+	}
+	bmcIP := addresses[0]
+	if deepWalk {
+		executeDeepWalk(bmcIP, opts, printer, stdout)
+		return
+	}
+	if viper.GetBool("decommission") {
+		executeDecommission(bmcIP, opts, printer, stdout)
+		return
+	}
+
+	if err := loadPlugins(); err != nil {
+		fmt.Fprintf(os.Stderr, "Collection Failed: %v\n", err)
+		os.Exit(1)
+	}
 
-	err := collector.CollectAndPost(bmcIP)
+	if opts.Publish.UsesAPI() {
+		resolveServer(&opts)
+	}
+	if len(addresses) > 1 || viper.GetString("hosts_file") != "" || len(scan) > 0 {
+		executeBatch(addresses, opts, printer, stdout)
+		return
+	}
+	opts.RunID = fabricaclient.NewRunID()
+	fmt.Printf("Starting inventory collection for BMC IP: %s (run %s)\n", bmcIP, opts.RunID)
+
+	summary, err := collector.Collect(bmcIP, opts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Collection Failed: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Println("Inventory collection and posting completed successfully.")
-}
\ No newline at end of file
+	followSnapshot(summary, opts, printer, stdout)
+	if printer != nil {
+		if err := printer.Print(stdout, summary); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print the run summary: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// bmcAddresses returns the addresses of --ip followed by those of
+// --hosts-file and those found by scanning the networks of --scan.
+func bmcAddresses(scan []string, opts collector.CollectOptions) ([]string, error) {
+	addresses := listSetting("ip")
+	if path := viper.GetString("hosts_file"); path != "" {
+		hosts, err := collector.ReadHostsFile(path)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, hosts...)
+	}
+	if len(scan) > 0 {
+		found, err := collector.Scan(context.Background(), scan, opts, collector.ScanOptions{
+			Port:        viper.GetInt("scan_port"),
+			Concurrency: viper.GetInt("scan_concurrency"),
+			Timeout:     viper.GetDuration("scan_timeout"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, found...)
+	}
+	return addresses, nil
+}
+
+// executeBatch collects from several BMCs, a snapshot each, and prints a
+// summary of the successes and failures. It exits nonzero if any failed.
+func executeBatch(addresses []string, opts collector.CollectOptions, printer *render.Printer, stdout *os.File) {
+	// One API client for every collection, so they share the circuit breaker
+	opts, err := opts.ShareAPIClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Collection Failed: %v\n", err)
+		os.Exit(1)
+	}
+	summary := collector.CollectBatch(addresses, opts, collector.BatchOptions{
+		Concurrency: viper.GetInt("concurrency"),
+		Wait:        viper.GetBool("wait"),
+		WaitTimeout: viper.GetDuration("wait_timeout"),
+	})
+
+	if printer != nil {
+		if err := printer.Print(stdout, summary); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print the run summary: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		fmt.Println("Batch collection finished:")
+		summary.Print(os.Stdout)
+	}
+	if summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// executeDecommission publishes the deletion snapshot of a decommissioned BMC.
+func executeDecommission(bmcIP string, opts collector.CollectOptions, printer *render.Printer, stdout *os.File) {
+	if opts.Publish.UsesAPI() {
+		resolveServer(&opts)
+	}
+	opts.RunID = fabricaclient.NewRunID()
+	fmt.Printf("Decommissioning BMC IP: %s (run %s)\n", bmcIP, opts.RunID)
+
+	summary, err := collector.Decommission(bmcIP, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Decommission Failed: %v\n", err)
+		os.Exit(1)
+	}
+	followSnapshot(summary, opts, printer, stdout)
+	if printer != nil {
+		if err := printer.Print(stdout, summary); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print the run summary: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// followSnapshot waits for the server to reconcile the snapshot posted, when
+// --wait is set. If it was not applied, it prints the run summary and exits.
+func followSnapshot(summary *collector.RunSummary, opts collector.CollectOptions, printer *render.Printer, stdout *os.File) {
+	if !viper.GetBool("wait") {
+		return
+	}
+	if err := collector.Follow(summary, opts, viper.GetDuration("wait_timeout")); err != nil {
+		fmt.Fprintf(os.Stderr, "Reconciliation Failed: %v\n", err)
+		if printer != nil {
+			printer.Print(stdout, summary)
+		}
+		os.Exit(1)
+	}
+}
+
+// executeDeepWalk crawls the BMC and prints the resource type report.
+func executeDeepWalk(bmcIP string, opts collector.CollectOptions, printer *render.Printer, stdout *os.File) {
+	fmt.Printf("Starting deep walk for BMC IP: %s\n", bmcIP)
+
+	report, err := collector.DeepWalk(bmcIP, deepWalkDepth, deepWalkMax, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Deep Walk Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if printer != nil {
+		if err := printer.Print(stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to print the deep walk report: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	report.Print(os.Stdout)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/redfishproxy"
+	"github.com/example/inventory-v3/pkg/tlspolicy"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var proxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Serve a caching Redfish proxy that tools share to read BMCs",
+	Long: `Serve a caching HTTP proxy for Redfish reads, so that the tools reading a site's
+BMCs (collectors, firmware tooling) share each response instead of each
+asking the BMC. Tools address a BMC by prefixing its paths with
+/bmc/<address>, e.g. https://proxy:8444/bmc/10.0.0.5/redfish/v1/Systems/1;
+collectors read through it with --redfish-proxy https://proxy:8444.
+
+GETs answered 200 OK are cached for --ttl, and served from the cache to the
+clients whose credentials the BMC accepted within it. Identical GETs in flight
+at once are sent to the BMC once, and at most --max-concurrent requests go to
+one BMC at a time. Send Cache-Control: no-cache to read past the cache. Other
+methods pass through; a successful one other than a login or logout drops the
+BMC's cached responses.
+
+The proxy reaches BMCs with the collector's TLS settings (--verify-bmc-tls,
+--ca-file, --tls-min-version, ...). Clients send it BMC credentials, so serve
+it with --tls-cert and --tls-key unless it listens on localhost, and limit the
+BMCs it reaches with --allow.
+
+It serves /healthz, /metrics (Prometheus) and /stats (JSON) besides the BMCs.`,
+	Args: cobra.NoArgs,
+	// Bound only when running, as --listen is also the agent's
+	PreRun: func(cmd *cobra.Command, args []string) { bindFlags(cmd.Flags()) },
+	Run:    executeProxy,
+}
+
+func init() {
+	proxyCmd.Flags().String("listen", "127.0.0.1:8444", "Address to serve the proxy on")
+	proxyCmd.Flags().String("tls-cert", "", "PEM certificate to serve the proxy with over TLS (default: plain HTTP)")
+	proxyCmd.Flags().String("tls-key", "", "PEM private key of --tls-cert")
+	proxyCmd.Flags().Duration("ttl", redfishproxy.DefaultTTL, "How long a BMC response is served from the cache")
+	proxyCmd.Flags().Int("max-entries", redfishproxy.DefaultMaxEntries, "Most responses cached; the least recently used are evicted first")
+	proxyCmd.Flags().Int("max-concurrent", redfishproxy.DefaultMaxConcurrent, "Most requests sent to one BMC at once")
+	proxyCmd.Flags().StringSlice("allow", nil, "Networks (CIDR) of the BMCs the proxy may reach (default: any)")
+	rootCmd.AddCommand(proxyCmd)
+}
+
+// executeProxy serves the proxy until SIGTERM or SIGINT.
+func executeProxy(cmd *cobra.Command, args []string) {
+	proxy, err := newProxy()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Proxy Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(redfishproxy.PathPrefix, proxy)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write(proxy.MarshalMetrics())
+	})
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(proxy.Stats())
+	})
+	server := &http.Server{
+		Addr:              viper.GetString("listen"),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	certFile, keyFile := viper.GetString("tls_cert"), viper.GetString("tls_key")
+	if (certFile == "") != (keyFile == "") {
+		fmt.Fprintln(os.Stderr, "Proxy Failed: --tls-cert and --tls-key go together")
+		os.Exit(1)
+	}
+	go func() {
+		var err error
+		if certFile != "" {
+			err = server.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Proxy Failed: %v\n", err)
+			os.Exit(1)
+		}
+	}()
+	fmt.Printf("Redfish proxy caching BMC responses for %s on %s\n", proxy.TTL, server.Addr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	<-ctx.Done()
+	fmt.Println("Proxy shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+}
+
+// newProxy builds the proxy from the flags and config.
+func newProxy() (*redfishproxy.Proxy, error) {
+	policy, err := tlspolicy.Parse(viper.GetString("tls_min_version"), viper.GetString("tls_cipher_suites"), viper.GetBool("tls_fips"))
+	if err != nil {
+		return nil, err
+	}
+	opts := collector.CollectOptions{
+		CAFile:       viper.GetString("ca_file"),
+		VerifyBMCTLS: viper.GetBool("verify_bmc_tls"),
+		TLSPolicy:    policy,
+	}
+	transport, err := opts.BMCTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := redfishproxy.New(transport)
+	proxy.TTL = viper.GetDuration("ttl")
+	proxy.MaxEntries = viper.GetInt("max_entries")
+	proxy.MaxConcurrent = viper.GetInt("max_concurrent")
+	for _, network := range listSetting("allow") {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --allow network %q: %w", network, err)
+		}
+		proxy.Allowed = append(proxy.Allowed, prefix)
+	}
+	if proxy.TTL <= 0 || proxy.MaxEntries <= 0 || proxy.MaxConcurrent <= 0 {
+		return nil, errors.New("--ttl, --max-entries and --max-concurrent must be positive")
+	}
+	return proxy, nil
+}
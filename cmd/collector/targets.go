@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/render"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var targetsCmd = &cobra.Command{
+	Use:   "targets",
+	Short: "Manage the BMC endpoints collected from",
+}
+
+var targetsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Register the BMCs known to SMD or Kea as BMC endpoints",
+	Long: `Create a BMCEndpoint for every BMC listed by SMD's RedfishEndpoints or leased by
+the Kea DHCP server, bootstrapping the agent's target list from what the site
+already runs. BMCs whose address already has an endpoint are left alone, so the
+import can be repeated. Created endpoints carry the label imported-from=smd or
+imported-from=kea.
+
+From SMD, each endpoint is named after the xname and disabled if SMD disables
+it. From Kea, the active leases are asked of the Control Agent (--kea-url) or
+read from a memfile lease file (--kea-lease-file); narrow them to the BMCs with
+--kea-subnet-id and --hostname-regexp. Each endpoint is named after the lease's
+host name, else its address.
+
+The outcome is printed as JSON; --dry-run lists what would be created.`,
+	Args: cobra.NoArgs,
+	Run:  executeTargetsImport,
+}
+
+func init() {
+	targetsImportCmd.Flags().String("from", "", fmt.Sprintf("Where to import BMCs from %v (required)", collector.TargetSources))
+	targetsImportCmd.Flags().String("smd-url", "", "SMD base URL, e.g. https://smd.example.com")
+	targetsImportCmd.Flags().String("smd-token", "", "Bearer token for SMD")
+	targetsImportCmd.Flags().String("kea-url", "", "Kea Control Agent URL, e.g. http://kea:8000")
+	targetsImportCmd.Flags().String("kea-lease-file", "", "Kea memfile lease file to read instead of asking the Control Agent")
+	targetsImportCmd.Flags().IntSlice("kea-subnet-id", nil, "Kea subnets holding the BMCs (default: all)")
+	targetsImportCmd.Flags().String("hostname-regexp", "", "Import only the Kea leases whose host name matches")
+	targetsImportCmd.Flags().String("endpoint-profile", "", "Collection profile of the endpoints created (default: full)")
+	targetsImportCmd.Flags().String("endpoint-backend", "", fmt.Sprintf("Collector backend of the endpoints created (default: %s)", collector.DefaultBackend))
+	targetsImportCmd.Flags().Bool("dry-run", false, "List the endpoints that would be created without creating them")
+	bindFlags(targetsImportCmd.Flags())
+	targetsCmd.AddCommand(targetsImportCmd)
+	rootCmd.AddCommand(targetsCmd)
+}
+
+// executeTargetsImport imports the BMCs of --from and prints the outcome.
+func executeTargetsImport(cmd *cobra.Command, args []string) {
+	opts, err := collectOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import Failed: %v\n", err)
+		os.Exit(1)
+	}
+	imp, err := importOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import Failed: %v\n", err)
+		os.Exit(1)
+	}
+	printer, err := render.New(viper.GetString("template"), viper.GetString("jsonpath"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import Failed: %v\n", err)
+		os.Exit(1)
+	}
+	// The outcome goes to stdout, so progress goes to stderr
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	resolveServer(&opts)
+
+	result, err := opts.ImportTargets(context.Background(), imp)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import Failed: %v\n", err)
+		os.Exit(1)
+	}
+	if printer != nil {
+		if err := printer.Print(stdout, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Import Failed: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Fprintln(stdout, string(data))
+	}
+	if len(result.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// importOptions builds the import options from the flags and config.
+func importOptions() (collector.ImportOptions, error) {
+	var filter collector.KeaFilter
+	for _, id := range listSetting("kea_subnet_id") {
+		var subnetID int
+		if _, err := fmt.Sscan(id, &subnetID); err != nil {
+			return collector.ImportOptions{}, fmt.Errorf("invalid Kea subnet ID %q", id)
+		}
+		filter.SubnetIDs = append(filter.SubnetIDs, subnetID)
+	}
+	if expr := viper.GetString("hostname_regexp"); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return collector.ImportOptions{}, fmt.Errorf("invalid --hostname-regexp: %w", err)
+		}
+		filter.Hostname = re
+	}
+	spec := bmcendpoint.BMCEndpointSpec{
+		Profile: viper.GetString("endpoint_profile"),
+		Backend: viper.GetString("endpoint_backend"),
+	}
+	if !bmcendpoint.ValidProfile(spec.Profile) {
+		return collector.ImportOptions{}, fmt.Errorf("unknown profile %q (valid: %v)", spec.Profile, bmcendpoint.Profiles)
+	}
+	return collector.ImportOptions{
+		From:         viper.GetString("from"),
+		SMDURL:       viper.GetString("smd_url"),
+		SMDToken:     viper.GetString("smd_token"),
+		KeaURL:       viper.GetString("kea_url"),
+		KeaLeaseFile: viper.GetString("kea_lease_file"),
+		KeaFilter:    filter,
+		Spec:         spec,
+		DryRun:       viper.GetBool("dry_run"),
+	}, nil
+}
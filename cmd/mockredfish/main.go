@@ -0,0 +1,186 @@
+// Command mockredfish serves fake BMCs for running the collector without
+// hardware, optionally injecting faults.
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+	"github.com/example/inventory-v3/pkg/fixtures"
+
+	"github.com/spf13/cobra"
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "mockredfish",
+	Short: "Serve fake Redfish BMCs for testing the collector",
+	Long: `Serve fake Redfish BMCs over HTTPS with a self-signed certificate. Each BMC
+manages one node with CPUs, DIMMs, NVMe drives and a NIC; with --count N the
+BMCs listen on N consecutive ports, and node serial numbers end in the BMC's
+index. Collect from one with
+
+  COLLECTOR_BMC_USERNAME=admin COLLECTOR_BMC_PASSWORD=admin \
+    collector --ip 127.0.0.1:8443
+
+Faults make the BMCs misbehave deterministically for a given --seed, e.g.
+--error-rate 0.1 --fault-paths /redfish/v1/Systems/1/Memory fails a tenth of
+the memory reads.
+
+With --fixture the BMCs serve a recorded fixture (collector --record) instead
+of the synthetic node, e.g. one of the fixtures/ of this repository.`,
+	Args: cobra.NoArgs,
+	RunE: run,
+}
+
+func init() {
+	flags := rootCmd.Flags()
+	flags.String("listen", "127.0.0.1:8443", "Address of the first BMC")
+	flags.Int("count", 1, "Number of BMCs, on consecutive ports")
+	flags.String("serial-prefix", "MOCK", "Prefix of the node serial numbers")
+	flags.String("username", "admin", "BMC login user name (empty accepts anyone)")
+	flags.String("password", "admin", "BMC login password")
+	flags.Int("dimms", 8, "DIMMs per node")
+	flags.String("fixture", "", "Serve this recorded fixture file instead of the synthetic node")
+	flags.Int("page-size", 0, "Split collections into pages of this many members (0: one page)")
+	flags.Duration("clock-skew", 0, "Report the BMC clock this far off the real time (negative: behind)")
+	flags.Int("min-password-length", 0, "Refuse account passwords shorter than this, as a password policy does")
+	flags.Bool("fixed-accounts", false, "Refuse to create accounts, as BMCs with fixed account slots do")
+
+	flags.Duration("latency", 0, "Delay every response by this much")
+	flags.Duration("jitter", 0, "Delay responses by up to this much more at random")
+	flags.Float64("error-rate", 0, "Share of requests answered 500 (0 to 1)")
+	flags.Float64("truncate-rate", 0, "Share of responses cut off halfway through their JSON (0 to 1)")
+	flags.Int("auth-flap-every", 0, "Answer every nth authenticated request 401, as if the session expired (0: never)")
+	flags.Float64("task-rate", 0, "Share of requests answered 202 Accepted with a task monitor (0 to 1)")
+	flags.Int("task-polls", 1, "Polls a task monitor answers 202 before the resource")
+	flags.Bool("drop-next-link", false, "Leave nextLink out of collection pages (with --page-size)")
+	flags.StringSlice("fault-paths", nil, "Inject faults only under these Redfish paths (default: everywhere)")
+	flags.Int64("seed", 1, "Seed of the random faults")
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	listen, _ := flags.GetString("listen")
+	count, _ := flags.GetInt("count")
+	prefix, _ := flags.GetString("serial-prefix")
+	username, _ := flags.GetString("username")
+	password, _ := flags.GetString("password")
+	dimms, _ := flags.GetInt("dimms")
+	pageSize, _ := flags.GetInt("page-size")
+	clockSkew, _ := flags.GetDuration("clock-skew")
+	minPasswordLength, _ := flags.GetInt("min-password-length")
+	fixedAccounts, _ := flags.GetBool("fixed-accounts")
+	fixturePath, _ := flags.GetString("fixture")
+
+	var faults redfishmock.Faults
+	faults.Latency, _ = flags.GetDuration("latency")
+	faults.Jitter, _ = flags.GetDuration("jitter")
+	faults.ErrorRate, _ = flags.GetFloat64("error-rate")
+	faults.TruncateRate, _ = flags.GetFloat64("truncate-rate")
+	faults.AuthFlapEvery, _ = flags.GetInt("auth-flap-every")
+	faults.TaskRate, _ = flags.GetFloat64("task-rate")
+	faults.TaskPolls, _ = flags.GetInt("task-polls")
+	faults.DropNextLink, _ = flags.GetBool("drop-next-link")
+	faults.Paths, _ = flags.GetStringSlice("fault-paths")
+	faults.Seed, _ = flags.GetInt64("seed")
+
+	host, portText, err := net.SplitHostPort(listen)
+	if err != nil {
+		return fmt.Errorf("invalid --listen: %w", err)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return fmt.Errorf("invalid --listen port: %w", err)
+	}
+	cert, err := selfSignedCertificate(host)
+	if err != nil {
+		return err
+	}
+	var fixture *fixtures.Fixture
+	if fixturePath != "" {
+		if fixture, err = fixtures.ReadFile(fixturePath); err != nil {
+			return err
+		}
+	}
+
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		node := redfishmock.DefaultNode(fmt.Sprintf("%s%04d", prefix, i))
+		node.DIMMs = dimms
+		resources := redfishmock.Fixture(node)
+		if fixture != nil {
+			resources = fixture.MockResources()
+		}
+		server, err := redfishmock.New(resources)
+		if err != nil {
+			return err
+		}
+		server.Username, server.Password, server.PageSize = username, password, pageSize
+		server.ClockSkew = clockSkew
+		server.MinPasswordLength = minPasswordLength
+		server.FixedAccounts = fixedAccounts
+		server.SetFaults(faults)
+
+		addr := net.JoinHostPort(host, strconv.Itoa(port+i))
+		httpServer := &http.Server{
+			Addr:              addr,
+			Handler:           server,
+			TLSConfig:         &tls.Config{Certificates: []tls.Certificate{cert}},
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		if fixture != nil {
+			fmt.Printf("BMC %s serving fixture %s\n", addr, fixture.Name)
+		} else {
+			fmt.Printf("BMC %s serving node %s\n", addr, node.Serial)
+		}
+		go func() { errs <- httpServer.ListenAndServeTLS("", "") }()
+	}
+	return <-errs
+}
+
+// selfSignedCertificate returns a certificate for host, which the collector
+// accepts unless it verifies BMC certificates.
+func selfSignedCertificate(host string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mockredfish"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else if host != "" {
+		template.DNSNames = append(template.DNSNames, host)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetReconcilerStatus reports what the reconcilers are doing: how much each
+// has reconciled, what it is reconciling now, its last error, and how many
+// snapshots wait in each priority lane.
+func GetReconcilerStatus(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, reconcilers.GetStatus())
+}
+
+// EnableReconciler resumes a paused reconciler, which then reconciles the
+// resources that changed while it was paused.
+func EnableReconciler(w http.ResponseWriter, r *http.Request) {
+	setReconcilerEnabled(w, r, true)
+}
+
+// DisableReconciler pauses a reconciler until it is enabled again, e.g. to
+// stop snapshots from changing devices during an investigation.
+func DisableReconciler(w http.ResponseWriter, r *http.Request) {
+	setReconcilerEnabled(w, r, false)
+}
+
+func setReconcilerEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	kind := chi.URLParam(r, "kind")
+	status, err := reconcilers.SetEnabled(kind, enabled)
+	if errors.Is(err, reconcilers.ErrUnknownReconciler) {
+		respondError(w, http.StatusNotFound, fmt.Errorf("no reconciler is running for %s", kind))
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,443 @@
+// Code generated by Fabrica dev. DO NOT EDIT.
+// Template: server/handlers.go.tmpl
+// Generated: 2025-11-17T12:46:44-08:00
+//
+// # Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+//
+// This file contains REST API handlers for Alert resources.
+//
+// To modify this code:
+//  1. Edit the template file: pkg/codegen/templates/handlers.go.tmpl
+//  2. Run 'make dev' to regenerate
+//  3. Do NOT edit this file directly - changes will be lost
+//
+// Generated handlers provide:
+//   - GET /alerts (list all alerts)
+//   - GET /alerts/{uid} (get specific Alert)
+//   - POST /alerts (create new Alert)
+//   - PUT /alerts/{uid} (update Alert spec)
+//   - PATCH /alerts/{uid} (patch Alert spec)
+//   - DELETE /alerts/{uid} (delete Alert)
+//   - PUT /alerts/{uid}/status (update Alert status)
+//   - PATCH /alerts/{uid}/status (patch Alert status)
+//
+// Authorization: Add custom middleware for authentication/authorization
+// Storage: Uses storage.LoadAlert*/SaveAlert*/DeleteAlert*
+// Version Support: Available (see version context in handlers)
+//
+// To enable full version conversion for this resource:
+//  1. Create v2beta1 package: pkg/resources/alert/v2beta1/
+//  2. Implement converter: v2beta1/converter.go
+//  3. Add version-aware storage: storage.LoadAlertWithVersion()
+//  4. Register versions in cmd/server/main.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/patch"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/validation"
+	"github.com/openchami/fabrica/pkg/versioning"
+)
+
+// GetAlerts returns all Alert resources
+func GetAlerts(w http.ResponseWriter, r *http.Request) {
+	// Authorization: Add custom middleware in routes.go or implement checks here
+	// Example: if !authorized(r) { respondError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized")); return }
+
+	alerts, err := storage.LoadAllAlerts(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load alerts: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, alerts)
+}
+
+// GetAlert returns a specific Alert resource by UID
+func GetAlert(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Alert UID is required"))
+		return
+	}
+
+	// Version context available here for version-aware operations
+	// versionCtx := versioning.GetVersionContext(r.Context())
+	// Requested version: versionCtx.ServeVersion
+	// To enable: replace storage.LoadAlert() with version-aware function
+
+	// Authorization: Add custom middleware in routes.go or implement checks here
+	// Example: if !authorized(r) { respondError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized")); return }
+
+	alert, err := storage.LoadAlert(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Alert not found: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, alert)
+}
+
+// CreateAlert creates a new Alert resource
+func CreateAlert(w http.ResponseWriter, r *http.Request) {
+	var req CreateAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// Get version context from request
+	versionCtx := versioning.GetVersionContext(r.Context())
+
+	uid, err := resource.GenerateUIDForResource("Alert")
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to generate UID: %w", err))
+		return
+	}
+
+	alert := &alert.Alert{
+		Resource: resource.Resource{
+			APIVersion:    versionCtx.GroupVersion,
+			Kind:          "Alert",
+			SchemaVersion: versionCtx.ServeVersion,
+		},
+		Spec: req.AlertSpec,
+	}
+
+	alert.Metadata.Initialize(req.Name, uid)
+
+	// Set timestamps
+	now := time.Now()
+	alert.Metadata.CreatedAt = now
+	alert.Metadata.UpdatedAt = now
+
+	// Set labels and annotations
+	for k, v := range req.Labels {
+		alert.SetLabel(k, v)
+	}
+	for k, v := range req.Annotations {
+		alert.SetAnnotation(k, v)
+	}
+
+	// Layer 2: Fabrica struct tag validation
+	if err := validation.ValidateResource(alert); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+
+	// Layer 3: Custom business logic validation
+	if err := validation.ValidateWithContext(r.Context(), alert); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+
+	// Set initial status
+	// This assumes the generator passes an 'IsReconcilable' boolean
+	// to this template, and that the resource has a .Status.Phase field.
+
+	// Save (Layer 1: Ent validation happens automatically if using Ent storage)
+	if err := storage.SaveAlert(r.Context(), alert); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Alert: %w", err))
+		return
+	}
+
+	// Publish resource created event
+	if err := events.PublishResourceCreated(r.Context(), "Alert", alert.GetUID(), alert.GetName(), alert); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource created event for Alert %s: %v\n", alert.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusCreated, alert)
+}
+
+// UpdateAlert updates the spec of an existing Alert resource
+// NOTE: This endpoint ONLY updates the spec. Use PUT //alerts/{uid}/status to update status.
+func UpdateAlert(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Alert UID is required"))
+		return
+	}
+
+	alert, err := storage.LoadAlert(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Alert not found: %w", err))
+		return
+	}
+
+	var req UpdateAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// Apply updates
+	if req.Name != "" {
+		alert.SetName(req.Name)
+	}
+
+	// Update spec fields ONLY - status should use /status subresource
+	alert.Spec = req.AlertSpec
+
+	// Update labels and annotations
+	for k, v := range req.Labels {
+		alert.SetLabel(k, v)
+	}
+	for k, v := range req.Annotations {
+		alert.SetAnnotation(k, v)
+	}
+
+	alert.Touch()
+
+	if err := storage.SaveAlert(r.Context(), alert); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Alert: %w", err))
+		return
+	}
+
+	// Publish resource updated event
+	updateMetadata := map[string]interface{}{
+		"updatedAt": alert.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Alert", alert.GetUID(), alert.GetName(), alert, updateMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource updated event for Alert %s: %v\n", alert.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, alert)
+}
+
+// PatchAlert patches an existing Alert resource spec using JSON Merge Patch, JSON Patch, or Shorthand Patch
+// Only the spec portion of the resource can be patched - metadata and status are API-managed
+func PatchAlert(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Alert UID is required"))
+		return
+	}
+
+	alert, err := storage.LoadAlert(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Alert not found: %w", err))
+		return
+	}
+
+	// Read patch document
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+
+	// Marshal current spec to JSON for patching (only allow spec modifications)
+	currentSpecJSON, err := json.Marshal(alert.Spec)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current spec: %w", err))
+		return
+	}
+
+	// Detect patch type from Content-Type header
+	contentType := r.Header.Get("Content-Type")
+	patchType := patch.DetectPatchType(contentType)
+
+	// Apply patch to spec only
+	patchResult, err := patch.ApplyPatchWithOptions(currentSpecJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: true,
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to spec: %w", err))
+		return
+	}
+
+	// Unmarshal the patched result back to the spec
+	if err := json.Unmarshal(patchResult.Updated, &alert.Spec); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to unmarshal patched spec: %w", err))
+		return
+	}
+
+	// Touch to update metadata
+	alert.Touch()
+
+	// Save the patched resource
+	if err := storage.SaveAlert(r.Context(), alert); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched Alert: %w", err))
+		return
+	}
+
+	// Publish resource patched event
+	patchMetadata := map[string]interface{}{
+		"patchType": patchType,
+		"updatedAt": alert.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourcePatched(r.Context(), "Alert", alert.GetUID(), alert.GetName(), alert, patchMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource patched event for Alert %s: %v\n", alert.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, alert)
+}
+
+// UpdateAlertStatus updates only the status of a Alert resource
+// This endpoint is intended for controllers, reconcilers, and monitoring systems.
+// It does not modify the spec or metadata (except updatedAt timestamp).
+//
+// Authorization: Requires 'update_status' permission (separate from 'update' permission)
+// Events: Publishes resource updated event with updateType: "status"
+func UpdateAlertStatus(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Alert UID is required"))
+		return
+	}
+
+	// Authorization: Add custom middleware for status update authorization
+	// Status updates can have different permissions than spec updates
+
+	res, err := storage.LoadAlert(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Alert not found: %w", err))
+		return
+	}
+
+	var statusUpdate alert.AlertStatus
+	if err := json.NewDecoder(r.Body).Decode(&statusUpdate); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid status body: %w", err))
+		return
+	}
+
+	// Preserve spec - only update status
+	res.Status = statusUpdate
+	res.Touch()
+
+	if err := storage.SaveAlert(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Alert status: %w", err))
+		return
+	}
+
+	// Publish status update event
+	statusMetadata := map[string]interface{}{
+		"updatedAt":  res.Metadata.UpdatedAt,
+		"updateType": "status",
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Alert", res.GetUID(), res.GetName(), res, statusMetadata); err != nil {
+		// Log but don't fail - events are non-critical
+		fmt.Printf("Warning: Failed to publish status update event for Alert %s: %v\n", res.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
+
+// PatchAlertStatus patches only the status of a Alert resource
+// Supports JSON Merge Patch, JSON Patch, and Shorthand Patch formats.
+// Only modifies status fields - spec and metadata are preserved.
+func PatchAlertStatus(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Alert UID is required"))
+		return
+	}
+
+	// Authorization: Add custom middleware for status patch authorization
+	// Status patches can have different permissions than spec patches
+
+	res, err := storage.LoadAlert(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Alert not found: %w", err))
+		return
+	}
+
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+
+	// Marshal current status for patching
+	currentStatusJSON, err := json.Marshal(res.Status)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current status: %w", err))
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	patchType := patch.DetectPatchType(contentType)
+
+	patchResult, err := patch.ApplyPatchWithOptions(currentStatusJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: false, // Don't allow removing status fields
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to status: %w", err))
+		return
+	}
+
+	// Unmarshal patched status back
+	if err := json.Unmarshal(patchResult.Updated, &res.Status); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to unmarshal patched status: %w", err))
+		return
+	}
+
+	res.Touch()
+
+	if err := storage.SaveAlert(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched Alert status: %w", err))
+		return
+	}
+
+	// Publish status patch event
+	patchMetadata := map[string]interface{}{
+		"patchType":  patchType,
+		"updatedAt":  res.Metadata.UpdatedAt,
+		"updateType": "status",
+	}
+	if err := events.PublishResourcePatched(r.Context(), "Alert", res.GetUID(), res.GetName(), res, patchMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish status patch event for Alert %s: %v\n", res.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
+
+// DeleteAlert deletes a Alert resource
+func DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Alert UID is required"))
+		return
+	}
+
+	// Load resource before deletion for event publishing
+	alert, err := storage.LoadAlert(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Alert not found: %w", err))
+		return
+	}
+
+	if err := storage.DeleteAlert(r.Context(), uid); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to delete Alert: %w", err))
+		return
+	}
+
+	// Publish resource deleted event
+	deleteMetadata := map[string]interface{}{
+		"deletedAt": time.Now(),
+	}
+	if err := events.PublishResourceDeleted(r.Context(), "Alert", alert.GetUID(), alert.GetName(), deleteMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource deleted event for Alert %s: %v\n", alert.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, &DeleteResponse{
+		Message: "Alert deleted successfully",
+		UID:     uid,
+	})
+}
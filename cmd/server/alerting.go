@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/alerting"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// alertEvaluator evaluates the alert rules, stores the alerts they raise and
+// announces the ones that change state.
+type alertEvaluator struct {
+	engine    *alerting.Engine
+	notifiers []alerting.Notifier
+
+	// mu serializes evaluations, which read and write the same alerts.
+	mu   sync.Mutex
+	last *alerting.Evaluation
+}
+
+// evaluator is the server's alert evaluator, set up in runServer.
+var evaluator = &alertEvaluator{}
+
+// Run evaluates the rules now. An alert that changes state is announced on
+// the event bus and to every notifier; a failed notifier does not stop the
+// others, and its error is recorded in the evaluation.
+func (e *alertEvaluator) Run(ctx context.Context) (*alerting.Evaluation, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	start := time.Now()
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	alerts, err := storage.LoadAllAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alerts: %w", err)
+	}
+	blackout, err := loadFleetBlackout(ctx, devices, start)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &alerting.Inventory{Devices: devices, Blackout: blackout, Baseline: scheduler.options.Baseline}
+	result := &alerting.Evaluation{
+		EvaluatedAt: start,
+		Rules:       len(e.engine.Rules()),
+		Findings:    e.engine.Evaluate(inv, start),
+	}
+	updates, err := alerting.Apply(alerts, result.Findings, start)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range updates {
+		if err := e.store(ctx, u); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", u.Alert.GetName(), err))
+			continue
+		}
+		switch u.Event {
+		case alerting.EventFiring:
+			result.Fired++
+		case alerting.EventResolved:
+			result.Resolved++
+		default:
+			continue
+		}
+		result.Errors = append(result.Errors, e.notify(ctx, alerting.Notification{Event: u.Event, At: start, Alert: u.Alert})...)
+	}
+	for _, a := range alerts {
+		if a.Firing() {
+			result.Firing++
+		}
+	}
+	for _, u := range updates {
+		if u.Created {
+			result.Firing++
+		}
+	}
+	result.DurationMillis = time.Since(start).Milliseconds()
+
+	e.last = result
+	return result, nil
+}
+
+// store saves an alert and publishes its lifecycle event.
+func (e *alertEvaluator) store(ctx context.Context, u alerting.Update) error {
+	a := u.Alert
+	if u.Created {
+		if err := storage.SaveAlert(ctx, a); err != nil {
+			return err
+		}
+		if err := events.PublishResourceCreated(ctx, "Alert", a.GetUID(), a.GetName(), a); err != nil {
+			fmt.Printf("Warning: Failed to publish resource created event for Alert %s: %v\n", a.GetUID(), err)
+		}
+		return nil
+	}
+	if err := storage.UpdateAlert(ctx, a); err != nil {
+		return err
+	}
+	updateMetadata := map[string]interface{}{"state": a.Status.State}
+	if err := events.PublishResourceUpdated(ctx, "Alert", a.GetUID(), a.GetName(), a, updateMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish resource updated event for Alert %s: %v\n", a.GetUID(), err)
+	}
+	return nil
+}
+
+// notify announces a state change on the event bus and to every notifier,
+// returning the notifiers' errors.
+func (e *alertEvaluator) notify(ctx context.Context, n alerting.Notification) []string {
+	if err := events.PublishResourceEvent(ctx, n.Event, "Alert", n.Alert.GetUID(), n.Alert); err != nil {
+		fmt.Printf("Warning: Failed to publish %s event for Alert %s: %v\n", n.Event, n.Alert.GetUID(), err)
+	}
+	var errs []string
+	for _, notifier := range e.notifiers {
+		if err := notifier.Notify(ctx, n); err != nil {
+			log.Printf("Alert notification to %s failed: %v", notifier.Name(), err)
+			errs = append(errs, fmt.Sprintf("%s: %v", notifier.Name(), err))
+		}
+	}
+	return errs
+}
+
+// Start evaluates the rules every interval until ctx is done. Alert state is
+// stored, so the first evaluation after a restart announces only changes.
+func (e *alertEvaluator) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if result, err := e.Run(ctx); err != nil {
+				log.Printf("Alert evaluation failed: %v", err)
+			} else if result.Fired > 0 || result.Resolved > 0 {
+				log.Printf("Alert evaluation: %d fired, %d resolved, %d firing", result.Fired, result.Resolved, result.Firing)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// EvaluateAlerts evaluates the alert rules now.
+func EvaluateAlerts(w http.ResponseWriter, r *http.Request) {
+	result, err := evaluator.Run(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// GetLastAlertEvaluation returns the outcome of the last evaluation.
+func GetLastAlertEvaluation(w http.ResponseWriter, r *http.Request) {
+	evaluator.mu.Lock()
+	result := evaluator.last
+	evaluator.mu.Unlock()
+	if result == nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("no alert evaluation has run yet"))
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// GetAlertRules returns the configured alert rules.
+func GetAlertRules(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, evaluator.engine.Rules())
+}
+
+// setupAlerting configures the alert rules and notifiers. Without a rules
+// file the default rules apply. Webhooks connect under the configured TLS
+// policy.
+func setupAlerting(cfg *Config) error {
+	configs := alerting.DefaultRules()
+	if cfg.AlertRules != "" {
+		var err error
+		if configs, err = alerting.LoadRules(cfg.AlertRules); err != nil {
+			return err
+		}
+	}
+	engine, err := alerting.NewEngine(configs)
+	if err != nil {
+		return err
+	}
+	evaluator.engine = engine
+
+	evaluator.notifiers = nil
+	if cfg.AlertWebhookURL != "" {
+		tlsPolicy, err := cfg.TLSPolicy()
+		if err != nil {
+			return err
+		}
+		evaluator.notifiers = append(evaluator.notifiers, &alerting.WebhookNotifier{
+			URL:    cfg.AlertWebhookURL,
+			Client: &http.Client{Timeout: 30 * time.Second, Transport: tlsPolicy.Transport(nil)},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/bom"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetDeviceBOM exports the hardware bill of materials of a device, usually a
+// node or rack, and everything in it as CycloneDX JSON.
+func GetDeviceBOM(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	for _, d := range devices {
+		if d.GetUID() == uid {
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", uid+".cdx.json"))
+			respondJSON(w, http.StatusOK, bom.Build(d, devices, time.Now()))
+			return
+		}
+	}
+	respondError(w, http.StatusNotFound, fmt.Errorf("Device not found: %s", uid))
+}
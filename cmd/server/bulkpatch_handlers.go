@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/bulkpatch"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// PatchDevices applies bulk edits of device labels and properties, one
+// device per row, posted as CSV (Content-Type text/csv) or as a JSON array.
+// Rows name their device by UID or serial number; a row that names no single
+// device, or whose edits are invalid, fails alone and is reported in its
+// entry. "dryRun=true" returns what would change without changing it.
+func PatchDevices(w http.ResponseWriter, r *http.Request) {
+	var rows []bulkpatch.Row
+	var err error
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		rows, err = bulkpatch.ParseCSV(r.Body)
+	} else {
+		rows, err = bulkpatch.ParseJSON(r.Body)
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	byUID := make(map[string]*device.Device, len(devices))
+	bySerial := make(map[string][]*device.Device, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+		if d.Spec.SerialNumber != "" {
+			bySerial[d.Spec.SerialNumber] = append(bySerial[d.Spec.SerialNumber], d)
+		}
+	}
+
+	result := &bulkpatch.Result{
+		DryRun:  r.URL.Query().Get("dryRun") == "true",
+		Rows:    len(rows),
+		Entries: make([]bulkpatch.Entry, 0, len(rows)),
+	}
+	for _, row := range rows {
+		entry := bulkpatch.Entry{Line: row.Line, UID: row.UID, SerialNumber: row.SerialNumber}
+		changed, err := patchDevice(r, row, byUID, bySerial, result.DryRun, &entry)
+		switch {
+		case err != nil:
+			entry.Error = err.Error()
+			result.Failed++
+		case len(changed) == 0:
+			result.Unchanged++
+		default:
+			entry.Changed = changed
+			result.Applied++
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// patchDevice applies a row to the device it names, saving the device unless
+// dryRun, and fills in the entry's UID and serial number.
+func patchDevice(r *http.Request, row bulkpatch.Row, byUID map[string]*device.Device, bySerial map[string][]*device.Device, dryRun bool, entry *bulkpatch.Entry) ([]string, error) {
+	var d *device.Device
+	switch {
+	case row.UID != "":
+		if d = byUID[row.UID]; d == nil {
+			return nil, fmt.Errorf("no device has UID %s", row.UID)
+		}
+		if row.SerialNumber != "" && d.Spec.SerialNumber != row.SerialNumber {
+			return nil, fmt.Errorf("device %s has serial number %q, not %s", row.UID, d.Spec.SerialNumber, row.SerialNumber)
+		}
+	case row.SerialNumber != "":
+		matches := bySerial[row.SerialNumber]
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no device has serial number %s", row.SerialNumber)
+		}
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("%d devices have serial number %s; name the device by UID", len(matches), row.SerialNumber)
+		}
+		d = matches[0]
+	default:
+		return nil, fmt.Errorf("the row names no device; give a uid or serial number")
+	}
+	entry.UID, entry.SerialNumber = d.GetUID(), d.Spec.SerialNumber
+
+	changed, err := bulkpatch.Apply(d, row)
+	if err != nil || len(changed) == 0 || dryRun {
+		return changed, err
+	}
+	d.Touch()
+	if err := storage.SaveDevice(r.Context(), d); err != nil {
+		return nil, fmt.Errorf("failed to save device: %w", err)
+	}
+	updateMetadata := map[string]interface{}{
+		"updatedAt": d.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Device", d.GetUID(), d.GetName(), d, updateMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish resource updated event for Device %s: %v\n", d.GetUID(), err)
+	}
+	return changed, nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/cabling"
+	"github.com/example/inventory-v3/pkg/resources/connection"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// cablingInferrer infers the cabling between switches and node NICs and keeps
+// the Connection resources that record it.
+type cablingInferrer struct {
+	// mu serializes runs, which would otherwise create the same connection twice
+	mu sync.Mutex
+}
+
+// inferrer is the server's cabling inferrer.
+var inferrer = &cablingInferrer{}
+
+// Run infers the cabling now. Links found are created or refreshed as
+// Connections; inferred Connections no longer found are marked Stale.
+// Manual Connections record the planned cabling: links they already record
+// are not created again, and they are marked Miscabled while the links
+// contradict them.
+func (c *cablingInferrer) Run(ctx context.Context) (*cabling.Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start := time.Now()
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	connections, err := storage.LoadAllConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+
+	manual := make(map[string]bool)
+	var planned []*connection.Connection
+	inferred := make(map[string]*connection.Connection)
+	for _, conn := range connections {
+		key := cabling.Key(conn.Spec.SwitchID, conn.Spec.SwitchPort, conn.Spec.MACAddress)
+		if conn.Spec.Method == connection.MethodManual {
+			manual[key] = true
+			planned = append(planned, conn)
+		} else {
+			inferred[key] = conn
+		}
+	}
+
+	links := cabling.Infer(devices)
+	result := &cabling.Result{RanAt: start.UTC(), Links: len(links)}
+	found := make(map[string]bool, len(links))
+	for _, link := range links {
+		key := cabling.Key(link.SwitchID, link.SwitchPort, link.MACAddress)
+		found[key] = true
+		if manual[key] {
+			continue
+		}
+		if conn, ok := inferred[key]; ok {
+			if err := refreshConnection(ctx, conn, link, start); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", conn.GetUID(), err))
+				continue
+			}
+			result.Updated++
+			continue
+		}
+		if err := createConnection(ctx, link, start); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s %s: %v", link.SwitchID, link.SwitchPort, err))
+			continue
+		}
+		result.Created++
+	}
+
+	for key, conn := range inferred {
+		if found[key] || conn.Status.Phase == connection.PhaseStale {
+			continue
+		}
+		conn.Status.Phase = connection.PhaseStale
+		conn.Status.Ready = false
+		if err := saveConnection(ctx, conn); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", conn.GetUID(), err))
+			continue
+		}
+		result.Stale++
+	}
+
+	result.Miscabled = cabling.Miscablings(links, planned)
+	for _, conn := range planned {
+		miscabled := miscabling(result.Miscabled, conn.GetUID())
+		switch {
+		case miscabled != nil && (conn.Status.Phase != connection.PhaseMiscabled || conn.Status.Found != miscabled.Found):
+			conn.Status.Phase = connection.PhaseMiscabled
+			conn.Status.Ready = false
+			conn.Status.Found = miscabled.Found
+		case miscabled == nil && conn.Status.Phase == connection.PhaseMiscabled:
+			conn.Status.Phase = connection.PhaseActive
+			conn.Status.Ready = true
+			conn.Status.Found = ""
+		default:
+			continue
+		}
+		if err := saveConnection(ctx, conn); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", conn.GetUID(), err))
+		}
+	}
+
+	result.DurationMillis = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// Start infers the cabling every interval until ctx is done.
+func (c *cablingInferrer) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if result, err := c.Run(ctx); err != nil {
+				log.Printf("Cabling inference failed: %v", err)
+			} else if result.Created+result.Stale+len(result.Miscabled) > 0 || len(result.Errors) > 0 {
+				log.Printf("Cabling inference found %d links: %d new, %d stale, %d miscabled, %d errors", result.Links, result.Created, result.Stale, len(result.Miscabled), len(result.Errors))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// createConnection records a newly found link, named after the switch port.
+func createConnection(ctx context.Context, link cabling.Link, now time.Time) error {
+	uid, err := resource.GenerateUIDForResource("Connection")
+	if err != nil {
+		return fmt.Errorf("failed to generate UID: %w", err)
+	}
+	seen := now.UTC()
+	conn := &connection.Connection{
+		Resource: resource.Resource{
+			APIVersion:    "v1",
+			Kind:          "Connection",
+			SchemaVersion: "v1",
+		},
+		Spec: connectionSpec(link),
+		Status: connection.ConnectionStatus{
+			Phase:    connection.PhaseActive,
+			Ready:    true,
+			LastSeen: &seen,
+		},
+	}
+	conn.Metadata.Initialize(link.SwitchID+"/"+link.SwitchPort, uid)
+	conn.Metadata.CreatedAt = now
+	conn.Metadata.UpdatedAt = now
+
+	if err := storage.SaveConnection(ctx, conn); err != nil {
+		return err
+	}
+	if err := events.PublishResourceCreated(ctx, "Connection", conn.GetUID(), conn.GetName(), conn); err != nil {
+		fmt.Printf("Warning: Failed to publish resource created event for Connection %s: %v\n", conn.GetUID(), err)
+	}
+	return nil
+}
+
+// refreshConnection records that an inferred connection was found again,
+// possibly by another method or with the NIC since moved to another node.
+func refreshConnection(ctx context.Context, conn *connection.Connection, link cabling.Link, now time.Time) error {
+	seen := now.UTC()
+	conn.Spec = connectionSpec(link)
+	conn.Status.Phase = connection.PhaseActive
+	conn.Status.Ready = true
+	conn.Status.LastSeen = &seen
+	return saveConnection(ctx, conn)
+}
+
+func saveConnection(ctx context.Context, conn *connection.Connection) error {
+	conn.Touch()
+	if err := storage.SaveConnection(ctx, conn); err != nil {
+		return err
+	}
+	updateMetadata := map[string]interface{}{
+		"updatedAt": conn.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourceUpdated(ctx, "Connection", conn.GetUID(), conn.GetName(), conn, updateMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish resource updated event for Connection %s: %v\n", conn.GetUID(), err)
+	}
+	return nil
+}
+
+// miscabling returns the miscabling found of a connection, or nil.
+func miscabling(miscabled []cabling.Miscabling, uid string) *cabling.Miscabling {
+	for i := range miscabled {
+		if miscabled[i].ConnectionID == uid {
+			return &miscabled[i]
+		}
+	}
+	return nil
+}
+
+func connectionSpec(link cabling.Link) connection.ConnectionSpec {
+	return connection.ConnectionSpec{
+		SwitchID:   link.SwitchID,
+		SwitchPort: link.SwitchPort,
+		NICID:      link.NICID,
+		NodeID:     link.NodeID,
+		MACAddress: link.MACAddress,
+		Method:     link.Method,
+	}
+}
+
+// InferCabling infers the cabling now and returns what changed.
+func InferCabling(w http.ResponseWriter, r *http.Request) {
+	result, err := inferrer.Run(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
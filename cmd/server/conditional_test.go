@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// TestConditionalRequests guards device writes with the ETag of their last
+// read, whether the resource changed since through the API or a reconciler.
+func TestConditionalRequests(t *testing.T) {
+	if err := storage.InitFileBackend(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	dev := &device.Device{Spec: device.DeviceSpec{DeviceType: "Node", SerialNumber: "ETAG0001"}}
+	dev.APIVersion, dev.Kind = "v1", "Device"
+	dev.Metadata.Name, dev.Metadata.UID = "node-1", "dev-etag0001"
+	if err := storage.SaveDevice(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(conditionalRequests)
+	RegisterGeneratedRoutes(r)
+	server := httptest.NewServer(r)
+	defer server.Close()
+	c, err := client.NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, etag, err := c.GetDeviceWithETag(ctx, dev.GetUID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if etag == "" || got.Spec.SerialNumber != "ETAG0001" {
+		t.Fatalf("GetDeviceWithETag = %s with ETag %q", got.Spec.SerialNumber, etag)
+	}
+
+	// An unchanged resource is not sent again
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/devices/"+dev.GetUID(), nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("GET with If-None-Match = %d, want 304", resp.StatusCode)
+	}
+
+	update := client.UpdateDeviceRequest{DeviceSpec: got.Spec}
+	update.PartNumber = "first"
+	_, newETag, err := c.UpdateDeviceIfMatch(ctx, dev.GetUID(), update, etag)
+	if err != nil {
+		t.Fatalf("UpdateDeviceIfMatch with the current ETag failed: %v", err)
+	}
+	if newETag == "" || newETag == etag {
+		t.Errorf("update returned ETag %q, want a new one", newETag)
+	}
+
+	// A write with the ETag read before the update fails
+	update.PartNumber = "stale"
+	if _, _, err := c.UpdateDeviceIfMatch(ctx, dev.GetUID(), update, etag); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Errorf("update with a stale ETag error = %v, want ErrPreconditionFailed", err)
+	}
+
+	// So does one with the ETag from before a reconciler's write
+	stored, err := storage.LoadDevice(ctx, dev.GetUID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored.Spec.PartNumber = "reconciled"
+	if err := (lockingClient{storage.NewStorageClient()}).Update(ctx, stored); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.UpdateDeviceStatusIfMatch(ctx, dev.GetUID(), device.DeviceStatus{Phase: "Ready"}, newETag); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Errorf("status update after a reconcile error = %v, want ErrPreconditionFailed", err)
+	}
+	if err := c.DeleteDeviceIfMatch(ctx, dev.GetUID(), newETag); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Errorf("delete after a reconcile error = %v, want ErrPreconditionFailed", err)
+	}
+
+	_, current, err := c.GetDeviceWithETag(ctx, dev.GetUID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteDeviceIfMatch(ctx, dev.GetUID(), current); err != nil {
+		t.Fatalf("DeleteDeviceIfMatch with the current ETag failed: %v", err)
+	}
+	if err := c.DeleteDeviceIfMatch(ctx, dev.GetUID(), current); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Errorf("delete of a deleted device error = %v, want ErrPreconditionFailed", err)
+	}
+}
@@ -0,0 +1,443 @@
+// Code generated by Fabrica dev. DO NOT EDIT.
+// Template: server/handlers.go.tmpl
+// Generated: 2025-11-17T12:46:44-08:00
+//
+// # Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+//
+// This file contains REST API handlers for Connection resources.
+//
+// To modify this code:
+//  1. Edit the template file: pkg/codegen/templates/handlers.go.tmpl
+//  2. Run 'make dev' to regenerate
+//  3. Do NOT edit this file directly - changes will be lost
+//
+// Generated handlers provide:
+//   - GET /connections (list all connections)
+//   - GET /connections/{uid} (get specific Connection)
+//   - POST /connections (create new Connection)
+//   - PUT /connections/{uid} (update Connection spec)
+//   - PATCH /connections/{uid} (patch Connection spec)
+//   - DELETE /connections/{uid} (delete Connection)
+//   - PUT /connections/{uid}/status (update Connection status)
+//   - PATCH /connections/{uid}/status (patch Connection status)
+//
+// Authorization: Add custom middleware for authentication/authorization
+// Storage: Uses storage.LoadConnection*/SaveConnection*/DeleteConnection*
+// Version Support: Available (see version context in handlers)
+//
+// To enable full version conversion for this resource:
+//  1. Create v2beta1 package: pkg/resources/connection/v2beta1/
+//  2. Implement converter: v2beta1/converter.go
+//  3. Add version-aware storage: storage.LoadConnectionWithVersion()
+//  4. Register versions in cmd/server/main.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/resources/connection"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/patch"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/validation"
+	"github.com/openchami/fabrica/pkg/versioning"
+)
+
+// GetConnections returns all Connection resources
+func GetConnections(w http.ResponseWriter, r *http.Request) {
+	// Authorization: Add custom middleware in routes.go or implement checks here
+	// Example: if !authorized(r) { respondError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized")); return }
+
+	connections, err := storage.LoadAllConnections(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load connections: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, connections)
+}
+
+// GetConnection returns a specific Connection resource by UID
+func GetConnection(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Connection UID is required"))
+		return
+	}
+
+	// Version context available here for version-aware operations
+	// versionCtx := versioning.GetVersionContext(r.Context())
+	// Requested version: versionCtx.ServeVersion
+	// To enable: replace storage.LoadConnection() with version-aware function
+
+	// Authorization: Add custom middleware in routes.go or implement checks here
+	// Example: if !authorized(r) { respondError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized")); return }
+
+	connection, err := storage.LoadConnection(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Connection not found: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, connection)
+}
+
+// CreateConnection creates a new Connection resource
+func CreateConnection(w http.ResponseWriter, r *http.Request) {
+	var req CreateConnectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// Get version context from request
+	versionCtx := versioning.GetVersionContext(r.Context())
+
+	uid, err := resource.GenerateUIDForResource("Connection")
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to generate UID: %w", err))
+		return
+	}
+
+	connection := &connection.Connection{
+		Resource: resource.Resource{
+			APIVersion:    versionCtx.GroupVersion,
+			Kind:          "Connection",
+			SchemaVersion: versionCtx.ServeVersion,
+		},
+		Spec: req.ConnectionSpec,
+	}
+
+	connection.Metadata.Initialize(req.Name, uid)
+
+	// Set timestamps
+	now := time.Now()
+	connection.Metadata.CreatedAt = now
+	connection.Metadata.UpdatedAt = now
+
+	// Set labels and annotations
+	for k, v := range req.Labels {
+		connection.SetLabel(k, v)
+	}
+	for k, v := range req.Annotations {
+		connection.SetAnnotation(k, v)
+	}
+
+	// Layer 2: Fabrica struct tag validation
+	if err := validation.ValidateResource(connection); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+
+	// Layer 3: Custom business logic validation
+	if err := validation.ValidateWithContext(r.Context(), connection); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+
+	// Set initial status
+	// This assumes the generator passes an 'IsReconcilable' boolean
+	// to this template, and that the resource has a .Status.Phase field.
+
+	// Save (Layer 1: Ent validation happens automatically if using Ent storage)
+	if err := storage.SaveConnection(r.Context(), connection); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Connection: %w", err))
+		return
+	}
+
+	// Publish resource created event
+	if err := events.PublishResourceCreated(r.Context(), "Connection", connection.GetUID(), connection.GetName(), connection); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource created event for Connection %s: %v\n", connection.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusCreated, connection)
+}
+
+// UpdateConnection updates the spec of an existing Connection resource
+// NOTE: This endpoint ONLY updates the spec. Use PUT //connections/{uid}/status to update status.
+func UpdateConnection(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Connection UID is required"))
+		return
+	}
+
+	connection, err := storage.LoadConnection(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Connection not found: %w", err))
+		return
+	}
+
+	var req UpdateConnectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// Apply updates
+	if req.Name != "" {
+		connection.SetName(req.Name)
+	}
+
+	// Update spec fields ONLY - status should use /status subresource
+	connection.Spec = req.ConnectionSpec
+
+	// Update labels and annotations
+	for k, v := range req.Labels {
+		connection.SetLabel(k, v)
+	}
+	for k, v := range req.Annotations {
+		connection.SetAnnotation(k, v)
+	}
+
+	connection.Touch()
+
+	if err := storage.SaveConnection(r.Context(), connection); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Connection: %w", err))
+		return
+	}
+
+	// Publish resource updated event
+	updateMetadata := map[string]interface{}{
+		"updatedAt": connection.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Connection", connection.GetUID(), connection.GetName(), connection, updateMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource updated event for Connection %s: %v\n", connection.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, connection)
+}
+
+// PatchConnection patches an existing Connection resource spec using JSON Merge Patch, JSON Patch, or Shorthand Patch
+// Only the spec portion of the resource can be patched - metadata and status are API-managed
+func PatchConnection(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Connection UID is required"))
+		return
+	}
+
+	connection, err := storage.LoadConnection(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Connection not found: %w", err))
+		return
+	}
+
+	// Read patch document
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+
+	// Marshal current spec to JSON for patching (only allow spec modifications)
+	currentSpecJSON, err := json.Marshal(connection.Spec)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current spec: %w", err))
+		return
+	}
+
+	// Detect patch type from Content-Type header
+	contentType := r.Header.Get("Content-Type")
+	patchType := patch.DetectPatchType(contentType)
+
+	// Apply patch to spec only
+	patchResult, err := patch.ApplyPatchWithOptions(currentSpecJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: true,
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to spec: %w", err))
+		return
+	}
+
+	// Unmarshal the patched result back to the spec
+	if err := json.Unmarshal(patchResult.Updated, &connection.Spec); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to unmarshal patched spec: %w", err))
+		return
+	}
+
+	// Touch to update metadata
+	connection.Touch()
+
+	// Save the patched resource
+	if err := storage.SaveConnection(r.Context(), connection); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched Connection: %w", err))
+		return
+	}
+
+	// Publish resource patched event
+	patchMetadata := map[string]interface{}{
+		"patchType": patchType,
+		"updatedAt": connection.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourcePatched(r.Context(), "Connection", connection.GetUID(), connection.GetName(), connection, patchMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource patched event for Connection %s: %v\n", connection.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, connection)
+}
+
+// UpdateConnectionStatus updates only the status of a Connection resource
+// This endpoint is intended for controllers, reconcilers, and monitoring systems.
+// It does not modify the spec or metadata (except updatedAt timestamp).
+//
+// Authorization: Requires 'update_status' permission (separate from 'update' permission)
+// Events: Publishes resource updated event with updateType: "status"
+func UpdateConnectionStatus(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Connection UID is required"))
+		return
+	}
+
+	// Authorization: Add custom middleware for status update authorization
+	// Status updates can have different permissions than spec updates
+
+	res, err := storage.LoadConnection(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Connection not found: %w", err))
+		return
+	}
+
+	var statusUpdate connection.ConnectionStatus
+	if err := json.NewDecoder(r.Body).Decode(&statusUpdate); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid status body: %w", err))
+		return
+	}
+
+	// Preserve spec - only update status
+	res.Status = statusUpdate
+	res.Touch()
+
+	if err := storage.SaveConnection(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Connection status: %w", err))
+		return
+	}
+
+	// Publish status update event
+	statusMetadata := map[string]interface{}{
+		"updatedAt":  res.Metadata.UpdatedAt,
+		"updateType": "status",
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Connection", res.GetUID(), res.GetName(), res, statusMetadata); err != nil {
+		// Log but don't fail - events are non-critical
+		fmt.Printf("Warning: Failed to publish status update event for Connection %s: %v\n", res.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
+
+// PatchConnectionStatus patches only the status of a Connection resource
+// Supports JSON Merge Patch, JSON Patch, and Shorthand Patch formats.
+// Only modifies status fields - spec and metadata are preserved.
+func PatchConnectionStatus(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Connection UID is required"))
+		return
+	}
+
+	// Authorization: Add custom middleware for status patch authorization
+	// Status patches can have different permissions than spec patches
+
+	res, err := storage.LoadConnection(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Connection not found: %w", err))
+		return
+	}
+
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+
+	// Marshal current status for patching
+	currentStatusJSON, err := json.Marshal(res.Status)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current status: %w", err))
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	patchType := patch.DetectPatchType(contentType)
+
+	patchResult, err := patch.ApplyPatchWithOptions(currentStatusJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: false, // Don't allow removing status fields
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to status: %w", err))
+		return
+	}
+
+	// Unmarshal patched status back
+	if err := json.Unmarshal(patchResult.Updated, &res.Status); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to unmarshal patched status: %w", err))
+		return
+	}
+
+	res.Touch()
+
+	if err := storage.SaveConnection(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched Connection status: %w", err))
+		return
+	}
+
+	// Publish status patch event
+	patchMetadata := map[string]interface{}{
+		"patchType":  patchType,
+		"updatedAt":  res.Metadata.UpdatedAt,
+		"updateType": "status",
+	}
+	if err := events.PublishResourcePatched(r.Context(), "Connection", res.GetUID(), res.GetName(), res, patchMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish status patch event for Connection %s: %v\n", res.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
+
+// DeleteConnection deletes a Connection resource
+func DeleteConnection(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Connection UID is required"))
+		return
+	}
+
+	// Load resource before deletion for event publishing
+	connection, err := storage.LoadConnection(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Connection not found: %w", err))
+		return
+	}
+
+	if err := storage.DeleteConnection(r.Context(), uid); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to delete Connection: %w", err))
+		return
+	}
+
+	// Publish resource deleted event
+	deleteMetadata := map[string]interface{}{
+		"deletedAt": time.Now(),
+	}
+	if err := events.PublishResourceDeleted(r.Context(), "Connection", connection.GetUID(), connection.GetName(), deleteMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource deleted event for Connection %s: %v\n", connection.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, &DeleteResponse{
+		Message: "Connection deleted successfully",
+		UID:     uid,
+	})
+}
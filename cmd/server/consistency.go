@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/consistency"
+	"github.com/example/inventory-v3/pkg/reconcilers"
+)
+
+// consistencyChecker runs the fleet consistency check and keeps the latest report.
+type consistencyChecker struct {
+	maxAge time.Duration
+
+	mu     sync.Mutex
+	latest *consistency.Report
+}
+
+// checker is the server's consistency checker, set up in runServer.
+var checker = &consistencyChecker{maxAge: consistency.DefaultSnapshotMaxAge}
+
+// Run checks the fleet now and stores the report.
+func (c *consistencyChecker) Run(ctx context.Context) (*consistency.Report, error) {
+	start := time.Now()
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	endpoints, err := storage.LoadAllBMCEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BMC endpoints: %w", err)
+	}
+	snapshots, err := storage.LoadAllDiscoverySnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshots: %w", err)
+	}
+
+	blackout, err := loadBlackout(ctx, devices, endpoints, start)
+	if err != nil {
+		return nil, err
+	}
+
+	report := consistency.Check(devices, endpoints, snapshots, blackout, c.maxAge, start)
+	report.DurationMillis = time.Since(start).Milliseconds()
+
+	c.mu.Lock()
+	c.latest = report
+	c.mu.Unlock()
+	return report, nil
+}
+
+// Latest returns the last report, running a check if there is none yet.
+func (c *consistencyChecker) Latest(ctx context.Context) (*consistency.Report, error) {
+	c.mu.Lock()
+	report := c.latest
+	c.mu.Unlock()
+	if report != nil {
+		return report, nil
+	}
+	return c.Run(ctx)
+}
+
+// Start runs the check every interval until ctx is done.
+func (c *consistencyChecker) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if report, err := c.Run(ctx); err != nil {
+				log.Printf("Consistency check failed: %v", err)
+			} else if len(report.Violations) > 0 {
+				log.Printf("Consistency check found %d violations: %v", len(report.Violations), report.Counts)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GetConsistencyReport returns the latest fleet consistency report.
+// "refresh=true" runs a new check instead of returning the stored one.
+func GetConsistencyReport(w http.ResponseWriter, r *http.Request) {
+	var report *consistency.Report
+	var err error
+	if r.URL.Query().Get("refresh") == "true" {
+		report, err = checker.Run(r.Context())
+	} else {
+		report, err = checker.Latest(r.Context())
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, report)
+}
+
+// metricsHandler serves the consistency metrics in the Prometheus text format.
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	report, err := checker.Latest(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(report.MarshalMetrics())
+	w.Write(ingest.MarshalMetrics())
+	w.Write(reconcilers.SnapshotQueueMetrics())
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/changecontrol"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// DecommissionDevice retires a node or rack and all its descendants, and
+// disables the BMCEndpoints that no longer report an active node.
+// "dryRun=true" returns what would change without changing it.
+func DecommissionDevice(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	var root *device.Device
+	for _, d := range devices {
+		if d.GetUID() == uid {
+			root = d
+			break
+		}
+	}
+	if root == nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Device not found: %s", uid))
+		return
+	}
+	if !prune.DecommissionableTypes[root.Spec.DeviceType] {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("cannot decommission a %s; only nodes and racks can be decommissioned", root.Spec.DeviceType))
+		return
+	}
+
+	subtree := prune.Subtree(devices, root)
+	result := &prune.DecommissionResult{
+		DryRun:  dryRun,
+		Root:    prune.NewEntry(root),
+		Devices: make([]prune.Entry, 0, len(subtree)),
+	}
+	retiring := make(map[string]bool, len(subtree))
+	for _, d := range subtree {
+		result.Devices = append(result.Devices, prune.NewEntry(d))
+		retiring[d.GetUID()] = true
+	}
+
+	detach, err := endpointsToDetach(r, devices, subtree, retiring)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if dryRun {
+		for _, name := range detach {
+			result.DetachedEndpoints = append(result.DetachedEndpoints, name)
+		}
+		sort.Strings(result.DetachedEndpoints)
+		for _, d := range subtree {
+			if !prune.IsRetired(d) {
+				result.Retired++
+			}
+		}
+		respondJSON(w, http.StatusOK, result)
+		return
+	}
+
+	var active []string
+	for _, d := range subtree {
+		if !prune.IsRetired(d) {
+			active = append(active, d.GetUID())
+		}
+	}
+	if changePolicy.RetireNeedsApproval(len(active)) {
+		change, err := changecontrol.New(pendingchange.ActionRetire, fmt.Sprintf("Decommission %s %s and its %d active devices", root.Spec.DeviceType, root.GetName(), len(active)))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		change.Spec.Devices = active
+		for uid, name := range detach {
+			change.Spec.DetachEndpoints = append(change.Spec.DetachEndpoints, uid)
+			result.DetachedEndpoints = append(result.DetachedEndpoints, name)
+		}
+		sort.Strings(change.Spec.DetachEndpoints)
+		sort.Strings(result.DetachedEndpoints)
+		if err := holdForApproval(r, change); err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		result.PendingChange = change.GetUID()
+		respondJSON(w, http.StatusAccepted, result)
+		return
+	}
+
+	for _, d := range subtree {
+		if prune.IsRetired(d) {
+			continue
+		}
+		if err := decommission(r, d, root); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", d.GetUID(), err))
+			continue
+		}
+		result.Retired++
+	}
+
+	for uid, name := range detach {
+		endpoint, err := storage.LoadBMCEndpoint(r.Context(), uid)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", uid, err))
+			continue
+		}
+		endpoint.Spec.Disabled = true
+		endpoint.Touch()
+		if err := storage.SaveBMCEndpoint(r.Context(), endpoint); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", uid, err))
+			continue
+		}
+		if err := events.PublishResourceEvent(r.Context(), "detached", "BMCEndpoint", uid, endpoint); err != nil {
+			fmt.Printf("Warning: Failed to publish detached event for BMCEndpoint %s: %v\n", uid, err)
+		}
+		result.DetachedEndpoints = append(result.DetachedEndpoints, name)
+	}
+	sort.Strings(result.DetachedEndpoints)
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// decommission retires one device and publishes a "decommissioned" event.
+func decommission(r *http.Request, d, root *device.Device) error {
+	d.Status.Phase = prune.PhaseRetired
+	d.Status.Message = fmt.Sprintf("Decommissioned with %s %s at %s", root.Spec.DeviceType, root.GetName(), time.Now().Format(time.RFC3339))
+	d.Touch()
+	if err := storage.SaveDevice(r.Context(), d); err != nil {
+		return err
+	}
+	if err := events.PublishResourceEvent(r.Context(), "decommissioned", "Device", d.GetUID(), d); err != nil {
+		fmt.Printf("Warning: Failed to publish decommissioned event for Device %s: %v\n", d.GetUID(), err)
+	}
+	return nil
+}
+
+// endpointsToDetach returns the enabled BMCEndpoints (UID to name) that report
+// a node being retired and no node that stays active.
+func endpointsToDetach(r *http.Request, devices, subtree []*device.Device, retiring map[string]bool) (map[string]string, error) {
+	sources := make(map[string]bool)
+	for _, d := range subtree {
+		if d.Spec.DeviceType == "Node" && d.Status.Source != "" {
+			sources[d.Status.Source] = true
+		}
+	}
+	for _, d := range devices {
+		if d.Spec.DeviceType == "Node" && !retiring[d.GetUID()] && !prune.IsRetired(d) {
+			delete(sources, d.Status.Source)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	endpoints, err := storage.LoadAllBMCEndpoints(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BMC endpoints: %w", err)
+	}
+	detach := make(map[string]string)
+	for _, e := range endpoints {
+		if sources[e.Spec.Address] && !e.Spec.Disabled {
+			detach[e.GetUID()] = e.GetName()
+		}
+	}
+	return detach, nil
+}
+
+// filterRetiredDevices drops retired and decommissioned devices from a device
+// list unless the request asks for them with "includeRetired=true".
+func filterRetiredDevices(r *http.Request, devices []*device.Device) []*device.Device {
+	if r.URL.Query().Get("includeRetired") == "true" {
+		return devices
+	}
+	active := make([]*device.Device, 0, len(devices))
+	for _, d := range devices {
+		if !prune.IsRetired(d) {
+			active = append(active, d)
+		}
+	}
+	return active
+}
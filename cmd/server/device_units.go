@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/patch"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/units"
+)
+
+// normalizeDeviceUnits converts the properties of devices written through
+// the API to their canonical units (see units.Normalize) before the device
+// handlers see them, so that creates are validated, and every write stored,
+// in the units the collector's devices are.
+//
+// Creates and updates carry the spec, which is normalized in place. A patch
+// is applied here to the stored spec first; if that normalizes to something
+// else, the handler is given the merge patch to the normalized spec instead.
+// Bodies that do not decode are passed on for the handlers to report.
+func normalizeDeviceUnits(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if parts[0] != "devices" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		create := len(parts) == 1 && r.Method == http.MethodPost
+		update := len(parts) == 2 && r.Method == http.MethodPut
+		patched := len(parts) == 2 && r.Method == http.MethodPatch
+		if !create && !update && !patched {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+			return
+		}
+		var normalized []byte
+		var ok bool
+		if patched {
+			normalized, ok = normalizeDevicePatch(r, parts[1], body)
+		} else {
+			normalized, ok = normalizeDeviceRequest(body)
+		}
+		if ok {
+			body = normalized
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// normalizeDeviceRequest normalizes the spec fields of a create or update
+// request, keeping its other fields (name, labels and annotations).
+func normalizeDeviceRequest(body []byte) ([]byte, bool) {
+	var fields map[string]json.RawMessage
+	var spec device.DeviceSpec
+	if json.Unmarshal(body, &fields) != nil || json.Unmarshal(body, &spec) != nil {
+		return nil, false
+	}
+	if len(units.Normalize(&spec)) == 0 {
+		return nil, false
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil || json.Unmarshal(specJSON, &fields) != nil {
+		return nil, false
+	}
+	normalized, err := json.Marshal(fields)
+	return normalized, err == nil
+}
+
+// normalizeDevicePatch returns the merge patch taking the stored spec to the
+// normalized result of the patch.
+func normalizeDevicePatch(r *http.Request, uid string, body []byte) ([]byte, bool) {
+	d, err := storage.LoadDevice(r.Context(), uid)
+	if err != nil {
+		return nil, false
+	}
+	current, err := json.Marshal(d.Spec)
+	if err != nil {
+		return nil, false
+	}
+	result, err := patch.ApplyPatchWithOptions(current, body, patch.DetectPatchType(r.Header.Get("Content-Type")), patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: true,
+	})
+	if err != nil {
+		return nil, false
+	}
+	var spec device.DeviceSpec
+	if json.Unmarshal(result.Updated, &spec) != nil || len(units.Normalize(&spec)) == 0 {
+		return nil, false
+	}
+	normalized, err := json.Marshal(spec)
+	if err != nil {
+		return nil, false
+	}
+	merge, err := patch.CreatePatch(current, normalized)
+	if err != nil {
+		return nil, false
+	}
+	r.Header.Set("Content-Type", string(patch.JSONMergePatch))
+	return merge, true
+}
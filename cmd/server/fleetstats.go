@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/fleetstats"
+)
+
+// fleetStatsRecorder records the day's fleet statistics.
+type fleetStatsRecorder struct {
+	// mu serializes recordings, which read and write the same days.
+	mu sync.Mutex
+}
+
+// fleetRecorder is the server's fleet statistics recorder.
+var fleetRecorder = &fleetStatsRecorder{}
+
+// Run records today's statistics, replacing those recorded earlier in the
+// day. A day recorded for the last time before it ended is closed first,
+// so that the changes reconciled after its last record count.
+func (f *fleetStatsRecorder) Run(ctx context.Context) (*fleetstats.Day, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now().UTC()
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	snapshots, err := storage.LoadAllDiscoverySnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshots: %w", err)
+	}
+
+	yesterday, err := storage.LoadFleetStats(ctx, now.AddDate(0, 0, -1).Format(fleetstats.DateLayout))
+	if err != nil {
+		return nil, err
+	}
+	if yesterday != nil && yesterday.Close(snapshots) {
+		if err := storage.SaveFleetStats(ctx, yesterday); err != nil {
+			return nil, fmt.Errorf("failed to save fleet stats of %s: %w", yesterday.Date, err)
+		}
+	}
+
+	today := fleetstats.Record(devices, snapshots, now)
+	if err := storage.SaveFleetStats(ctx, today); err != nil {
+		return nil, fmt.Errorf("failed to save fleet stats of %s: %w", today.Date, err)
+	}
+	return today, nil
+}
+
+// Start records the statistics every interval until ctx is done.
+func (f *fleetStatsRecorder) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := f.Run(ctx); err != nil {
+				log.Printf("Fleet statistics recording failed: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GetFleetStats returns the recorded fleet statistics. The optional "from"
+// and "to" query parameters (YYYY-MM-DD) bound the days, and "period" (day,
+// week or month) rolls them up.
+func GetFleetStats(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period != "" && !slices.Contains(fleetstats.Periods, period) {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("unknown period %q (valid: %v)", period, fleetstats.Periods))
+		return
+	}
+	days, err := storage.LoadAllFleetStats(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	series, err := fleetstats.Rollup(days, period, r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, series)
+}
+
+// RecordFleetStats records today's fleet statistics now.
+func RecordFleetStats(w http.ResponseWriter, r *http.Request) {
+	day, err := fleetRecorder.Run(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, day)
+}
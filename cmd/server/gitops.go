@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/gitops"
+	"github.com/example/inventory-v3/pkg/gitopsstatus"
+	"github.com/go-chi/chi/v5"
+)
+
+// gitopsSyncer keeps the inventory in step with a git repository of
+// declarative resources, and keeps the result of the last sync.
+type gitopsSyncer struct {
+	repo     gitops.Repo
+	path     string
+	options  gitopsstatus.Options
+	interval time.Duration
+	// client applies documents through the server's own API, so synced
+	// resources are validated, and their events published, as any others.
+	client *client.Client
+
+	// mu serializes syncs, which share the checkout
+	mu        sync.Mutex
+	last      *gitopsstatus.Result
+	lastError string
+}
+
+// syncer is the server's GitOps syncer, set up in runServer when a
+// repository is configured.
+var syncer *gitopsSyncer
+
+// handlerTransport serves requests with a router in-process.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A sync run by an API request must not inherit its routing, or the
+	// router would route the sync's requests as a subrouter's.
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, (*chi.Context)(nil)))
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+// setupGitOps sets up the syncer of the configured repository, applying
+// documents through handler.
+func setupGitOps(config *Config, handler http.Handler) error {
+	if config.GitOpsRepo == "" {
+		return nil
+	}
+	c, err := client.NewClient("http://inventory", &http.Client{Transport: handlerTransport{handler}})
+	if err != nil {
+		return err
+	}
+	syncer = &gitopsSyncer{
+		repo: gitops.Repo{
+			URL:    config.GitOpsRepo,
+			Branch: config.GitOpsBranch,
+			Dir:    filepath.Join(config.DataDir, "gitops"),
+		},
+		path:     config.GitOpsPath,
+		options:  gitopsstatus.Options{ReportOnly: config.GitOpsReportOnly, Prune: config.GitOpsPrune},
+		interval: time.Duration(config.GitOpsInterval) * time.Second,
+		client:   c,
+	}
+	return nil
+}
+
+// Run pulls the repository and syncs the inventory with it now. reportOnly
+// reports drift without correcting it, whatever the configured options.
+func (s *gitopsSyncer) Run(ctx context.Context, reportOnly bool) (*gitopsstatus.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.sync(ctx, reportOnly)
+	if err != nil {
+		s.lastError = err.Error()
+		return nil, err
+	}
+	s.last, s.lastError = result, ""
+	return result, nil
+}
+
+func (s *gitopsSyncer) sync(ctx context.Context, reportOnly bool) (*gitopsstatus.Result, error) {
+	commit, err := s.repo.Pull(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s: %w", s.repo.URL, err)
+	}
+	docs, err := gitops.Load(filepath.Join(s.repo.Dir, s.path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load documents at %s: %w", commit, err)
+	}
+	options := s.options
+	options.ReportOnly = options.ReportOnly || reportOnly
+	result, err := gitops.Sync(ctx, s.client, docs, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync %s: %w", commit, err)
+	}
+	result.Commit = commit
+	return result, nil
+}
+
+// Status returns the configuration and the last sync's result.
+func (s *gitopsSyncer) Status() gitopsstatus.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return gitopsstatus.Status{
+		Repository:      s.repo.URL,
+		Branch:          s.repo.Branch,
+		Path:            s.path,
+		Options:         s.options,
+		IntervalSeconds: int(s.interval / time.Second),
+		Last:            s.last,
+		LastError:       s.lastError,
+	}
+}
+
+// Start syncs every interval until ctx is done.
+func (s *gitopsSyncer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if result, err := s.Run(ctx, false); err != nil {
+				log.Printf("GitOps sync failed: %v", err)
+			} else if len(result.Drift) > 0 || len(result.Errors) > 0 {
+				log.Printf("GitOps sync of %s found %d drifted resources: %d created, %d configured, %d pruned, %d errors",
+					result.Commit, len(result.Drift), result.Created, result.Configured, result.Pruned, len(result.Errors))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GetGitOpsStatus returns the GitOps configuration and the last sync's result.
+func GetGitOpsStatus(w http.ResponseWriter, r *http.Request) {
+	if syncer == nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("GitOps sync is not configured (see --gitops-repo)"))
+		return
+	}
+	respondJSON(w, http.StatusOK, syncer.Status())
+}
+
+// SyncGitOps syncs with the repository now and returns the result. With
+// ?reportOnly=true it reports drift without correcting it.
+func SyncGitOps(w http.ResponseWriter, r *http.Request) {
+	if syncer == nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("GitOps sync is not configured (see --gitops-repo)"))
+		return
+	}
+	reportOnly := false
+	if v := r.URL.Query().Get("reportOnly"); v != "" {
+		var err error
+		if reportOnly, err = strconv.ParseBool(v); err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("invalid reportOnly %q", v))
+			return
+		}
+	}
+	result, err := syncer.Run(r.Context(), reportOnly)
+	if err != nil {
+		respondError(w, http.StatusBadGateway, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
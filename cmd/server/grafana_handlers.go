@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/grafana"
+)
+
+// GrafanaHealth answers the datasource's connection test.
+func GrafanaHealth(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// SearchGrafanaMetrics lists the metrics containing the body's "target", as
+// the simple JSON datasource asks for them.
+func SearchGrafanaMetrics(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Target string `json:"target"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	names := grafana.Metrics(devices, req.Target)
+	if names == nil {
+		names = []string{}
+	}
+	respondJSON(w, http.StatusOK, names)
+}
+
+// ListGrafanaMetrics lists the metrics as label and value pairs, as the JSON
+// datasource asks for them.
+func ListGrafanaMetrics(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Metric string `json:"metric"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	options := []grafana.MetricOption{}
+	for _, name := range grafana.Metrics(devices, req.Metric) {
+		options = append(options, grafana.MetricOption{Label: name, Value: name})
+	}
+	respondJSON(w, http.StatusOK, options)
+}
+
+// QueryGrafana answers the targets of a datasource query with time series
+// and tables.
+func QueryGrafana(w http.ResponseWriter, r *http.Request) {
+	var req grafana.QueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	snapshots, err := storage.LoadAllDiscoverySnapshots(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load snapshots: %w", err))
+		return
+	}
+	results, err := grafana.Query(&req, &grafana.Source{Devices: devices, Snapshots: snapshots})
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, results)
+}
+
+// GrafanaAnnotations answers annotation queries, which the inventory has
+// none of, so that dashboards that ask do not show errors.
+func GrafanaAnnotations(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, []interface{}{})
+}
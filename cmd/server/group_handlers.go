@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/groups"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/group"
+)
+
+// GetGroupMembers returns the devices the group with the given UID or name
+// covers. Retired devices are left out unless "includeRetired=true".
+func GetGroupMembers(w http.ResponseWriter, r *http.Request) {
+	g, err := findGroup(r, chi.URLParam(r, "uid"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if g == nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %s", chi.URLParam(r, "uid")))
+		return
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, groups.Resolve(g, filterRetiredDevices(r, devices)))
+}
+
+// scopeToGroup narrows devices to the members of the group named by the
+// optional "group" query parameter (a UID or name). When the group does not
+// exist it writes a 400 response and returns false.
+func scopeToGroup(w http.ResponseWriter, r *http.Request, devices []*device.Device) ([]*device.Device, bool) {
+	id := r.URL.Query().Get("group")
+	if id == "" {
+		return devices, true
+	}
+	g, err := findGroup(r, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	if g == nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("unknown group %q", id))
+		return nil, false
+	}
+	members, _ := groups.Members(g, devices)
+	return members, true
+}
+
+// findGroup loads the group whose UID or name is id, or nil.
+func findGroup(r *http.Request, id string) (*group.Group, error) {
+	all, err := storage.LoadAllGroups(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load groups: %w", err)
+	}
+	return groups.Find(all, id), nil
+}
@@ -0,0 +1,443 @@
+// Code generated by Fabrica dev. DO NOT EDIT.
+// Template: server/handlers.go.tmpl
+// Generated: 2025-11-17T12:46:44-08:00
+//
+// # Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+//
+// This file contains REST API handlers for Group resources.
+//
+// To modify this code:
+//  1. Edit the template file: pkg/codegen/templates/handlers.go.tmpl
+//  2. Run 'make dev' to regenerate
+//  3. Do NOT edit this file directly - changes will be lost
+//
+// Generated handlers provide:
+//   - GET /groups (list all groups)
+//   - GET /groups/{uid} (get specific Group)
+//   - POST /groups (create new Group)
+//   - PUT /groups/{uid} (update Group spec)
+//   - PATCH /groups/{uid} (patch Group spec)
+//   - DELETE /groups/{uid} (delete Group)
+//   - PUT /groups/{uid}/status (update Group status)
+//   - PATCH /groups/{uid}/status (patch Group status)
+//
+// Authorization: Add custom middleware for authentication/authorization
+// Storage: Uses storage.LoadGroup*/SaveGroup*/DeleteGroup*
+// Version Support: Available (see version context in handlers)
+//
+// To enable full version conversion for this resource:
+//  1. Create v2beta1 package: pkg/resources/group/v2beta1/
+//  2. Implement converter: v2beta1/converter.go
+//  3. Add version-aware storage: storage.LoadGroupWithVersion()
+//  4. Register versions in cmd/server/main.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/patch"
+	"github.com/openchami/fabrica/pkg/resource"
+	"github.com/openchami/fabrica/pkg/validation"
+	"github.com/openchami/fabrica/pkg/versioning"
+)
+
+// GetGroups returns all Group resources
+func GetGroups(w http.ResponseWriter, r *http.Request) {
+	// Authorization: Add custom middleware in routes.go or implement checks here
+	// Example: if !authorized(r) { respondError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized")); return }
+
+	groups, err := storage.LoadAllGroups(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load groups: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, groups)
+}
+
+// GetGroup returns a specific Group resource by UID
+func GetGroup(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Group UID is required"))
+		return
+	}
+
+	// Version context available here for version-aware operations
+	// versionCtx := versioning.GetVersionContext(r.Context())
+	// Requested version: versionCtx.ServeVersion
+	// To enable: replace storage.LoadGroup() with version-aware function
+
+	// Authorization: Add custom middleware in routes.go or implement checks here
+	// Example: if !authorized(r) { respondError(w, http.StatusUnauthorized, fmt.Errorf("unauthorized")); return }
+
+	group, err := storage.LoadGroup(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, group)
+}
+
+// CreateGroup creates a new Group resource
+func CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req CreateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// Get version context from request
+	versionCtx := versioning.GetVersionContext(r.Context())
+
+	uid, err := resource.GenerateUIDForResource("Group")
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to generate UID: %w", err))
+		return
+	}
+
+	group := &group.Group{
+		Resource: resource.Resource{
+			APIVersion:    versionCtx.GroupVersion,
+			Kind:          "Group",
+			SchemaVersion: versionCtx.ServeVersion,
+		},
+		Spec: req.GroupSpec,
+	}
+
+	group.Metadata.Initialize(req.Name, uid)
+
+	// Set timestamps
+	now := time.Now()
+	group.Metadata.CreatedAt = now
+	group.Metadata.UpdatedAt = now
+
+	// Set labels and annotations
+	for k, v := range req.Labels {
+		group.SetLabel(k, v)
+	}
+	for k, v := range req.Annotations {
+		group.SetAnnotation(k, v)
+	}
+
+	// Layer 2: Fabrica struct tag validation
+	if err := validation.ValidateResource(group); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+
+	// Layer 3: Custom business logic validation
+	if err := validation.ValidateWithContext(r.Context(), group); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("validation failed: %w", err))
+		return
+	}
+
+	// Set initial status
+	// This assumes the generator passes an 'IsReconcilable' boolean
+	// to this template, and that the resource has a .Status.Phase field.
+
+	// Save (Layer 1: Ent validation happens automatically if using Ent storage)
+	if err := storage.SaveGroup(r.Context(), group); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Group: %w", err))
+		return
+	}
+
+	// Publish resource created event
+	if err := events.PublishResourceCreated(r.Context(), "Group", group.GetUID(), group.GetName(), group); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource created event for Group %s: %v\n", group.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusCreated, group)
+}
+
+// UpdateGroup updates the spec of an existing Group resource
+// NOTE: This endpoint ONLY updates the spec. Use PUT //groups/{uid}/status to update status.
+func UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Group UID is required"))
+		return
+	}
+
+	group, err := storage.LoadGroup(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %w", err))
+		return
+	}
+
+	var req UpdateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	// Apply updates
+	if req.Name != "" {
+		group.SetName(req.Name)
+	}
+
+	// Update spec fields ONLY - status should use /status subresource
+	group.Spec = req.GroupSpec
+
+	// Update labels and annotations
+	for k, v := range req.Labels {
+		group.SetLabel(k, v)
+	}
+	for k, v := range req.Annotations {
+		group.SetAnnotation(k, v)
+	}
+
+	group.Touch()
+
+	if err := storage.SaveGroup(r.Context(), group); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Group: %w", err))
+		return
+	}
+
+	// Publish resource updated event
+	updateMetadata := map[string]interface{}{
+		"updatedAt": group.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Group", group.GetUID(), group.GetName(), group, updateMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource updated event for Group %s: %v\n", group.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, group)
+}
+
+// PatchGroup patches an existing Group resource spec using JSON Merge Patch, JSON Patch, or Shorthand Patch
+// Only the spec portion of the resource can be patched - metadata and status are API-managed
+func PatchGroup(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Group UID is required"))
+		return
+	}
+
+	group, err := storage.LoadGroup(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %w", err))
+		return
+	}
+
+	// Read patch document
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+
+	// Marshal current spec to JSON for patching (only allow spec modifications)
+	currentSpecJSON, err := json.Marshal(group.Spec)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current spec: %w", err))
+		return
+	}
+
+	// Detect patch type from Content-Type header
+	contentType := r.Header.Get("Content-Type")
+	patchType := patch.DetectPatchType(contentType)
+
+	// Apply patch to spec only
+	patchResult, err := patch.ApplyPatchWithOptions(currentSpecJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: true,
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to spec: %w", err))
+		return
+	}
+
+	// Unmarshal the patched result back to the spec
+	if err := json.Unmarshal(patchResult.Updated, &group.Spec); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to unmarshal patched spec: %w", err))
+		return
+	}
+
+	// Touch to update metadata
+	group.Touch()
+
+	// Save the patched resource
+	if err := storage.SaveGroup(r.Context(), group); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched Group: %w", err))
+		return
+	}
+
+	// Publish resource patched event
+	patchMetadata := map[string]interface{}{
+		"patchType": patchType,
+		"updatedAt": group.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourcePatched(r.Context(), "Group", group.GetUID(), group.GetName(), group, patchMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource patched event for Group %s: %v\n", group.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, group)
+}
+
+// UpdateGroupStatus updates only the status of a Group resource
+// This endpoint is intended for controllers, reconcilers, and monitoring systems.
+// It does not modify the spec or metadata (except updatedAt timestamp).
+//
+// Authorization: Requires 'update_status' permission (separate from 'update' permission)
+// Events: Publishes resource updated event with updateType: "status"
+func UpdateGroupStatus(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Group UID is required"))
+		return
+	}
+
+	// Authorization: Add custom middleware for status update authorization
+	// Status updates can have different permissions than spec updates
+
+	res, err := storage.LoadGroup(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %w", err))
+		return
+	}
+
+	var statusUpdate group.GroupStatus
+	if err := json.NewDecoder(r.Body).Decode(&statusUpdate); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid status body: %w", err))
+		return
+	}
+
+	// Preserve spec - only update status
+	res.Status = statusUpdate
+	res.Touch()
+
+	if err := storage.SaveGroup(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save Group status: %w", err))
+		return
+	}
+
+	// Publish status update event
+	statusMetadata := map[string]interface{}{
+		"updatedAt":  res.Metadata.UpdatedAt,
+		"updateType": "status",
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Group", res.GetUID(), res.GetName(), res, statusMetadata); err != nil {
+		// Log but don't fail - events are non-critical
+		fmt.Printf("Warning: Failed to publish status update event for Group %s: %v\n", res.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
+
+// PatchGroupStatus patches only the status of a Group resource
+// Supports JSON Merge Patch, JSON Patch, and Shorthand Patch formats.
+// Only modifies status fields - spec and metadata are preserved.
+func PatchGroupStatus(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Group UID is required"))
+		return
+	}
+
+	// Authorization: Add custom middleware for status patch authorization
+	// Status patches can have different permissions than spec patches
+
+	res, err := storage.LoadGroup(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %w", err))
+		return
+	}
+
+	patchData, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read patch data: %w", err))
+		return
+	}
+
+	// Marshal current status for patching
+	currentStatusJSON, err := json.Marshal(res.Status)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal current status: %w", err))
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	patchType := patch.DetectPatchType(contentType)
+
+	patchResult, err := patch.ApplyPatchWithOptions(currentStatusJSON, patchData, patchType, patch.PatchOptions{
+		AllowAddFields:    true,
+		AllowRemoveFields: false, // Don't allow removing status fields
+	})
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Errorf("failed to apply patch to status: %w", err))
+		return
+	}
+
+	// Unmarshal patched status back
+	if err := json.Unmarshal(patchResult.Updated, &res.Status); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to unmarshal patched status: %w", err))
+		return
+	}
+
+	res.Touch()
+
+	if err := storage.SaveGroup(r.Context(), res); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save patched Group status: %w", err))
+		return
+	}
+
+	// Publish status patch event
+	patchMetadata := map[string]interface{}{
+		"patchType":  patchType,
+		"updatedAt":  res.Metadata.UpdatedAt,
+		"updateType": "status",
+	}
+	if err := events.PublishResourcePatched(r.Context(), "Group", res.GetUID(), res.GetName(), res, patchMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish status patch event for Group %s: %v\n", res.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
+
+// DeleteGroup deletes a Group resource
+func DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	if uid == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("Group UID is required"))
+		return
+	}
+
+	// Load resource before deletion for event publishing
+	group, err := storage.LoadGroup(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Group not found: %w", err))
+		return
+	}
+
+	if err := storage.DeleteGroup(r.Context(), uid); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to delete Group: %w", err))
+		return
+	}
+
+	// Publish resource deleted event
+	deleteMetadata := map[string]interface{}{
+		"deletedAt": time.Now(),
+	}
+	if err := events.PublishResourceDeleted(r.Context(), "Group", group.GetUID(), group.GetName(), deleteMetadata); err != nil {
+		// Log the error but don't fail the request - events are non-critical
+		fmt.Printf("Warning: Failed to publish resource deleted event for Group %s: %v\n", group.GetUID(), err)
+	}
+
+	respondJSON(w, http.StatusOK, &DeleteResponse{
+		Message: "Group deleted successfully",
+		UID:     uid,
+	})
+}
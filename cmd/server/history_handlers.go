@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/history"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// GetInventoryAsOf reconstructs the inventory at the time in the "at" query
+// parameter from the stored snapshots. "at" is an RFC 3339 timestamp or a date
+// (YYYY-MM-DD), which means the end of that day in UTC. "node" (a device UID
+// or serial number) limits the result to a node and its components, and
+// "deviceType" to one type.
+func GetInventoryAsOf(w http.ResponseWriter, r *http.Request) {
+	at, err := parseAsOf(r.URL.Query().Get("at"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+	q := history.Query{At: at, DeviceType: r.URL.Query().Get("deviceType")}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	currentByURI := make(map[string]string, len(devices))
+	for _, d := range devices {
+		var uri string
+		if d.Spec.GetProperty("redfish_uri", &uri) {
+			currentByURI[uri] = d.GetUID()
+		}
+	}
+	if node := r.URL.Query().Get("node"); node != "" {
+		q.NodeSerial = node
+		for _, d := range devices {
+			if d.GetUID() == node {
+				q.NodeSerial = d.Spec.SerialNumber
+				break
+			}
+		}
+	}
+
+	snapshots, err := storage.LoadAllDiscoverySnapshots(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load snapshots: %w", err))
+		return
+	}
+	inventory, err := history.AsOf(snapshots, q, currentByURI)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, inventory)
+}
+
+// parseAsOf accepts an RFC 3339 timestamp or a date, which selects the end of
+// that day in UTC.
+func parseAsOf(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, fmt.Errorf("the at query parameter is required")
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid at %q: use an RFC 3339 timestamp or YYYY-MM-DD", raw)
+	}
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// GetDiscoverySnapshotDiff compares the device lists of two stored snapshots,
+// {uid} before {other}, whether or not they have been reconciled.
+func GetDiscoverySnapshotDiff(w http.ResponseWriter, r *http.Request) {
+	from, err := storage.LoadDiscoverySnapshot(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("DiscoverySnapshot not found: %w", err))
+		return
+	}
+	to, err := storage.LoadDiscoverySnapshot(r.Context(), chi.URLParam(r, "other"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("DiscoverySnapshot not found: %w", err))
+		return
+	}
+	diff, err := history.Diff(from, to)
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, diff)
+}
+
+// GetDiscoverySnapshotDevices lists the devices reconciling snapshot {uid}
+// created, updated and retired, as they are now.
+func GetDiscoverySnapshotDevices(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := storage.LoadDiscoverySnapshot(r.Context(), chi.URLParam(r, "uid"))
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("DiscoverySnapshot not found: %w", err))
+		return
+	}
+	result, err := history.Produced(snapshot, func(uid string) (*device.Device, error) {
+		dev, err := storage.LoadDevice(r.Context(), uid)
+		if errors.Is(err, fabricaStorage.ErrNotFound) {
+			return nil, nil
+		}
+		return dev, err
+	})
+	if errors.Is(err, history.ErrNotReconciled) {
+		respondError(w, http.StatusConflict, fmt.Errorf("DiscoverySnapshot %s has not been reconciled (phase %q)", snapshot.GetUID(), snapshot.Status.Phase))
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load the snapshot's devices: %w", err))
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
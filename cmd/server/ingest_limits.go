@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ingestLimiter protects snapshot creation from a misconfigured collector
+// posting in a tight loop. Each client (by IP address) gets a token bucket of
+// rate snapshots per second, holding up to burst; a client with an empty
+// bucket gets 429 Too Many Requests and a Retry-After. Snapshots larger than
+// maxBytes get 413 Request Entity Too Large.
+//
+// Clients are told apart by the address of the connection; the forwarded
+// address is used only for connections from trustedProxies, as anyone can
+// send X-Forwarded-For.
+type ingestLimiter struct {
+	rate           float64
+	burst          float64
+	maxBytes       int64
+	trustedProxies []netip.Prefix
+
+	mu      sync.Mutex
+	clients map[string]*tokenBucket
+
+	throttled atomic.Uint64
+	oversized atomic.Uint64
+}
+
+// tokenBucket is one client's allowance.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// maxIngestClients bounds the buckets kept; full buckets are dropped beyond it.
+const maxIngestClients = 10000
+
+// ingest is the server's snapshot ingest limiter, set up in runServer.
+var ingest = &ingestLimiter{}
+
+// newIngestLimiter returns a limiter allowing perMinute snapshots per client
+// with bursts of burst, and snapshots of up to maxBytes. Zero disables a limit.
+func newIngestLimiter(perMinute, burst int, maxBytes int64, trustedProxies []netip.Prefix) *ingestLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &ingestLimiter{
+		rate:           float64(perMinute) / 60,
+		burst:          float64(burst),
+		maxBytes:       maxBytes,
+		trustedProxies: trustedProxies,
+		clients:        make(map[string]*tokenBucket),
+	}
+}
+
+// parseTrustedProxies parses a comma-separated list of proxy addresses and
+// networks.
+func parseTrustedProxies(list string) ([]netip.Prefix, error) {
+	var proxies []netip.Prefix
+	for _, item := range splitList(list) {
+		if addr, err := netip.ParseAddr(item); err == nil {
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: not an address or network", item)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+// allow takes a token from the client's bucket. When the bucket is empty it
+// returns how long until the next token.
+func (l *ingestLimiter) allow(client string, now time.Time) (time.Duration, bool) {
+	if l.rate <= 0 {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.clients[client]
+	if !ok {
+		if len(l.clients) >= maxIngestClients {
+			l.sweep(now)
+		}
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.clients[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second)), false
+}
+
+// sweep drops the buckets that have refilled; their clients start over with
+// a full bucket anyway.
+func (l *ingestLimiter) sweep(now time.Time) {
+	for client, b := range l.clients {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// peerAddressKey is the context key of the address a request came from.
+type peerAddressKey struct{}
+
+// recordPeerAddress keeps the address of the connection, before RealIP
+// replaces RemoteAddr with the forwarded address.
+func recordPeerAddress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), peerAddressKey{}, r.RemoteAddr)))
+	})
+}
+
+// hostOf returns the host of a host:port address.
+func hostOf(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
+
+// clientAddress identifies the client of a request by IP address: the
+// connection's, or the forwarded one RealIP put in RemoteAddr when the
+// connection is from a trusted proxy.
+func (l *ingestLimiter) clientAddress(r *http.Request) string {
+	peer, ok := r.Context().Value(peerAddressKey{}).(string)
+	if !ok {
+		return hostOf(r.RemoteAddr)
+	}
+	peer = hostOf(peer)
+	if addr, err := netip.ParseAddr(peer); err == nil {
+		for _, proxy := range l.trustedProxies {
+			if proxy.Contains(addr.Unmap()) {
+				return hostOf(r.RemoteAddr)
+			}
+		}
+	}
+	return peer
+}
+
+// limitIngest applies the ingest limits to snapshot creation.
+func limitIngest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || strings.TrimSuffix(r.URL.Path, "/") != "/discoverysnapshots" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		l := ingest
+
+		if l.maxBytes > 0 && r.ContentLength > l.maxBytes {
+			l.rejectOversized(w)
+			return
+		}
+		client := l.clientAddress(r)
+		if wait, ok := l.allow(client, time.Now()); !ok {
+			l.throttled.Add(1)
+			seconds := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", fmt.Sprint(seconds))
+			respondError(w, http.StatusTooManyRequests, fmt.Errorf("snapshot rate limit exceeded for %s; retry after %ds", client, seconds))
+			return
+		}
+
+		// Chunked bodies have no length; read up to the limit to find out
+		if l.maxBytes > 0 && r.ContentLength < 0 {
+			body, err := io.ReadAll(io.LimitReader(r.Body, l.maxBytes+1))
+			r.Body.Close()
+			if err != nil {
+				respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+				return
+			}
+			if int64(len(body)) > l.maxBytes {
+				l.rejectOversized(w)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rejectOversized answers a snapshot over the size quota.
+func (l *ingestLimiter) rejectOversized(w http.ResponseWriter) {
+	l.oversized.Add(1)
+	respondError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("snapshot exceeds the %d byte limit", l.maxBytes))
+}
+
+// MarshalMetrics renders the rejection counters in the Prometheus text format.
+func (l *ingestLimiter) MarshalMetrics() []byte {
+	var out []byte
+	out = append(out, "# HELP inventory_snapshot_rejected_total Snapshot creations rejected by the ingest limits.\n"...)
+	out = append(out, "# TYPE inventory_snapshot_rejected_total counter\n"...)
+	out = append(out, fmt.Sprintf("inventory_snapshot_rejected_total{reason=\"rate_limit\"} %d\n", l.throttled.Load())...)
+	out = append(out, fmt.Sprintf("inventory_snapshot_rejected_total{reason=\"too_large\"} %d\n", l.oversized.Load())...)
+	return out
+}
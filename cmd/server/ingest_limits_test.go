@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// TestIngestClientAddress keys the snapshot rate limit on the connection's
+// address, and on the forwarded one only behind a trusted proxy.
+func TestIngestClientAddress(t *testing.T) {
+	proxies, err := parseTrustedProxies("10.0.0.1, fd00::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := newIngestLimiter(60, 1, 0, proxies)
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded string
+		want      string
+	}{
+		{name: "direct", peer: "192.0.2.7:5000", want: "192.0.2.7"},
+		{name: "forwarded by a client", peer: "192.0.2.7:5000", forwarded: "198.51.100.1", want: "192.0.2.7"},
+		{name: "forwarded by a trusted proxy", peer: "10.0.0.1:5000", forwarded: "198.51.100.1", want: "198.51.100.1"},
+		{name: "forwarded by a trusted IPv6 proxy", peer: "[fd00::5]:5000", forwarded: "198.51.100.1", want: "198.51.100.1"},
+		{name: "forwarded by another proxy", peer: "10.0.0.2:5000", forwarded: "198.51.100.1", want: "10.0.0.2"},
+		{name: "trusted proxy forwarding nothing", peer: "10.0.0.1:5000", want: "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := recordPeerAddress(middleware.RealIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = l.clientAddress(r)
+			})))
+			req := httptest.NewRequest(http.MethodPost, "/discoverysnapshots", nil)
+			req.RemoteAddr = tt.peer
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			if got != tt.want {
+				t.Errorf("clientAddress = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// A client varying its forwarded address shares one bucket
+	for _, forwarded := range []string{"198.51.100.1", "198.51.100.2"} {
+		req := httptest.NewRequest(http.MethodPost, "/discoverysnapshots", nil)
+		req.RemoteAddr = "192.0.2.7:5000"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		handler := recordPeerAddress(middleware.RealIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			l.allow(l.clientAddress(r), time.Now())
+		})))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if len(l.clients) != 1 {
+		t.Errorf("limiter keeps %d buckets, want 1", len(l.clients))
+	}
+
+	if _, err := parseTrustedProxies("10.0.0.0/8,proxy.example.com"); err == nil {
+		t.Error("parseTrustedProxies accepted a host name")
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/lookup"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/go-chi/chi/v5"
+)
+
+// GetDevicesByMAC returns the devices that report a MAC address and the node
+// each belongs to. Backends with a device index answer from it; otherwise the
+// inventory is scanned.
+func GetDevicesByMAC(w http.ResponseWriter, r *http.Request) {
+	mac, err := lookup.NormalizeMAC(chi.URLParam(r, "mac"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	holders, getParent, err := findDevices(r.Context(),
+		func(index storage.DeviceIndex) ([]*device.Device, error) {
+			return index.FindDevicesByMAC(r.Context(), mac)
+		},
+		func(d *device.Device) bool { return lookup.HasMAC(d, mac) })
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to look up MAC %s: %w", mac, err))
+		return
+	}
+	if len(holders) == 0 {
+		respondError(w, http.StatusNotFound, fmt.Errorf("no device reports MAC %s", mac))
+		return
+	}
+
+	result := &lookup.MACResult{MAC: mac, Matches: make([]lookup.MACMatch, 0, len(holders))}
+	for _, d := range holders {
+		match := lookup.MACMatch{Device: lookup.NewDeviceRef(d)}
+		if node := lookup.OwningNode(d, getParent); node != nil {
+			ref := lookup.NewDeviceRef(node)
+			match.Node = &ref
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// GetDevicesBySerial returns the devices with a serial number, each with its
+// parents up to the root and its location. It is meant for looking up a part
+// from a failure alert, so it is served from the device index when the
+// backend has one.
+func GetDevicesBySerial(w http.ResponseWriter, r *http.Request) {
+	// Serial numbers may contain escaped characters such as "/".
+	serial, err := url.PathUnescape(chi.URLParam(r, "sn"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid serial number: %w", err))
+		return
+	}
+	serial = strings.TrimSpace(serial)
+	if serial == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("serial number is required"))
+		return
+	}
+
+	matches, getParent, err := findDevices(r.Context(),
+		func(index storage.DeviceIndex) ([]*device.Device, error) {
+			return index.FindDevicesBySerial(r.Context(), serial)
+		},
+		func(d *device.Device) bool { return d.Spec.SerialNumber == serial })
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to look up serial %s: %w", serial, err))
+		return
+	}
+	if len(matches) == 0 {
+		respondError(w, http.StatusNotFound, fmt.Errorf("no device has serial number %s", serial))
+		return
+	}
+
+	result := &lookup.SerialResult{SerialNumber: serial, Matches: make([]lookup.SerialMatch, 0, len(matches))}
+	for _, d := range matches {
+		result.Matches = append(result.Matches, lookup.NewSerialMatch(d, getParent))
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// findDevices returns the matching devices and a function resolving their
+// ancestors. Backends with a device index are queried through byIndex and
+// parents are loaded one by one; otherwise the inventory is scanned with
+// matches.
+func findDevices(ctx context.Context, byIndex func(storage.DeviceIndex) ([]*device.Device, error), matches func(*device.Device) bool) ([]*device.Device, func(uid string) *device.Device, error) {
+	if index, ok := storage.Index(); ok {
+		found, err := byIndex(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		getParent := func(uid string) *device.Device {
+			parent, err := storage.LoadDevice(ctx, uid)
+			if err != nil {
+				return nil
+			}
+			return parent
+		}
+		return found, getParent, nil
+	}
+
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	byUID := make(map[string]*device.Device, len(devices))
+	var found []*device.Device
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+		if matches(d) {
+			found = append(found, d)
+		}
+	}
+	return found, func(uid string) *device.Device { return byUID[uid] }, nil
+}
@@ -16,10 +16,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -39,7 +41,10 @@ import (
 
 	
 	"github.com/openchami/fabrica/pkg/reconcile"
+	"github.com/example/inventory-v3/pkg/changecontrol"
 	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/example/inventory-v3/pkg/naming"
+	"github.com/example/inventory-v3/pkg/reports"
 	
 )
 
@@ -56,6 +61,11 @@ type Config struct {
 	// Storage Configuration
 	
 	DataDir string `mapstructure:"data_dir"`
+
+	// StorageType selects the backend: "file" (default) or "postgres".
+	StorageType string `mapstructure:"storage_type"`
+	// DatabaseURL is the PostgreSQL connection string for the postgres backend.
+	DatabaseURL string `mapstructure:"database_url"`
 	
 	
 
@@ -65,6 +75,109 @@ type Config struct {
 	// Reconciliation Configuration
 	ReconcileEnabled bool `mapstructure:"reconcile_enabled"`
 	ReconcileWorkers int  `mapstructure:"reconcile_workers"`
+	// DeviceNaming is the strategy devices are named by: uri, xname, serial,
+	// or template (with DeviceNameTemplate).
+	DeviceNaming       string `mapstructure:"device_naming"`
+	DeviceNameTemplate string `mapstructure:"device_name_template"`
+	// PropertySchema is the path of a JSON file of device property
+	// definitions registered besides the built-in ones.
+	PropertySchema string `mapstructure:"property_schema"`
+	// UnitRules is the path of a JSON file of rules telling the units of
+	// property values their sources report without one.
+	UnitRules string `mapstructure:"unit_rules"`
+	// PropertyPolicy is the path of a JSON file of the device properties
+	// the site allows, denies or hashes before they are stored.
+	PropertyPolicy string `mapstructure:"property_policy"`
+
+	// Consistency Check Configuration
+	ConsistencyInterval int `mapstructure:"consistency_interval"`
+	SnapshotMaxAgeDays  int `mapstructure:"snapshot_max_age_days"`
+
+	// CablingInterval is the seconds between cabling inference runs (0
+	// disables them).
+	CablingInterval int `mapstructure:"cabling_interval"`
+
+	// GitOps Configuration
+	// GitOpsRepo is the git repository of declarative resources to keep the
+	// inventory in step with (empty disables GitOps sync).
+	GitOpsRepo   string `mapstructure:"gitops_repo"`
+	GitOpsBranch string `mapstructure:"gitops_branch"`
+	// GitOpsPath is the directory of the documents in the repository.
+	GitOpsPath string `mapstructure:"gitops_path"`
+	// GitOpsInterval is the seconds between syncs (0 syncs only on request).
+	GitOpsInterval   int  `mapstructure:"gitops_interval"`
+	GitOpsPrune      bool `mapstructure:"gitops_prune"`
+	GitOpsReportOnly bool `mapstructure:"gitops_report_only"`
+
+	// Scheduled Report Configuration
+	// ReportInterval is the hours between scheduled report runs (0 disables them).
+	ReportInterval int `mapstructure:"report_interval"`
+	// ScheduledReports is a comma-separated list of reports to send.
+	ScheduledReports string `mapstructure:"scheduled_reports"`
+	MissingAfterDays int    `mapstructure:"missing_after_days"`
+	// FirmwareBaseline is the path of a JSON file of firmware rules.
+	FirmwareBaseline   string `mapstructure:"firmware_baseline"`
+	ReportSMTPAddr     string `mapstructure:"report_smtp_addr"`
+	ReportSMTPFrom     string `mapstructure:"report_smtp_from"`
+	ReportSMTPTo       string `mapstructure:"report_smtp_to"`
+	ReportSMTPUsername string `mapstructure:"report_smtp_username"`
+	ReportSMTPPassword string `mapstructure:"report_smtp_password"`
+	ReportWebhookURL   string `mapstructure:"report_webhook_url"`
+	// ReportS3URL is a bucket URL with an optional key prefix. Credentials
+	// come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+	ReportS3URL    string `mapstructure:"report_s3_url"`
+	ReportS3Region string `mapstructure:"report_s3_region"`
+
+	// Alerting Configuration
+	// AlertInterval is the seconds between alert rule evaluations (0
+	// evaluates only on request).
+	AlertInterval int `mapstructure:"alert_interval"`
+	// AlertRules is the path of a JSON file of alert rules (empty uses the
+	// default rules).
+	AlertRules      string `mapstructure:"alert_rules"`
+	AlertWebhookURL string `mapstructure:"alert_webhook_url"`
+
+	// Fleet Statistics
+	// FleetStatsInterval is the minutes between recordings of the day's
+	// fleet statistics (0 records only on request).
+	FleetStatsInterval int `mapstructure:"fleet_stats_interval"`
+
+	// Outbound TLS Policy, applied to report deliveries and alert webhooks
+	TLSMinVersion   string `mapstructure:"tls_min_version"`
+	TLSCipherSuites string `mapstructure:"tls_cipher_suites"`
+	TLSFIPS         bool   `mapstructure:"tls_fips"`
+
+	// Snapshot Signing
+	// SnapshotTrustedKeys is a PEM file or directory of the public keys (or
+	// certificates) collectors sign snapshots with.
+	SnapshotTrustedKeys    string `mapstructure:"snapshot_trusted_keys"`
+	RequireSignedSnapshots bool   `mapstructure:"require_signed_snapshots"`
+
+	// Snapshot Ingest Limits
+	// SnapshotRateLimit is the snapshots each client may create per minute
+	// (0 disables the limit), in bursts of up to SnapshotRateBurst.
+	SnapshotRateLimit int `mapstructure:"snapshot_rate_limit"`
+	SnapshotRateBurst int `mapstructure:"snapshot_rate_burst"`
+	// SnapshotMaxMB caps the size of a snapshot request (0 disables the cap).
+	SnapshotMaxMB int `mapstructure:"snapshot_max_mb"`
+	// SnapshotTrustedProxies is a comma-separated list of the proxies whose
+	// X-Forwarded-For and X-Real-IP name the client the rate limit applies to.
+	SnapshotTrustedProxies string `mapstructure:"snapshot_trusted_proxies"`
+	// SnapshotWorkers is how many snapshots are reconciled at once; one of
+	// them is kept for interactive snapshots.
+	SnapshotWorkers int `mapstructure:"snapshot_workers"`
+	// ChangeGuard* hold a snapshot for approval when it would flag missing,
+	// retire or replace more than the percentage of its BMC's or the fleet's
+	// devices (0 disables the check). BMCs and fleets with fewer than
+	// ChangeGuardMinDevices devices are not checked.
+	ChangeGuardBMCPercent   float64 `mapstructure:"change_guard_bmc_percent"`
+	ChangeGuardFleetPercent float64 `mapstructure:"change_guard_fleet_percent"`
+	ChangeGuardMinDevices   int     `mapstructure:"change_guard_min_devices"`
+	// ApproveRetireOver holds prunes and decommissions of more devices as
+	// PendingChanges for approval (0 never does); ApproveSerialRewrites
+	// holds the new serial numbers snapshots report for known devices.
+	ApproveRetireOver     int  `mapstructure:"approve_retire_over"`
+	ApproveSerialRewrites bool `mapstructure:"approve_serial_rewrites"`
 	
 
 	// Feature Flags
@@ -83,12 +196,39 @@ func DefaultConfig() *Config {
 		
 		
 		DataDir:      "./data",
+		StorageType:  "file",
 		
 		
 		
 		
 		ReconcileEnabled: true,
 		ReconcileWorkers: 5,
+		DeviceNaming:     naming.StrategyURI,
+
+		ConsistencyInterval: 300,
+		SnapshotMaxAgeDays:  7,
+
+		CablingInterval: 900,
+
+		GitOpsInterval: 300,
+
+		SnapshotRateLimit: 300,
+		SnapshotRateBurst: 100,
+		SnapshotMaxMB:     32,
+		SnapshotWorkers:   reconcilers.DefaultSnapshotWorkers,
+
+		ChangeGuardBMCPercent:   50,
+		ChangeGuardFleetPercent: 25,
+		ChangeGuardMinDevices:   20,
+
+		ReportInterval:   168,
+		ScheduledReports: strings.Join(reports.ScheduledReports, ","),
+		MissingAfterDays: 7,
+		ReportS3Region:   "us-east-1",
+
+		AlertInterval: 300,
+
+		FleetStatsInterval: 60,
 		
 		
 		Debug: false,
@@ -137,15 +277,65 @@ func init() {
 	
 	
 	serveCmd.Flags().String("data-dir", "./data", "Directory for file storage")
-	
-	
-
-	
-
-	
-
-	// Bind flags to viper
-	viper.BindPFlags(serveCmd.Flags())
+	serveCmd.Flags().String("storage-type", "file", "Storage backend: file or postgres")
+	serveCmd.Flags().String("database-url", "", "PostgreSQL connection string (postgres storage)")
+	serveCmd.Flags().Int("consistency-interval", 300, "Seconds between fleet consistency checks (0 disables the periodic check)")
+	serveCmd.Flags().Int("snapshot-max-age-days", 7, "Days a BMC endpoint may go without a successful snapshot")
+	serveCmd.Flags().Int("cabling-interval", 900, "Seconds between switch cabling inference runs (0 disables the periodic run)")
+	serveCmd.Flags().String("gitops-repo", "", "Git repository of declarative resources to keep the inventory in step with")
+	serveCmd.Flags().String("gitops-branch", "", "Branch of the GitOps repository (default: its default branch)")
+	serveCmd.Flags().String("gitops-path", "", "Directory of the documents in the GitOps repository (default: all of it)")
+	serveCmd.Flags().Int("gitops-interval", 300, "Seconds between GitOps syncs (0 syncs only on request)")
+	serveCmd.Flags().Bool("gitops-prune", false, "Delete resources whose documents were removed from the GitOps repository")
+	serveCmd.Flags().Bool("gitops-report-only", false, "Report drift from the GitOps repository without correcting it")
+	serveCmd.Flags().Int("report-interval", 168, "Hours between scheduled report runs (0 disables scheduled reports)")
+	serveCmd.Flags().String("scheduled-reports", strings.Join(reports.ScheduledReports, ","), "Comma-separated reports to schedule")
+	serveCmd.Flags().Int("missing-after-days", 7, "Days a device may go unreported before it is listed as missing")
+	serveCmd.Flags().String("firmware-baseline", "", "JSON file of expected firmware versions")
+	serveCmd.Flags().String("report-smtp-addr", "", "SMTP server (host:port) for emailing scheduled reports")
+	serveCmd.Flags().String("report-smtp-from", "", "Sender address for report emails")
+	serveCmd.Flags().String("report-smtp-to", "", "Comma-separated recipients of report emails")
+	serveCmd.Flags().String("report-smtp-username", "", "SMTP username")
+	serveCmd.Flags().String("report-smtp-password", "", "SMTP password")
+	serveCmd.Flags().String("report-webhook-url", "", "URL to POST scheduled reports to as JSON")
+	serveCmd.Flags().String("report-s3-url", "", "S3 bucket URL and key prefix to write scheduled reports to")
+	serveCmd.Flags().String("report-s3-region", "us-east-1", "Region used to sign S3 requests")
+	serveCmd.Flags().Int("alert-interval", 300, "Seconds between alert rule evaluations (0 evaluates only on request)")
+	serveCmd.Flags().String("alert-rules", "", "JSON file of alert rules (default: missing devices and firmware compliance)")
+	serveCmd.Flags().String("alert-webhook-url", "", "URL to POST alerts to as JSON when they fire or resolve")
+	serveCmd.Flags().Int("fleet-stats-interval", 60, "Minutes between recordings of the day's fleet size and changes (0 records only on request)")
+	serveCmd.Flags().String("tls-min-version", "", "Oldest TLS version for outbound connections: 1.2 or 1.3 (default 1.2)")
+	serveCmd.Flags().String("tls-cipher-suites", "", "Comma-separated TLS 1.2 cipher suites to allow outbound (default: Go's secure suites)")
+	serveCmd.Flags().Bool("tls-fips", false, "Allow only FIPS 140-3 approved TLS outbound; requires GODEBUG=fips140=on")
+	serveCmd.Flags().String("snapshot-trusted-keys", "", "PEM file or directory of public keys or certificates trusted to sign snapshots")
+	serveCmd.Flags().Bool("require-signed-snapshots", false, "Reject snapshots without a valid signature from a trusted key")
+	serveCmd.Flags().Int("snapshot-rate-limit", 300, "Snapshots each client may create per minute (0 disables the limit)")
+	serveCmd.Flags().Int("snapshot-rate-burst", 100, "Snapshots each client may create in a burst")
+	serveCmd.Flags().Int("snapshot-max-mb", 32, "Largest snapshot request accepted, in MiB (0 disables the cap)")
+	serveCmd.Flags().String("snapshot-trusted-proxies", "", "Comma-separated proxy addresses or networks whose forwarded client address the snapshot rate limit applies to")
+	serveCmd.Flags().Int("snapshot-workers", reconcilers.DefaultSnapshotWorkers, "Snapshots reconciled at once; one worker is kept for interactive snapshots when there are several")
+	serveCmd.Flags().Float64("change-guard-bmc-percent", 50, "Hold snapshots that would flag missing, retire or replace more than this percentage of their BMC's devices until approved (0 disables)")
+	serveCmd.Flags().Float64("change-guard-fleet-percent", 25, "Hold snapshots that would flag missing, retire or replace more than this percentage of the fleet's devices until approved (0 disables)")
+	serveCmd.Flags().Int("change-guard-min-devices", 20, "Do not hold snapshots of BMCs, or fleets, with fewer devices")
+	serveCmd.Flags().Int("approve-retire-over", 0, "Hold prunes and decommissions of more than this many devices as pending changes until approved (0 disables)")
+	serveCmd.Flags().Bool("approve-serial-rewrites", false, "Hold new serial numbers reported for known devices as pending changes until approved")
+	serveCmd.Flags().String("device-naming", naming.StrategyURI, fmt.Sprintf("Strategy devices are named by %v", naming.Strategies))
+	serveCmd.Flags().String("device-name-template", "", "Name template for the template strategy, e.g. \"{{.ParentSerialNumber}}-{{lower .DeviceType}}-{{.Slot}}\"")
+	serveCmd.Flags().String("property-schema", "", "JSON file of device property definitions to register besides the built-in ones")
+	serveCmd.Flags().String("unit-rules", "", "JSON file of rules telling the units of property values reported without one")
+	serveCmd.Flags().String("property-policy", "", "JSON file of the device properties to allow, deny or hash before storing devices")
+	
+	
+
+	
+
+	
+
+	// Bind flags to viper. Flags are kebab-case and config keys snake_case,
+	// so each flag is bound under its config key.
+	serveCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		viper.BindPFlag(strings.ReplaceAll(f.Name, "-", "_"), f)
+	})
 	viper.BindPFlags(rootCmd.PersistentFlags())
 
 	// Add subcommands
@@ -195,13 +385,49 @@ func initConfig() {
 func runServer(cmd *cobra.Command, args []string) error {
 	log.Printf("Starting inventory-v3 server...")
 
+	tlsPolicy, err := config.TLSPolicy()
+	if err != nil {
+		return err
+	}
+	log.Printf("Outbound TLS policy: %s", tlsPolicy)
+
+	if err := setupSnapshotTrust(config); err != nil {
+		return err
+	}
+	if err := setupDeviceNaming(config); err != nil {
+		return err
+	}
+	if err := setupPropertySchema(config); err != nil {
+		return err
+	}
+	trustedProxies, err := parseTrustedProxies(config.SnapshotTrustedProxies)
+	if err != nil {
+		return err
+	}
+	ingest = newIngestLimiter(config.SnapshotRateLimit, config.SnapshotRateBurst, int64(config.SnapshotMaxMB)<<20, trustedProxies)
+	changePolicy = changecontrol.Policy{RetireOver: config.ApproveRetireOver, SerialRewrites: config.ApproveSerialRewrites}
+
 	
 	// Initialize storage backend
 	
-	if err := storage.InitFileBackend(config.DataDir); err != nil {
-	  return fmt.Errorf("failed to initialize file storage: %w", err)
+	switch config.StorageType {
+	case "", "file":
+		if err := storage.InitFileBackend(config.DataDir); err != nil {
+			return fmt.Errorf("failed to initialize file storage: %w", err)
+		}
+		log.Printf("File storage initialized in %s", config.DataDir)
+	case "postgres":
+		if config.DatabaseURL == "" {
+			return fmt.Errorf("postgres storage requires --database-url")
+		}
+		if err := storage.InitPostgresBackend(context.Background(), config.DatabaseURL); err != nil {
+			return fmt.Errorf("failed to initialize postgres storage: %w", err)
+		}
+		defer storage.Backend.Close()
+		log.Printf("PostgreSQL storage initialized")
+	default:
+		return fmt.Errorf("unknown storage type %q (valid: file, postgres)", config.StorageType)
 	}
-	log.Printf("File storage initialized in %s", config.DataDir)
 	
 	
 
@@ -263,7 +489,14 @@ func runServer(cmd *cobra.Command, args []string) error {
 		storageClient := storage.NewStorageClient()
 
 		// Register reconcilers
-		if err := reconcilers.RegisterReconcilers(controller, storageClient, eventBus); err != nil {
+		reconcilers.SetSnapshotWorkers(config.SnapshotWorkers)
+		reconcilers.SetChangeGuard(reconcilers.ChangeGuard{
+			BMCPercent:   config.ChangeGuardBMCPercent,
+			FleetPercent: config.ChangeGuardFleetPercent,
+			MinDevices:   config.ChangeGuardMinDevices,
+		})
+		reconcilers.SetChangePolicy(changePolicy)
+		if err := reconcilers.Register(controller, lockingClient{storageClient}, eventBus); err != nil {
 			log.Fatalf("Failed to register reconcilers: %v", err)
 		}
 
@@ -274,10 +507,58 @@ func runServer(cmd *cobra.Command, args []string) error {
 		defer controller.Stop()
 
 		log.Printf("Reconciliation controller started with %d workers", 5)
+		log.Printf("Snapshots reconciled by %d workers, interactive snapshots first", config.SnapshotWorkers)
 	}
 	
 	
 
+	// Start the periodic fleet consistency check
+	checker.maxAge = time.Duration(config.SnapshotMaxAgeDays) * 24 * time.Hour
+	if config.ConsistencyInterval > 0 {
+		checkCtx, stopChecks := context.WithCancel(context.Background())
+		defer stopChecks()
+		checker.Start(checkCtx, time.Duration(config.ConsistencyInterval)*time.Second)
+		log.Printf("Consistency check running every %ds", config.ConsistencyInterval)
+	}
+
+	// Start the periodic cabling inference
+	if config.CablingInterval > 0 {
+		cablingCtx, stopCabling := context.WithCancel(context.Background())
+		defer stopCabling()
+		inferrer.Start(cablingCtx, time.Duration(config.CablingInterval)*time.Second)
+		log.Printf("Cabling inference running every %ds", config.CablingInterval)
+	}
+
+	// Start the scheduled reports
+	if err := setupScheduledReports(config); err != nil {
+		log.Fatalf("Failed to configure scheduled reports: %v", err)
+	}
+	if config.ReportInterval > 0 && len(scheduler.destinations) > 0 {
+		reportCtx, stopReports := context.WithCancel(context.Background())
+		defer stopReports()
+		scheduler.Start(reportCtx, time.Duration(config.ReportInterval)*time.Hour)
+		log.Printf("Scheduled reports (%s) sent every %dh to %d destination(s)", config.ScheduledReports, config.ReportInterval, len(scheduler.destinations))
+	}
+
+	// Start the alert rule evaluation
+	if err := setupAlerting(config); err != nil {
+		log.Fatalf("Failed to configure alerting: %v", err)
+	}
+	if config.AlertInterval > 0 {
+		alertCtx, stopAlerts := context.WithCancel(context.Background())
+		defer stopAlerts()
+		evaluator.Start(alertCtx, time.Duration(config.AlertInterval)*time.Second)
+		log.Printf("Alert rules (%d) evaluated every %ds, notifying %d webhook(s)", len(evaluator.engine.Rules()), config.AlertInterval, len(evaluator.notifiers))
+	}
+
+	// Start recording the daily fleet statistics
+	if config.FleetStatsInterval > 0 {
+		statsCtx, stopStats := context.WithCancel(context.Background())
+		defer stopStats()
+		fleetRecorder.Start(statsCtx, time.Duration(config.FleetStatsInterval)*time.Minute)
+		log.Printf("Fleet statistics recorded every %dm", config.FleetStatsInterval)
+	}
+
 	// Setup router
 	r := chi.NewRouter()
 
@@ -285,7 +566,13 @@ func runServer(cmd *cobra.Command, args []string) error {
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
+	r.Use(recordPeerAddress)
 	r.Use(middleware.RealIP)
+	r.Use(conditionalRequests)
+	r.Use(limitIngest)
+	r.Use(recordRunIDs)
+	r.Use(paginateLists)
+	r.Use(normalizeDeviceUnits)
 
 	if config.Debug {
 		r.Mount("/debug", middleware.Profiler())
@@ -295,8 +582,20 @@ func runServer(cmd *cobra.Command, args []string) error {
 
 	// Register routes - generated by 'fabrica generate'
 	RegisterGeneratedRoutes(r)
+	RegisterCustomRoutes(r)
 	r.Get("/health", healthHandler)
 
+	// Start the GitOps sync
+	if err := setupGitOps(config, r); err != nil {
+		log.Fatalf("Failed to configure GitOps sync: %v", err)
+	}
+	if syncer != nil && syncer.interval > 0 {
+		gitopsCtx, stopGitOps := context.WithCancel(context.Background())
+		defer stopGitOps()
+		syncer.Start(gitopsCtx)
+		log.Printf("GitOps sync of %s running every %ds", config.GitOpsRepo, config.GitOpsInterval)
+	}
+
 	
 
 	// Create HTTP server
@@ -314,7 +613,11 @@ func runServer(cmd *cobra.Command, args []string) error {
 		log.Printf("Server starting on %s", addr)
 		
 		
-		log.Printf("Storage: file backend in %s", config.DataDir)
+		if config.StorageType == "postgres" {
+			log.Printf("Storage: postgres backend")
+		} else {
+			log.Printf("Storage: file backend in %s", config.DataDir)
+		}
 		
 		
 		
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/maintenance"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// GetActiveMaintenance returns what the maintenance windows active now, or at
+// the time in "at", cover. The collector agent skips the BMC addresses it
+// lists.
+func GetActiveMaintenance(w http.ResponseWriter, r *http.Request) {
+	at := time.Now()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("invalid at %q: use an RFC 3339 timestamp", raw))
+			return
+		}
+		at = t
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	blackout, err := loadFleetBlackout(r.Context(), devices, at)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, blackout)
+}
+
+// loadBlackout loads the maintenance windows and resolves those active at t.
+func loadBlackout(ctx context.Context, devices []*device.Device, endpoints []*bmcendpoint.BMCEndpoint, t time.Time) (*maintenance.Blackout, error) {
+	windows, err := storage.LoadAllMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load maintenance windows: %w", err)
+	}
+	return maintenance.Resolve(windows, endpoints, devices, t), nil
+}
+
+// loadFleetBlackout is loadBlackout for callers that have not loaded the
+// BMC endpoints.
+func loadFleetBlackout(ctx context.Context, devices []*device.Device, t time.Time) (*maintenance.Blackout, error) {
+	endpoints, err := storage.LoadAllBMCEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BMC endpoints: %w", err)
+	}
+	return loadBlackout(ctx, devices, endpoints, t)
+}
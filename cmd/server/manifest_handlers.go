@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// ImportManifest creates an Expected device for each row of a vendor shipping
+// manifest posted as CSV. The "name" query parameter names the manifest and
+// is required; "deviceType" is used for rows without a type (default "Node").
+// Serials already in the inventory are reported but not duplicated.
+// "dryRun=true" returns what would be created without creating it.
+func ImportManifest(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("the name query parameter is required"))
+		return
+	}
+	defaultType := r.URL.Query().Get("deviceType")
+	if defaultType == "" {
+		defaultType = "Node"
+	}
+
+	rows, err := manifest.Parse(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	bySerial := make(map[string]string, len(devices))
+	for _, d := range devices {
+		if d.Spec.SerialNumber != "" {
+			bySerial[d.Spec.SerialNumber] = d.GetUID()
+		}
+	}
+
+	result := &manifest.ImportResult{
+		Manifest: name,
+		DryRun:   r.URL.Query().Get("dryRun") == "true",
+		Rows:     len(rows),
+		Entries:  make([]manifest.Entry, 0, len(rows)),
+	}
+	for _, row := range rows {
+		spec := row.Spec(name, defaultType)
+		entry := manifest.Entry{Line: row.Line, SerialNumber: row.SerialNumber, DeviceType: spec.DeviceType}
+		if uid, ok := bySerial[row.SerialNumber]; ok {
+			entry.UID = uid
+			entry.Existing = true
+			result.Existing++
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+		if !result.DryRun {
+			d, err := createExpectedDevice(r, spec)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d (%s): %v", row.Line, row.SerialNumber, err))
+				continue
+			}
+			entry.UID = d.GetUID()
+			bySerial[row.SerialNumber] = d.GetUID()
+		} else {
+			bySerial[row.SerialNumber] = ""
+		}
+		result.Created++
+		result.Entries = append(result.Entries, entry)
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// createExpectedDevice saves a device awaiting delivery. It is named by serial
+// until discovery finds it and renames it to its redfish_uri.
+func createExpectedDevice(r *http.Request, spec device.DeviceSpec) (*device.Device, error) {
+	uid, err := resource.GenerateUIDForResource("Device")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate UID: %w", err)
+	}
+	d := &device.Device{
+		Resource: resource.Resource{
+			APIVersion:    "v1",
+			Kind:          "Device",
+			SchemaVersion: "v1",
+		},
+		Spec: spec,
+		Status: device.DeviceStatus{
+			Phase:   manifest.PhaseExpected,
+			Message: "Listed on a shipping manifest; not yet discovered.",
+		},
+	}
+	d.Metadata.Initialize("expected/"+spec.SerialNumber, uid)
+	now := time.Now()
+	d.Metadata.CreatedAt = now
+	d.Metadata.UpdatedAt = now
+
+	if err := storage.SaveDevice(r.Context(), d); err != nil {
+		return nil, err
+	}
+	if err := events.PublishResourceCreated(r.Context(), "Device", d.GetUID(), d.GetName(), d); err != nil {
+		fmt.Printf("Warning: Failed to publish resource created event for Device %s: %v\n", d.GetUID(), err)
+	}
+	return d, nil
+}
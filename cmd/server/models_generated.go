@@ -40,6 +40,20 @@ import (
 	"github.com/example/inventory-v3/pkg/resources/device"
 
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+
+	"github.com/example/inventory-v3/pkg/resources/group"
+
+	"github.com/example/inventory-v3/pkg/resources/connection"
+
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+
+	"github.com/example/inventory-v3/pkg/resources/alert"
 )
 
 // DeviceResponse represents the response for Device operations
@@ -80,6 +94,139 @@ type UpdateDiscoverySnapshotRequest struct {
 	Annotations                             map[string]string `json:"annotations,omitempty"`
 }
 
+// BMCEndpointResponse represents the response for BMCEndpoint operations
+type BMCEndpointResponse = bmcendpoint.BMCEndpoint
+
+// CreateBMCEndpointRequest represents a request to create a BMCEndpoint
+type CreateBMCEndpointRequest struct {
+	bmcendpoint.BMCEndpointSpec `json:",inline"`
+	Name                        string            `json:"name" validate:"required"`
+	Labels                      map[string]string `json:"labels,omitempty"`
+	Annotations                 map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateBMCEndpointRequest represents a request to update a BMCEndpoint
+type UpdateBMCEndpointRequest struct {
+	bmcendpoint.BMCEndpointSpec `json:",inline,omitempty"`
+	Name                        string            `json:"name,omitempty"`
+	Labels                      map[string]string `json:"labels,omitempty"`
+	Annotations                 map[string]string `json:"annotations,omitempty"`
+}
+
+// ServiceEventResponse represents the response for ServiceEvent operations
+type ServiceEventResponse = serviceevent.ServiceEvent
+
+// CreateServiceEventRequest represents a request to create a ServiceEvent
+type CreateServiceEventRequest struct {
+	serviceevent.ServiceEventSpec `json:",inline"`
+	Name                          string            `json:"name" validate:"required"`
+	Labels                        map[string]string `json:"labels,omitempty"`
+	Annotations                   map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateServiceEventRequest represents a request to update a ServiceEvent
+type UpdateServiceEventRequest struct {
+	serviceevent.ServiceEventSpec `json:",inline,omitempty"`
+	Name                          string            `json:"name,omitempty"`
+	Labels                        map[string]string `json:"labels,omitempty"`
+	Annotations                   map[string]string `json:"annotations,omitempty"`
+}
+
+// MaintenanceWindowResponse represents the response for MaintenanceWindow operations
+type MaintenanceWindowResponse = maintenancewindow.MaintenanceWindow
+
+// CreateMaintenanceWindowRequest represents a request to create a MaintenanceWindow
+type CreateMaintenanceWindowRequest struct {
+	maintenancewindow.MaintenanceWindowSpec `json:",inline"`
+	Name                                    string            `json:"name" validate:"required"`
+	Labels                                  map[string]string `json:"labels,omitempty"`
+	Annotations                             map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateMaintenanceWindowRequest represents a request to update a MaintenanceWindow
+type UpdateMaintenanceWindowRequest struct {
+	maintenancewindow.MaintenanceWindowSpec `json:",inline,omitempty"`
+	Name                                    string            `json:"name,omitempty"`
+	Labels                                  map[string]string `json:"labels,omitempty"`
+	Annotations                             map[string]string `json:"annotations,omitempty"`
+}
+
+// GroupResponse represents the response for Group operations
+type GroupResponse = group.Group
+
+// CreateGroupRequest represents a request to create a Group
+type CreateGroupRequest struct {
+	group.GroupSpec `json:",inline"`
+	Name            string            `json:"name" validate:"required"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateGroupRequest represents a request to update a Group
+type UpdateGroupRequest struct {
+	group.GroupSpec `json:",inline,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// ConnectionResponse represents the response for Connection operations
+type ConnectionResponse = connection.Connection
+
+// CreateConnectionRequest represents a request to create a Connection
+type CreateConnectionRequest struct {
+	connection.ConnectionSpec `json:",inline"`
+	Name                      string            `json:"name" validate:"required"`
+	Labels                    map[string]string `json:"labels,omitempty"`
+	Annotations               map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateConnectionRequest represents a request to update a Connection
+type UpdateConnectionRequest struct {
+	connection.ConnectionSpec `json:",inline,omitempty"`
+	Name                      string            `json:"name,omitempty"`
+	Labels                    map[string]string `json:"labels,omitempty"`
+	Annotations               map[string]string `json:"annotations,omitempty"`
+}
+
+// PendingChangeResponse represents the response for PendingChange operations
+type PendingChangeResponse = pendingchange.PendingChange
+
+// CreatePendingChangeRequest represents a request to create a PendingChange
+type CreatePendingChangeRequest struct {
+	pendingchange.PendingChangeSpec `json:",inline"`
+	Name                            string            `json:"name" validate:"required"`
+	Labels                          map[string]string `json:"labels,omitempty"`
+	Annotations                     map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdatePendingChangeRequest represents a request to update a PendingChange
+type UpdatePendingChangeRequest struct {
+	pendingchange.PendingChangeSpec `json:",inline,omitempty"`
+	Name                            string            `json:"name,omitempty"`
+	Labels                          map[string]string `json:"labels,omitempty"`
+	Annotations                     map[string]string `json:"annotations,omitempty"`
+}
+
+// AlertResponse represents the response for Alert operations
+type AlertResponse = alert.Alert
+
+// CreateAlertRequest represents a request to create a Alert
+type CreateAlertRequest struct {
+	alert.AlertSpec `json:",inline"`
+	Name            string            `json:"name" validate:"required"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateAlertRequest represents a request to update a Alert
+type UpdateAlertRequest struct {
+	alert.AlertSpec `json:",inline,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
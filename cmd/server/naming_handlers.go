@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/naming"
+	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// deviceNamer is the configured naming strategy.
+var deviceNamer, _ = naming.New(naming.StrategyURI, "")
+
+// setupDeviceNaming sets the strategy the DiscoverySnapshot reconciler names
+// devices by.
+func setupDeviceNaming(cfg *Config) error {
+	namer, err := naming.New(cfg.DeviceNaming, cfg.DeviceNameTemplate)
+	if err != nil {
+		return err
+	}
+	deviceNamer = namer
+	reconcilers.SetDeviceNamer(namer)
+	log.Printf("Devices are named by the %s strategy", namer)
+	return nil
+}
+
+// RenameDevices renames every device by the configured naming strategy, so
+// that records named under another strategy (e.g. by Redfish URI) are
+// migrated without waiting for their BMC to report them again. Names are kept
+// unique. "dryRun=true" returns the renames without applying them.
+func RenameDevices(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	result := &naming.RenameResult{
+		DryRun:   dryRun,
+		Strategy: deviceNamer.String(),
+		Renames:  deviceNamer.Plan(devices),
+	}
+	if dryRun {
+		respondJSON(w, http.StatusOK, result)
+		return
+	}
+
+	byUID := make(map[string]int, len(devices))
+	for i, d := range devices {
+		byUID[d.GetUID()] = i
+	}
+	for _, rename := range result.Renames {
+		d := devices[byUID[rename.UID]]
+		d.Metadata.Name = rename.To
+		d.Touch()
+		if err := storage.SaveDevice(r.Context(), d); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", rename.UID, err))
+			continue
+		}
+		updateMetadata := map[string]interface{}{
+			"updatedAt":   d.Metadata.UpdatedAt,
+			"renamedFrom": rename.From,
+		}
+		if err := events.PublishResourceUpdated(r.Context(), "Device", d.GetUID(), d.GetName(), d, updateMetadata); err != nil {
+			fmt.Printf("Warning: Failed to publish resource updated event for Device %s: %v\n", d.GetUID(), err)
+		}
+		result.Applied++
+	}
+	respondJSON(w, http.StatusOK, result)
+}
@@ -23,8 +23,15 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/connection"
 	"github.com/example/inventory-v3/pkg/resources/device"
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3gen"
 )
@@ -107,6 +114,13 @@ func GenerateOpenAPISpec() *openapi3.T {
 	// Register all resource paths
 	registerDevicePaths(spec)
 	registerDiscoverySnapshotPaths(spec)
+	registerBMCEndpointPaths(spec)
+	registerServiceEventPaths(spec)
+	registerMaintenanceWindowPaths(spec)
+	registerGroupPaths(spec)
+	registerConnectionPaths(spec)
+	registerPendingChangePaths(spec)
+	registerAlertPaths(spec)
 
 	return spec
 }
@@ -413,6 +427,1063 @@ func registerDiscoverySnapshotPaths(spec *openapi3.T) {
 	spec.Paths.Set("/discoverysnapshots/{uid}", itemPath)
 }
 
+// registerBMCEndpointPaths registers OpenAPI paths for BMCEndpoint resources
+func registerBMCEndpointPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&bmcendpoint.BMCEndpoint{}, spec.Components.Schemas)
+	spec.Components.Schemas["BMCEndpoint"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateBMCEndpointRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateBMCEndpointRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateBMCEndpointRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateBMCEndpointRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List BMCEndpoints operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listBMCEndpoints"
+	listOp.Summary = "List all BMCEndpoint resources"
+	listOp.Description = "Returns a list of all BMCEndpoint resources in the inventory"
+	listOp.Tags = []string{"BMCEndpoint"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/BMCEndpoint"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create BMCEndpoint operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createBMCEndpoint"
+	createOp.Summary = "Create a new BMCEndpoint resource"
+	createOp.Description = "Creates a new BMCEndpoint resource with the provided specification"
+	createOp.Tags = []string{"BMCEndpoint"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateBMCEndpointRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/BMCEndpoint",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get BMCEndpoint operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getBMCEndpoint"
+	getOp.Summary = "Get a specific BMCEndpoint resource"
+	getOp.Description = "Returns details of a specific BMCEndpoint resource by UID"
+	getOp.Tags = []string{"BMCEndpoint"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/BMCEndpoint",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update BMCEndpoint operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateBMCEndpoint"
+	updateOp.Summary = "Update a BMCEndpoint resource"
+	updateOp.Description = "Updates an existing BMCEndpoint resource with new values"
+	updateOp.Tags = []string{"BMCEndpoint"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateBMCEndpointRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/BMCEndpoint",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete BMCEndpoint operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteBMCEndpoint"
+	deleteOp.Summary = "Delete a BMCEndpoint resource"
+	deleteOp.Description = "Removes a BMCEndpoint resource from the inventory"
+	deleteOp.Tags = []string{"BMCEndpoint"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the BMCEndpoint resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/bmcendpoints", collectionPath)
+	spec.Paths.Set("/bmcendpoints/{uid}", itemPath)
+}
+
+// registerServiceEventPaths registers OpenAPI paths for ServiceEvent resources
+func registerServiceEventPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&serviceevent.ServiceEvent{}, spec.Components.Schemas)
+	spec.Components.Schemas["ServiceEvent"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateServiceEventRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateServiceEventRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateServiceEventRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateServiceEventRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List ServiceEvents operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listServiceEvents"
+	listOp.Summary = "List all ServiceEvent resources"
+	listOp.Description = "Returns a list of all ServiceEvent resources in the inventory"
+	listOp.Tags = []string{"ServiceEvent"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/ServiceEvent"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create ServiceEvent operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createServiceEvent"
+	createOp.Summary = "Create a new ServiceEvent resource"
+	createOp.Description = "Creates a new ServiceEvent resource with the provided specification"
+	createOp.Tags = []string{"ServiceEvent"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateServiceEventRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/ServiceEvent",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get ServiceEvent operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getServiceEvent"
+	getOp.Summary = "Get a specific ServiceEvent resource"
+	getOp.Description = "Returns details of a specific ServiceEvent resource by UID"
+	getOp.Tags = []string{"ServiceEvent"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/ServiceEvent",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update ServiceEvent operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateServiceEvent"
+	updateOp.Summary = "Update a ServiceEvent resource"
+	updateOp.Description = "Updates an existing ServiceEvent resource with new values"
+	updateOp.Tags = []string{"ServiceEvent"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateServiceEventRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/ServiceEvent",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete ServiceEvent operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteServiceEvent"
+	deleteOp.Summary = "Delete a ServiceEvent resource"
+	deleteOp.Description = "Removes a ServiceEvent resource from the inventory"
+	deleteOp.Tags = []string{"ServiceEvent"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the ServiceEvent resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/serviceevents", collectionPath)
+	spec.Paths.Set("/serviceevents/{uid}", itemPath)
+}
+
+// registerMaintenanceWindowPaths registers OpenAPI paths for MaintenanceWindow resources
+func registerMaintenanceWindowPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&maintenancewindow.MaintenanceWindow{}, spec.Components.Schemas)
+	spec.Components.Schemas["MaintenanceWindow"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateMaintenanceWindowRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateMaintenanceWindowRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateMaintenanceWindowRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateMaintenanceWindowRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List MaintenanceWindows operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listMaintenanceWindows"
+	listOp.Summary = "List all MaintenanceWindow resources"
+	listOp.Description = "Returns a list of all MaintenanceWindow resources in the inventory"
+	listOp.Tags = []string{"MaintenanceWindow"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/MaintenanceWindow"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create MaintenanceWindow operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createMaintenanceWindow"
+	createOp.Summary = "Create a new MaintenanceWindow resource"
+	createOp.Description = "Creates a new MaintenanceWindow resource with the provided specification"
+	createOp.Tags = []string{"MaintenanceWindow"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateMaintenanceWindowRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/MaintenanceWindow",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get MaintenanceWindow operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getMaintenanceWindow"
+	getOp.Summary = "Get a specific MaintenanceWindow resource"
+	getOp.Description = "Returns details of a specific MaintenanceWindow resource by UID"
+	getOp.Tags = []string{"MaintenanceWindow"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/MaintenanceWindow",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update MaintenanceWindow operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateMaintenanceWindow"
+	updateOp.Summary = "Update a MaintenanceWindow resource"
+	updateOp.Description = "Updates an existing MaintenanceWindow resource with new values"
+	updateOp.Tags = []string{"MaintenanceWindow"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateMaintenanceWindowRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/MaintenanceWindow",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete MaintenanceWindow operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteMaintenanceWindow"
+	deleteOp.Summary = "Delete a MaintenanceWindow resource"
+	deleteOp.Description = "Removes a MaintenanceWindow resource from the inventory"
+	deleteOp.Tags = []string{"MaintenanceWindow"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the MaintenanceWindow resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/maintenancewindows", collectionPath)
+	spec.Paths.Set("/maintenancewindows/{uid}", itemPath)
+}
+
+// registerGroupPaths registers OpenAPI paths for Group resources
+func registerGroupPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&group.Group{}, spec.Components.Schemas)
+	spec.Components.Schemas["Group"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateGroupRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateGroupRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateGroupRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateGroupRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List Groups operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listGroups"
+	listOp.Summary = "List all Group resources"
+	listOp.Description = "Returns a list of all Group resources in the inventory"
+	listOp.Tags = []string{"Group"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/Group"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create Group operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createGroup"
+	createOp.Summary = "Create a new Group resource"
+	createOp.Description = "Creates a new Group resource with the provided specification"
+	createOp.Tags = []string{"Group"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateGroupRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Group",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get Group operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getGroup"
+	getOp.Summary = "Get a specific Group resource"
+	getOp.Description = "Returns details of a specific Group resource by UID"
+	getOp.Tags = []string{"Group"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Group",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update Group operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateGroup"
+	updateOp.Summary = "Update a Group resource"
+	updateOp.Description = "Updates an existing Group resource with new values"
+	updateOp.Tags = []string{"Group"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateGroupRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Group",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete Group operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteGroup"
+	deleteOp.Summary = "Delete a Group resource"
+	deleteOp.Description = "Removes a Group resource from the inventory"
+	deleteOp.Tags = []string{"Group"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the Group resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/groups", collectionPath)
+	spec.Paths.Set("/groups/{uid}", itemPath)
+}
+
+// registerConnectionPaths registers OpenAPI paths for Connection resources
+func registerConnectionPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&connection.Connection{}, spec.Components.Schemas)
+	spec.Components.Schemas["Connection"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateConnectionRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateConnectionRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateConnectionRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateConnectionRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List Connections operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listConnections"
+	listOp.Summary = "List all Connection resources"
+	listOp.Description = "Returns a list of all Connection resources in the inventory"
+	listOp.Tags = []string{"Connection"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/Connection"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create Connection operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createConnection"
+	createOp.Summary = "Create a new Connection resource"
+	createOp.Description = "Creates a new Connection resource with the provided specification"
+	createOp.Tags = []string{"Connection"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateConnectionRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Connection",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get Connection operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getConnection"
+	getOp.Summary = "Get a specific Connection resource"
+	getOp.Description = "Returns details of a specific Connection resource by UID"
+	getOp.Tags = []string{"Connection"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Connection",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update Connection operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateConnection"
+	updateOp.Summary = "Update a Connection resource"
+	updateOp.Description = "Updates an existing Connection resource with new values"
+	updateOp.Tags = []string{"Connection"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateConnectionRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Connection",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete Connection operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteConnection"
+	deleteOp.Summary = "Delete a Connection resource"
+	deleteOp.Description = "Removes a Connection resource from the inventory"
+	deleteOp.Tags = []string{"Connection"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the Connection resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/connections", collectionPath)
+	spec.Paths.Set("/connections/{uid}", itemPath)
+}
+
+// registerPendingChangePaths registers OpenAPI paths for PendingChange resources
+func registerPendingChangePaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&pendingchange.PendingChange{}, spec.Components.Schemas)
+	spec.Components.Schemas["PendingChange"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreatePendingChangeRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreatePendingChangeRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdatePendingChangeRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdatePendingChangeRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List PendingChanges operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listPendingChanges"
+	listOp.Summary = "List all PendingChange resources"
+	listOp.Description = "Returns a list of all PendingChange resources in the inventory"
+	listOp.Tags = []string{"PendingChange"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/PendingChange"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create PendingChange operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createPendingChange"
+	createOp.Summary = "Create a new PendingChange resource"
+	createOp.Description = "Creates a new PendingChange resource with the provided specification"
+	createOp.Tags = []string{"PendingChange"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreatePendingChangeRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/PendingChange",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get PendingChange operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getPendingChange"
+	getOp.Summary = "Get a specific PendingChange resource"
+	getOp.Description = "Returns details of a specific PendingChange resource by UID"
+	getOp.Tags = []string{"PendingChange"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/PendingChange",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update PendingChange operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updatePendingChange"
+	updateOp.Summary = "Update a PendingChange resource"
+	updateOp.Description = "Updates an existing PendingChange resource with new values"
+	updateOp.Tags = []string{"PendingChange"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdatePendingChangeRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/PendingChange",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete PendingChange operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deletePendingChange"
+	deleteOp.Summary = "Delete a PendingChange resource"
+	deleteOp.Description = "Removes a PendingChange resource from the inventory"
+	deleteOp.Tags = []string{"PendingChange"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the PendingChange resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/pendingchanges", collectionPath)
+	spec.Paths.Set("/pendingchanges/{uid}", itemPath)
+}
+
+// registerAlertPaths registers OpenAPI paths for Alert resources
+func registerAlertPaths(spec *openapi3.T) {
+	// Generate schemas from Go types - NO ANNOTATIONS NEEDED
+	resourceSchema, _ := openapi3gen.NewSchemaRefForValue(&alert.Alert{}, spec.Components.Schemas)
+	spec.Components.Schemas["Alert"] = resourceSchema
+
+	createReqSchema, _ := openapi3gen.NewSchemaRefForValue(&CreateAlertRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["CreateAlertRequest"] = createReqSchema
+
+	updateReqSchema, _ := openapi3gen.NewSchemaRefForValue(&UpdateAlertRequest{}, spec.Components.Schemas)
+	spec.Components.Schemas["UpdateAlertRequest"] = updateReqSchema
+
+	// Error response schema
+	if _, exists := spec.Components.Schemas["ErrorResponse"]; !exists {
+		errorSchema := openapi3.NewObjectSchema().
+			WithProperty("error", openapi3.NewStringSchema()).
+			WithRequired([]string{"error"})
+		spec.Components.Schemas["ErrorResponse"] = &openapi3.SchemaRef{Value: errorSchema}
+	}
+
+	// DELETE response schema
+	if _, exists := spec.Components.Schemas["DeleteResponse"]; !exists {
+		deleteSchema, _ := openapi3gen.NewSchemaRefForValue(&DeleteResponse{}, spec.Components.Schemas)
+		spec.Components.Schemas["DeleteResponse"] = deleteSchema
+	}
+
+	// List Alerts operation
+	listOp := openapi3.NewOperation()
+	listOp.OperationID = "listAlerts"
+	listOp.Summary = "List all Alert resources"
+	listOp.Description = "Returns a list of all Alert resources in the inventory"
+	listOp.Tags = []string{"Alert"}
+	listOp.Responses = openapi3.NewResponses()
+	arraySchema := openapi3.NewArraySchema()
+	arraySchema.Items = &openapi3.SchemaRef{Ref: "#/components/schemas/Alert"}
+	listOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{Value: arraySchema}),
+	})
+	listOp.Responses.Set("500", errorResponse())
+
+	// Create Alert operation
+	createOp := openapi3.NewOperation()
+	createOp.OperationID = "createAlert"
+	createOp.Summary = "Create a new Alert resource"
+	createOp.Description = "Creates a new Alert resource with the provided specification"
+	createOp.Tags = []string{"Alert"}
+	createOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/CreateAlertRequest",
+			}),
+	}
+	createOp.Responses = openapi3.NewResponses()
+	createOp.Responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource created successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Alert",
+			}),
+	})
+	createOp.Responses.Set("400", errorResponse())
+	createOp.Responses.Set("500", errorResponse())
+
+	// Get Alert operation
+	getOp := openapi3.NewOperation()
+	getOp.OperationID = "getAlert"
+	getOp.Summary = "Get a specific Alert resource"
+	getOp.Description = "Returns details of a specific Alert resource by UID"
+	getOp.Tags = []string{"Alert"}
+	getOp.Responses = openapi3.NewResponses()
+	getOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successful response").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Alert",
+			}),
+	})
+	getOp.Responses.Set("404", errorResponse())
+	getOp.Responses.Set("500", errorResponse())
+
+	// Update Alert operation
+	updateOp := openapi3.NewOperation()
+	updateOp.OperationID = "updateAlert"
+	updateOp.Summary = "Update a Alert resource"
+	updateOp.Description = "Updates an existing Alert resource with new values"
+	updateOp.Tags = []string{"Alert"}
+	updateOp.RequestBody = &openapi3.RequestBodyRef{
+		Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/UpdateAlertRequest",
+			}),
+	}
+	updateOp.Responses = openapi3.NewResponses()
+	updateOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource updated successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/Alert",
+			}),
+	})
+	updateOp.Responses.Set("400", errorResponse())
+	updateOp.Responses.Set("404", errorResponse())
+	updateOp.Responses.Set("500", errorResponse())
+
+	// Delete Alert operation
+	deleteOp := openapi3.NewOperation()
+	deleteOp.OperationID = "deleteAlert"
+	deleteOp.Summary = "Delete a Alert resource"
+	deleteOp.Description = "Removes a Alert resource from the inventory"
+	deleteOp.Tags = []string{"Alert"}
+	deleteOp.Responses = openapi3.NewResponses()
+	deleteOp.Responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Resource deleted successfully").
+			WithJSONSchemaRef(&openapi3.SchemaRef{
+				Ref: "#/components/schemas/DeleteResponse",
+			}),
+	})
+	deleteOp.Responses.Set("400", errorResponse())
+	deleteOp.Responses.Set("404", errorResponse())
+	deleteOp.Responses.Set("500", errorResponse())
+
+	// Create path items
+	collectionPath := &openapi3.PathItem{
+		Get:  listOp,
+		Post: createOp,
+	}
+
+	uidParam := openapi3.NewPathParameter("uid").
+		WithDescription("Unique identifier of the Alert resource").
+		WithRequired(true).
+		WithSchema(openapi3.NewStringSchema())
+
+	itemPath := &openapi3.PathItem{
+		Get:    getOp,
+		Put:    updateOp,
+		Delete: deleteOp,
+		Parameters: []*openapi3.ParameterRef{
+			{Value: uidParam},
+		},
+	}
+
+	// Add paths to spec
+	spec.Paths.Set("/alerts", collectionPath)
+	spec.Paths.Set("/alerts/{uid}", itemPath)
+}
+
 // Helper function for error responses
 func errorResponse() *openapi3.ResponseRef {
 	return &openapi3.ResponseRef{
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/openchami/fabrica/pkg/events"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/changecontrol"
+	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+)
+
+// changePolicy is the site's change-control policy, set from the
+// configuration at startup.
+var changePolicy changecontrol.Policy
+
+// holdForApproval stores a change the policy holds for approval instead of
+// applying it.
+func holdForApproval(r *http.Request, change *pendingchange.PendingChange) error {
+	if err := storage.SavePendingChange(r.Context(), change); err != nil {
+		return fmt.Errorf("failed to save pending change: %w", err)
+	}
+	if err := events.PublishResourceCreated(r.Context(), "PendingChange", change.GetUID(), change.GetName(), change); err != nil {
+		fmt.Printf("Warning: Failed to publish resource created event for PendingChange %s: %v\n", change.GetUID(), err)
+	}
+	return nil
+}
+
+// ApprovePendingChange signs off a pending change; the reconciler then
+// applies it. The optional body ({"by", "reason"}) is recorded on the change.
+func ApprovePendingChange(w http.ResponseWriter, r *http.Request) {
+	reviewPendingChange(w, r, true)
+}
+
+// RejectPendingChange discards a pending change; it is never applied.
+func RejectPendingChange(w http.ResponseWriter, r *http.Request) {
+	reviewPendingChange(w, r, false)
+}
+
+func reviewPendingChange(w http.ResponseWriter, r *http.Request, approved bool) {
+	var review pendingchange.Review
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	review.Approved, review.At = approved, time.Now()
+
+	uid := chi.URLParam(r, "uid")
+	change, err := storage.LoadPendingChange(r.Context(), uid)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		respondError(w, http.StatusNotFound, fmt.Errorf("PendingChange not found: %s", uid))
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load the pending change: %w", err))
+		return
+	}
+	if change.Status.Phase != pendingchange.PhasePending && change.Status.Phase != "" {
+		respondError(w, http.StatusConflict, fmt.Errorf("PendingChange %s is not waiting for approval (phase %q)", uid, change.Status.Phase))
+		return
+	}
+
+	change.Status.Review = &review
+	if approved {
+		change.Status.Phase = pendingchange.PhaseApproved
+		change.Status.Message = "Approved; queued to be applied."
+	} else {
+		change.Status.Phase = pendingchange.PhaseRejected
+		change.Status.Message = "Rejected by an operator."
+	}
+	if review.Reason != "" {
+		change.Status.Message += " " + review.Reason
+	}
+	change.Metadata.UpdatedAt = review.At
+	if err := storage.UpdatePendingChange(r.Context(), change); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save the pending change: %w", err))
+		return
+	}
+	if approved {
+		if err := reconcilers.Requeue("PendingChange", uid); err != nil {
+			respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to queue the pending change: %w", err))
+			return
+		}
+	}
+	respondJSON(w, http.StatusOK, change)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/properties"
+	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/reports"
+	"github.com/example/inventory-v3/pkg/units"
+)
+
+// setupPropertySchema registers the site's property definitions and unit
+// rules, if any, alongside the built-in ones, and sets its property policy.
+func setupPropertySchema(cfg *Config) error {
+	if cfg.PropertySchema != "" {
+		n, err := properties.LoadFile(cfg.PropertySchema)
+		if err != nil {
+			return err
+		}
+		log.Printf("Registered %d device properties from %s", n, cfg.PropertySchema)
+	}
+	if cfg.UnitRules != "" {
+		n, err := units.LoadRules(cfg.UnitRules)
+		if err != nil {
+			return err
+		}
+		log.Printf("Registered %d unit rules from %s", n, cfg.UnitRules)
+	}
+	if cfg.PropertyPolicy != "" {
+		policy, err := redact.LoadPolicy(cfg.PropertyPolicy)
+		if err != nil {
+			return err
+		}
+		reconcilers.SetPropertyPolicy(policy)
+		log.Printf("Applying the property policy in %s to stored devices", cfg.PropertyPolicy)
+	}
+	return nil
+}
+
+// ListProperties returns the property registry, sorted by key. The optional
+// "deviceType" query parameter keeps the properties of that device type.
+func ListProperties(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, properties.List(r.URL.Query().Get("deviceType")))
+}
+
+// GetProperty returns the schema of one property key.
+func GetProperty(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	schema, ok := properties.Lookup(key)
+	if !ok {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Property not registered: %s", key))
+		return
+	}
+	respondJSON(w, http.StatusOK, schema)
+}
+
+// GetPropertyUsageReport audits the property keys of the active devices
+// against the registry: undocumented keys, values of the wrong type and keys
+// on device types the registry does not expect.
+func GetPropertyUsageReport(w http.ResponseWriter, r *http.Request) {
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, devices)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.PropertyAudit(devices))
+}
+
+// GetDeviceProperties returns the properties of a device with their schemas,
+// as the CLI and UI render them.
+func GetDeviceProperties(w http.ResponseWriter, r *http.Request) {
+	uid := chi.URLParam(r, "uid")
+	d, err := storage.LoadDevice(r.Context(), uid)
+	if err != nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("Device not found: %w", err))
+		return
+	}
+	described := properties.Describe(d.Spec.DeviceType, d.Spec.Properties)
+	for i := range described {
+		if prov, ok := d.Spec.Provenance[described[i].Key]; ok {
+			described[i].Reported = fmt.Sprintf("%s (read as %s)", prov.Raw, prov.Unit)
+		}
+	}
+	respondJSON(w, http.StatusOK, described)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/changecontrol"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/openchami/fabrica/pkg/events"
+)
+
+// PruneDevices deletes or retires the devices matching a selector.
+//
+// Every prune starts as a dry run: a request without a previewToken (or with
+// dryRun set) only returns the matched devices and a token. The prune is
+// applied by repeating the request with that token; if the matched devices
+// changed in between, it fails with 409 Conflict and returns the new preview.
+func PruneDevices(w http.ResponseWriter, r *http.Request) {
+	var req prune.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.Action != prune.ActionDelete && req.Action != prune.ActionRetire {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid action %q (valid: %s, %s)", req.Action, prune.ActionDelete, prune.ActionRetire))
+		return
+	}
+	sel, err := prune.ParseSelector(req.Selector)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err)
+		return
+	}
+	if sel.Endpoint != "" {
+		address, err := resolveEndpointAddress(r, sel.Endpoint)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, err)
+			return
+		}
+		sel.Source = address
+	}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	selected := prune.Select(devices, sel, req.IncludeChildren, time.Now())
+
+	result := &prune.Result{
+		DryRun:       req.DryRun || req.PreviewToken == "",
+		Action:       req.Action,
+		Selector:     req.Selector,
+		Matched:      len(selected),
+		PreviewToken: prune.PreviewToken(req.Action, selected),
+		Devices:      make([]prune.Entry, 0, len(selected)),
+	}
+	for _, d := range selected {
+		result.Devices = append(result.Devices, prune.NewEntry(d))
+	}
+
+	if result.DryRun {
+		respondJSON(w, http.StatusOK, result)
+		return
+	}
+	if req.PreviewToken != result.PreviewToken {
+		result.DryRun = true
+		result.Error = "preview token does not match the current selection; review the new preview"
+		respondJSON(w, http.StatusConflict, result)
+		return
+	}
+	if changePolicy.RetireNeedsApproval(len(selected)) {
+		change, err := changecontrol.New(req.Action, fmt.Sprintf("Prune (%s) %d devices matching %q", req.Action, len(selected), req.Selector))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, d := range selected {
+			change.Spec.Devices = append(change.Spec.Devices, d.GetUID())
+		}
+		if err := holdForApproval(r, change); err != nil {
+			respondError(w, http.StatusInternalServerError, err)
+			return
+		}
+		result.PendingChange = change.GetUID()
+		respondJSON(w, http.StatusAccepted, result)
+		return
+	}
+
+	for _, d := range selected {
+		if err := applyPrune(r, req.Action, d); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", d.GetUID(), err))
+			continue
+		}
+		result.Applied++
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+// applyPrune deletes or retires one device and publishes the matching event.
+func applyPrune(r *http.Request, action string, d *device.Device) error {
+	if action == prune.ActionDelete {
+		if err := storage.DeleteDevice(r.Context(), d.GetUID()); err != nil {
+			return err
+		}
+		deleteMetadata := map[string]interface{}{
+			"deletedAt": time.Now(),
+			"reason":    "prune",
+		}
+		if err := events.PublishResourceDeleted(r.Context(), "Device", d.GetUID(), d.GetName(), deleteMetadata); err != nil {
+			fmt.Printf("Warning: Failed to publish resource deleted event for Device %s: %v\n", d.GetUID(), err)
+		}
+		return nil
+	}
+
+	if d.Status.Phase == prune.PhaseRetired {
+		return nil
+	}
+	d.Status.Phase = prune.PhaseRetired
+	d.Status.Message = fmt.Sprintf("Retired by prune at %s", time.Now().Format(time.RFC3339))
+	d.Touch()
+	if err := storage.SaveDevice(r.Context(), d); err != nil {
+		return err
+	}
+	updateMetadata := map[string]interface{}{
+		"updatedAt": d.Metadata.UpdatedAt,
+	}
+	if err := events.PublishResourceUpdated(r.Context(), "Device", d.GetUID(), d.GetName(), d, updateMetadata); err != nil {
+		fmt.Printf("Warning: Failed to publish resource updated event for Device %s: %v\n", d.GetUID(), err)
+	}
+	return nil
+}
+
+// resolveEndpointAddress returns the address of the BMCEndpoint with the given
+// name or UID.
+func resolveEndpointAddress(r *http.Request, nameOrUID string) (string, error) {
+	endpoints, err := storage.LoadAllBMCEndpoints(r.Context())
+	if err != nil {
+		return "", fmt.Errorf("failed to load BMC endpoints: %w", err)
+	}
+	for _, e := range endpoints {
+		if e.GetUID() == nameOrUID || e.GetName() == nameOrUID {
+			return e.Spec.Address, nil
+		}
+	}
+	return "", fmt.Errorf("BMCEndpoint %q not found", nameOrUID)
+}
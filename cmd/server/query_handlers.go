@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/devicetable"
+	"github.com/example/inventory-v3/pkg/query"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// ListDevices serves GET /devices in place of the generated GetDevices. It
+// leaves out retired devices unless asked for them, and narrows and orders
+// the list by the optional "group", "where" and "sort" query parameters.
+func ListDevices(w http.ResponseWriter, r *http.Request) {
+	all, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, filterRetiredDevices(r, all))
+	if !ok {
+		return
+	}
+	if devices, ok = filterWhere(w, r, devices); !ok {
+		return
+	}
+	if !sortDevices(w, r, devices, all) {
+		return
+	}
+	respondJSON(w, http.StatusOK, devices)
+}
+
+// filterWhere narrows devices to those satisfying the expression in the
+// optional "where" query parameter, e.g. "CapacityMiB >= 65536". On a bad
+// expression it writes a 400 response and returns false.
+func filterWhere(w http.ResponseWriter, r *http.Request, devices []*device.Device) ([]*device.Device, bool) {
+	raw := r.URL.Query().Get("where")
+	if raw == "" {
+		return devices, true
+	}
+	expr, err := query.Parse(raw)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid where %q: %w", raw, err))
+		return nil, false
+	}
+	return expr.Filter(devices), true
+}
+
+// sortDevices orders devices by the columns in the optional "sort" query
+// parameter, e.g. "type,-lastSeen" (see devicetable.Sort); locations are
+// looked up among all, the devices before filtering. On a bad column it
+// writes a 400 response and returns false.
+func sortDevices(w http.ResponseWriter, r *http.Request, devices, all []*device.Device) bool {
+	order := r.URL.Query().Get("sort")
+	if order == "" {
+		return true
+	}
+	byUID := make(map[string]*device.Device, len(all))
+	for _, d := range all {
+		byUID[d.GetUID()] = d
+	}
+	if err := devicetable.Sort(devices, order, func(uid string) *device.Device { return byUID[uid] }); err != nil {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid sort %q: %w", order, err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/reports"
+)
+
+// GetDriveEnduranceReport returns the fleet drive endurance report.
+// The optional "threshold" query parameter sets the percentage used at which
+// a drive is counted as worn.
+func GetDriveEnduranceReport(w http.ResponseWriter, r *http.Request) {
+	var threshold float64
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("invalid threshold %q: %w", raw, err))
+			return
+		}
+		threshold = parsed
+	}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, devices)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.DriveEndurance(devices, threshold))
+}
+
+// GetCompletenessReport returns the inventory completeness of every node.
+// The optional "threshold" query parameter sets the score below which a node
+// is counted as incomplete.
+func GetCompletenessReport(w http.ResponseWriter, r *http.Request) {
+	var threshold float64
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("invalid threshold %q: %w", raw, err))
+			return
+		}
+		threshold = parsed
+	}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, devices)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.Completeness(devices, threshold))
+}
+
+// GetFirmwareVersionsReport returns the distribution of firmware versions by
+// model. The optional "deviceType", "manufacturer", "model", "firmware" and
+// "version" query parameters narrow it, e.g. "deviceType=Node&firmware=BIOS"
+// for the BIOS versions of every node model.
+func GetFirmwareVersionsReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := reports.FirmwareVersionFilter{
+		DeviceType:   query.Get("deviceType"),
+		Manufacturer: query.Get("manufacturer"),
+		Model:        query.Get("model"),
+		Firmware:     query.Get("firmware"),
+		Version:      query.Get("version"),
+	}
+
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, devices)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.FirmwareVersions(devices, filter))
+}
+
+// GetPowerCapacityReport returns the power capacity of the nodes in each
+// rack, with each rack's headroom against its power budget.
+func GetPowerCapacityReport(w http.ResponseWriter, r *http.Request) {
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, devices)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.PowerCapacity(devices))
+}
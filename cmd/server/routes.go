@@ -0,0 +1,79 @@
+package main
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterCustomRoutes registers hand-written routes that are not produced by
+// 'fabrica generate'. It is called after RegisterGeneratedRoutes in main.go.
+func RegisterCustomRoutes(r chi.Router) {
+
+	// Report routes
+	r.Route("/reports", func(r chi.Router) {
+		r.Get("/drive-endurance", GetDriveEnduranceReport)
+		r.Get("/consistency", GetConsistencyReport)
+		r.Get("/new-hardware", GetNewHardwareReport)
+		r.Get("/missing-devices", GetMissingDevicesReport)
+		r.Get("/firmware-compliance", GetFirmwareComplianceReport)
+		r.Get("/firmware-versions", GetFirmwareVersionsReport)
+		r.Get("/completeness", GetCompletenessReport)
+		r.Get("/power-capacity", GetPowerCapacityReport)
+		r.Get("/properties", GetPropertyUsageReport)
+		r.Get("/scheduled", GetLastScheduledReport)
+		r.Post("/scheduled/run", RunScheduledReports)
+		r.Get("/fleet-stats", GetFleetStats)
+		r.Post("/fleet-stats/record", RecordFleetStats)
+	})
+
+	r.Get("/metrics", metricsHandler)
+
+	// Grafana JSON datasource
+	r.Route("/grafana", func(r chi.Router) {
+		r.Get("/", GrafanaHealth)
+		r.Post("/search", SearchGrafanaMetrics)
+		r.Post("/metrics", ListGrafanaMetrics)
+		r.Post("/query", QueryGrafana)
+		r.Post("/annotations", GrafanaAnnotations)
+	})
+
+	// Background processing
+	r.Route("/admin/reconcilers", func(r chi.Router) {
+		r.Get("/", GetReconcilerStatus)
+		r.Post("/{kind}/enable", EnableReconciler)
+		r.Post("/{kind}/disable", DisableReconciler)
+	})
+	r.Get("/admin/gitops", GetGitOpsStatus)
+	r.Post("/admin/gitops/sync", SyncGitOps)
+
+	r.Get("/maintenancewindows/active", GetActiveMaintenance)
+	r.Get("/alerts/rules", GetAlertRules)
+	r.Get("/alerts/evaluation", GetLastAlertEvaluation)
+	r.Post("/alerts/evaluate", EvaluateAlerts)
+	r.Get("/groups/{uid}/members", GetGroupMembers)
+	r.Get("/discoverysnapshots/{uid}/diff/{other}", GetDiscoverySnapshotDiff)
+	r.Get("/discoverysnapshots/{uid}/devices", GetDiscoverySnapshotDevices)
+	r.Post("/discoverysnapshots/{uid}/approve", ApproveDiscoverySnapshot)
+	r.Post("/discoverysnapshots/{uid}/reject", RejectDiscoverySnapshot)
+	r.Post("/pendingchanges/{uid}/approve", ApprovePendingChange)
+	r.Post("/pendingchanges/{uid}/reject", RejectPendingChange)
+
+	// Property registry
+	r.Get("/properties", ListProperties)
+	r.Get("/properties/{key}", GetProperty)
+
+	// Device lists are filtered and sorted; see ListDevices
+	r.Get("/devices", ListDevices)
+
+	// Bulk operations
+	r.Post("/devices/prune", PruneDevices)
+	r.Post("/devices/import-manifest", ImportManifest)
+	r.Post("/devices/patch", PatchDevices)
+	r.Post("/devices/rename", RenameDevices)
+	r.Get("/devices/by-mac/{mac}", GetDevicesByMAC)
+	r.Get("/devices/by-serial/{sn}", GetDevicesBySerial)
+	r.Get("/devices/as-of", GetInventoryAsOf)
+	r.Post("/devices/{uid}/decommission", DecommissionDevice)
+	r.Get("/devices/{uid}/bom", GetDeviceBOM)
+	r.Get("/devices/{uid}/properties", GetDeviceProperties)
+	r.Post("/connections/infer", InferCabling)
+}
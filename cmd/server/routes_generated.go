@@ -14,6 +14,13 @@
 // This file registers routes for all resource types:
 //   - /devices (Device operations)
 //   - /discoverysnapshots (DiscoverySnapshot operations)
+//   - /bmcendpoints (BMCEndpoint operations)
+//   - /serviceevents (ServiceEvent operations)
+//   - /maintenancewindows (MaintenanceWindow operations)
+//   - /groups (Group operations)
+//   - /connections (Connection operations)
+//   - /pendingchanges (PendingChange operations)
+//   - /alerts (Alert operations)
 //
 // Route patterns:
 //   - GET    /resource              -> List all resources
@@ -78,6 +85,132 @@ func RegisterGeneratedRoutes(r chi.Router) {
 		})
 	})
 
+	// BMCEndpoint routes
+	r.Route("/bmcendpoints", func(r chi.Router) {
+		r.Get("/", GetBMCEndpoints)
+		r.Post("/", CreateBMCEndpoint)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetBMCEndpoint)
+			r.Put("/", UpdateBMCEndpoint)
+			r.Patch("/", PatchBMCEndpoint)
+			r.Delete("/", DeleteBMCEndpoint)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdateBMCEndpointStatus)
+				r.Patch("/", PatchBMCEndpointStatus)
+			})
+		})
+	})
+
+	// ServiceEvent routes
+	r.Route("/serviceevents", func(r chi.Router) {
+		r.Get("/", GetServiceEvents)
+		r.Post("/", CreateServiceEvent)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetServiceEvent)
+			r.Put("/", UpdateServiceEvent)
+			r.Patch("/", PatchServiceEvent)
+			r.Delete("/", DeleteServiceEvent)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdateServiceEventStatus)
+				r.Patch("/", PatchServiceEventStatus)
+			})
+		})
+	})
+
+	// MaintenanceWindow routes
+	r.Route("/maintenancewindows", func(r chi.Router) {
+		r.Get("/", GetMaintenanceWindows)
+		r.Post("/", CreateMaintenanceWindow)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetMaintenanceWindow)
+			r.Put("/", UpdateMaintenanceWindow)
+			r.Patch("/", PatchMaintenanceWindow)
+			r.Delete("/", DeleteMaintenanceWindow)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdateMaintenanceWindowStatus)
+				r.Patch("/", PatchMaintenanceWindowStatus)
+			})
+		})
+	})
+
+	// Group routes
+	r.Route("/groups", func(r chi.Router) {
+		r.Get("/", GetGroups)
+		r.Post("/", CreateGroup)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetGroup)
+			r.Put("/", UpdateGroup)
+			r.Patch("/", PatchGroup)
+			r.Delete("/", DeleteGroup)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdateGroupStatus)
+				r.Patch("/", PatchGroupStatus)
+			})
+		})
+	})
+
+	// Connection routes
+	r.Route("/connections", func(r chi.Router) {
+		r.Get("/", GetConnections)
+		r.Post("/", CreateConnection)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetConnection)
+			r.Put("/", UpdateConnection)
+			r.Patch("/", PatchConnection)
+			r.Delete("/", DeleteConnection)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdateConnectionStatus)
+				r.Patch("/", PatchConnectionStatus)
+			})
+		})
+	})
+
+	// PendingChange routes
+	r.Route("/pendingchanges", func(r chi.Router) {
+		r.Get("/", GetPendingChanges)
+		r.Post("/", CreatePendingChange)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetPendingChange)
+			r.Put("/", UpdatePendingChange)
+			r.Patch("/", PatchPendingChange)
+			r.Delete("/", DeletePendingChange)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdatePendingChangeStatus)
+				r.Patch("/", PatchPendingChangeStatus)
+			})
+		})
+	})
+
+	// Alert routes
+	r.Route("/alerts", func(r chi.Router) {
+		r.Get("/", GetAlerts)
+		r.Post("/", CreateAlert)
+		r.Route("/{uid}", func(r chi.Router) {
+			r.Get("/", GetAlert)
+			r.Put("/", UpdateAlert)
+			r.Patch("/", PatchAlert)
+			r.Delete("/", DeleteAlert)
+
+			// Status subresource
+			r.Route("/status", func(r chi.Router) {
+				r.Put("/", UpdateAlertStatus)
+				r.Patch("/", PatchAlertStatus)
+			})
+		})
+	})
+
 	// OpenAPI documentation routes
 	r.Get("/openapi.json", ServeOpenAPISpec)
 	r.Get("/docs", ServeSwaggerUI)
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// recordRunIDs traces collection runs through snapshot creation: the run ID
+// a collector sends in the X-Run-ID header is logged, and stored in the
+// snapshot's provenance when the body does not carry one already, so that
+// the reconciler can log it too.
+func recordRunIDs(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runID := r.Header.Get(client.RunIDHeader)
+		if runID == "" || r.Method != http.MethodPost || strings.TrimSuffix(r.URL.Path, "/") != "/discoverysnapshots" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+			return
+		}
+		var req map[string]json.RawMessage
+		if json.Unmarshal(body, &req) == nil {
+			var provenance discoverysnapshot.Provenance
+			json.Unmarshal(req["provenance"], &provenance)
+			if provenance.RunID == "" {
+				provenance.RunID = runID
+				req["provenance"], _ = json.Marshal(provenance)
+				if tagged, err := json.Marshal(req); err == nil {
+					body = tagged
+				}
+			} else if provenance.RunID != runID {
+				log.Printf("Snapshot from %s: header run %s differs from provenance run %s", ingest.clientAddress(r), runID, provenance.RunID)
+			}
+		}
+		log.Printf("Snapshot from %s (run %s)", ingest.clientAddress(r), runID)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
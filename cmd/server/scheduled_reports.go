@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/reports"
+	"github.com/example/inventory-v3/pkg/tlspolicy"
+)
+
+// reportScheduler renders the scheduled reports and sends them to every
+// configured destination.
+type reportScheduler struct {
+	options      reports.BundleOptions
+	destinations []reports.Destination
+
+	mu   sync.Mutex
+	last *reports.Bundle
+}
+
+// scheduler is the server's report scheduler, set up in runServer.
+var scheduler = &reportScheduler{
+	options: reports.BundleOptions{Period: 7 * 24 * time.Hour, MissingAfter: reports.DefaultMissingAfter},
+}
+
+// Run renders the reports now and delivers them. A failed destination does
+// not stop delivery to the others; its error is recorded in the bundle.
+func (s *reportScheduler) Run(ctx context.Context) (*reports.Bundle, error) {
+	devices, err := storage.LoadAllDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	blackout, err := loadFleetBlackout(ctx, devices, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	bundle := reports.BuildBundle(devices, blackout, s.options, time.Now())
+
+	for _, dest := range s.destinations {
+		delivery := reports.Delivery{Destination: dest.Name()}
+		if err := dest.Deliver(ctx, bundle); err != nil {
+			delivery.Error = err.Error()
+			log.Printf("Scheduled report delivery to %s failed: %v", dest.Name(), err)
+		}
+		bundle.Deliveries = append(bundle.Deliveries, delivery)
+	}
+
+	s.mu.Lock()
+	s.last = bundle
+	s.mu.Unlock()
+	return bundle, nil
+}
+
+// Start runs the reports every interval until ctx is done. Unlike the
+// consistency check, the first run waits a full interval so that restarts do
+// not resend the reports.
+func (s *reportScheduler) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if _, err := s.Run(ctx); err != nil {
+				log.Printf("Scheduled reports failed: %v", err)
+			}
+		}
+	}()
+}
+
+// RunScheduledReports renders and delivers the scheduled reports now.
+func RunScheduledReports(w http.ResponseWriter, r *http.Request) {
+	bundle, err := scheduler.Run(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, bundle)
+}
+
+// GetLastScheduledReport returns the bundle of the last scheduled run.
+func GetLastScheduledReport(w http.ResponseWriter, r *http.Request) {
+	scheduler.mu.Lock()
+	bundle := scheduler.last
+	scheduler.mu.Unlock()
+	if bundle == nil {
+		respondError(w, http.StatusNotFound, fmt.Errorf("no scheduled report has run yet"))
+		return
+	}
+	respondJSON(w, http.StatusOK, bundle)
+}
+
+// GetNewHardwareReport returns the devices first seen in the last "days"
+// days (default: the scheduled report period).
+func GetNewHardwareReport(w http.ResponseWriter, r *http.Request) {
+	period, ok := daysParam(w, r, scheduler.options.Period)
+	if !ok {
+		return
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	if devices, ok = scopeToGroup(w, r, devices); !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.NewHardware(devices, time.Now().Add(-period)))
+}
+
+// GetMissingDevicesReport returns the devices not seen for more than "days"
+// days (default: the configured missing-after period).
+func GetMissingDevicesReport(w http.ResponseWriter, r *http.Request) {
+	missingAfter, ok := daysParam(w, r, scheduler.options.MissingAfter)
+	if !ok {
+		return
+	}
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	// The blackout is resolved over the fleet, as a rack covers what is in it
+	blackout, err := loadFleetBlackout(r.Context(), devices, time.Now())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if devices, ok = scopeToGroup(w, r, devices); !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.MissingDevices(devices, blackout, missingAfter, time.Now()))
+}
+
+// GetFirmwareComplianceReport compares device firmware with the configured
+// firmware baseline.
+func GetFirmwareComplianceReport(w http.ResponseWriter, r *http.Request) {
+	devices, err := storage.LoadAllDevices(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load devices: %w", err))
+		return
+	}
+	devices, ok := scopeToGroup(w, r, devices)
+	if !ok {
+		return
+	}
+	respondJSON(w, http.StatusOK, reports.FirmwareCompliance(devices, scheduler.options.Baseline))
+}
+
+// daysParam parses the optional "days" query parameter. On a bad value it
+// writes a 400 response and returns false.
+func daysParam(w http.ResponseWriter, r *http.Request, def time.Duration) (time.Duration, bool) {
+	raw := r.URL.Query().Get("days")
+	if raw == "" {
+		return def, true
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days <= 0 {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid days %q: must be a positive integer", raw))
+		return 0, false
+	}
+	return time.Duration(days) * 24 * time.Hour, true
+}
+
+// TLSPolicy returns the policy for outbound TLS connections.
+func (c *Config) TLSPolicy() (tlspolicy.Policy, error) {
+	policy, err := tlspolicy.Parse(c.TLSMinVersion, c.TLSCipherSuites, c.TLSFIPS)
+	if err != nil {
+		return tlspolicy.Policy{}, fmt.Errorf("invalid TLS policy: %w", err)
+	}
+	return policy, nil
+}
+
+// setupScheduledReports configures the scheduler's reports and destinations.
+// Every destination connects under the configured TLS policy.
+func setupScheduledReports(cfg *Config) error {
+	tlsPolicy, err := cfg.TLSPolicy()
+	if err != nil {
+		return err
+	}
+	scheduler.options.Period = time.Duration(cfg.ReportInterval) * time.Hour
+	if cfg.ReportInterval <= 0 {
+		scheduler.options.Period = 7 * 24 * time.Hour
+	}
+	scheduler.options.MissingAfter = time.Duration(cfg.MissingAfterDays) * 24 * time.Hour
+
+	scheduler.options.Reports = nil
+	for _, name := range splitList(cfg.ScheduledReports) {
+		if !reports.ValidReport(name) {
+			return fmt.Errorf("unknown report %q (valid: %v)", name, reports.ScheduledReports)
+		}
+		scheduler.options.Reports = append(scheduler.options.Reports, name)
+	}
+
+	if cfg.FirmwareBaseline != "" {
+		baseline, err := reports.LoadFirmwareBaseline(cfg.FirmwareBaseline)
+		if err != nil {
+			return err
+		}
+		scheduler.options.Baseline = baseline
+	}
+
+	scheduler.destinations = nil
+	if cfg.ReportSMTPAddr != "" {
+		to := splitList(cfg.ReportSMTPTo)
+		if cfg.ReportSMTPFrom == "" || len(to) == 0 {
+			return fmt.Errorf("report-smtp-addr requires report-smtp-from and report-smtp-to")
+		}
+		scheduler.destinations = append(scheduler.destinations, &reports.SMTPDestination{
+			Addr:      cfg.ReportSMTPAddr,
+			From:      cfg.ReportSMTPFrom,
+			To:        to,
+			Username:  cfg.ReportSMTPUsername,
+			Password:  cfg.ReportSMTPPassword,
+			TLSConfig: tlsPolicy.Apply(nil),
+		})
+	}
+	if cfg.ReportWebhookURL != "" {
+		scheduler.destinations = append(scheduler.destinations, &reports.WebhookDestination{
+			URL:    cfg.ReportWebhookURL,
+			Client: &http.Client{Timeout: 30 * time.Second, Transport: tlsPolicy.Transport(nil)},
+		})
+	}
+	if cfg.ReportS3URL != "" {
+		scheduler.destinations = append(scheduler.destinations, &reports.S3Destination{
+			URL:             cfg.ReportS3URL,
+			Region:          cfg.ReportS3Region,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			Client:          &http.Client{Timeout: 60 * time.Second, Transport: tlsPolicy.Transport(nil)},
+		})
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// ApproveDiscoverySnapshot applies a snapshot the change guard held: the
+// reconciler processes it as if it had not been held. The optional body
+// ({"by", "reason"}) is recorded on the snapshot.
+func ApproveDiscoverySnapshot(w http.ResponseWriter, r *http.Request) {
+	reviewDiscoverySnapshot(w, r, true)
+}
+
+// RejectDiscoverySnapshot discards a snapshot the change guard held; the
+// reconciler leaves it unapplied, in phase Rejected.
+func RejectDiscoverySnapshot(w http.ResponseWriter, r *http.Request) {
+	reviewDiscoverySnapshot(w, r, false)
+}
+
+func reviewDiscoverySnapshot(w http.ResponseWriter, r *http.Request, approved bool) {
+	var review discoverysnapshot.Review
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	review.Approved, review.At = approved, time.Now()
+
+	uid := chi.URLParam(r, "uid")
+	snapshot, err := storage.LoadDiscoverySnapshot(r.Context(), uid)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		respondError(w, http.StatusNotFound, fmt.Errorf("DiscoverySnapshot not found: %s", uid))
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to load the snapshot: %w", err))
+		return
+	}
+	if snapshot.Status.Phase != discoverysnapshot.PhaseHeld {
+		respondError(w, http.StatusConflict, fmt.Errorf("DiscoverySnapshot %s is not held for approval (phase %q)", uid, snapshot.Status.Phase))
+		return
+	}
+
+	snapshot.Status.Review = &review
+	if approved {
+		snapshot.Status.Phase = ""
+		snapshot.Status.Message = "Approved; queued for reconciliation."
+	} else {
+		snapshot.Status.Phase = "Rejected"
+		snapshot.Status.Message = "Rejected by an operator."
+	}
+	if review.Reason != "" {
+		snapshot.Status.Message += " " + review.Reason
+	}
+	snapshot.Metadata.UpdatedAt = review.At
+	if err := storage.UpdateDiscoverySnapshot(r.Context(), snapshot); err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to save the snapshot: %w", err))
+		return
+	}
+	if approved {
+		if err := reconcilers.Requeue("DiscoverySnapshot", uid); err != nil {
+			respondError(w, http.StatusInternalServerError, fmt.Errorf("failed to queue the snapshot: %w", err))
+			return
+		}
+	}
+	respondJSON(w, http.StatusOK, snapshot)
+}
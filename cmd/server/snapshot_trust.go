@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/example/inventory-v3/pkg/reconcilers"
+	"github.com/example/inventory-v3/pkg/signing"
+)
+
+// setupSnapshotTrust loads the keys trusted to sign snapshots for the
+// DiscoverySnapshot reconciler.
+func setupSnapshotTrust(cfg *Config) error {
+	if cfg.SnapshotTrustedKeys == "" {
+		if cfg.RequireSignedSnapshots {
+			return fmt.Errorf("require-signed-snapshots needs snapshot-trusted-keys")
+		}
+		return nil
+	}
+	keys, err := signing.LoadKeyRing(cfg.SnapshotTrustedKeys)
+	if err != nil {
+		return err
+	}
+	reconcilers.SetSnapshotTrust(keys, cfg.RequireSignedSnapshots)
+	if cfg.RequireSignedSnapshots {
+		log.Printf("Snapshots must be signed by one of %d trusted keys", keys.Len())
+	} else {
+		log.Printf("Signed snapshots are verified against %d trusted keys", keys.Len())
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package contract holds the wire contract between the collector and the
+// server's DiscoverySnapshot reconciler. A snapshot's RawData is a JSON list
+// of device.DeviceSpec whose properties (redfish_uri above all) and parent
+// serial numbers the reconciler relies on, but nothing but convention ties
+// the two sides together. The golden files in testdata are the RawData the
+// collector's mapper produces for the redfishmock fixtures below: the
+// collector's tests fail when its output no longer matches them, and the
+// reconciler's tests reconcile exactly those payloads.
+//
+// After an intended change to the collector's output, rewrite the files with
+//
+//	go test ./pkg/collector -run TestGoldenPayloads -update
+//
+// and check that the reconciler's tests still pass.
+package contract
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+//go:embed testdata/*.json
+var golden embed.FS
+
+// Fixture is a fake BMC collected with a profile.
+type Fixture struct {
+	// Name names the fixture's golden file.
+	Name    string
+	Node    redfishmock.Node
+	Profile string
+}
+
+// Fixtures are the collections the golden payloads record.
+var Fixtures = []Fixture{
+	{Name: "node-full", Node: redfishmock.DefaultNode("GOLD0001"), Profile: bmcendpoint.ProfileFull},
+	{Name: "node-quick", Node: redfishmock.DefaultNode("GOLD0002"), Profile: bmcendpoint.ProfileQuick},
+	{Name: "diskless-full", Node: diskless("GOLD0003"), Profile: bmcendpoint.ProfileFull},
+}
+
+// diskless is a node without drives and with two NICs.
+func diskless(serial string) redfishmock.Node {
+	n := redfishmock.DefaultNode(serial)
+	n.Drives, n.NICs = 0, 2
+	return n
+}
+
+// Payload returns the committed RawData of the named fixture.
+func Payload(name string) ([]byte, error) {
+	data, err := golden.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no golden payload for fixture %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// PayloadFile returns the path of the named fixture's golden file in the
+// source tree, for rewriting it.
+func PayloadFile(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata", name+".json")
+}
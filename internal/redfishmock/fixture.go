@@ -0,0 +1,182 @@
+package redfishmock
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node describes the single-node BMC Fixture builds.
+type Node struct {
+	// Serial is the node's serial number; its components' serials derive
+	// from it.
+	Serial       string
+	Manufacturer string
+	Model        string
+	CPUs         int
+	DIMMs        int
+	Drives       int
+	NICs         int
+}
+
+// DefaultNode is a two-socket node with eight DIMMs, two drives and a NIC.
+func DefaultNode(serial string) Node {
+	return Node{Serial: serial, Manufacturer: "Contoso", Model: "CX-2200", CPUs: 2, DIMMs: 8, Drives: 2, NICs: 1}
+}
+
+// Fixture returns the Redfish tree of a BMC managing one node: the service
+// root, the system and its processors, memory, storage and network
+// adapters, the chassis and the manager with its network configuration, and
+// an account service holding the admin account.
+func Fixture(n Node) map[string]interface{} {
+	system := Root + "/Systems/1"
+	chassis := Root + "/Chassis/1"
+	manager := Root + "/Managers/1"
+	tree := map[string]interface{}{
+		Root: map[string]interface{}{
+			"@odata.id":      Root,
+			"Vendor":         n.Manufacturer,
+			"Product":        n.Model + " BMC",
+			"RedfishVersion": "1.15.0",
+			"Systems":        link(Root + "/Systems"),
+			"Chassis":        link(Root + "/Chassis"),
+			"Managers":       link(Root + "/Managers"),
+			"SessionService": link(Root + "/SessionService"),
+			"AccountService": link(Root + "/AccountService"),
+		},
+		Root + "/SessionService":          map[string]interface{}{"Sessions": link(Root + "/SessionService/Sessions")},
+		Root + "/SessionService/Sessions": collection(),
+		Root + "/AccountService":          map[string]interface{}{"Accounts": link(Root + "/AccountService/Accounts")},
+		Root + "/AccountService/Accounts": collection(Root + "/AccountService/Accounts/1"),
+		Root + "/AccountService/Accounts/1": map[string]interface{}{
+			"@odata.id": Root + "/AccountService/Accounts/1",
+			"Id":        "1",
+			"UserName":  "admin",
+			"RoleId":    "Administrator",
+			"Enabled":   true,
+		},
+		Root + "/Systems":  collection(system),
+		Root + "/Chassis":  collection(chassis),
+		Root + "/Managers": collection(manager),
+		system: map[string]interface{}{
+			"@odata.id":    system,
+			"Manufacturer": n.Manufacturer,
+			"Model":        n.Model,
+			"PartNumber":   n.Model + "-A",
+			"SerialNumber": n.Serial,
+			"PowerState":   "On",
+			"BiosVersion":  "2.4.1",
+			"Processors":   link(system + "/Processors"),
+			"Memory":       link(system + "/Memory"),
+			"Storage":      link(system + "/Storage"),
+			"Links": map[string]interface{}{
+				"Chassis":   []interface{}{link(chassis)},
+				"ManagedBy": []interface{}{link(manager)},
+			},
+		},
+		chassis: map[string]interface{}{
+			"@odata.id":       chassis,
+			"ChassisType":     "RackMount",
+			"Manufacturer":    n.Manufacturer,
+			"SerialNumber":    n.Serial + "-CH",
+			"NetworkAdapters": link(chassis + "/NetworkAdapters"),
+		},
+		manager: map[string]interface{}{
+			"@odata.id":          manager,
+			"Manufacturer":       n.Manufacturer,
+			"Model":              n.Model + " BMC",
+			"FirmwareVersion":    "1.8.0",
+			"DateTime":           "",
+			"NetworkProtocol":    link(manager + "/NetworkProtocol"),
+			"EthernetInterfaces": link(manager + "/EthernetInterfaces"),
+		},
+		manager + "/NetworkProtocol": map[string]interface{}{
+			"@odata.id": manager + "/NetworkProtocol",
+			"HostName":  "bmc-" + strings.ToLower(n.Serial),
+			"NTP": map[string]interface{}{
+				"ProtocolEnabled": true,
+				"NTPServers":      []interface{}{"ntp1.example.com", "ntp2.example.com", ""},
+			},
+		},
+		manager + "/EthernetInterfaces": collection(manager + "/EthernetInterfaces/1"),
+		manager + "/EthernetInterfaces/1": map[string]interface{}{
+			"@odata.id":        manager + "/EthernetInterfaces/1",
+			"InterfaceEnabled": true,
+			"MACAddress":       "02:00:00:00:00:01",
+			"DHCPv4":           map[string]interface{}{"DHCPEnabled": true},
+			"IPv4Addresses": []interface{}{
+				map[string]interface{}{"Address": "192.0.2.10", "SubnetMask": "255.255.255.0", "AddressOrigin": "DHCP"},
+			},
+		},
+	}
+
+	members := func(collectionPath string, count int, member func(i int, path string) map[string]interface{}) {
+		var paths []string
+		for i := 1; i <= count; i++ {
+			path := fmt.Sprintf("%s/%d", collectionPath, i)
+			resource := member(i, path)
+			resource["@odata.id"] = path
+			tree[path] = resource
+			paths = append(paths, path)
+		}
+		tree[collectionPath] = collection(paths...)
+	}
+	members(system+"/Processors", n.CPUs, func(i int, path string) map[string]interface{} {
+		return map[string]interface{}{
+			"Manufacturer":  "Intel(R) Corporation",
+			"Model":         "Xeon Gold 6430",
+			"SerialNumber":  fmt.Sprintf("%s-CPU%d", n.Serial, i),
+			"ProcessorType": "CPU",
+			"Socket":        fmt.Sprintf("CPU %d", i),
+			"TotalCores":    32,
+			"TotalThreads":  64,
+		}
+	})
+	members(system+"/Memory", n.DIMMs, func(i int, path string) map[string]interface{} {
+		return map[string]interface{}{
+			"Manufacturer":      "Hynix",
+			"PartNumber":        "HMCG94AEBRA",
+			"SerialNumber":      fmt.Sprintf("%s-DIMM%d", n.Serial, i),
+			"CapacityMiB":       32768,
+			"MemoryDeviceType":  "DDR5",
+			"OperatingSpeedMhz": 4800,
+			"DeviceLocator":     fmt.Sprintf("DIMM %d", i),
+		}
+	})
+	var drives []interface{}
+	for i := 1; i <= n.Drives; i++ {
+		path := fmt.Sprintf("%s/Storage/1/Drives/%d", system, i)
+		tree[path] = map[string]interface{}{
+			"@odata.id":     path,
+			"Manufacturer":  "Samsung",
+			"Model":         "PM9A3",
+			"SerialNumber":  fmt.Sprintf("%s-NVME%d", n.Serial, i),
+			"Protocol":      "NVMe",
+			"MediaType":     "SSD",
+			"CapacityBytes": 3840755982336,
+		}
+		drives = append(drives, link(path))
+	}
+	tree[system+"/Storage"] = collection(system + "/Storage/1")
+	tree[system+"/Storage/1"] = map[string]interface{}{"@odata.id": system + "/Storage/1", "Drives": drives}
+	members(chassis+"/NetworkAdapters", n.NICs, func(i int, path string) map[string]interface{} {
+		return map[string]interface{}{
+			"Manufacturer": "Mellanox Technologies",
+			"Model":        "ConnectX-7",
+			"PartNumber":   "MCX75310AAS",
+			"SerialNumber": fmt.Sprintf("%s-NIC%d", n.Serial, i),
+		}
+	})
+	return tree
+}
+
+func link(path string) map[string]interface{} {
+	return map[string]interface{}{"@odata.id": path}
+}
+
+func collection(paths ...string) map[string]interface{} {
+	members := make([]interface{}, 0, len(paths))
+	for _, path := range paths {
+		members = append(members, link(path))
+	}
+	return map[string]interface{}{"Members": members, "Members@odata.count": len(members)}
+}
@@ -0,0 +1,481 @@
+// Package redfishmock serves a fake BMC's Redfish tree, so the collector can
+// be run without hardware. Faults make it misbehave the way real BMCs do:
+// slow or failing responses, truncated JSON, sessions that expire and
+// collections whose later pages cannot be reached. Random faults draw from a
+// seeded source, so a run with the same seed and requests fails the same way.
+package redfishmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Root is the path of the Redfish service root.
+const Root = "/redfish/v1"
+
+// Faults configures the faults a Server injects. The zero value injects none.
+type Faults struct {
+	// Latency delays every response, plus up to Jitter more at random.
+	Latency time.Duration
+	Jitter  time.Duration
+	// ErrorRate is the share of requests answered 500 Internal Server Error.
+	ErrorRate float64
+	// TruncateRate is the share of responses cut off halfway through their
+	// JSON body.
+	TruncateRate float64
+	// AuthFlapEvery answers every nth authenticated request 401
+	// Unauthorized, as a BMC whose sessions expire early; 0 never does.
+	AuthFlapEvery int
+	// DropNextLink leaves Members@odata.nextLink out of collection pages, so
+	// only the first page of a paged collection is reachable.
+	DropNextLink bool
+	// TaskRate is the share of requests answered 202 Accepted with a task
+	// monitor, as a busy BMC does; the monitor answers 202 for TaskPolls
+	// polls and then with the resource.
+	TaskRate  float64
+	TaskPolls int
+	// TaskHost, e.g. "https://other:8443", points task monitors at another
+	// host, as a rogue or compromised BMC might.
+	TaskHost string
+	// Paths limits the faults to the paths under these prefixes (e.g.
+	// "/redfish/v1/Systems/1/Memory"); empty applies them everywhere. The
+	// service root and session service are never faulted, since a collector
+	// cannot start without them.
+	Paths []string
+	// Seed seeds the random faults.
+	Seed int64
+}
+
+// Stats counts what a Server has served.
+type Stats struct {
+	Requests  int `json:"requests"`
+	Errors    int `json:"errors"`
+	Truncated int `json:"truncated"`
+	AuthFlaps int `json:"authFlaps"`
+	Sessions  int `json:"sessions"`
+	Tasks     int `json:"tasks"`
+}
+
+// Server is an http.Handler serving a Redfish tree. Serve it over TLS, as
+// the collector only speaks HTTPS to BMCs.
+type Server struct {
+	// Username and Password are the BMC login, taken with basic auth or a
+	// session; an empty Username accepts anyone.
+	Username string
+	Password string
+	// PageSize splits collections into pages linked by nextLink; 0 serves
+	// each in one page.
+	PageSize int
+	// ClockSkew sets the clock resources report in DateTime this far off
+	// the real time.
+	ClockSkew time.Duration
+	// MinPasswordLength refuses account passwords shorter than this, as a
+	// BMC's password policy does.
+	MinPasswordLength int
+	// FixedAccounts refuses to create accounts, as BMCs with a fixed table
+	// of account slots do; an account is set by patching an empty slot.
+	FixedAccounts bool
+
+	mu        sync.Mutex
+	resources map[string]json.RawMessage
+	faults    Faults
+	rng       *rand.Rand
+	sessions  map[string]bool
+	accounts  map[string]string
+	nextID    int
+	tasks     map[string]*task
+	stats     Stats
+}
+
+// task is a request answered through a task monitor.
+type task struct {
+	path  string
+	skip  string
+	polls int
+}
+
+// New returns a server for the resources, keyed by path under Root (e.g.
+// "/redfish/v1/Systems/1"). Values are encoded as JSON.
+func New(resources map[string]interface{}) (*Server, error) {
+	s := &Server{resources: make(map[string]json.RawMessage, len(resources)), sessions: map[string]bool{}, accounts: map[string]string{}, tasks: map[string]*task{}}
+	for path, resource := range resources {
+		body, err := json.Marshal(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+		s.resources[strings.TrimSuffix(path, "/")] = body
+	}
+	s.SetFaults(Faults{})
+	return s, nil
+}
+
+// SetFaults replaces the server's faults and reseeds its random source.
+func (s *Server) SetFaults(f Faults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = f
+	s.rng = rand.New(rand.NewSource(f.Seed))
+}
+
+// Stats returns what the server has served so far.
+func (s *Server) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// ServeHTTP serves the Redfish tree, logins and the configured faults.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	sessions := Root + "/SessionService/Sessions"
+	accounts := Root + "/AccountService/Accounts"
+
+	s.mu.Lock()
+	s.stats.Requests++
+	s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && path == sessions:
+		s.createSession(w, r)
+		return
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, sessions+"/"):
+		s.mu.Lock()
+		delete(s.sessions, r.Header.Get("X-Auth-Token"))
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case r.Method == http.MethodPost && path == accounts, r.Method == http.MethodPatch && strings.HasPrefix(path, accounts+"/"):
+		if !s.authorized(r) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodPost && s.FixedAccounts {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.writeAccount(w, r, path)
+		return
+	case r.Method != http.MethodGet:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The service root is readable without logging in, as Redfish requires
+	if path != Root && !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if strings.HasPrefix(path, taskMonitors+"/") {
+		s.pollTask(w, path)
+		return
+	}
+	body, ok := s.resources[path]
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	faulted := s.faulted(path)
+	delay, fail, truncate, flap := s.roll(faulted)
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	switch {
+	case flap:
+		http.Error(w, "session expired", http.StatusUnauthorized)
+		return
+	case fail:
+		http.Error(w, "injected fault", http.StatusInternalServerError)
+		return
+	}
+	if s.rollTask(faulted) {
+		s.startTask(w, path, r.URL.Query().Get("$skip"))
+		return
+	}
+
+	body = s.page(body, path, r.URL.Query().Get("$skip"), faulted)
+	body = s.clock(body)
+	w.Header().Set("Content-Type", "application/json")
+	if truncate {
+		body = body[:len(body)/2]
+	}
+	w.Write(body)
+}
+
+// clock sets the DateTime of a resource that reports one to the server's
+// time, as a BMC reports its clock.
+func (s *Server) clock(body json.RawMessage) json.RawMessage {
+	if !bytes.Contains(body, []byte(`"DateTime"`)) {
+		return body
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return body
+	}
+	if _, ok := resource["DateTime"]; !ok {
+		return body
+	}
+	resource["DateTime"] = time.Now().Add(s.ClockSkew).Format(time.RFC3339Nano)
+	clocked, err := json.Marshal(resource)
+	if err != nil {
+		return body
+	}
+	return clocked
+}
+
+// faulted reports whether faults apply to path.
+func (s *Server) faulted(path string) bool {
+	if path == Root || strings.HasPrefix(path, Root+"/SessionService") {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.faults.Paths) == 0 {
+		return true
+	}
+	for _, prefix := range s.faults.Paths {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// roll decides the faults of one request.
+func (s *Server) roll(faulted bool) (delay time.Duration, fail, truncate, flap bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !faulted {
+		return 0, false, false, false
+	}
+	f := s.faults
+	delay = f.Latency
+	if f.Jitter > 0 {
+		delay += time.Duration(s.rng.Int63n(int64(f.Jitter)))
+	}
+	if f.AuthFlapEvery > 0 && s.stats.Requests%f.AuthFlapEvery == 0 {
+		s.stats.AuthFlaps++
+		return delay, false, false, true
+	}
+	if f.ErrorRate > 0 && s.rng.Float64() < f.ErrorRate {
+		s.stats.Errors++
+		return delay, true, false, false
+	}
+	if f.TruncateRate > 0 && s.rng.Float64() < f.TruncateRate {
+		s.stats.Truncated++
+		truncate = true
+	}
+	return delay, false, truncate, false
+}
+
+// taskMonitors is where the task monitors of deferred requests live.
+const taskMonitors = Root + "/TaskService/TaskMonitors"
+
+// rollTask decides whether a request is deferred to a task.
+func (s *Server) rollTask(faulted bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return faulted && s.faults.TaskRate > 0 && s.rng.Float64() < s.faults.TaskRate
+}
+
+// startTask answers 202 Accepted with the monitor of a new task for a request.
+func (s *Server) startTask(w http.ResponseWriter, path, skip string) {
+	s.mu.Lock()
+	s.nextID++
+	monitor := fmt.Sprintf("%s/%d", taskMonitors, s.nextID)
+	s.tasks[monitor] = &task{path: path, skip: skip, polls: s.faults.TaskPolls}
+	s.stats.Tasks++
+	location := s.faults.TaskHost + monitor
+	s.mu.Unlock()
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// pollTask answers a task monitor: 202 while the task runs, then the
+// deferred request's response, after which the monitor is gone.
+func (s *Server) pollTask(w http.ResponseWriter, monitor string) {
+	s.mu.Lock()
+	t, ok := s.tasks[monitor]
+	running := ok && t.polls > 0
+	switch {
+	case running:
+		t.polls--
+	case ok:
+		delete(s.tasks, monitor)
+	}
+	s.mu.Unlock()
+	switch {
+	case !ok:
+		http.Error(w, "not found", http.StatusNotFound)
+	case running:
+		w.Header().Set("Location", monitor)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.page(s.resources[t.path], t.path, t.skip, true))
+	}
+}
+
+// page returns one page of a collection, or body itself for other resources.
+func (s *Server) page(body json.RawMessage, path, skipParam string, faulted bool) json.RawMessage {
+	if s.PageSize <= 0 {
+		return body
+	}
+	var collection map[string]json.RawMessage
+	if json.Unmarshal(body, &collection) != nil {
+		return body
+	}
+	var members []json.RawMessage
+	if json.Unmarshal(collection["Members"], &members) != nil || len(members) <= s.PageSize {
+		return body
+	}
+	skip, _ := strconv.Atoi(skipParam)
+	if skip < 0 || skip > len(members) {
+		skip = len(members)
+	}
+	end := min(skip+s.PageSize, len(members))
+	collection["Members"], _ = json.Marshal(members[skip:end])
+	collection["Members@odata.count"], _ = json.Marshal(len(members))
+	delete(collection, "Members@odata.nextLink")
+	s.mu.Lock()
+	dropNextLink := faulted && s.faults.DropNextLink
+	s.mu.Unlock()
+	if end < len(members) && !dropNextLink {
+		collection["Members@odata.nextLink"], _ = json.Marshal(fmt.Sprintf("%s?$skip=%d", path, end))
+	}
+	paged, err := json.Marshal(collection)
+	if err != nil {
+		return body
+	}
+	return paged
+}
+
+// authorized checks the request's session token or basic auth.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.Username == "" {
+		return true
+	}
+	if token := r.Header.Get("X-Auth-Token"); token != "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.sessions[token]
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && s.login(username, password)
+}
+
+// login checks a user name and password against the BMC login and the
+// accounts added through the AccountService.
+func (s *Server) login(username, password string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stored, ok := s.accounts[username]; ok {
+		return password == stored
+	}
+	return username == s.Username && password == s.Password
+}
+
+// writeAccount creates an account (POST to the Accounts collection) or
+// updates one (PATCH). Passwords are kept out of the served resource, as
+// Redfish requires, and accepted by later logins.
+func (s *Server) writeAccount(w http.ResponseWriter, r *http.Request, path string) {
+	var fields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if password, ok := fields["Password"].(string); ok && len(password) < s.MinPasswordLength {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{
+			"code":    "Base.1.8.GeneralError",
+			"message": "A general error has occurred.",
+			"@Message.ExtendedInfo": []map[string]string{{
+				"MessageId": "Base.1.8.PropertyValueFormatError",
+				"Message":   fmt.Sprintf("The password must be at least %d characters long.", s.MinPasswordLength),
+			}},
+		}})
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account := map[string]interface{}{}
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		var list struct {
+			Members []map[string]string `json:"Members"`
+		}
+		json.Unmarshal(s.resources[path], &list)
+		s.nextID++
+		id := fmt.Sprintf("%d", 100+s.nextID)
+		account["Id"] = id
+		member := path + "/" + id
+		list.Members = append(list.Members, map[string]string{"@odata.id": member})
+		collection, _ := json.Marshal(map[string]interface{}{"Members": list.Members, "Members@odata.count": len(list.Members)})
+		s.resources[path] = collection
+		path = member
+		account["@odata.id"] = path
+		status = http.StatusCreated
+		w.Header().Set("Location", path)
+	} else {
+		body, ok := s.resources[path]
+		if !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		json.Unmarshal(body, &account)
+	}
+	password, hasPassword := fields["Password"].(string)
+	delete(fields, "Password")
+	for key, value := range fields {
+		account[key] = value
+	}
+	if username, _ := account["UserName"].(string); username != "" && hasPassword {
+		s.accounts[username] = password
+	}
+	body, _ := json.Marshal(account)
+	s.resources[path] = body
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// createSession logs in with the credentials in the request body.
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	var login struct {
+		UserName string
+		Password string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&login); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if s.Username != "" && !s.login(login.UserName, login.Password) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	token := fmt.Sprintf("token-%d", id)
+	s.sessions[token] = true
+	s.stats.Sessions++
+	s.mu.Unlock()
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", fmt.Sprintf("%s/SessionService/Sessions/%d", Root, id))
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, `{"Id":"%d","UserName":%q}`, id, login.UserName)
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/example/inventory-v3/pkg/fleetstats"
+)
+
+// fleetStatsKind is the storage kind of the daily fleet records. They are
+// not resources: they have no API of their own and are keyed by date.
+const fleetStatsKind = "FleetStats"
+
+// LoadAllFleetStats retrieves every daily fleet record.
+func LoadAllFleetStats(ctx context.Context) ([]*fleetstats.Day, error) {
+	ensureBackend()
+	rawData, err := Backend.LoadAll(ctx, fleetStatsKind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fleet stats: %w", err)
+	}
+	days := make([]*fleetstats.Day, 0, len(rawData))
+	for _, raw := range rawData {
+		var day fleetstats.Day
+		if err := json.Unmarshal(raw, &day); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal fleet stats: %w", err)
+		}
+		days = append(days, &day)
+	}
+	return days, nil
+}
+
+// LoadFleetStats retrieves the record of a date (YYYY-MM-DD), or nil if there
+// is none.
+func LoadFleetStats(ctx context.Context, date string) (*fleetstats.Day, error) {
+	ensureBackend()
+	raw, err := Backend.Load(ctx, fleetStatsKind, date)
+	if errors.Is(err, fabricaStorage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fleet stats of %s: %w", date, err)
+	}
+	var day fleetstats.Day
+	if err := json.Unmarshal(raw, &day); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fleet stats of %s: %w", date, err)
+	}
+	return &day, nil
+}
+
+// SaveFleetStats creates or replaces the record of a day.
+func SaveFleetStats(ctx context.Context, day *fleetstats.Day) error {
+	ensureBackend()
+	data, err := json.Marshal(day)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fleet stats: %w", err)
+	}
+	return Backend.Save(ctx, fleetStatsKind, day.Date, data)
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// DeviceIndex is implemented by backends that can look devices up by their
+// identifying fields without loading the whole inventory. Backends without it
+// are served by a full scan.
+type DeviceIndex interface {
+	FindDevicesBySerial(ctx context.Context, serial string) ([]*device.Device, error)
+	FindDevicesByRedfishURI(ctx context.Context, uri string) ([]*device.Device, error)
+	// FindDevicesByCanonicalURI returns the devices whose "canonical_uri"
+	// property (see identity.CanonicalURI) is uri.
+	FindDevicesByCanonicalURI(ctx context.Context, uri string) ([]*device.Device, error)
+	// FindDevicesByCompositeKey returns the devices whose "composite_key"
+	// property (see identity.CompositeKey) is key.
+	FindDevicesByCompositeKey(ctx context.Context, key string) ([]*device.Device, error)
+	FindDevicesByType(ctx context.Context, deviceType string) ([]*device.Device, error)
+	// FindDevicesByName returns the devices named name (Metadata.Name).
+	FindDevicesByName(ctx context.Context, name string) ([]*device.Device, error)
+	// FindDevicesBySource returns the devices last reported by the BMC at address.
+	FindDevicesBySource(ctx context.Context, address string) ([]*device.Device, error)
+	// FindDevicesByMAC returns the devices whose "mac_addresses" property
+	// contains mac, which must be normalized (lowercase, colon-separated).
+	FindDevicesByMAC(ctx context.Context, mac string) ([]*device.Device, error)
+}
+
+// Index returns the configured backend's DeviceIndex, if it has one.
+func Index() (DeviceIndex, bool) {
+	ensureBackend()
+	index, ok := Backend.(DeviceIndex)
+	return index, ok
+}
+
+// DeviceIndex returns the client's backend DeviceIndex, if it has one.
+func (c *StorageClient) DeviceIndex() (DeviceIndex, bool) {
+	index, ok := c.backend.(DeviceIndex)
+	return index, ok
+}
+
+// decodeDevices unmarshals raw Device resources.
+func decodeDevices(rawData []json.RawMessage) ([]*device.Device, error) {
+	devices := make([]*device.Device, 0, len(rawData))
+	for _, raw := range rawData {
+		var d device.Device
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Device: %w", err)
+		}
+		devices = append(devices, &d)
+	}
+	return devices, nil
+}
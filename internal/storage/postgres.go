@@ -0,0 +1,253 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	// Registers the "pgx" database/sql driver.
+	_ "github.com/jackc/pgx/v5/stdlib"
+	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// postgresSchema creates the resource table and the Device lookup indexes.
+// Every resource kind shares one table; the indexes are partial so they only
+// cover Device rows.
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS resources (
+	resource_type TEXT        NOT NULL,
+	uid           TEXT        NOT NULL,
+	data          JSONB       NOT NULL,
+	updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (resource_type, uid)
+);
+CREATE INDEX IF NOT EXISTS resources_device_serial_idx
+	ON resources ((data->'spec'->>'serialNumber')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_redfish_uri_idx
+	ON resources ((data->'spec'->'properties'->>'redfish_uri')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_canonical_uri_idx
+	ON resources ((data->'spec'->'properties'->>'canonical_uri')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_composite_key_idx
+	ON resources ((data->'spec'->'properties'->>'composite_key')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_type_idx
+	ON resources ((data->'spec'->>'deviceType')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_source_idx
+	ON resources ((data->'status'->>'source')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_name_idx
+	ON resources ((data->'metadata'->>'name')) WHERE resource_type = 'Device';
+CREATE INDEX IF NOT EXISTS resources_device_mac_idx
+	ON resources USING GIN ((data->'spec'->'properties'->'mac_addresses')) WHERE resource_type = 'Device';
+`
+
+// PostgresBackend implements StorageBackend on a PostgreSQL database.
+//
+// Resources are stored as JSONB in a single table keyed by kind and UID.
+// Version conversion is not supported: resources are stored and returned in
+// the version they were saved with.
+type PostgresBackend struct {
+	db *sql.DB
+}
+
+// Compile-time checks for the storage interfaces PostgresBackend implements.
+var (
+	_ fabricaStorage.StorageBackend = (*PostgresBackend)(nil)
+	_ DeviceIndex                   = (*PostgresBackend)(nil)
+)
+
+// NewPostgresBackend connects to the database at dsn and creates the schema
+// if it does not exist.
+func NewPostgresBackend(ctx context.Context, dsn string) (*PostgresBackend, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, postgresSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	return &PostgresBackend{db: db}, nil
+}
+
+// InitPostgresBackend is a convenience function to initialize PostgreSQL storage.
+func InitPostgresBackend(ctx context.Context, dsn string) error {
+	backend, err := NewPostgresBackend(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to create postgres backend: %w", err)
+	}
+	Backend = backend
+	return nil
+}
+
+// LoadAll returns every resource of the given kind, ordered by UID.
+func (p *PostgresBackend) LoadAll(ctx context.Context, resourceType string) ([]json.RawMessage, error) {
+	return p.query(ctx, `SELECT data FROM resources WHERE resource_type = $1 ORDER BY uid`, resourceType)
+}
+
+// Load returns one resource, or ErrNotFound.
+func (p *PostgresBackend) Load(ctx context.Context, resourceType, uid string) (json.RawMessage, error) {
+	var data []byte
+	err := p.db.QueryRowContext(ctx, `SELECT data FROM resources WHERE resource_type = $1 AND uid = $2`, resourceType, uid).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fabricaStorage.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s %s: %w", resourceType, uid, err)
+	}
+	return json.RawMessage(data), nil
+}
+
+// Save creates or replaces a resource.
+func (p *PostgresBackend) Save(ctx context.Context, resourceType, uid string, data json.RawMessage) error {
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid JSON data: %w", fabricaStorage.ErrInvalidData)
+	}
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO resources (resource_type, uid, data, updated_at) VALUES ($1, $2, $3, now())
+		ON CONFLICT (resource_type, uid) DO UPDATE SET data = EXCLUDED.data, updated_at = now()`,
+		resourceType, uid, []byte(data))
+	if err != nil {
+		return fmt.Errorf("failed to save %s %s: %w", resourceType, uid, err)
+	}
+	return nil
+}
+
+// Delete removes a resource, or returns ErrNotFound.
+func (p *PostgresBackend) Delete(ctx context.Context, resourceType, uid string) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM resources WHERE resource_type = $1 AND uid = $2`, resourceType, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", resourceType, uid, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fabricaStorage.ErrNotFound
+	}
+	return nil
+}
+
+// Exists reports whether a resource is stored.
+func (p *PostgresBackend) Exists(ctx context.Context, resourceType, uid string) (bool, error) {
+	var exists bool
+	err := p.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM resources WHERE resource_type = $1 AND uid = $2)`, resourceType, uid).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s %s: %w", resourceType, uid, err)
+	}
+	return exists, nil
+}
+
+// List returns the UIDs of every resource of the given kind.
+func (p *PostgresBackend) List(ctx context.Context, resourceType string) ([]string, error) {
+	rows, err := p.db.QueryContext(ctx, `SELECT uid FROM resources WHERE resource_type = $1 ORDER BY uid`, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resourceType, err)
+	}
+	defer rows.Close()
+
+	uids := []string{}
+	for rows.Next() {
+		var uid string
+		if err := rows.Scan(&uid); err != nil {
+			return nil, fmt.Errorf("failed to scan %s UID: %w", resourceType, err)
+		}
+		uids = append(uids, uid)
+	}
+	return uids, rows.Err()
+}
+
+// Close closes the database connection pool.
+func (p *PostgresBackend) Close() error {
+	return p.db.Close()
+}
+
+// LoadWithVersion loads a resource as stored; version is ignored.
+func (p *PostgresBackend) LoadWithVersion(ctx context.Context, resourceType, uid, version string) (json.RawMessage, string, error) {
+	data, err := p.Load(ctx, resourceType, uid)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, "v1", nil
+}
+
+// LoadAllWithVersion loads every resource as stored; version is ignored.
+func (p *PostgresBackend) LoadAllWithVersion(ctx context.Context, resourceType, version string) ([]json.RawMessage, error) {
+	return p.LoadAll(ctx, resourceType)
+}
+
+// SaveWithVersion saves a resource as given; version is ignored.
+func (p *PostgresBackend) SaveWithVersion(ctx context.Context, resourceType, uid string, data json.RawMessage, version string) error {
+	return p.Save(ctx, resourceType, uid, data)
+}
+
+// FindDevicesBySerial uses the serial number index.
+func (p *PostgresBackend) FindDevicesBySerial(ctx context.Context, serial string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'spec'->>'serialNumber' = $1 ORDER BY uid`, serial)
+}
+
+// FindDevicesByRedfishURI uses the redfish_uri index.
+func (p *PostgresBackend) FindDevicesByRedfishURI(ctx context.Context, uri string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'spec'->'properties'->>'redfish_uri' = $1 ORDER BY uid`, uri)
+}
+
+// FindDevicesByCanonicalURI uses the canonical_uri index.
+func (p *PostgresBackend) FindDevicesByCanonicalURI(ctx context.Context, uri string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'spec'->'properties'->>'canonical_uri' = $1 ORDER BY uid`, uri)
+}
+
+// FindDevicesByCompositeKey uses the composite_key index.
+func (p *PostgresBackend) FindDevicesByCompositeKey(ctx context.Context, key string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'spec'->'properties'->>'composite_key' = $1 ORDER BY uid`, key)
+}
+
+// FindDevicesByType uses the device type index.
+func (p *PostgresBackend) FindDevicesByType(ctx context.Context, deviceType string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'spec'->>'deviceType' = $1 ORDER BY uid`, deviceType)
+}
+
+// FindDevicesBySource uses the source index.
+func (p *PostgresBackend) FindDevicesBySource(ctx context.Context, address string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'status'->>'source' = $1 ORDER BY uid`, address)
+}
+
+// FindDevicesByName uses the name index.
+func (p *PostgresBackend) FindDevicesByName(ctx context.Context, name string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'metadata'->>'name' = $1 ORDER BY uid`, name)
+}
+
+// FindDevicesByMAC uses the GIN index on mac_addresses.
+func (p *PostgresBackend) FindDevicesByMAC(ctx context.Context, mac string) ([]*device.Device, error) {
+	return p.queryDevices(ctx, `SELECT data FROM resources WHERE resource_type = 'Device' AND data->'spec'->'properties'->'mac_addresses' ? $1 ORDER BY uid`, mac)
+}
+
+// query runs a query selecting a single data column.
+func (p *PostgresBackend) query(ctx context.Context, query string, args ...interface{}) ([]json.RawMessage, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resources: %w", err)
+	}
+	defer rows.Close()
+
+	results := []json.RawMessage{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan resource: %w", err)
+		}
+		results = append(results, json.RawMessage(data))
+	}
+	return results, rows.Err()
+}
+
+// queryDevices runs a data query and decodes the rows as Devices.
+func (p *PostgresBackend) queryDevices(ctx context.Context, query string, args ...interface{}) ([]*device.Device, error) {
+	rawData, err := p.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDevices(rawData)
+}
@@ -23,8 +23,15 @@ import (
 	"github.com/openchami/fabrica/pkg/reconcile"
 	fabricaStorage "github.com/openchami/fabrica/pkg/storage"
 
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/connection"
 	"github.com/example/inventory-v3/pkg/resources/device"
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
 )
 
 // Backend is the storage backend used by all storage operations.
@@ -398,6 +405,1175 @@ func ListDiscoverySnapshotUIDs(ctx context.Context) ([]string, error) {
 	return uids, nil
 }
 
+// BMCEndpoint storage operations
+
+// LoadAllBMCEndpoints retrieves all BMCEndpoint resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*bmcendpoint.BMCEndpoint: Slice of BMCEndpoint resources
+//   - error: Any error that occurred during loading
+func LoadAllBMCEndpoints(ctx context.Context) ([]*bmcendpoint.BMCEndpoint, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "BMCEndpoint")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all bmcendpoints: %w", err)
+	}
+
+	bmcendpoints := make([]*bmcendpoint.BMCEndpoint, 0, len(rawData))
+	for _, raw := range rawData {
+		bMCEndpoint := &bmcendpoint.BMCEndpoint{}
+		if err := json.Unmarshal(raw, bMCEndpoint); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BMCEndpoint: %w", err)
+		}
+		bmcendpoints = append(bmcendpoints, bMCEndpoint)
+	}
+
+	return bmcendpoints, nil
+}
+
+// LoadBMCEndpoint retrieves a single BMCEndpoint resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the BMCEndpoint resource
+//
+// Returns:
+//   - *bmcendpoint.BMCEndpoint: The BMCEndpoint resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadBMCEndpoint(ctx context.Context, uid string) (*bmcendpoint.BMCEndpoint, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "BMCEndpoint", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BMCEndpoint %s: %w", uid, err)
+	}
+
+	bMCEndpoint := &bmcendpoint.BMCEndpoint{}
+	if err := json.Unmarshal(rawData, bMCEndpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal BMCEndpoint: %w", err)
+	}
+
+	return bMCEndpoint, nil
+}
+
+// SaveBMCEndpoint stores a BMCEndpoint resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - bMCEndpoint: The BMCEndpoint resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SaveBMCEndpoint(ctx context.Context, bMCEndpoint *bmcendpoint.BMCEndpoint) error {
+	ensureBackend()
+
+	data, err := json.Marshal(bMCEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal BMCEndpoint: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "BMCEndpoint", bMCEndpoint.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save BMCEndpoint: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBMCEndpoint updates an existing BMCEndpoint resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - bMCEndpoint: The BMCEndpoint resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdateBMCEndpoint(ctx context.Context, bMCEndpoint *bmcendpoint.BMCEndpoint) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "BMCEndpoint", bMCEndpoint.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check BMCEndpoint existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(bMCEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal BMCEndpoint: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "BMCEndpoint", bMCEndpoint.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update BMCEndpoint: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBMCEndpoint removes a BMCEndpoint resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the BMCEndpoint resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeleteBMCEndpoint(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "BMCEndpoint", uid); err != nil {
+		return fmt.Errorf("failed to delete BMCEndpoint %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsBMCEndpoint checks if a BMCEndpoint resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the BMCEndpoint resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsBMCEndpoint(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "BMCEndpoint", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check BMCEndpoint existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListBMCEndpointUIDs returns UIDs of all BMCEndpoint resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of BMCEndpoint resource UIDs
+//   - error: Any error that occurred during listing
+func ListBMCEndpointUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "BMCEndpoint")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BMCEndpoint UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
+// ServiceEvent storage operations
+
+// LoadAllServiceEvents retrieves all ServiceEvent resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*serviceevent.ServiceEvent: Slice of ServiceEvent resources
+//   - error: Any error that occurred during loading
+func LoadAllServiceEvents(ctx context.Context) ([]*serviceevent.ServiceEvent, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "ServiceEvent")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all serviceevents: %w", err)
+	}
+
+	serviceevents := make([]*serviceevent.ServiceEvent, 0, len(rawData))
+	for _, raw := range rawData {
+		serviceEvent := &serviceevent.ServiceEvent{}
+		if err := json.Unmarshal(raw, serviceEvent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ServiceEvent: %w", err)
+		}
+		serviceevents = append(serviceevents, serviceEvent)
+	}
+
+	return serviceevents, nil
+}
+
+// LoadServiceEvent retrieves a single ServiceEvent resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the ServiceEvent resource
+//
+// Returns:
+//   - *serviceevent.ServiceEvent: The ServiceEvent resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadServiceEvent(ctx context.Context, uid string) (*serviceevent.ServiceEvent, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "ServiceEvent", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ServiceEvent %s: %w", uid, err)
+	}
+
+	serviceEvent := &serviceevent.ServiceEvent{}
+	if err := json.Unmarshal(rawData, serviceEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ServiceEvent: %w", err)
+	}
+
+	return serviceEvent, nil
+}
+
+// SaveServiceEvent stores a ServiceEvent resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - serviceEvent: The ServiceEvent resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SaveServiceEvent(ctx context.Context, serviceEvent *serviceevent.ServiceEvent) error {
+	ensureBackend()
+
+	data, err := json.Marshal(serviceEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ServiceEvent: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "ServiceEvent", serviceEvent.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save ServiceEvent: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateServiceEvent updates an existing ServiceEvent resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - serviceEvent: The ServiceEvent resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdateServiceEvent(ctx context.Context, serviceEvent *serviceevent.ServiceEvent) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "ServiceEvent", serviceEvent.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check ServiceEvent existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(serviceEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ServiceEvent: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "ServiceEvent", serviceEvent.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update ServiceEvent: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteServiceEvent removes a ServiceEvent resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the ServiceEvent resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeleteServiceEvent(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "ServiceEvent", uid); err != nil {
+		return fmt.Errorf("failed to delete ServiceEvent %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsServiceEvent checks if a ServiceEvent resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the ServiceEvent resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsServiceEvent(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "ServiceEvent", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check ServiceEvent existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListServiceEventUIDs returns UIDs of all ServiceEvent resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of ServiceEvent resource UIDs
+//   - error: Any error that occurred during listing
+func ListServiceEventUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "ServiceEvent")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ServiceEvent UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
+// MaintenanceWindow storage operations
+
+// LoadAllMaintenanceWindows retrieves all MaintenanceWindow resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*maintenancewindow.MaintenanceWindow: Slice of MaintenanceWindow resources
+//   - error: Any error that occurred during loading
+func LoadAllMaintenanceWindows(ctx context.Context) ([]*maintenancewindow.MaintenanceWindow, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "MaintenanceWindow")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all maintenancewindows: %w", err)
+	}
+
+	maintenancewindows := make([]*maintenancewindow.MaintenanceWindow, 0, len(rawData))
+	for _, raw := range rawData {
+		maintenanceWindow := &maintenancewindow.MaintenanceWindow{}
+		if err := json.Unmarshal(raw, maintenanceWindow); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MaintenanceWindow: %w", err)
+		}
+		maintenancewindows = append(maintenancewindows, maintenanceWindow)
+	}
+
+	return maintenancewindows, nil
+}
+
+// LoadMaintenanceWindow retrieves a single MaintenanceWindow resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the MaintenanceWindow resource
+//
+// Returns:
+//   - *maintenancewindow.MaintenanceWindow: The MaintenanceWindow resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadMaintenanceWindow(ctx context.Context, uid string) (*maintenancewindow.MaintenanceWindow, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "MaintenanceWindow", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MaintenanceWindow %s: %w", uid, err)
+	}
+
+	maintenanceWindow := &maintenancewindow.MaintenanceWindow{}
+	if err := json.Unmarshal(rawData, maintenanceWindow); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal MaintenanceWindow: %w", err)
+	}
+
+	return maintenanceWindow, nil
+}
+
+// SaveMaintenanceWindow stores a MaintenanceWindow resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - maintenanceWindow: The MaintenanceWindow resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SaveMaintenanceWindow(ctx context.Context, maintenanceWindow *maintenancewindow.MaintenanceWindow) error {
+	ensureBackend()
+
+	data, err := json.Marshal(maintenanceWindow)
+	if err != nil {
+		return fmt.Errorf("failed to marshal MaintenanceWindow: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "MaintenanceWindow", maintenanceWindow.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save MaintenanceWindow: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMaintenanceWindow updates an existing MaintenanceWindow resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - maintenanceWindow: The MaintenanceWindow resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdateMaintenanceWindow(ctx context.Context, maintenanceWindow *maintenancewindow.MaintenanceWindow) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "MaintenanceWindow", maintenanceWindow.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check MaintenanceWindow existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(maintenanceWindow)
+	if err != nil {
+		return fmt.Errorf("failed to marshal MaintenanceWindow: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "MaintenanceWindow", maintenanceWindow.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update MaintenanceWindow: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMaintenanceWindow removes a MaintenanceWindow resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the MaintenanceWindow resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeleteMaintenanceWindow(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "MaintenanceWindow", uid); err != nil {
+		return fmt.Errorf("failed to delete MaintenanceWindow %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsMaintenanceWindow checks if a MaintenanceWindow resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the MaintenanceWindow resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsMaintenanceWindow(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "MaintenanceWindow", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check MaintenanceWindow existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListMaintenanceWindowUIDs returns UIDs of all MaintenanceWindow resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of MaintenanceWindow resource UIDs
+//   - error: Any error that occurred during listing
+func ListMaintenanceWindowUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "MaintenanceWindow")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MaintenanceWindow UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
+// Group storage operations
+
+// LoadAllGroups retrieves all Group resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*group.Group: Slice of Group resources
+//   - error: Any error that occurred during loading
+func LoadAllGroups(ctx context.Context) ([]*group.Group, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "Group")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all groups: %w", err)
+	}
+
+	groups := make([]*group.Group, 0, len(rawData))
+	for _, raw := range rawData {
+		group := &group.Group{}
+		if err := json.Unmarshal(raw, group); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// LoadGroup retrieves a single Group resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Group resource
+//
+// Returns:
+//   - *group.Group: The Group resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadGroup(ctx context.Context, uid string) (*group.Group, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "Group", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Group %s: %w", uid, err)
+	}
+
+	group := &group.Group{}
+	if err := json.Unmarshal(rawData, group); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Group: %w", err)
+	}
+
+	return group, nil
+}
+
+// SaveGroup stores a Group resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - group: The Group resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SaveGroup(ctx context.Context, group *group.Group) error {
+	ensureBackend()
+
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Group: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "Group", group.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save Group: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateGroup updates an existing Group resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - group: The Group resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdateGroup(ctx context.Context, group *group.Group) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "Group", group.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check Group existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Group: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "Group", group.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update Group: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGroup removes a Group resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Group resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeleteGroup(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "Group", uid); err != nil {
+		return fmt.Errorf("failed to delete Group %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsGroup checks if a Group resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Group resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsGroup(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "Group", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check Group existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListGroupUIDs returns UIDs of all Group resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of Group resource UIDs
+//   - error: Any error that occurred during listing
+func ListGroupUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "Group")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Group UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
+// Connection storage operations
+
+// LoadAllConnections retrieves all Connection resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*connection.Connection: Slice of Connection resources
+//   - error: Any error that occurred during loading
+func LoadAllConnections(ctx context.Context) ([]*connection.Connection, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "Connection")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all connections: %w", err)
+	}
+
+	connections := make([]*connection.Connection, 0, len(rawData))
+	for _, raw := range rawData {
+		connection := &connection.Connection{}
+		if err := json.Unmarshal(raw, connection); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Connection: %w", err)
+		}
+		connections = append(connections, connection)
+	}
+
+	return connections, nil
+}
+
+// LoadConnection retrieves a single Connection resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Connection resource
+//
+// Returns:
+//   - *connection.Connection: The Connection resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadConnection(ctx context.Context, uid string) (*connection.Connection, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "Connection", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Connection %s: %w", uid, err)
+	}
+
+	connection := &connection.Connection{}
+	if err := json.Unmarshal(rawData, connection); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Connection: %w", err)
+	}
+
+	return connection, nil
+}
+
+// SaveConnection stores a Connection resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - connection: The Connection resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SaveConnection(ctx context.Context, connection *connection.Connection) error {
+	ensureBackend()
+
+	data, err := json.Marshal(connection)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Connection: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "Connection", connection.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save Connection: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateConnection updates an existing Connection resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - connection: The Connection resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdateConnection(ctx context.Context, connection *connection.Connection) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "Connection", connection.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check Connection existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(connection)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Connection: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "Connection", connection.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update Connection: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteConnection removes a Connection resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Connection resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeleteConnection(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "Connection", uid); err != nil {
+		return fmt.Errorf("failed to delete Connection %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsConnection checks if a Connection resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Connection resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsConnection(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "Connection", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check Connection existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListConnectionUIDs returns UIDs of all Connection resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of Connection resource UIDs
+//   - error: Any error that occurred during listing
+func ListConnectionUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "Connection")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Connection UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
+// PendingChange storage operations
+
+// LoadAllPendingChanges retrieves all PendingChange resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*pendingchange.PendingChange: Slice of PendingChange resources
+//   - error: Any error that occurred during loading
+func LoadAllPendingChanges(ctx context.Context) ([]*pendingchange.PendingChange, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "PendingChange")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all pendingchanges: %w", err)
+	}
+
+	pendingchanges := make([]*pendingchange.PendingChange, 0, len(rawData))
+	for _, raw := range rawData {
+		pendingChange := &pendingchange.PendingChange{}
+		if err := json.Unmarshal(raw, pendingChange); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PendingChange: %w", err)
+		}
+		pendingchanges = append(pendingchanges, pendingChange)
+	}
+
+	return pendingchanges, nil
+}
+
+// LoadPendingChange retrieves a single PendingChange resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the PendingChange resource
+//
+// Returns:
+//   - *pendingchange.PendingChange: The PendingChange resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadPendingChange(ctx context.Context, uid string) (*pendingchange.PendingChange, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "PendingChange", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PendingChange %s: %w", uid, err)
+	}
+
+	pendingChange := &pendingchange.PendingChange{}
+	if err := json.Unmarshal(rawData, pendingChange); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PendingChange: %w", err)
+	}
+
+	return pendingChange, nil
+}
+
+// SavePendingChange stores a PendingChange resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - pendingChange: The PendingChange resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SavePendingChange(ctx context.Context, pendingChange *pendingchange.PendingChange) error {
+	ensureBackend()
+
+	data, err := json.Marshal(pendingChange)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PendingChange: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "PendingChange", pendingChange.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save PendingChange: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePendingChange updates an existing PendingChange resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - pendingChange: The PendingChange resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdatePendingChange(ctx context.Context, pendingChange *pendingchange.PendingChange) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "PendingChange", pendingChange.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check PendingChange existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(pendingChange)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PendingChange: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "PendingChange", pendingChange.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update PendingChange: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePendingChange removes a PendingChange resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the PendingChange resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeletePendingChange(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "PendingChange", uid); err != nil {
+		return fmt.Errorf("failed to delete PendingChange %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsPendingChange checks if a PendingChange resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the PendingChange resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsPendingChange(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "PendingChange", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check PendingChange existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListPendingChangeUIDs returns UIDs of all PendingChange resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of PendingChange resource UIDs
+//   - error: Any error that occurred during listing
+func ListPendingChangeUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "PendingChange")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PendingChange UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
+// Alert storage operations
+
+// LoadAllAlerts retrieves all Alert resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []*alert.Alert: Slice of Alert resources
+//   - error: Any error that occurred during loading
+func LoadAllAlerts(ctx context.Context) ([]*alert.Alert, error) {
+	ensureBackend()
+
+	rawData, err := Backend.LoadAll(ctx, "Alert")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load all alerts: %w", err)
+	}
+
+	alerts := make([]*alert.Alert, 0, len(rawData))
+	for _, raw := range rawData {
+		alert := &alert.Alert{}
+		if err := json.Unmarshal(raw, alert); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// LoadAlert retrieves a single Alert resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Alert resource
+//
+// Returns:
+//   - *alert.Alert: The Alert resource
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func LoadAlert(ctx context.Context, uid string) (*alert.Alert, error) {
+	ensureBackend()
+
+	rawData, err := Backend.Load(ctx, "Alert", uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Alert %s: %w", uid, err)
+	}
+
+	alert := &alert.Alert{}
+	if err := json.Unmarshal(rawData, alert); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// SaveAlert stores a Alert resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - alert: The Alert resource to save
+//
+// Returns:
+//   - error: Any error that occurred during saving
+func SaveAlert(ctx context.Context, alert *alert.Alert) error {
+	ensureBackend()
+
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Alert: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "Alert", alert.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to save Alert: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAlert updates an existing Alert resource.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - alert: The Alert resource to update
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func UpdateAlert(ctx context.Context, alert *alert.Alert) error {
+	ensureBackend()
+
+	// Check if resource exists first
+	exists, err := Backend.Exists(ctx, "Alert", alert.Metadata.UID)
+	if err != nil {
+		return fmt.Errorf("failed to check Alert existence: %w", err)
+	}
+	if !exists {
+		return fabricaStorage.ErrNotFound
+	}
+
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Alert: %w", err)
+	}
+
+	if err := Backend.Save(ctx, "Alert", alert.Metadata.UID, data); err != nil {
+		return fmt.Errorf("failed to update Alert: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAlert removes a Alert resource by UID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Alert resource
+//
+// Returns:
+//   - error: fabricaStorage.ErrNotFound if resource doesn't exist, other errors for failures
+func DeleteAlert(ctx context.Context, uid string) error {
+	ensureBackend()
+
+	if err := Backend.Delete(ctx, "Alert", uid); err != nil {
+		return fmt.Errorf("failed to delete Alert %s: %w", uid, err)
+	}
+
+	return nil
+}
+
+// ExistsAlert checks if a Alert resource exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - uid: Unique identifier of the Alert resource
+//
+// Returns:
+//   - bool: true if the resource exists
+//   - error: Any error that occurred during the check
+func ExistsAlert(ctx context.Context, uid string) (bool, error) {
+	ensureBackend()
+
+	exists, err := Backend.Exists(ctx, "Alert", uid)
+	if err != nil {
+		return false, fmt.Errorf("failed to check Alert existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// ListAlertUIDs returns UIDs of all Alert resources.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []string: Array of Alert resource UIDs
+//   - error: Any error that occurred during listing
+func ListAlertUIDs(ctx context.Context) ([]string, error) {
+	ensureBackend()
+
+	uids, err := Backend.List(ctx, "Alert")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Alert UIDs: %w", err)
+	}
+
+	return uids, nil
+}
+
 // StorageClient wraps a StorageBackend to implement reconcile.ClientInterface.
 //
 // This adapter allows reconcilers to use the storage backend through a
@@ -453,6 +1629,48 @@ func (c *StorageClient) Get(ctx context.Context, kind, uid string) (interface{},
 			return nil, fmt.Errorf("failed to unmarshal DiscoverySnapshot: %w", err)
 		}
 		return &resource, nil
+	case "BMCEndpoint":
+		var resource bmcendpoint.BMCEndpoint
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BMCEndpoint: %w", err)
+		}
+		return &resource, nil
+	case "ServiceEvent":
+		var resource serviceevent.ServiceEvent
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ServiceEvent: %w", err)
+		}
+		return &resource, nil
+	case "MaintenanceWindow":
+		var resource maintenancewindow.MaintenanceWindow
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MaintenanceWindow: %w", err)
+		}
+		return &resource, nil
+	case "Group":
+		var resource group.Group
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Group: %w", err)
+		}
+		return &resource, nil
+	case "Connection":
+		var resource connection.Connection
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Connection: %w", err)
+		}
+		return &resource, nil
+	case "PendingChange":
+		var resource pendingchange.PendingChange
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PendingChange: %w", err)
+		}
+		return &resource, nil
+	case "Alert":
+		var resource alert.Alert
+		if err := json.Unmarshal(rawData, &resource); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Alert: %w", err)
+		}
+		return &resource, nil
 	default:
 		return nil, fmt.Errorf("unknown resource kind: %s", kind)
 	}
@@ -495,6 +1713,76 @@ func (c *StorageClient) List(ctx context.Context, kind string) ([]interface{}, e
 			result = append(result, &resource)
 		}
 		return result, nil
+	case "BMCEndpoint":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource bmcendpoint.BMCEndpoint
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal BMCEndpoint: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
+	case "ServiceEvent":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource serviceevent.ServiceEvent
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal ServiceEvent: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
+	case "MaintenanceWindow":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource maintenancewindow.MaintenanceWindow
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal MaintenanceWindow: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
+	case "Group":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource group.Group
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal Group: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
+	case "Connection":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource connection.Connection
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal Connection: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
+	case "PendingChange":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource pendingchange.PendingChange
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal PendingChange: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
+	case "Alert":
+		result := make([]interface{}, 0, len(rawData))
+		for _, raw := range rawData {
+			var resource alert.Alert
+			if err := json.Unmarshal(raw, &resource); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal Alert: %w", err)
+			}
+			result = append(result, &resource)
+		}
+		return result, nil
 	default:
 		return nil, fmt.Errorf("unknown resource kind: %s", kind)
 	}
@@ -520,6 +1808,20 @@ func (c *StorageClient) Update(ctx context.Context, resource interface{}) error
 		return c.backend.Save(ctx, "Device", res.Metadata.UID, data)
 	case *discoverysnapshot.DiscoverySnapshot:
 		return c.backend.Save(ctx, "DiscoverySnapshot", res.Metadata.UID, data)
+	case *bmcendpoint.BMCEndpoint:
+		return c.backend.Save(ctx, "BMCEndpoint", res.Metadata.UID, data)
+	case *serviceevent.ServiceEvent:
+		return c.backend.Save(ctx, "ServiceEvent", res.Metadata.UID, data)
+	case *maintenancewindow.MaintenanceWindow:
+		return c.backend.Save(ctx, "MaintenanceWindow", res.Metadata.UID, data)
+	case *group.Group:
+		return c.backend.Save(ctx, "Group", res.Metadata.UID, data)
+	case *connection.Connection:
+		return c.backend.Save(ctx, "Connection", res.Metadata.UID, data)
+	case *pendingchange.PendingChange:
+		return c.backend.Save(ctx, "PendingChange", res.Metadata.UID, data)
+	case *alert.Alert:
+		return c.backend.Save(ctx, "Alert", res.Metadata.UID, data)
 	default:
 		return fmt.Errorf("unknown resource type: %T", resource)
 	}
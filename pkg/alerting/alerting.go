@@ -0,0 +1,207 @@
+// Package alerting evaluates alert rules against the inventory and tracks
+// the state of the alerts they raise.
+//
+// A rule checks one condition, such as a device missing for a day, and
+// returns a finding for every device the condition holds for. Each finding
+// is kept as an Alert resource that fires when the finding first appears and
+// resolves once it is gone, so that notifications go out on changes rather
+// than on every evaluation. Rule types are pluggable: sites add their own
+// with RegisterRuleType and configure them in the rules file alongside the
+// built-in ones.
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/maintenance"
+	"github.com/example/inventory-v3/pkg/reports"
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Inventory is what rules are evaluated against.
+type Inventory struct {
+	Devices []*device.Device
+	// Blackout lists what active maintenance windows cover; it may be nil.
+	Blackout *maintenance.Blackout
+	// Baseline is the server's firmware baseline.
+	Baseline reports.FirmwareBaseline
+}
+
+// Finding is one subject a rule's condition holds for.
+type Finding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	// Subject is the UID of the device, SubjectName its name.
+	Subject     string `json:"subject"`
+	SubjectName string `json:"subjectName"`
+	Message     string `json:"message"`
+}
+
+// Rule checks one condition across the inventory.
+type Rule interface {
+	// Evaluate returns a finding for every subject the condition holds for.
+	// The evaluator fills in the findings' Rule and Severity.
+	Evaluate(inv *Inventory, now time.Time) []Finding
+}
+
+// RuleConfig configures one rule. Which fields apply depends on the type.
+type RuleConfig struct {
+	// Name identifies the rule in alerts; it defaults to the type.
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Severity string `json:"severity,omitempty"`
+
+	// After is how long a condition must last, e.g. "24h", for the rule
+	// types that measure time.
+	After string `json:"after,omitempty"`
+
+	// DeviceType, Manufacturer, PartNumber and Model select the devices a
+	// rule checks; empty fields match any device.
+	DeviceType   string `json:"deviceType,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	PartNumber   string `json:"partNumber,omitempty"`
+	Model        string `json:"model,omitempty"`
+
+	// Count is the expected count of the rule types that count components.
+	Count int `json:"count,omitempty"`
+}
+
+// Matches reports whether the rule's selectors match the device.
+func (c RuleConfig) Matches(d *device.Device) bool {
+	switch {
+	case c.DeviceType != "" && d.Spec.DeviceType != c.DeviceType:
+		return false
+	case c.Manufacturer != "" && d.Spec.Manufacturer != c.Manufacturer:
+		return false
+	case c.PartNumber != "" && d.Spec.PartNumber != c.PartNumber:
+		return false
+	}
+	if c.Model != "" {
+		var model string
+		d.Spec.GetProperty("model", &model)
+		return model == c.Model
+	}
+	return true
+}
+
+// RuleFactory builds a rule of one type from its configuration.
+type RuleFactory func(cfg RuleConfig) (Rule, error)
+
+var (
+	ruleTypesMu sync.RWMutex
+	ruleTypes   = make(map[string]RuleFactory)
+)
+
+// RegisterRuleType makes a rule type available to rules files. Registering
+// a type twice replaces the first factory.
+func RegisterRuleType(name string, factory RuleFactory) {
+	ruleTypesMu.Lock()
+	defer ruleTypesMu.Unlock()
+	ruleTypes[name] = factory
+}
+
+// RuleTypes returns the registered rule types, sorted.
+func RuleTypes() []string {
+	ruleTypesMu.RLock()
+	defer ruleTypesMu.RUnlock()
+	names := make([]string, 0, len(ruleTypes))
+	for name := range ruleTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadRules reads a JSON array of RuleConfig from path.
+func LoadRules(path string) ([]RuleConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert rules: %w", err)
+	}
+	var configs []RuleConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules %s: %w", path, err)
+	}
+	return configs, nil
+}
+
+// Engine evaluates a set of configured rules.
+type Engine struct {
+	configs []RuleConfig
+	rules   []Rule
+}
+
+// NewEngine builds the configured rules. Rule names must be unique.
+func NewEngine(configs []RuleConfig) (*Engine, error) {
+	e := &Engine{}
+	names := make(map[string]bool)
+	for i, cfg := range configs {
+		if cfg.Name == "" {
+			cfg.Name = cfg.Type
+		}
+		if cfg.Severity == "" {
+			cfg.Severity = alert.SeverityWarning
+		}
+		if !validSeverity(cfg.Severity) {
+			return nil, fmt.Errorf("alert rule %d (%s): unknown severity %q (valid: %v)", i, cfg.Name, cfg.Severity, alert.Severities)
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("alert rule %d: duplicate name %q", i, cfg.Name)
+		}
+		names[cfg.Name] = true
+
+		ruleTypesMu.RLock()
+		factory, ok := ruleTypes[cfg.Type]
+		ruleTypesMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("alert rule %d (%s): unknown type %q (valid: %v)", i, cfg.Name, cfg.Type, RuleTypes())
+		}
+		rule, err := factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %d (%s): %w", i, cfg.Name, err)
+		}
+		e.configs = append(e.configs, cfg)
+		e.rules = append(e.rules, rule)
+	}
+	return e, nil
+}
+
+func validSeverity(severity string) bool {
+	for _, s := range alert.Severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules returns the configuration of every rule, with defaults filled in.
+func (e *Engine) Rules() []RuleConfig {
+	return e.configs
+}
+
+// Evaluate runs every rule and returns their findings, sorted by rule and
+// subject.
+func (e *Engine) Evaluate(inv *Inventory, now time.Time) []Finding {
+	findings := []Finding{}
+	for i, rule := range e.rules {
+		for _, f := range rule.Evaluate(inv, now) {
+			f.Rule = e.configs[i].Name
+			f.Severity = e.configs[i].Severity
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Rule != findings[j].Rule {
+			return findings[i].Rule < findings[j].Rule
+		}
+		return findings[i].Subject < findings[j].Subject
+	})
+	return findings
+}
@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/example/inventory-v3/pkg/resources/alert"
+)
+
+// TestNewEngine fills in rule defaults and rejects rules that cannot be built.
+func TestNewEngine(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []RuleConfig
+		want    RuleConfig
+		wantErr string
+	}{
+		{
+			name:    "defaults",
+			configs: []RuleConfig{{Type: TypeClockSkew}},
+			want:    RuleConfig{Name: TypeClockSkew, Type: TypeClockSkew, Severity: alert.SeverityWarning},
+		},
+		{
+			name:    "named",
+			configs: []RuleConfig{{Name: "nic-firmware", Type: TypeFirmwareCompliance, Severity: alert.SeverityCritical, DeviceType: "NIC"}},
+			want:    RuleConfig{Name: "nic-firmware", Type: TypeFirmwareCompliance, Severity: alert.SeverityCritical, DeviceType: "NIC"},
+		},
+		{name: "unknown type", configs: []RuleConfig{{Type: "disk-full"}}, wantErr: `unknown type "disk-full"`},
+		{name: "unknown severity", configs: []RuleConfig{{Type: TypeClockSkew, Severity: "page"}}, wantErr: `unknown severity "page"`},
+		{
+			name:    "duplicate name",
+			configs: []RuleConfig{{Type: TypeClockSkew}, {Type: TypeClockSkew}},
+			wantErr: `duplicate name "clock-skew"`,
+		},
+		{name: "invalid after", configs: []RuleConfig{{Type: TypeDeviceMissing, After: "-1h"}}, wantErr: `invalid after "-1h"`},
+		{name: "dimm-count without a count", configs: []RuleConfig{{Type: TypeDIMMCount}}, wantErr: "needs a positive count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewEngine(tt.configs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewEngine error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewEngine failed: %v", err)
+			}
+			if rules := engine.Rules(); len(rules) != 1 || rules[0] != tt.want {
+				t.Errorf("rules = %+v, want [%+v]", rules, tt.want)
+			}
+		})
+	}
+}
+
+// TestDefaultRules builds the rules used without a rules file.
+func TestDefaultRules(t *testing.T) {
+	if _, err := NewEngine(DefaultRules()); err != nil {
+		t.Fatalf("NewEngine(DefaultRules()) failed: %v", err)
+	}
+}
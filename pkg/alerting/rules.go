@@ -0,0 +1,202 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/reports"
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Built-in rule types.
+const (
+	// TypeDeviceMissing fires for devices not reported for longer than
+	// After (default 24h).
+	TypeDeviceMissing = "device-missing"
+	// TypeDIMMCount fires for nodes with fewer than Count DIMMs.
+	TypeDIMMCount = "dimm-count"
+	// TypeFirmwareCompliance fires for devices whose firmware does not match
+	// the server's firmware baseline.
+	TypeFirmwareCompliance = "firmware-compliance"
+	// TypeClockSkew fires for BMCs whose clock the collector found off by
+	// more than its --max-clock-skew.
+	TypeClockSkew = "clock-skew"
+)
+
+// defaultMissingAfter is the After of device-missing rules that set none.
+const defaultMissingAfter = 24 * time.Hour
+
+func init() {
+	RegisterRuleType(TypeDeviceMissing, newDeviceMissingRule)
+	RegisterRuleType(TypeDIMMCount, newDIMMCountRule)
+	RegisterRuleType(TypeFirmwareCompliance, newFirmwareComplianceRule)
+	RegisterRuleType(TypeClockSkew, newClockSkewRule)
+}
+
+// DefaultRules are the rules evaluated when no rules file is configured.
+// DIMM counts differ by platform, so no dimm-count rule is among them.
+func DefaultRules() []RuleConfig {
+	return []RuleConfig{
+		{Type: TypeDeviceMissing, Severity: alert.SeverityWarning, After: defaultMissingAfter.String()},
+		{Type: TypeFirmwareCompliance, Severity: alert.SeverityWarning},
+		{Type: TypeClockSkew, Severity: alert.SeverityWarning},
+	}
+}
+
+// deviceMissingRule reports devices that have gone unreported. A device
+// whose parent is missing too is covered by the parent's alert, so that a
+// node that disappears raises one alert rather than one per component.
+type deviceMissingRule struct {
+	cfg   RuleConfig
+	after time.Duration
+}
+
+func newDeviceMissingRule(cfg RuleConfig) (Rule, error) {
+	after := defaultMissingAfter
+	if cfg.After != "" {
+		var err error
+		if after, err = time.ParseDuration(cfg.After); err != nil || after <= 0 {
+			return nil, fmt.Errorf("invalid after %q: must be a positive duration", cfg.After)
+		}
+	}
+	return &deviceMissingRule{cfg: cfg, after: after}, nil
+}
+
+func (r *deviceMissingRule) Evaluate(inv *Inventory, now time.Time) []Finding {
+	report := reports.MissingDevices(inv.Devices, inv.Blackout, r.after, now)
+	byUID := make(map[string]*device.Device, len(inv.Devices))
+	for _, d := range inv.Devices {
+		byUID[d.GetUID()] = d
+	}
+	missing := make(map[string]bool, len(report.Devices))
+	for _, entry := range report.Devices {
+		missing[entry.UID] = true
+	}
+
+	var findings []Finding
+	for _, entry := range report.Devices {
+		d := byUID[entry.UID]
+		if d == nil || missing[d.Spec.ParentID] || !r.cfg.Matches(d) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Subject:     entry.UID,
+			SubjectName: entry.Name,
+			Message: fmt.Sprintf("%s %s (serial %q) not reported since %s",
+				entry.DeviceType, entry.Name, entry.SerialNumber, entry.LastSeen.Format(time.RFC3339)),
+		})
+	}
+	return findings
+}
+
+// dimmCountRule reports nodes with fewer DIMMs than expected. The count is
+// the node's dimm_count property, else its active DIMM devices.
+type dimmCountRule struct {
+	cfg RuleConfig
+}
+
+func newDIMMCountRule(cfg RuleConfig) (Rule, error) {
+	if cfg.Count <= 0 {
+		return nil, errors.New("a dimm-count rule needs a positive count")
+	}
+	if cfg.DeviceType == "" {
+		cfg.DeviceType = "Node"
+	}
+	return &dimmCountRule{cfg: cfg}, nil
+}
+
+func (r *dimmCountRule) Evaluate(inv *Inventory, now time.Time) []Finding {
+	dimms := make(map[string]int)
+	for _, d := range inv.Devices {
+		if d.Spec.DeviceType == "DIMM" && d.Spec.ParentID != "" && !prune.IsRetired(d) {
+			dimms[d.Spec.ParentID]++
+		}
+	}
+
+	var findings []Finding
+	for _, d := range inv.Devices {
+		if prune.IsRetired(d) || manifest.IsExpected(d) || !r.cfg.Matches(d) || inv.Blackout.CoversDevice(d) {
+			continue
+		}
+		count := dimms[d.GetUID()]
+		d.Spec.GetProperty("dimm_count", &count)
+		if count >= r.cfg.Count {
+			continue
+		}
+		findings = append(findings, Finding{
+			Subject:     d.GetUID(),
+			SubjectName: d.GetName(),
+			Message:     fmt.Sprintf("%s %s has %d DIMM(s), expected %d", d.Spec.DeviceType, d.GetName(), count, r.cfg.Count),
+		})
+	}
+	return findings
+}
+
+// firmwareComplianceRule reports devices running firmware other than the
+// baseline's. Devices that report no version are not alerted on.
+type firmwareComplianceRule struct {
+	cfg RuleConfig
+}
+
+func newFirmwareComplianceRule(cfg RuleConfig) (Rule, error) {
+	return &firmwareComplianceRule{cfg: cfg}, nil
+}
+
+func (r *firmwareComplianceRule) Evaluate(inv *Inventory, now time.Time) []Finding {
+	if len(inv.Baseline) == 0 {
+		return nil
+	}
+	byUID := make(map[string]*device.Device, len(inv.Devices))
+	for _, d := range inv.Devices {
+		byUID[d.GetUID()] = d
+	}
+
+	var findings []Finding
+	for _, entry := range reports.FirmwareCompliance(inv.Devices, inv.Baseline).Devices {
+		d := byUID[entry.UID]
+		if entry.FirmwareVersion == "" || d == nil || !r.cfg.Matches(d) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Subject:     entry.UID,
+			SubjectName: entry.Name,
+			Message: fmt.Sprintf("%s %s runs firmware %s, expected %s",
+				entry.DeviceType, entry.Name, entry.FirmwareVersion, entry.ExpectedVersion),
+		})
+	}
+	return findings
+}
+
+// clockSkewRule reports BMCs flagged clock_skewed by their last collection.
+type clockSkewRule struct {
+	cfg RuleConfig
+}
+
+func newClockSkewRule(cfg RuleConfig) (Rule, error) {
+	if cfg.DeviceType == "" {
+		cfg.DeviceType = "BMC"
+	}
+	return &clockSkewRule{cfg: cfg}, nil
+}
+
+func (r *clockSkewRule) Evaluate(inv *Inventory, now time.Time) []Finding {
+	var findings []Finding
+	for _, d := range inv.Devices {
+		var skewed bool
+		if prune.IsRetired(d) || !r.cfg.Matches(d) || !d.Spec.GetProperty("clock_skewed", &skewed) || !skewed {
+			continue
+		}
+		var seconds float64
+		d.Spec.GetProperty("clock_skew_seconds", &seconds)
+		findings = append(findings, Finding{
+			Subject:     d.GetUID(),
+			SubjectName: d.GetName(),
+			Message:     fmt.Sprintf("%s %s clock is off by %s", d.Spec.DeviceType, d.GetName(), time.Duration(seconds)*time.Second),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,323 @@
+package alerting
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/maintenance"
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/reports"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// testDevice returns a device of the given type, last seen at lastSeen, with
+// the given properties.
+func testDevice(uid, deviceType, parent string, lastSeen time.Time, properties map[string]interface{}) *device.Device {
+	d := &device.Device{}
+	d.Metadata.UID = uid
+	d.Metadata.Name = uid
+	d.Spec.DeviceType = deviceType
+	d.Spec.ParentID = parent
+	d.Status.LastSeen = &lastSeen
+	for key, value := range properties {
+		d.Spec.SetProperty(key, value)
+	}
+	return d
+}
+
+func withPhase(d *device.Device, phase string) *device.Device {
+	d.Status.Phase = phase
+	return d
+}
+
+// blackoutOf returns the blackout of a window active now over racks.
+func blackoutOf(devices []*device.Device, racks ...string) *maintenance.Blackout {
+	w := &maintenancewindow.MaintenanceWindow{}
+	w.Spec.Start = now.Add(-time.Hour)
+	w.Spec.End = now.Add(time.Hour)
+	w.Spec.RackIDs = racks
+	return maintenance.Resolve([]*maintenancewindow.MaintenanceWindow{w}, nil, devices, now)
+}
+
+func subjects(findings []Finding) []string {
+	var uids []string
+	for _, f := range findings {
+		uids = append(uids, f.Subject)
+	}
+	return uids
+}
+
+// evaluate builds a rule from cfg and evaluates it.
+func evaluate(t *testing.T, cfg RuleConfig, inv *Inventory) []Finding {
+	t.Helper()
+	engine, err := NewEngine([]RuleConfig{cfg})
+	if err != nil {
+		t.Fatalf("NewEngine failed: %v", err)
+	}
+	return engine.Evaluate(inv, now)
+}
+
+// TestDeviceMissingRule reports devices unreported for longer than After,
+// once per missing subtree.
+func TestDeviceMissingRule(t *testing.T) {
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	tests := []struct {
+		name    string
+		cfg     RuleConfig
+		devices []*device.Device
+		racks   []string
+		want    []string
+	}{
+		{
+			name:    "seen recently",
+			cfg:     RuleConfig{Type: TypeDeviceMissing},
+			devices: []*device.Device{testDevice("node-1", "Node", "", recent, nil)},
+		},
+		{
+			name:    "missing longer than the default",
+			cfg:     RuleConfig{Type: TypeDeviceMissing},
+			devices: []*device.Device{testDevice("node-1", "Node", "", old, nil)},
+			want:    []string{"node-1"},
+		},
+		{
+			name:    "missing less than after",
+			cfg:     RuleConfig{Type: TypeDeviceMissing, After: "72h"},
+			devices: []*device.Device{testDevice("node-1", "Node", "", old, nil)},
+		},
+		{
+			name: "components of a missing node",
+			cfg:  RuleConfig{Type: TypeDeviceMissing},
+			devices: []*device.Device{
+				testDevice("node-1", "Node", "", old, nil),
+				testDevice("cpu-1", "CPU", "node-1", old, nil),
+				testDevice("cpu-2", "CPU", "node-2", old, nil),
+				testDevice("node-2", "Node", "", recent, nil),
+			},
+			want: []string{"cpu-2", "node-1"},
+		},
+		{
+			name: "selected by type",
+			cfg:  RuleConfig{Type: TypeDeviceMissing, DeviceType: "CPU"},
+			devices: []*device.Device{
+				testDevice("node-1", "Node", "", old, nil),
+				testDevice("cpu-2", "CPU", "node-2", old, nil),
+			},
+			want: []string{"cpu-2"},
+		},
+		{
+			name: "retired and expected",
+			cfg:  RuleConfig{Type: TypeDeviceMissing},
+			devices: []*device.Device{
+				withPhase(testDevice("node-1", "Node", "", old, nil), prune.PhaseRetired),
+				withPhase(testDevice("node-2", "Node", "", old, nil), manifest.PhaseExpected),
+			},
+		},
+		{
+			name: "under maintenance",
+			cfg:  RuleConfig{Type: TypeDeviceMissing},
+			devices: []*device.Device{
+				testDevice("rack-1", "Rack", "", recent, nil),
+				testDevice("node-1", "Node", "rack-1", old, nil),
+				testDevice("node-2", "Node", "", old, nil),
+			},
+			racks: []string{"rack-1"},
+			want:  []string{"node-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Devices: tt.devices}
+			if tt.racks != nil {
+				inv.Blackout = blackoutOf(tt.devices, tt.racks...)
+			}
+			if got := subjects(evaluate(t, tt.cfg, inv)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findings for %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDIMMCountRule reports nodes with fewer DIMMs than Count, counting
+// dimm_count or else the node's active DIMMs.
+func TestDIMMCountRule(t *testing.T) {
+	nodeWith := func(uid string, dimms int) []*device.Device {
+		devices := []*device.Device{testDevice(uid, "Node", "", now, nil)}
+		for i := 0; i < dimms; i++ {
+			devices = append(devices, testDevice(uid+"-dimm-"+string(rune('a'+i)), "DIMM", uid, now, nil))
+		}
+		return devices
+	}
+	tests := []struct {
+		name    string
+		devices []*device.Device
+		racks   []string
+		want    []string
+	}{
+		{name: "enough DIMMs", devices: nodeWith("node-1", 2)},
+		{name: "too few DIMMs", devices: nodeWith("node-1", 1), want: []string{"node-1"}},
+		{
+			name:    "retired DIMMs are not counted",
+			devices: append(nodeWith("node-1", 1), withPhase(testDevice("node-1-dimm-x", "DIMM", "node-1", now, nil), prune.PhaseRetired)),
+			want:    []string{"node-1"},
+		},
+		{
+			name:    "dimm_count property",
+			devices: []*device.Device{testDevice("node-1", "Node", "", now, map[string]interface{}{"dimm_count": 2})},
+		},
+		{
+			name:    "dimm_count property short",
+			devices: []*device.Device{testDevice("node-1", "Node", "", now, map[string]interface{}{"dimm_count": 1})},
+			want:    []string{"node-1"},
+		},
+		{
+			name:    "expected nodes",
+			devices: []*device.Device{withPhase(testDevice("node-1", "Node", "", now, nil), manifest.PhaseExpected)},
+		},
+		{
+			name: "under maintenance",
+			devices: []*device.Device{
+				testDevice("rack-1", "Rack", "", now, nil),
+				testDevice("node-1", "Node", "rack-1", now, nil),
+				testDevice("node-2", "Node", "", now, nil),
+			},
+			racks: []string{"rack-1"},
+			want:  []string{"node-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Devices: tt.devices}
+			if tt.racks != nil {
+				inv.Blackout = blackoutOf(tt.devices, tt.racks...)
+			}
+			if got := subjects(evaluate(t, RuleConfig{Type: TypeDIMMCount, Count: 2}, inv)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findings for %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFirmwareComplianceRule reports devices whose known firmware is not the
+// baseline's.
+func TestFirmwareComplianceRule(t *testing.T) {
+	firmware := func(uid, deviceType, version string) *device.Device {
+		properties := map[string]interface{}{}
+		if version != "" {
+			properties["firmware_version"] = version
+		}
+		return testDevice(uid, deviceType, "", now, properties)
+	}
+	baseline := reports.FirmwareBaseline{{DeviceType: "BMC", Version: "2.0"}, {DeviceType: "NIC", Version: "1.5"}}
+	tests := []struct {
+		name     string
+		cfg      RuleConfig
+		baseline reports.FirmwareBaseline
+		devices  []*device.Device
+		want     []string
+	}{
+		{
+			name:    "no baseline",
+			cfg:     RuleConfig{Type: TypeFirmwareCompliance},
+			devices: []*device.Device{firmware("bmc-1", "BMC", "1.0")},
+		},
+		{
+			name:     "compliant, outdated and unknown",
+			cfg:      RuleConfig{Type: TypeFirmwareCompliance},
+			baseline: baseline,
+			devices: []*device.Device{
+				firmware("bmc-1", "BMC", "2.0"),
+				firmware("bmc-2", "BMC", "1.0"),
+				firmware("bmc-3", "BMC", ""),
+				firmware("nic-1", "NIC", "1.4"),
+				firmware("cpu-1", "CPU", "9.9"),
+			},
+			want: []string{"bmc-2", "nic-1"},
+		},
+		{
+			name:     "selected by type",
+			cfg:      RuleConfig{Type: TypeFirmwareCompliance, DeviceType: "NIC"},
+			baseline: baseline,
+			devices:  []*device.Device{firmware("bmc-2", "BMC", "1.0"), firmware("nic-1", "NIC", "1.4")},
+			want:     []string{"nic-1"},
+		},
+		{
+			name:     "retired",
+			cfg:      RuleConfig{Type: TypeFirmwareCompliance},
+			baseline: baseline,
+			devices:  []*device.Device{withPhase(firmware("bmc-2", "BMC", "1.0"), prune.PhaseRetired)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Devices: tt.devices, Baseline: tt.baseline}
+			if got := subjects(evaluate(t, tt.cfg, inv)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findings for %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClockSkewRule reports the BMCs flagged clock_skewed.
+func TestClockSkewRule(t *testing.T) {
+	skewed := func(uid, deviceType string, flagged bool) *device.Device {
+		return testDevice(uid, deviceType, "", now, map[string]interface{}{"clock_skewed": flagged, "clock_skew_seconds": 90.0})
+	}
+	tests := []struct {
+		name    string
+		cfg     RuleConfig
+		devices []*device.Device
+		want    []string
+		message string
+	}{
+		{
+			name:    "skewed",
+			cfg:     RuleConfig{Type: TypeClockSkew},
+			devices: []*device.Device{skewed("bmc-1", "BMC", true)},
+			want:    []string{"bmc-1"},
+			message: "BMC bmc-1 clock is off by 1m30s",
+		},
+		{
+			name:    "in sync",
+			cfg:     RuleConfig{Type: TypeClockSkew},
+			devices: []*device.Device{skewed("bmc-1", "BMC", false)},
+		},
+		{
+			name:    "never checked",
+			cfg:     RuleConfig{Type: TypeClockSkew},
+			devices: []*device.Device{testDevice("bmc-1", "BMC", "", now, nil)},
+		},
+		{
+			name:    "BMCs by default",
+			cfg:     RuleConfig{Type: TypeClockSkew},
+			devices: []*device.Device{skewed("node-1", "Node", true)},
+		},
+		{
+			name:    "other types when selected",
+			cfg:     RuleConfig{Type: TypeClockSkew, DeviceType: "Node"},
+			devices: []*device.Device{skewed("node-1", "Node", true), skewed("bmc-1", "BMC", true)},
+			want:    []string{"node-1"},
+			message: "Node node-1 clock is off by 1m30s",
+		},
+		{
+			name:    "retired",
+			cfg:     RuleConfig{Type: TypeClockSkew},
+			devices: []*device.Device{withPhase(skewed("bmc-1", "BMC", true), prune.PhaseRetired)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := evaluate(t, tt.cfg, &Inventory{Devices: tt.devices})
+			if got := subjects(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("findings for %v, want %v", got, tt.want)
+			}
+			if tt.message != "" && findings[0].Message != tt.message {
+				t.Errorf("message = %q, want %q", findings[0].Message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/reports"
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Events announced when an alert changes state.
+const (
+	EventFiring   = "firing"
+	EventResolved = "resolved"
+)
+
+// Evaluation is the outcome of one evaluation of the rules.
+type Evaluation struct {
+	EvaluatedAt    time.Time `json:"evaluatedAt"`
+	DurationMillis int64     `json:"durationMillis"`
+	Rules          int       `json:"rules"`
+	// Firing counts the alerts firing after the evaluation; Fired and
+	// Resolved count the ones that changed state in it.
+	Firing   int       `json:"firing"`
+	Fired    int       `json:"fired"`
+	Resolved int       `json:"resolved"`
+	Findings []Finding `json:"findings"`
+	// Errors lists alerts that could not be stored and failed notifications.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Update is an alert changed by an evaluation, to be stored.
+type Update struct {
+	Alert *alert.Alert
+	// Created is set for an alert that did not exist yet.
+	Created bool
+	// Event is EventFiring or EventResolved when the alert changed state,
+	// and empty when only its message changed.
+	Event string
+}
+
+// Apply brings the alerts in step with the findings of an evaluation: a new
+// finding fires an alert, reopening a resolved alert for the same rule and
+// subject, and an alert without a finding resolves. Alerts of rules that are
+// no longer configured resolve too. It returns the alerts to store.
+func Apply(alerts []*alert.Alert, findings []Finding, now time.Time) ([]Update, error) {
+	existing := make(map[string]*alert.Alert, len(alerts))
+	for _, a := range alerts {
+		existing[key(a.Spec.Rule, a.Spec.Subject)] = a
+	}
+
+	var updates []Update
+	found := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		k := key(f.Rule, f.Subject)
+		found[k] = true
+		a, ok := existing[k]
+		if !ok {
+			created, err := newAlert(f, now)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, Update{Alert: created, Created: true, Event: EventFiring})
+			continue
+		}
+		switch {
+		case !a.Firing():
+			a.Status.State = alert.StateFiring
+			a.Status.FiredAt = now
+			a.Status.ResolvedAt = nil
+			a.Status.Message = f.Message
+			a.Spec.Severity = f.Severity
+			a.Spec.SubjectName = f.SubjectName
+			updates = append(updates, Update{Alert: a, Event: EventFiring})
+		case a.Status.Message != f.Message || a.Spec.Severity != f.Severity:
+			a.Status.Message = f.Message
+			a.Spec.Severity = f.Severity
+			updates = append(updates, Update{Alert: a})
+		default:
+			continue
+		}
+		a.Touch()
+	}
+
+	for _, a := range alerts {
+		if found[key(a.Spec.Rule, a.Spec.Subject)] || !a.Firing() {
+			continue
+		}
+		resolvedAt := now
+		a.Status.State = alert.StateResolved
+		a.Status.ResolvedAt = &resolvedAt
+		a.Status.Message = fmt.Sprintf("Resolved at %s: %s", now.Format(time.RFC3339), a.Status.Message)
+		a.Touch()
+		updates = append(updates, Update{Alert: a, Event: EventResolved})
+	}
+	return updates, nil
+}
+
+func key(rule, subject string) string {
+	return rule + "\x00" + subject
+}
+
+// newAlert returns a firing alert for a finding, named after its rule and
+// subject.
+func newAlert(f Finding, now time.Time) (*alert.Alert, error) {
+	uid, err := resource.GenerateUIDForResource("Alert")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate UID for alert: %w", err)
+	}
+	a := &alert.Alert{
+		Resource: resource.Resource{
+			APIVersion:    "v1",
+			Kind:          "Alert",
+			SchemaVersion: "v1",
+		},
+		Spec: alert.AlertSpec{
+			Rule:        f.Rule,
+			Severity:    f.Severity,
+			Subject:     f.Subject,
+			SubjectName: f.SubjectName,
+		},
+		Status: alert.AlertStatus{
+			State:   alert.StateFiring,
+			Message: f.Message,
+			FiredAt: now,
+		},
+	}
+	a.Metadata.UID = uid
+	a.Metadata.Name = f.Rule + "-" + f.Subject
+	a.Metadata.CreatedAt = now
+	a.Metadata.UpdatedAt = now
+	return a, nil
+}
+
+// Notification announces that an alert started or stopped firing.
+type Notification struct {
+	Event string       `json:"event"`
+	At    time.Time    `json:"at"`
+	Alert *alert.Alert `json:"alert"`
+}
+
+// Notifier is told about alerts changing state.
+type Notifier interface {
+	// Name identifies the notifier in logs.
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+// WebhookNotifier POSTs each notification as JSON.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// Name implements Notifier.
+func (w *WebhookNotifier) Name() string {
+	return redact.URL(w.URL)
+}
+
+// Notify implements Notifier.
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert notification: %w", err)
+	}
+	return reports.PostJSON(ctx, w.Client, w.URL, body)
+}
@@ -0,0 +1,110 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/alert"
+)
+
+func testAlert(rule, subject, state, message string) *alert.Alert {
+	a := &alert.Alert{}
+	a.Metadata.UID = "alert-" + rule + "-" + subject
+	a.Spec = alert.AlertSpec{Rule: rule, Severity: alert.SeverityWarning, Subject: subject}
+	a.Status = alert.AlertStatus{State: state, Message: message, FiredAt: now.Add(-time.Hour)}
+	if state == alert.StateResolved {
+		resolvedAt := now.Add(-time.Minute)
+		a.Status.ResolvedAt = &resolvedAt
+	}
+	return a
+}
+
+func testFinding(rule, subject, message string) Finding {
+	return Finding{Rule: rule, Severity: alert.SeverityWarning, Subject: subject, SubjectName: subject, Message: message}
+}
+
+// TestApply fires alerts for new findings, resolves those whose finding is
+// gone, and stores only the alerts that changed.
+func TestApply(t *testing.T) {
+	type want struct {
+		subject, event, state, message string
+		created                        bool
+	}
+	tests := []struct {
+		name     string
+		alerts   []*alert.Alert
+		findings []Finding
+		want     []want
+	}{
+		{
+			name:     "new finding",
+			findings: []Finding{testFinding("clock-skew", "bmc-1", "off")},
+			want:     []want{{subject: "bmc-1", event: EventFiring, state: alert.StateFiring, message: "off", created: true}},
+		},
+		{
+			name:     "still firing",
+			alerts:   []*alert.Alert{testAlert("clock-skew", "bmc-1", alert.StateFiring, "off")},
+			findings: []Finding{testFinding("clock-skew", "bmc-1", "off")},
+		},
+		{
+			name:     "new message",
+			alerts:   []*alert.Alert{testAlert("clock-skew", "bmc-1", alert.StateFiring, "off")},
+			findings: []Finding{testFinding("clock-skew", "bmc-1", "further off")},
+			want:     []want{{subject: "bmc-1", state: alert.StateFiring, message: "further off"}},
+		},
+		{
+			name:   "finding gone",
+			alerts: []*alert.Alert{testAlert("clock-skew", "bmc-1", alert.StateFiring, "off")},
+			want: []want{{
+				subject: "bmc-1", event: EventResolved, state: alert.StateResolved,
+				message: "Resolved at 2026-03-01T12:00:00Z: off",
+			}},
+		},
+		{
+			name:   "already resolved",
+			alerts: []*alert.Alert{testAlert("clock-skew", "bmc-1", alert.StateResolved, "off")},
+		},
+		{
+			name:     "reopened",
+			alerts:   []*alert.Alert{testAlert("clock-skew", "bmc-1", alert.StateResolved, "off")},
+			findings: []Finding{testFinding("clock-skew", "bmc-1", "off again")},
+			want:     []want{{subject: "bmc-1", event: EventFiring, state: alert.StateFiring, message: "off again"}},
+		},
+		{
+			name:     "same subject, other rule",
+			alerts:   []*alert.Alert{testAlert("device-missing", "bmc-1", alert.StateFiring, "gone")},
+			findings: []Finding{testFinding("clock-skew", "bmc-1", "off")},
+			want: []want{
+				{subject: "bmc-1", event: EventFiring, state: alert.StateFiring, message: "off", created: true},
+				{subject: "bmc-1", event: EventResolved, state: alert.StateResolved, message: "Resolved at 2026-03-01T12:00:00Z: gone"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := Apply(tt.alerts, tt.findings, now)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+			if len(updates) != len(tt.want) {
+				t.Fatalf("Apply returned %d updates, want %d", len(updates), len(tt.want))
+			}
+			for i, u := range updates {
+				w := tt.want[i]
+				a := u.Alert
+				if a.Spec.Subject != w.subject || u.Event != w.event || u.Created != w.created {
+					t.Errorf("update %d = %s %q created %v, want %s %q created %v", i, a.Spec.Subject, u.Event, u.Created, w.subject, w.event, w.created)
+				}
+				if a.Status.State != w.state || a.Status.Message != w.message {
+					t.Errorf("update %d alert is %s %q, want %s %q", i, a.Status.State, a.Status.Message, w.state, w.message)
+				}
+				switch {
+				case u.Event == EventFiring && (!a.Status.FiredAt.Equal(now) || a.Status.ResolvedAt != nil):
+					t.Errorf("fired alert has firedAt %v and resolvedAt %v, want %v and none", a.Status.FiredAt, a.Status.ResolvedAt, now)
+				case u.Event == EventResolved && (a.Status.ResolvedAt == nil || !a.Status.ResolvedAt.Equal(now)):
+					t.Errorf("resolved alert has resolvedAt %v, want %v", a.Status.ResolvedAt, now)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Package anonymize replaces the asset identifiers in inventory data (serial
+// numbers, asset tags, UUIDs, MAC and IP addresses) with stand-ins derived
+// from a keyed hash, so that a site can share real-shape exports, snapshots
+// and Redfish captures with developers or vendors. The same key maps the
+// same identifier to the same stand-in everywhere, so devices still match
+// across files; without the key the stand-ins cannot be traced back.
+//
+// Stand-ins keep the shape of what they replace: a serial number keeps its
+// length and which characters are letters or digits, a MAC address becomes
+// a locally administered one, and IP addresses map into private ranges with
+// addresses of the same /24 (or IPv6 /64) staying together.
+package anonymize
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// identifierKeys are the fragments of field names whose string values are
+// identifiers, compared in lower case without separators.
+var identifierKeys = []string{"serial", "assettag", "uuid"}
+
+// minIdentifier is the shortest identifier also replaced where it appears in
+// other strings, e.g. a device named after its serial number; shorter ones
+// would match unrelated text.
+const minIdentifier = 4
+
+var (
+	macPattern  = regexp.MustCompile(`\b[0-9A-Fa-f]{2}([:-])[0-9A-Fa-f]{2}(?:[:-][0-9A-Fa-f]{2}){4}\b`)
+	ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	// ipv6Pattern finds candidates; net.ParseIP decides.
+	ipv6Pattern = regexp.MustCompile(`[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}`)
+)
+
+// Anonymizer replaces identifiers under one key.
+type Anonymizer struct {
+	key []byte
+}
+
+// New returns an anonymizer keyed with key, which must not be empty.
+func New(key string) (*Anonymizer, error) {
+	if key == "" {
+		return nil, fmt.Errorf("anonymization needs a key")
+	}
+	return &Anonymizer{key: []byte(key)}, nil
+}
+
+// IsIdentifierKey reports whether a field named key holds an identifier
+// ("SerialNumber", "parent_serial_number", "AssetTag", "UUID").
+func IsIdentifierKey(key string) bool {
+	key = strings.ToLower(key)
+	key = strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(key)
+	for _, fragment := range identifierKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns a decoded JSON value with its identifiers replaced. The
+// values of identifier fields are replaced wherever they appear, in any
+// string or field name, as are MAC and IP addresses.
+func (a *Anonymizer) Value(v interface{}) interface{} {
+	identifiers := map[string]string{}
+	a.collect(v, false, identifiers)
+	return a.replace(v, false, a.newReplacer(identifiers))
+}
+
+// JSON anonymizes a JSON document.
+func (a *Anonymizer) JSON(raw []byte) ([]byte, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(a.Value(doc))
+}
+
+// Stream anonymizes the JSON values read from r, e.g. one document or a
+// line of JSON per snapshot, and writes each indented to w. Identifiers are
+// collected from all values first, so that one named in a later value is
+// also replaced in earlier ones.
+func (a *Anonymizer) Stream(r io.Reader, w io.Writer) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var docs []interface{}
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	identifiers := map[string]string{}
+	for _, doc := range docs {
+		a.collect(doc, false, identifiers)
+	}
+	replacer := a.newReplacer(identifiers)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	for _, doc := range docs {
+		if err := encoder.Encode(a.replace(doc, false, replacer)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collect adds the values of identifier fields to into.
+func (a *Anonymizer) collect(v interface{}, identifier bool, into map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			a.collect(child, identifier || IsIdentifierKey(key), into)
+		}
+	case []interface{}:
+		for _, child := range v {
+			a.collect(child, identifier, into)
+		}
+	case string:
+		if identifier && v != "" {
+			into[v] = ""
+		}
+	}
+}
+
+func (a *Anonymizer) replace(v interface{}, identifier bool, r *strings.Replacer) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[a.String(key, r)] = a.replace(child, identifier || IsIdentifierKey(key), r)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = a.replace(child, identifier, r)
+		}
+		return out
+	case string:
+		if identifier && len(v) < minIdentifier {
+			return a.Identifier(v)
+		}
+		return a.String(v, r)
+	}
+	return v
+}
+
+// newReplacer works out the stand-ins of identifiers and replaces those long
+// enough to be found in text, longest first. An identifier containing a
+// shorter one, such as a DIMM serial number made of its node's, gets the
+// shorter one's stand-in in that place, so that the two still match.
+func (a *Anonymizer) newReplacer(identifiers map[string]string) *strings.Replacer {
+	var originals []string
+	for original := range identifiers {
+		if len(original) >= minIdentifier {
+			originals = append(originals, original)
+		}
+	}
+	sort.Slice(originals, func(i, j int) bool {
+		if len(originals[i]) != len(originals[j]) {
+			return len(originals[i]) < len(originals[j])
+		}
+		return originals[i] < originals[j]
+	})
+	for i, original := range originals {
+		standIn := []byte(a.Identifier(original))
+		for _, shorter := range originals[:i] {
+			for at := 0; ; {
+				n := strings.Index(original[at:], shorter)
+				if n < 0 {
+					break
+				}
+				copy(standIn[at+n:], identifiers[shorter])
+				at += n + len(shorter)
+			}
+		}
+		identifiers[original] = string(standIn)
+	}
+
+	var pairs []string
+	for i := len(originals) - 1; i >= 0; i-- {
+		pairs = append(pairs, originals[i], identifiers[originals[i]])
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// String replaces the MAC and IP addresses in free text, and the
+// identifiers r knows.
+func (a *Anonymizer) String(s string, r *strings.Replacer) string {
+	if r != nil {
+		s = r.Replace(s)
+	}
+	s = macPattern.ReplaceAllStringFunc(s, a.MAC)
+	s = ipv6Pattern.ReplaceAllStringFunc(s, func(m string) string {
+		if ip := net.ParseIP(m); ip != nil && ip.To4() == nil {
+			return a.IP(ip).String()
+		}
+		return m
+	})
+	s = ipv4Pattern.ReplaceAllStringFunc(s, func(m string) string {
+		if ip := net.ParseIP(m).To4(); ip != nil {
+			return a.IP(ip).String()
+		}
+		return m
+	})
+	return s
+}
+
+// Identifier returns the stand-in of a serial number, asset tag or UUID: each
+// digit, letter or (in hexadecimal identifiers) hex digit is replaced by one
+// of its kind, keeping case; other characters are kept.
+func (a *Anonymizer) Identifier(s string) string {
+	stream := a.stream("id", s)
+	hex := isHex(s)
+	out := []byte(s)
+	for i, c := range out {
+		b := stream()
+		switch {
+		case hex && c >= '0' && c <= '9', hex && c >= 'a' && c <= 'f', hex && c >= 'A' && c <= 'F':
+			digit := "0123456789abcdef"[b%16]
+			if c >= 'A' && c <= 'F' {
+				digit = "0123456789ABCDEF"[b%16]
+			}
+			out[i] = digit
+		case c >= '0' && c <= '9':
+			out[i] = '0' + b%10
+		case c >= 'A' && c <= 'Z':
+			out[i] = 'A' + b%26
+		case c >= 'a' && c <= 'z':
+			out[i] = 'a' + b%26
+		}
+	}
+	return string(out)
+}
+
+// isHex reports whether s looks like a UUID or other hexadecimal identifier.
+func isHex(s string) bool {
+	digits := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			digits++
+		case c != '-' && c != ':':
+			return false
+		}
+	}
+	return digits >= 8
+}
+
+// MAC returns the stand-in of a MAC address: a locally administered unicast
+// address, written with the same separator and case.
+func (a *Anonymizer) MAC(mac string) string {
+	normalized := strings.ToLower(strings.NewReplacer(":", "", "-", "").Replace(mac))
+	sum := a.sum("mac", normalized)
+	sum[0] = sum[0]&0xfc | 0x02
+	sep := string(mac[2])
+	parts := make([]string, 6)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%02x", sum[i])
+	}
+	out := strings.Join(parts, sep)
+	if strings.ToUpper(mac) == mac {
+		out = strings.ToUpper(out)
+	}
+	return out
+}
+
+// IP returns the stand-in of an IP address. Loopback, unspecified and
+// multicast addresses and netmasks are kept. IPv4 addresses map into
+// 10.0.0.0/8 with each /24 mapped as a whole; IPv6 addresses into fd00::/8,
+// or fe80::/64 for link-local ones, with each /64 mapped as a whole.
+func (a *Anonymizer) IP(ip net.IP) net.IP {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsMulticast() {
+		return ip
+	}
+	if v4 := ip.To4(); v4 != nil {
+		if isNetmask(v4) {
+			return ip
+		}
+		subnet := a.sum("ipv4", string(v4[:3]))
+		host := a.sum("ipv4", v4.String())
+		return net.IPv4(10, subnet[0], subnet[1], 1+host[0]%254)
+	}
+	out := make(net.IP, net.IPv6len)
+	host := a.sum("ipv6", ip.String())
+	copy(out[8:], host[:8])
+	if ip.IsLinkLocalUnicast() {
+		out[0], out[1] = 0xfe, 0x80
+		return out
+	}
+	subnet := a.sum("ipv6", string(ip.To16()[:8]))
+	out[0] = 0xfd
+	copy(out[1:8], subnet[:7])
+	return out
+}
+
+// isNetmask reports whether an IPv4 address is a subnet mask, e.g.
+// 255.255.255.0.
+func isNetmask(ip net.IP) bool {
+	ones, bits := net.IPMask(ip).Size()
+	return bits == 32 && ones >= 8
+}
+
+// sum is the HMAC of a value under the key, separated by kind so that equal
+// text of different kinds maps apart.
+func (a *Anonymizer) sum(kind, value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// stream returns the bytes of successive HMACs of a value, for stand-ins
+// longer than one sum.
+func (a *Anonymizer) stream(kind, value string) func() byte {
+	var block []byte
+	counter := uint32(0)
+	return func() byte {
+		if len(block) == 0 {
+			var n [4]byte
+			binary.BigEndian.PutUint32(n[:], counter)
+			counter++
+			block = a.sum(kind, value+string(n[:]))
+		}
+		b := block[0]
+		block = block[1:]
+		return b
+	}
+}
@@ -0,0 +1,139 @@
+// Package attestation records the provenance of asset data the way software
+// supply chains record that of builds: each collection run produces an
+// in-toto Statement saying what was collected, from which BMC, when and by
+// which collector version, whose subject is the SHA-256 digest of the
+// snapshot's signed content. The statement travels in a DSSE envelope on the
+// snapshot, signed with the collector's snapshot signing key when it has one,
+// so that tools verifying in-toto attestations can check it on their own.
+package attestation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/signing"
+)
+
+// Identifiers of the statement and its envelope.
+const (
+	StatementType = "https://in-toto.io/Statement/v1"
+	PayloadType   = "application/vnd.in-toto+json"
+	// PredicateType identifies the Collection predicate.
+	PredicateType = "https://github.com/example/inventory-v3/attestation/collection/v1"
+)
+
+// Subject names.
+const (
+	// SubjectSnapshot is the snapshot's SignedContent: BMC address, profile,
+	// devices and coverage.
+	SubjectSnapshot = "snapshot"
+	// SubjectRawCapture is the snapshot's raw capture, when it has one.
+	SubjectRawCapture = "rawCapture"
+)
+
+// Statement is an in-toto v1 Statement about a collection.
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Subject  `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     Collection `json:"predicate"`
+}
+
+// Subject is an artifact the statement is about, by digest.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Collection is the predicate: how the snapshot was collected.
+type Collection struct {
+	Collector  Collector `json:"collector"`
+	RunID      string    `json:"runId,omitempty"`
+	BMCAddress string    `json:"bmcAddress"`
+	Profile    string    `json:"profile,omitempty"`
+	Vendor     string    `json:"vendor,omitempty"`
+	StartedOn  time.Time `json:"startedOn"`
+	FinishedOn time.Time `json:"finishedOn"`
+	Devices    int       `json:"devices"`
+	// Decommissioned is set for the deletion snapshot of a decommissioned
+	// BMC, which collected nothing.
+	Decommissioned bool `json:"decommissioned,omitempty"`
+	// Reads and FailedReads summarize the snapshot's coverage.
+	Reads       int `json:"reads,omitempty"`
+	FailedReads int `json:"failedReads,omitempty"`
+}
+
+// Collector identifies the collector that ran.
+type Collector struct {
+	// Host is the collector's host name.
+	Host    string `json:"host,omitempty"`
+	Version string `json:"version"`
+}
+
+// Attest adds the attestation of a collection to its snapshot, signed by
+// signer unless it is nil. The snapshot must be complete: later changes to
+// its signed content or raw capture no longer match the subject digests.
+func Attest(spec *discoverysnapshot.DiscoverySnapshotSpec, predicate Collection, signer *signing.Signer) error {
+	content, err := spec.SignedContent()
+	if err != nil {
+		return err
+	}
+	statement := Statement{
+		Type:          StatementType,
+		Subject:       []Subject{subject(SubjectSnapshot, content)},
+		PredicateType: PredicateType,
+		Predicate:     predicate,
+	}
+	if len(spec.RawCapture) > 0 {
+		statement.Subject = append(statement.Subject, subject(SubjectRawCapture, spec.RawCapture))
+	}
+	if coverage := spec.Coverage; coverage != nil {
+		statement.Predicate.Reads, statement.Predicate.FailedReads = coverage.Reads, len(coverage.Failed)
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return fmt.Errorf("failed to encode attestation: %w", err)
+	}
+	envelope := &discoverysnapshot.Envelope{
+		PayloadType: PayloadType,
+		Payload:     payload,
+		Signatures:  []discoverysnapshot.EnvelopeSignature{},
+	}
+	if signer != nil {
+		sig, err := signer.Sign(PAE(PayloadType, payload))
+		if err != nil {
+			return err
+		}
+		envelope.Signatures = append(envelope.Signatures, discoverysnapshot.EnvelopeSignature{KeyID: sig.KeyID, Sig: sig.Value})
+	}
+	spec.Attestation = envelope
+	return nil
+}
+
+// Decode returns the statement of an envelope.
+func Decode(envelope *discoverysnapshot.Envelope) (*Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("unexpected attestation payload type %q", envelope.PayloadType)
+	}
+	var statement Statement
+	if err := json.Unmarshal(envelope.Payload, &statement); err != nil {
+		return nil, fmt.Errorf("failed to decode attestation: %w", err)
+	}
+	return &statement, nil
+}
+
+// PAE returns the DSSE pre-authentication encoding of a payload, which is
+// what its signatures cover.
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func subject(name string, content []byte) Subject {
+	sum := sha256.Sum256(content)
+	return Subject{Name: name, Digest: map[string]string{"sha256": hex.EncodeToString(sum[:])}}
+}
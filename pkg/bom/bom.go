@@ -0,0 +1,174 @@
+// Package bom exports a hardware bill of materials for a device and its
+// components in the CycloneDX JSON format.
+package bom
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// SpecVersion is the CycloneDX specification version of the exported BOMs.
+const SpecVersion = "1.5"
+
+// propertyPrefix namespaces the inventory properties in a component.
+const propertyPrefix = "inventory:"
+
+// BOM is a CycloneDX bill of materials.
+type BOM struct {
+	BOMFormat    string       `json:"bomFormat"`
+	SpecVersion  string       `json:"specVersion"`
+	SerialNumber string       `json:"serialNumber"`
+	Version      int          `json:"version"`
+	Metadata     Metadata     `json:"metadata"`
+	Components   []Component  `json:"components"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Metadata describes the BOM and the device it covers.
+type Metadata struct {
+	Timestamp time.Time `json:"timestamp"`
+	Tools     Tools     `json:"tools"`
+	Component Component `json:"component"`
+}
+
+// Tools lists the tools that produced the BOM.
+type Tools struct {
+	Components []Component `json:"components"`
+}
+
+// Component is one device. Nested components are the devices inside it.
+type Component struct {
+	Type        string        `json:"type"`
+	BOMRef      string        `json:"bom-ref,omitempty"`
+	Name        string        `json:"name"`
+	Version     string        `json:"version,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Supplier    *Organization `json:"supplier,omitempty"`
+	Properties  []Property    `json:"properties,omitempty"`
+	Components  []Component   `json:"components,omitempty"`
+}
+
+// Organization is a supplier or manufacturer.
+type Organization struct {
+	Name string `json:"name"`
+}
+
+// Property is a name/value pair.
+type Property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Dependency records that a component contains others.
+type Dependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// identifyingProperties are the device properties copied into components
+// when present, besides serial and part numbers.
+var identifyingProperties = []string{"redfish_uri", "xname", "model", "sku", "service_tag", "express_service_code", "machine_type_model", "fru_part_number", "board_serial_number", "mac_addresses", "port_guids", "node_guid", "rack", "rack_offset"}
+
+// Build returns the BOM of root and its descendants (linked by ParentID).
+// Retired devices and devices still expected from a manifest are not
+// installed and are left out.
+func Build(root *device.Device, devices []*device.Device, now time.Time) *BOM {
+	children := make(map[string][]*device.Device)
+	for _, d := range devices {
+		if d.Spec.ParentID != "" && !prune.IsRetired(d) && !manifest.IsExpected(d) {
+			children[d.Spec.ParentID] = append(children[d.Spec.ParentID], d)
+		}
+	}
+	for _, list := range children {
+		sort.Slice(list, func(i, j int) bool { return list[i].GetName() < list[j].GetName() })
+	}
+
+	bom := &BOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  SpecVersion,
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Metadata: Metadata{
+			Timestamp: now.UTC(),
+			Tools: Tools{Components: []Component{
+				{Type: "application", Name: "inventory-v3"},
+			}},
+		},
+		Components:   []Component{},
+		Dependencies: []Dependency{},
+	}
+
+	seen := map[string]bool{root.GetUID(): true}
+	var build func(d *device.Device) Component
+	build = func(d *device.Device) Component {
+		c := component(d)
+		dep := Dependency{Ref: d.GetUID()}
+		for _, child := range children[d.GetUID()] {
+			if seen[child.GetUID()] {
+				continue
+			}
+			seen[child.GetUID()] = true
+			c.Components = append(c.Components, build(child))
+			dep.DependsOn = append(dep.DependsOn, child.GetUID())
+		}
+		bom.Dependencies = append(bom.Dependencies, dep)
+		return c
+	}
+	top := build(root)
+	bom.Components = top.Components
+	top.Components = nil
+	bom.Metadata.Component = top
+	return bom
+}
+
+// component describes one device without its children.
+func component(d *device.Device) Component {
+	c := Component{
+		Type:        "device",
+		BOMRef:      d.GetUID(),
+		Name:        d.Spec.DeviceType,
+		Description: d.GetName(),
+	}
+	if d.Spec.PartNumber != "" {
+		c.Name = fmt.Sprintf("%s %s", d.Spec.DeviceType, d.Spec.PartNumber)
+	}
+	d.Spec.GetProperty("firmware_version", &c.Version)
+	if d.Spec.Manufacturer != "" {
+		c.Supplier = &Organization{Name: d.Spec.Manufacturer}
+	}
+
+	add := func(name, value string) {
+		if value != "" {
+			c.Properties = append(c.Properties, Property{Name: propertyPrefix + name, Value: value})
+		}
+	}
+	add("deviceType", d.Spec.DeviceType)
+	add("serialNumber", d.Spec.SerialNumber)
+	add("partNumber", d.Spec.PartNumber)
+	for _, key := range identifyingProperties {
+		if raw, ok := d.Spec.Properties[key]; ok {
+			var s string
+			if d.Spec.GetProperty(key, &s) {
+				add(key, s)
+			} else {
+				add(key, string(raw))
+			}
+		}
+	}
+	return c
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
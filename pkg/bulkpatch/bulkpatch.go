@@ -0,0 +1,318 @@
+// Package bulkpatch applies bulk edits to devices, such as asset tags,
+// locations, labels and other properties, listed one device per row in a
+// CSV file or a JSON array, and keyed by UID or serial number.
+//
+// Discovery replaces a device's spec with what its BMC reports. Properties a
+// bulk patch sets are listed in the ManualPropertiesAnnotation, and kept by
+// discovery unless the BMC reports them too.
+package bulkpatch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/properties"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// ManualPropertiesAnnotation lists, comma-separated, the properties of a
+// device set by bulk patches.
+const ManualPropertiesAnnotation = "inventory.manualProperties"
+
+// Row is the edits of one device.
+type Row struct {
+	// Line is the CSV line number, or the position in the JSON array, for
+	// error messages.
+	Line int `json:"line,omitempty"`
+	// UID or SerialNumber identifies the device; when both are given they
+	// must agree.
+	UID          string `json:"uid,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// Labels are set; a null value removes the label.
+	Labels map[string]*string `json:"labels,omitempty"`
+	// Properties are set; a null value removes the property.
+	Properties map[string]json.RawMessage `json:"properties,omitempty"`
+}
+
+// Entry is the outcome of one row.
+type Entry struct {
+	Line         int    `json:"line"`
+	UID          string `json:"uid,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// Changed lists the labels and properties the row changed, e.g.
+	// "labels.role" or "properties.rack". It is empty when the device
+	// already had the row's values.
+	Changed []string `json:"changed,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Result is the response of a bulk patch.
+type Result struct {
+	DryRun bool `json:"dryRun,omitempty"`
+	Rows   int  `json:"rows"`
+	// Applied counts the rows that changed their device (or would, in a dry
+	// run), Unchanged those whose device already had their values, and
+	// Failed those rejected; see their entries' errors.
+	Applied   int     `json:"applied"`
+	Unchanged int     `json:"unchanged"`
+	Failed    int     `json:"failed"`
+	Entries   []Entry `json:"entries"`
+}
+
+// columns maps the shorthand headers (lowercase, without spaces, dashes or
+// underscores) to the properties they set.
+var columns = map[string]string{
+	"assettag":        "asset_tag",
+	"row":             "row",
+	"rack":            "rack",
+	"rackoffset":      "rack_offset",
+	"rackoffsetunits": "rack_offset_units",
+}
+
+// ParseCSV reads a bulk patch CSV. The first line is a header naming the
+// columns: uid or serialNumber (or both) identify the device, and the others
+// name what a row sets: labels.KEY, properties.KEY, or a shorthand for a
+// property (assetTag, row, rack, rackOffset, rackOffsetUnits). Unknown
+// columns are rejected. Empty cells leave their field unchanged; removing a
+// label or property takes a JSON patch with a null value.
+//
+// Property values are converted to the type the property registry gives
+// the key, e.g. a number for rack_offset; unregistered keys are strings.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the patch is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	type column struct{ label, property string }
+	fields := make([]column, len(header))
+	uidColumn, serialColumn := -1, -1
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		prefix, key, dotted := strings.Cut(name, ".")
+		switch normalized := normalize(name); {
+		case normalized == "uid":
+			uidColumn = i
+		case normalized == "serial" || normalized == "serialnumber" || normalized == "sn":
+			serialColumn = i
+		case dotted && key != "" && (normalize(prefix) == "labels" || normalize(prefix) == "label"):
+			fields[i].label = key
+		case dotted && key != "" && (normalize(prefix) == "properties" || normalize(prefix) == "property"):
+			fields[i].property = key
+		case columns[normalized] != "":
+			fields[i].property = columns[normalized]
+		default:
+			return nil, fmt.Errorf("unknown column %q: use uid, serialNumber, labels.KEY, properties.KEY, assetTag, row, rack, rackOffset or rackOffsetUnits", name)
+		}
+	}
+	if uidColumn < 0 && serialColumn < 0 {
+		return nil, fmt.Errorf("header %v has no uid or serial number column", header)
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read patch: %w", err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			switch {
+			case value == "" || i >= len(fields):
+			case i == uidColumn:
+				row.UID = value
+			case i == serialColumn:
+				row.SerialNumber = value
+			case fields[i].label != "":
+				if row.Labels == nil {
+					row.Labels = make(map[string]*string)
+				}
+				row.Labels[fields[i].label] = &value
+			case fields[i].property != "":
+				if row.Properties == nil {
+					row.Properties = make(map[string]json.RawMessage)
+				}
+				row.Properties[fields[i].property] = propertyValue(fields[i].property, value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ParseJSON reads a bulk patch JSON array of rows.
+func ParseJSON(r io.Reader) ([]Row, error) {
+	var rows []Row
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	for i := range rows {
+		if rows[i].Line == 0 {
+			rows[i].Line = i + 1
+		}
+	}
+	return rows, nil
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// propertyValue converts a CSV cell to the registered type of the property.
+// A cell that does not convert stays a string, which Apply rejects.
+func propertyValue(key, value string) json.RawMessage {
+	schema, _ := properties.Lookup(key)
+	var converted interface{} = value
+	switch schema.Type {
+	case properties.TypeInteger:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			converted = n
+		}
+	case properties.TypeNumber:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			converted = n
+		}
+	case properties.TypeBoolean:
+		if b, err := strconv.ParseBool(value); err == nil {
+			converted = b
+		}
+	case properties.TypeObject, properties.TypeArray:
+		if json.Valid([]byte(value)) {
+			return json.RawMessage(value)
+		}
+	}
+	raw, _ := json.Marshal(converted)
+	return raw
+}
+
+// Apply makes the row's edits to d and returns what changed, sorted. It
+// changes nothing when the edits are invalid.
+func Apply(d *device.Device, row Row) ([]string, error) {
+	patched := make(map[string]json.RawMessage, len(row.Properties))
+	for key, raw := range row.Properties {
+		if len(raw) > 0 && string(raw) != "null" {
+			patched[key] = raw
+		}
+	}
+	if err := properties.Validate(patched); err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for key, value := range row.Labels {
+		current, ok := d.Metadata.Labels[key]
+		switch {
+		case value == nil && ok:
+			delete(d.Metadata.Labels, key)
+		case value != nil && (!ok || current != *value):
+			if d.Metadata.Labels == nil {
+				d.Metadata.Labels = make(map[string]string)
+			}
+			d.Metadata.Labels[key] = *value
+		default:
+			continue
+		}
+		changed = append(changed, "labels."+key)
+	}
+
+	manual := ManualProperties(d)
+	for key := range row.Properties {
+		current, ok := d.Spec.Properties[key]
+		if value, set := patched[key]; set {
+			manual[key] = true
+			if ok && jsonEqual(current, value) {
+				continue
+			}
+			if d.Spec.Properties == nil {
+				d.Spec.Properties = make(map[string]json.RawMessage)
+			}
+			d.Spec.Properties[key] = value
+		} else {
+			delete(manual, key)
+			if !ok {
+				continue
+			}
+			delete(d.Spec.Properties, key)
+		}
+		changed = append(changed, "properties."+key)
+	}
+	setManualProperties(d, manual)
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// ManualProperties returns the keys of the properties of d set by bulk
+// patches.
+func ManualProperties(d *device.Device) map[string]bool {
+	manual := make(map[string]bool)
+	for _, key := range strings.Split(d.Metadata.Annotations[ManualPropertiesAnnotation], ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			manual[key] = true
+		}
+	}
+	return manual
+}
+
+func setManualProperties(d *device.Device, manual map[string]bool) {
+	if len(manual) == 0 {
+		delete(d.Metadata.Annotations, ManualPropertiesAnnotation)
+		return
+	}
+	keys := make([]string, 0, len(manual))
+	for key := range manual {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if d.Metadata.Annotations == nil {
+		d.Metadata.Annotations = make(map[string]string)
+	}
+	d.Metadata.Annotations[ManualPropertiesAnnotation] = strings.Join(keys, ",")
+}
+
+// KeepManualProperties copies into spec, which discovery is replacing the
+// spec of d with, the properties of d set by bulk patches that spec does not
+// report.
+func KeepManualProperties(d *device.Device, spec *device.DeviceSpec) {
+	for key := range ManualProperties(d) {
+		if _, reported := spec.Properties[key]; reported {
+			continue
+		}
+		if value, ok := d.Spec.Properties[key]; ok {
+			if spec.Properties == nil {
+				spec.Properties = make(map[string]json.RawMessage)
+			}
+			spec.Properties[key] = value
+		}
+	}
+}
+
+// jsonEqual reports whether two JSON values are equal.
+func jsonEqual(a, b json.RawMessage) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	ax, _ := json.Marshal(x)
+	by, _ := json.Marshal(y)
+	return string(ax) == string(by)
+}
@@ -0,0 +1,374 @@
+// Package cabling infers which switch port each node NIC is cabled to, from
+// what switches report about their ports, and keeps the Connection resources
+// that record the cabling.
+//
+// Switch devices (deviceType Switch), reported by a Redfish or SNMP backend
+// or plugin, carry two properties:
+//
+//	lldp_neighbors: [{"port": "Ethernet12", "chassisId": "b8:59:9f:00:11:20", "portId": "b8:59:9f:00:11:22", "systemName": "node12"}]
+//	mac_table:      [{"port": "Ethernet12", "macAddress": "b8:59:9f:00:11:22", "vlan": 10}]
+//
+// and NICs their mac_addresses. An LLDP neighbor whose port or chassis ID is
+// a NIC's MAC address is a cable. NICs whose BMC reports LLDP carry
+// lldp_neighbors too, naming the switch by its chassis_id (or a MAC address
+// of its) or system_name, and the switch port by its port ID. Without LLDP,
+// a port that learned the MAC addresses of one node only is taken to be
+// cabled to it; ports learning several nodes' addresses are uplinks and tell
+// nothing.
+package cabling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/connection"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// SwitchType is the device type of switches.
+const SwitchType = "Switch"
+
+// Neighbor is an LLDP neighbor a switch or NIC port sees.
+type Neighbor struct {
+	// Port is the local port: the switch's name for it, or the Redfish Id
+	// (or Dell FQDD) of the NIC port. MACAddress is a NIC port's address.
+	Port       string `json:"port"`
+	MACAddress string `json:"macAddress,omitempty"`
+	ChassisID  string `json:"chassisId,omitempty"`
+	PortID     string `json:"portId,omitempty"`
+	// PortIDSubtype says what PortID holds, in Redfish's terms: "IfName",
+	// "LocalAssign", "MacAddr"...
+	PortIDSubtype     string `json:"portIdSubtype,omitempty"`
+	SystemName        string `json:"systemName,omitempty"`
+	ManagementAddress string `json:"managementAddress,omitempty"`
+}
+
+// MACEntry is an address a switch port learned.
+type MACEntry struct {
+	Port       string `json:"port"`
+	MACAddress string `json:"macAddress"`
+	VLAN       int    `json:"vlan,omitempty"`
+}
+
+// Link is a cable found between a switch port and a NIC.
+type Link struct {
+	SwitchID   string
+	SwitchPort string
+	NICID      string
+	NodeID     string
+	MACAddress string
+	// Method is connection.MethodLLDP or connection.MethodMACTable.
+	Method string
+}
+
+// Key identifies the cable a link or connection records.
+func Key(switchID, port, mac string) string {
+	return switchID + "|" + port + "|" + NormalizeMAC(mac)
+}
+
+// nic is a NIC port's device and the node it is in.
+type nic struct {
+	device *device.Device
+	nodeID string
+}
+
+// Infer returns the links the switches among devices report, sorted by
+// switch and port.
+func Infer(devices []*device.Device) []Link {
+	byUID := make(map[string]*device.Device, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+	}
+	nics := make(map[string]nic)
+	for _, d := range devices {
+		var macs []string
+		if !d.Spec.GetProperty("mac_addresses", &macs) {
+			continue
+		}
+		n := nic{device: d, nodeID: nodeOf(d, byUID)}
+		for _, mac := range macs {
+			if mac = NormalizeMAC(mac); mac != "" {
+				nics[mac] = n
+			}
+		}
+	}
+
+	var lldp, learned []Link
+	// lldpPorts are the switch ports with a cable found by LLDP
+	lldpPorts := make(map[string]bool)
+	addLLDP := func(l Link) {
+		key := Key(l.SwitchID, l.SwitchPort, l.MACAddress)
+		if !lldpPorts[key] {
+			lldp = append(lldp, l)
+		}
+		lldpPorts[key] = true
+		lldpPorts[l.SwitchID+"|"+l.SwitchPort] = true
+	}
+
+	switches := indexSwitches(devices)
+	for _, d := range devices {
+		var neighbors []Neighbor
+		if d.Spec.DeviceType == SwitchType || !d.Spec.GetProperty("lldp_neighbors", &neighbors) {
+			continue
+		}
+		n := nic{device: d, nodeID: nodeOf(d, byUID)}
+		for _, neighbor := range neighbors {
+			sw := switches[NormalizeMAC(neighbor.ChassisID)]
+			if sw == nil && neighbor.SystemName != "" {
+				sw = switches[strings.ToLower(neighbor.SystemName)]
+			}
+			port := switchPort(neighbor)
+			if sw == nil || port == "" {
+				continue
+			}
+			addLLDP(link(sw, port, firstNonEmpty(NormalizeMAC(neighbor.MACAddress), onlyMAC(d)), n, connection.MethodLLDP))
+		}
+	}
+
+	// learnedCount is how many addresses the port of a learned link learned
+	learnedCount := make(map[Link]int)
+	for _, sw := range devices {
+		if sw.Spec.DeviceType != SwitchType {
+			continue
+		}
+		var neighbors []Neighbor
+		sw.Spec.GetProperty("lldp_neighbors", &neighbors)
+		for _, neighbor := range neighbors {
+			for _, id := range []string{neighbor.PortID, neighbor.ChassisID} {
+				mac := NormalizeMAC(id)
+				if n, ok := nics[mac]; ok && neighbor.Port != "" {
+					addLLDP(link(sw, neighbor.Port, mac, n, connection.MethodLLDP))
+					break
+				}
+			}
+		}
+
+		var table []MACEntry
+		sw.Spec.GetProperty("mac_table", &table)
+		byPort := make(map[string][]string)
+		for _, entry := range table {
+			if entry.Port != "" && !lldpPorts[sw.GetUID()+"|"+entry.Port] {
+				byPort[entry.Port] = append(byPort[entry.Port], NormalizeMAC(entry.MACAddress))
+			}
+		}
+		for port, macs := range byPort {
+			var known []string
+			nodes := make(map[string]bool)
+			for _, mac := range macs {
+				if n, ok := nics[mac]; ok {
+					known = append(known, mac)
+					nodes[firstNonEmpty(n.nodeID, n.device.GetUID())] = true
+				}
+			}
+			if len(nodes) != 1 {
+				continue
+			}
+			for _, mac := range known {
+				l := link(sw, port, mac, nics[mac], connection.MethodMACTable)
+				learned = append(learned, l)
+				learnedCount[l] = len(macs)
+			}
+		}
+	}
+
+	// A NIC found by LLDP is not looked for in MAC tables, and one learned
+	// on several edge ports is taken to be on the port learning the fewest
+	// addresses, or dropped when that is a tie
+	links := lldp
+	found := make(map[string]bool)
+	for _, l := range lldp {
+		found[l.MACAddress] = true
+	}
+	best := make(map[string][]Link)
+	for _, l := range learned {
+		if found[l.MACAddress] {
+			continue
+		}
+		current := best[l.MACAddress]
+		switch {
+		case len(current) == 0 || learnedCount[l] < learnedCount[current[0]]:
+			best[l.MACAddress] = []Link{l}
+		case learnedCount[l] == learnedCount[current[0]]:
+			best[l.MACAddress] = append(current, l)
+		}
+	}
+	for _, candidates := range best {
+		if len(candidates) == 1 {
+			links = append(links, candidates[0])
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return Key(links[i].SwitchID, links[i].SwitchPort, links[i].MACAddress) < Key(links[j].SwitchID, links[j].SwitchPort, links[j].MACAddress)
+	})
+	return links
+}
+
+func link(sw *device.Device, port, mac string, n nic, method string) Link {
+	return Link{
+		SwitchID:   sw.GetUID(),
+		SwitchPort: port,
+		NICID:      n.device.GetUID(),
+		NodeID:     n.nodeID,
+		MACAddress: mac,
+		Method:     method,
+	}
+}
+
+// Miscabling is a manual connection, which records the planned cabling,
+// that the links found contradict.
+type Miscabling struct {
+	ConnectionID string `json:"connectionID"`
+	// Found says what was found instead.
+	Found string `json:"found"`
+}
+
+// Miscablings returns the manual connections among connections whose switch
+// port links find cabled to another NIC, or whose NIC they find on another
+// switch port. That is how a node cabled to the wrong port during bring-up
+// shows.
+func Miscablings(links []Link, connections []*connection.Connection) []Miscabling {
+	var out []Miscabling
+	for _, conn := range connections {
+		if conn.Spec.Method != connection.MethodManual {
+			continue
+		}
+		for _, l := range links {
+			samePort := l.SwitchID == conn.Spec.SwitchID && l.SwitchPort == conn.Spec.SwitchPort
+			same, known := sameNIC(l, conn)
+			if !known || samePort == same {
+				continue
+			}
+			found := fmt.Sprintf("port %s of switch %s is cabled to %s", l.SwitchPort, l.SwitchID, firstNonEmpty(l.MACAddress, l.NICID))
+			if same {
+				found = fmt.Sprintf("%s is cabled to port %s of switch %s", firstNonEmpty(conn.Spec.MACAddress, conn.Spec.NICID), l.SwitchPort, l.SwitchID)
+			}
+			out = append(out, Miscabling{ConnectionID: conn.GetUID(), Found: found + ", found by " + l.Method})
+			break
+		}
+	}
+	return out
+}
+
+// sameNIC reports whether a link and a connection are to the same NIC port,
+// by MAC address or else NIC, and whether that can be told.
+func sameNIC(l Link, conn *connection.Connection) (same, known bool) {
+	if mac := NormalizeMAC(conn.Spec.MACAddress); mac != "" && l.MACAddress != "" {
+		return mac == l.MACAddress, true
+	}
+	if conn.Spec.NICID != "" && l.NICID != "" {
+		return conn.Spec.NICID == l.NICID, true
+	}
+	return false, false
+}
+
+// indexSwitches maps the switches among devices by what NICs' LLDP neighbors
+// name them with: their chassis ID and MAC addresses, and their system name
+// and device name in lower case.
+func indexSwitches(devices []*device.Device) map[string]*device.Device {
+	switches := make(map[string]*device.Device)
+	for _, d := range devices {
+		if d.Spec.DeviceType != SwitchType {
+			continue
+		}
+		var chassisID, systemName string
+		var macs []string
+		d.Spec.GetProperty("chassis_id", &chassisID)
+		d.Spec.GetProperty("system_name", &systemName)
+		d.Spec.GetProperty("mac_addresses", &macs)
+		for _, mac := range append(macs, chassisID) {
+			if mac = NormalizeMAC(mac); mac != "" {
+				switches[mac] = d
+			}
+		}
+		for _, name := range []string{systemName, d.GetName()} {
+			if name != "" {
+				switches[strings.ToLower(name)] = d
+			}
+		}
+	}
+	return switches
+}
+
+// switchPort returns the switch port a NIC's LLDP neighbor names, or "" when
+// its port ID is an address rather than a name.
+func switchPort(n Neighbor) string {
+	switch n.PortIDSubtype {
+	case "MacAddr", "NetworkAddr", "NotTransmitted":
+		return ""
+	}
+	if NormalizeMAC(n.PortID) != "" {
+		return ""
+	}
+	return strings.TrimSpace(n.PortID)
+}
+
+// onlyMAC returns the MAC address of a NIC with one, or "".
+func onlyMAC(d *device.Device) string {
+	var macs []string
+	if d.Spec.GetProperty("mac_addresses", &macs) && len(macs) == 1 {
+		return NormalizeMAC(macs[0])
+	}
+	return ""
+}
+
+// nodeOf returns the UID of the Node a device is in, or "".
+func nodeOf(d *device.Device, byUID map[string]*device.Device) string {
+	seen := make(map[string]bool)
+	for d != nil && !seen[d.GetUID()] {
+		if d.Spec.DeviceType == "Node" {
+			return d.GetUID()
+		}
+		seen[d.GetUID()] = true
+		d = byUID[d.Spec.ParentID]
+	}
+	return ""
+}
+
+// NormalizeMAC returns a MAC address in lower case with colons, whatever
+// its separators ("B8-59-9F-00-11-22", "b859.9f00.1122"), or "" when s is
+// not a MAC address.
+func NormalizeMAC(s string) string {
+	hex := strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+	if len(hex) != 12 {
+		return ""
+	}
+	var b strings.Builder
+	for i := 0; i < 12; i++ {
+		c := hex[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return ""
+		}
+		if i > 0 && i%2 == 0 {
+			b.WriteByte(':')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Result is the outcome of a cabling inference run.
+type Result struct {
+	RanAt          time.Time `json:"ranAt"`
+	DurationMillis int64     `json:"durationMillis"`
+	// Links counts the cables found; Created, Updated and Stale the
+	// Connection resources created, refreshed and marked stale for them.
+	Links   int `json:"links"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Stale   int `json:"stale"`
+	// Miscabled lists the manual connections found contradicted.
+	Miscabled []Miscabling `json:"miscabled,omitempty"`
+	Errors    []string     `json:"errors,omitempty"`
+}
@@ -0,0 +1,353 @@
+// Package cache keeps a local SQLite copy of the inventory's devices, so they
+// can be looked up by serial number, MAC address or rack, or queried, with
+// no connection to the server, e.g. on a field engineer's laptop in the data
+// center. The copy is as old as its last sync, which Info reports.
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/lookup"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/query"
+	"github.com/example/inventory-v3/pkg/resources/device"
+
+	_ "modernc.org/sqlite"
+)
+
+// DefaultMaxAge is how old a cache may be before it is reported stale.
+const DefaultMaxAge = 24 * time.Hour
+
+// schemaVersion is stored as the database's user_version; caches of another
+// version are rebuilt by the next sync.
+const schemaVersion = 1
+
+const schema = `
+CREATE TABLE IF NOT EXISTS devices (
+	uid           TEXT PRIMARY KEY,
+	name          TEXT NOT NULL,
+	device_type   TEXT NOT NULL,
+	serial_number TEXT NOT NULL,
+	rack          TEXT NOT NULL,
+	retired       INTEGER NOT NULL,
+	document      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS devices_serial_number ON devices (serial_number);
+CREATE INDEX IF NOT EXISTS devices_rack ON devices (rack);
+CREATE TABLE IF NOT EXISTS device_macs (
+	mac TEXT NOT NULL,
+	uid TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS device_macs_mac ON device_macs (mac);
+CREATE TABLE IF NOT EXISTS sync (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);`
+
+// ErrNotSynced is returned by lookups in a cache that was never synced.
+var ErrNotSynced = errors.New("the cache was never synced; run sync while connected to the server")
+
+// Info describes a cache's contents and how old they are.
+type Info struct {
+	Path string `json:"path"`
+	// Server is the server the cache was last synced from.
+	Server   string     `json:"server,omitempty"`
+	SyncedAt *time.Time `json:"syncedAt,omitempty"`
+	Devices  int        `json:"devices"`
+	// Age is the time since the last sync, and Stale whether it is over the
+	// maximum age asked for.
+	Age   string `json:"age,omitempty"`
+	Stale bool   `json:"stale"`
+}
+
+// Cache is a local copy of the inventory's devices.
+type Cache struct {
+	path string
+	db   *sql.DB
+}
+
+// DefaultPath returns the cache file in the user's cache directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "inventory_v3", "devices.db"), nil
+}
+
+// Open opens the cache at path, creating it if needed.
+func Open(path string) (*Cache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache %s: %w", path, err)
+	}
+	c := &Cache{path: path, db: db}
+	if err := c.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open cache %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Close closes the cache.
+func (c *Cache) Close() error {
+	return c.db.Close()
+}
+
+func (c *Cache) migrate() error {
+	var version int
+	if err := c.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version != schemaVersion {
+		for _, table := range []string{"devices", "device_macs", "sync"} {
+			if _, err := c.db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := c.db.Exec(schema); err != nil {
+		return err
+	}
+	_, err := c.db.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion))
+	return err
+}
+
+// Sync replaces the cache's devices, retired ones included, with the
+// inventory of the server at serverURL.
+func (c *Cache) Sync(ctx context.Context, cl *client.Client, serverURL string) (*Info, error) {
+	var devices []*device.Device
+	byUID := make(map[string]*device.Device)
+	opts := client.ListOptions{Query: url.Values{"includeRetired": {"true"}}}
+	for d, err := range client.Devices(cl).All(ctx, opts) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list devices: %w", err)
+		}
+		dev := d
+		devices = append(devices, &dev)
+		byUID[dev.GetUID()] = &dev
+	}
+	getParent := func(uid string) *device.Device { return byUID[uid] }
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	for _, table := range []string{"devices", "device_macs"} {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range devices {
+		doc, err := json.Marshal(d)
+		if err != nil {
+			return nil, err
+		}
+		// A device's rack is its own, or its nearest ancestor's.
+		rack := lookup.NewSerialMatch(d, getParent).Location.Rack
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO devices (uid, name, device_type, serial_number, rack, retired, document) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			d.GetUID(), d.GetName(), d.Spec.DeviceType, d.Spec.SerialNumber, rack, prune.IsRetired(d), string(doc)); err != nil {
+			return nil, fmt.Errorf("failed to cache device %s: %w", d.GetUID(), err)
+		}
+		var macs []string
+		d.Spec.GetProperty("mac_addresses", &macs)
+		for _, mac := range macs {
+			if normalized, err := lookup.NormalizeMAC(mac); err == nil {
+				if _, err := tx.ExecContext(ctx, "INSERT INTO device_macs (mac, uid) VALUES (?, ?)", normalized, d.GetUID()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	now := time.Now().UTC()
+	for key, value := range map[string]string{"server": serverURL, "syncedAt": now.Format(time.RFC3339Nano)} {
+		if _, err := tx.ExecContext(ctx, "INSERT OR REPLACE INTO sync (key, value) VALUES (?, ?)", key, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to save cache: %w", err)
+	}
+	return c.Info(ctx, DefaultMaxAge)
+}
+
+// Info describes the cache. It is stale when last synced over maxAge ago.
+func (c *Cache) Info(ctx context.Context, maxAge time.Duration) (*Info, error) {
+	info := &Info{Path: c.path}
+	rows, err := c.db.QueryContext(ctx, "SELECT key, value FROM sync")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		switch key {
+		case "server":
+			info.Server = value
+		case "syncedAt":
+			if at, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				info.SyncedAt = &at
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM devices").Scan(&info.Devices); err != nil {
+		return nil, err
+	}
+	if info.SyncedAt == nil {
+		info.Stale = true
+		return info, nil
+	}
+	age := time.Since(*info.SyncedAt)
+	info.Age = age.Round(time.Second).String()
+	info.Stale = maxAge > 0 && age > maxAge
+	return info, nil
+}
+
+// BySerial returns the devices with the serial number, each with its
+// ancestors and location, as the server's serial number lookup does.
+func (c *Cache) BySerial(ctx context.Context, serial string) (*lookup.SerialResult, error) {
+	matches, err := c.load(ctx, "serial_number = ?", serial)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no cached device has serial number %s", serial)
+	}
+	result := &lookup.SerialResult{SerialNumber: serial, Matches: make([]lookup.SerialMatch, 0, len(matches))}
+	getParent := c.parents(ctx)
+	for _, d := range matches {
+		result.Matches = append(result.Matches, lookup.NewSerialMatch(d, getParent))
+	}
+	return result, nil
+}
+
+// ByMAC returns the devices reporting the MAC address, each with its node,
+// as the server's MAC lookup does.
+func (c *Cache) ByMAC(ctx context.Context, mac string) (*lookup.MACResult, error) {
+	mac, err := lookup.NormalizeMAC(mac)
+	if err != nil {
+		return nil, err
+	}
+	holders, err := c.load(ctx, "uid IN (SELECT uid FROM device_macs WHERE mac = ?)", mac)
+	if err != nil {
+		return nil, err
+	}
+	if len(holders) == 0 {
+		return nil, fmt.Errorf("no cached device reports MAC %s", mac)
+	}
+	result := &lookup.MACResult{MAC: mac, Matches: make([]lookup.MACMatch, 0, len(holders))}
+	getParent := c.parents(ctx)
+	for _, d := range holders {
+		match := lookup.MACMatch{Device: lookup.NewDeviceRef(d)}
+		if node := lookup.OwningNode(d, getParent); node != nil {
+			ref := lookup.NewDeviceRef(node)
+			match.Node = &ref
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	return result, nil
+}
+
+// Rack returns the devices in the rack: those with the rack property, and
+// their descendants.
+func (c *Cache) Rack(ctx context.Context, rack string, includeRetired bool) ([]device.Device, error) {
+	where := "rack = ?"
+	if !includeRetired {
+		where += " AND NOT retired"
+	}
+	devices, err := c.load(ctx, where, rack)
+	if err != nil {
+		return nil, err
+	}
+	return values(devices), nil
+}
+
+// Query returns the devices satisfying the filter expression.
+func (c *Cache) Query(ctx context.Context, expr *query.Expr, includeRetired bool) ([]device.Device, error) {
+	where := "1"
+	if !includeRetired {
+		where = "NOT retired"
+	}
+	devices, err := c.load(ctx, where)
+	if err != nil {
+		return nil, err
+	}
+	return values(expr.Filter(devices)), nil
+}
+
+// load returns the cached devices matching the WHERE clause, by name.
+func (c *Cache) load(ctx context.Context, where string, args ...interface{}) ([]*device.Device, error) {
+	info, err := c.Info(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	if info.SyncedAt == nil {
+		return nil, ErrNotSynced
+	}
+	return c.scan(ctx, where, args...)
+}
+
+func (c *Cache) scan(ctx context.Context, where string, args ...interface{}) ([]*device.Device, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT document FROM devices WHERE "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var devices []*device.Device
+	for rows.Next() {
+		var doc string
+		if err := rows.Scan(&doc); err != nil {
+			return nil, err
+		}
+		var d device.Device
+		if err := json.Unmarshal([]byte(doc), &d); err != nil {
+			return nil, fmt.Errorf("corrupt cache entry: %w", err)
+		}
+		devices = append(devices, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].GetName() < devices[j].GetName() })
+	return devices, nil
+}
+
+// parents returns a function loading a cached device by UID, or nil.
+func (c *Cache) parents(ctx context.Context) func(uid string) *device.Device {
+	return func(uid string) *device.Device {
+		devices, err := c.scan(ctx, "uid = ?", uid)
+		if err != nil || len(devices) == 0 {
+			return nil
+		}
+		return devices[0]
+	}
+}
+
+func values(devices []*device.Device) []device.Device {
+	result := make([]device.Device, len(devices))
+	for i, d := range devices {
+		result[i] = *d
+	}
+	return result
+}
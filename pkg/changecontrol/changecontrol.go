@@ -0,0 +1,55 @@
+// Package changecontrol decides which inventory changes need an operator's
+// sign-off, and records them as PendingChanges until they get it.
+package changecontrol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Policy is a site's change-control policy. The zero value signs off
+// every change.
+type Policy struct {
+	// RetireOver holds retirements and deletions of more than this many
+	// devices at once, by prune or decommission, for approval (0 never does).
+	RetireOver int
+	// SerialRewrites holds new serial numbers reported for known devices for
+	// approval, instead of recording them as replacements straight away.
+	SerialRewrites bool
+}
+
+// RetireNeedsApproval reports whether retiring or deleting n devices at once
+// needs an operator's sign-off.
+func (p Policy) RetireNeedsApproval(n int) bool {
+	return p.RetireOver > 0 && n > p.RetireOver
+}
+
+// New returns a PendingChange of action, waiting for approval. The caller
+// fills in what it changes and stores it.
+func New(action, summary string) (*pendingchange.PendingChange, error) {
+	uid, err := resource.GenerateUIDForResource("PendingChange")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate UID for pending change: %w", err)
+	}
+	change := &pendingchange.PendingChange{
+		Resource: resource.Resource{
+			APIVersion:    "v1",
+			Kind:          "PendingChange",
+			SchemaVersion: "v1",
+		},
+		Spec: pendingchange.PendingChangeSpec{Action: action, Summary: summary},
+		Status: pendingchange.PendingChangeStatus{
+			Phase:   pendingchange.PhasePending,
+			Message: "Waiting for approval: " + summary,
+		},
+	}
+	now := time.Now()
+	change.Metadata.UID = uid
+	change.Metadata.Name = uid
+	change.Metadata.CreatedAt = now
+	change.Metadata.UpdatedAt = now
+	return change, nil
+}
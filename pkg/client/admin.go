@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/example/inventory-v3/pkg/gitopsstatus"
+	"github.com/example/inventory-v3/pkg/reconcilestatus"
+)
+
+// GetReconcilerStatus retrieves what the server's reconcilers are doing.
+func (c *Client) GetReconcilerStatus(ctx context.Context) (*reconcilestatus.Status, error) {
+	var result reconcilestatus.Status
+	if err := c.doRequest(ctx, "GET", "/admin/reconcilers", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetReconcilerEnabled pauses or resumes the server's reconciler of kind.
+func (c *Client) SetReconcilerEnabled(ctx context.Context, kind string, enabled bool) (*reconcilestatus.Reconciler, error) {
+	action := "/disable"
+	if enabled {
+		action = "/enable"
+	}
+	var result reconcilestatus.Reconciler
+	if err := c.doRequest(ctx, "POST", "/admin/reconcilers/"+url.PathEscape(kind)+action, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetGitOpsStatus retrieves the server's GitOps configuration and the result
+// of its last sync.
+func (c *Client) GetGitOpsStatus(ctx context.Context) (*gitopsstatus.Status, error) {
+	var result gitopsstatus.Status
+	if err := c.doRequest(ctx, "GET", "/admin/gitops", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncGitOps has the server sync with its GitOps repository now. reportOnly
+// reports drift without correcting it.
+func (c *Client) SyncGitOps(ctx context.Context, reportOnly bool) (*gitopsstatus.Result, error) {
+	query := url.Values{}
+	if reportOnly {
+		query.Set("reportOnly", "true")
+	}
+	var result gitopsstatus.Result
+	if err := c.doQueryRequest(ctx, "POST", "/admin/gitops/sync", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
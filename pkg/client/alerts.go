@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+
+	"github.com/example/inventory-v3/pkg/alerting"
+)
+
+// EvaluateAlerts evaluates the server's alert rules now.
+func (c *Client) EvaluateAlerts(ctx context.Context) (*alerting.Evaluation, error) {
+	var result alerting.Evaluation
+	if err := c.doRequest(ctx, "POST", "/alerts/evaluate", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAlertRules retrieves the server's alert rules.
+func (c *Client) GetAlertRules(ctx context.Context) ([]alerting.RuleConfig, error) {
+	var result []alerting.RuleConfig
+	if err := c.doRequest(ctx, "GET", "/alerts/rules", nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
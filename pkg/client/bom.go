@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/bom"
+)
+
+// GetDeviceBOM exports the CycloneDX hardware bill of materials of a device,
+// usually a node or rack, and everything in it.
+func (c *Client) GetDeviceBOM(ctx context.Context, uid string) (*bom.BOM, error) {
+	var result bom.BOM
+	if err := c.doRequest(ctx, "GET", fmt.Sprintf("/devices/%s/bom", uid), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
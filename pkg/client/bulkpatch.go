@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/example/inventory-v3/pkg/bulkpatch"
+)
+
+// PatchDevices uploads bulk edits of device labels and properties, as CSV
+// (contentType "text/csv") or as a JSON array of bulkpatch.Row, and returns
+// what each row changed. With dryRun set nothing is changed.
+func (c *Client) PatchDevices(ctx context.Context, body io.Reader, contentType string, dryRun bool) (*bulkpatch.Result, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, "/devices/patch")
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	acceptType := "application/json"
+	if c.version != "" {
+		acceptType = fmt.Sprintf("application/json;version=%s", c.version)
+	}
+	req.Header.Set("Accept", acceptType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResp); err != nil {
+			return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errorResp.Error)
+	}
+
+	var result bulkpatch.Result
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &result, nil
+}
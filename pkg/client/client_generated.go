@@ -61,8 +61,15 @@ import (
 	"net/url"
 	"path"
 
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/connection"
 	"github.com/example/inventory-v3/pkg/resources/device"
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
 )
 
 // Client provides access to the inventory API
@@ -384,3 +391,612 @@ func (c *Client) DeleteDiscoverySnapshot(ctx context.Context, uid string) error
 	}
 	return nil
 }
+
+// GetBMCEndpoints retrieves all bmcendpoints
+func (c *Client) GetBMCEndpoints(ctx context.Context) ([]bmcendpoint.BMCEndpoint, error) {
+	var response []bmcendpoint.BMCEndpoint
+	if err := c.doRequest(ctx, "GET", "/bmcendpoints", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetBMCEndpoint retrieves a specific BMCEndpoint by UID
+func (c *Client) GetBMCEndpoint(ctx context.Context, uid string) (*bmcendpoint.BMCEndpoint, error) {
+	var result bmcendpoint.BMCEndpoint
+	endpoint := fmt.Sprintf("/bmcendpoints/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateBMCEndpoint creates a new BMCEndpoint
+func (c *Client) CreateBMCEndpoint(ctx context.Context, req CreateBMCEndpointRequest) (*bmcendpoint.BMCEndpoint, error) {
+	var result bmcendpoint.BMCEndpoint
+	if err := c.doRequest(ctx, "POST", "/bmcendpoints", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateBMCEndpoint updates an existing BMCEndpoint
+func (c *Client) UpdateBMCEndpoint(ctx context.Context, uid string, req UpdateBMCEndpointRequest) (*bmcendpoint.BMCEndpoint, error) {
+	var result bmcendpoint.BMCEndpoint
+	endpoint := fmt.Sprintf("/bmcendpoints/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchBMCEndpoint patches an existing BMCEndpoint spec with the specified patch data and content type
+func (c *Client) PatchBMCEndpoint(ctx context.Context, uid string, patchData []byte, contentType string) (*bmcendpoint.BMCEndpoint, error) {
+	var result bmcendpoint.BMCEndpoint
+	endpoint := fmt.Sprintf("/bmcendpoints/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateBMCEndpointStatus updates only the status of an existing BMCEndpoint
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateBMCEndpointStatus(ctx context.Context, uid string, status bmcendpoint.BMCEndpointStatus) (*bmcendpoint.BMCEndpoint, error) {
+	var result bmcendpoint.BMCEndpoint
+	endpoint := fmt.Sprintf("/bmcendpoints/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchBMCEndpointStatus patches only the status of an existing BMCEndpoint
+// Supports JSON Merge Patch by default. Use PatchBMCEndpointStatusWithType for other patch formats.
+func (c *Client) PatchBMCEndpointStatus(ctx context.Context, uid string, patchData []byte) (*bmcendpoint.BMCEndpoint, error) {
+	return c.PatchBMCEndpointStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchBMCEndpointStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchBMCEndpointStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*bmcendpoint.BMCEndpoint, error) {
+	var result bmcendpoint.BMCEndpoint
+	endpoint := fmt.Sprintf("/bmcendpoints/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteBMCEndpoint deletes a BMCEndpoint by UID
+func (c *Client) DeleteBMCEndpoint(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/bmcendpoints/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetServiceEvents retrieves all serviceevents
+func (c *Client) GetServiceEvents(ctx context.Context) ([]serviceevent.ServiceEvent, error) {
+	var response []serviceevent.ServiceEvent
+	if err := c.doRequest(ctx, "GET", "/serviceevents", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetServiceEvent retrieves a specific ServiceEvent by UID
+func (c *Client) GetServiceEvent(ctx context.Context, uid string) (*serviceevent.ServiceEvent, error) {
+	var result serviceevent.ServiceEvent
+	endpoint := fmt.Sprintf("/serviceevents/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateServiceEvent creates a new ServiceEvent
+func (c *Client) CreateServiceEvent(ctx context.Context, req CreateServiceEventRequest) (*serviceevent.ServiceEvent, error) {
+	var result serviceevent.ServiceEvent
+	if err := c.doRequest(ctx, "POST", "/serviceevents", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateServiceEvent updates an existing ServiceEvent
+func (c *Client) UpdateServiceEvent(ctx context.Context, uid string, req UpdateServiceEventRequest) (*serviceevent.ServiceEvent, error) {
+	var result serviceevent.ServiceEvent
+	endpoint := fmt.Sprintf("/serviceevents/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchServiceEvent patches an existing ServiceEvent spec with the specified patch data and content type
+func (c *Client) PatchServiceEvent(ctx context.Context, uid string, patchData []byte, contentType string) (*serviceevent.ServiceEvent, error) {
+	var result serviceevent.ServiceEvent
+	endpoint := fmt.Sprintf("/serviceevents/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateServiceEventStatus updates only the status of an existing ServiceEvent
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateServiceEventStatus(ctx context.Context, uid string, status serviceevent.ServiceEventStatus) (*serviceevent.ServiceEvent, error) {
+	var result serviceevent.ServiceEvent
+	endpoint := fmt.Sprintf("/serviceevents/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchServiceEventStatus patches only the status of an existing ServiceEvent
+// Supports JSON Merge Patch by default. Use PatchServiceEventStatusWithType for other patch formats.
+func (c *Client) PatchServiceEventStatus(ctx context.Context, uid string, patchData []byte) (*serviceevent.ServiceEvent, error) {
+	return c.PatchServiceEventStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchServiceEventStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchServiceEventStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*serviceevent.ServiceEvent, error) {
+	var result serviceevent.ServiceEvent
+	endpoint := fmt.Sprintf("/serviceevents/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteServiceEvent deletes a ServiceEvent by UID
+func (c *Client) DeleteServiceEvent(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/serviceevents/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMaintenanceWindows retrieves all maintenancewindows
+func (c *Client) GetMaintenanceWindows(ctx context.Context) ([]maintenancewindow.MaintenanceWindow, error) {
+	var response []maintenancewindow.MaintenanceWindow
+	if err := c.doRequest(ctx, "GET", "/maintenancewindows", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetMaintenanceWindow retrieves a specific MaintenanceWindow by UID
+func (c *Client) GetMaintenanceWindow(ctx context.Context, uid string) (*maintenancewindow.MaintenanceWindow, error) {
+	var result maintenancewindow.MaintenanceWindow
+	endpoint := fmt.Sprintf("/maintenancewindows/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateMaintenanceWindow creates a new MaintenanceWindow
+func (c *Client) CreateMaintenanceWindow(ctx context.Context, req CreateMaintenanceWindowRequest) (*maintenancewindow.MaintenanceWindow, error) {
+	var result maintenancewindow.MaintenanceWindow
+	if err := c.doRequest(ctx, "POST", "/maintenancewindows", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateMaintenanceWindow updates an existing MaintenanceWindow
+func (c *Client) UpdateMaintenanceWindow(ctx context.Context, uid string, req UpdateMaintenanceWindowRequest) (*maintenancewindow.MaintenanceWindow, error) {
+	var result maintenancewindow.MaintenanceWindow
+	endpoint := fmt.Sprintf("/maintenancewindows/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchMaintenanceWindow patches an existing MaintenanceWindow spec with the specified patch data and content type
+func (c *Client) PatchMaintenanceWindow(ctx context.Context, uid string, patchData []byte, contentType string) (*maintenancewindow.MaintenanceWindow, error) {
+	var result maintenancewindow.MaintenanceWindow
+	endpoint := fmt.Sprintf("/maintenancewindows/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateMaintenanceWindowStatus updates only the status of an existing MaintenanceWindow
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateMaintenanceWindowStatus(ctx context.Context, uid string, status maintenancewindow.MaintenanceWindowStatus) (*maintenancewindow.MaintenanceWindow, error) {
+	var result maintenancewindow.MaintenanceWindow
+	endpoint := fmt.Sprintf("/maintenancewindows/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchMaintenanceWindowStatus patches only the status of an existing MaintenanceWindow
+// Supports JSON Merge Patch by default. Use PatchMaintenanceWindowStatusWithType for other patch formats.
+func (c *Client) PatchMaintenanceWindowStatus(ctx context.Context, uid string, patchData []byte) (*maintenancewindow.MaintenanceWindow, error) {
+	return c.PatchMaintenanceWindowStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchMaintenanceWindowStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchMaintenanceWindowStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*maintenancewindow.MaintenanceWindow, error) {
+	var result maintenancewindow.MaintenanceWindow
+	endpoint := fmt.Sprintf("/maintenancewindows/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteMaintenanceWindow deletes a MaintenanceWindow by UID
+func (c *Client) DeleteMaintenanceWindow(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/maintenancewindows/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetGroups retrieves all groups
+func (c *Client) GetGroups(ctx context.Context) ([]group.Group, error) {
+	var response []group.Group
+	if err := c.doRequest(ctx, "GET", "/groups", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetGroup retrieves a specific Group by UID
+func (c *Client) GetGroup(ctx context.Context, uid string) (*group.Group, error) {
+	var result group.Group
+	endpoint := fmt.Sprintf("/groups/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateGroup creates a new Group
+func (c *Client) CreateGroup(ctx context.Context, req CreateGroupRequest) (*group.Group, error) {
+	var result group.Group
+	if err := c.doRequest(ctx, "POST", "/groups", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateGroup updates an existing Group
+func (c *Client) UpdateGroup(ctx context.Context, uid string, req UpdateGroupRequest) (*group.Group, error) {
+	var result group.Group
+	endpoint := fmt.Sprintf("/groups/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchGroup patches an existing Group spec with the specified patch data and content type
+func (c *Client) PatchGroup(ctx context.Context, uid string, patchData []byte, contentType string) (*group.Group, error) {
+	var result group.Group
+	endpoint := fmt.Sprintf("/groups/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateGroupStatus updates only the status of an existing Group
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateGroupStatus(ctx context.Context, uid string, status group.GroupStatus) (*group.Group, error) {
+	var result group.Group
+	endpoint := fmt.Sprintf("/groups/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchGroupStatus patches only the status of an existing Group
+// Supports JSON Merge Patch by default. Use PatchGroupStatusWithType for other patch formats.
+func (c *Client) PatchGroupStatus(ctx context.Context, uid string, patchData []byte) (*group.Group, error) {
+	return c.PatchGroupStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchGroupStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchGroupStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*group.Group, error) {
+	var result group.Group
+	endpoint := fmt.Sprintf("/groups/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteGroup deletes a Group by UID
+func (c *Client) DeleteGroup(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/groups/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetConnections retrieves all connections
+func (c *Client) GetConnections(ctx context.Context) ([]connection.Connection, error) {
+	var response []connection.Connection
+	if err := c.doRequest(ctx, "GET", "/connections", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetConnection retrieves a specific Connection by UID
+func (c *Client) GetConnection(ctx context.Context, uid string) (*connection.Connection, error) {
+	var result connection.Connection
+	endpoint := fmt.Sprintf("/connections/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateConnection creates a new Connection
+func (c *Client) CreateConnection(ctx context.Context, req CreateConnectionRequest) (*connection.Connection, error) {
+	var result connection.Connection
+	if err := c.doRequest(ctx, "POST", "/connections", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateConnection updates an existing Connection
+func (c *Client) UpdateConnection(ctx context.Context, uid string, req UpdateConnectionRequest) (*connection.Connection, error) {
+	var result connection.Connection
+	endpoint := fmt.Sprintf("/connections/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchConnection patches an existing Connection spec with the specified patch data and content type
+func (c *Client) PatchConnection(ctx context.Context, uid string, patchData []byte, contentType string) (*connection.Connection, error) {
+	var result connection.Connection
+	endpoint := fmt.Sprintf("/connections/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateConnectionStatus updates only the status of an existing Connection
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateConnectionStatus(ctx context.Context, uid string, status connection.ConnectionStatus) (*connection.Connection, error) {
+	var result connection.Connection
+	endpoint := fmt.Sprintf("/connections/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchConnectionStatus patches only the status of an existing Connection
+// Supports JSON Merge Patch by default. Use PatchConnectionStatusWithType for other patch formats.
+func (c *Client) PatchConnectionStatus(ctx context.Context, uid string, patchData []byte) (*connection.Connection, error) {
+	return c.PatchConnectionStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchConnectionStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchConnectionStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*connection.Connection, error) {
+	var result connection.Connection
+	endpoint := fmt.Sprintf("/connections/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteConnection deletes a Connection by UID
+func (c *Client) DeleteConnection(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/connections/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingChanges retrieves all pendingchanges
+func (c *Client) GetPendingChanges(ctx context.Context) ([]pendingchange.PendingChange, error) {
+	var response []pendingchange.PendingChange
+	if err := c.doRequest(ctx, "GET", "/pendingchanges", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetPendingChange retrieves a specific PendingChange by UID
+func (c *Client) GetPendingChange(ctx context.Context, uid string) (*pendingchange.PendingChange, error) {
+	var result pendingchange.PendingChange
+	endpoint := fmt.Sprintf("/pendingchanges/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreatePendingChange creates a new PendingChange
+func (c *Client) CreatePendingChange(ctx context.Context, req CreatePendingChangeRequest) (*pendingchange.PendingChange, error) {
+	var result pendingchange.PendingChange
+	if err := c.doRequest(ctx, "POST", "/pendingchanges", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdatePendingChange updates an existing PendingChange
+func (c *Client) UpdatePendingChange(ctx context.Context, uid string, req UpdatePendingChangeRequest) (*pendingchange.PendingChange, error) {
+	var result pendingchange.PendingChange
+	endpoint := fmt.Sprintf("/pendingchanges/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchPendingChange patches an existing PendingChange spec with the specified patch data and content type
+func (c *Client) PatchPendingChange(ctx context.Context, uid string, patchData []byte, contentType string) (*pendingchange.PendingChange, error) {
+	var result pendingchange.PendingChange
+	endpoint := fmt.Sprintf("/pendingchanges/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdatePendingChangeStatus updates only the status of an existing PendingChange
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdatePendingChangeStatus(ctx context.Context, uid string, status pendingchange.PendingChangeStatus) (*pendingchange.PendingChange, error) {
+	var result pendingchange.PendingChange
+	endpoint := fmt.Sprintf("/pendingchanges/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchPendingChangeStatus patches only the status of an existing PendingChange
+// Supports JSON Merge Patch by default. Use PatchPendingChangeStatusWithType for other patch formats.
+func (c *Client) PatchPendingChangeStatus(ctx context.Context, uid string, patchData []byte) (*pendingchange.PendingChange, error) {
+	return c.PatchPendingChangeStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchPendingChangeStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchPendingChangeStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*pendingchange.PendingChange, error) {
+	var result pendingchange.PendingChange
+	endpoint := fmt.Sprintf("/pendingchanges/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeletePendingChange deletes a PendingChange by UID
+func (c *Client) DeletePendingChange(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/pendingchanges/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAlerts retrieves all alerts
+func (c *Client) GetAlerts(ctx context.Context) ([]alert.Alert, error) {
+	var response []alert.Alert
+	if err := c.doRequest(ctx, "GET", "/alerts", nil, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetAlert retrieves a specific Alert by UID
+func (c *Client) GetAlert(ctx context.Context, uid string) (*alert.Alert, error) {
+	var result alert.Alert
+	endpoint := fmt.Sprintf("/alerts/%s", uid)
+	if err := c.doRequest(ctx, "GET", endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAlert creates a new Alert
+func (c *Client) CreateAlert(ctx context.Context, req CreateAlertRequest) (*alert.Alert, error) {
+	var result alert.Alert
+	if err := c.doRequest(ctx, "POST", "/alerts", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAlert updates an existing Alert
+func (c *Client) UpdateAlert(ctx context.Context, uid string, req UpdateAlertRequest) (*alert.Alert, error) {
+	var result alert.Alert
+	endpoint := fmt.Sprintf("/alerts/%s", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchAlert patches an existing Alert spec with the specified patch data and content type
+func (c *Client) PatchAlert(ctx context.Context, uid string, patchData []byte, contentType string) (*alert.Alert, error) {
+	var result alert.Alert
+	endpoint := fmt.Sprintf("/alerts/%s", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAlertStatus updates only the status of an existing Alert
+// This method is intended for controllers, reconcilers, and monitoring systems.
+// It preserves the spec and only updates the status portion of the resource.
+func (c *Client) UpdateAlertStatus(ctx context.Context, uid string, status alert.AlertStatus) (*alert.Alert, error) {
+	var result alert.Alert
+	endpoint := fmt.Sprintf("/alerts/%s/status", uid)
+	if err := c.doRequest(ctx, "PUT", endpoint, status, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PatchAlertStatus patches only the status of an existing Alert
+// Supports JSON Merge Patch by default. Use PatchAlertStatusWithType for other patch formats.
+func (c *Client) PatchAlertStatus(ctx context.Context, uid string, patchData []byte) (*alert.Alert, error) {
+	return c.PatchAlertStatusWithType(ctx, uid, patchData, "application/merge-patch+json")
+}
+
+// PatchAlertStatusWithType patches status with a specific patch content type
+// Supported types: application/merge-patch+json, application/json-patch+json, application/fabrica-patch+json
+func (c *Client) PatchAlertStatusWithType(ctx context.Context, uid string, patchData []byte, contentType string) (*alert.Alert, error) {
+	var result alert.Alert
+	endpoint := fmt.Sprintf("/alerts/%s/status", uid)
+	if err := c.doPatchRequest(ctx, endpoint, patchData, contentType, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAlert deletes a Alert by UID
+func (c *Client) DeleteAlert(ctx context.Context, uid string) error {
+	endpoint := fmt.Sprintf("/alerts/%s", uid)
+	var response DeleteResponse
+	if err := c.doRequest(ctx, "DELETE", endpoint, nil, &response); err != nil {
+		return err
+	}
+	return nil
+}
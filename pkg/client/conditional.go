@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// ErrPreconditionFailed is returned by the IfMatch methods when the resource
+// was modified after the given ETag was read.
+var ErrPreconditionFailed = errors.New("precondition failed: resource has been modified")
+
+// doConditionalRequest performs a request with an optional If-Match header and
+// returns the ETag of the response. A 412 response returns ErrPreconditionFailed.
+func (c *Client) doConditionalRequest(ctx context.Context, method, endpoint, ifMatch string, body interface{}, result interface{}) (string, error) {
+	var reqBody io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewBuffer(jsonData)
+	}
+
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	contentType := "application/json"
+	acceptType := "application/json"
+	if c.version != "" {
+		contentType = fmt.Sprintf("application/json;version=%s", c.version)
+		acceptType = fmt.Sprintf("application/json;version=%s", c.version)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", acceptType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return "", ErrPreconditionFailed
+	}
+	if resp.StatusCode >= 400 {
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResp); err != nil {
+			return "", fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(respBody))
+		}
+		return "", fmt.Errorf("API error (%d): %s", resp.StatusCode, errorResp.Error)
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// Device
+
+// GetDeviceWithETag retrieves a Device and its current ETag.
+func (c *Client) GetDeviceWithETag(ctx context.Context, uid string) (*device.Device, string, error) {
+	var result device.Device
+	etag, err := c.doConditionalRequest(ctx, "GET", fmt.Sprintf("/devices/%s", uid), "", nil, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, etag, nil
+}
+
+// UpdateDeviceIfMatch updates a Device only if its ETag is still etag, and
+// returns the new ETag.
+func (c *Client) UpdateDeviceIfMatch(ctx context.Context, uid string, req UpdateDeviceRequest, etag string) (*device.Device, string, error) {
+	var result device.Device
+	newETag, err := c.doConditionalRequest(ctx, "PUT", fmt.Sprintf("/devices/%s", uid), etag, req, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, newETag, nil
+}
+
+// UpdateDeviceStatusIfMatch updates a Device status only if its ETag is still
+// etag, and returns the new ETag.
+func (c *Client) UpdateDeviceStatusIfMatch(ctx context.Context, uid string, status device.DeviceStatus, etag string) (*device.Device, string, error) {
+	var result device.Device
+	newETag, err := c.doConditionalRequest(ctx, "PUT", fmt.Sprintf("/devices/%s/status", uid), etag, status, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, newETag, nil
+}
+
+// DeleteDeviceIfMatch deletes a Device only if its ETag is still etag.
+func (c *Client) DeleteDeviceIfMatch(ctx context.Context, uid string, etag string) error {
+	_, err := c.doConditionalRequest(ctx, "DELETE", fmt.Sprintf("/devices/%s", uid), etag, nil, nil)
+	return err
+}
+
+// DiscoverySnapshot
+
+// GetDiscoverySnapshotWithETag retrieves a DiscoverySnapshot and its current ETag.
+func (c *Client) GetDiscoverySnapshotWithETag(ctx context.Context, uid string) (*discoverysnapshot.DiscoverySnapshot, string, error) {
+	var result discoverysnapshot.DiscoverySnapshot
+	etag, err := c.doConditionalRequest(ctx, "GET", fmt.Sprintf("/discoverysnapshots/%s", uid), "", nil, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, etag, nil
+}
+
+// UpdateDiscoverySnapshotIfMatch updates a DiscoverySnapshot only if its ETag
+// is still etag, and returns the new ETag.
+func (c *Client) UpdateDiscoverySnapshotIfMatch(ctx context.Context, uid string, req UpdateDiscoverySnapshotRequest, etag string) (*discoverysnapshot.DiscoverySnapshot, string, error) {
+	var result discoverysnapshot.DiscoverySnapshot
+	newETag, err := c.doConditionalRequest(ctx, "PUT", fmt.Sprintf("/discoverysnapshots/%s", uid), etag, req, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, newETag, nil
+}
+
+// DeleteDiscoverySnapshotIfMatch deletes a DiscoverySnapshot only if its ETag is still etag.
+func (c *Client) DeleteDiscoverySnapshotIfMatch(ctx context.Context, uid string, etag string) error {
+	_, err := c.doConditionalRequest(ctx, "DELETE", fmt.Sprintf("/discoverysnapshots/%s", uid), etag, nil, nil)
+	return err
+}
+
+// BMCEndpoint
+
+// GetBMCEndpointWithETag retrieves a BMCEndpoint and its current ETag.
+func (c *Client) GetBMCEndpointWithETag(ctx context.Context, uid string) (*bmcendpoint.BMCEndpoint, string, error) {
+	var result bmcendpoint.BMCEndpoint
+	etag, err := c.doConditionalRequest(ctx, "GET", fmt.Sprintf("/bmcendpoints/%s", uid), "", nil, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, etag, nil
+}
+
+// UpdateBMCEndpointIfMatch updates a BMCEndpoint only if its ETag is still
+// etag, and returns the new ETag.
+func (c *Client) UpdateBMCEndpointIfMatch(ctx context.Context, uid string, req UpdateBMCEndpointRequest, etag string) (*bmcendpoint.BMCEndpoint, string, error) {
+	var result bmcendpoint.BMCEndpoint
+	newETag, err := c.doConditionalRequest(ctx, "PUT", fmt.Sprintf("/bmcendpoints/%s", uid), etag, req, &result)
+	if err != nil {
+		return nil, "", err
+	}
+	return &result, newETag, nil
+}
+
+// DeleteBMCEndpointIfMatch deletes a BMCEndpoint only if its ETag is still etag.
+func (c *Client) DeleteBMCEndpointIfMatch(ctx context.Context, uid string, etag string) error {
+	_, err := c.doConditionalRequest(ctx, "DELETE", fmt.Sprintf("/bmcendpoints/%s", uid), etag, nil, nil)
+	return err
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestConditionalRequest sends the ETag as If-Match, returns the ETag of the
+// response, and tells a failed precondition from other errors.
+func TestConditionalRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("If-Match") {
+		case "", `"v1"`:
+			w.Header().Set("ETag", `"v2"`)
+			w.Write([]byte(`{"metadata":{"uid":"dev-1"}}`))
+		case `"missing"`:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Device not found"}`))
+		default:
+			w.WriteHeader(http.StatusPreconditionFailed)
+		}
+	}))
+	defer server.Close()
+	c, err := NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		ifMatch  string
+		wantETag string
+		wantErr  string
+	}{
+		{name: "unconditional", wantETag: `"v2"`},
+		{name: "current ETag", ifMatch: `"v1"`, wantETag: `"v2"`},
+		{name: "stale ETag", ifMatch: `"v0"`, wantErr: ErrPreconditionFailed.Error()},
+		{name: "API error", ifMatch: `"missing"`, wantErr: "API error (404): Device not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev, etag, err := c.UpdateDeviceIfMatch(ctx, "dev-1", UpdateDeviceRequest{}, tt.ifMatch)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UpdateDeviceIfMatch error = %v, want one containing %q", err, tt.wantErr)
+				}
+				if tt.wantErr == ErrPreconditionFailed.Error() && !errors.Is(err, ErrPreconditionFailed) {
+					t.Errorf("UpdateDeviceIfMatch error = %v, want ErrPreconditionFailed", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateDeviceIfMatch failed: %v", err)
+			}
+			if etag != tt.wantETag || dev.GetUID() != "dev-1" {
+				t.Errorf("UpdateDeviceIfMatch = %s with ETag %s, want dev-1 with %s", dev.GetUID(), etag, tt.wantETag)
+			}
+		})
+	}
+}
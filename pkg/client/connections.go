@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+
+	"github.com/example/inventory-v3/pkg/cabling"
+)
+
+// InferCabling runs cabling inference on the server now and returns what it
+// created, refreshed and marked stale.
+func (c *Client) InferCabling(ctx context.Context) (*cabling.Result, error) {
+	var result cabling.Result
+	if err := c.doRequest(ctx, "POST", "/connections/infer", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,376 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/connection"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+)
+
+// Document is one resource in the Kubernetes-style layout Export writes and
+// Apply reads: its kind, identity and spec. Status is observed by the
+// server, so it is not part of a document.
+type Document struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   DocumentMetadata `json:"metadata"`
+	Spec       json.RawMessage  `json:"spec"`
+}
+
+// DocumentMetadata identifies the resource of a document. Apply updates the
+// resource with the UID, else the one with the name, else creates one.
+type DocumentMetadata struct {
+	Name        string            `json:"name"`
+	UID         string            `json:"uid,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Apply actions.
+const (
+	ApplyCreated    = "created"
+	ApplyConfigured = "configured"
+	ApplyUnchanged  = "unchanged"
+)
+
+// ApplyResult is what Apply did with one document.
+type ApplyResult struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	UID    string `json:"uid,omitempty"`
+	Action string `json:"action"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+// declarativeKind is a kind whose resources are configuration, and can be
+// exported and applied.
+type declarativeKind struct {
+	kind       string
+	collection string
+	// normalize re-encodes a spec through its Go type, so specs that differ
+	// only in field order or omitted empty fields compare equal.
+	normalize func(json.RawMessage) (json.RawMessage, error)
+}
+
+// declarativeKinds are the kinds Export and Apply handle, in the order they
+// are exported. Snapshots, service events, pending changes and alerts are
+// records of what happened rather than configuration, and are left out.
+var declarativeKinds = []declarativeKind{
+	{"BMCEndpoint", "bmcendpoints", normalizeSpec[bmcendpoint.BMCEndpointSpec]},
+	{"Device", "devices", normalizeSpec[device.DeviceSpec]},
+	{"Connection", "connections", normalizeSpec[connection.ConnectionSpec]},
+	{"Group", "groups", normalizeSpec[group.GroupSpec]},
+	{"MaintenanceWindow", "maintenancewindows", normalizeSpec[maintenancewindow.MaintenanceWindowSpec]},
+}
+
+// DeclarativeKinds lists the kinds Export and Apply handle.
+func DeclarativeKinds() []string {
+	kinds := make([]string, len(declarativeKinds))
+	for i, k := range declarativeKinds {
+		kinds[i] = k.kind
+	}
+	return kinds
+}
+
+// findKind returns the declarative kind named kind, case-insensitively.
+func findKind(kind string) (declarativeKind, error) {
+	for _, k := range declarativeKinds {
+		if strings.EqualFold(k.kind, kind) {
+			return k, nil
+		}
+	}
+	return declarativeKind{}, fmt.Errorf("unsupported kind %q (supported: %s)", kind, strings.Join(DeclarativeKinds(), ", "))
+}
+
+func normalizeSpec[S any](raw json.RawMessage) (json.RawMessage, error) {
+	var spec S
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, err
+	}
+	return json.Marshal(spec)
+}
+
+// documentClient reads and writes the resources of kind as documents.
+func (c *Client) documentClient(k declarativeKind) *Resource[Document, json.RawMessage] {
+	return NewResource[Document, json.RawMessage](c, k.collection)
+}
+
+// Export returns the resources of kinds (every declarative kind when empty)
+// as documents. Retired devices are left out.
+func (c *Client) Export(ctx context.Context, kinds []string) ([]Document, error) {
+	selected := declarativeKinds
+	if len(kinds) > 0 {
+		selected = nil
+		for _, kind := range kinds {
+			k, err := findKind(kind)
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, k)
+		}
+	}
+	docs := []Document{}
+	for _, k := range selected {
+		items, err := c.documentClient(k).List(ctx, ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", k.kind, err)
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Metadata.Name < items[j].Metadata.Name })
+		for _, doc := range items {
+			doc.APIVersion, doc.Kind = "v1", k.kind
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// DeleteDocument deletes the resource of kind with the UID.
+func (c *Client) DeleteDocument(ctx context.Context, kind, uid string) error {
+	k, err := findKind(kind)
+	if err != nil {
+		return err
+	}
+	return c.documentClient(k).Delete(ctx, uid)
+}
+
+// Applier applies documents to the inventory, creating the resources they
+// describe or updating the specs of the ones that exist.
+type Applier struct {
+	client *Client
+	// DryRun reports what would change without changing it.
+	DryRun bool
+	// existing caches the resources of each kind, listed once.
+	existing map[string][]Document
+}
+
+// NewApplier returns an Applier for the inventory of c.
+func (c *Client) NewApplier(dryRun bool) *Applier {
+	return &Applier{client: c, DryRun: dryRun, existing: map[string][]Document{}}
+}
+
+// Apply creates or updates the resource of doc. An existing resource's spec
+// is merged with the document's as by a JSON merge patch (RFC 7386): fields
+// the document leaves out are kept, and those it sets to null are cleared.
+func (a *Applier) Apply(ctx context.Context, doc Document) (ApplyResult, error) {
+	k, err := findKind(doc.Kind)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	result := ApplyResult{Kind: k.kind, Name: doc.Metadata.Name, UID: doc.Metadata.UID, DryRun: a.DryRun}
+	if doc.Metadata.Name == "" && doc.Metadata.UID == "" {
+		return result, errors.New("metadata needs a name or uid")
+	}
+	if len(doc.Spec) == 0 || string(doc.Spec) == "null" {
+		doc.Spec = json.RawMessage("{}")
+	}
+
+	current, err := a.find(ctx, k, doc.Metadata)
+	if err != nil {
+		return result, err
+	}
+	resources := a.client.documentClient(k)
+	opts := WriteOptions{Labels: doc.Metadata.Labels, Annotations: doc.Metadata.Annotations}
+	if current == nil {
+		desired, err := k.normalize(doc.Spec)
+		if err != nil {
+			return result, fmt.Errorf("invalid %s spec: %w", k.kind, err)
+		}
+		result.Action = ApplyCreated
+		if a.DryRun {
+			return result, nil
+		}
+		created, err := resources.Create(ctx, doc.Metadata.Name, desired, opts)
+		if err != nil {
+			return result, fmt.Errorf("failed to create %s %s: %w", k.kind, doc.Metadata.Name, err)
+		}
+		result.UID = created.Metadata.UID
+		a.existing[k.kind] = append(a.existing[k.kind], *created)
+		return result, nil
+	}
+
+	result.UID, result.Name = current.Metadata.UID, current.Metadata.Name
+	existing, err := k.normalize(current.Spec)
+	if err != nil {
+		return result, fmt.Errorf("failed to read %s %s: %w", k.kind, result.UID, err)
+	}
+	merged, err := mergePatch(existing, doc.Spec)
+	if err != nil {
+		return result, fmt.Errorf("invalid %s spec: %w", k.kind, err)
+	}
+	desired, err := k.normalize(merged)
+	if err != nil {
+		return result, fmt.Errorf("invalid %s spec: %w", k.kind, err)
+	}
+	if bytes.Equal(existing, desired) && containsAll(current.Metadata.Labels, doc.Metadata.Labels) && containsAll(current.Metadata.Annotations, doc.Metadata.Annotations) {
+		result.Action = ApplyUnchanged
+		return result, nil
+	}
+	result.Action = ApplyConfigured
+	if a.DryRun {
+		return result, nil
+	}
+	if _, err := resources.Update(ctx, current.Metadata.UID, desired, opts); err != nil {
+		return result, fmt.Errorf("failed to update %s %s: %w", k.kind, current.Metadata.UID, err)
+	}
+	return result, nil
+}
+
+// find returns the existing resource meta identifies: the one with its UID,
+// else the only one with its name, else nil.
+func (a *Applier) find(ctx context.Context, k declarativeKind, meta DocumentMetadata) (*Document, error) {
+	existing, ok := a.existing[k.kind]
+	if !ok {
+		var err error
+		existing, err = a.client.documentClient(k).List(ctx, ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", k.kind, err)
+		}
+		a.existing[k.kind] = existing
+	}
+	var named []*Document
+	for i := range existing {
+		doc := &existing[i]
+		if meta.UID != "" && doc.Metadata.UID == meta.UID {
+			return doc, nil
+		}
+		if meta.Name != "" && doc.Metadata.Name == meta.Name {
+			named = append(named, doc)
+		}
+	}
+	switch {
+	case meta.UID != "" || len(named) == 0:
+		return nil, nil
+	case len(named) > 1:
+		return nil, fmt.Errorf("%d %ss are named %q; give the document's metadata.uid", len(named), k.kind, meta.Name)
+	}
+	return named[0], nil
+}
+
+// mergePatch applies the JSON merge patch patch to the JSON object target.
+func mergePatch(target, patch json.RawMessage) (json.RawMessage, error) {
+	var t, p interface{}
+	if err := json.Unmarshal(target, &t); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValue(t, p))
+}
+
+func mergeValue(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergeValue(t[key], value)
+	}
+	return t
+}
+
+// containsAll reports whether have holds every entry of want.
+func containsAll(have, want map[string]string) bool {
+	for key, value := range want {
+		if v, ok := have[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeDocuments reads the documents of a multi-document YAML stream. JSON,
+// being YAML, is read as well.
+func DecodeDocuments(r io.Reader) ([]Document, error) {
+	decoder := yaml.NewDecoder(r)
+	var docs []Document
+	for {
+		var value interface{}
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML in document %d: %w", len(docs)+1, err)
+		}
+		if value == nil {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", len(docs)+1, err)
+		}
+		var doc Document
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("document %d is not a resource: %w", len(docs)+1, err)
+		}
+		if doc.Kind == "" {
+			return nil, fmt.Errorf("document %d has no kind", len(docs)+1)
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// EncodeDocuments writes docs as a multi-document YAML stream.
+func EncodeDocuments(w io.Writer, docs []Document) error {
+	for i, doc := range docs {
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if err := EncodeYAML(w, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeYAML writes v as YAML, laid out as its JSON encoding is: with its
+// JSON field names, in their order.
+func EncodeYAML(w io.Writer, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(raw, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle drops the flow style JSON decodes with, so the encoder picks
+// the block style.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
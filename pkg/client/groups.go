@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/example/inventory-v3/pkg/groups"
+)
+
+// GetGroupMembers retrieves the devices the group with the given UID or name
+// covers. Retired devices are included only when includeRetired is set.
+func (c *Client) GetGroupMembers(ctx context.Context, id string, includeRetired bool) (*groups.Membership, error) {
+	query := url.Values{}
+	if includeRetired {
+		query.Set("includeRetired", "true")
+	}
+	var result groups.Membership
+	if err := c.doQueryRequest(ctx, "GET", "/groups/"+url.PathEscape(id)+"/members", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/history"
+)
+
+// GetInventoryAsOf reconstructs the inventory at a past time from the stored
+// snapshots. nodeSerial (a serial number or device UID) and deviceType narrow
+// the result when set.
+func (c *Client) GetInventoryAsOf(ctx context.Context, at time.Time, nodeSerial, deviceType string) (*history.Inventory, error) {
+	query := url.Values{}
+	query.Set("at", at.Format(time.RFC3339))
+	if nodeSerial != "" {
+		query.Set("node", nodeSerial)
+	}
+	if deviceType != "" {
+		query.Set("deviceType", deviceType)
+	}
+	var result history.Inventory
+	if err := c.doQueryRequest(ctx, "GET", "/devices/as-of", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiffDiscoverySnapshots compares the device lists of two snapshots, from
+// before to, whether or not they have been reconciled.
+func (c *Client) DiffDiscoverySnapshots(ctx context.Context, from, to string) (*history.SnapshotDiff, error) {
+	var result history.SnapshotDiff
+	path := "/discoverysnapshots/" + url.PathEscape(from) + "/diff/" + url.PathEscape(to)
+	if err := c.doRequest(ctx, "GET", path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDiscoverySnapshotDevices retrieves the devices reconciling a snapshot
+// created, updated and retired.
+func (c *Client) GetDiscoverySnapshotDevices(ctx context.Context, uid string) (*history.SnapshotDevices, error) {
+	var result history.SnapshotDevices
+	if err := c.doRequest(ctx, "GET", "/discoverysnapshots/"+url.PathEscape(uid)+"/devices", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/lookup"
+)
+
+// GetDevicesByMAC returns the devices that report a MAC address and the node
+// each belongs to. Any common MAC notation is accepted.
+func (c *Client) GetDevicesByMAC(ctx context.Context, mac string) (*lookup.MACResult, error) {
+	var result lookup.MACResult
+	if err := c.doRequest(ctx, "GET", fmt.Sprintf("/devices/by-mac/%s", mac), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDevicesBySerial returns the devices with a serial number, each with its
+// parents and location.
+func (c *Client) GetDevicesBySerial(ctx context.Context, serial string) (*lookup.SerialResult, error) {
+	var result lookup.SerialResult
+	if err := c.doRequest(ctx, "GET", fmt.Sprintf("/devices/by-serial/%s", serial), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"context"
+
+	"github.com/example/inventory-v3/pkg/maintenance"
+)
+
+// GetActiveMaintenance retrieves what the maintenance windows active now cover.
+func (c *Client) GetActiveMaintenance(ctx context.Context) (*maintenance.Blackout, error) {
+	var result maintenance.Blackout
+	if err := c.doRequest(ctx, "GET", "/maintenancewindows/active", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
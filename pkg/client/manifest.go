@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/example/inventory-v3/pkg/manifest"
+)
+
+// ImportManifest uploads a vendor shipping manifest (CSV) and creates an
+// Expected device for each serial not yet in the inventory. deviceType is
+// used for rows without a type; empty uses the server default. With dryRun
+// set nothing is created.
+func (c *Client) ImportManifest(ctx context.Context, name, deviceType string, csv io.Reader, dryRun bool) (*manifest.ImportResult, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	if deviceType != "" {
+		query.Set("deviceType", deviceType)
+	}
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, "/devices/import-manifest")
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), csv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/csv")
+	acceptType := "application/json"
+	if c.version != "" {
+		acceptType = fmt.Sprintf("application/json;version=%s", c.version)
+	}
+	req.Header.Set("Accept", acceptType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResp); err != nil {
+			return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errorResp.Error)
+	}
+
+	var result manifest.ImportResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &result, nil
+}
@@ -34,8 +34,15 @@
 package client
 
 import (
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/connection"
 	"github.com/example/inventory-v3/pkg/resources/device"
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
 )
 
 // CreateDeviceRequest represents a request to create a Device
@@ -70,6 +77,118 @@ type UpdateDiscoverySnapshotRequest struct {
 	Annotations                             map[string]string `json:"annotations,omitempty"`
 }
 
+// CreateBMCEndpointRequest represents a request to create a BMCEndpoint
+type CreateBMCEndpointRequest struct {
+	bmcendpoint.BMCEndpointSpec `json:",inline"`
+	Name                        string            `json:"name" validate:"required"`
+	Labels                      map[string]string `json:"labels,omitempty"`
+	Annotations                 map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateBMCEndpointRequest represents a request to update a BMCEndpoint
+type UpdateBMCEndpointRequest struct {
+	bmcendpoint.BMCEndpointSpec `json:",inline,omitempty"`
+	Name                        string            `json:"name,omitempty"`
+	Labels                      map[string]string `json:"labels,omitempty"`
+	Annotations                 map[string]string `json:"annotations,omitempty"`
+}
+
+// CreateServiceEventRequest represents a request to create a ServiceEvent
+type CreateServiceEventRequest struct {
+	serviceevent.ServiceEventSpec `json:",inline"`
+	Name                          string            `json:"name" validate:"required"`
+	Labels                        map[string]string `json:"labels,omitempty"`
+	Annotations                   map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateServiceEventRequest represents a request to update a ServiceEvent
+type UpdateServiceEventRequest struct {
+	serviceevent.ServiceEventSpec `json:",inline,omitempty"`
+	Name                          string            `json:"name,omitempty"`
+	Labels                        map[string]string `json:"labels,omitempty"`
+	Annotations                   map[string]string `json:"annotations,omitempty"`
+}
+
+// CreateMaintenanceWindowRequest represents a request to create a MaintenanceWindow
+type CreateMaintenanceWindowRequest struct {
+	maintenancewindow.MaintenanceWindowSpec `json:",inline"`
+	Name                                    string            `json:"name" validate:"required"`
+	Labels                                  map[string]string `json:"labels,omitempty"`
+	Annotations                             map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateMaintenanceWindowRequest represents a request to update a MaintenanceWindow
+type UpdateMaintenanceWindowRequest struct {
+	maintenancewindow.MaintenanceWindowSpec `json:",inline,omitempty"`
+	Name                                    string            `json:"name,omitempty"`
+	Labels                                  map[string]string `json:"labels,omitempty"`
+	Annotations                             map[string]string `json:"annotations,omitempty"`
+}
+
+// CreateGroupRequest represents a request to create a Group
+type CreateGroupRequest struct {
+	group.GroupSpec `json:",inline"`
+	Name            string            `json:"name" validate:"required"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateGroupRequest represents a request to update a Group
+type UpdateGroupRequest struct {
+	group.GroupSpec `json:",inline,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// CreateConnectionRequest represents a request to create a Connection
+type CreateConnectionRequest struct {
+	connection.ConnectionSpec `json:",inline"`
+	Name                      string            `json:"name" validate:"required"`
+	Labels                    map[string]string `json:"labels,omitempty"`
+	Annotations               map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateConnectionRequest represents a request to update a Connection
+type UpdateConnectionRequest struct {
+	connection.ConnectionSpec `json:",inline,omitempty"`
+	Name                      string            `json:"name,omitempty"`
+	Labels                    map[string]string `json:"labels,omitempty"`
+	Annotations               map[string]string `json:"annotations,omitempty"`
+}
+
+// CreatePendingChangeRequest represents a request to create a PendingChange
+type CreatePendingChangeRequest struct {
+	pendingchange.PendingChangeSpec `json:",inline"`
+	Name                            string            `json:"name" validate:"required"`
+	Labels                          map[string]string `json:"labels,omitempty"`
+	Annotations                     map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdatePendingChangeRequest represents a request to update a PendingChange
+type UpdatePendingChangeRequest struct {
+	pendingchange.PendingChangeSpec `json:",inline,omitempty"`
+	Name                            string            `json:"name,omitempty"`
+	Labels                          map[string]string `json:"labels,omitempty"`
+	Annotations                     map[string]string `json:"annotations,omitempty"`
+}
+
+// CreateAlertRequest represents a request to create a Alert
+type CreateAlertRequest struct {
+	alert.AlertSpec `json:",inline"`
+	Name            string            `json:"name" validate:"required"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// UpdateAlertRequest represents a request to update a Alert
+type UpdateAlertRequest struct {
+	alert.AlertSpec `json:",inline,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
 // DeleteResponse represents a successful deletion response
 type DeleteResponse struct {
 	Message string `json:"message"`
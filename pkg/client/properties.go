@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/example/inventory-v3/pkg/properties"
+	"github.com/example/inventory-v3/pkg/reports"
+)
+
+// ListProperties retrieves the server's property registry, narrowed to the
+// properties of deviceType when it is set.
+func (c *Client) ListProperties(ctx context.Context, deviceType string) ([]properties.Schema, error) {
+	query := url.Values{}
+	if deviceType != "" {
+		query.Set("deviceType", deviceType)
+	}
+	var result []properties.Schema
+	if err := c.doQueryRequest(ctx, "GET", "/properties", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetProperty retrieves the schema of one property key.
+func (c *Client) GetProperty(ctx context.Context, key string) (*properties.Schema, error) {
+	var result properties.Schema
+	if err := c.doRequest(ctx, "GET", "/properties/"+url.PathEscape(key), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDeviceProperties retrieves the properties of a device with their
+// schemas, units and any problems with their values.
+func (c *Client) GetDeviceProperties(ctx context.Context, uid string) ([]properties.Property, error) {
+	var result []properties.Property
+	if err := c.doRequest(ctx, "GET", fmt.Sprintf("/devices/%s/properties", uid), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPropertyUsageReport audits the property keys of the active devices
+// against the server's property registry.
+func (c *Client) GetPropertyUsageReport(ctx context.Context, group string) (*reports.PropertyUsageReport, error) {
+	var result reports.PropertyUsageReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/properties", groupQuery(group), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/example/inventory-v3/pkg/naming"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// PreviewPrune returns the devices a prune would affect and the preview token
+// needed to apply it.
+func (c *Client) PreviewPrune(ctx context.Context, req prune.Request) (*prune.Result, error) {
+	req.DryRun = true
+	req.PreviewToken = ""
+	var result prune.Result
+	if err := c.doRequest(ctx, "POST", "/devices/prune", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ApplyPrune deletes or retires the devices of a previous preview. It fails if
+// the matched devices changed since previewToken was issued.
+func (c *Client) ApplyPrune(ctx context.Context, req prune.Request, previewToken string) (*prune.Result, error) {
+	req.DryRun = false
+	req.PreviewToken = previewToken
+	var result prune.Result
+	if err := c.doRequest(ctx, "POST", "/devices/prune", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DecommissionDevice retires a node or rack and all its descendants and
+// disables BMCEndpoints left without an active node. With dryRun set it only
+// reports what would change.
+func (c *Client) DecommissionDevice(ctx context.Context, uid string, dryRun bool) (*prune.DecommissionResult, error) {
+	var result prune.DecommissionResult
+	if err := c.doQueryRequest(ctx, "POST", fmt.Sprintf("/devices/%s/decommission", uid), dryRunQuery(dryRun), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDevicesIncludingRetired retrieves all devices, including retired and
+// decommissioned ones that GetDevices leaves out.
+func (c *Client) GetDevicesIncludingRetired(ctx context.Context) ([]device.Device, error) {
+	var result []device.Device
+	if err := c.doQueryRequest(ctx, "GET", "/devices", url.Values{"includeRetired": {"true"}}, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RenameDevices renames every device by the server's naming strategy. With
+// dryRun set it only returns the renames.
+func (c *Client) RenameDevices(ctx context.Context, dryRun bool) (*naming.RenameResult, error) {
+	var result naming.RenameResult
+	if err := c.doQueryRequest(ctx, "POST", "/devices/rename", dryRunQuery(dryRun), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// dryRunQuery returns the query asking for a dry run, if dryRun is set.
+func dryRunQuery(dryRun bool) url.Values {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+	return query
+}
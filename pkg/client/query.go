@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// DeviceListOptions narrows and orders a device list.
+type DeviceListOptions struct {
+	// Where is a filter expression such as "CapacityMiB >= 65536" evaluated
+	// by the server (see package query).
+	Where string
+	// Group, if set, limits the list to a group's members.
+	Group string
+	// Sort orders the list by columns, e.g. "type,-lastSeen" (see package
+	// devicetable).
+	Sort           string
+	IncludeRetired bool
+}
+
+// ListDevices retrieves the devices that opts selects, in its order.
+func (c *Client) ListDevices(ctx context.Context, opts DeviceListOptions) ([]device.Device, error) {
+	query := url.Values{}
+	if opts.Where != "" {
+		query.Set("where", opts.Where)
+	}
+	if opts.Group != "" {
+		query.Set("group", opts.Group)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	if opts.IncludeRetired {
+		query.Set("includeRetired", "true")
+	}
+	return Devices(c).List(ctx, ListOptions{Query: query})
+}
+
+// QueryDevices retrieves the devices satisfying where, a filter expression
+// such as "CapacityMiB >= 65536" evaluated by the server (see package
+// query). group, if set, limits them to a group's members.
+func (c *Client) QueryDevices(ctx context.Context, where, group string, includeRetired bool) ([]device.Device, error) {
+	return c.ListDevices(ctx, DeviceListOptions{Where: where, Group: group, IncludeRetired: includeRetired})
+}
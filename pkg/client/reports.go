@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/example/inventory-v3/pkg/consistency"
+	"github.com/example/inventory-v3/pkg/fleetstats"
+	"github.com/example/inventory-v3/pkg/reports"
+)
+
+// The device reports take the UID or name of a Group to cover only its
+// members; "" covers the fleet.
+
+// GetDriveEnduranceReport retrieves the fleet drive endurance report.
+// A threshold of zero uses the server default for counting worn drives.
+func (c *Client) GetDriveEnduranceReport(ctx context.Context, threshold float64, group string) (*reports.DriveEnduranceReport, error) {
+	query := groupQuery(group)
+	if threshold > 0 {
+		query.Set("threshold", strconv.FormatFloat(threshold, 'f', -1, 64))
+	}
+	var result reports.DriveEnduranceReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/drive-endurance", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetConsistencyReport retrieves the latest fleet consistency report.
+// When refresh is true the server runs a new check first.
+func (c *Client) GetConsistencyReport(ctx context.Context, refresh bool) (*consistency.Report, error) {
+	query := url.Values{}
+	if refresh {
+		query.Set("refresh", "true")
+	}
+	var result consistency.Report
+	if err := c.doQueryRequest(ctx, "GET", "/reports/consistency", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetNewHardwareReport retrieves the devices first seen in the last days days.
+// Zero days uses the server's scheduled report period.
+func (c *Client) GetNewHardwareReport(ctx context.Context, days int, group string) (*reports.NewHardwareReport, error) {
+	var result reports.NewHardwareReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/new-hardware", withDays(groupQuery(group), days), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMissingDevicesReport retrieves the devices not seen for more than days
+// days. Zero days uses the server default.
+func (c *Client) GetMissingDevicesReport(ctx context.Context, days int, group string) (*reports.MissingDevicesReport, error) {
+	var result reports.MissingDevicesReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/missing-devices", withDays(groupQuery(group), days), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFirmwareComplianceReport compares device firmware with the server's
+// firmware baseline.
+func (c *Client) GetFirmwareComplianceReport(ctx context.Context, group string) (*reports.FirmwareComplianceReport, error) {
+	var result reports.FirmwareComplianceReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/firmware-compliance", groupQuery(group), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFirmwareVersionsReport retrieves the distribution of firmware versions
+// by model, narrowed by filter.
+func (c *Client) GetFirmwareVersionsReport(ctx context.Context, filter reports.FirmwareVersionFilter, group string) (*reports.FirmwareVersionReport, error) {
+	query := groupQuery(group)
+	for name, value := range map[string]string{
+		"deviceType":   filter.DeviceType,
+		"manufacturer": filter.Manufacturer,
+		"model":        filter.Model,
+		"firmware":     filter.Firmware,
+		"version":      filter.Version,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	var result reports.FirmwareVersionReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/firmware-versions", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCompletenessReport retrieves the inventory completeness of every node.
+// A threshold of zero uses the server default for counting incomplete nodes.
+func (c *Client) GetCompletenessReport(ctx context.Context, threshold float64, group string) (*reports.CompletenessReport, error) {
+	query := groupQuery(group)
+	if threshold > 0 {
+		query.Set("threshold", strconv.FormatFloat(threshold, 'f', -1, 64))
+	}
+	var result reports.CompletenessReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/completeness", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPowerCapacityReport retrieves the power capacity of the nodes in each rack.
+func (c *Client) GetPowerCapacityReport(ctx context.Context, group string) (*reports.PowerCapacityReport, error) {
+	var result reports.PowerCapacityReport
+	if err := c.doQueryRequest(ctx, "GET", "/reports/power-capacity", groupQuery(group), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RunScheduledReports renders the scheduled reports now and delivers them to
+// the server's configured destinations.
+func (c *Client) RunScheduledReports(ctx context.Context) (*reports.Bundle, error) {
+	var result reports.Bundle
+	if err := c.doRequest(ctx, "POST", "/reports/scheduled/run", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFleetStats retrieves the recorded daily fleet statistics from from to
+// to (YYYY-MM-DD; "" leaves the range open), rolled up by period (day, week
+// or month; "" is day).
+func (c *Client) GetFleetStats(ctx context.Context, period, from, to string) (*fleetstats.Series, error) {
+	query := url.Values{}
+	for name, value := range map[string]string{"period": period, "from": from, "to": to} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	var result fleetstats.Series
+	if err := c.doQueryRequest(ctx, "GET", "/reports/fleet-stats", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RecordFleetStats records today's fleet statistics now.
+func (c *Client) RecordFleetStats(ctx context.Context) (*fleetstats.Day, error) {
+	var result fleetstats.Day
+	if err := c.doRequest(ctx, "POST", "/reports/fleet-stats/record", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func withDays(query url.Values, days int) url.Values {
+	if days > 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	return query
+}
+
+// groupQuery returns the query scoping a report to group, if set.
+func groupQuery(group string) url.Values {
+	query := url.Values{}
+	if group != "" {
+		query.Set("group", group)
+	}
+	return query
+}
+
+// doQueryRequest is doRequest with query as the query string. doRequest
+// joins the endpoint to the base URL as a path, which would escape a query
+// written into the endpoint.
+func (c *Client) doQueryRequest(ctx context.Context, method, endpoint string, query url.Values, body interface{}, result interface{}) error {
+	baseURL := *c.baseURL
+	baseURL.RawQuery = query.Encode()
+	queried := *c
+	queried.baseURL = &baseURL
+	return queried.doRequest(ctx, method, endpoint, body, result)
+}
@@ -0,0 +1,416 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/connection"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+)
+
+// ContinueHeader is the response header holding the token of the next page of
+// a paged list.
+const ContinueHeader = "X-Continue"
+
+// DefaultPageSize is the number of items requested per page of a list.
+const DefaultPageSize = 500
+
+// DefaultWatchInterval is how often Watch lists the resources.
+const DefaultWatchInterval = 10 * time.Second
+
+// Resource is a typed client for one kind of resource, T, whose spec is S.
+// It offers the operations every kind has with one implementation, so
+// hand-written code needs no per-kind method to list, watch or write a kind,
+// and a kind only needs a constructor such as Devices. It does not replace
+// the per-kind methods of Client, which Fabrica generates in
+// client_generated.go and the CLI uses.
+type Resource[T, S any] struct {
+	client     *Client
+	collection string
+}
+
+// NewResource returns the client for the resources under collection, the
+// path segment of their endpoints (e.g. "devices").
+func NewResource[T, S any](c *Client, collection string) *Resource[T, S] {
+	return &Resource[T, S]{client: c, collection: collection}
+}
+
+// Devices returns the client for Device resources.
+func Devices(c *Client) *Resource[device.Device, device.DeviceSpec] {
+	return NewResource[device.Device, device.DeviceSpec](c, "devices")
+}
+
+// DiscoverySnapshots returns the client for DiscoverySnapshot resources.
+func DiscoverySnapshots(c *Client) *Resource[discoverysnapshot.DiscoverySnapshot, discoverysnapshot.DiscoverySnapshotSpec] {
+	return NewResource[discoverysnapshot.DiscoverySnapshot, discoverysnapshot.DiscoverySnapshotSpec](c, "discoverysnapshots")
+}
+
+// BMCEndpoints returns the client for BMCEndpoint resources.
+func BMCEndpoints(c *Client) *Resource[bmcendpoint.BMCEndpoint, bmcendpoint.BMCEndpointSpec] {
+	return NewResource[bmcendpoint.BMCEndpoint, bmcendpoint.BMCEndpointSpec](c, "bmcendpoints")
+}
+
+// ServiceEvents returns the client for ServiceEvent resources.
+func ServiceEvents(c *Client) *Resource[serviceevent.ServiceEvent, serviceevent.ServiceEventSpec] {
+	return NewResource[serviceevent.ServiceEvent, serviceevent.ServiceEventSpec](c, "serviceevents")
+}
+
+// MaintenanceWindows returns the client for MaintenanceWindow resources.
+func MaintenanceWindows(c *Client) *Resource[maintenancewindow.MaintenanceWindow, maintenancewindow.MaintenanceWindowSpec] {
+	return NewResource[maintenancewindow.MaintenanceWindow, maintenancewindow.MaintenanceWindowSpec](c, "maintenancewindows")
+}
+
+// Groups returns the client for Group resources.
+func Groups(c *Client) *Resource[group.Group, group.GroupSpec] {
+	return NewResource[group.Group, group.GroupSpec](c, "groups")
+}
+
+// Connections returns the client for Connection resources.
+func Connections(c *Client) *Resource[connection.Connection, connection.ConnectionSpec] {
+	return NewResource[connection.Connection, connection.ConnectionSpec](c, "connections")
+}
+
+// PendingChanges returns the client for PendingChange resources.
+func PendingChanges(c *Client) *Resource[pendingchange.PendingChange, pendingchange.PendingChangeSpec] {
+	return NewResource[pendingchange.PendingChange, pendingchange.PendingChangeSpec](c, "pendingchanges")
+}
+
+// Alerts returns the client for Alert resources.
+func Alerts(c *Client) *Resource[alert.Alert, alert.AlertSpec] {
+	return NewResource[alert.Alert, alert.AlertSpec](c, "alerts")
+}
+
+// ListOptions configures List, All and Watch.
+type ListOptions struct {
+	// PageSize is the number of items fetched per request; 0 uses
+	// DefaultPageSize. Servers without paging return everything at once.
+	PageSize int
+	// Query holds further query parameters, e.g. includeRetired=true for
+	// devices.
+	Query url.Values
+}
+
+// WriteOptions configures Create and Update.
+type WriteOptions struct {
+	// Labels and Annotations are added to the resource's metadata.
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// endpoint returns the path of the collection, or of one of its resources.
+func (r *Resource[T, S]) endpoint(uid string) string {
+	if uid == "" {
+		return "/" + r.collection
+	}
+	return "/" + r.collection + "/" + url.PathEscape(uid)
+}
+
+// Get retrieves a resource by UID.
+func (r *Resource[T, S]) Get(ctx context.Context, uid string) (*T, error) {
+	var result T
+	if err := r.client.doRequest(ctx, "GET", r.endpoint(uid), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Create creates a resource named name with spec.
+func (r *Resource[T, S]) Create(ctx context.Context, name string, spec S, opts WriteOptions) (*T, error) {
+	var result T
+	req := writeRequest[S]{spec: spec, name: name, opts: opts}
+	if err := r.client.doRequest(ctx, "POST", r.endpoint(""), req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Update replaces the spec of a resource; its status is left alone.
+func (r *Resource[T, S]) Update(ctx context.Context, uid string, spec S, opts WriteOptions) (*T, error) {
+	var result T
+	req := writeRequest[S]{spec: spec, opts: opts}
+	if err := r.client.doRequest(ctx, "PUT", r.endpoint(uid), req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Delete deletes a resource.
+func (r *Resource[T, S]) Delete(ctx context.Context, uid string) error {
+	return r.client.doRequest(ctx, "DELETE", r.endpoint(uid), nil, nil)
+}
+
+// List retrieves all the resources, a page at a time.
+func (r *Resource[T, S]) List(ctx context.Context, opts ListOptions) ([]T, error) {
+	var items []T
+	for item, err := range r.All(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// All iterates over the resources, fetching the next page when the loop
+// reaches the end of the current one. A failed request ends the iteration
+// with its error.
+func (r *Resource[T, S]) All(ctx context.Context, opts ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range r.raw(ctx, opts) {
+			var value T
+			if err == nil {
+				err = json.Unmarshal(item.data, &value)
+			}
+			if !yield(value, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// rawItem is a listed resource with its UID, still encoded.
+type rawItem struct {
+	uid  string
+	data json.RawMessage
+}
+
+// raw iterates over the encoded resources, page by page.
+func (r *Resource[T, S]) raw(ctx context.Context, opts ListOptions) iter.Seq2[rawItem, error] {
+	return func(yield func(rawItem, error) bool) {
+		pageSize := opts.PageSize
+		if pageSize <= 0 {
+			pageSize = DefaultPageSize
+		}
+		query := url.Values{}
+		for key, values := range opts.Query {
+			query[key] = values
+		}
+		query.Set("limit", strconv.Itoa(pageSize))
+		query.Del("continue")
+
+		for {
+			var page []json.RawMessage
+			next, err := r.client.doPageRequest(ctx, r.endpoint(""), query, &page)
+			if err != nil {
+				yield(rawItem{}, err)
+				return
+			}
+			for _, data := range page {
+				var meta struct {
+					Metadata struct {
+						UID string `json:"uid"`
+					} `json:"metadata"`
+				}
+				if err := json.Unmarshal(data, &meta); err != nil {
+					yield(rawItem{}, fmt.Errorf("failed to unmarshal %s item: %w", r.collection, err))
+					return
+				}
+				if !yield(rawItem{uid: meta.Metadata.UID, data: data}, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			query.Set("continue", next)
+		}
+	}
+}
+
+// --- Watch ---
+
+// EventType is the kind of change Watch reports.
+type EventType string
+
+// Event types.
+const (
+	EventAdded    EventType = "Added"
+	EventModified EventType = "Modified"
+	EventDeleted  EventType = "Deleted"
+)
+
+// Event is a change to a resource. A deleted resource is reported as last
+// seen.
+type Event[T any] struct {
+	Type   EventType
+	UID    string
+	Object T
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	ListOptions
+	// Interval is the time between two lists; 0 uses DefaultWatchInterval.
+	Interval time.Duration
+}
+
+// Watch reports the changes to the resources until ctx is done or the loop
+// stops. The API has no change stream, so Watch lists the resources every
+// interval and compares them with the previous list; the first list reports
+// every resource as added. A failed list is reported as an error and tried
+// again at the next interval, without losing track of the resources.
+func (r *Resource[T, S]) Watch(ctx context.Context, opts WatchOptions) iter.Seq2[Event[T], error] {
+	return func(yield func(Event[T], error) bool) {
+		interval := opts.Interval
+		if interval <= 0 {
+			interval = DefaultWatchInterval
+		}
+		seen := make(map[string]json.RawMessage)
+		for {
+			if !r.poll(ctx, opts.ListOptions, seen, yield) {
+				return
+			}
+			if sleep(ctx, interval) != nil {
+				return
+			}
+		}
+	}
+}
+
+// poll lists the resources once and yields the differences from seen, which
+// it updates: additions and modifications by UID, then deletions by UID. It
+// returns false once the loop stops or ctx is done.
+func (r *Resource[T, S]) poll(ctx context.Context, opts ListOptions, seen map[string]json.RawMessage, yield func(Event[T], error) bool) bool {
+	current := make(map[string]json.RawMessage, len(seen))
+	for item, err := range r.raw(ctx, opts) {
+		if err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			return yield(Event[T]{}, err)
+		}
+		current[item.uid] = item.data
+	}
+
+	emit := func(eventType EventType, uid string, data json.RawMessage) bool {
+		event := Event[T]{Type: eventType, UID: uid}
+		if err := json.Unmarshal(data, &event.Object); err != nil {
+			return yield(Event[T]{}, fmt.Errorf("failed to unmarshal %s %s: %w", r.collection, uid, err))
+		}
+		return yield(event, nil)
+	}
+	for _, uid := range sortedUIDs(current) {
+		data := current[uid]
+		previous, ok := seen[uid]
+		seen[uid] = data
+		switch {
+		case !ok:
+			if !emit(EventAdded, uid, data) {
+				return false
+			}
+		case !bytes.Equal(previous, data):
+			if !emit(EventModified, uid, data) {
+				return false
+			}
+		}
+	}
+	for _, uid := range sortedUIDs(seen) {
+		if _, ok := current[uid]; !ok {
+			data := seen[uid]
+			delete(seen, uid)
+			if !emit(EventDeleted, uid, data) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sortedUIDs returns the keys of items in order.
+func sortedUIDs(items map[string]json.RawMessage) []string {
+	uids := make([]string, 0, len(items))
+	for uid := range items {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+	return uids
+}
+
+// --- Requests ---
+
+// writeRequest is the body of a create or update: the spec fields with the
+// name, labels and annotations alongside, as the Create and Update request
+// types lay them out.
+type writeRequest[S any] struct {
+	spec S
+	name string
+	opts WriteOptions
+}
+
+func (w writeRequest[S]) MarshalJSON() ([]byte, error) {
+	spec, err := json.Marshal(w.spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(spec, &fields); err != nil {
+		return nil, fmt.Errorf("spec must encode as a JSON object: %w", err)
+	}
+	if w.name != "" {
+		fields["name"] = w.name
+	}
+	if len(w.opts.Labels) > 0 {
+		fields["labels"] = w.opts.Labels
+	}
+	if len(w.opts.Annotations) > 0 {
+		fields["annotations"] = w.opts.Annotations
+	}
+	return json.Marshal(fields)
+}
+
+// doPageRequest fetches one page of a list and returns the token of the next
+// page, or "" after the last.
+func (c *Client) doPageRequest(ctx context.Context, endpoint string, query url.Values, result interface{}) (string, error) {
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, endpoint)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	acceptType := "application/json"
+	if c.version != "" {
+		acceptType = fmt.Sprintf("application/json;version=%s", c.version)
+	}
+	req.Header.Set("Accept", acceptType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		var errorResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errorResp); err != nil {
+			return "", fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(respBody))
+		}
+		return "", fmt.Errorf("API error (%d): %s", resp.StatusCode, errorResp.Error)
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return resp.Header.Get(ContinueHeader), nil
+}
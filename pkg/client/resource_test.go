@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// TestWatchOrder reports the changes of each list in UID order: additions
+// and modifications, then deletions.
+func TestWatchOrder(t *testing.T) {
+	lists := [][]string{
+		{"dev-c", "dev-a", "dev-e", "dev-b", "dev-d"},
+		{"dev-e", "dev-c", "dev-f"},
+	}
+	var mu sync.Mutex
+	listed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		uids := lists[min(listed, len(lists)-1)]
+		listed++
+		mu.Unlock()
+		var devices []device.Device
+		for _, uid := range uids {
+			var dev device.Device
+			dev.Metadata.UID = uid
+			if uid == "dev-c" && listed > 1 {
+				dev.Metadata.Name = "renamed"
+			}
+			devices = append(devices, dev)
+		}
+		json.NewEncoder(w).Encode(devices)
+	}))
+	defer server.Close()
+	c, err := NewClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Added dev-a", "Added dev-b", "Added dev-c", "Added dev-d", "Added dev-e",
+		"Modified dev-c", "Added dev-f", "Deleted dev-a", "Deleted dev-b", "Deleted dev-d",
+	}
+	var got []string
+	for event, err := range Devices(c).Watch(context.Background(), WatchOptions{Interval: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(event.Type)+" "+event.UID)
+		if len(got) == len(want) {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Watch = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,298 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of RetryPolicy.
+const (
+	DefaultMaxRetries       = 3
+	DefaultBaseDelay        = 500 * time.Millisecond
+	DefaultMaxDelay         = 30 * time.Second
+	DefaultMaxRetryWait     = 2 * time.Minute
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting the API while the circuit
+// breaker is open after repeated failures.
+var ErrCircuitOpen = errors.New("inventory API circuit breaker is open after repeated failures")
+
+// RetryPolicy configures RetryTransport. Zero fields use the defaults.
+type RetryPolicy struct {
+	// MaxRetries caps the retries of one request; negative disables retries.
+	MaxRetries int
+	// BaseDelay and MaxDelay bound the jittered exponential backoff between
+	// retries of failed requests.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxWait caps the delay a throttled response may ask for; a longer
+	// Retry-After returns the response as is.
+	MaxWait time.Duration
+	// Timeout bounds each attempt, including reading the response body;
+	// 0 leaves attempts to the caller's context.
+	Timeout time.Duration
+	// BreakerThreshold is how many requests in a row may fail before the
+	// breaker opens; negative disables the breaker.
+	BreakerThreshold int
+	// BreakerCooldown is how long the breaker stays open before letting one
+	// trial request through.
+	BreakerCooldown time.Duration
+}
+
+// withDefaults fills the zero fields.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxRetries == 0 {
+		p.MaxRetries = DefaultMaxRetries
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultMaxDelay
+	}
+	if p.MaxWait <= 0 {
+		p.MaxWait = DefaultMaxRetryWait
+	}
+	if p.BreakerThreshold == 0 {
+		p.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if p.BreakerCooldown <= 0 {
+		p.BreakerCooldown = DefaultBreakerCooldown
+	}
+	return p
+}
+
+// RetryTransport makes API calls survive transient outages:
+//
+//   - Throttled requests (429, or 503 with Retry-After) are sent again once
+//     the Retry-After delay has passed; the server rejected them unprocessed,
+//     so this is safe for every method.
+//   - Requests that failed in transit or with 502, 503 or 504 are retried
+//     with jittered exponential backoff, but only when they are idempotent:
+//     GET, HEAD, OPTIONS, PUT and DELETE, or any request carrying an
+//     Idempotency-Key header.
+//   - After BreakerThreshold failures in a row the circuit breaker opens and
+//     requests fail with ErrCircuitOpen until BreakerCooldown has passed.
+//
+// Share one transport between the clients of a process so that they share
+// the breaker.
+type RetryTransport struct {
+	// Base sends the requests; nil uses http.DefaultTransport.
+	Base   http.RoundTripper
+	Policy RetryPolicy
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// WithRetry returns a copy of httpClient (nil: http.DefaultClient) whose
+// requests go through a RetryTransport with the default policy.
+func WithRetry(httpClient *http.Client) *http.Client {
+	return WithRetryPolicy(httpClient, RetryPolicy{})
+}
+
+// WithRetryPolicy is WithRetry with a policy.
+func WithRetryPolicy(httpClient *http.Client, policy RetryPolicy) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	retrying := *httpClient
+	retrying.Transport = &RetryTransport{Base: httpClient.Transport, Policy: policy}
+	return &retrying
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy := t.Policy.withDefaults()
+	for attempt := 0; ; attempt++ {
+		if err := t.admit(policy); err != nil {
+			return nil, err
+		}
+		resp, err := t.send(req, policy)
+		failed := err != nil || isServerFailure(resp)
+		// Once the breaker opens, report this failure rather than the breaker
+		if t.record(policy, failed) || attempt >= policy.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		var wait time.Duration
+		if err == nil {
+			var throttled bool
+			if wait, throttled = RetryAfter(resp, time.Now()); throttled {
+				if wait > policy.MaxWait {
+					return resp, nil
+				}
+			} else if !failed || !idempotent(req) {
+				return resp, nil
+			} else {
+				wait = backoff(policy, attempt)
+			}
+			resp.Body.Close()
+		} else if !idempotent(req) || req.Context().Err() != nil {
+			return nil, err
+		} else {
+			wait = backoff(policy, attempt)
+		}
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// send performs one attempt, bounded by the policy's timeout. The timeout
+// also covers reading the body, so it is released when the body is closed.
+func (t *RetryTransport) send(req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if policy.Timeout <= 0 {
+		return base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), policy.Timeout)
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// admit fails fast while the breaker is open. Once the cooldown has passed,
+// one trial request is let through; its outcome closes or reopens the breaker.
+func (t *RetryTransport) admit(policy RetryPolicy) error {
+	if policy.BreakerThreshold < 0 {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.openUntil.IsZero() {
+		return nil
+	}
+	if time.Now().Before(t.openUntil) || t.trial {
+		return fmt.Errorf("%w; retry after %s", ErrCircuitOpen, t.openUntil.Format(time.RFC3339))
+	}
+	t.trial = true
+	return nil
+}
+
+// record counts a request's outcome toward the breaker, and reports whether
+// the breaker is open.
+func (t *RetryTransport) record(policy RetryPolicy, failed bool) bool {
+	if policy.BreakerThreshold < 0 {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trial = false
+	if !failed {
+		t.failures = 0
+		t.openUntil = time.Time{}
+		return false
+	}
+	t.failures++
+	if t.failures >= policy.BreakerThreshold {
+		t.openUntil = time.Now().Add(policy.BreakerCooldown)
+	}
+	return !t.openUntil.IsZero()
+}
+
+// isServerFailure reports whether the response means the API is unavailable.
+func isServerFailure(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// idempotent reports whether repeating req cannot apply it twice.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff returns the delay before retry attempt+1: a random duration up to
+// BaseDelay doubled per attempt, capped at MaxDelay ("full jitter").
+func backoff(policy RetryPolicy, attempt int) time.Duration {
+	ceiling := policy.MaxDelay
+	if attempt < 30 {
+		if d := policy.BaseDelay << attempt; d > 0 && d < ceiling {
+			ceiling = d
+		}
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases an attempt's timeout when its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// RetryAfter returns how long the server asked to wait before retrying, and
+// whether resp is a throttled response (429, or 503 with Retry-After). The
+// header may be a number of seconds or an HTTP date; a 429 without it waits
+// one second.
+func RetryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	header := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+	case resp.StatusCode == http.StatusServiceUnavailable && header != "":
+	default:
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return time.Second, true
+}
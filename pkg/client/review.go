@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+)
+
+// ReviewDiscoverySnapshot approves or rejects a snapshot the server's change
+// guard held, recording who decided and why.
+func (c *Client) ReviewDiscoverySnapshot(ctx context.Context, uid string, approve bool, by, reason string) (*discoverysnapshot.DiscoverySnapshot, error) {
+	action := "/reject"
+	if approve {
+		action = "/approve"
+	}
+	body := discoverysnapshot.Review{By: by, Reason: reason}
+	var result discoverysnapshot.DiscoverySnapshot
+	if err := c.doRequest(ctx, "POST", "/discoverysnapshots/"+url.PathEscape(uid)+action, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ReviewPendingChange approves or rejects a change held for sign-off by the
+// server's change-control policy, recording who decided and why.
+func (c *Client) ReviewPendingChange(ctx context.Context, uid string, approve bool, by, reason string) (*pendingchange.PendingChange, error) {
+	action := "/reject"
+	if approve {
+		action = "/approve"
+	}
+	body := pendingchange.Review{By: by, Reason: reason}
+	var result pendingchange.PendingChange
+	if err := c.doRequest(ctx, "POST", "/pendingchanges/"+url.PathEscape(uid)+action, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RunIDHeader carries the ID of the collection run an API call belongs to, so
+// that a collection can be traced across the collector, API and reconciler.
+const RunIDHeader = "X-Run-ID"
+
+type runIDKey struct{}
+
+// NewRunID returns a random run ID.
+func NewRunID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRunID returns a context whose API calls carry runID in the X-Run-ID
+// header, when made through a client from WithRunIDHeader.
+func WithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunID returns the run ID of ctx, or "".
+func RunID(ctx context.Context) string {
+	runID, _ := ctx.Value(runIDKey{}).(string)
+	return runID
+}
+
+// WithRunIDHeader returns a copy of httpClient (nil: http.DefaultClient) that
+// sets the X-Run-ID header of each request from its context.
+func WithRunIDHeader(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	tagging := *httpClient
+	tagging.Transport = runIDTransport{base: httpClient.Transport}
+	return &tagging
+}
+
+// runIDTransport sets the X-Run-ID header.
+type runIDTransport struct {
+	base http.RoundTripper
+}
+
+func (t runIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if runID := RunID(req.Context()); runID != "" && req.Header.Get(RunIDHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(RunIDHeader, runID)
+	}
+	return base.RoundTrip(req)
+}
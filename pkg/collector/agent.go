@@ -0,0 +1,288 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/telemetry"
+)
+
+// --- Agent Mode ---
+//
+// The agent is the collector as a long-lived process, e.g. a container in a
+// site management cluster. Every interval it collects from each enabled
+// BMCEndpoint registered in the inventory API, and it serves /healthz and
+// /readyz for the orchestrator's probes.
+
+// DefaultAgentInterval is how often the agent collects from every endpoint.
+const DefaultAgentInterval = time.Hour
+
+// DefaultAgentConcurrency is how many endpoints the agent collects from at once.
+const DefaultAgentConcurrency = 4
+
+// Agent collects from the registered BMCEndpoints on a schedule.
+type Agent struct {
+	// Options are used for every collection; Backend, Profile and Protocol
+	// are left to the endpoints.
+	Options     CollectOptions
+	Interval    time.Duration
+	Concurrency int
+	// Shard selects the endpoints this agent collects when several share a fleet.
+	Shard Shard
+	// MaxBackoff caps the wait before retrying an endpoint that keeps failing.
+	MaxBackoff time.Duration
+	// Telemetry, when the site opts in, counts the collections and sends the
+	// maintainers anonymous usage statistics; nil sends nothing.
+	Telemetry *telemetry.Reporter
+
+	mu     sync.Mutex
+	status AgentStatus
+	wg     sync.WaitGroup
+}
+
+// AgentStatus is the body of the health endpoints.
+type AgentStatus struct {
+	Ready bool `json:"ready"`
+	// Reason explains why the agent is not ready.
+	Reason  string    `json:"reason,omitempty"`
+	Running bool      `json:"running"`
+	LastRun time.Time `json:"lastRun,omitempty"`
+	NextRun time.Time `json:"nextRun,omitempty"`
+	Shard   Shard     `json:"shard"`
+	// Endpoints counts the enabled endpoints this agent's shard owns. The
+	// last run skipped CoolingDown of them after failures, and InMaintenance
+	// because a maintenance window covers them.
+	Endpoints     int    `json:"endpoints"`
+	CoolingDown   int    `json:"coolingDown"`
+	InMaintenance int    `json:"inMaintenance"`
+	Succeeded     int    `json:"succeeded"`
+	Failed        int    `json:"failed"`
+	LastFailure   string `json:"lastFailure,omitempty"`
+}
+
+// NewAgent returns an agent collecting the shard's endpoints; a zero interval
+// or concurrency uses the defaults.
+func NewAgent(opts CollectOptions, interval time.Duration, concurrency int, shard Shard) *Agent {
+	if interval <= 0 {
+		interval = DefaultAgentInterval
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultAgentConcurrency
+	}
+	opts.Backend, opts.Profile, opts.Protocol = "", "", ""
+	opts.endpointSettings = true
+	return &Agent{
+		Options:     opts,
+		Interval:    interval,
+		Concurrency: concurrency,
+		Shard:       shard,
+		MaxBackoff:  DefaultMaxBackoff,
+		status:      AgentStatus{Shard: shard, Reason: "the first run has not listed the endpoints yet"},
+	}
+}
+
+// Status returns the agent's current status.
+func (a *Agent) Status() AgentStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status
+}
+
+// Run collects immediately and then every interval until ctx is done. It
+// then waits up to grace for collections in progress to finish.
+func (a *Agent) Run(ctx context.Context, grace time.Duration) error {
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+	for {
+		a.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return a.drain(grace)
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain marks the agent as stopping and waits for collections in progress.
+func (a *Agent) drain(grace time.Duration) error {
+	a.mu.Lock()
+	a.status.Ready = false
+	a.status.Reason = "shutting down"
+	a.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(grace):
+		return fmt.Errorf("collections still running after %s", grace)
+	}
+}
+
+// runOnce collects from every enabled endpoint, a few at a time. No new
+// collection starts once ctx is done.
+func (a *Agent) runOnce(ctx context.Context) {
+	a.mu.Lock()
+	a.status.Running = true
+	a.mu.Unlock()
+
+	addresses, skipped, err := a.endpointAddresses(ctx)
+
+	a.mu.Lock()
+	a.status.LastRun = time.Now()
+	a.status.NextRun = a.status.LastRun.Add(a.Interval)
+	if err != nil {
+		a.status.Running = false
+		a.status.Ready = false
+		a.status.Reason = err.Error()
+		a.mu.Unlock()
+		fmt.Printf("Warning: Failed to list BMC endpoints: %v\n", err)
+		return
+	}
+	a.status.Ready = true
+	a.status.Reason = ""
+	a.status.Endpoints = len(addresses) + skipped.coolingDown + skipped.inMaintenance
+	a.status.CoolingDown = skipped.coolingDown
+	a.status.InMaintenance = skipped.inMaintenance
+	a.status.Succeeded, a.status.Failed, a.status.LastFailure = 0, 0, ""
+	a.mu.Unlock()
+
+	fmt.Printf("Collecting from %d BMC endpoints (shard %s, %d cooling down, %d in maintenance)\n", len(addresses), a.Shard, skipped.coolingDown, skipped.inMaintenance)
+	sem := make(chan struct{}, a.Concurrency)
+	var run sync.WaitGroup
+	for _, address := range addresses {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			run.Add(1)
+			a.wg.Add(1)
+			go func(address string) {
+				defer func() {
+					<-sem
+					run.Done()
+					a.wg.Done()
+				}()
+				opts := a.Options
+				opts.RunID = fabricaclient.NewRunID()
+				summary, err := Collect(address, opts)
+				collection := telemetry.Collection{Protocol: opts.Protocol, Err: err}
+				if summary != nil {
+					collection.Vendor, collection.Protocol = summary.Vendor, summary.Protocol
+				}
+				a.Telemetry.Record(collection)
+				a.mu.Lock()
+				defer a.mu.Unlock()
+				if err != nil {
+					a.status.Failed++
+					a.status.LastFailure = fmt.Sprintf("%s (run %s): %v", address, opts.RunID, err)
+					fmt.Printf("Warning: Collection from %s (run %s) failed: %v\n", address, opts.RunID, err)
+					return
+				}
+				a.status.Succeeded++
+			}(address)
+		}
+	}
+	run.Wait()
+
+	a.mu.Lock()
+	a.status.Running = false
+	endpoints := a.status.Endpoints
+	a.mu.Unlock()
+	a.sendTelemetry(ctx, endpoints)
+}
+
+// sendTelemetry sends the usage statistics when a report is due. The report
+// is logged, so that the site sees what leaves it.
+func (a *Agent) sendTelemetry(ctx context.Context, endpoints int) {
+	if !a.Telemetry.Due(time.Now()) {
+		return
+	}
+	report := a.Telemetry.Flush(endpoints)
+	body, _ := json.Marshal(report)
+	fmt.Printf("Sending usage statistics to %s: %s\n", a.Telemetry.Endpoint, body)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+	defer cancel()
+	if err := a.Telemetry.Send(ctx, report); err != nil {
+		fmt.Printf("Warning: Failed to send usage statistics: %v\n", err)
+	}
+}
+
+// skippedEndpoints counts the endpoints a run left out.
+type skippedEndpoints struct {
+	coolingDown   int
+	inMaintenance int
+}
+
+// endpointAddresses lists the addresses of the collected BMCEndpoints the
+// agent's shard owns and that are due, leaving out those backing off after
+// failures and those in a maintenance window.
+func (a *Agent) endpointAddresses(ctx context.Context) ([]string, skippedEndpoints, error) {
+	var skipped skippedEndpoints
+	sdkClient, err := a.Options.apiClient()
+	if err != nil {
+		return nil, skipped, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	endpoints, err := fabricaclient.BMCEndpoints(sdkClient).List(ctx, fabricaclient.ListOptions{})
+	if err != nil {
+		return nil, skipped, fmt.Errorf("failed to list BMC endpoints: %w", err)
+	}
+	blackout, err := sdkClient.GetActiveMaintenance(ctx)
+	if err != nil {
+		return nil, skipped, fmt.Errorf("failed to get active maintenance windows: %w", err)
+	}
+	// Runs start an interval apart but failures are recorded during a run;
+	// half an interval of slack keeps the first retry on schedule.
+	due := time.Now().Add(a.Interval / 2)
+	var addresses []string
+	for _, endpoint := range endpoints {
+		if !endpoint.Spec.Collected() || endpoint.Spec.Address == "" || !a.Shard.Owns(endpoint.Spec.Address) {
+			continue
+		}
+		if blackout.CoversAddress(endpoint.Spec.Address) {
+			skipped.inMaintenance++
+			continue
+		}
+		if retry := endpoint.Status.RetryAfter(a.Interval, a.MaxBackoff); retry.After(due) {
+			skipped.coolingDown++
+			continue
+		}
+		addresses = append(addresses, endpoint.Spec.Address)
+	}
+	return addresses, skipped, nil
+}
+
+// Handler serves the health endpoints: /healthz answers while the process
+// runs, and /readyz once the inventory API is reachable and until shutdown.
+func (a *Agent) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, a.Status())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		status := a.Status()
+		code := http.StatusOK
+		if !status.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		writeStatus(w, code, status)
+	})
+	return mux
+}
+
+// writeStatus writes the agent status as JSON.
+func writeStatus(w http.ResponseWriter, code int, status AgentStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
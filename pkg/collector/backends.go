@@ -0,0 +1,50 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Collector Backends ---
+
+// DiscoveryFunc walks a Redfish service and returns the device specs to post.
+type DiscoveryFunc func(c *RedfishClient) ([]*device.DeviceSpec, error)
+
+// DefaultBackend discovers compute nodes and their components.
+const DefaultBackend = "redfish"
+
+// backends maps a backend name to its discovery function.
+var backends = map[string]DiscoveryFunc{
+	DefaultBackend: discoverDevices,
+	"pdu":          discoverPDUDevices,
+	"cdu":          discoverCDUDevices,
+}
+
+// RegisterBackend adds (or replaces) a named discovery backend.
+func RegisterBackend(name string, fn DiscoveryFunc) {
+	backends[name] = fn
+}
+
+// Backends returns the registered backend names in sorted order.
+func Backends() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupBackend returns the discovery function for name ("" selects the default).
+func lookupBackend(name string) (DiscoveryFunc, error) {
+	if name == "" {
+		name = DefaultBackend
+	}
+	fn, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown collector backend %q (available: %v)", name, Backends())
+	}
+	return fn, nil
+}
@@ -0,0 +1,145 @@
+package collector
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+)
+
+// --- Batch Collection ---
+//
+// A collector run by hand may be given many BMCs, by repeating --ip or with a
+// hosts file, e.g. to inventory a new row of racks. Each BMC is collected and
+// published on its own, with its own run ID, a few at a time as the agent
+// does, and the run ends with a summary of the successes and failures.
+
+// BatchOptions configures a batch collection.
+type BatchOptions struct {
+	// Concurrency is how many BMCs are collected from at once; zero or less
+	// uses DefaultAgentConcurrency.
+	Concurrency int
+	// Wait follows each snapshot until the server has reconciled it (see
+	// Follow), for at most WaitTimeout; a snapshot that is not applied
+	// counts as a failure.
+	Wait        bool
+	WaitTimeout time.Duration
+}
+
+// BatchResult is the outcome of collecting one BMC of a batch.
+type BatchResult struct {
+	Address string `json:"address"`
+	RunID   string `json:"runId"`
+	// Summary is set when the snapshot was published, even if waiting for
+	// it failed.
+	Summary *RunSummary `json:"summary,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// BatchSummary is the outcome of a batch collection.
+type BatchSummary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// Results are in the order the addresses were given.
+	Results []BatchResult `json:"results"`
+}
+
+// ReadHostsFile reads BMC addresses from a file with one address per line.
+// Blank lines and lines starting with # are skipped, as is anything after
+// the address, so /etc/hosts-style lines carrying a name also work.
+func ReadHostsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hosts file: %w", err)
+	}
+	defer f.Close()
+
+	var addresses []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		addresses = append(addresses, fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read hosts file %s: %w", path, err)
+	}
+	return addresses, nil
+}
+
+// CollectBatch collects from every address, publishing one snapshot per BMC.
+// A failed BMC does not stop the others. Addresses given twice are
+// collected once.
+func CollectBatch(addresses []string, opts CollectOptions, batch BatchOptions) *BatchSummary {
+	concurrency := batch.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultAgentConcurrency
+	}
+
+	seen := make(map[string]bool, len(addresses))
+	summary := &BatchSummary{Results: []BatchResult{}}
+	for _, address := range addresses {
+		if !seen[address] {
+			seen[address] = true
+			summary.Results = append(summary.Results, BatchResult{Address: address})
+		}
+	}
+	summary.Total = len(summary.Results)
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range summary.Results {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(result *BatchResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			runOpts := opts
+			runOpts.RunID = fabricaclient.NewRunID()
+			result.RunID = runOpts.RunID
+			fmt.Printf("Starting inventory collection for BMC IP: %s (run %s)\n", result.Address, runOpts.RunID)
+
+			run, err := Collect(result.Address, runOpts)
+			if err == nil && batch.Wait {
+				err = Follow(run, runOpts, batch.WaitTimeout)
+			}
+			result.Summary = run
+			if err != nil {
+				result.Error = err.Error()
+				fmt.Printf("Warning: Collection from %s (run %s) failed: %v\n", result.Address, runOpts.RunID, err)
+			}
+		}(&summary.Results[i])
+	}
+	wg.Wait()
+
+	for _, result := range summary.Results {
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Succeeded++
+		}
+	}
+	return summary
+}
+
+// Print writes the summary as one line per BMC, then the totals.
+func (s *BatchSummary) Print(w io.Writer) {
+	for _, result := range s.Results {
+		if result.Error != "" {
+			fmt.Fprintf(w, "  FAILED  %-20s run %s: %s\n", result.Address, result.RunID, result.Error)
+			continue
+		}
+		fmt.Fprintf(w, "  OK      %-20s run %s: snapshot %s, %d device(s)\n", result.Address, result.RunID, result.Summary.Snapshot, result.Summary.Devices)
+	}
+	fmt.Fprintf(w, "Collected %d of %d BMC(s), %d failed\n", s.Succeeded, s.Total, s.Failed)
+}
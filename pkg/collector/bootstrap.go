@@ -0,0 +1,407 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// --- Credential Bootstrap ---
+//
+// BMCs leave the factory with a well-known login. Before a new rack is
+// first collected, "collector bootstrap-creds" logs into each BMC with it,
+// creates the site's account (or resets its password, if the account
+// exists) with a generated password, checks that the new login works, and
+// records the change in the BMC's endpoint. The password is written to a
+// credential store once the BMC has taken it, so the stored login keeps
+// working if the BMC refuses it; it is neither printed nor sent to the
+// inventory API.
+
+// Bootstrap defaults.
+const (
+	DefaultBootstrapRole  = "Administrator"
+	DefaultPasswordLength = 16
+	MinPasswordLength     = 12
+)
+
+// passwordClasses are the characters generated passwords draw from; every
+// password has at least one of each. The symbols are those BMC firmware
+// commonly accepts.
+var passwordClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!#%+-.=_@",
+}
+
+// BootstrapOptions configures a credential bootstrap.
+type BootstrapOptions struct {
+	// Factory is the login the BMCs shipped with.
+	Factory Credential
+	// Account is the user name of the site account and Role its Redfish
+	// role (DefaultBootstrapRole when empty).
+	Account string
+	Role    string
+	// PasswordLength is the length of the generated passwords
+	// (DefaultPasswordLength when zero).
+	PasswordLength int
+	// Store receives each BMC's new credential, and StoreName names it in
+	// the endpoint's record.
+	Store     CredentialWriter
+	StoreName string
+	// Concurrency is how many BMCs are changed at once; zero or less uses
+	// DefaultAgentConcurrency.
+	Concurrency int
+}
+
+// BootstrapResult is the outcome of bootstrapping one BMC.
+type BootstrapResult struct {
+	Address string `json:"address"`
+	// AccountURI is the Redfish account the site login was set on, and
+	// Created is set when it was created.
+	AccountURI string `json:"accountUri,omitempty"`
+	Created    bool   `json:"created"`
+	// Auth is how the BMC accepted the new login (AuthBasic or AuthSession).
+	Auth string `json:"auth,omitempty"`
+	// Endpoint is the BMCEndpoint the change was recorded on, if any.
+	Endpoint string `json:"endpoint,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// BootstrapSummary is the outcome of a credential bootstrap.
+type BootstrapSummary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	// Results are in the order the addresses were given.
+	Results []BootstrapResult `json:"results"`
+}
+
+// Bootstrap sets up the site account on every address. A failed BMC does
+// not stop the others. Addresses given twice are changed once.
+func (opts CollectOptions) Bootstrap(ctx context.Context, addresses []string, b BootstrapOptions) (*BootstrapSummary, error) {
+	if b.Account == "" {
+		return nil, errors.New("no site account given")
+	}
+	if b.Store == nil {
+		return nil, errors.New("no credential store to write the new passwords to")
+	}
+	if b.Role == "" {
+		b.Role = DefaultBootstrapRole
+	}
+	if b.PasswordLength == 0 {
+		b.PasswordLength = DefaultPasswordLength
+	}
+	if b.PasswordLength < MinPasswordLength {
+		return nil, fmt.Errorf("passwords must be at least %d characters", MinPasswordLength)
+	}
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultAgentConcurrency
+	}
+
+	seen := make(map[string]bool, len(addresses))
+	summary := &BootstrapSummary{Results: []BootstrapResult{}}
+	for _, address := range addresses {
+		if !seen[address] {
+			seen[address] = true
+			summary.Results = append(summary.Results, BootstrapResult{Address: address})
+		}
+	}
+	summary.Total = len(summary.Results)
+	sdkClient, endpoints := opts.bootstrapEndpoints(ctx)
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range summary.Results {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(result *BootstrapResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := opts.bootstrapBMC(result, b); err != nil {
+				result.Error = err.Error()
+				fmt.Printf("Warning: Credential bootstrap of %s failed: %v\n", result.Address, err)
+				return
+			}
+			if endpoint, ok := endpoints[result.Address]; ok {
+				result.Endpoint = endpoint.GetName()
+				recordRotation(ctx, sdkClient, endpoint, b, result.Created)
+			}
+		}(&summary.Results[i])
+	}
+	wg.Wait()
+
+	for _, result := range summary.Results {
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Succeeded++
+		}
+	}
+	return summary, nil
+}
+
+// bootstrapEndpoints returns the registered BMCEndpoints by address, to
+// record the changes on. Without the inventory API the changes are only
+// made, not recorded.
+func (opts CollectOptions) bootstrapEndpoints(ctx context.Context) (*fabricaclient.Client, map[string]*bmcendpoint.BMCEndpoint) {
+	endpoints := map[string]*bmcendpoint.BMCEndpoint{}
+	sdkClient, err := opts.apiClient()
+	if err != nil {
+		fmt.Printf("Warning: Changes will not be recorded: %v\n", err)
+		return nil, endpoints
+	}
+	for endpoint, err := range fabricaclient.BMCEndpoints(sdkClient).All(ctx, fabricaclient.ListOptions{}) {
+		if err != nil {
+			fmt.Printf("Warning: Failed to list BMCEndpoints; changes will not be recorded: %v\n", err)
+			return nil, map[string]*bmcendpoint.BMCEndpoint{}
+		}
+		endpoints[endpoint.Spec.Address] = &endpoint
+	}
+	return sdkClient, endpoints
+}
+
+// bootstrapBMC logs into the BMC at result.Address with the factory login,
+// sets a new password for the site account and stores it.
+func (opts CollectOptions) bootstrapBMC(result *BootstrapResult, b BootstrapOptions) error {
+	c, err := opts.redfishClient(result.Address, b.Factory)
+	if err != nil {
+		return err
+	}
+	if _, err := c.get("/AccountService"); err != nil {
+		var status *statusError
+		if !errors.As(err, &status) || (status.Code != http.StatusUnauthorized && status.Code != http.StatusForbidden) {
+			return err
+		}
+		if err := c.login(); err != nil {
+			return fmt.Errorf("the factory login was refused: %w", err)
+		}
+	}
+	defer c.Logout()
+
+	password, err := GeneratePassword(b.PasswordLength)
+	if err != nil {
+		return err
+	}
+	cred := Credential{Username: b.Account, Password: password}
+	// The stored credential is only replaced once the BMC has taken the new
+	// one: a BMC that refuses it, e.g. by its password policy, keeps the
+	// login it has
+	if result.AccountURI, result.Created, err = c.setAccount(cred, b.Role); err != nil {
+		return fmt.Errorf("the BMC refused the new login: %w", err)
+	}
+	if err := b.Store.Store(result.Address, cred); err != nil {
+		// The factory login still works, so a rerun resets the password again
+		return fmt.Errorf("the new password was set on the BMC but could not be stored; rerun once the store works: %w", err)
+	}
+	if result.Auth, err = opts.CheckCredential(result.Address, cred); err != nil {
+		return fmt.Errorf("the BMC did not accept the new login: %w", err)
+	}
+	return nil
+}
+
+// RedfishAccount is a member of the AccountService's Accounts collection.
+type RedfishAccount struct {
+	ID       string `json:"Id"`
+	UserName string `json:"UserName"`
+	RoleID   string `json:"RoleId"`
+	Enabled  bool   `json:"Enabled"`
+}
+
+// setAccount sets the password of the account named cred.Username, and
+// returns its URI and whether it was created. A missing account is created,
+// or, on BMCs with a fixed table of account slots that refuse to create
+// accounts, written into the first empty slot after the first.
+func (c *RedfishClient) setAccount(cred Credential, role string) (string, bool, error) {
+	body, err := c.get("/AccountService")
+	if err != nil {
+		return "", false, err
+	}
+	var service struct {
+		Accounts ODataLink `json:"Accounts"`
+	}
+	if err := json.Unmarshal(body, &service); err != nil {
+		return "", false, fmt.Errorf("failed to decode the account service: %w", err)
+	}
+	if service.Accounts.ODataID == "" {
+		return "", false, errors.New("the BMC lists no accounts")
+	}
+	accountsURI := strings.TrimPrefix(service.Accounts.ODataID, "/redfish/v1")
+	uris, err := getCollectionMembers(c, accountsURI)
+	if err != nil {
+		return "", false, err
+	}
+
+	var emptySlot string
+	for _, uri := range uris {
+		body, err := c.get(uri)
+		if err != nil {
+			return "", false, err
+		}
+		var account RedfishAccount
+		if err := json.Unmarshal(body, &account); err != nil {
+			return "", false, fmt.Errorf("failed to decode account %s: %w", uri, err)
+		}
+		if account.UserName == cred.Username {
+			_, err := c.write(http.MethodPatch, uri, map[string]interface{}{"Password": cred.Password, "RoleId": role, "Enabled": true})
+			return uri, false, err
+		}
+		if account.UserName == "" && account.ID != "1" && emptySlot == "" {
+			emptySlot = uri
+		}
+	}
+
+	account := map[string]interface{}{"UserName": cred.Username, "Password": cred.Password, "RoleId": role, "Enabled": true}
+	resp, err := c.write(http.MethodPost, accountsURI, account)
+	var status *statusError
+	if errors.As(err, &status) && (status.Code == http.StatusMethodNotAllowed || status.Code == http.StatusNotImplemented) && emptySlot != "" {
+		_, err = c.write(http.MethodPatch, emptySlot, account)
+		return emptySlot, true, err
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimPrefix(resp.Header.Get("Location"), "/redfish/v1"), true, nil
+}
+
+// write sends value as the JSON body of a request to a Redfish path and
+// returns the response, whose body is closed. Any 2xx status succeeds.
+func (c *RedfishClient) write(method, path string, value interface{}) (*http.Response, error) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request for %s: %w", path, err)
+	}
+	targetURL, err := url.JoinPath(c.BaseURL, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join path: %w", err)
+	}
+	resp, err := c.send(method, targetURL, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Redfish errors say why, e.g. a password the BMC's policy rejects
+		var message bytes.Buffer
+		io.Copy(&message, io.LimitReader(resp.Body, 4096))
+		if msg := redfishErrorMessage(message.Bytes()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", &statusError{Code: resp.StatusCode, URL: targetURL}, msg)
+		}
+		return nil, &statusError{Code: resp.StatusCode, URL: targetURL}
+	}
+	return resp, nil
+}
+
+// redfishErrorMessage returns the message of a Redfish error response, or
+// "" if body is not one.
+func redfishErrorMessage(body []byte) string {
+	var response struct {
+		Error struct {
+			Message  string `json:"message"`
+			Extended []struct {
+				Message string `json:"Message"`
+			} `json:"@Message.ExtendedInfo"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &response) != nil {
+		return ""
+	}
+	if len(response.Error.Extended) > 0 && response.Error.Extended[0].Message != "" {
+		return response.Error.Extended[0].Message
+	}
+	return response.Error.Message
+}
+
+// GeneratePassword returns a random password of length characters with at
+// least one upper and lower case letter, digit and symbol.
+func GeneratePassword(length int) (string, error) {
+	if length < len(passwordClasses) {
+		return "", fmt.Errorf("passwords must be at least %d characters", len(passwordClasses))
+	}
+	all := strings.Join(passwordClasses, "")
+	password := make([]byte, length)
+	for i := range password {
+		// The first characters cover every class; the shuffle below hides
+		// where they are
+		chars := all
+		if i < len(passwordClasses) {
+			chars = passwordClasses[i]
+		}
+		n, err := randomInt(len(chars))
+		if err != nil {
+			return "", err
+		}
+		password[i] = chars[n]
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// randomInt returns a uniform random number in [0, n).
+func randomInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate a password: %w", err)
+	}
+	return int(v.Int64()), nil
+}
+
+// recordRotation patches the change of login into the endpoint's status.
+// Failing to record it is only a warning.
+func recordRotation(ctx context.Context, sdkClient *fabricaclient.Client, endpoint *bmcendpoint.BMCEndpoint, b BootstrapOptions, created bool) {
+	patch, err := json.Marshal(map[string]interface{}{"credential": bmcendpoint.CredentialRotation{
+		Account:   b.Account,
+		Store:     b.StoreName,
+		Created:   created,
+		RotatedAt: time.Now().UTC(),
+	}})
+	if err != nil {
+		fmt.Printf("Warning: Failed to encode credential rotation for BMCEndpoint %s: %v\n", endpoint.GetName(), err)
+		return
+	}
+	if _, err := sdkClient.PatchBMCEndpointStatus(ctx, endpoint.GetUID(), patch); err != nil {
+		fmt.Printf("Warning: Failed to record credential rotation for BMCEndpoint %s: %v\n", endpoint.GetName(), err)
+	}
+}
+
+// Print writes the summary as one line per BMC, then the totals.
+func (s *BootstrapSummary) Print(w io.Writer) {
+	for _, result := range s.Results {
+		if result.Error != "" {
+			fmt.Fprintf(w, "  FAILED  %-20s %s\n", result.Address, result.Error)
+			continue
+		}
+		action := "password reset"
+		if result.Created {
+			action = "account created"
+		}
+		recorded := "not registered"
+		if result.Endpoint != "" {
+			recorded = "recorded on " + result.Endpoint
+		}
+		fmt.Fprintf(w, "  OK      %-20s %s at %s, %s login, %s\n", result.Address, action, result.AccountURI, result.Auth, recorded)
+	}
+	fmt.Fprintf(w, "Bootstrapped %d of %d BMC(s), %d failed\n", s.Succeeded, s.Total, s.Failed)
+}
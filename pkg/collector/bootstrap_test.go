@@ -0,0 +1,199 @@
+package collector
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+)
+
+// memoryCredentials is a CredentialWriter keeping what it is given.
+type memoryCredentials struct {
+	stored map[string]Credential
+	err    error
+}
+
+func (m *memoryCredentials) Store(address string, cred Credential) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.stored[address] = cred
+	return nil
+}
+
+// bootstrapMock serves the default node, logging in with admin/admin, with
+// an empty account slot 2 when slot is set. It returns the server's address.
+func bootstrapMock(t *testing.T, slot bool, configure func(*redfishmock.Server)) string {
+	t.Helper()
+	resources := redfishmock.Fixture(redfishmock.DefaultNode("BOOT0001"))
+	if slot {
+		accounts := redfishmock.Root + "/AccountService/Accounts"
+		resources[accounts] = map[string]interface{}{
+			"Members": []map[string]string{
+				{"@odata.id": accounts + "/1"},
+				{"@odata.id": accounts + "/2"},
+			},
+			"Members@odata.count": 2,
+		}
+		resources[accounts+"/2"] = map[string]interface{}{"@odata.id": accounts + "/2", "Id": "2", "UserName": "", "Enabled": false}
+	}
+	mock, err := redfishmock.New(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.Username, mock.Password = "admin", "admin"
+	if configure != nil {
+		configure(mock)
+	}
+	server := httptest.NewTLSServer(mock)
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "https://")
+}
+
+// TestSetAccount sets the site account on BMCs that create accounts, that
+// have one already, and that only have fixed slots.
+func TestSetAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		username    string
+		slot        bool
+		configure   func(*redfishmock.Server)
+		wantURI     string
+		wantCreated bool
+		wantErr     string
+	}{
+		{name: "creates a missing account", username: "site", wantURI: "/AccountService/Accounts/101", wantCreated: true},
+		{name: "resets an existing account", username: "admin", wantURI: "/AccountService/Accounts/1"},
+		{
+			name: "fills an empty slot", username: "site", slot: true,
+			configure: func(s *redfishmock.Server) { s.FixedAccounts = true },
+			wantURI:   "/AccountService/Accounts/2", wantCreated: true,
+		},
+		{
+			name: "fails without a free slot", username: "site",
+			configure: func(s *redfishmock.Server) { s.FixedAccounts = true },
+			wantErr:   "405",
+		},
+		{
+			name: "reports the password policy", username: "site",
+			configure: func(s *redfishmock.Server) { s.MinPasswordLength = 64 },
+			wantErr:   "at least 64 characters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := bootstrapMock(t, tt.slot, tt.configure)
+			c, err := CollectOptions{}.redfishClient(address, Credential{Username: "admin", Password: "admin"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cred := Credential{Username: tt.username, Password: "new-password-1"}
+			uri, created, err := c.setAccount(cred, DefaultBootstrapRole)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("setAccount error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setAccount failed: %v", err)
+			}
+			if uri != tt.wantURI || created != tt.wantCreated {
+				t.Errorf("setAccount = %s, created %v; want %s, created %v", uri, created, tt.wantURI, tt.wantCreated)
+			}
+			if _, err := (CollectOptions{}).CheckCredential(address, cred); err != nil {
+				t.Errorf("the new login was refused: %v", err)
+			}
+		})
+	}
+}
+
+// TestBootstrapStoresAcceptedPasswords checks that a password is stored
+// only once the BMC has taken it.
+func TestBootstrapStoresAcceptedPasswords(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*redfishmock.Server)
+		storeErr  error
+		wantErr   string
+		wantStore bool
+	}{
+		{name: "stores the accepted password", wantStore: true},
+		{
+			name:      "keeps the stored login when the BMC refuses",
+			configure: func(s *redfishmock.Server) { s.MinPasswordLength = 64 },
+			wantErr:   "refused the new login",
+		},
+		{name: "reports a failed store", storeErr: errors.New("store is read-only"), wantErr: "could not be stored"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := bootstrapMock(t, false, tt.configure)
+			store := &memoryCredentials{stored: map[string]Credential{}, err: tt.storeErr}
+			result := &BootstrapResult{Address: address}
+			err := CollectOptions{}.bootstrapBMC(result, BootstrapOptions{
+				Factory:        Credential{Username: "admin", Password: "admin"},
+				Account:        "site",
+				Role:           DefaultBootstrapRole,
+				PasswordLength: DefaultPasswordLength,
+				Store:          store,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("bootstrap error = %v, want one containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("bootstrap failed: %v", err)
+			}
+			cred, stored := store.stored[address]
+			if stored != tt.wantStore {
+				t.Fatalf("stored = %v, want %v", stored, tt.wantStore)
+			}
+			if stored {
+				if cred.Username != "site" || len(cred.Password) != DefaultPasswordLength {
+					t.Errorf("stored %q with a %d character password", cred.Username, len(cred.Password))
+				}
+				if _, err := (CollectOptions{}).CheckCredential(address, cred); err != nil {
+					t.Errorf("the stored login was refused: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestGeneratePassword checks the length and character classes of
+// generated passwords.
+func TestGeneratePassword(t *testing.T) {
+	all := strings.Join(passwordClasses, "")
+	seen := map[string]bool{}
+	for _, length := range []int{len(passwordClasses), MinPasswordLength, DefaultPasswordLength, 64} {
+		for i := 0; i < 50; i++ {
+			password, err := GeneratePassword(length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(password) != length {
+				t.Fatalf("GeneratePassword(%d) returned %d characters", length, len(password))
+			}
+			for _, class := range passwordClasses {
+				if !strings.ContainsAny(password, class) {
+					t.Errorf("password %q has none of %q", password, class)
+				}
+			}
+			for _, r := range password {
+				if !strings.ContainsRune(all, r) {
+					t.Errorf("password %q has %q, outside the classes", password, r)
+				}
+			}
+			seen[password] = true
+		}
+	}
+	if len(seen) < 190 {
+		t.Errorf("only %d distinct passwords in 200", len(seen))
+	}
+	if _, err := GeneratePassword(len(passwordClasses) - 1); err == nil {
+		t.Error("GeneratePassword accepted a length too short for every class")
+	}
+}
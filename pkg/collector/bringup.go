@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+)
+
+// --- Bring-up Checks ---
+//
+// The checks "collector init" runs while a site configures the collector:
+// each tries one thing the collector needs and says what went wrong.
+
+// BMCProbe is what a BMC's Redfish service root tells without logging in.
+type BMCProbe struct {
+	Address        string
+	Vendor         string
+	Product        string
+	RedfishVersion string
+	// TrustedTLS is set when the BMC's certificate verifies against the
+	// system trust store and opts.CAFile; TLSError says why it did not.
+	TrustedTLS bool
+	TLSError   string
+}
+
+// ProbeBMC reads the service root of the BMC at address, which Redfish
+// serves without credentials, first verifying the BMC's certificate and
+// then, if that fails, without.
+func (opts CollectOptions) ProbeBMC(address string) (*BMCProbe, error) {
+	probe := &BMCProbe{Address: address, TrustedTLS: true}
+	verified := opts
+	verified.VerifyBMCTLS = true
+	body, err := probeServiceRoot(verified, address)
+	var verifyErr *tls.CertificateVerificationError
+	if errors.As(err, &verifyErr) {
+		probe.TrustedTLS = false
+		probe.TLSError = verifyErr.Err.Error()
+		unverified := opts
+		unverified.VerifyBMCTLS = false
+		body, err = probeServiceRoot(unverified, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var root RedfishServiceRoot
+	if err := json.Unmarshal(body, &root); err != nil {
+		return nil, fmt.Errorf("%s did not return a Redfish service root: %w", address, err)
+	}
+	probe.Vendor, probe.Product, probe.RedfishVersion = root.Vendor, root.Product, root.RedfishVersion
+	return probe, nil
+}
+
+func probeServiceRoot(opts CollectOptions, address string) ([]byte, error) {
+	c, err := opts.redfishClient(address, Credential{})
+	if err != nil {
+		return nil, err
+	}
+	return c.get("/")
+}
+
+// Redfish authentication methods CheckCredential reports.
+const (
+	AuthBasic   = "basic"
+	AuthSession = "session"
+)
+
+// CheckCredential reads the BMC's Systems collection with cred and returns
+// how the BMC accepted it: with basic auth or, for BMCs that refuse basic
+// auth, a session, which it deletes again.
+func (opts CollectOptions) CheckCredential(address string, cred Credential) (string, error) {
+	c, err := opts.redfishClient(address, cred)
+	if err != nil {
+		return "", err
+	}
+	_, err = c.get("/Systems")
+	if err == nil {
+		return AuthBasic, nil
+	}
+	var status *statusError
+	if !errors.As(err, &status) || (status.Code != http.StatusUnauthorized && status.Code != http.StatusForbidden) {
+		return "", err
+	}
+	if loginErr := c.login(); loginErr != nil {
+		return "", fmt.Errorf("%s refused the login (%v), and creating a session failed: %w", address, err, loginErr)
+	}
+	defer c.Logout()
+	if _, err := c.get("/Systems"); err != nil {
+		return "", fmt.Errorf("%s accepted a session but refused to list systems: %w", address, err)
+	}
+	return AuthSession, nil
+}
+
+// CheckAPI lists the BMCEndpoints registered in the inventory API at
+// opts.Server, as the agent does, and returns how many there are. It fails
+// when the API cannot be reached, its certificate is not trusted or it
+// refuses the collector.
+func (opts CollectOptions) CheckAPI(ctx context.Context) (int, error) {
+	sdkClient, err := opts.apiClient()
+	if err != nil {
+		return 0, err
+	}
+	endpoints, err := fabricaclient.BMCEndpoints(sdkClient).List(ctx, fabricaclient.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return len(endpoints), nil
+}
@@ -0,0 +1,242 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- CDU Discovery (ThermalEquipment / CoolingLoops) ---
+//
+// Coolant distribution units are mapped as:
+//
+//	Rack (from the CDU's Location.Placement)
+//	└── CDU
+//	    ├── Pump
+//	    └── CoolingLoop (loops whose SupplyEquipmentNames name the CDU)
+//
+// Services without ThermalEquipment (older HPE Cray EX CDU controllers) expose
+// the CDU as a Chassis instead; those are found by their ChassisType or name.
+
+// discoverCDUDevices walks /ThermalEquipment, falling back to CDU chassis.
+func discoverCDUDevices(c *RedfishClient) ([]*device.DeviceSpec, error) {
+	body, err := c.Get("/ThermalEquipment")
+	if err != nil {
+		fmt.Printf("Warning: ThermalEquipment not available (%v); looking for CDU chassis instead\n", err)
+		return discoverCDUChassis(c)
+	}
+	var equipment RedfishThermalEquipment
+	if err := json.Unmarshal(body, &equipment); err != nil {
+		return nil, fmt.Errorf("failed to decode ThermalEquipment: %w", err)
+	}
+	if equipment.CDUs.ODataID == "" {
+		return discoverCDUChassis(c)
+	}
+
+	cduURIs, err := getCollectionMembers(c, strings.TrimPrefix(equipment.CDUs.ODataID, "/redfish/v1"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CDUs collection: %w", err)
+	}
+
+	var specs []*device.DeviceSpec
+	racks := make(map[string]bool)
+	cdusByName := make(map[string]*device.DeviceSpec)
+	for _, cduURI := range cduURIs {
+		cduSpecs, rack, cduName, err := getCDUDevices(c, cduURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to map CDU %s: %v\n", cduURI, err)
+			continue
+		}
+		cdu := cduSpecs[0]
+		if rack != nil && !racks[rack.SerialNumber] {
+			racks[rack.SerialNumber] = true
+			specs = append(specs, rack)
+		}
+		if cduName != "" {
+			cdusByName[cduName] = cdu
+		}
+		specs = append(specs, cduSpecs...)
+	}
+
+	if equipment.CoolingLoops.ODataID != "" {
+		specs = append(specs, getCoolingLoopDevices(c, strings.TrimPrefix(equipment.CoolingLoops.ODataID, "/redfish/v1"), cdusByName)...)
+	}
+	return specs, nil
+}
+
+// getCDUDevices maps one CoolingUnit and its pumps. The CDU spec is always the
+// first element. It also returns the CDU's rack (nil when none is reported)
+// and its name for matching cooling loops.
+func getCDUDevices(c *RedfishClient, cduURI string) ([]*device.DeviceSpec, *device.DeviceSpec, string, error) {
+	body, err := c.Get(cduURI)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	var cdu RedfishCoolingUnit
+	if err := json.Unmarshal(body, &cdu); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to decode CDU: %w", err)
+	}
+
+	rackSerial := ""
+	rack := rackSpec(cdu.Location)
+	if rack != nil {
+		rackSerial = rack.SerialNumber
+	}
+	cduSpec := mapCommonProperties(cdu.CommonRedfishProperties, "CDU", cduURI, "", rackSerial)
+	setLocationProperties(cduSpec, cdu.Location)
+	if cdu.Name != "" {
+		setProperty(cduSpec, "name", cdu.Name)
+	}
+	if cdu.EquipmentType != "" {
+		setProperty(cduSpec, "equipment_type", cdu.EquipmentType)
+	}
+	if cdu.FirmwareVersion != "" {
+		setProperty(cduSpec, "firmware_version", cdu.FirmwareVersion)
+	}
+	if cdu.Coolant.CoolantType != "" {
+		setProperty(cduSpec, "coolant_type", cdu.Coolant.CoolantType)
+	}
+	if len(cdu.Oem) > 0 {
+		cduSpec.Properties["oem"] = cdu.Oem
+	}
+	specs := []*device.DeviceSpec{cduSpec}
+
+	if cdu.Pumps.ODataID != "" {
+		pumpURIs, err := getCollectionMembers(c, strings.TrimPrefix(cdu.Pumps.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve pumps from %s: %v\n", cdu.Pumps.ODataID, err)
+		}
+		for _, pumpURI := range pumpURIs {
+			pumpBody, err := c.Get(pumpURI)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get pump %s: %v\n", pumpURI, err)
+				continue
+			}
+			var pump RedfishPump
+			if err := json.Unmarshal(pumpBody, &pump); err != nil {
+				fmt.Printf("Warning: Failed to decode pump %s: %v\n", pumpURI, err)
+				continue
+			}
+			spec := mapCommonProperties(pump.CommonRedfishProperties, "Pump", pumpURI, cduURI, cdu.SerialNumber)
+			if spec.SerialNumber == "" {
+				spec.SerialNumber = componentSerial(cdu.SerialNumber, "pump", pump.ID)
+			}
+			if pump.PumpType != "" {
+				setProperty(spec, "pump_type", pump.PumpType)
+			}
+			specs = append(specs, spec)
+		}
+	}
+	return specs, rack, cdu.Name, nil
+}
+
+// getCoolingLoopDevices maps cooling loops, parenting each to the CDU that supplies it.
+func getCoolingLoopDevices(c *RedfishClient, loopsURI string, cdusByName map[string]*device.DeviceSpec) []*device.DeviceSpec {
+	loopURIs, err := getCollectionMembers(c, loopsURI)
+	if err != nil {
+		fmt.Printf("Warning: Failed to retrieve cooling loops from %s: %v\n", loopsURI, err)
+		return nil
+	}
+	var specs []*device.DeviceSpec
+	for _, loopURI := range loopURIs {
+		body, err := c.Get(loopURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get cooling loop %s: %v\n", loopURI, err)
+			continue
+		}
+		var loop RedfishCoolingLoop
+		if err := json.Unmarshal(body, &loop); err != nil {
+			fmt.Printf("Warning: Failed to decode cooling loop %s: %v\n", loopURI, err)
+			continue
+		}
+
+		spec := &device.DeviceSpec{DeviceType: "CoolingLoop"}
+		var supplierURI string
+		for _, name := range loop.SupplyEquipmentNames {
+			if cdu, ok := cdusByName[name]; ok {
+				spec.Manufacturer = cdu.Manufacturer
+				spec.ParentSerialNumber = cdu.SerialNumber
+				spec.SerialNumber = componentSerial(cdu.SerialNumber, "loop", loop.ID)
+				cdu.GetProperty("redfish_uri", &supplierURI)
+				break
+			}
+		}
+		setProperty(spec, "redfish_uri", loopURI)
+		setProperty(spec, "redfish_parent_uri", supplierURI)
+		if loop.Name != "" {
+			setProperty(spec, "name", loop.Name)
+		}
+		if loop.Coolant.CoolantType != "" {
+			setProperty(spec, "coolant_type", loop.Coolant.CoolantType)
+		}
+		if len(loop.SupplyEquipmentNames) > 0 {
+			setProperty(spec, "supply_equipment", loop.SupplyEquipmentNames)
+		}
+		if len(loop.ConsumingEquipmentNames) > 0 {
+			setProperty(spec, "consuming_equipment", loop.ConsumingEquipmentNames)
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// discoverCDUChassis maps CDUs that are only exposed as Chassis resources.
+func discoverCDUChassis(c *RedfishClient) ([]*device.DeviceSpec, error) {
+	chassisURIs, err := getCollectionMembers(c, "/Chassis")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Chassis collection: %w", err)
+	}
+	var specs []*device.DeviceSpec
+	racks := make(map[string]bool)
+	for _, chassisURI := range chassisURIs {
+		body, err := c.Get(chassisURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get chassis %s: %v\n", chassisURI, err)
+			continue
+		}
+		var chassis RedfishChassis
+		if err := json.Unmarshal(body, &chassis); err != nil {
+			fmt.Printf("Warning: Failed to decode chassis %s: %v\n", chassisURI, err)
+			continue
+		}
+		if !isCDUChassis(chassis) {
+			continue
+		}
+
+		rackSerial := ""
+		if rack := rackSpec(chassis.Location); rack != nil {
+			rackSerial = rack.SerialNumber
+			if !racks[rackSerial] {
+				racks[rackSerial] = true
+				specs = append(specs, rack)
+			}
+		}
+		spec := mapCommonProperties(chassis.CommonRedfishProperties, "CDU", chassisURI, "", rackSerial)
+		setLocationProperties(spec, chassis.Location)
+		if chassis.Name != "" {
+			setProperty(spec, "name", chassis.Name)
+		}
+		if len(chassis.Oem) > 0 {
+			spec.Properties["oem"] = chassis.Oem
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// isCDUChassis recognizes a CDU chassis by type, or by "CDU" in its name or model.
+func isCDUChassis(chassis RedfishChassis) bool {
+	if chassis.ChassisType == "CoolingUnit" {
+		return true
+	}
+	for _, field := range []string{chassis.Name, chassis.Model} {
+		for _, word := range strings.Fields(strings.ToUpper(field)) {
+			if word == "CDU" {
+				return true
+			}
+		}
+	}
+	return false
+}
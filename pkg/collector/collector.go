@@ -2,6 +2,7 @@
 package collector
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -10,13 +11,19 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path"
 	"reflect"
 	"strings"
 	"time"
 
 	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/discovery"
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
 	"github.com/example/inventory-v3/pkg/resources/device"
-	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/signing"
+	"github.com/example/inventory-v3/pkg/telemetry"
+	"github.com/example/inventory-v3/pkg/tlspolicy"
 )
 
 // --- Configuration ---
@@ -24,69 +31,289 @@ import (
 // InventoryAPIHost is the address of the Fabrica API server.
 const InventoryAPIHost = "http://localhost:8081" // Your server runs on 8081
 
-// DefaultUsername and DefaultPassword are hardcoded for Redfish basic auth.
+// DefaultUsername is the Redfish user name used when no credential store
+// has one. There is no default password: one must be given by a flag, the
+// environment, the config file or a credential store.
 const DefaultUsername = "root"
-const DefaultPassword = "initial0" // Make sure this is your correct password
 
 // --- Main Orchestration Function ---
 
-// CollectAndPost is the main function for the collector.
-func CollectAndPost(bmcIP string) error {
-	// 1. Initialize Redfish Client
-	rfClient, err := NewRedfishClient(bmcIP, DefaultUsername, DefaultPassword)
-	if err != nil {
-		return fmt.Errorf("failed to initialize Redfish client: %w", err)
-	}
+// CollectOptions controls how CollectAndPost discovers a BMC.
+// Empty fields fall back to the BMCEndpoint registered for the address, if
+// any, and then to the defaults.
+type CollectOptions struct {
+	// Backend selects the discovery backend (see Backends); "" uses DefaultBackend.
+	Backend string
+	// Profile selects the collection profile (quick, full, deep); "" uses ProfileFull.
+	Profile string
+	// Protocol is the management protocol of the BMC (see Protocol
+	// Detection); "" probes the protocols of ProbeOrder.
+	Protocol string
+	// ProbeOrder lists the protocols to probe; nil uses
+	// bmcendpoint.DefaultProbeOrder.
+	ProbeOrder []string
+
+	// Server is the inventory API URL; "" uses InventoryAPIHost.
+	Server string
+	// Credentials looks up the BMC login; nil uses the environment, with
+	// DefaultUsername for a missing user name.
+	Credentials CredentialStore
+	// CAFile adds trusted CA certificates to the system trust store.
+	CAFile string
+	// VerifyBMCTLS verifies BMC certificates instead of accepting any.
+	VerifyBMCTLS bool
+	// TLSPolicy applies to the BMC and inventory API connections.
+	TLSPolicy tlspolicy.Policy
+	// RedfishProxy is the URL of a caching Redfish proxy (see redfishproxy)
+	// to read BMCs through; "" reads them directly. Plugins talk to their
+	// equipment themselves and do not use it.
+	RedfishProxy string
+	// Signer signs the snapshots posted; nil posts them unsigned.
+	Signer *signing.Signer
+	// PropertyPolicy removes and hashes the device data the site may not
+	// store, before the snapshot is signed and posted; nil keeps it all.
+	PropertyPolicy *redact.Policy
+	// Priority is the reconciliation priority of the snapshots posted (see
+	// discoverysnapshot.Priorities); "" leaves them scheduled.
+	Priority string
+	// Record records the Redfish tree read as a fixture; the zero value
+	// records nothing.
+	Record RecordOptions
+	// RunID identifies the collection in the collector's and server's logs
+	// and in the snapshot's provenance; empty generates one.
+	RunID string
+	// APIRetry configures retries and circuit breaking of inventory API calls.
+	APIRetry fabricaclient.RetryPolicy
+	// APIHTTPClient, when set, is used for the inventory API instead of a
+	// client built per collection; see ShareAPIClient.
+	APIHTTPClient *http.Client
+	// ReprobeInterval is how often the subtrees the BMCEndpoint records as
+	// absent are probed again; 0 uses DefaultReprobeInterval, and a negative
+	// interval probes them every collection.
+	ReprobeInterval time.Duration
+	// TaskTimeout bounds how long the task monitor of a BMC answering 202
+	// Accepted is followed; 0 uses DefaultTaskTimeout.
+	TaskTimeout time.Duration
+	// MaxClockSkew is how far a BMC's clock may be off before it is flagged;
+	// 0 uses DefaultMaxClockSkew.
+	MaxClockSkew time.Duration
+	// Publish selects where snapshots go; the zero value posts them to the
+	// inventory API.
+	Publish PublishOptions
+
+	// endpointSettings looks up the address's BMCEndpoint even when not
+	// publishing to the API; the agent collects registered endpoints.
+	endpointSettings bool
+}
+
+// RunSummary is the outcome of a collection, as the collector prints it for
+// --template and --jsonpath.
+type RunSummary struct {
+	RunID   string `json:"runId"`
+	Address string `json:"address"`
+	Profile string `json:"profile,omitempty"`
+	// Protocol is the management protocol collected over, when known.
+	Protocol string `json:"protocol,omitempty"`
+	// Vendor is the BMC's vendor, when it was identified.
+	Vendor string `json:"vendor,omitempty"`
+	// Snapshot is the UID of the snapshot posted, or the reference the
+	// publish targets returned.
+	Snapshot string   `json:"snapshot"`
+	Targets  []string `json:"targets,omitempty"`
+	// Devices counts the devices reported, DeviceTypes by device type.
+	Devices     int            `json:"devices"`
+	DeviceTypes map[string]int `json:"deviceTypes"`
+	// Partial, Confidence and Failed give the coverage; Failed maps the
+	// Redfish paths that could not be read to their errors.
+	Partial    bool              `json:"partial,omitempty"`
+	Confidence float64           `json:"confidence"`
+	Failed     map[string]string `json:"failed,omitempty"`
+	// Phase and Message are the snapshot's status when the reconciler
+	// finished with it; they are only known when the collector waited (see
+	// Follow).
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+}
 
-	fmt.Println("Starting Redfish discovery...")
+// CollectAndPost is the main function for the collector: it runs the
+// RedfishDiscoverer and the default discovery.SnapshotMapper, and publishes
+// to the targets of opts.Publish. The outcome is recorded on the address's
+// BMCEndpoint, if one is registered.
+func CollectAndPost(bmcIP string, opts CollectOptions) error {
+	_, err := Collect(bmcIP, opts)
+	return err
+}
 
-	// --- 2. REDFISH DISCOVERY (Live Call) ---
-	deviceSpecs, err := discoverDevices(rfClient)
+// Collect is CollectAndPost returning a summary of the run.
+func Collect(bmcIP string, opts CollectOptions) (summary *RunSummary, err error) {
+	// 1. Initialize API client (the SDK), used for endpoint settings and posting
+	sdkClient, err := opts.apiClient()
 	if err != nil {
-		return fmt.Errorf("redfish discovery failed: %w", err)
+		return nil, fmt.Errorf("failed to create fabrica client: %w", err)
 	}
-	if len(deviceSpecs) == 0 {
-		return errors.New("redfish discovery found no devices to post")
+	if opts.RunID == "" {
+		opts.RunID = fabricaclient.NewRunID()
 	}
-	fmt.Printf("Redfish Discovery Complete: Found %d total devices.\n", len(deviceSpecs))
+	ctx := fabricaclient.WithRunID(context.Background(), opts.RunID)
 
-	// --- 3. PREPARE SNAPSHOT PAYLOAD ---
-	snapshotData, err := json.Marshal(deviceSpecs)
+	publisher, err := opts.publisher(sdkClient)
 	if err != nil {
-		return fmt.Errorf("failed to marshal device list into snapshot data: %w", err)
+		return nil, err
+	}
+	var endpoint *bmcendpoint.BMCEndpoint
+	if opts.Publish.UsesAPI() || opts.endpointSettings {
+		if opts, endpoint, err = resolveEndpointOptions(ctx, sdkClient, bmcIP, opts); err != nil {
+			return nil, err
+		}
+	}
+	var result *discovery.Result
+	var detected string
+	if endpoint != nil {
+		defer func() {
+			var shape *bmcendpoint.DiscoveryShape
+			if result != nil {
+				shape = result.Shape
+			}
+			recordCollection(ctx, sdkClient, endpoint, shape, detected, err)
+		}()
+	}
+	if opts, detected, err = opts.resolveProtocol(ctx, bmcIP, endpoint); err != nil {
+		return nil, err
+	}
+	discoverer := &RedfishDiscoverer{Options: opts}
+	if endpoint != nil {
+		discoverer.Shape = endpoint.Status.Shape
 	}
 
-	// --- 4. INITIALIZE API CLIENT (THE SDK) ---
-	sdkClient, err := fabricaclient.NewClient(InventoryAPIHost, nil)
+	// 2. Discover, map and publish the snapshot
+	mapper := discovery.SnapshotMapper{RunID: opts.RunID, Signer: opts.Signer, Version: telemetry.Version(), Priority: opts.Priority}
+	result, published, err := discovery.Run(ctx, bmcIP, discoverer, mapper, publisher)
 	if err != nil {
-		return fmt.Errorf("failed to create fabrica client: %w", err)
+		return nil, err
 	}
-	ctx := context.Background()
 
-	// --- 5. POST THE SNAPSHOT ---
-	fmt.Println("Creating new DiscoverySnapshot resource...")
+	if len(opts.Publish.Targets) == 0 {
+		fmt.Printf("Successfully created snapshot with UID: %s (run %s)\n", published, opts.RunID)
+		fmt.Println("The server reconciler will now process this snapshot.")
+	} else {
+		fmt.Printf("Successfully published snapshot to %v: %s (run %s)\n", opts.Publish.Targets, published, opts.RunID)
+	}
+	return summarize(result, published, opts), nil
+}
 
-	// Create the Spec for the new snapshot
-	snapshotSpec := discoverysnapshot.DiscoverySnapshotSpec{
-		RawData: json.RawMessage(snapshotData),
+func summarize(result *discovery.Result, published string, opts CollectOptions) *RunSummary {
+	summary := &RunSummary{
+		RunID:       opts.RunID,
+		Address:     result.Address,
+		Profile:     result.Profile,
+		Protocol:    opts.Protocol,
+		Vendor:      result.Vendor,
+		Snapshot:    published,
+		Targets:     opts.Publish.Targets,
+		Devices:     len(result.Devices),
+		DeviceTypes: map[string]int{},
+		Partial:     result.Coverage.Partial(),
+		Confidence:  result.Coverage.Confidence(),
+	}
+	if result.Coverage != nil {
+		summary.Failed = result.Coverage.Failed
 	}
+	for _, spec := range result.Devices {
+		summary.DeviceTypes[spec.DeviceType]++
+	}
+	return summary
+}
 
-	// The generated CreateDiscoverySnapshotRequest struct embeds the Spec struct
-	createReq := fabricaclient.CreateDiscoverySnapshotRequest{
-		Name:                  fmt.Sprintf("snapshot-%s-%d", bmcIP, time.Now().Unix()),
-		DiscoverySnapshotSpec: snapshotSpec, // Use the embedded struct
+// RedfishDiscoverer is the discovery.Discoverer of the collector's backends.
+// It walks the BMC with the backend and profile of its options, maps the
+// Redfish resources to DeviceSpecs and applies the device quirks and
+// redaction.
+type RedfishDiscoverer struct {
+	// Options supply the backend, profile, credentials and BMC TLS settings;
+	// the inventory API settings are not used.
+	Options CollectOptions
+	// Shape is the shape recorded by an earlier collection, whose absent
+	// subtrees are skipped until a re-probe is due; nil probes everything.
+	Shape *bmcendpoint.DiscoveryShape
+}
+
+var _ discovery.Discoverer = (*RedfishDiscoverer)(nil)
+
+// Discover implements discovery.Discoverer.
+func (d *RedfishDiscoverer) Discover(ctx context.Context, bmcIP string) (result *discovery.Result, err error) {
+	opts := d.Options
+	if !bmcendpoint.ValidProfile(opts.Profile) {
+		return nil, fmt.Errorf("unknown collection profile %q (valid: %v)", opts.Profile, bmcendpoint.Profiles)
+	}
+	discover, err := lookupBackend(opts.Backend)
+	if err != nil {
+		return nil, err
 	}
 
-	// Use the SDK to create the snapshot resource
-	createdSnapshot, err := sdkClient.CreateDiscoverySnapshot(ctx, createReq)
+	rfClient, err := opts.newRedfishClient(bmcIP)
 	if err != nil {
-		return fmt.Errorf("failed to create snapshot: %w", err)
+		return nil, fmt.Errorf("failed to initialize Redfish client: %w", err)
+	}
+	rfClient.SetProfile(opts.Profile)
+	defer func() { err = redact.Error(err, rfClient.Password) }()
+	// Each collection has its own transport; do not leave its connections
+	// open in a long-running agent
+	defer rfClient.HTTPClient.CloseIdleConnections()
+
+	if !IsPlugin(opts.Backend) {
+		if opts.Record.Store != nil {
+			// From the service root on, which identifies the BMC
+			rfClient.startCapture()
+		}
+		rfClient.Identify()
+		defer rfClient.Logout()
 	}
+	if d.Shape != nil {
+		rfClient.useShape(d.Shape, opts.ReprobeInterval)
+	}
+	fmt.Printf("Starting Redfish discovery (%s profile)...\n", rfClient.Profile)
 
-	fmt.Printf("Successfully created snapshot with UID: %s\n", createdSnapshot.Metadata.UID)
-	fmt.Println("The server reconciler will now process this snapshot.")
+	deviceSpecs, err := discover(rfClient)
+	if err != nil {
+		return nil, fmt.Errorf("redfish discovery failed: %w", err)
+	}
+	rfClient.applyDeviceQuirks(deviceSpecs)
+	if opts.Record.Store != nil && !IsPlugin(opts.Backend) {
+		if !opts.PropertyPolicy.Empty() {
+			// The fixture holds everything the policy removes
+			fmt.Println("Warning: Not recording a fixture, as a property policy is in effect.")
+		} else if name, err := rfClient.record(ctx, opts.Record); err != nil {
+			fmt.Printf("Warning: Failed to record fixture: %v\n", err)
+		} else {
+			fmt.Printf("Recorded fixture %s.\n", name)
+		}
+	}
+	if stripped := redactSpecs(deviceSpecs); stripped > 0 {
+		fmt.Printf("Redacted %d sensitive device properties.\n", stripped)
+	}
+	rawCapture := rfClient.RawCapture()
+	if policy := opts.PropertyPolicy; !policy.Empty() {
+		if changed := applyPolicy(policy, deviceSpecs); changed > 0 {
+			fmt.Printf("Property policy removed or hashed %d device fields.\n", changed)
+		}
+		if rawCapture != nil {
+			// The raw responses hold everything the policy removes
+			fmt.Println("Warning: Leaving out the raw capture, as a property policy is in effect.")
+			rawCapture = nil
+		}
+	}
+	rfClient.printFiredQuirks()
+	fmt.Printf("Redfish Discovery Complete: Found %d total devices.\n", len(deviceSpecs))
 
-	return nil
+	return &discovery.Result{
+		Address:    bmcIP,
+		Profile:    rfClient.Profile,
+		Vendor:     rfClient.Identity.Vendor,
+		Devices:    deviceSpecs,
+		Coverage:   rfClient.Coverage(),
+		RawCapture: rawCapture,
+		Shape:      rfClient.discoveredShape(d.Shape),
+	}, nil
 }
 
 // --- Redfish Client Struct and Methods ---
@@ -105,31 +332,157 @@ func NewRedfishClient(bmcIP, username, password string) (*RedfishClient, error)
 	}, nil
 }
 
-// Get makes an authenticated GET request to a Redfish path.
+// Get makes an authenticated GET request to a Redfish path. The outcome is
+// recorded in the client's coverage.
 func (c *RedfishClient) Get(path string) ([]byte, error) {
+	body, err := c.get(path)
+	c.noteRead(path, err)
+	return body, err
+}
+
+// get performs the request for Get.
+func (c *RedfishClient) get(path string) ([]byte, error) {
+	resp, err := c.open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if c.capture != nil {
+		c.capture[path] = redact.JSON(body)
+	}
+	return body, nil
+}
+
+// open sends an authenticated GET for a Redfish path and returns the
+// response, which must be 200 OK, following the task monitor of a 202
+// Accepted to it. The caller closes its body.
+func (c *RedfishClient) open(path string) (*http.Response, error) {
+	path, query, _ := strings.Cut(path, "?")
+	if q := c.hasQuirk(func(q *Quirk) bool { return q.TrailingSlash }); q != nil && !strings.HasSuffix(path, "/") {
+		path += "/"
+		c.noteQuirk(q)
+	}
 	targetURL, err := url.JoinPath(c.BaseURL, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to join path: %w", err)
 	}
-	req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if query != "" {
+		targetURL += "?" + query
+	}
+	resp, err := c.do(http.MethodGet, targetURL)
+	if err != nil {
+		return nil, err
+	}
+	// Sessions expire; log in again once when the service stops accepting ours
+	if resp.StatusCode == http.StatusUnauthorized {
+		if q := c.hasQuirk(func(q *Quirk) bool { return q.SessionAuth }); q != nil {
+			resp.Body.Close()
+			if err := c.login(); err != nil {
+				return nil, err
+			}
+			c.noteQuirk(q)
+			if resp, err = c.do(http.MethodGet, targetURL); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return c.followTask(resp, targetURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &statusError{Code: resp.StatusCode, URL: targetURL}
+	}
+	return resp, nil
+}
+
+// do sends a request with the client's credentials: the session token once
+// logged in, basic auth otherwise.
+func (c *RedfishClient) do(method, targetURL string) (*http.Response, error) {
+	return c.send(method, targetURL, nil)
+}
+
+// send is do with a JSON request body; a nil body sends none.
+func (c *RedfishClient) send(method, targetURL string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, targetURL, reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Redfish request for %s: %w", targetURL, err)
 	}
-	req.SetBasicAuth(c.Username, c.Password)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.sessionToken != "" {
+		req.Header.Set("X-Auth-Token", c.sessionToken)
+	} else {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
 	req.Header.Add("Accept", "application/json")
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute Redfish request for %s: %w", targetURL, err)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Redfish API returned status code %d for %s", resp.StatusCode, targetURL)
+	return resp, nil
+}
+
+// login creates a Redfish session and uses its token for later requests.
+func (c *RedfishClient) login() error {
+	c.Logout()
+	credentials, err := json.Marshal(map[string]string{"UserName": c.Username, "Password": c.Password})
+	if err != nil {
+		return fmt.Errorf("failed to encode session credentials: %w", err)
 	}
-	body, err := io.ReadAll(resp.Body)
+	targetURL, err := url.JoinPath(c.BaseURL, "/SessionService/Sessions")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return fmt.Errorf("failed to join path: %w", err)
 	}
-	return body, nil
+	req, err := http.NewRequest(http.MethodPost, targetURL, bytes.NewReader(credentials))
+	if err != nil {
+		return fmt.Errorf("failed to create session request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to create Redfish session: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Redfish API returned status code %d creating a session", resp.StatusCode)
+	}
+	c.sessionToken = resp.Header.Get("X-Auth-Token")
+	if c.sessionToken == "" {
+		return errors.New("Redfish session response has no X-Auth-Token")
+	}
+	c.sessionURI = resp.Header.Get("Location")
+	return nil
+}
+
+// Logout deletes the client's Redfish session, if it has one. BMCs allow few
+// concurrent sessions, so collectors log out rather than let them expire.
+func (c *RedfishClient) Logout() {
+	if c.sessionToken == "" {
+		return
+	}
+	if c.sessionURI != "" {
+		targetURL := c.sessionURI
+		if strings.HasPrefix(targetURL, "/") {
+			targetURL = strings.TrimSuffix(c.BaseURL, "/redfish/v1") + targetURL
+		}
+		if resp, err := c.do(http.MethodDelete, targetURL); err != nil {
+			fmt.Printf("Warning: Failed to delete Redfish session: %v\n", err)
+		} else {
+			resp.Body.Close()
+		}
+	}
+	c.sessionToken = ""
+	c.sessionURI = ""
 }
 
 // --- Redfish Discovery and Mapping Functions ---
@@ -138,15 +491,16 @@ func (c *RedfishClient) Get(path string) ([]byte, error) {
 func discoverDevices(c *RedfishClient) ([]*device.DeviceSpec, error) {
 	var specs []*device.DeviceSpec
 
-	systemsBody, err := c.Get("/Systems")
+	systemsCollection, err := c.getMembersPage("/Systems")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Systems collection: %w", err)
 	}
-	var systemsCollection RedfishCollection
-	if err := json.Unmarshal(systemsBody, &systemsCollection); err != nil {
-		return nil, fmt.Errorf("failed to decode Systems collection: %w", err)
-	}
 
+	// Load every system first: a DPU shows up both as a NetworkAdapter on its
+	// host and as a ComputerSystem of its own, and the two are merged below.
+	var systemURIs []string
+	systems := make(map[string]*RedfishSystem)
+	inventories := make(map[string]*SystemInventory)
 	for _, member := range systemsCollection.Members {
 		systemURI := strings.TrimPrefix(member.ODataID, "/redfish/v1")
 
@@ -166,12 +520,67 @@ func discoverDevices(c *RedfishClient) ([]*device.DeviceSpec, error) {
 			fmt.Printf("Warning: Failed to get inventory for system %s: %v\n", member.ODataID, err)
 			continue
 		}
+		systemURIs = append(systemURIs, systemURI)
+		systems[systemURI] = &systemData
+		inventories[systemURI] = systemInventory
+	}
+
+	hostedDPUs := findHostedDPUs(systemURIs, inventories)
+	for _, systemURI := range systemURIs {
+		if _, hosted := hostedDPUs[systemURI]; hosted {
+			continue // emitted as children of the hosting adapter
+		}
+		systemInventory := inventories[systemURI]
+		if isDPUSystem(systems[systemURI]) {
+			// A DPU seen without its host is still recorded as a DPU
+			systemInventory.NodeSpec.DeviceType = "DPU"
+			setDPUReferences(systemInventory.NodeSpec, systemURI, systems[systemURI])
+		}
 
 		// Add the Node's spec
 		specs = append(specs, systemInventory.NodeSpec)
 		// Add all child specs
 		specs = append(specs, systemInventory.CPUs...)
 		specs = append(specs, systemInventory.DIMMs...)
+		specs = append(specs, systemInventory.NICs...)
+		specs = append(specs, systemInventory.Drives...)
+		specs = append(specs, systemInventory.Fans...)
+		specs = append(specs, systemInventory.PSUs...)
+
+		// Fold each hosted DPU system into the adapter that carries it
+		for _, nic := range systemInventory.NICs {
+			dpuURI := offloadSystemURI(nic)
+			if dpuURI == "" || hostedDPUs[dpuURI] != nic {
+				continue
+			}
+			specs = append(specs, foldDPUSystem(nic, dpuURI, systems[dpuURI], inventories[dpuURI])...)
+		}
+	}
+
+	// The BMCs of the nodes, with their network configuration and clocks
+	if c.collectComponents() {
+		var nodeURIs []string
+		for _, systemURI := range systemURIs {
+			if _, hosted := hostedDPUs[systemURI]; !hosted {
+				nodeURIs = append(nodeURIs, systemURI)
+			}
+		}
+		specs = append(specs, getManagerDevices(c, nodeURIs, systems)...)
+	}
+
+	// Composable hardware: resource blocks and the systems composed from them
+	if c.collectComponents() {
+		specs = append(specs, getCompositionDevices(c, emittedURIs(specs))...)
+	}
+	// Deep profile: BIOS attributes, firmware inventory, and logs
+	if c.Profile == ProfileDeep {
+		nodes := make(map[string]*device.DeviceSpec)
+		for systemURI, inv := range inventories {
+			if _, hosted := hostedDPUs[systemURI]; !hosted {
+				nodes[systemURI] = inv.NodeSpec
+			}
+		}
+		collectDeepExtras(c, nodes, systems)
 	}
 	return specs, nil
 }
@@ -188,12 +597,34 @@ func getSystemInventory(c *RedfishClient, systemURI string, systemData *RedfishS
 		"", // Node has no parent URI
 		"", // Node has no parent Serial
 	)
+	if systemData.SystemType != "" {
+		setProperty(inv.NodeSpec, "system_type", systemData.SystemType)
+	}
+	if systemData.PowerState != "" {
+		setProperty(inv.NodeSpec, "power_state", systemData.PowerState)
+	}
+	if systemData.BiosVersion != "" {
+		setProperty(inv.NodeSpec, "bios_version", systemData.BiosVersion)
+	}
+	if blocks := linkPaths(systemData.Links.ResourceBlocks); len(blocks) > 0 {
+		setProperty(inv.NodeSpec, "resource_blocks", blocks)
+	}
+	enrichSystemOem(c, inv.NodeSpec, systemData)
+
+	// Get Network Adapters (NICs, InfiniBand HCAs, Slingshot NICs) from the linked chassis
+	inv.NICs = getNetworkAdapterDevices(c, systemData.Links.Chassis, systemURI, systemData.SerialNumber)
+	enrichNICOem(c, systemData, systemURI, inv.NICs)
+	// The quick profile stops at the node and its NIC addresses
+	if !c.collectComponents() {
+		return inv, nil
+	}
 
 	// Get Processors (CPUs)
-	if cpuCollectionURI := systemData.Processors.ODataID; cpuCollectionURI != "" {
-		cleanedURI := strings.TrimPrefix(cpuCollectionURI, "/redfish/v1")
+	if cleanedURI := c.probeLink(subtreeProcessors, systemData.Processors); cleanedURI != "" {
+		cpuCollectionURI := systemData.Processors.ODataID
 		// Pass the Node's Serial Number as the parent identifier
 		cpuDevices, err := getCollectionDevices(c, cleanedURI, "CPU", systemURI, systemData.SerialNumber, &RedfishProcessor{})
+		c.noteSubtree(subtreeProcessors, err)
 		if err != nil {
 			fmt.Printf("Warning: Failed to retrieve CPU inventory from %s: %v\n", cpuCollectionURI, err)
 		} else {
@@ -201,62 +632,184 @@ func getSystemInventory(c *RedfishClient, systemURI string, systemData *RedfishS
 		}
 	}
 	// Get Memory (DIMMs)
-	if dimmCollectionURI := systemData.Memory.ODataID; dimmCollectionURI != "" {
-		cleanedURI := strings.TrimPrefix(dimmCollectionURI, "/redfish/v1")
+	dimmLink := systemData.Memory
+	if q := c.hasQuirk(func(q *Quirk) bool { return q.MemoryPath != "" }); q != nil && dimmLink.ODataID == "" && !c.shape.skip[subtreeMemory] {
+		dimmLink.ODataID = systemURI + "/" + q.MemoryPath
+		c.noteQuirk(q)
+	}
+	if cleanedURI := c.probeLink(subtreeMemory, dimmLink); cleanedURI != "" {
+		dimmCollectionURI := dimmLink.ODataID
 		// Pass the Node's Serial Number as the parent identifier
 		dimmDevices, err := getCollectionDevices(c, cleanedURI, "DIMM", systemURI, systemData.SerialNumber, &RedfishMemory{})
+		c.noteSubtree(subtreeMemory, err)
 		if err != nil {
 			fmt.Printf("Warning: Failed to retrieve DIMM inventory from %s: %v\n", dimmCollectionURI, err)
 		} else {
 			inv.DIMMs = dimmDevices
 		}
 	}
+	// Get Drives from every Storage subsystem
+	if cleanedURI := c.probeLink(subtreeStorage, systemData.Storage); cleanedURI != "" {
+		storageCollectionURI := systemData.Storage.ODataID
+		driveDevices, err := getDriveDevices(c, cleanedURI, systemURI, systemData.SerialNumber)
+		c.noteSubtree(subtreeStorage, err)
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve drive inventory from %s: %v\n", storageCollectionURI, err)
+		} else {
+			inv.Drives = driveDevices
+		}
+	} else if cleanedURI := c.probeLink(subtreeSimpleStorage, systemData.SimpleStorage); cleanedURI != "" {
+		// Older firmware only implements SimpleStorage
+		simpleStorageURI := systemData.SimpleStorage.ODataID
+		driveDevices, err := getSimpleStorageDevices(c, cleanedURI, systemURI, systemData.SerialNumber)
+		c.noteSubtree(subtreeSimpleStorage, err)
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve drive inventory from %s: %v\n", simpleStorageURI, err)
+		} else {
+			inv.Drives = driveDevices
+		}
+	}
+	// Get Fans and Power Supplies (ThermalSubsystem/PowerSubsystem or legacy Thermal/Power)
+	var budget powerBudget
+	inv.Fans, inv.PSUs, budget = getChassisFanAndPowerDevices(c, systemData.Links.Chassis, systemURI, systemData.SerialNumber)
+	budget.setProperties(inv.NodeSpec)
 	return inv, nil
 }
 
 // getCollectionDevices retrieves a collection, iterates over members, and maps them.
 func getCollectionDevices(c *RedfishClient, collectionURI, deviceType, parentURI, parentSerial string, componentTypeExample interface{}) ([]*device.DeviceSpec, error) {
 	var specs []*device.DeviceSpec
-	collectionBody, err := c.Get(collectionURI)
+	memberURIs, err := getCollectionMembers(c, collectionURI)
 	if err != nil {
 		return nil, err
 	}
-	var collection RedfishCollection
-	if err := json.Unmarshal(collectionBody, &collection); err != nil {
-		return nil, fmt.Errorf("failed to decode collection from %s: %w", collectionURI, err)
-	}
-	for _, member := range collection.Members {
-		memberURI := strings.TrimPrefix(member.ODataID, "/redfish/v1")
-		memberBody, err := c.Get(memberURI)
+	for _, memberURI := range memberURIs {
+		component := reflect.New(reflect.TypeOf(componentTypeExample).Elem()).Interface()
+		memberBody, err := c.GetSelected(memberURI, component)
 		if err != nil {
-			fmt.Printf("Warning: Failed to get member %s: %v\n", member.ODataID, err)
+			fmt.Printf("Warning: Failed to get member %s: %v\n", memberURI, err)
 			continue
 		}
-		component := reflect.New(reflect.TypeOf(componentTypeExample).Elem()).Interface()
 		if err := json.Unmarshal(memberBody, &component); err != nil {
-			fmt.Printf("Warning: Failed to unmarshal component %s: %v\n", member.ODataID, err)
+			fmt.Printf("Warning: Failed to unmarshal component %s: %v\n", memberURI, err)
 			continue
 		}
 		rfProps := reflect.ValueOf(component).Elem().Field(0).Interface().(CommonRedfishProperties)
 
+		// Components may refine the collection's device type (e.g. GPU processors)
+		memberType := deviceType
+		if typed, ok := component.(typedComponent); ok && typed.deviceType() != "" {
+			memberType = typed.deviceType()
+		}
+
 		// Pass the parentSerial to mapCommonProperties
-		specs = append(specs, mapCommonProperties(rfProps, deviceType, memberURI, parentURI, parentSerial))
+		spec := mapCommonProperties(rfProps, memberType, memberURI, parentURI, parentSerial)
+		if mapper, ok := component.(extraPropertyMapper); ok {
+			for key, value := range mapper.extraProperties() {
+				setProperty(spec, key, value)
+			}
+		}
+		specs = append(specs, spec)
 	}
 	return specs, nil
 }
 
+// extraPropertyMapper is implemented by component types that carry attributes
+// beyond CommonRedfishProperties.
+type extraPropertyMapper interface {
+	extraProperties() map[string]interface{}
+}
+
+// typedComponent is implemented by component types whose members can map to
+// a different device type than the collection's.
+type typedComponent interface {
+	deviceType() string
+}
+
+// getCollectionMembers returns the member URIs of a collection, relative to /redfish/v1.
+// Paginated collections are followed through Members@odata.nextLink.
+func getCollectionMembers(c *RedfishClient, collectionURI string) ([]string, error) {
+	collection, err := c.getMembersPage(collectionURI)
+	if err != nil {
+		return nil, err
+	}
+	var uris []string
+	seen := make(map[string]bool)
+	for page := collection; ; {
+		added := 0
+		for _, member := range page.Members {
+			uri := strings.TrimPrefix(member.ODataID, "/redfish/v1")
+			if q := c.hasQuirk(func(q *Quirk) bool { return q.RelativeMembers }); q != nil && uri != "" && !strings.HasPrefix(uri, "/") {
+				uri = path.Join(strings.TrimPrefix(collectionURI, "/redfish/v1"), uri)
+				c.noteQuirk(q)
+			}
+			if !seen[uri] {
+				seen[uri] = true
+				uris = append(uris, uri)
+				added++
+			}
+		}
+		if added == 0 {
+			break // a page with nothing new (e.g. $skip ignored) ends the walk
+		}
+
+		nextURI := strings.TrimPrefix(page.NextLink, "/redfish/v1")
+		if nextURI == "" && collection.Count != nil && len(uris) < *collection.Count {
+			// Short page without a nextLink: ask for the rest with $skip
+			if q := c.hasQuirk(func(q *Quirk) bool { return q.PaginateWithSkip }); q != nil {
+				nextURI = fmt.Sprintf("%s?$skip=%d", collectionURI, len(uris))
+				c.noteQuirk(q)
+			}
+		}
+		if nextURI == "" {
+			break
+		}
+		if page, err = c.getMembersPage(nextURI); err != nil {
+			fmt.Printf("Warning: Failed to get next page %s: %v\n", nextURI, err)
+			break
+		}
+	}
+	return uris, nil
+}
+
+// getMembersPage fetches and decodes a single page of a collection,
+// streaming its members.
+func (c *RedfishClient) getMembersPage(uri string) (*RedfishCollection, error) {
+	var collection RedfishCollection
+	err := c.getStream(uri, func(dec *json.Decoder) error {
+		if err := decodeCollection(dec, &collection); err != nil {
+			return fmt.Errorf("failed to decode collection from %s: %w", uri, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// setProperty JSON-encodes value into the spec's Properties map.
+func setProperty(spec *device.DeviceSpec, key string, value interface{}) {
+	if err := spec.SetProperty(key, value); err != nil {
+		fmt.Printf("Warning: Failed to encode property %s: %v\n", key, err)
+	}
+}
+
 // mapCommonProperties maps Redfish fields to the API's DeviceSpec struct.
 func mapCommonProperties(rfProps CommonRedfishProperties, deviceType, redfishURI, parentURI, parentSerial string) *device.DeviceSpec {
-	partNum := rfProps.PartNumber
-	if partNum == "" {
-		partNum = rfProps.Model
-	}
+	partNum := firstNonEmpty(rfProps.PartNumber, rfProps.SparePartNumber, rfProps.Model)
 	uriBytes, _ := json.Marshal(redfishURI)
 	parentURIBytes, _ := json.Marshal(parentURI)
 	props := map[string]json.RawMessage{
 		"redfish_uri":        uriBytes,
 		"redfish_parent_uri": parentURIBytes,
 	}
+	if rfProps.Model != "" {
+		props["model"], _ = json.Marshal(rfProps.Model)
+	}
+	if label := rfProps.Location.PartLocation.ServiceLabel; label != "" {
+		props["location"], _ = json.Marshal(label)
+	}
 
 	return &device.DeviceSpec{
 		DeviceType:         deviceType,
@@ -266,4 +819,4 @@ func mapCommonProperties(rfProps CommonRedfishProperties, deviceType, redfishURI
 		Properties:         props,
 		ParentSerialNumber: parentSerial,
 	}
-}
\ No newline at end of file
+}
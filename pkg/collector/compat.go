@@ -0,0 +1,303 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Schema Version Compatibility ---
+//
+// Redfish 2020.4 replaced Thermal and Power with ThermalSubsystem and
+// PowerSubsystem, and Storage replaced SimpleStorage before that. Firmware in
+// the field serves either model (sometimes both), so each reader below tries
+// the modern model first and falls back to the legacy one. Callers only see
+// the schema-neutral records, plus the name of the model that answered.
+
+// fanRecord is a schema-neutral view of a fan.
+type fanRecord struct {
+	props CommonRedfishProperties
+	uri   string
+	id    string
+	name  string
+}
+
+// psuRecord is a schema-neutral view of a power supply.
+type psuRecord struct {
+	props         CommonRedfishProperties
+	uri           string
+	id            string
+	name          string
+	firmware      string
+	supplyType    string
+	capacityWatts *float64
+}
+
+// chassisPower is a schema-neutral view of a chassis' power supplies and capacity.
+type chassisPower struct {
+	budget   powerBudget
+	supplies []psuRecord
+	source   string
+}
+
+// readChassisFans returns the chassis' fans from ThermalSubsystem, or from Thermal.
+func readChassisFans(c *RedfishClient, chassis RedfishChassis) ([]fanRecord, string) {
+	if thermalURI := c.probeLink(subtreeThermalSubsystem, chassis.ThermalSubsystem); thermalURI != "" {
+		fans, err := readThermalSubsystemFans(c, thermalURI)
+		c.noteSubtree(subtreeThermalSubsystem, err)
+		if err == nil {
+			return fans, "ThermalSubsystem"
+		}
+		fmt.Printf("Warning: Failed to read ThermalSubsystem %s, trying Thermal: %v\n", chassis.ThermalSubsystem.ODataID, err)
+	}
+	if chassis.Thermal.ODataID != "" {
+		body, err := c.Get(strings.TrimPrefix(chassis.Thermal.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to get Thermal %s: %v\n", chassis.Thermal.ODataID, err)
+			return nil, ""
+		}
+		var thermal RedfishLegacyThermal
+		if err := json.Unmarshal(body, &thermal); err != nil {
+			fmt.Printf("Warning: Failed to decode Thermal %s: %v\n", chassis.Thermal.ODataID, err)
+			return nil, ""
+		}
+		fans := make([]fanRecord, 0, len(thermal.Fans))
+		for _, fan := range thermal.Fans {
+			fans = append(fans, fanRecord{
+				props: fan.CommonRedfishProperties,
+				uri:   strings.TrimPrefix(fan.ODataID, "/redfish/v1"),
+				id:    fan.MemberID,
+				name:  firstNonEmpty(fan.Name, fan.FanName),
+			})
+		}
+		return fans, "Thermal"
+	}
+	return nil, ""
+}
+
+func readThermalSubsystemFans(c *RedfishClient, thermalURI string) ([]fanRecord, error) {
+	body, err := c.Get(strings.TrimPrefix(thermalURI, "/redfish/v1"))
+	if err != nil {
+		return nil, err
+	}
+	var thermal RedfishThermalSubsystem
+	if err := json.Unmarshal(body, &thermal); err != nil {
+		return nil, fmt.Errorf("failed to decode ThermalSubsystem: %w", err)
+	}
+	if thermal.Fans.ODataID == "" {
+		return nil, nil
+	}
+	fanURIs, err := getCollectionMembers(c, strings.TrimPrefix(thermal.Fans.ODataID, "/redfish/v1"))
+	if err != nil {
+		return nil, err
+	}
+	var fans []fanRecord
+	for _, fanURI := range fanURIs {
+		fanBody, err := c.Get(fanURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get fan %s: %v\n", fanURI, err)
+			continue
+		}
+		var fan RedfishFan
+		if err := json.Unmarshal(fanBody, &fan); err != nil {
+			fmt.Printf("Warning: Failed to decode fan %s: %v\n", fanURI, err)
+			continue
+		}
+		fans = append(fans, fanRecord{props: fan.CommonRedfishProperties, uri: fanURI, id: fan.ID, name: fan.Name})
+	}
+	return fans, nil
+}
+
+// readChassisPower returns the chassis' power supplies and capacity from
+// PowerSubsystem, or from Power (PowerControl and PowerSupplies).
+func readChassisPower(c *RedfishClient, chassis RedfishChassis) chassisPower {
+	if powerURI := c.probeLink(subtreePowerSubsystem, chassis.PowerSubsystem); powerURI != "" {
+		power, err := readPowerSubsystem(c, powerURI)
+		c.noteSubtree(subtreePowerSubsystem, err)
+		if err == nil {
+			return power
+		}
+		fmt.Printf("Warning: Failed to read PowerSubsystem %s, trying Power: %v\n", chassis.PowerSubsystem.ODataID, err)
+	}
+	if chassis.Power.ODataID == "" {
+		return chassisPower{}
+	}
+	body, err := c.Get(strings.TrimPrefix(chassis.Power.ODataID, "/redfish/v1"))
+	if err != nil {
+		fmt.Printf("Warning: Failed to get Power %s: %v\n", chassis.Power.ODataID, err)
+		return chassisPower{}
+	}
+	var legacy RedfishLegacyPower
+	if err := json.Unmarshal(body, &legacy); err != nil {
+		fmt.Printf("Warning: Failed to decode Power %s: %v\n", chassis.Power.ODataID, err)
+		return chassisPower{}
+	}
+	power := chassisPower{source: "Power"}
+	if len(legacy.PowerControl) > 0 {
+		control := legacy.PowerControl[0]
+		power.budget = powerBudget{
+			capacityWatts:    control.PowerCapacityWatts,
+			allocatedWatts:   control.PowerAllocatedWatts,
+			limitWatts:       control.PowerLimit.LimitInWatts,
+			consumedWatts:    control.PowerConsumedWatts,
+			maxConsumedWatts: control.PowerMetrics.MaxConsumedWatts,
+		}
+		enabled := control.PowerLimit.LimitInWatts != nil
+		power.budget.limitEnabled = &enabled
+	}
+	for _, psu := range legacy.PowerSupplies {
+		power.supplies = append(power.supplies, psuRecord{
+			props:         psu.CommonRedfishProperties,
+			uri:           strings.TrimPrefix(psu.ODataID, "/redfish/v1"),
+			id:            psu.MemberID,
+			name:          psu.Name,
+			firmware:      psu.FirmwareVersion,
+			supplyType:    psu.PowerSupplyType,
+			capacityWatts: psu.PowerCapacityWatts,
+		})
+	}
+	return power
+}
+
+func readPowerSubsystem(c *RedfishClient, powerURI string) (chassisPower, error) {
+	body, err := c.Get(strings.TrimPrefix(powerURI, "/redfish/v1"))
+	if err != nil {
+		return chassisPower{}, err
+	}
+	var subsystem RedfishPowerSubsystem
+	if err := json.Unmarshal(body, &subsystem); err != nil {
+		return chassisPower{}, fmt.Errorf("failed to decode PowerSubsystem: %w", err)
+	}
+	power := chassisPower{
+		budget: powerBudget{capacityWatts: subsystem.CapacityWatts, allocatedWatts: subsystem.Allocation.AllocatedWatts},
+		source: "PowerSubsystem",
+	}
+	if subsystem.PowerSupplies.ODataID == "" {
+		return power, nil
+	}
+	psuURIs, err := getCollectionMembers(c, strings.TrimPrefix(subsystem.PowerSupplies.ODataID, "/redfish/v1"))
+	if err != nil {
+		return chassisPower{}, err
+	}
+	for _, psuURI := range psuURIs {
+		psuBody, err := c.Get(psuURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get power supply %s: %v\n", psuURI, err)
+			continue
+		}
+		var psu RedfishPowerSupply
+		if err := json.Unmarshal(psuBody, &psu); err != nil {
+			fmt.Printf("Warning: Failed to decode power supply %s: %v\n", psuURI, err)
+			continue
+		}
+		power.supplies = append(power.supplies, psuRecord{
+			props:         psu.CommonRedfishProperties,
+			uri:           psuURI,
+			id:            psu.ID,
+			name:          psu.Name,
+			firmware:      psu.FirmwareVersion,
+			supplyType:    psu.PowerSupplyType,
+			capacityWatts: psu.PowerCapacityWatts,
+		})
+	}
+	return power, nil
+}
+
+// getChassisFanAndPowerDevices maps the fans and power supplies of every chassis
+// linked to a system, and returns the power budget the chassis report.
+func getChassisFanAndPowerDevices(c *RedfishClient, chassisLinks []ODataLink, parentURI, parentSerial string) ([]*device.DeviceSpec, []*device.DeviceSpec, powerBudget) {
+	var fans, psus []*device.DeviceSpec
+	var budget powerBudget
+	for _, chassisLink := range chassisLinks {
+		chassisURI := strings.TrimPrefix(chassisLink.ODataID, "/redfish/v1")
+		body, err := c.Get(chassisURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get chassis %s: %v\n", chassisLink.ODataID, err)
+			continue
+		}
+		var chassis RedfishChassis
+		if err := json.Unmarshal(body, &chassis); err != nil {
+			fmt.Printf("Warning: Failed to decode chassis %s: %v\n", chassisURI, err)
+			continue
+		}
+
+		fanRecords, fanSource := readChassisFans(c, chassis)
+		for _, fan := range fanRecords {
+			spec := mapCommonProperties(fan.props, "Fan", fan.uri, parentURI, parentSerial)
+			if spec.SerialNumber == "" {
+				spec.SerialNumber = componentSerial(parentSerial, "fan", firstNonEmpty(fan.id, fan.name))
+			}
+			if fan.name != "" {
+				setProperty(spec, "name", fan.name)
+			}
+			setProperty(spec, "schema_source", fanSource)
+			fans = append(fans, spec)
+		}
+
+		power := readChassisPower(c, chassis)
+		readEnvironmentMetrics(c, chassis, &power.budget)
+		for _, psu := range power.supplies {
+			power.budget.psuRatedWatts = sumWatts(power.budget.psuRatedWatts, psu.capacityWatts)
+		}
+		budget.add(power.budget)
+		for _, psu := range power.supplies {
+			spec := mapCommonProperties(psu.props, "PSU", psu.uri, parentURI, parentSerial)
+			if spec.SerialNumber == "" {
+				spec.SerialNumber = componentSerial(parentSerial, "psu", firstNonEmpty(psu.id, psu.name))
+			}
+			if psu.name != "" {
+				setProperty(spec, "name", psu.name)
+			}
+			if psu.firmware != "" {
+				setProperty(spec, "firmware_version", psu.firmware)
+			}
+			if psu.supplyType != "" {
+				setProperty(spec, "power_supply_type", psu.supplyType)
+			}
+			if psu.capacityWatts != nil {
+				setProperty(spec, "power_capacity_watts", *psu.capacityWatts)
+			}
+			setProperty(spec, "schema_source", power.source)
+			psus = append(psus, spec)
+		}
+	}
+	return fans, psus, budget
+}
+
+// getSimpleStorageDevices maps the embedded devices of legacy SimpleStorage
+// resources as Drives, for systems that do not implement Storage.
+func getSimpleStorageDevices(c *RedfishClient, simpleStorageURI, parentURI, parentSerial string) ([]*device.DeviceSpec, error) {
+	controllerURIs, err := getCollectionMembers(c, simpleStorageURI)
+	if err != nil {
+		return nil, err
+	}
+	var specs []*device.DeviceSpec
+	for _, controllerURI := range controllerURIs {
+		body, err := c.Get(controllerURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get simple storage %s: %v\n", controllerURI, err)
+			continue
+		}
+		var storage RedfishSimpleStorage
+		if err := json.Unmarshal(body, &storage); err != nil {
+			fmt.Printf("Warning: Failed to decode simple storage %s: %v\n", controllerURI, err)
+			continue
+		}
+		for i, dev := range storage.Devices {
+			// Embedded devices have no URI of their own
+			uri := fmt.Sprintf("%s#/Devices/%d", controllerURI, i)
+			spec := mapCommonProperties(dev.CommonRedfishProperties, "Drive", uri, parentURI, parentSerial)
+			if dev.CapacityBytes > 0 {
+				setProperty(spec, "capacity_bytes", dev.CapacityBytes)
+			}
+			if dev.Name != "" {
+				setProperty(spec, "name", dev.Name)
+			}
+			setProperty(spec, "schema_source", "SimpleStorage")
+			specs = append(specs, spec)
+		}
+	}
+	return specs, nil
+}
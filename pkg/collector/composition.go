@@ -0,0 +1,244 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Composable Hardware (CompositionService) ---
+//
+// Disaggregated hardware is exposed as ResourceBlocks grouped into Zones.
+// Each block becomes a "ResourceBlock" device recording the systems composed
+// from it ("composed_systems"), and each composed Node records the blocks it
+// was built from ("resource_blocks"). Block members that are not already
+// inventoried through a composed system (free pool hardware) are parented to
+// their block.
+
+// getCompositionDevices maps the CompositionService, if the service has one.
+// emitted holds the redfish_uri of every device already discovered.
+func getCompositionDevices(c *RedfishClient, emitted map[string]bool) []*device.DeviceSpec {
+	if c.shape.skip[subtreeComposition] {
+		return nil
+	}
+	rootBody, err := c.Get("/")
+	if err != nil {
+		fmt.Printf("Warning: Failed to get service root: %v\n", err)
+		return nil
+	}
+	var root RedfishServiceRoot
+	if err := json.Unmarshal(rootBody, &root); err != nil {
+		return nil
+	}
+	serviceURI := c.probeLink(subtreeComposition, root.CompositionService)
+	if serviceURI == "" {
+		return nil
+	}
+
+	serviceBody, err := c.Get(serviceURI)
+	c.noteSubtree(subtreeComposition, err)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get CompositionService: %v\n", err)
+		return nil
+	}
+	var service RedfishCompositionService
+	if err := json.Unmarshal(serviceBody, &service); err != nil {
+		fmt.Printf("Warning: Failed to decode CompositionService: %v\n", err)
+		return nil
+	}
+	if service.ResourceBlocks.ODataID == "" {
+		return nil
+	}
+
+	blockZones := getBlockZones(c, service.ResourceZones)
+
+	blockURIs, err := getCollectionMembers(c, strings.TrimPrefix(service.ResourceBlocks.ODataID, "/redfish/v1"))
+	if err != nil {
+		fmt.Printf("Warning: Failed to retrieve resource blocks from %s: %v\n", service.ResourceBlocks.ODataID, err)
+		return nil
+	}
+	var specs []*device.DeviceSpec
+	for _, blockURI := range blockURIs {
+		blockSpecs, err := getResourceBlockDevices(c, blockURI, blockZones[blockURI], emitted)
+		if err != nil {
+			fmt.Printf("Warning: Failed to map resource block %s: %v\n", blockURI, err)
+			continue
+		}
+		specs = append(specs, blockSpecs...)
+	}
+	return specs
+}
+
+// getBlockZones maps each resource block URI to the names of the zones containing it.
+func getBlockZones(c *RedfishClient, zonesLink ODataLink) map[string][]string {
+	blockZones := make(map[string][]string)
+	if zonesLink.ODataID == "" {
+		return blockZones
+	}
+	zoneURIs, err := getCollectionMembers(c, strings.TrimPrefix(zonesLink.ODataID, "/redfish/v1"))
+	if err != nil {
+		fmt.Printf("Warning: Failed to retrieve resource zones from %s: %v\n", zonesLink.ODataID, err)
+		return blockZones
+	}
+	for _, zoneURI := range zoneURIs {
+		body, err := c.Get(zoneURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get zone %s: %v\n", zoneURI, err)
+			continue
+		}
+		var zone RedfishResourceZone
+		if err := json.Unmarshal(body, &zone); err != nil {
+			fmt.Printf("Warning: Failed to decode zone %s: %v\n", zoneURI, err)
+			continue
+		}
+		name := firstNonEmpty(zone.Name, zone.ID)
+		for _, block := range zone.Links.ResourceBlocks {
+			blockURI := strings.TrimPrefix(block.ODataID, "/redfish/v1")
+			blockZones[blockURI] = append(blockZones[blockURI], name)
+		}
+	}
+	return blockZones
+}
+
+// getResourceBlockDevices maps one ResourceBlock and any members not yet inventoried.
+func getResourceBlockDevices(c *RedfishClient, blockURI string, zones []string, emitted map[string]bool) ([]*device.DeviceSpec, error) {
+	body, err := c.Get(blockURI)
+	if err != nil {
+		return nil, err
+	}
+	var block RedfishResourceBlock
+	if err := json.Unmarshal(body, &block); err != nil {
+		return nil, fmt.Errorf("failed to decode resource block: %w", err)
+	}
+
+	// Blocks have no serial number; derive one so members can link to the block
+	blockSpec := &device.DeviceSpec{
+		DeviceType:   "ResourceBlock",
+		SerialNumber: "resource-block:" + blockURI,
+	}
+	setProperty(blockSpec, "redfish_uri", blockURI)
+	setProperty(blockSpec, "redfish_parent_uri", "")
+	if block.Name != "" {
+		setProperty(blockSpec, "name", block.Name)
+	}
+	if len(block.ResourceBlockType) > 0 {
+		setProperty(blockSpec, "block_types", block.ResourceBlockType)
+	}
+	status := block.CompositionStatus
+	if status.CompositionState != "" {
+		setProperty(blockSpec, "composition_state", status.CompositionState)
+	}
+	if status.Reserved != nil {
+		setProperty(blockSpec, "reserved", *status.Reserved)
+	}
+	if status.SharingCapable != nil {
+		setProperty(blockSpec, "sharing_capable", *status.SharingCapable)
+	}
+	if status.NumberOfCompositions != nil {
+		setProperty(blockSpec, "number_of_compositions", *status.NumberOfCompositions)
+	}
+	if len(zones) > 0 {
+		setProperty(blockSpec, "zones", zones)
+	}
+	if composed := linkPaths(block.Links.ComputerSystems); len(composed) > 0 {
+		setProperty(blockSpec, "composed_systems", composed)
+	}
+	if contained := linkPaths(block.ComputerSystems); len(contained) > 0 {
+		setProperty(blockSpec, "contained_systems", contained)
+	}
+	specs := []*device.DeviceSpec{blockSpec}
+
+	// Free pool members, parented to the block
+	for _, member := range []struct {
+		links      []ODataLink
+		deviceType string
+	}{
+		{block.Processors, "CPU"},
+		{block.Memory, "DIMM"},
+	} {
+		for _, uri := range linkPaths(member.links) {
+			if emitted[uri] {
+				continue
+			}
+			spec, err := getBlockMemberDevice(c, uri, member.deviceType, blockURI, blockSpec.SerialNumber)
+			if err != nil {
+				fmt.Printf("Warning: Failed to map block member %s: %v\n", uri, err)
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+	for _, uri := range linkPaths(block.Drives) {
+		if emitted[uri] {
+			continue
+		}
+		spec, err := getDriveDevice(c, uri, blockURI, blockSpec.SerialNumber)
+		if err != nil {
+			fmt.Printf("Warning: Failed to map block drive %s: %v\n", uri, err)
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	for _, storageURI := range linkPaths(block.Storage) {
+		storageBody, err := c.Get(storageURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get block storage %s: %v\n", storageURI, err)
+			continue
+		}
+		var storage RedfishStorage
+		if err := json.Unmarshal(storageBody, &storage); err != nil {
+			fmt.Printf("Warning: Failed to decode block storage %s: %v\n", storageURI, err)
+			continue
+		}
+		for _, uri := range linkPaths(storage.Drives) {
+			if emitted[uri] {
+				continue
+			}
+			spec, err := getDriveDevice(c, uri, blockURI, blockSpec.SerialNumber)
+			if err != nil {
+				fmt.Printf("Warning: Failed to map block drive %s: %v\n", uri, err)
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+	return specs, nil
+}
+
+// getBlockMemberDevice maps a single processor or memory member of a block.
+func getBlockMemberDevice(c *RedfishClient, uri, deviceType, blockURI, blockSerial string) (*device.DeviceSpec, error) {
+	body, err := c.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	var props CommonRedfishProperties
+	if err := json.Unmarshal(body, &props); err != nil {
+		return nil, fmt.Errorf("failed to decode block member: %w", err)
+	}
+	return mapCommonProperties(props, deviceType, uri, blockURI, blockSerial), nil
+}
+
+// linkPaths converts links to paths relative to /redfish/v1.
+func linkPaths(links []ODataLink) []string {
+	paths := make([]string, 0, len(links))
+	for _, link := range links {
+		if link.ODataID != "" {
+			paths = append(paths, strings.TrimPrefix(link.ODataID, "/redfish/v1"))
+		}
+	}
+	return paths
+}
+
+// emittedURIs returns the set of redfish_uri values in specs.
+func emittedURIs(specs []*device.DeviceSpec) map[string]bool {
+	uris := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		var uri string
+		if spec.GetProperty("redfish_uri", &uri) && uri != "" {
+			uris[uri] = true
+		}
+	}
+	return uris
+}
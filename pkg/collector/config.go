@@ -0,0 +1,156 @@
+package collector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/redfishproxy"
+)
+
+// --- Collector Configuration ---
+
+// configDirName is the collector's directory inside the user config directory.
+const configDirName = "inventory-v3"
+
+// ConfigDir returns the collector's configuration directory: inventory-v3 in
+// the user config directory (~/.config on Linux, ~/Library/Application
+// Support on macOS, %AppData% on Windows).
+func ConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+	return filepath.Join(dir, configDirName), nil
+}
+
+// DefaultPluginDir returns the plugins directory inside ConfigDir, or "" when
+// there is no config directory.
+func DefaultPluginDir() string {
+	dir, err := ConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "plugins")
+}
+
+// certPool returns the operating system's trust store (the Keychain on macOS,
+// the certificate store on Windows) with the certificates of caFile added.
+func certPool(caFile string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if caFile == "" {
+		return pool, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+	}
+	return pool, nil
+}
+
+// apiHTTPClient returns the HTTP client for the inventory API, verifying its
+// certificate against the system trust store and opts.CAFile, under the TLS
+// policy. Failed and throttled requests are retried under opts.APIRetry, and
+// requests carry the run ID of their context.
+func (opts CollectOptions) apiHTTPClient() (*http.Client, error) {
+	if opts.APIHTTPClient != nil {
+		return opts.APIHTTPClient, nil
+	}
+	pool, err := certPool(opts.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: opts.TLSPolicy.Transport(&tls.Config{RootCAs: pool})}
+	return fabricaclient.WithRunIDHeader(fabricaclient.WithRetryPolicy(httpClient, opts.APIRetry)), nil
+}
+
+// ShareAPIClient returns the options with the inventory API HTTP client
+// built once, so that every collection made with them shares its
+// connections and circuit breaker.
+func (opts CollectOptions) ShareAPIClient() (CollectOptions, error) {
+	httpClient, err := opts.apiHTTPClient()
+	if err != nil {
+		return opts, err
+	}
+	opts.APIHTTPClient = httpClient
+	return opts, nil
+}
+
+// apiClient returns the SDK client for the inventory API at opts.Server.
+func (opts CollectOptions) apiClient() (*fabricaclient.Client, error) {
+	server := opts.Server
+	if server == "" {
+		server = InventoryAPIHost
+	}
+	httpClient, err := opts.apiHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return fabricaclient.NewClient(server, httpClient)
+}
+
+// newRedfishClient creates the Redfish client for a BMC, with the credential
+// found in opts.Credentials and the TLS verification and policy opts asks for.
+func (opts CollectOptions) newRedfishClient(address string) (*RedfishClient, error) {
+	store := opts.Credentials
+	if store == nil {
+		store = credentialChain{EnvCredentials{}}
+	}
+	cred, err := store.Lookup(address)
+	if err != nil {
+		return nil, err
+	}
+	return opts.redfishClient(address, cred)
+}
+
+// redfishClient creates the Redfish client for a BMC with the given
+// credential.
+func (opts CollectOptions) redfishClient(address string, cred Credential) (*RedfishClient, error) {
+	c, err := NewRedfishClient(address, cred.Username, cred.Password)
+	if err != nil {
+		return nil, err
+	}
+	if opts.RedfishProxy != "" && !IsPlugin(opts.Backend) {
+		// The proxy is a site service, verified as the inventory API is
+		c.BaseURL = strings.TrimSuffix(opts.RedfishProxy, "/") + redfishproxy.PathPrefix + address + "/redfish/v1"
+		pool, err := certPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		c.HTTPClient = &http.Client{Transport: opts.TLSPolicy.Transport(&tls.Config{RootCAs: pool})}
+	} else {
+		transport, err := opts.BMCTransport()
+		if err != nil {
+			return nil, err
+		}
+		c.HTTPClient = &http.Client{Transport: transport}
+	}
+	c.TaskTimeout = opts.TaskTimeout
+	c.MaxClockSkew = opts.MaxClockSkew
+	return c, nil
+}
+
+// BMCTransport returns the transport for BMC connections, which accepts any
+// certificate unless opts.VerifyBMCTLS, under the TLS policy.
+func (opts CollectOptions) BMCTransport() (*http.Transport, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	if opts.VerifyBMCTLS {
+		pool, err := certPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Transport{TLSClientConfig: opts.TLSPolicy.Apply(tlsConfig)}, nil
+}
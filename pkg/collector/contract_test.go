@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/internal/redfishmock"
+	"github.com/example/inventory-v3/pkg/discovery"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden payloads in internal/contract")
+
+// TestGoldenPayloads collects each contract fixture from a mock BMC and
+// compares the snapshot's RawData with the committed golden payload.
+func TestGoldenPayloads(t *testing.T) {
+	for _, fixture := range contract.Fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			got := collectFixture(t, fixture)
+			if *update {
+				if err := os.WriteFile(contract.PayloadFile(fixture.Name), got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := contract.Payload(fixture.Name)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("RawData of %s no longer matches the golden payload; if the change is intended, rerun with -update and check the reconciler tests.\ngot:\n%s", fixture.Name, got)
+			}
+		})
+	}
+}
+
+// collectFixture returns the indented RawData the default mapper produces
+// for a fixture, with the mock's address replaced by "bmc".
+func collectFixture(t *testing.T, fixture contract.Fixture) []byte {
+	t.Helper()
+	mock, err := redfishmock.New(redfishmock.Fixture(fixture.Node))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.Username, mock.Password = "admin", "admin"
+	server := httptest.NewTLSServer(mock)
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	opts := CollectOptions{
+		Profile:     fixture.Profile,
+		Credentials: ConfigCredentials{Default: Credential{Username: "admin", Password: "admin"}},
+	}
+	result, err := (&RedfishDiscoverer{Options: opts}).Discover(context.Background(), address)
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	if result.Coverage.Partial() {
+		t.Fatalf("discovery was partial: %v", result.Coverage.Failed)
+	}
+	spec, err := discovery.SnapshotMapper{Collector: "contract"}.Map(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, spec.RawData, "", "  "); err != nil {
+		t.Fatal(err)
+	}
+	indented.WriteByte('\n')
+	return bytes.ReplaceAll(indented.Bytes(), []byte(address), []byte("bmc"))
+}
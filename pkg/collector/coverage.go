@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// --- Collection Coverage ---
+//
+// A BMC can fail parts of a collection (Memory returning 500s while the rest
+// of the system reads fine). The client records every read so the snapshot
+// says which subtrees are missing; the reconciler then leaves the devices
+// under them alone instead of flagging them as gone.
+
+// statusError is returned by Get for a response other than 200 OK.
+type statusError struct {
+	Code int
+	URL  string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("Redfish API returned status code %d for %s", e.Code, e.URL)
+}
+
+// notImplemented reports whether err means the BMC has no such resource,
+// which is not a collection failure.
+func notImplemented(err error) bool {
+	var status *statusError
+	if !errors.As(err, &status) {
+		return false
+	}
+	switch status.Code {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// noteRead records the outcome of a read of path in the client's coverage.
+func (c *RedfishClient) noteRead(path string, err error) {
+	if notImplemented(err) {
+		return
+	}
+	if c.coverage == nil {
+		c.coverage = &discoverysnapshot.Coverage{}
+	}
+	c.coverage.Reads++
+	if err == nil {
+		return
+	}
+	path, _, _ = strings.Cut(path, "?")
+	if c.coverage.Failed == nil {
+		c.coverage.Failed = make(map[string]string)
+	}
+	c.coverage.Failed[path] = err.Error()
+}
+
+// Coverage returns the reads recorded since the profile was set, or nil when
+// the client made none (plugins talk to their equipment themselves).
+func (c *RedfishClient) Coverage() *discoverysnapshot.Coverage {
+	return c.coverage
+}
@@ -0,0 +1,248 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// --- BMC Credentials ---
+//
+// The collector looks up the username and password of each BMC in a
+// credential store. Field engineers running discovery from a laptop keep BMC
+// passwords in the operating system's store (macOS Keychain, Windows
+// Credential Manager, or the Secret Service on Linux) rather than in files.
+
+// Credential store names.
+const (
+	// CredentialStoreConfig reads credentials from the collector configuration.
+	CredentialStoreConfig = "config"
+	// CredentialStoreEnv reads COLLECTOR_BMC_USERNAME and COLLECTOR_BMC_PASSWORD.
+	CredentialStoreEnv = "env"
+	// CredentialStoreSystem reads the operating system's credential store,
+	// under the name "inventory-v3/<address>".
+	CredentialStoreSystem = "system"
+	// CredentialStoreVault reads a HashiCorp Vault secret per BMC.
+	CredentialStoreVault = "vault"
+)
+
+// CredentialStores lists the valid credential store names.
+var CredentialStores = []string{CredentialStoreConfig, CredentialStoreEnv, CredentialStoreSystem, CredentialStoreVault}
+
+// credentialTargetPrefix prefixes the BMC address in system store entries.
+const credentialTargetPrefix = "inventory-v3/"
+
+// ErrNoCredential is returned by stores that hold no credential for an address.
+var ErrNoCredential = errors.New("no credential stored")
+
+// Credential is the login of one BMC. An empty Username means the store only
+// holds the password.
+type Credential struct {
+	Username string `json:"username,omitempty" mapstructure:"username"`
+	Password string `json:"password,omitempty" mapstructure:"password"`
+}
+
+// CredentialStore looks up the credential of a BMC address.
+type CredentialStore interface {
+	Lookup(address string) (Credential, error)
+}
+
+// CredentialWriter stores the credential of a BMC address, for commands
+// that change BMC logins.
+type CredentialWriter interface {
+	Store(address string, cred Credential) error
+}
+
+// ConfigCredentials holds credentials from the collector configuration or a
+// credentials file: one per BMC address or network (CIDR), and a default for
+// the rest.
+type ConfigCredentials struct {
+	Default   Credential
+	Addresses map[string]Credential
+}
+
+// Lookup returns the address's credential: the one given for the address
+// itself, with or without its port or IPv6 brackets, else the one of the
+// narrowest network holding it, else the default. Of networks equally narrow,
+// the one first in key order is taken.
+func (s ConfigCredentials) Lookup(address string) (Credential, error) {
+	if cred, ok := s.Addresses[address]; ok {
+		return cred, nil
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		host = h
+	}
+	if cred, ok := s.Addresses[host]; ok {
+		return cred, nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		var found *Credential
+		var foundKey string
+		bits := -1
+		for key, cred := range s.Addresses {
+			_, network, err := net.ParseCIDR(key)
+			if err != nil || !network.Contains(ip) {
+				continue
+			}
+			if ones, _ := network.Mask.Size(); ones > bits || (ones == bits && key < foundKey) {
+				cred := cred
+				found, foundKey, bits = &cred, key, ones
+			}
+		}
+		if found != nil {
+			return *found, nil
+		}
+	}
+	if s.Default.Password != "" {
+		return s.Default, nil
+	}
+	return Credential{}, ErrNoCredential
+}
+
+// hasPassword reports whether any of the credentials holds a password.
+func (s ConfigCredentials) hasPassword() bool {
+	if s.Default.Password != "" {
+		return true
+	}
+	for _, cred := range s.Addresses {
+		if cred.Password != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvCredentials reads the same credential for every BMC from the environment.
+type EnvCredentials struct{}
+
+// Lookup returns COLLECTOR_BMC_USERNAME and COLLECTOR_BMC_PASSWORD, or the
+// INVENTORY_BMC_USERNAME and INVENTORY_BMC_PASSWORD they replace.
+func (EnvCredentials) Lookup(address string) (Credential, error) {
+	cred := Credential{
+		Username: firstEnv("COLLECTOR_BMC_USERNAME", "INVENTORY_BMC_USERNAME"),
+		Password: firstEnv("COLLECTOR_BMC_PASSWORD", "INVENTORY_BMC_PASSWORD"),
+	}
+	if cred.Password == "" {
+		return Credential{}, ErrNoCredential
+	}
+	return cred, nil
+}
+
+// firstEnv returns the first of the environment variables that is set.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// staticCredential gives the same, possibly partial, credential for every
+// BMC.
+type staticCredential Credential
+
+// Lookup returns the credential.
+func (s staticCredential) Lookup(address string) (Credential, error) {
+	if s == (staticCredential{}) {
+		return Credential{}, ErrNoCredential
+	}
+	return Credential(s), nil
+}
+
+// WithLogin returns the store with cred, as given on the command line,
+// taking precedence for every BMC. A field cred leaves empty is looked up in
+// store.
+func WithLogin(store CredentialStore, cred Credential) CredentialStore {
+	if cred == (Credential{}) {
+		return store
+	}
+	return credentialChain{staticCredential(cred), store}
+}
+
+// ChainCredentials returns a store that tries each store in turn, as
+// NewCredentialStore's do, so that a store can be put in front of another.
+func ChainCredentials(stores ...CredentialStore) CredentialStore {
+	return credentialChain(stores)
+}
+
+// SystemCredentials reads the operating system's credential store.
+type SystemCredentials struct{}
+
+// Lookup returns the credential stored as "inventory-v3/<address>".
+func (SystemCredentials) Lookup(address string) (Credential, error) {
+	return lookupSystemCredential(credentialTargetPrefix + address)
+}
+
+// Store writes the credential as "inventory-v3/<address>".
+func (SystemCredentials) Store(address string, cred Credential) error {
+	return storeSystemCredential(credentialTargetPrefix+address, cred)
+}
+
+// NewCredentialStore returns the named store ("" selects the environment,
+// then the configuration). Every store falls back to the configuration for a
+// missing username or password, and then to DefaultUsername; a BMC without a
+// password fails to look up. The Vault store, which vault configures, is the
+// exception: sites use it to keep BMC passwords out of files and the
+// environment, so it is the only source of passwords, and a password in the
+// configuration or the environment is refused.
+func NewCredentialStore(name string, config ConfigCredentials, vault VaultOptions) (CredentialStore, error) {
+	switch name {
+	case "":
+		return credentialChain{EnvCredentials{}, config}, nil
+	case CredentialStoreConfig:
+		return credentialChain{config}, nil
+	case CredentialStoreEnv:
+		return credentialChain{EnvCredentials{}, config}, nil
+	case CredentialStoreSystem:
+		return credentialChain{SystemCredentials{}, config}, nil
+	case CredentialStoreVault:
+		if config.hasPassword() || firstEnv("COLLECTOR_BMC_PASSWORD", "INVENTORY_BMC_PASSWORD") != "" {
+			return nil, errors.New("the vault credential store takes BMC passwords only from Vault; remove the passwords from the config file and COLLECTOR_BMC_PASSWORD")
+		}
+		store, err := NewVaultCredentials(vault)
+		if err != nil {
+			return nil, err
+		}
+		return credentialChain{store}, nil
+	}
+	return nil, fmt.Errorf("unknown credential store %q (valid: %v)", name, CredentialStores)
+}
+
+// credentialChain tries each store in turn. The first password found wins;
+// a username missing from its store is taken from the later stores.
+type credentialChain []CredentialStore
+
+// Lookup returns the first credential found, completed from the later stores
+// and DefaultUsername.
+func (chain credentialChain) Lookup(address string) (Credential, error) {
+	var found Credential
+	for _, store := range chain {
+		cred, err := store.Lookup(address)
+		if errors.Is(err, ErrNoCredential) {
+			continue
+		}
+		if err != nil {
+			return Credential{}, fmt.Errorf("failed to look up credentials for %s: %w", address, err)
+		}
+		if found.Password == "" {
+			found.Password = cred.Password
+		}
+		if found.Username == "" {
+			found.Username = cred.Username
+		}
+		if found.Password != "" && found.Username != "" {
+			break
+		}
+	}
+	if found.Password == "" {
+		return Credential{}, fmt.Errorf("%w for %s: give --password, COLLECTOR_BMC_PASSWORD or a credential store", ErrNoCredential, address)
+	}
+	if found.Username == "" {
+		found.Username = DefaultUsername
+	}
+	return found, nil
+}
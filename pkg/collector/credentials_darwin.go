@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// keychainAccount matches the account attribute in `security` output.
+var keychainAccount = regexp.MustCompile(`"acct"<blob>="([^"]*)"`)
+
+// lookupSystemCredential reads a generic password item from the macOS
+// Keychain, with the target as its service name and the BMC username as its
+// account, as stored by:
+//
+//	security add-generic-password -s inventory-v3/<address> -a <username> -w
+func lookupSystemCredential(target string) (Credential, error) {
+	attributes, err := exec.Command("security", "find-generic-password", "-s", target).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		// security exits with 44 when no item matches
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 44 {
+			return Credential{}, ErrNoCredential
+		}
+		return Credential{}, fmt.Errorf("failed to read keychain item %s: %w", target, err)
+	}
+	password, err := exec.Command("security", "find-generic-password", "-s", target, "-w").Output()
+	if err != nil {
+		return Credential{}, fmt.Errorf("failed to read keychain password %s: %w", target, err)
+	}
+	cred := Credential{Password: strings.TrimSuffix(string(password), "\n")}
+	if m := keychainAccount.FindSubmatch(attributes); m != nil {
+		cred.Username = string(m[1])
+	}
+	return cred, nil
+}
+
+// storeSystemCredential replaces the Keychain items of target with one for
+// cred.
+// The command is given to security's interactive mode on stdin, with the
+// password hex-encoded (-X), so that the password is never in the
+// arguments of a process, which every local user can list.
+func storeSystemCredential(target string, cred Credential) error {
+	if strings.ContainsAny(cred.Username, "\"\\\n") {
+		return fmt.Errorf("user name %q cannot be stored in the keychain", cred.Username)
+	}
+	// Items of the target stored before under another username would be
+	// found first; delete-generic-password removes one match per run
+	for i := 0; i < 16; i++ {
+		if exec.Command("security", "delete-generic-password", "-s", target).Run() != nil {
+			break
+		}
+	}
+	command := fmt.Sprintf("add-generic-password -U -s \"%s\" -a \"%s\" -X %s\n", target, cred.Username, hex.EncodeToString([]byte(cred.Password)))
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(command)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to store keychain item %s: %w: %s", target, err, strings.TrimSpace(string(out)))
+	}
+	// Interactive mode exits 0 whether or not the command worked
+	if stored, err := lookupSystemCredential(target); err != nil || stored != cred {
+		return fmt.Errorf("failed to store keychain item %s: %s", target, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
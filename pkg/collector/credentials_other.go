@@ -0,0 +1,84 @@
+//go:build !darwin && !windows
+
+package collector
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// lookupSystemCredential reads a password from the Secret Service (GNOME
+// Keyring, KWallet) through secret-tool, with the username from the item's
+// username attribute, as stored by:
+//
+//	secret-tool store --label=<address> target inventory-v3/<address> username <username>
+//
+// Items stored without the attribute hold only the password; their username
+// comes from the configuration.
+func lookupSystemCredential(target string) (Credential, error) {
+	out, err := exec.Command("secret-tool", "lookup", "target", target).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) == 0 {
+			return Credential{}, ErrNoCredential
+		}
+		if errors.Is(err, exec.ErrNotFound) {
+			return Credential{}, errors.New("secret-tool not found; install libsecret-tools to use the system credential store")
+		}
+		return Credential{}, fmt.Errorf("failed to read secret %s: %w", target, err)
+	}
+	return Credential{
+		Username: lookupSecretUsername(target),
+		Password: strings.TrimSuffix(string(out), "\n"),
+	}, nil
+}
+
+// secretUsername matches the username attribute in secret-tool search output.
+var secretUsername = regexp.MustCompile(`(?m)^attribute\.username = (.*)$`)
+
+// lookupSecretUsername returns the username attribute of the target's item,
+// or "" if it has none. secret-tool prints the attributes on stdout or
+// stderr, depending on its version, so both are searched.
+func lookupSecretUsername(target string) string {
+	var out bytes.Buffer
+	cmd := exec.Command("secret-tool", "search", "target", target)
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+	if m := secretUsername.FindSubmatch(out.Bytes()); m != nil {
+		return strings.TrimSpace(string(m[1]))
+	}
+	return ""
+}
+
+// storeSystemCredential writes the credential to the Secret Service: the
+// password as the secret, passed to secret-tool on stdin, and the username
+// as an attribute. Items of the target stored before, possibly under another
+// username, are cleared first so that lookups find the new one.
+func storeSystemCredential(target string, cred Credential) error {
+	if out, err := exec.Command("secret-tool", "clear", "target", target).CombinedOutput(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return errors.New("secret-tool not found; install libsecret-tools to use the system credential store")
+		}
+		// clear fails when nothing matches, which is no error here
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || len(bytes.TrimSpace(out)) > 0 {
+			return fmt.Errorf("failed to replace secret %s: %w: %s", target, err, strings.TrimSpace(string(out)))
+		}
+	}
+	args := []string{"store", "--label=" + target, "target", target}
+	if cred.Username != "" {
+		args = append(args, "username", cred.Username)
+	}
+	cmd := exec.Command("secret-tool", args...)
+	cmd.Stdin = strings.NewReader(cred.Password)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to store secret %s: %w: %s", target, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestConfigCredentialsLookup takes the login of the address, else of the
+// narrowest network holding it, else the default.
+func TestConfigCredentialsLookup(t *testing.T) {
+	login := func(password string) Credential { return Credential{Username: "admin", Password: password} }
+	creds := ConfigCredentials{
+		Default: login("default"),
+		Addresses: map[string]Credential{
+			"10.0.0.0/16":     login("site"),
+			"10.0.1.0/24":     login("rack"),
+			"10.0.1.17":       login("host"),
+			"10.0.2.5:8443":   login("host-port"),
+			"bmc.example.com": login("name"),
+			"fd00::/64":       login("v6-site"),
+			"fd00::10/124":    login("v6-rack"),
+			"fd00::17":        login("v6-host"),
+			"10.0.3.0/24":     login("tie-first"),
+			"10.0.3.1/24":     login("tie-second"),
+		},
+	}
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "address", address: "10.0.1.17", want: "host"},
+		{name: "address with port", address: "10.0.1.17:8443", want: "host"},
+		{name: "address and port", address: "10.0.2.5:8443", want: "host-port"},
+		{name: "other port of the address", address: "10.0.2.5:443", want: "site"},
+		{name: "host name", address: "bmc.example.com:443", want: "name"},
+		{name: "narrowest network", address: "10.0.1.18", want: "rack"},
+		{name: "narrowest network with port", address: "10.0.1.18:8443", want: "rack"},
+		{name: "wider network", address: "10.0.200.1", want: "site"},
+		{name: "equally narrow networks", address: "10.0.3.9", want: "tie-first"},
+		{name: "IPv6 address", address: "fd00::17", want: "v6-host"},
+		{name: "IPv6 address in brackets", address: "[fd00::17]", want: "v6-host"},
+		{name: "IPv6 address with port", address: "[fd00::17]:8443", want: "v6-host"},
+		{name: "IPv6 narrowest network", address: "[fd00::1a]", want: "v6-rack"},
+		{name: "IPv6 wider network", address: "[fd00::1:1]:443", want: "v6-site"},
+		{name: "default", address: "192.168.0.1", want: "default"},
+		{name: "other host name", address: "other.example.com", want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := creds.Lookup(tt.address)
+			if err != nil {
+				t.Fatalf("Lookup(%q) failed: %v", tt.address, err)
+			}
+			if got.Password != tt.want {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.address, got.Password, tt.want)
+			}
+		})
+	}
+
+	// Without a default, addresses matching nothing have no credential
+	creds.Default = Credential{}
+	if _, err := creds.Lookup("192.168.0.1"); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Lookup without a default error = %v, want ErrNoCredential", err)
+	}
+}
@@ -0,0 +1,113 @@
+package collector
+
+import (
+	"fmt"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// winCredential mirrors the Win32 CREDENTIALW structure.
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// lookupSystemCredential reads a generic credential from the Windows
+// Credential Manager, as stored by:
+//
+//	cmdkey /generic:inventory-v3/<address> /user:<username> /pass
+func lookupSystemCredential(target string) (Credential, error) {
+	name, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return Credential{}, err
+	}
+	var cred *winCredential
+	r, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(name)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		if callErr == errorNotFound {
+			return Credential{}, ErrNoCredential
+		}
+		return Credential{}, fmt.Errorf("failed to read credential %s: %w", target, callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	// cmdkey and the Credential Manager UI store the password as UTF-16
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	chars := make([]uint16, len(blob)/2)
+	for i := range chars {
+		chars[i] = uint16(blob[2*i]) | uint16(blob[2*i+1])<<8
+	}
+	return Credential{
+		Username: utf16PtrToString(cred.UserName),
+		Password: string(utf16.Decode(chars)),
+	}, nil
+}
+
+// storeSystemCredential writes a generic credential to the Windows
+// Credential Manager, replacing any stored under target. The password is
+// stored as UTF-16, as cmdkey does.
+func storeSystemCredential(target string, cred Credential) error {
+	name, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(cred.Username)
+	if err != nil {
+		return err
+	}
+	chars := utf16.Encode([]rune(cred.Password))
+	blob := make([]byte, 2*len(chars))
+	for i, c := range chars {
+		blob[2*i], blob[2*i+1] = byte(c), byte(c>>8)
+	}
+	w := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         name,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if len(blob) > 0 {
+		w.CredentialBlob = &blob[0]
+	}
+	if r, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&w)), 0); r == 0 {
+		return fmt.Errorf("failed to store credential %s: %w", target, callErr)
+	}
+	return nil
+}
+
+// utf16PtrToString converts a NUL-terminated UTF-16 string.
+func utf16PtrToString(p *uint16) string {
+	if p == nil {
+		return ""
+	}
+	var chars []uint16
+	for ptr := unsafe.Pointer(p); *(*uint16)(ptr) != 0; ptr = unsafe.Add(ptr, 2) {
+		chars = append(chars, *(*uint16)(ptr))
+	}
+	return string(utf16.Decode(chars))
+}
@@ -0,0 +1,74 @@
+package collector
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// --- Credentials File ---
+//
+// A fleet of mixed vendors has as many BMC logins as it has vendors, often
+// one per rack or network. A credentials file maps BMC addresses and
+// networks to their logins, so that such a fleet is collected in one pass:
+//
+//	default: {username: root, password: changeme}
+//	bmcs:
+//	  10.0.0.0/24: {username: ADMIN, password: rack1}
+//	  10.0.1.0/24: {username: admin, password: rack2}
+//	  10.0.1.17: {username: Administrator, password: odd-one}
+//
+// A BMC takes the login of its own address, else of the narrowest network
+// holding it, else the default. The file is YAML, TOML or JSON by its
+// extension, YAML without one.
+
+// credentialsFileKeys are the top-level keys of a credentials file.
+var credentialsFileKeys = map[string]bool{"default": true, "bmcs": true}
+
+// LoadCredentialsFile reads a credentials file. A file other users can read
+// is used, with a warning.
+func LoadCredentialsFile(path string) (ConfigCredentials, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ConfigCredentials{}, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		fmt.Printf("Warning: Credentials file %s is readable by other users; chmod 600 it\n", path)
+	}
+
+	// BMC addresses contain dots, viper's default key delimiter
+	v := viper.NewWithOptions(viper.KeyDelimiter("::"))
+	v.SetConfigFile(path)
+	if filepath.Ext(path) == "" {
+		v.SetConfigType("yaml")
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return ConfigCredentials{}, fmt.Errorf("failed to parse credentials file %s: %w", path, err)
+	}
+	for _, key := range v.AllKeys() {
+		if top, _, _ := strings.Cut(key, "::"); !credentialsFileKeys[top] {
+			return ConfigCredentials{}, fmt.Errorf("credentials file %s: unknown key %q (valid: default, bmcs)", path, top)
+		}
+	}
+
+	var creds ConfigCredentials
+	if err := v.UnmarshalKey("default", &creds.Default); err != nil {
+		return ConfigCredentials{}, fmt.Errorf("credentials file %s: bad default: %w", path, err)
+	}
+	if err := v.UnmarshalKey("bmcs", &creds.Addresses); err != nil {
+		return ConfigCredentials{}, fmt.Errorf("credentials file %s: bad bmcs: %w", path, err)
+	}
+	for key := range creds.Addresses {
+		if strings.Contains(key, "/") {
+			if _, _, err := net.ParseCIDR(key); err != nil {
+				return ConfigCredentials{}, fmt.Errorf("credentials file %s: %q is not a network: %w", path, key, err)
+			}
+		}
+	}
+	return creds, nil
+}
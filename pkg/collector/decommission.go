@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/discovery"
+	"github.com/example/inventory-v3/pkg/telemetry"
+)
+
+// Decommission publishes a deletion snapshot for a decommissioned BMC,
+// without contacting it: the snapshot reports no devices, and the reconciler
+// retires every device the BMC reported before. It is signed and attested
+// like a collection.
+func Decommission(bmcIP string, opts CollectOptions) (*RunSummary, error) {
+	sdkClient, err := opts.apiClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fabrica client: %w", err)
+	}
+	if opts.RunID == "" {
+		opts.RunID = fabricaclient.NewRunID()
+	}
+	ctx := fabricaclient.WithRunID(context.Background(), opts.RunID)
+	publisher, err := opts.publisher(sdkClient)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := discovery.SnapshotMapper{RunID: opts.RunID, Signer: opts.Signer, Version: telemetry.Version(), Priority: opts.Priority}
+	result, published, err := discovery.Run(ctx, bmcIP, decommissioned{}, mapper, publisher)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Published deletion snapshot for decommissioned BMC %s: %s (run %s)\n", bmcIP, published, opts.RunID)
+	return summarize(result, published, opts), nil
+}
+
+// decommissioned is the discovery.Discoverer of a decommissioned BMC, which
+// has nothing left to report.
+type decommissioned struct{}
+
+// Discover implements discovery.Discoverer.
+func (decommissioned) Discover(ctx context.Context, address string) (*discovery.Result, error) {
+	return &discovery.Result{Address: address, Decommissioned: true}, nil
+}
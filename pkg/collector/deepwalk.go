@@ -0,0 +1,273 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// --- Deep-Walk Configuration ---
+
+// DefaultDeepWalkDepth and DefaultDeepWalkMaxVisited bound the exhaustive crawl.
+const DefaultDeepWalkDepth = 6
+const DefaultDeepWalkMaxVisited = 2000
+
+// mappedResourceTypes lists the Redfish resource types the collector already
+// turns into devices (or walks through to reach them). Anything else found
+// during a deep walk is reported as unmapped.
+var mappedResourceTypes = map[string]bool{
+	"ServiceRoot":                     true,
+	"ComputerSystemCollection":        true,
+	"ComputerSystem":                  true,
+	"ProcessorCollection":             true,
+	"Processor":                       true,
+	"MemoryCollection":                true,
+	"Memory":                          true,
+	"Chassis":                         true,
+	"NetworkAdapterCollection":        true,
+	"NetworkAdapter":                  true,
+	"PortCollection":                  true,
+	"Port":                            true,
+	"NetworkPortCollection":           true,
+	"NetworkPort":                     true,
+	"NetworkDeviceFunctionCollection": true,
+	"NetworkDeviceFunction":           true,
+	"StorageCollection":               true,
+	"Storage":                         true,
+	"Drive":                           true,
+	"DriveMetrics":                    true,
+	"Volume":                          true,
+	"PowerDistributionCollection":     true,
+	"PowerDistribution":               true,
+	"PowerEquipment":                  true,
+	"CircuitCollection":               true,
+	"Circuit":                         true,
+	"OutletCollection":                true,
+	"Outlet":                          true,
+	"ThermalEquipment":                true,
+	"CoolingUnitCollection":           true,
+	"CoolingUnit":                     true,
+	"PumpCollection":                  true,
+	"Pump":                            true,
+	"CoolingLoopCollection":           true,
+	"CoolingLoop":                     true,
+	"CompositionService":              true,
+	"ResourceBlockCollection":         true,
+	"ResourceBlock":                   true,
+	"ZoneCollection":                  true,
+	"Zone":                            true,
+	"ThermalSubsystem":                true,
+	"FanCollection":                   true,
+	"Fan":                             true,
+	"Thermal":                         true,
+	"PowerSubsystem":                  true,
+	"PowerSupplyCollection":           true,
+	"PowerSupply":                     true,
+	"Power":                           true,
+	"SimpleStorageCollection":         true,
+	"SimpleStorage":                   true,
+	"ManagerCollection":               true,
+	"Manager":                         true,
+	"ManagerNetworkProtocol":          true,
+	"EthernetInterfaceCollection":     true,
+	"EthernetInterface":               true,
+}
+
+// --- Deep-Walk Report ---
+
+// DeepWalkReport summarizes an exhaustive crawl of a Redfish service.
+type DeepWalkReport struct {
+	BaseURL   string
+	Visited   int
+	Failed    int
+	Truncated bool // true when MaxVisited stopped the crawl early
+
+	// MappedTypes and UnmappedTypes count resources by their @odata.type name.
+	MappedTypes   map[string]int
+	UnmappedTypes map[string]int
+
+	// Examples holds the first URI seen for each resource type.
+	Examples map[string]string
+}
+
+// Print writes a human-readable summary of the report, unmapped types first.
+func (r *DeepWalkReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "Deep walk of %s: visited %d resources (%d failed)", r.BaseURL, r.Visited, r.Failed)
+	if r.Truncated {
+		fmt.Fprint(w, " [truncated: visit limit reached]")
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nUNMAPPED TYPE\tCOUNT\tEXAMPLE URI")
+	for _, t := range sortedTypeNames(r.UnmappedTypes) {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", t, r.UnmappedTypes[t], r.Examples[t])
+	}
+	fmt.Fprintln(tw, "\nMAPPED TYPE\tCOUNT\tEXAMPLE URI")
+	for _, t := range sortedTypeNames(r.MappedTypes) {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", t, r.MappedTypes[t], r.Examples[t])
+	}
+	tw.Flush()
+}
+
+// sortedTypeNames orders type names by descending count, then by name.
+func sortedTypeNames(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// --- Deep-Walk Orchestration ---
+
+// DeepWalk crawls every @odata.id link reachable from the service root of the
+// given BMC and reports which resource types exist. Nothing is posted to the
+// inventory API; the report is meant for writing new mappings.
+// Only the credential and TLS settings of opts are used.
+func DeepWalk(bmcIP string, maxDepth, maxVisited int, opts CollectOptions) (*DeepWalkReport, error) {
+	rfClient, err := opts.newRedfishClient(bmcIP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Redfish client: %w", err)
+	}
+	rfClient.Identify()
+	defer rfClient.Logout()
+	return deepWalk(rfClient, maxDepth, maxVisited)
+}
+
+// deepWalk performs a breadth-first crawl bounded by depth and visit count.
+func deepWalk(c *RedfishClient, maxDepth, maxVisited int) (*DeepWalkReport, error) {
+	report := &DeepWalkReport{
+		BaseURL:       c.BaseURL,
+		MappedTypes:   make(map[string]int),
+		UnmappedTypes: make(map[string]int),
+		Examples:      make(map[string]string),
+	}
+
+	type queued struct {
+		uri   string
+		depth int
+	}
+	visited := map[string]bool{"/": true}
+	queue := []queued{{uri: "/", depth: 0}}
+
+	for len(queue) > 0 {
+		if report.Visited+report.Failed >= maxVisited {
+			report.Truncated = true
+			break
+		}
+		item := queue[0]
+		queue = queue[1:]
+
+		body, err := c.Get(item.uri)
+		if err != nil {
+			report.Failed++
+			fmt.Printf("Warning: Deep walk failed to get %s: %v\n", item.uri, err)
+			continue
+		}
+		report.Visited++
+
+		var doc interface{}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			report.Failed++
+			fmt.Printf("Warning: Deep walk failed to decode %s: %v\n", item.uri, err)
+			continue
+		}
+
+		if obj, ok := doc.(map[string]interface{}); ok {
+			if typeName := odataTypeName(obj); typeName != "" {
+				if mappedResourceTypes[typeName] {
+					report.MappedTypes[typeName]++
+				} else {
+					report.UnmappedTypes[typeName]++
+				}
+				if _, seen := report.Examples[typeName]; !seen {
+					report.Examples[typeName] = item.uri
+				}
+			}
+		}
+
+		if item.depth >= maxDepth {
+			continue
+		}
+		for _, link := range collectODataLinks(doc, nil) {
+			if visited[link] {
+				continue
+			}
+			visited[link] = true
+			queue = append(queue, queued{uri: link, depth: item.depth + 1})
+		}
+	}
+	return report, nil
+}
+
+// odataTypeName turns "#Processor.v1_5_0.Processor" into "Processor".
+func odataTypeName(obj map[string]interface{}) string {
+	raw, _ := obj["@odata.type"].(string)
+	raw = strings.TrimPrefix(raw, "#")
+	if raw == "" {
+		return ""
+	}
+	parts := strings.Split(raw, ".")
+	return parts[len(parts)-1]
+}
+
+// collectODataLinks returns every @odata.id below doc, normalized to paths
+// relative to /redfish/v1 with fragments and query strings removed. Members
+// are visited in key order, so that walks of the same BMC queue, and cut
+// off at the same links.
+func collectODataLinks(doc interface{}, links []string) []string {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := v[key]
+			if key == "@odata.id" {
+				if link, ok := child.(string); ok {
+					if normalized := normalizeWalkLink(link); normalized != "" {
+						links = append(links, normalized)
+					}
+				}
+				continue
+			}
+			links = collectODataLinks(child, links)
+		}
+	case []interface{}:
+		for _, child := range v {
+			links = collectODataLinks(child, links)
+		}
+	}
+	return links
+}
+
+// normalizeWalkLink strips the service prefix, fragments, and queries from a link.
+// Links that leave the Redfish tree (or point at schema metadata) return "".
+func normalizeWalkLink(link string) string {
+	if i := strings.IndexAny(link, "#?"); i >= 0 {
+		link = link[:i]
+	}
+	if !strings.HasPrefix(link, "/redfish/v1") {
+		return ""
+	}
+	link = strings.TrimSuffix(strings.TrimPrefix(link, "/redfish/v1"), "/")
+	if link == "" {
+		return ""
+	}
+	if strings.HasPrefix(link, "/$metadata") || strings.HasPrefix(link, "/JsonSchemas") || strings.HasPrefix(link, "/odata") {
+		return ""
+	}
+	return link
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+)
+
+// TestDeepWalkOrder walks a BMC in the same order every time, so that a walk
+// cut off by the visit limit reports the same types and examples.
+func TestDeepWalkOrder(t *testing.T) {
+	mock, err := redfishmock.New(redfishmock.Fixture(redfishmock.DefaultNode("WALK0001")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.Username, mock.Password = "admin", "admin"
+	server := httptest.NewTLSServer(mock)
+	defer server.Close()
+	c, err := NewRedfishClient(strings.TrimPrefix(server.URL, "https://"), "admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var first *DeepWalkReport
+	for i := 0; i < 5; i++ {
+		report, err := deepWalk(c, 10, 12)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.Truncated {
+			t.Fatal("the walk was not cut off by its visit limit")
+		}
+		if first == nil {
+			first = report
+			continue
+		}
+		if !reflect.DeepEqual(report, first) {
+			t.Fatalf("walk %d = %+v, want %+v", i, report, first)
+		}
+	}
+
+	doc := map[string]interface{}{
+		"Systems":  map[string]interface{}{"@odata.id": "/redfish/v1/Systems"},
+		"Chassis":  map[string]interface{}{"@odata.id": "/redfish/v1/Chassis"},
+		"Managers": map[string]interface{}{"@odata.id": "/redfish/v1/Managers#/x"},
+		"Links":    []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/$metadata"}},
+	}
+	want := []string{"/Chassis", "/Managers", "/Systems"}
+	if got := collectODataLinks(doc, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("collectODataLinks = %v, want %v", got, want)
+	}
+}
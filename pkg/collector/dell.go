@@ -0,0 +1,234 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/cabling"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Dell iDRAC OEM Enrichment ---
+//
+// Dell support asks for the Service Tag and Express Service Code, which plain
+// Redfish only exposes as the system SKU (if at all). iDRAC also publishes
+// extra memory and processor attributes under Oem.Dell, and the license level
+// decides which iDRAC features are available. These are mapped into device
+// properties whenever the resources carry an Oem.Dell block. iDRAC also lists
+// the switch port each NIC port sees over LLDP, which older iDRACs do not
+// publish as standard Port data.
+
+// dellExtension maps Oem.Dell blocks.
+var dellExtension = &oemExtension{
+	Key:       "Dell",
+	System:    enrichDellSystem,
+	Component: dellComponentProperties,
+	NICs:      enrichDellNICs,
+}
+
+// dellOem is the Oem.Dell block of a Dell resource.
+type dellOem struct {
+	DellSystem    *dellSystem                `json:"DellSystem,omitempty"`
+	DellMemory    map[string]json.RawMessage `json:"DellMemory,omitempty"`
+	DellProcessor map[string]json.RawMessage `json:"DellProcessor,omitempty"`
+}
+
+// dellSystem carries the Dell identifiers of a ComputerSystem.
+type dellSystem struct {
+	ChassisServiceTag  string `json:"ChassisServiceTag,omitempty"`
+	ExpressServiceCode string `json:"ExpressServiceCode,omitempty"`
+	SystemGeneration   string `json:"SystemGeneration,omitempty"`
+}
+
+// dellLicense is a member of the manager's Oem/Dell/DellLicenses collection.
+type dellLicense struct {
+	LicenseDescription   []string `json:"LicenseDescription,omitempty"`
+	LicensePrimaryStatus string   `json:"LicensePrimaryStatus,omitempty"`
+}
+
+// dellSwitchConnection is a member of a system's DellSwitchConnections: the
+// switch port a NIC port, named by its FQDD ("NIC.Integrated.1-1-1"), sees.
+type dellSwitchConnection struct {
+	FQDD                   string `json:"FQDD,omitempty"`
+	SwitchConnectionID     string `json:"SwitchConnectionID,omitempty"`
+	SwitchPortConnectionID string `json:"SwitchPortConnectionID,omitempty"`
+	// StaleData is "Stale" when the port has not seen LLDP lately.
+	StaleData string `json:"StaleData,omitempty"`
+}
+
+// dellLicenseLevels orders the iDRAC license levels, lowest first.
+var dellLicenseLevels = []string{"Basic", "Express", "Enterprise", "Datacenter"}
+
+// enrichDellSystem sets the Service Tag, Express Service Code, and iDRAC
+// license level on a Dell node.
+func enrichDellSystem(c *RedfishClient, spec *device.DeviceSpec, system *RedfishSystem, oem json.RawMessage) {
+	var decoded dellOem
+	if err := json.Unmarshal(oem, &decoded); err != nil || decoded.DellSystem == nil {
+		return
+	}
+	dell := decoded.DellSystem
+
+	// iDRAC reports the Service Tag as the system SKU; blades also carry the
+	// Service Tag of their enclosure.
+	serviceTag := firstNonEmpty(system.SKU, dell.ChassisServiceTag)
+	if serviceTag != "" {
+		setProperty(spec, "service_tag", serviceTag)
+	}
+	if dell.ChassisServiceTag != "" && dell.ChassisServiceTag != serviceTag {
+		setProperty(spec, "chassis_service_tag", dell.ChassisServiceTag)
+	}
+	if code := firstNonEmpty(dell.ExpressServiceCode, expressServiceCode(serviceTag)); code != "" {
+		setProperty(spec, "express_service_code", code)
+	}
+	if dell.SystemGeneration != "" {
+		setProperty(spec, "dell_system_generation", dell.SystemGeneration)
+	}
+
+	// The license needs another request, so the quick profile skips it
+	if !c.collectComponents() || len(system.Links.ManagedBy) == 0 {
+		return
+	}
+	managerURI := normalizeWalkLink(system.Links.ManagedBy[0].ODataID)
+	if managerURI == "" {
+		return
+	}
+	level, licenses, err := getDellLicenses(c, managerURI)
+	if err != nil {
+		fmt.Printf("Warning: Failed to read iDRAC licenses from %s: %v\n", managerURI, err)
+		return
+	}
+	if level != "" {
+		setProperty(spec, "idrac_license", level)
+	}
+	if len(licenses) > 0 {
+		setProperty(spec, "idrac_licenses", licenses)
+	}
+}
+
+// getDellLicenses reads the licenses installed on an iDRAC and returns the
+// highest license level among them and their descriptions.
+func getDellLicenses(c *RedfishClient, managerURI string) (string, []string, error) {
+	memberURIs, err := getCollectionMembers(c, managerURI+"/Oem/Dell/DellLicenses")
+	if err != nil {
+		return "", nil, err
+	}
+	best := -1
+	var licenses []string
+	for _, memberURI := range memberURIs {
+		body, err := c.Get(memberURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get license %s: %v\n", memberURI, err)
+			continue
+		}
+		var license dellLicense
+		if err := json.Unmarshal(body, &license); err != nil {
+			fmt.Printf("Warning: Failed to decode license %s: %v\n", memberURI, err)
+			continue
+		}
+		for _, description := range license.LicenseDescription {
+			licenses = append(licenses, description)
+			// Expired or otherwise unhealthy licenses do not grant their level
+			if license.LicensePrimaryStatus != "" && license.LicensePrimaryStatus != "OK" {
+				continue
+			}
+			for i, level := range dellLicenseLevels {
+				if i > best && strings.Contains(description, level) {
+					best = i
+				}
+			}
+		}
+	}
+	if best < 0 {
+		return "", licenses, nil
+	}
+	return dellLicenseLevels[best], licenses, nil
+}
+
+// expressServiceCode converts a Service Tag to its Express Service Code: the
+// tag read as a base-36 number, written in decimal.
+func expressServiceCode(serviceTag string) string {
+	if serviceTag == "" {
+		return ""
+	}
+	n, ok := new(big.Int).SetString(strings.ToLower(serviceTag), 36)
+	if !ok {
+		return ""
+	}
+	return n.String()
+}
+
+// dellComponentProperties maps the attributes of a DellMemory or
+// DellProcessor block to "dell_"-prefixed properties.
+func dellComponentProperties(oem json.RawMessage) map[string]interface{} {
+	var decoded dellOem
+	if err := json.Unmarshal(oem, &decoded); err != nil {
+		return nil
+	}
+	if decoded.DellMemory != nil {
+		return oemAttributeProperties("dell_", decoded.DellMemory)
+	}
+	return oemAttributeProperties("dell_", decoded.DellProcessor)
+}
+
+// enrichDellNICs adds the switch ports iDRAC lists in DellSwitchConnections
+// to the lldp_neighbors of the NICs they are seen from.
+func enrichDellNICs(c *RedfishClient, systemURI string, nics []*device.DeviceSpec) {
+	if !c.collectComponents() {
+		return
+	}
+	memberURIs, err := getCollectionMembers(c, systemURI+"/NetworkPorts/Oem/Dell/DellSwitchConnections")
+	if err != nil {
+		// Only iDRAC9 and later publish them
+		return
+	}
+
+	// NICs are named in FQDDs by their adapter's Id, the last segment of its URI
+	byAdapter := make(map[string]*device.DeviceSpec, len(nics))
+	for _, nic := range nics {
+		var uri string
+		if nic.GetProperty("redfish_uri", &uri) {
+			byAdapter[path.Base(uri)] = nic
+		}
+	}
+	for _, memberURI := range memberURIs {
+		body, err := c.Get(memberURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get switch connection %s: %v\n", memberURI, err)
+			continue
+		}
+		var conn dellSwitchConnection
+		if err := json.Unmarshal(body, &conn); err != nil {
+			fmt.Printf("Warning: Failed to decode switch connection %s: %v\n", memberURI, err)
+			continue
+		}
+		// Ports without a link report "No Link" instead of a switch
+		if conn.StaleData == "Stale" || cabling.NormalizeMAC(conn.SwitchConnectionID) == "" {
+			continue
+		}
+		adapter, _, _ := strings.Cut(conn.FQDD, "-")
+		nic, ok := byAdapter[adapter]
+		if !ok {
+			continue
+		}
+		var neighbors []cabling.Neighbor
+		nic.GetProperty("lldp_neighbors", &neighbors)
+		neighbor := cabling.Neighbor{Port: conn.FQDD, ChassisID: conn.SwitchConnectionID, PortID: conn.SwitchPortConnectionID}
+		if !hasNeighbor(neighbors, neighbor) {
+			setProperty(nic, "lldp_neighbors", append(neighbors, neighbor))
+		}
+	}
+}
+
+// hasNeighbor reports whether neighbors already hold the switch port n sees,
+// as the NIC's standard Port data may.
+func hasNeighbor(neighbors []cabling.Neighbor, n cabling.Neighbor) bool {
+	for _, existing := range neighbors {
+		if cabling.NormalizeMAC(existing.ChassisID) == cabling.NormalizeMAC(n.ChassisID) && existing.PortID == n.PortID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- DPU / SmartNIC Discovery ---
+//
+// A DPU (e.g. NVIDIA BlueField) appears twice in Redfish: as a NetworkAdapter
+// of its host and as a separate ComputerSystem running its own OS, usually with
+// its own BMC. The collector merges the two into one "DPU" device: the adapter
+// record is kept (parented to the host node) and the DPU system's processors,
+// memory, drives, and ports become children of it.
+
+// isDPUSystem reports whether a ComputerSystem describes a DPU.
+func isDPUSystem(system *RedfishSystem) bool {
+	return system != nil && system.SystemType == "DPU"
+}
+
+// offloadSystemURI returns the DPU system linked from an adapter, if any.
+func offloadSystemURI(nic *device.DeviceSpec) string {
+	var uri string
+	nic.GetProperty("offload_system_uri", &uri)
+	return uri
+}
+
+// findHostedDPUs maps each DPU system URI to the adapter spec that hosts it,
+// the first one in systemURIs order when several link to the same system.
+// Adapters with an OffloadSystem link are marked as DPUs even when the linked
+// system was not found in the Systems collection.
+func findHostedDPUs(systemURIs []string, inventories map[string]*SystemInventory) map[string]*device.DeviceSpec {
+	hosted := make(map[string]*device.DeviceSpec)
+	for _, systemURI := range systemURIs {
+		for _, nic := range inventories[systemURI].NICs {
+			dpuURI := offloadSystemURI(nic)
+			if dpuURI == "" {
+				continue
+			}
+			nic.DeviceType = "DPU"
+			// A DPU listing its own adapter must not hide itself
+			if dpuURI == systemURI {
+				continue
+			}
+			if _, found := inventories[dpuURI]; !found {
+				continue
+			}
+			if _, claimed := hosted[dpuURI]; !claimed {
+				hosted[dpuURI] = nic
+			}
+		}
+	}
+	return hosted
+}
+
+// setDPUReferences records the DPU's own system and BMC on its device spec.
+func setDPUReferences(spec *device.DeviceSpec, systemURI string, system *RedfishSystem) {
+	setProperty(spec, "dpu_system_uri", systemURI)
+	if system == nil {
+		return
+	}
+	if system.SerialNumber != "" && system.SerialNumber != spec.SerialNumber {
+		setProperty(spec, "dpu_system_serial", system.SerialNumber)
+	}
+	if len(system.Links.ManagedBy) > 0 {
+		setProperty(spec, "bmc_uri", normalizeWalkLink(system.Links.ManagedBy[0].ODataID))
+	}
+}
+
+// foldDPUSystem merges a DPU system into the adapter spec that hosts it and
+// returns the DPU system's components, re-parented to the adapter.
+func foldDPUSystem(adapter *device.DeviceSpec, dpuURI string, system *RedfishSystem, inv *SystemInventory) []*device.DeviceSpec {
+	if adapter.SerialNumber == "" {
+		adapter.SerialNumber = inv.NodeSpec.SerialNumber
+	}
+	if adapter.Manufacturer == "" {
+		adapter.Manufacturer = inv.NodeSpec.Manufacturer
+	}
+	setDPUReferences(adapter, dpuURI, system)
+
+	var adapterURI string
+	adapter.GetProperty("redfish_uri", &adapterURI)
+
+	var children []*device.DeviceSpec
+	children = append(children, inv.CPUs...)
+	children = append(children, inv.DIMMs...)
+	children = append(children, inv.Drives...)
+	children = append(children, inv.Fans...)
+	children = append(children, inv.PSUs...)
+	for _, nic := range inv.NICs {
+		var nicURI string
+		nic.GetProperty("redfish_uri", &nicURI)
+		if nicURI == adapterURI {
+			continue // the DPU's view of the adapter itself
+		}
+		children = append(children, nic)
+	}
+	for _, child := range children {
+		child.ParentSerialNumber = adapter.SerialNumber
+		setProperty(child, "redfish_parent_uri", adapterURI)
+	}
+	return children
+}
@@ -0,0 +1,39 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+func offloadAdapter(dpuURI string) *device.DeviceSpec {
+	spec := &device.DeviceSpec{DeviceType: "NIC"}
+	setProperty(spec, "offload_system_uri", dpuURI)
+	return spec
+}
+
+// TestFindHostedDPUs gives a DPU linked from two hosts to the first host in
+// systemURIs order, every time.
+func TestFindHostedDPUs(t *testing.T) {
+	first, second := offloadAdapter("/Systems/dpu"), offloadAdapter("/Systems/dpu")
+	self := offloadAdapter("/Systems/dpu")
+	missing := offloadAdapter("/Systems/gone")
+	systemURIs := []string{"/Systems/b", "/Systems/a", "/Systems/dpu"}
+	inventories := map[string]*SystemInventory{
+		"/Systems/a":   {NICs: []*device.DeviceSpec{second}},
+		"/Systems/b":   {NICs: []*device.DeviceSpec{first, missing}},
+		"/Systems/dpu": {NICs: []*device.DeviceSpec{self}},
+	}
+	// Maps iterate in a random order; a single run could pass by chance
+	for i := 0; i < 20; i++ {
+		hosted := findHostedDPUs(systemURIs, inventories)
+		if len(hosted) != 1 || hosted["/Systems/dpu"] != first {
+			t.Fatalf("findHostedDPUs = %v, want only /Systems/dpu hosted by the adapter of /Systems/b", hosted)
+		}
+	}
+	for _, nic := range []*device.DeviceSpec{first, second, self, missing} {
+		if nic.DeviceType != "DPU" {
+			t.Errorf("adapter linked to %v has type %q, want DPU", nic.Properties["offload_system_uri"], nic.DeviceType)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// --- Endpoint Collection State ---
+//
+// Every collection from a registered BMCEndpoint records its outcome in the
+// endpoint's status: when it last succeeded and failed, the last error, and
+// how many collections failed in a row. Schedulers such as the agent use it
+// to back off from BMCs that keep failing. Successful collections also
+// record the BMC's discovery shape (see Adaptive Discovery).
+
+// DefaultMaxBackoff caps how long a scheduler waits before retrying a
+// failing endpoint.
+const DefaultMaxBackoff = 24 * time.Hour
+
+// collectionState is the merge patch of the collection fields of a
+// BMCEndpoint status. LastError and ConsecutiveFailures are always sent so
+// that a success clears them.
+type collectionState struct {
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+	LastFailureAt       *time.Time `json:"lastFailureAt,omitempty"`
+	LastError           string     `json:"lastError"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+
+	Shape            *bmcendpoint.DiscoveryShape `json:"shape,omitempty"`
+	DetectedProtocol string                      `json:"detectedProtocol,omitempty"`
+}
+
+// recordCollection patches the outcome of a collection into the endpoint's
+// status, with the discovery shape of a successful one (nil keeps the shape
+// recorded) and the protocol detected, if any. Failing to record it is only
+// a warning.
+func recordCollection(ctx context.Context, sdkClient *fabricaclient.Client, endpoint *bmcendpoint.BMCEndpoint, shape *bmcendpoint.DiscoveryShape, detected string, collectErr error) {
+	now := time.Now().UTC()
+	state := collectionState{LastSuccessAt: &now, Shape: shape}
+	if collectErr != nil {
+		state = collectionState{
+			LastFailureAt:       &now,
+			LastError:           redact.String(collectErr.Error()),
+			ConsecutiveFailures: endpoint.Status.ConsecutiveFailures + 1,
+		}
+	}
+	state.DetectedProtocol = detected
+	patch, err := json.Marshal(state)
+	if err != nil {
+		fmt.Printf("Warning: Failed to encode collection state for BMCEndpoint %s: %v\n", endpoint.GetName(), err)
+		return
+	}
+	if _, err := sdkClient.PatchBMCEndpointStatus(ctx, endpoint.GetUID(), patch); err != nil {
+		fmt.Printf("Warning: Failed to record collection state for BMCEndpoint %s: %v\n", endpoint.GetName(), err)
+	}
+}
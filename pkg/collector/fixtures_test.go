@@ -0,0 +1,124 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+	"github.com/example/inventory-v3/pkg/fixtures"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// fixtureDir holds the recorded fixtures contributed to the repository.
+const fixtureDir = "../../fixtures"
+
+// fixtureChecks check, by fixture name, what the vendor handling of the
+// collector makes of a fixture's devices.
+var fixtureChecks = map[string]func(t *testing.T, devices []*device.DeviceSpec){
+	// enrichLenovoSystem and the Oem.Lenovo FRU part numbers
+	"lenovo-thinksystem-sr650-v3-esx312p-3.10": func(t *testing.T, devices []*device.DeviceSpec) {
+		node := fixtureDevices(devices, "Node")[0]
+		wantProperty(t, node, "machine_type", "7D76")
+		wantProperty(t, node, "machine_type_model", "7D76CTO1WW")
+		wantProperty(t, node, "lenovo_system_status", "OSBooted")
+		dimms := fixtureDevices(devices, "DIMM")
+		if len(dimms) != 4 {
+			t.Fatalf("collected %d DIMMs, want 4", len(dimms))
+		}
+		for _, dimm := range dimms {
+			wantProperty(t, dimm, "fru_part_number", "03LC214")
+		}
+	},
+	// enrichSupermicroSystem, and the Memory collection the system does not
+	// link, with members relative to it (MemoryPath and RelativeMembers)
+	"supermicro-x13dei-01.01.10": func(t *testing.T, devices []*device.DeviceSpec) {
+		node := fixtureDevices(devices, "Node")[0]
+		wantProperty(t, node, "board_serial_number", "OM23BS000001")
+		wantProperty(t, node, "supermicro_chassis_board_id", "0x1b8f")
+		wantProperty(t, node, "supermicro_bios_date", "2024-03-11")
+		dimms := fixtureDevices(devices, "DIMM")
+		if len(dimms) != 4 {
+			t.Fatalf("collected %d DIMMs, want 4", len(dimms))
+		}
+		for i, dimm := range dimms {
+			wantProperty(t, dimm, "redfish_uri", fmt.Sprintf("/Systems/1/Memory/%d", i+1))
+			if dimm.PartNumber != "MTC20F2085S1RC48BA1" {
+				t.Errorf("DIMM %s part number = %q, want its model", dimm.SerialNumber, dimm.PartNumber)
+			}
+		}
+	},
+}
+
+// fixtureDevices returns the devices of deviceType, in collection order.
+func fixtureDevices(devices []*device.DeviceSpec, deviceType string) []*device.DeviceSpec {
+	var matched []*device.DeviceSpec
+	for _, spec := range devices {
+		if spec.DeviceType == deviceType {
+			matched = append(matched, spec)
+		}
+	}
+	return matched
+}
+
+func wantProperty(t *testing.T, spec *device.DeviceSpec, key, want string) {
+	t.Helper()
+	var got string
+	if !spec.GetProperty(key, &got) || got != want {
+		t.Errorf("%s %s: %s = %q, want %q", spec.DeviceType, spec.SerialNumber, key, got, want)
+	}
+}
+
+// TestRecordedFixtures collects each fixture of the catalog from a mock BMC,
+// recording it again, and checks that the collector applies the quirks it
+// applied when the fixture was recorded, and the vendor handling in
+// fixtureChecks.
+func TestRecordedFixtures(t *testing.T) {
+	ctx := context.Background()
+	catalog, err := fixtures.LoadCatalog(ctx, fixtures.DirStore(fixtureDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range catalog.Fixtures {
+		t.Run(entry.Name, func(t *testing.T) {
+			fixture, err := fixtures.Load(ctx, fixtures.DirStore(fixtureDir), entry.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mock, err := redfishmock.New(fixture.MockResources())
+			if err != nil {
+				t.Fatal(err)
+			}
+			mock.Username, mock.Password = "admin", "admin"
+			server := httptest.NewTLSServer(mock)
+			defer server.Close()
+
+			store := fixtures.DirStore(t.TempDir())
+			opts := CollectOptions{
+				Profile:     entry.Profile,
+				Credentials: ConfigCredentials{Default: Credential{Username: "admin", Password: "admin"}},
+				Record:      RecordOptions{Store: store, Name: entry.Name},
+			}
+			result, err := (&RedfishDiscoverer{Options: opts}).Discover(ctx, strings.TrimPrefix(server.URL, "https://"))
+			if err != nil {
+				t.Fatalf("discovery failed: %v", err)
+			}
+			if len(result.Devices) == 0 {
+				t.Fatal("no devices collected")
+			}
+			recorded, err := fixtures.Load(ctx, store, entry.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(recorded.Quirks, entry.Quirks) {
+				t.Errorf("quirks applied: %v, want %v", recorded.Quirks, entry.Quirks)
+			}
+			if check, ok := fixtureChecks[entry.Name]; ok {
+				check(t, result.Devices)
+			}
+		})
+	}
+}
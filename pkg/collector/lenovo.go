@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Lenovo XClarity Controller OEM Enrichment ---
+//
+// Lenovo support identifies a server by its machine type and serial number.
+// XCC reports the machine type-model (e.g. "7X06CTO1WW") as the system Model
+// and SKU; the machine type is its first four characters. Components carry
+// their FRU part number, the number Lenovo ships replacements under, in
+// Oem.Lenovo.
+
+// lenovoExtension maps Oem.Lenovo blocks.
+var lenovoExtension = &oemExtension{
+	Key:       "Lenovo",
+	System:    enrichLenovoSystem,
+	Component: lenovoComponentProperties,
+}
+
+// enrichLenovoSystem sets the machine type and model and the Oem.Lenovo
+// attributes on a Lenovo node.
+func enrichLenovoSystem(c *RedfishClient, spec *device.DeviceSpec, system *RedfishSystem, oem json.RawMessage) {
+	if mtm := firstNonEmpty(system.SKU, system.Model); isLenovoMachineTypeModel(mtm) {
+		setProperty(spec, "machine_type_model", mtm)
+		setProperty(spec, "machine_type", mtm[:4])
+	}
+	for key, value := range lenovoComponentProperties(oem) {
+		setProperty(spec, key, value)
+	}
+}
+
+// lenovoComponentProperties maps the attributes of an Oem.Lenovo block to
+// "lenovo_"-prefixed properties, with FruPartNumber as fru_part_number.
+func lenovoComponentProperties(oem json.RawMessage) map[string]interface{} {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(oem, &attributes); err != nil {
+		return nil
+	}
+	return oemAttributeProperties("lenovo_", attributes)
+}
+
+// isLenovoMachineTypeModel reports whether s looks like a machine type-model:
+// ten alphanumeric characters, the first four being the machine type.
+func isLenovoMachineTypeModel(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+	return strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && !unicode.IsUpper(r)
+	}) < 0
+}
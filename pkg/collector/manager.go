@@ -0,0 +1,239 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- BMC Management Network ---
+//
+// The manager of each system, its BMC, is recorded as a BMC device under the
+// node, with the management network it is configured with: its host name,
+// its addresses and whether they are static or from DHCP, and its NTP
+// servers. Its clock is compared with the collector's, since a skewed BMC
+// clock puts its logs and events out of order with the rest of the site's;
+// a BMC off by more than MaxClockSkew is flagged with clock_skewed.
+
+// DefaultMaxClockSkew is how far a BMC's clock may be off before it is
+// flagged.
+const DefaultMaxClockSkew = time.Minute
+
+// RedfishManagerNetworkProtocol defines the parts of a manager's
+// NetworkProtocol resource the collector uses.
+type RedfishManagerNetworkProtocol struct {
+	HostName string `json:"HostName,omitempty"`
+	FQDN     string `json:"FQDN,omitempty"`
+	NTP      struct {
+		ProtocolEnabled *bool    `json:"ProtocolEnabled,omitempty"`
+		NTPServers      []string `json:"NTPServers,omitempty"`
+	} `json:"NTP"`
+}
+
+// RedfishEthernetInterface defines the parts of a manager's
+// EthernetInterface the collector uses.
+type RedfishEthernetInterface struct {
+	HostName         string `json:"HostName,omitempty"`
+	FQDN             string `json:"FQDN,omitempty"`
+	InterfaceEnabled *bool  `json:"InterfaceEnabled,omitempty"`
+	DHCPv4           struct {
+		DHCPEnabled *bool `json:"DHCPEnabled,omitempty"`
+	} `json:"DHCPv4"`
+	IPv4Addresses []struct {
+		Address       string `json:"Address,omitempty"`
+		AddressOrigin string `json:"AddressOrigin,omitempty"`
+	} `json:"IPv4Addresses"`
+}
+
+// getManagerDevices returns a BMC device for the manager of each system,
+// under the first system it manages.
+func getManagerDevices(c *RedfishClient, systemURIs []string, systems map[string]*RedfishSystem) []*device.DeviceSpec {
+	var specs []*device.DeviceSpec
+	seen := make(map[string]bool)
+	for _, systemURI := range systemURIs {
+		system := systems[systemURI]
+		if len(system.Links.ManagedBy) == 0 {
+			continue
+		}
+		managerURI := normalizeWalkLink(system.Links.ManagedBy[0].ODataID)
+		if managerURI == "" || seen[managerURI] {
+			continue
+		}
+		seen[managerURI] = true
+		spec, err := getManagerDevice(c, managerURI, systemURI, system.SerialNumber)
+		if err != nil {
+			if !notImplemented(err) {
+				fmt.Printf("Warning: Failed to get manager %s: %v\n", managerURI, err)
+			}
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// getManagerDevice maps a manager, its clock and its network configuration.
+func getManagerDevice(c *RedfishClient, managerURI, parentURI, parentSerial string) (*device.DeviceSpec, error) {
+	sent := time.Now()
+	body, err := c.Get(managerURI)
+	if err != nil {
+		return nil, err
+	}
+	// The BMC read its clock somewhere between sending and receiving
+	now := sent.Add(time.Since(sent) / 2)
+	var manager RedfishManager
+	if err := json.Unmarshal(body, &manager); err != nil {
+		return nil, fmt.Errorf("failed to decode manager %s: %w", managerURI, err)
+	}
+
+	spec := mapCommonProperties(manager.CommonRedfishProperties, "BMC", managerURI, parentURI, parentSerial)
+	if manager.FirmwareVersion != "" {
+		setProperty(spec, "firmware_version", manager.FirmwareVersion)
+	}
+	if manager.DateTime != "" {
+		if clock, err := time.Parse(time.RFC3339, manager.DateTime); err != nil {
+			fmt.Printf("Warning: Manager %s reports an unreadable DateTime %q\n", managerURI, manager.DateTime)
+		} else {
+			c.setClockSkew(spec, managerURI, clock.Sub(now))
+		}
+	}
+
+	var network managerNetwork
+	if link := strings.TrimPrefix(manager.NetworkProtocol.ODataID, "/redfish/v1"); link != "" {
+		network.readProtocol(c, link)
+	}
+	if link := strings.TrimPrefix(manager.EthernetInterfaces.ODataID, "/redfish/v1"); link != "" {
+		network.readInterfaces(c, link)
+	}
+	network.setProperties(spec)
+	return spec, nil
+}
+
+// setClockSkew records how far the BMC's clock is off, in whole seconds so
+// that jitter does not change the device on every collection, and flags it
+// beyond c.MaxClockSkew.
+func (c *RedfishClient) setClockSkew(spec *device.DeviceSpec, managerURI string, skew time.Duration) {
+	limit := c.MaxClockSkew
+	if limit <= 0 {
+		limit = DefaultMaxClockSkew
+	}
+	skew = skew.Round(time.Second)
+	skewed := skew > limit || skew < -limit
+	setProperty(spec, "clock_skew_seconds", math.Round(skew.Seconds()))
+	setProperty(spec, "clock_skewed", skewed)
+	if skewed {
+		fmt.Printf("Warning: The clock of manager %s is off by %s (more than %s)\n", managerURI, skew, limit)
+	}
+}
+
+// managerNetwork gathers a manager's network configuration.
+type managerNetwork struct {
+	hostname   string
+	fqdn       string
+	addresses  map[string]bool
+	origins    map[string]bool
+	ntpEnabled *bool
+	ntpServers []string
+}
+
+// readProtocol reads the host name and NTP settings of the manager's
+// NetworkProtocol resource.
+func (n *managerNetwork) readProtocol(c *RedfishClient, uri string) {
+	body, err := c.Get(uri)
+	if err != nil {
+		if !notImplemented(err) {
+			fmt.Printf("Warning: Failed to get network protocol %s: %v\n", uri, err)
+		}
+		return
+	}
+	var protocol RedfishManagerNetworkProtocol
+	if err := json.Unmarshal(body, &protocol); err != nil {
+		fmt.Printf("Warning: Failed to decode network protocol %s: %v\n", uri, err)
+		return
+	}
+	n.hostname = firstNonEmpty(n.hostname, protocol.HostName)
+	n.fqdn = firstNonEmpty(n.fqdn, protocol.FQDN)
+	n.ntpEnabled = protocol.NTP.ProtocolEnabled
+	for _, server := range protocol.NTP.NTPServers {
+		// Some BMCs pad the list with empty slots
+		if server = strings.TrimSpace(server); server != "" {
+			n.ntpServers = append(n.ntpServers, server)
+		}
+	}
+}
+
+// readInterfaces reads the addresses of the manager's enabled Ethernet
+// interfaces, and how they were assigned.
+func (n *managerNetwork) readInterfaces(c *RedfishClient, uri string) {
+	memberURIs, err := getCollectionMembers(c, uri)
+	if err != nil {
+		if !notImplemented(err) {
+			fmt.Printf("Warning: Failed to get Ethernet interfaces %s: %v\n", uri, err)
+		}
+		return
+	}
+	n.addresses, n.origins = map[string]bool{}, map[string]bool{}
+	for _, memberURI := range memberURIs {
+		body, err := c.Get(memberURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get Ethernet interface %s: %v\n", memberURI, err)
+			continue
+		}
+		var iface RedfishEthernetInterface
+		if err := json.Unmarshal(body, &iface); err != nil {
+			fmt.Printf("Warning: Failed to decode Ethernet interface %s: %v\n", memberURI, err)
+			continue
+		}
+		if iface.InterfaceEnabled != nil && !*iface.InterfaceEnabled {
+			continue
+		}
+		n.hostname = firstNonEmpty(n.hostname, iface.HostName)
+		n.fqdn = firstNonEmpty(n.fqdn, iface.FQDN)
+		assigned, withOrigin := 0, 0
+		for _, addr := range iface.IPv4Addresses {
+			if addr.Address == "" || addr.Address == "0.0.0.0" {
+				continue
+			}
+			assigned++
+			n.addresses[addr.Address] = true
+			if addr.AddressOrigin != "" {
+				n.origins[addr.AddressOrigin] = true
+				withOrigin++
+			}
+		}
+		// Without an origin per address, DHCPv4 tells how they were assigned
+		if assigned > 0 && withOrigin == 0 && iface.DHCPv4.DHCPEnabled != nil {
+			if *iface.DHCPv4.DHCPEnabled {
+				n.origins["DHCP"] = true
+			} else {
+				n.origins["Static"] = true
+			}
+		}
+	}
+}
+
+// setProperties records the network configuration found on the BMC device.
+func (n *managerNetwork) setProperties(spec *device.DeviceSpec) {
+	if n.hostname != "" {
+		setProperty(spec, "hostname", n.hostname)
+	}
+	if n.fqdn != "" {
+		setProperty(spec, "fqdn", n.fqdn)
+	}
+	if len(n.addresses) > 0 {
+		setProperty(spec, "ipv4_addresses", sortedKeys(n.addresses))
+	}
+	if len(n.origins) > 0 {
+		setProperty(spec, "address_origin", strings.Join(sortedKeys(n.origins), ","))
+	}
+	if n.ntpEnabled != nil {
+		setProperty(spec, "ntp_enabled", *n.ntpEnabled)
+	}
+	if len(n.ntpServers) > 0 {
+		setProperty(spec, "ntp_servers", n.ntpServers)
+	}
+}
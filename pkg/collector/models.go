@@ -1,9 +1,12 @@
 package collector
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
 )
 
 // --- Redfish Client Struct ---
@@ -14,6 +17,32 @@ type RedfishClient struct {
 	Username   string
 	Password   string
 	HTTPClient *http.Client
+
+	// Identity and Quirks are filled in by Identify.
+	Identity ServiceIdentity
+	Quirks   []*Quirk
+	// Features are the query parameters the service supports.
+	Features RedfishProtocolFeatures
+	fired    map[string]int
+
+	// sessionToken and sessionURI identify the Redfish session once logged in.
+	sessionToken string
+	sessionURI   string
+
+	// Profile is the collection profile; see SetProfile.
+	Profile string
+	// TaskTimeout bounds how long a task monitor is followed; 0 uses
+	// DefaultTaskTimeout.
+	TaskTimeout time.Duration
+	// MaxClockSkew is how far the BMC's clock may be off before its BMC
+	// device is flagged; 0 uses DefaultMaxClockSkew.
+	MaxClockSkew time.Duration
+	// capture records every response body by path in the deep profile.
+	capture map[string]json.RawMessage
+	// coverage records the reads made and the paths that failed.
+	coverage *discoverysnapshot.Coverage
+	// shape records the subtrees probed and skipped; see useShape.
+	shape discoveryShape
 }
 
 // --- Redfish Helper Structs ---
@@ -25,6 +54,15 @@ type SystemInventory struct {
 	NodeSpec *device.DeviceSpec
 	CPUs     []*device.DeviceSpec
 	DIMMs    []*device.DeviceSpec
+	NICs     []*device.DeviceSpec
+	Drives   []*device.DeviceSpec
+	Fans     []*device.DeviceSpec
+	PSUs     []*device.DeviceSpec
+}
+
+// ODataLink is a bare Redfish navigation link.
+type ODataLink struct {
+	ODataID string `json:"@odata.id"`
 }
 
 // RedfishCollection defines the structure for Redfish collection responses.
@@ -32,6 +70,29 @@ type RedfishCollection struct {
 	Members []struct {
 		ODataID string `json:"@odata.id"`
 	} `json:"Members"`
+	Count    *int   `json:"Members@odata.count,omitempty"`
+	NextLink string `json:"Members@odata.nextLink,omitempty"`
+}
+
+// RedfishServiceRoot defines the parts of the service root (/redfish/v1) the collector uses.
+type RedfishServiceRoot struct {
+	Vendor             string    `json:"Vendor,omitempty"`
+	Product            string    `json:"Product,omitempty"`
+	RedfishVersion     string    `json:"RedfishVersion,omitempty"`
+	Managers           ODataLink `json:"Managers"`
+	CompositionService ODataLink `json:"CompositionService"`
+
+	ProtocolFeaturesSupported RedfishProtocolFeatures `json:"ProtocolFeaturesSupported"`
+}
+
+// RedfishManager defines the parts of a Manager resource (the BMC) the collector uses.
+type RedfishManager struct {
+	CommonRedfishProperties
+	FirmwareVersion string `json:"FirmwareVersion,omitempty"`
+	// DateTime is the BMC's clock when it answered.
+	DateTime           string    `json:"DateTime,omitempty"`
+	NetworkProtocol    ODataLink `json:"NetworkProtocol"`
+	EthernetInterfaces ODataLink `json:"EthernetInterfaces"`
 }
 
 // CommonRedfishProperties contains the fields required by the Device model.
@@ -40,6 +101,10 @@ type CommonRedfishProperties struct {
 	Model        string `json:"Model,omitempty"`
 	PartNumber   string `json:"PartNumber,omitempty"`
 	SerialNumber string `json:"SerialNumber,omitempty"`
+	// SparePartNumber stands in for a missing PartNumber.
+	SparePartNumber string `json:"SparePartNumber,omitempty"`
+	// Location.PartLocation names the slot a component sits in.
+	Location RedfishLocation `json:"Location"`
 }
 
 // RedfishSystem defines the structure for a System resource (the Node).
@@ -51,14 +116,440 @@ type RedfishSystem struct {
 	Memory struct {
 		ODataID string `json:"@odata.id"`
 	} `json:"Memory"`
+	Storage     ODataLink `json:"Storage"`
+	Bios        ODataLink `json:"Bios"`
+	LogServices ODataLink `json:"LogServices"`
+	// SimpleStorage is the pre-Storage model still served by older firmware.
+	SimpleStorage ODataLink `json:"SimpleStorage"`
+	// SystemType is "DPU" for the operating system running on a SmartNIC.
+	SystemType string `json:"SystemType,omitempty"`
+	// PowerState is On, Off, PoweringOn, PoweringOff, or Paused. BMCs often
+	// omit the components of a system that is not on.
+	PowerState string `json:"PowerState,omitempty"`
+	// SKU holds the Service Tag on Dell systems.
+	SKU         string          `json:"SKU,omitempty"`
+	BiosVersion string          `json:"BiosVersion,omitempty"`
+	Oem         json.RawMessage `json:"Oem,omitempty"`
+	Links       struct {
+		Chassis   []ODataLink `json:"Chassis"`
+		ManagedBy []ODataLink `json:"ManagedBy"`
+		// ResourceBlocks lists the blocks a composed system was built from.
+		ResourceBlocks []ODataLink `json:"ResourceBlocks"`
+	} `json:"Links"`
 }
 
 // RedfishProcessor defines the structure for a Processor resource (the CPU).
 type RedfishProcessor struct {
-	CommonRedfishProperties // Embeds the common fields
+	CommonRedfishProperties                 // Embeds the common fields
+	ProcessorType           string          `json:"ProcessorType,omitempty"`
+	Socket                  string          `json:"Socket,omitempty"`
+	TotalCores              *int            `json:"TotalCores,omitempty"`
+	TotalThreads            *int            `json:"TotalThreads,omitempty"`
+	Oem                     json.RawMessage `json:"Oem,omitempty"`
+}
+
+// extraProperties returns the processor attributes beyond the common fields.
+func (p *RedfishProcessor) extraProperties() map[string]interface{} {
+	props := map[string]interface{}{}
+	if p.ProcessorType != "" {
+		props["processor_type"] = p.ProcessorType
+	}
+	if p.TotalCores != nil {
+		props["total_cores"] = *p.TotalCores
+	}
+	if p.TotalThreads != nil {
+		props["total_threads"] = *p.TotalThreads
+	}
+	if p.Socket != "" {
+		props["socket"] = p.Socket
+	}
+	for key, value := range componentOemProperties(p.Oem) {
+		props[key] = value
+	}
+	return props
+}
+
+// deviceType maps GPU (and other accelerator) processors to their own device type.
+func (p *RedfishProcessor) deviceType() string {
+	if p.ProcessorType == "GPU" {
+		return "GPU"
+	}
+	return ""
 }
 
 // RedfishMemory defines the structure for a Memory resource (the DIMM).
 type RedfishMemory struct {
-	CommonRedfishProperties // Embeds the common fields
-}
\ No newline at end of file
+	CommonRedfishProperties                 // Embeds the common fields
+	CapacityMiB             *int64          `json:"CapacityMiB,omitempty"`
+	MemoryDeviceType        string          `json:"MemoryDeviceType,omitempty"`
+	OperatingSpeedMhz       *int            `json:"OperatingSpeedMhz,omitempty"`
+	DeviceLocator           string          `json:"DeviceLocator,omitempty"`
+	Oem                     json.RawMessage `json:"Oem,omitempty"`
+}
+
+// extraProperties returns the DIMM attributes beyond the common fields.
+func (m *RedfishMemory) extraProperties() map[string]interface{} {
+	props := map[string]interface{}{}
+	if m.CapacityMiB != nil {
+		props["capacity_mib"] = *m.CapacityMiB
+	}
+	if m.MemoryDeviceType != "" {
+		props["memory_device_type"] = m.MemoryDeviceType
+	}
+	if m.OperatingSpeedMhz != nil {
+		props["operating_speed_mhz"] = *m.OperatingSpeedMhz
+	}
+	if m.DeviceLocator != "" {
+		props["device_locator"] = m.DeviceLocator
+	}
+	for key, value := range componentOemProperties(m.Oem) {
+		props[key] = value
+	}
+	return props
+}
+
+// RedfishChassis defines the parts of a Chassis resource used to reach adapters.
+type RedfishChassis struct {
+	CommonRedfishProperties
+	Name            string          `json:"Name,omitempty"`
+	ChassisType     string          `json:"ChassisType,omitempty"`
+	Location        RedfishLocation `json:"Location"`
+	NetworkAdapters ODataLink       `json:"NetworkAdapters"`
+	Oem             json.RawMessage `json:"Oem,omitempty"`
+
+	// Fans and power supplies live under the modern *Subsystem resources or the
+	// legacy Thermal/Power resources, depending on the schema version.
+	ThermalSubsystem ODataLink `json:"ThermalSubsystem"`
+	Thermal          ODataLink `json:"Thermal"`
+	PowerSubsystem   ODataLink `json:"PowerSubsystem"`
+	Power            ODataLink `json:"Power"`
+	// EnvironmentMetrics carries the modern power reading and power limit.
+	EnvironmentMetrics ODataLink `json:"EnvironmentMetrics"`
+}
+
+// RedfishNetworkAdapter defines the structure for a NetworkAdapter resource (the NIC/HCA).
+type RedfishNetworkAdapter struct {
+	CommonRedfishProperties
+	Name        string `json:"Name,omitempty"`
+	Controllers []struct {
+		FirmwarePackageVersion string `json:"FirmwarePackageVersion,omitempty"`
+	} `json:"Controllers"`
+	Ports                  ODataLink       `json:"Ports"`
+	NetworkPorts           ODataLink       `json:"NetworkPorts"`
+	NetworkDeviceFunctions ODataLink       `json:"NetworkDeviceFunctions"`
+	Oem                    json.RawMessage `json:"Oem,omitempty"`
+}
+
+// RedfishNetworkDeviceFunction carries the addresses assigned to one adapter function.
+type RedfishNetworkDeviceFunction struct {
+	NetDevFuncType string `json:"NetDevFuncType,omitempty"`
+	Ethernet       struct {
+		MACAddress          string `json:"MACAddress,omitempty"`
+		PermanentMACAddress string `json:"PermanentMACAddress,omitempty"`
+	} `json:"Ethernet"`
+	InfiniBand struct {
+		PortGUID          string `json:"PortGUID,omitempty"`
+		NodeGUID          string `json:"NodeGUID,omitempty"`
+		PermanentPortGUID string `json:"PermanentPortGUID,omitempty"`
+		PermanentNodeGUID string `json:"PermanentNodeGUID,omitempty"`
+	} `json:"InfiniBand"`
+	Links struct {
+		// OffloadSystem points at the ComputerSystem of a DPU behind this function.
+		OffloadSystem ODataLink `json:"OffloadSystem"`
+	} `json:"Links"`
+}
+
+// RedfishPort carries the link technology and addresses of a Port (or legacy NetworkPort).
+type RedfishPort struct {
+	ID                    string `json:"Id,omitempty"`
+	LinkNetworkTechnology string `json:"LinkNetworkTechnology,omitempty"`
+	ActiveLinkTechnology  string `json:"ActiveLinkTechnology,omitempty"` // legacy NetworkPort
+	// AssociatedNetworkAddresses is the legacy NetworkPort field (MACs or GUIDs).
+	AssociatedNetworkAddresses []string `json:"AssociatedNetworkAddresses,omitempty"`
+	Ethernet                   struct {
+		AssociatedMACAddresses []string `json:"AssociatedMACAddresses,omitempty"`
+		// LLDPReceive is what the port last received from its LLDP neighbor.
+		LLDPReceive *RedfishLLDPReceive `json:"LLDPReceive,omitempty"`
+	} `json:"Ethernet"`
+	InfiniBand struct {
+		AssociatedPortGUIDs []string `json:"AssociatedPortGUIDs,omitempty"`
+		AssociatedNodeGUIDs []string `json:"AssociatedNodeGUIDs,omitempty"`
+	} `json:"InfiniBand"`
+	Oem json.RawMessage `json:"Oem,omitempty"`
+}
+
+// RedfishLLDPReceive defines the LLDP data a Port received from its link partner.
+type RedfishLLDPReceive struct {
+	ChassisID             string `json:"ChassisId,omitempty"`
+	ChassisIDSubtype      string `json:"ChassisIdSubtype,omitempty"`
+	PortID                string `json:"PortId,omitempty"`
+	PortIDSubtype         string `json:"PortIdSubtype,omitempty"`
+	SystemName            string `json:"SystemName,omitempty"`
+	ManagementAddressIPv4 string `json:"ManagementAddressIPv4,omitempty"`
+	ManagementAddressIPv6 string `json:"ManagementAddressIPv6,omitempty"`
+}
+
+// RedfishStorage defines the parts of a Storage subsystem used to reach its drives.
+type RedfishStorage struct {
+	Drives []ODataLink `json:"Drives"`
+}
+
+// RedfishDrive defines the structure for a Drive resource.
+type RedfishDrive struct {
+	CommonRedfishProperties
+	Protocol                      string    `json:"Protocol,omitempty"`
+	MediaType                     string    `json:"MediaType,omitempty"`
+	CapacityBytes                 int64     `json:"CapacityBytes,omitempty"`
+	FormFactor                    string    `json:"FormFactor,omitempty"`
+	Revision                      string    `json:"Revision,omitempty"`
+	PredictedMediaLifeLeftPercent *float64  `json:"PredictedMediaLifeLeftPercent,omitempty"`
+	Metrics                       ODataLink `json:"Metrics"`
+	Links                         struct {
+		Volumes []ODataLink `json:"Volumes"`
+	} `json:"Links"`
+	Oem json.RawMessage `json:"Oem,omitempty"`
+}
+
+// RedfishDriveMetrics carries NVMe SMART data for a drive.
+type RedfishDriveMetrics struct {
+	NVMeSMART struct {
+		PercentageUsed *float64 `json:"PercentageUsed,omitempty"`
+	} `json:"NVMeSMART"`
+}
+
+// RedfishVolume carries the size of a volume (an NVMe namespace for NVMe drives).
+type RedfishVolume struct {
+	CapacityBytes int64 `json:"CapacityBytes,omitempty"`
+}
+
+// RedfishLocation carries the physical placement of a resource.
+type RedfishLocation struct {
+	Placement struct {
+		Row             string `json:"Row,omitempty"`
+		Rack            string `json:"Rack,omitempty"`
+		RackOffset      *int   `json:"RackOffset,omitempty"`
+		RackOffsetUnits string `json:"RackOffsetUnits,omitempty"`
+	} `json:"Placement"`
+	PartLocation struct {
+		ServiceLabel string `json:"ServiceLabel,omitempty"`
+	} `json:"PartLocation"`
+}
+
+// RedfishPowerEquipment is the /PowerEquipment service root for power infrastructure.
+type RedfishPowerEquipment struct {
+	RackPDUs ODataLink `json:"RackPDUs"`
+}
+
+// RedfishPowerDistribution defines the structure for a PowerDistribution resource (the PDU).
+type RedfishPowerDistribution struct {
+	CommonRedfishProperties
+	Name            string          `json:"Name,omitempty"`
+	EquipmentType   string          `json:"EquipmentType,omitempty"`
+	FirmwareVersion string          `json:"FirmwareVersion,omitempty"`
+	Location        RedfishLocation `json:"Location"`
+	Branches        ODataLink       `json:"Branches"`
+	Outlets         ODataLink       `json:"Outlets"`
+}
+
+// RedfishCircuit defines the structure for a Circuit resource (a PDU branch circuit).
+type RedfishCircuit struct {
+	ID               string   `json:"Id"`
+	Name             string   `json:"Name,omitempty"`
+	CircuitType      string   `json:"CircuitType,omitempty"`
+	PhaseWiringType  string   `json:"PhaseWiringType,omitempty"`
+	NominalVoltage   string   `json:"NominalVoltage,omitempty"`
+	RatedCurrentAmps *float64 `json:"RatedCurrentAmps,omitempty"`
+	BreakerState     string   `json:"BreakerState,omitempty"`
+}
+
+// RedfishOutlet defines the structure for an Outlet resource.
+type RedfishOutlet struct {
+	ID               string   `json:"Id"`
+	Name             string   `json:"Name,omitempty"`
+	UserLabel        string   `json:"UserLabel,omitempty"`
+	OutletType       string   `json:"OutletType,omitempty"`
+	PhaseWiringType  string   `json:"PhaseWiringType,omitempty"`
+	NominalVoltage   string   `json:"NominalVoltage,omitempty"`
+	RatedCurrentAmps *float64 `json:"RatedCurrentAmps,omitempty"`
+	PowerState       string   `json:"PowerState,omitempty"`
+	Links            struct {
+		BranchCircuit ODataLink `json:"BranchCircuit"`
+	} `json:"Links"`
+}
+
+// RedfishThermalEquipment is the /ThermalEquipment service root for cooling infrastructure.
+type RedfishThermalEquipment struct {
+	CDUs         ODataLink `json:"CDUs"`
+	CoolingLoops ODataLink `json:"CoolingLoops"`
+}
+
+// RedfishCoolingUnit defines the structure for a CoolingUnit resource (the CDU).
+type RedfishCoolingUnit struct {
+	CommonRedfishProperties
+	Name            string          `json:"Name,omitempty"`
+	EquipmentType   string          `json:"EquipmentType,omitempty"`
+	FirmwareVersion string          `json:"FirmwareVersion,omitempty"`
+	Location        RedfishLocation `json:"Location"`
+	Coolant         struct {
+		CoolantType string `json:"CoolantType,omitempty"`
+	} `json:"Coolant"`
+	Pumps ODataLink       `json:"Pumps"`
+	Oem   json.RawMessage `json:"Oem,omitempty"`
+}
+
+// RedfishPump defines the structure for a Pump resource inside a CDU.
+type RedfishPump struct {
+	CommonRedfishProperties
+	ID       string `json:"Id"`
+	PumpType string `json:"PumpType,omitempty"`
+}
+
+// RedfishCoolingLoop defines the structure for a CoolingLoop resource.
+type RedfishCoolingLoop struct {
+	ID      string `json:"Id"`
+	Name    string `json:"Name,omitempty"`
+	Coolant struct {
+		CoolantType string `json:"CoolantType,omitempty"`
+	} `json:"Coolant"`
+	SupplyEquipmentNames    []string `json:"SupplyEquipmentNames,omitempty"`
+	ConsumingEquipmentNames []string `json:"ConsumingEquipmentNames,omitempty"`
+}
+
+// RedfishCompositionService is the entry point for composable (disaggregated) hardware.
+type RedfishCompositionService struct {
+	ResourceBlocks ODataLink `json:"ResourceBlocks"`
+	ResourceZones  ODataLink `json:"ResourceZones"`
+}
+
+// RedfishResourceBlock defines the structure for a ResourceBlock resource.
+type RedfishResourceBlock struct {
+	ID                string   `json:"Id"`
+	Name              string   `json:"Name,omitempty"`
+	ResourceBlockType []string `json:"ResourceBlockType,omitempty"`
+	CompositionStatus struct {
+		CompositionState     string `json:"CompositionState,omitempty"`
+		Reserved             *bool  `json:"Reserved,omitempty"`
+		SharingCapable       *bool  `json:"SharingCapable,omitempty"`
+		NumberOfCompositions *int   `json:"NumberOfCompositions,omitempty"`
+	} `json:"CompositionStatus"`
+	Processors         []ODataLink `json:"Processors"`
+	Memory             []ODataLink `json:"Memory"`
+	Drives             []ODataLink `json:"Drives"`
+	Storage            []ODataLink `json:"Storage"`
+	NetworkInterfaces  []ODataLink `json:"NetworkInterfaces"`
+	EthernetInterfaces []ODataLink `json:"EthernetInterfaces"`
+	ComputerSystems    []ODataLink `json:"ComputerSystems"`
+	Links              struct {
+		Chassis         []ODataLink `json:"Chassis"`
+		ComputerSystems []ODataLink `json:"ComputerSystems"`
+		Zones           []ODataLink `json:"Zones"`
+	} `json:"Links"`
+}
+
+// RedfishResourceZone defines the structure for a resource Zone of the CompositionService.
+type RedfishResourceZone struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name,omitempty"`
+	Links struct {
+		ResourceBlocks []ODataLink `json:"ResourceBlocks"`
+	} `json:"Links"`
+}
+
+// --- Fan and Power Supply Schemas (modern and legacy) ---
+
+// RedfishThermalSubsystem is the modern (2020.4+) thermal model.
+type RedfishThermalSubsystem struct {
+	Fans ODataLink `json:"Fans"`
+}
+
+// RedfishFan defines the structure for a Fan resource (modern model).
+type RedfishFan struct {
+	CommonRedfishProperties
+	ID   string `json:"Id"`
+	Name string `json:"Name,omitempty"`
+}
+
+// RedfishLegacyThermal is the deprecated Thermal resource with embedded fan objects.
+type RedfishLegacyThermal struct {
+	Fans []struct {
+		CommonRedfishProperties
+		ODataID  string `json:"@odata.id"`
+		MemberID string `json:"MemberId"`
+		Name     string `json:"Name,omitempty"`
+		FanName  string `json:"FanName,omitempty"` // pre-1.1 name field
+	} `json:"Fans"`
+}
+
+// RedfishPowerSubsystem is the modern (2020.4+) power model.
+type RedfishPowerSubsystem struct {
+	CapacityWatts *float64 `json:"CapacityWatts,omitempty"`
+	Allocation    struct {
+		AllocatedWatts *float64 `json:"AllocatedWatts,omitempty"`
+	} `json:"Allocation"`
+	PowerSupplies ODataLink `json:"PowerSupplies"`
+}
+
+// RedfishEnvironmentMetrics is the modern chassis power reading and limit.
+type RedfishEnvironmentMetrics struct {
+	PowerWatts *struct {
+		Reading       *float64 `json:"Reading,omitempty"`
+		DataSourceURI string   `json:"DataSourceUri,omitempty"`
+	} `json:"PowerWatts,omitempty"`
+	PowerLimitWatts *struct {
+		SetPoint     *float64 `json:"SetPoint,omitempty"`
+		AllowableMin *float64 `json:"AllowableMin,omitempty"`
+		AllowableMax *float64 `json:"AllowableMax,omitempty"`
+		// ControlMode is "Disabled" when the limit is not enforced.
+		ControlMode string `json:"ControlMode,omitempty"`
+	} `json:"PowerLimitWatts,omitempty"`
+}
+
+// RedfishSensor defines the parts of a Sensor resource used for its peak reading.
+type RedfishSensor struct {
+	Reading     *float64 `json:"Reading,omitempty"`
+	PeakReading *float64 `json:"PeakReading,omitempty"`
+}
+
+// RedfishPowerSupply defines the structure for a PowerSupply resource (modern model).
+type RedfishPowerSupply struct {
+	CommonRedfishProperties
+	ID                 string   `json:"Id"`
+	Name               string   `json:"Name,omitempty"`
+	FirmwareVersion    string   `json:"FirmwareVersion,omitempty"`
+	PowerCapacityWatts *float64 `json:"PowerCapacityWatts,omitempty"`
+	PowerSupplyType    string   `json:"PowerSupplyType,omitempty"`
+}
+
+// RedfishLegacyPower is the deprecated Power resource with embedded supplies and PowerControl.
+type RedfishLegacyPower struct {
+	PowerControl []struct {
+		PowerCapacityWatts  *float64 `json:"PowerCapacityWatts,omitempty"`
+		PowerConsumedWatts  *float64 `json:"PowerConsumedWatts,omitempty"`
+		PowerAllocatedWatts *float64 `json:"PowerAllocatedWatts,omitempty"`
+		PowerMetrics        struct {
+			MaxConsumedWatts *float64 `json:"MaxConsumedWatts,omitempty"`
+		} `json:"PowerMetrics"`
+		// PowerLimit.LimitInWatts is null when no limit is set.
+		PowerLimit struct {
+			LimitInWatts *float64 `json:"LimitInWatts,omitempty"`
+		} `json:"PowerLimit"`
+	} `json:"PowerControl"`
+	PowerSupplies []struct {
+		CommonRedfishProperties
+		ODataID            string   `json:"@odata.id"`
+		MemberID           string   `json:"MemberId"`
+		Name               string   `json:"Name,omitempty"`
+		FirmwareVersion    string   `json:"FirmwareVersion,omitempty"`
+		PowerCapacityWatts *float64 `json:"PowerCapacityWatts,omitempty"`
+		PowerSupplyType    string   `json:"PowerSupplyType,omitempty"`
+	} `json:"PowerSupplies"`
+}
+
+// RedfishSimpleStorage is the legacy storage model with embedded devices.
+type RedfishSimpleStorage struct {
+	Devices []struct {
+		CommonRedfishProperties
+		Name          string `json:"Name,omitempty"`
+		CapacityBytes int64  `json:"CapacityBytes,omitempty"`
+	} `json:"Devices"`
+}
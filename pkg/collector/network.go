@@ -0,0 +1,271 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/cabling"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Network Adapter Discovery (Ethernet, InfiniBand, Slingshot) ---
+
+// getNetworkAdapterDevices walks the NetworkAdapters of every chassis linked to a
+// system and maps each adapter to a NIC device parented to the node.
+func getNetworkAdapterDevices(c *RedfishClient, chassisLinks []ODataLink, parentURI, parentSerial string) []*device.DeviceSpec {
+	var specs []*device.DeviceSpec
+	// Skipping the subtree saves reading the chassis too
+	if c.shape.skip[subtreeNetworkAdapters] {
+		return specs
+	}
+	for _, chassisLink := range chassisLinks {
+		chassisURI := strings.TrimPrefix(chassisLink.ODataID, "/redfish/v1")
+		chassisBody, err := c.Get(chassisURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get chassis %s: %v\n", chassisLink.ODataID, err)
+			continue
+		}
+		var chassis RedfishChassis
+		if err := json.Unmarshal(chassisBody, &chassis); err != nil {
+			fmt.Printf("Warning: Failed to decode chassis %s: %v\n", chassisURI, err)
+			continue
+		}
+		adaptersURI := c.probeLink(subtreeNetworkAdapters, chassis.NetworkAdapters)
+		if adaptersURI == "" {
+			continue
+		}
+
+		adapterURIs, err := getCollectionMembers(c, adaptersURI)
+		c.noteSubtree(subtreeNetworkAdapters, err)
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve network adapters from %s: %v\n", chassis.NetworkAdapters.ODataID, err)
+			continue
+		}
+		for _, adapterURI := range adapterURIs {
+			spec, err := getNetworkAdapterDevice(c, adapterURI, parentURI, parentSerial)
+			if err != nil {
+				fmt.Printf("Warning: Failed to map network adapter %s: %v\n", adapterURI, err)
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// getNetworkAdapterDevice maps one NetworkAdapter, including the MAC addresses and
+// InfiniBand GUIDs found on its ports and device functions.
+func getNetworkAdapterDevice(c *RedfishClient, adapterURI, parentURI, parentSerial string) (*device.DeviceSpec, error) {
+	body, err := c.Get(adapterURI)
+	if err != nil {
+		return nil, err
+	}
+	var adapter RedfishNetworkAdapter
+	if err := json.Unmarshal(body, &adapter); err != nil {
+		return nil, fmt.Errorf("failed to decode network adapter: %w", err)
+	}
+
+	spec := mapCommonProperties(adapter.CommonRedfishProperties, "NIC", adapterURI, parentURI, parentSerial)
+
+	addrs := &adapterAddresses{macs: map[string]bool{}, portGUIDs: map[string]bool{}, nodeGUIDs: map[string]bool{}}
+	var portOEM []json.RawMessage
+	var offloadSystem string
+
+	// Ports (current schema) and NetworkPorts (deprecated) both describe link technology
+	for _, portsLink := range []ODataLink{adapter.Ports, adapter.NetworkPorts} {
+		if portsLink.ODataID == "" {
+			continue
+		}
+		portURIs, err := getCollectionMembers(c, strings.TrimPrefix(portsLink.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve ports from %s: %v\n", portsLink.ODataID, err)
+			continue
+		}
+		for _, portURI := range portURIs {
+			portBody, err := c.Get(portURI)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get port %s: %v\n", portURI, err)
+				continue
+			}
+			var port RedfishPort
+			if err := json.Unmarshal(portBody, &port); err != nil {
+				fmt.Printf("Warning: Failed to decode port %s: %v\n", portURI, err)
+				continue
+			}
+			addrs.addPort(port)
+			if len(port.Oem) > 0 {
+				portOEM = append(portOEM, port.Oem)
+			}
+		}
+	}
+
+	if adapter.NetworkDeviceFunctions.ODataID != "" {
+		funcURIs, err := getCollectionMembers(c, strings.TrimPrefix(adapter.NetworkDeviceFunctions.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve device functions from %s: %v\n", adapter.NetworkDeviceFunctions.ODataID, err)
+		}
+		for _, funcURI := range funcURIs {
+			funcBody, err := c.Get(funcURI)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get device function %s: %v\n", funcURI, err)
+				continue
+			}
+			var fn RedfishNetworkDeviceFunction
+			if err := json.Unmarshal(funcBody, &fn); err != nil {
+				fmt.Printf("Warning: Failed to decode device function %s: %v\n", funcURI, err)
+				continue
+			}
+			addrs.addFunction(fn)
+			if fn.Links.OffloadSystem.ODataID != "" && offloadSystem == "" {
+				offloadSystem = strings.TrimPrefix(fn.Links.OffloadSystem.ODataID, "/redfish/v1")
+			}
+		}
+	}
+
+	setProperty(spec, "fabric", classifyFabric(adapter, addrs))
+	if len(addrs.technologies) > 0 {
+		setProperty(spec, "link_technology", addrs.technologies[0])
+	}
+	if macs := sortedKeys(addrs.macs); len(macs) > 0 {
+		setProperty(spec, "mac_addresses", macs)
+	}
+	if guids := sortedKeys(addrs.portGUIDs); len(guids) > 0 {
+		setProperty(spec, "port_guids", guids)
+	}
+	if len(addrs.neighbors) > 0 {
+		setProperty(spec, "lldp_neighbors", addrs.neighbors)
+	}
+	if guids := sortedKeys(addrs.nodeGUIDs); len(guids) > 0 {
+		setProperty(spec, "node_guid", guids[0])
+	}
+	if len(adapter.Controllers) > 0 && adapter.Controllers[0].FirmwarePackageVersion != "" {
+		setProperty(spec, "firmware_version", adapter.Controllers[0].FirmwarePackageVersion)
+	}
+	if len(adapter.Oem) > 0 {
+		spec.Properties["oem"] = adapter.Oem
+	}
+	if len(portOEM) > 0 {
+		setProperty(spec, "port_oem", portOEM)
+	}
+	if offloadSystem != "" {
+		setProperty(spec, "offload_system_uri", offloadSystem)
+	}
+	return spec, nil
+}
+
+// adapterAddresses accumulates the identifiers found across an adapter's ports and functions.
+type adapterAddresses struct {
+	technologies []string
+	macs         map[string]bool
+	portGUIDs    map[string]bool
+	nodeGUIDs    map[string]bool
+	// neighbors are the LLDP neighbors the ports see, for cabling inference
+	neighbors []cabling.Neighbor
+}
+
+func (a *adapterAddresses) addTechnology(tech string) {
+	if tech == "" {
+		return
+	}
+	for _, t := range a.technologies {
+		if t == tech {
+			return
+		}
+	}
+	a.technologies = append(a.technologies, tech)
+}
+
+func (a *adapterAddresses) addPort(port RedfishPort) {
+	tech := port.LinkNetworkTechnology
+	if tech == "" {
+		tech = port.ActiveLinkTechnology
+	}
+	a.addTechnology(tech)
+	for _, mac := range port.Ethernet.AssociatedMACAddresses {
+		addAddress(a.macs, mac)
+	}
+	for _, guid := range port.InfiniBand.AssociatedPortGUIDs {
+		addAddress(a.portGUIDs, guid)
+	}
+	for _, guid := range port.InfiniBand.AssociatedNodeGUIDs {
+		addAddress(a.nodeGUIDs, guid)
+	}
+	if lldp := port.Ethernet.LLDPReceive; lldp != nil && (lldp.ChassisID != "" || lldp.PortID != "" || lldp.SystemName != "") {
+		var mac string
+		if len(port.Ethernet.AssociatedMACAddresses) > 0 {
+			mac = strings.ToLower(port.Ethernet.AssociatedMACAddresses[0])
+		}
+		a.neighbors = append(a.neighbors, cabling.Neighbor{
+			Port:              port.ID,
+			MACAddress:        mac,
+			ChassisID:         lldp.ChassisID,
+			PortID:            lldp.PortID,
+			PortIDSubtype:     lldp.PortIDSubtype,
+			SystemName:        lldp.SystemName,
+			ManagementAddress: firstNonEmpty(lldp.ManagementAddressIPv4, lldp.ManagementAddressIPv6),
+		})
+	}
+	// Legacy NetworkPorts put MACs and GUIDs in the same list
+	for _, addr := range port.AssociatedNetworkAddresses {
+		if strings.EqualFold(tech, "InfiniBand") {
+			addAddress(a.portGUIDs, addr)
+		} else {
+			addAddress(a.macs, addr)
+		}
+	}
+}
+
+func (a *adapterAddresses) addFunction(fn RedfishNetworkDeviceFunction) {
+	a.addTechnology(fn.NetDevFuncType)
+	addAddress(a.macs, firstNonEmpty(fn.Ethernet.PermanentMACAddress, fn.Ethernet.MACAddress))
+	addAddress(a.portGUIDs, firstNonEmpty(fn.InfiniBand.PermanentPortGUID, fn.InfiniBand.PortGUID))
+	addAddress(a.nodeGUIDs, firstNonEmpty(fn.InfiniBand.PermanentNodeGUID, fn.InfiniBand.NodeGUID))
+}
+
+// classifyFabric labels the adapter as InfiniBand, Slingshot, or Ethernet.
+// Slingshot NICs (HPE Cassini) report Ethernet link technology, so they are
+// recognized by their model/name.
+func classifyFabric(adapter RedfishNetworkAdapter, addrs *adapterAddresses) string {
+	ident := strings.ToLower(adapter.Model + " " + adapter.Name + " " + adapter.PartNumber)
+	if strings.Contains(ident, "slingshot") || strings.Contains(ident, "cassini") {
+		return "Slingshot"
+	}
+	for _, tech := range addrs.technologies {
+		if strings.EqualFold(tech, "InfiniBand") {
+			return "InfiniBand"
+		}
+	}
+	if len(addrs.portGUIDs) > 0 {
+		return "InfiniBand"
+	}
+	return "Ethernet"
+}
+
+// addAddress normalizes and records a MAC address or GUID.
+func addAddress(set map[string]bool, addr string) {
+	addr = strings.ToLower(strings.TrimSpace(addr))
+	if addr == "" || addr == "00:00:00:00:00:00" {
+		return
+	}
+	set[addr] = true
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
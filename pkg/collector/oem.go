@@ -0,0 +1,152 @@
+package collector
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Vendor OEM Extensions ---
+//
+// Vendors publish identifiers that plain Redfish omits (service tags, FRU part
+// numbers, board serials) under a resource's Oem.<Vendor> block. An extension
+// maps one vendor's block into device properties. Extensions select themselves
+// by the Oem key, so they apply whatever the identified BMC claims to be.
+
+// oemExtension maps one vendor's Oem blocks into device properties.
+type oemExtension struct {
+	// Key is the vendor's key in Oem blocks, e.g. "Dell".
+	Key string
+	// System enriches a node from its ComputerSystem's Oem.<Key> block. It may
+	// make further requests, except in the quick profile.
+	System func(c *RedfishClient, spec *device.DeviceSpec, system *RedfishSystem, oem json.RawMessage)
+	// Component returns the properties of a processor, DIMM, or drive from its
+	// Oem.<Key> block.
+	Component func(oem json.RawMessage) map[string]interface{}
+	// NICs enriches the NICs of a system with an Oem.<Key> block from the
+	// vendor's own resources. It may make further requests, except in the
+	// quick profile.
+	NICs func(c *RedfishClient, systemURI string, nics []*device.DeviceSpec)
+}
+
+// oemExtensions holds the known vendor extensions.
+var oemExtensions = []*oemExtension{dellExtension, lenovoExtension, supermicroExtension}
+
+// fruProperties maps vendor FRU attribute names (in snake_case) to the
+// vendor-neutral properties they are stored under.
+var fruProperties = map[string]string{
+	"fru_part_number":       "fru_part_number",
+	"fru_serial_number":     "fru_serial_number",
+	"board_part_number":     "board_part_number",
+	"board_serial_number":   "board_serial_number",
+	"board_manufacturer":    "board_manufacturer",
+	"product_part_number":   "product_part_number",
+	"product_serial_number": "product_serial_number",
+	"manufacture_date":      "manufacture_date",
+	"manufacturing_date":    "manufacture_date",
+}
+
+// splitOem returns the vendor blocks of an Oem object by key.
+func splitOem(oem json.RawMessage) map[string]json.RawMessage {
+	if len(oem) == 0 {
+		return nil
+	}
+	var blocks map[string]json.RawMessage
+	if err := json.Unmarshal(oem, &blocks); err != nil {
+		return nil
+	}
+	return blocks
+}
+
+// enrichSystemOem runs the system extensions of every vendor block present.
+func enrichSystemOem(c *RedfishClient, spec *device.DeviceSpec, system *RedfishSystem) {
+	blocks := splitOem(system.Oem)
+	for _, ext := range oemExtensions {
+		if block, ok := blocks[ext.Key]; ok && ext.System != nil {
+			ext.System(c, spec, system, block)
+		}
+	}
+}
+
+// enrichNICOem runs the NIC extensions of every vendor block of the system.
+func enrichNICOem(c *RedfishClient, system *RedfishSystem, systemURI string, nics []*device.DeviceSpec) {
+	if len(nics) == 0 {
+		return
+	}
+	blocks := splitOem(system.Oem)
+	for _, ext := range oemExtensions {
+		if _, ok := blocks[ext.Key]; ok && ext.NICs != nil {
+			ext.NICs(c, systemURI, nics)
+		}
+	}
+}
+
+// componentOemProperties returns the properties mapped from a component's Oem
+// block by the vendor extensions.
+func componentOemProperties(oem json.RawMessage) map[string]interface{} {
+	blocks := splitOem(oem)
+	props := map[string]interface{}{}
+	for _, ext := range oemExtensions {
+		if block, ok := blocks[ext.Key]; ok && ext.Component != nil {
+			for key, value := range ext.Component(block) {
+				props[key] = value
+			}
+		}
+	}
+	return props
+}
+
+// oemAttributeProperties maps the scalar attributes of an OEM block to
+// snake_case properties under prefix. FRU attributes are stored under their
+// vendor-neutral names instead.
+func oemAttributeProperties(prefix string, attributes map[string]json.RawMessage) map[string]interface{} {
+	props := map[string]interface{}{}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// Skip OData annotations, the resource's own identifiers and secrets
+		if strings.Contains(key, "@") || key == "Id" || key == "Name" || key == "Description" || redact.IsSensitiveKey(key) {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(attributes[key], &value); err != nil || value == nil || value == "" {
+			continue
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		}
+		name := snakeCase(key)
+		if fru, ok := fruProperties[name]; ok {
+			props[fru] = value
+		} else {
+			props[prefix+name] = value
+		}
+	}
+	return props
+}
+
+// snakeCase converts a Redfish attribute name such as "CPUFamily" or
+// "Cache1SizeKB" to snake_case ("cpu_family", "cache1_size_kb").
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
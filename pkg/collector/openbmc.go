@@ -0,0 +1,155 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- OpenBMC Support ---
+//
+// bmcweb, the OpenBMC Redfish server, builds resources from D-Bus objects and
+// omits properties whose D-Bus value is empty, so white-box nodes often lack
+// PartNumber, SerialNumber, or Manufacturer. The same objects are published
+// by the phosphor REST API under /xyz/openbmc_project/inventory, sometimes
+// with values bmcweb dropped. Redfish resource IDs are the D-Bus object names,
+// which ties the two together.
+
+// openBMCInventoryPath enumerates the phosphor inventory objects.
+const openBMCInventoryPath = "/xyz/openbmc_project/inventory/enumerate"
+
+// openBMCItem is the part of a phosphor inventory object the collector uses.
+type openBMCItem struct {
+	Manufacturer    string `json:"Manufacturer,omitempty"`
+	Model           string `json:"Model,omitempty"`
+	PartNumber      string `json:"PartNumber,omitempty"`
+	SerialNumber    string `json:"SerialNumber,omitempty"`
+	SparePartNumber string `json:"SparePartNumber,omitempty"`
+}
+
+// getOpenBMCInventory reads the phosphor inventory, keyed by object path.
+func getOpenBMCInventory(c *RedfishClient) (map[string]openBMCItem, error) {
+	targetURL := strings.TrimSuffix(c.BaseURL, "/redfish/v1") + openBMCInventoryPath
+	resp, err := c.do(http.MethodGet, targetURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenBMC returned status code %d for %s", resp.StatusCode, targetURL)
+	}
+	// The enumeration holds every D-Bus property; stream it item by item
+	items := make(map[string]openBMCItem)
+	dec := json.NewDecoder(resp.Body)
+	err = decodeObject(dec, func(key string) error {
+		if key != "data" {
+			return skipValue(dec)
+		}
+		return decodeObject(dec, func(objectPath string) error {
+			var item openBMCItem
+			if err := dec.Decode(&item); err != nil {
+				return err
+			}
+			items[objectPath] = item
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode OpenBMC inventory: %w", err)
+	}
+	return items, nil
+}
+
+// fillFromOpenBMCInventory fills blank identifiers of the mapped devices from
+// the phosphor inventory object with the same name. Names used by more than
+// one object are ambiguous and skipped. It returns how many devices changed.
+func fillFromOpenBMCInventory(c *RedfishClient, specs []*device.DeviceSpec) int {
+	var sparse []*device.DeviceSpec
+	for _, spec := range specs {
+		var model string
+		spec.GetProperty("model", &model)
+		if spec.PartNumber == "" || spec.PartNumber == model || spec.SerialNumber == "" || spec.Manufacturer == "" {
+			sparse = append(sparse, spec)
+		}
+	}
+	if len(sparse) == 0 {
+		return 0
+	}
+	inventory, err := getOpenBMCInventory(c)
+	if err != nil {
+		fmt.Printf("Warning: Failed to read OpenBMC inventory: %v\n", err)
+		return 0
+	}
+	byName := make(map[string]string)
+	for objectPath := range inventory {
+		name := path.Base(objectPath)
+		if _, dup := byName[name]; dup {
+			byName[name] = ""
+			continue
+		}
+		byName[name] = objectPath
+	}
+
+	changed := 0
+	filledSerials := make(map[string]string)
+	for _, spec := range sparse {
+		var uri string
+		spec.GetProperty("redfish_uri", &uri)
+		objectPath := byName[path.Base(uri)]
+		if uri == "" || objectPath == "" {
+			continue
+		}
+		hadSerial := spec.SerialNumber != ""
+		if fillFromOpenBMCItem(spec, inventory[objectPath]) {
+			setProperty(spec, "openbmc_inventory_path", objectPath)
+			changed++
+		}
+		if !hadSerial && spec.SerialNumber != "" {
+			filledSerials[uri] = spec.SerialNumber
+		}
+	}
+
+	// Children were mapped with the parent's blank serial
+	for _, spec := range specs {
+		var parentURI string
+		if spec.ParentSerialNumber == "" && spec.GetProperty("redfish_parent_uri", &parentURI) {
+			if serial, ok := filledSerials[parentURI]; ok {
+				spec.ParentSerialNumber = serial
+			}
+		}
+	}
+	return changed
+}
+
+// fillFromOpenBMCItem fills the blank identifiers of spec from item, and a
+// part number that only repeats the model. It reports whether it changed spec.
+func fillFromOpenBMCItem(spec *device.DeviceSpec, item openBMCItem) bool {
+	changed := false
+	var model string
+	spec.GetProperty("model", &model)
+	if partNumber := firstNonEmpty(item.PartNumber, item.SparePartNumber); partNumber != "" && (spec.PartNumber == "" || spec.PartNumber == model) && partNumber != spec.PartNumber {
+		spec.PartNumber = partNumber
+		changed = true
+	}
+	if spec.PartNumber == "" && item.Model != "" {
+		spec.PartNumber = item.Model
+		changed = true
+	}
+	if model == "" && item.Model != "" {
+		setProperty(spec, "model", item.Model)
+		changed = true
+	}
+	if spec.SerialNumber == "" && item.SerialNumber != "" {
+		spec.SerialNumber = item.SerialNumber
+		changed = true
+	}
+	if spec.Manufacturer == "" && item.Manufacturer != "" {
+		spec.Manufacturer = item.Manufacturer
+		changed = true
+	}
+	return changed
+}
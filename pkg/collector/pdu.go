@@ -0,0 +1,243 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- PDU Discovery (PowerEquipment / RackPDUs) ---
+//
+// PDUs are mapped as:
+//
+//	Rack (from the PDU's Location.Placement)
+//	└── PDU
+//	    └── PDUBranch (branch circuits)
+//	        └── PDUOutlet
+//
+// Branches and outlets have no serial numbers of their own, so they are
+// identified by the PDU serial plus their Redfish Id.
+
+// discoverPDUDevices walks /PowerEquipment/RackPDUs.
+func discoverPDUDevices(c *RedfishClient) ([]*device.DeviceSpec, error) {
+	body, err := c.Get("/PowerEquipment")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PowerEquipment: %w", err)
+	}
+	var equipment RedfishPowerEquipment
+	if err := json.Unmarshal(body, &equipment); err != nil {
+		return nil, fmt.Errorf("failed to decode PowerEquipment: %w", err)
+	}
+	if equipment.RackPDUs.ODataID == "" {
+		return nil, fmt.Errorf("service does not expose PowerEquipment/RackPDUs")
+	}
+
+	pduURIs, err := getCollectionMembers(c, strings.TrimPrefix(equipment.RackPDUs.ODataID, "/redfish/v1"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RackPDUs collection: %w", err)
+	}
+
+	var specs []*device.DeviceSpec
+	racks := make(map[string]bool)
+	for _, pduURI := range pduURIs {
+		pduSpecs, rack, err := getPDUDevices(c, pduURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to map PDU %s: %v\n", pduURI, err)
+			continue
+		}
+		if rack != nil {
+			if !racks[rack.SerialNumber] {
+				racks[rack.SerialNumber] = true
+				specs = append(specs, rack)
+			}
+		}
+		specs = append(specs, pduSpecs...)
+	}
+	return specs, nil
+}
+
+// getPDUDevices maps one PDU with its branches and outlets, and returns the
+// rack it is placed in (nil when the PDU reports no rack).
+func getPDUDevices(c *RedfishClient, pduURI string) ([]*device.DeviceSpec, *device.DeviceSpec, error) {
+	body, err := c.Get(pduURI)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pdu RedfishPowerDistribution
+	if err := json.Unmarshal(body, &pdu); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode PDU: %w", err)
+	}
+
+	rack := rackSpec(pdu.Location)
+	rackSerial := ""
+	if rack != nil {
+		rackSerial = rack.SerialNumber
+	}
+
+	pduSpec := mapCommonProperties(pdu.CommonRedfishProperties, "PDU", pduURI, "", rackSerial)
+	setLocationProperties(pduSpec, pdu.Location)
+	if pdu.EquipmentType != "" {
+		setProperty(pduSpec, "equipment_type", pdu.EquipmentType)
+	}
+	if pdu.FirmwareVersion != "" {
+		setProperty(pduSpec, "firmware_version", pdu.FirmwareVersion)
+	}
+	if pdu.Name != "" {
+		setProperty(pduSpec, "name", pdu.Name)
+	}
+	specs := []*device.DeviceSpec{pduSpec}
+	if pdu.SerialNumber == "" {
+		fmt.Printf("Warning: PDU %s reports no serial number; branches and outlets cannot be linked to it\n", pduURI)
+	}
+
+	// Branch circuits, keyed by URI so outlets can find their branch
+	branchSerials := make(map[string]string)
+	if pdu.Branches.ODataID != "" {
+		branchURIs, err := getCollectionMembers(c, strings.TrimPrefix(pdu.Branches.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve branches from %s: %v\n", pdu.Branches.ODataID, err)
+		}
+		for _, branchURI := range branchURIs {
+			branchBody, err := c.Get(branchURI)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get branch %s: %v\n", branchURI, err)
+				continue
+			}
+			var circuit RedfishCircuit
+			if err := json.Unmarshal(branchBody, &circuit); err != nil {
+				fmt.Printf("Warning: Failed to decode branch %s: %v\n", branchURI, err)
+				continue
+			}
+			spec := &device.DeviceSpec{
+				DeviceType:         "PDUBranch",
+				Manufacturer:       pdu.Manufacturer,
+				SerialNumber:       componentSerial(pdu.SerialNumber, "branch", circuit.ID),
+				ParentSerialNumber: pdu.SerialNumber,
+			}
+			setProperty(spec, "redfish_uri", branchURI)
+			setProperty(spec, "redfish_parent_uri", pduURI)
+			setCircuitProperties(spec, circuit.Name, circuit.PhaseWiringType, circuit.NominalVoltage, circuit.RatedCurrentAmps)
+			if circuit.CircuitType != "" {
+				setProperty(spec, "circuit_type", circuit.CircuitType)
+			}
+			if circuit.BreakerState != "" {
+				setProperty(spec, "breaker_state", circuit.BreakerState)
+			}
+			branchSerials[branchURI] = spec.SerialNumber
+			specs = append(specs, spec)
+		}
+	}
+
+	if pdu.Outlets.ODataID != "" {
+		outletURIs, err := getCollectionMembers(c, strings.TrimPrefix(pdu.Outlets.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to retrieve outlets from %s: %v\n", pdu.Outlets.ODataID, err)
+		}
+		for _, outletURI := range outletURIs {
+			outletBody, err := c.Get(outletURI)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get outlet %s: %v\n", outletURI, err)
+				continue
+			}
+			var outlet RedfishOutlet
+			if err := json.Unmarshal(outletBody, &outlet); err != nil {
+				fmt.Printf("Warning: Failed to decode outlet %s: %v\n", outletURI, err)
+				continue
+			}
+
+			// Outlets hang off their branch circuit when the PDU reports one
+			parentURI, parentSerial := pduURI, pdu.SerialNumber
+			branchURI := strings.TrimPrefix(outlet.Links.BranchCircuit.ODataID, "/redfish/v1")
+			if serial, ok := branchSerials[branchURI]; ok {
+				parentURI, parentSerial = branchURI, serial
+			}
+
+			spec := &device.DeviceSpec{
+				DeviceType:         "PDUOutlet",
+				Manufacturer:       pdu.Manufacturer,
+				SerialNumber:       componentSerial(pdu.SerialNumber, "outlet", outlet.ID),
+				ParentSerialNumber: parentSerial,
+			}
+			setProperty(spec, "redfish_uri", outletURI)
+			setProperty(spec, "redfish_parent_uri", parentURI)
+			setCircuitProperties(spec, outlet.Name, outlet.PhaseWiringType, outlet.NominalVoltage, outlet.RatedCurrentAmps)
+			if outlet.OutletType != "" {
+				setProperty(spec, "outlet_type", outlet.OutletType)
+			}
+			if outlet.UserLabel != "" {
+				setProperty(spec, "user_label", outlet.UserLabel)
+			}
+			if outlet.PowerState != "" {
+				setProperty(spec, "power_state", outlet.PowerState)
+			}
+			specs = append(specs, spec)
+		}
+	}
+	return specs, rack, nil
+}
+
+// rackSpec builds the Rack device for a Location, or nil if no rack is given.
+// Racks are not Redfish resources, so both their serial and redfish_uri are
+// derived from the row and rack names.
+func rackSpec(loc RedfishLocation) *device.DeviceSpec {
+	rack := loc.Placement.Rack
+	if rack == "" {
+		return nil
+	}
+	key := rack
+	if loc.Placement.Row != "" {
+		key = loc.Placement.Row + "/" + rack
+	}
+	spec := &device.DeviceSpec{
+		DeviceType:   "Rack",
+		SerialNumber: "rack:" + key,
+	}
+	setProperty(spec, "redfish_uri", "location:/racks/"+key)
+	setProperty(spec, "rack", rack)
+	if loc.Placement.Row != "" {
+		setProperty(spec, "row", loc.Placement.Row)
+	}
+	return spec
+}
+
+// setLocationProperties records the rack placement of a device.
+func setLocationProperties(spec *device.DeviceSpec, loc RedfishLocation) {
+	if loc.Placement.Rack != "" {
+		setProperty(spec, "rack", loc.Placement.Rack)
+	}
+	if loc.Placement.Row != "" {
+		setProperty(spec, "row", loc.Placement.Row)
+	}
+	if loc.Placement.RackOffset != nil {
+		setProperty(spec, "rack_offset", *loc.Placement.RackOffset)
+	}
+	if loc.Placement.RackOffsetUnits != "" {
+		setProperty(spec, "rack_offset_units", loc.Placement.RackOffsetUnits)
+	}
+}
+
+// setCircuitProperties records the electrical ratings shared by branches and outlets.
+func setCircuitProperties(spec *device.DeviceSpec, name, wiring, voltage string, ratedAmps *float64) {
+	if name != "" {
+		setProperty(spec, "name", name)
+	}
+	if wiring != "" {
+		setProperty(spec, "phase_wiring_type", wiring)
+	}
+	if voltage != "" {
+		setProperty(spec, "nominal_voltage", voltage)
+	}
+	if ratedAmps != nil {
+		setProperty(spec, "rated_current_amps", *ratedAmps)
+	}
+}
+
+// componentSerial derives a stable identifier for a serial-less sub-component.
+func componentSerial(parentSerial, kind, id string) string {
+	if parentSerial == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s:%s", parentSerial, kind, id)
+}
@@ -0,0 +1,204 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Collector Plugins ---
+//
+// A plugin is an executable that discovers equipment the built-in backends do
+// not know (custom chassis managers, lab instruments). It is registered as a
+// backend, so it is selected like one; the collector still resolves the
+// endpoint settings and credentials and posts the snapshot.
+//
+// The collector runs the plugin once per collection and writes one JSON-RPC
+// 2.0 request to its stdin:
+//
+//	{"jsonrpc": "2.0", "id": 1, "method": "discover",
+//	 "params": {"address": "10.0.0.5", "username": "root", "password": "...", "profile": "full"}}
+//
+// The plugin writes one response to stdout and exits. The result lists the
+// devices found, in the same form as the built-in backends:
+//
+//	{"jsonrpc": "2.0", "id": 1, "result": {"devices": [
+//	  {"deviceType": "Node", "serialNumber": "...", "properties": {"redfish_uri": "/lab/scope1"}}]}}
+//
+// or an error: {"jsonrpc": "2.0", "id": 1, "error": {"code": 1, "message": "..."}}.
+// Anything the plugin writes to stderr is passed through as log output, with
+// the password and credential patterns masked.
+
+// DefaultPluginTimeout bounds a plugin run.
+const DefaultPluginTimeout = 10 * time.Minute
+
+// PluginTimeout bounds a plugin run; a plugin still running is killed.
+var PluginTimeout = DefaultPluginTimeout
+
+// plugins maps the backend names registered by RegisterPlugin to their executables.
+var plugins = make(map[string]string)
+
+// pluginRequest is the JSON-RPC request sent to a plugin.
+type pluginRequest struct {
+	JSONRPC string       `json:"jsonrpc"`
+	ID      int          `json:"id"`
+	Method  string       `json:"method"`
+	Params  PluginParams `json:"params"`
+}
+
+// PluginParams are the parameters of the "discover" call.
+type PluginParams struct {
+	Address  string `json:"address"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Profile  string `json:"profile"`
+}
+
+// pluginResponse is the JSON-RPC response read from a plugin.
+type pluginResponse struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Result  *struct {
+		Devices []*device.DeviceSpec `json:"devices"`
+	} `json:"result,omitempty"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// RegisterPlugin registers the executable at path as the backend name.
+func RegisterPlugin(name, path string) {
+	plugins[name] = path
+	RegisterBackend(name, func(c *RedfishClient) ([]*device.DeviceSpec, error) {
+		return runPlugin(name, path, c)
+	})
+}
+
+// IsPlugin reports whether the backend name is a plugin. Plugins talk to
+// their equipment themselves, so the Redfish client is not identified.
+func IsPlugin(name string) bool {
+	_, ok := plugins[name]
+	return ok
+}
+
+// LoadPlugins registers every executable in dir as a backend named after the
+// file, without its extension. A missing directory loads nothing.
+func LoadPlugins(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory %s: %w", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || !isExecutable(entry.Name(), info.Mode()) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if _, builtin := backends[name]; builtin && !IsPlugin(name) {
+			fmt.Printf("Warning: Plugin %s shadows the built-in %s backend; skipping it\n", entry.Name(), name)
+			continue
+		}
+		RegisterPlugin(name, filepath.Join(dir, entry.Name()))
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// isExecutable reports whether a plugin directory entry can be run. Windows
+// has no execute permission, so the extension decides there.
+func isExecutable(name string, mode os.FileMode) bool {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".exe", ".bat", ".cmd", ".com":
+			return true
+		}
+		return false
+	}
+	return mode.Perm()&0o111 != 0
+}
+
+// runPlugin runs a plugin's "discover" call for the client's BMC.
+func runPlugin(name, path string, c *RedfishClient) ([]*device.DeviceSpec, error) {
+	address := c.BaseURL
+	if u, err := url.Parse(c.BaseURL); err == nil && u.Host != "" {
+		address = u.Host
+	}
+	request, err := json.Marshal(pluginRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "discover",
+		Params: PluginParams{
+			Address:  address,
+			Username: c.Username,
+			Password: c.Password,
+			Profile:  c.Profile,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), PluginTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(append(request, '\n'))
+	stderr := redact.NewWriter(os.Stderr, c.Password)
+	defer stderr.Close()
+	cmd.Stderr = stderr
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	fmt.Printf("Running collector plugin %s (%s)\n", name, path)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("plugin %s timed out after %s", name, PluginTimeout)
+		}
+		// A plugin may exit non-zero after reporting a JSON-RPC error
+		if stdout.Len() == 0 {
+			return nil, fmt.Errorf("plugin %s failed: %w", name, err)
+		}
+	}
+
+	var response pluginResponse
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response from plugin %s: %w", name, err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("plugin %s: %s (code %d)", name, redact.String(response.Error.Message, c.Password), response.Error.Code)
+	}
+	if response.Result == nil {
+		return nil, fmt.Errorf("plugin %s returned neither a result nor an error", name)
+	}
+
+	specs := make([]*device.DeviceSpec, 0, len(response.Result.Devices))
+	for i, spec := range response.Result.Devices {
+		if spec == nil || spec.DeviceType == "" {
+			fmt.Printf("Warning: Plugin %s device %d has no deviceType; skipping it\n", name, i)
+			continue
+		}
+		var uri string
+		if !spec.GetProperty("redfish_uri", &uri) || uri == "" {
+			fmt.Printf("Warning: Plugin %s device %d has no redfish_uri property; skipping it\n", name, i)
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
@@ -0,0 +1,136 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Power Capacity ---
+//
+// Rack power is budgeted from what each node can draw: the rated output of
+// its power supplies, the capacity its chassis reports, the power limit its
+// BMC enforces and the most it has been measured drawing. Legacy firmware
+// reports these in Power.PowerControl; modern firmware in PowerSubsystem
+// and the chassis' EnvironmentMetrics, whose power sensor keeps the peak.
+
+// powerBudget is a schema-neutral view of a node's power capacity and draw.
+type powerBudget struct {
+	// capacityWatts and psuRatedWatts add up across the node's chassis; the
+	// other values are those of the first chassis reporting them.
+	capacityWatts    *float64
+	psuRatedWatts    *float64
+	allocatedWatts   *float64
+	limitWatts       *float64
+	limitMinWatts    *float64
+	limitMaxWatts    *float64
+	limitEnabled     *bool
+	consumedWatts    *float64
+	maxConsumedWatts *float64
+}
+
+// add merges the budget of another chassis of the node.
+func (b *powerBudget) add(other powerBudget) {
+	b.capacityWatts = sumWatts(b.capacityWatts, other.capacityWatts)
+	b.psuRatedWatts = sumWatts(b.psuRatedWatts, other.psuRatedWatts)
+	for _, pair := range []struct{ into, from **float64 }{
+		{&b.allocatedWatts, &other.allocatedWatts},
+		{&b.limitWatts, &other.limitWatts},
+		{&b.limitMinWatts, &other.limitMinWatts},
+		{&b.limitMaxWatts, &other.limitMaxWatts},
+		{&b.consumedWatts, &other.consumedWatts},
+		{&b.maxConsumedWatts, &other.maxConsumedWatts},
+	} {
+		if *pair.into == nil {
+			*pair.into = *pair.from
+		}
+	}
+	if b.limitEnabled == nil {
+		b.limitEnabled = other.limitEnabled
+	}
+}
+
+func sumWatts(a, b *float64) *float64 {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	total := *a + *b
+	return &total
+}
+
+// setProperties records the budget on the node.
+func (b powerBudget) setProperties(spec *device.DeviceSpec) {
+	for _, value := range []struct {
+		key   string
+		watts *float64
+	}{
+		{"power_capacity_watts", b.capacityWatts},
+		{"psu_rated_watts", b.psuRatedWatts},
+		{"power_allocated_watts", b.allocatedWatts},
+		{"power_limit_watts", b.limitWatts},
+		{"power_limit_min_watts", b.limitMinWatts},
+		{"power_limit_max_watts", b.limitMaxWatts},
+		{"power_consumed_watts", b.consumedWatts},
+		{"power_max_consumed_watts", b.maxConsumedWatts},
+	} {
+		if value.watts != nil {
+			setProperty(spec, value.key, *value.watts)
+		}
+	}
+	if b.limitEnabled != nil {
+		setProperty(spec, "power_limit_enabled", *b.limitEnabled)
+	}
+}
+
+// readEnvironmentMetrics adds the power reading and limit of a modern
+// chassis to its budget, and the peak of its power sensor when the profile
+// reads components.
+func readEnvironmentMetrics(c *RedfishClient, chassis RedfishChassis, b *powerBudget) {
+	metricsURI := c.probeLink(subtreeEnvironment, chassis.EnvironmentMetrics)
+	if metricsURI == "" {
+		return
+	}
+	body, err := c.Get(metricsURI)
+	c.noteSubtree(subtreeEnvironment, err)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get EnvironmentMetrics %s: %v\n", chassis.EnvironmentMetrics.ODataID, err)
+		return
+	}
+	var metrics RedfishEnvironmentMetrics
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		fmt.Printf("Warning: Failed to decode EnvironmentMetrics %s: %v\n", metricsURI, err)
+		return
+	}
+
+	if limit := metrics.PowerLimitWatts; limit != nil {
+		b.limitWatts, b.limitMinWatts, b.limitMaxWatts = limit.SetPoint, limit.AllowableMin, limit.AllowableMax
+		if limit.ControlMode != "" {
+			enabled := limit.ControlMode != "Disabled"
+			b.limitEnabled = &enabled
+		}
+	}
+	power := metrics.PowerWatts
+	if power == nil {
+		return
+	}
+	b.consumedWatts = power.Reading
+	if power.DataSourceURI == "" || !c.collectComponents() {
+		return
+	}
+	sensorBody, err := c.Get(strings.TrimPrefix(power.DataSourceURI, "/redfish/v1"))
+	if err != nil {
+		fmt.Printf("Warning: Failed to get power sensor %s: %v\n", power.DataSourceURI, err)
+		return
+	}
+	var sensor RedfishSensor
+	if err := json.Unmarshal(sensorBody, &sensor); err != nil {
+		fmt.Printf("Warning: Failed to decode power sensor %s: %v\n", power.DataSourceURI, err)
+		return
+	}
+	b.maxConsumedWatts = sensor.PeakReading
+}
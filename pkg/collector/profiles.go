@@ -0,0 +1,194 @@
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Collection Profiles ---
+
+// Collection profiles, shared with the BMCEndpoint resource.
+const (
+	ProfileQuick = bmcendpoint.ProfileQuick
+	ProfileFull  = bmcendpoint.ProfileFull
+	ProfileDeep  = bmcendpoint.ProfileDeep
+)
+
+// DefaultLogEntryLimit caps the log entries read per log service in the deep profile.
+const DefaultLogEntryLimit = 200
+
+// SetProfile selects the collection profile ("" selects ProfileFull).
+// The deep profile also starts recording every response for the raw capture.
+// The coverage and discovery shape start over.
+func (c *RedfishClient) SetProfile(profile string) {
+	if profile == "" {
+		profile = ProfileFull
+	}
+	c.Profile = profile
+	c.coverage = nil
+	c.shape = discoveryShape{}
+	c.capture = nil
+	if profile == ProfileDeep {
+		c.startCapture()
+	}
+}
+
+// startCapture starts recording every response, for the raw capture or a
+// fixture.
+func (c *RedfishClient) startCapture() {
+	if c.capture == nil {
+		c.capture = make(map[string]json.RawMessage)
+	}
+}
+
+// collectComponents reports whether the profile includes components beyond
+// the node and its NICs.
+func (c *RedfishClient) collectComponents() bool {
+	return c.Profile != ProfileQuick
+}
+
+// RawCapture returns the responses recorded in the deep profile, or nil.
+func (c *RedfishClient) RawCapture() map[string]json.RawMessage {
+	if c.Profile != ProfileDeep {
+		return nil
+	}
+	return c.capture
+}
+
+// resolveEndpointOptions fills empty options from the BMCEndpoint registered
+// for the address, and refuses endpoints that are disabled. It also returns
+// the endpoint, or nil when none is registered. Lookup failures are not
+// fatal; the defaults apply.
+func resolveEndpointOptions(ctx context.Context, sdkClient *fabricaclient.Client, address string, opts CollectOptions) (CollectOptions, *bmcendpoint.BMCEndpoint, error) {
+	for endpoint, err := range fabricaclient.BMCEndpoints(sdkClient).All(ctx, fabricaclient.ListOptions{}) {
+		if err != nil {
+			fmt.Printf("Warning: Failed to look up BMCEndpoint settings for %s: %v\n", address, err)
+			return opts, nil, nil
+		}
+		if endpoint.Spec.Address != address {
+			continue
+		}
+		if endpoint.Spec.Disabled {
+			return opts, nil, fmt.Errorf("BMCEndpoint %s for %s is disabled", endpoint.GetName(), address)
+		}
+		if endpoint.Spec.Protocol == bmcendpoint.ProtocolNone {
+			return opts, nil, fmt.Errorf("BMCEndpoint %s for %s has no management protocol and is not collected", endpoint.GetName(), address)
+		}
+		if opts.Profile == "" {
+			opts.Profile = endpoint.Spec.Profile
+		}
+		if opts.Backend == "" {
+			opts.Backend = endpoint.Spec.Backend
+		}
+		if opts.Protocol == "" {
+			opts.Protocol = endpoint.Spec.Protocol
+		}
+		if opts.ProbeOrder == nil {
+			opts.ProbeOrder = endpoint.Spec.ProbeOrder
+		}
+		fmt.Printf("Using settings from BMCEndpoint %s\n", endpoint.GetName())
+		return opts, &endpoint, nil
+	}
+	return opts, nil, nil
+}
+
+// --- Deep Profile Extras ---
+
+// RedfishBios carries the current BIOS attribute values of a system.
+type RedfishBios struct {
+	Attributes map[string]interface{} `json:"Attributes"`
+}
+
+// RedfishSoftwareInventory defines the structure for a FirmwareInventory member.
+type RedfishSoftwareInventory struct {
+	ID        string `json:"Id"`
+	Name      string `json:"Name,omitempty"`
+	Version   string `json:"Version,omitempty"`
+	Updatable *bool  `json:"Updateable,omitempty"`
+}
+
+// firmwareEntry is one item of the "firmware_inventory" node property.
+type firmwareEntry struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Updatable *bool  `json:"updatable,omitempty"`
+}
+
+// collectDeepExtras adds BIOS attributes and the firmware inventory to the
+// node specs, and reads system and manager log entries into the raw capture.
+func collectDeepExtras(c *RedfishClient, nodes map[string]*device.DeviceSpec, systems map[string]*RedfishSystem) {
+	firmware := getFirmwareInventory(c)
+	for systemURI, node := range nodes {
+		system := systems[systemURI]
+		if system.Bios.ODataID != "" {
+			body, err := c.Get(strings.TrimPrefix(system.Bios.ODataID, "/redfish/v1"))
+			if err != nil {
+				fmt.Printf("Warning: Failed to get BIOS %s: %v\n", system.Bios.ODataID, err)
+			} else {
+				var bios RedfishBios
+				if err := json.Unmarshal(body, &bios); err == nil && len(bios.Attributes) > 0 {
+					setProperty(node, "bios_attributes", bios.Attributes)
+				}
+			}
+		}
+		if len(firmware) > 0 {
+			setProperty(node, "firmware_inventory", firmware)
+		}
+		readLogEntries(c, system.LogServices)
+	}
+
+	if managerURIs, err := getCollectionMembers(c, "/Managers"); err == nil {
+		for _, managerURI := range managerURIs {
+			readLogEntries(c, ODataLink{ODataID: managerURI + "/LogServices"})
+		}
+	}
+}
+
+// getFirmwareInventory lists /UpdateService/FirmwareInventory.
+func getFirmwareInventory(c *RedfishClient) []firmwareEntry {
+	memberURIs, err := getCollectionMembers(c, "/UpdateService/FirmwareInventory")
+	if err != nil {
+		fmt.Printf("Warning: Failed to get firmware inventory: %v\n", err)
+		return nil
+	}
+	var entries []firmwareEntry
+	for _, memberURI := range memberURIs {
+		body, err := c.Get(memberURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get firmware item %s: %v\n", memberURI, err)
+			continue
+		}
+		var item RedfishSoftwareInventory
+		if err := json.Unmarshal(body, &item); err != nil {
+			fmt.Printf("Warning: Failed to decode firmware item %s: %v\n", memberURI, err)
+			continue
+		}
+		entries = append(entries, firmwareEntry{ID: item.ID, Name: item.Name, Version: item.Version, Updatable: item.Updatable})
+	}
+	return entries
+}
+
+// readLogEntries reads the entries of every log service under link. The
+// entries are not mapped; reading them places them in the raw capture.
+func readLogEntries(c *RedfishClient, link ODataLink) {
+	if link.ODataID == "" {
+		return
+	}
+	serviceURIs, err := getCollectionMembers(c, strings.TrimPrefix(link.ODataID, "/redfish/v1"))
+	if err != nil {
+		return
+	}
+	for _, serviceURI := range serviceURIs {
+		entriesURI := fmt.Sprintf("%s/Entries?$top=%d", serviceURI, DefaultLogEntryLimit)
+		if _, err := c.Get(entriesURI); err != nil {
+			fmt.Printf("Warning: Failed to read log entries %s: %v\n", entriesURI, err)
+		}
+	}
+}
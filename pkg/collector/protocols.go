@@ -0,0 +1,235 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// --- Protocol Detection ---
+//
+// Mixed fleets have BMCs without Redfish: older servers speaking only IPMI,
+// switches and PDUs speaking SNMP. A BMCEndpoint names its protocol, or the
+// collector probes the protocols of its probe order and collects over the
+// first that answers, recording it on the endpoint. Redfish endpoints are
+// collected by the built-in backends; IPMI and SNMP endpoints by the
+// collector plugin named after the protocol (see Collector Plugins).
+
+const (
+	ProtocolRedfish = bmcendpoint.ProtocolRedfish
+	ProtocolIPMI    = bmcendpoint.ProtocolIPMI
+	ProtocolSNMP    = bmcendpoint.ProtocolSNMP
+	ProtocolNone    = bmcendpoint.ProtocolNone
+)
+
+// ProbeTimeout bounds each protocol probe.
+var ProbeTimeout = 3 * time.Second
+
+// SNMPProbeCommunity is the community the SNMP probe asks with. Agents
+// answering only SNMPv3, or another community, are not detected.
+var SNMPProbeCommunity = "public"
+
+// Default ports of the UDP protocols, used when the address has no port.
+const (
+	ipmiPort = "623"
+	snmpPort = "161"
+)
+
+// probes maps a protocol to its probe, which returns nil when the address
+// answers it.
+var probes = map[string]func(ctx context.Context, address string, opts CollectOptions) error{
+	ProtocolRedfish: probeRedfish,
+	ProtocolIPMI:    probeIPMI,
+	ProtocolSNMP:    probeSNMP,
+}
+
+// DetectProtocol probes the address for the protocols of order (nil uses
+// bmcendpoint.DefaultProbeOrder) one at a time, and returns the first that
+// answers. It returns "" and the probe errors when none does.
+func DetectProtocol(ctx context.Context, address string, order []string, opts CollectOptions) (string, error) {
+	if len(order) == 0 {
+		order = bmcendpoint.DefaultProbeOrder
+	}
+	var errs []error
+	for _, protocol := range order {
+		probe, ok := probes[protocol]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: cannot be probed", protocol))
+			continue
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+		err := probe(probeCtx, address, opts)
+		cancel()
+		if err == nil {
+			return protocol, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", protocol, err))
+	}
+	return "", errors.Join(errs...)
+}
+
+// resolveProtocol settles the protocol to collect the address over, and the
+// backend for it. Options naming a protocol or a backend are kept; otherwise
+// the endpoint's detected protocol is used while its collections succeed,
+// and the address is probed when there is none. It also returns the
+// protocol when it was detected, for the endpoint to record.
+func (opts CollectOptions) resolveProtocol(ctx context.Context, address string, endpoint *bmcendpoint.BMCEndpoint) (CollectOptions, string, error) {
+	var detected string
+	switch {
+	case opts.Protocol != "":
+	case opts.Backend != "":
+		// The backend knows how to talk to its equipment
+		return opts, "", nil
+	case endpoint != nil && endpoint.Status.DetectedProtocol != "" && endpoint.Status.ConsecutiveFailures == 0:
+		opts.Protocol = endpoint.Status.DetectedProtocol
+	default:
+		protocol, err := DetectProtocol(ctx, address, opts.ProbeOrder, opts)
+		if protocol == "" {
+			// Trying Redfish anyway reports why the BMC cannot be reached
+			fmt.Printf("Warning: No protocol answered at %s (%v); trying %s\n", address, err, ProtocolRedfish)
+			opts.Protocol = ProtocolRedfish
+			break
+		}
+		fmt.Printf("Detected %s at %s\n", protocol, address)
+		opts.Protocol, detected = protocol, protocol
+	}
+
+	switch opts.Protocol {
+	case ProtocolRedfish:
+	case ProtocolNone:
+		return opts, detected, fmt.Errorf("%s has no management protocol (protocol %s) and is not collected", address, ProtocolNone)
+	default:
+		if !IsPlugin(opts.Protocol) {
+			return opts, detected, fmt.Errorf("no collector backend for %s at %s: install a collector plugin named %q", opts.Protocol, address, opts.Protocol)
+		}
+		opts.Backend = opts.Protocol
+	}
+	return opts, detected, nil
+}
+
+// probeRedfish asks for the Redfish service root, which needs no login.
+// BMCs that want one anyway answer 401.
+func probeRedfish(ctx context.Context, address string, opts CollectOptions) error {
+	c, err := opts.redfishClient(address, Credential{})
+	if err != nil {
+		return err
+	}
+	defer c.HTTPClient.CloseIdleConnections()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var root struct {
+			ODataID string `json:"@odata.id"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&root); err != nil || root.ODataID == "" {
+			return fmt.Errorf("the service root is not Redfish")
+		}
+		return nil
+	case http.StatusUnauthorized:
+		return nil
+	}
+	return fmt.Errorf("the service root answered %s", resp.Status)
+}
+
+// probeIPMI sends an RMCP presence ping, which every IPMI BMC answers with
+// a pong without a session.
+func probeIPMI(ctx context.Context, address string, _ CollectOptions) error {
+	ping := []byte{
+		0x06, 0x00, 0xff, 0x06, // RMCP v1.0, no ACK, class ASF
+		0x00, 0x00, 0x11, 0xbe, // ASF IANA enterprise number
+		0x80, 0x00, 0x00, 0x00, // presence ping, tag, reserved, no data
+	}
+	pong, err := exchangeUDP(ctx, udpAddress(address, ipmiPort), ping)
+	if err != nil {
+		return err
+	}
+	if len(pong) < 9 || pong[0] != 0x06 || pong[3]&0x0f != 0x06 || pong[8] != 0x40 {
+		return fmt.Errorf("the answer is not an RMCP presence pong")
+	}
+	return nil
+}
+
+// probeSNMP asks an SNMPv2c agent for sysObjectID.0 with SNMPProbeCommunity.
+// Any SNMP message back, even an error, shows an agent is there.
+func probeSNMP(ctx context.Context, address string, _ CollectOptions) error {
+	sysObjectID := []byte{0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x02, 0x00} // 1.3.6.1.2.1.1.2.0
+	varBind := ber(0x30, ber(0x06, sysObjectID), ber(0x05, nil))
+	pdu := ber(0xa0, // GetRequest
+		ber(0x02, []byte{0x01}), // request-id
+		ber(0x02, []byte{0x00}), // error-status
+		ber(0x02, []byte{0x00}), // error-index
+		ber(0x30, varBind))
+	request := ber(0x30, ber(0x02, []byte{0x01}), ber(0x04, []byte(SNMPProbeCommunity)), pdu) // version 2c
+
+	response, err := exchangeUDP(ctx, udpAddress(address, snmpPort), request)
+	if err != nil {
+		return err
+	}
+	if len(response) < 2 || response[0] != 0x30 {
+		return fmt.Errorf("the answer is not an SNMP message")
+	}
+	return nil
+}
+
+// ber encodes a BER element with the given tag and the concatenated content.
+func ber(tag byte, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	out := []byte{tag}
+	switch n := len(body); {
+	case n < 0x80:
+		out = append(out, byte(n))
+	case n <= 0xff:
+		out = append(out, 0x81, byte(n))
+	default:
+		out = append(out, 0x82, byte(n>>8), byte(n))
+	}
+	return append(out, body...)
+}
+
+// udpAddress returns the host of address with its port, or port when it has
+// none. The port of a Redfish address is not that of the UDP protocols.
+func udpAddress(address, port string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// exchangeUDP sends request to address and returns the first datagram back.
+func exchangeUDP(ctx context.Context, address string, request []byte) ([]byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 1500)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/discovery"
+	"github.com/example/inventory-v3/pkg/s3"
+)
+
+// --- Publish Targets ---
+//
+// Snapshots go to the inventory API unless other targets are chosen, which
+// lets the collector run where no Fabrica server is deployed yet. Without
+// the API target, BMCEndpoint settings are not looked up (except by the
+// agent, which lists the endpoints from the API anyway).
+
+// Publish targets.
+const (
+	PublishAPI    = "api"
+	PublishFile   = "file"
+	PublishS3     = "s3"
+	PublishKafka  = "kafka"
+	PublishStdout = "stdout"
+)
+
+// PublishTargets lists the valid publish targets.
+var PublishTargets = []string{PublishAPI, PublishFile, PublishS3, PublishKafka, PublishStdout}
+
+// PublishOptions selects and configures where snapshots are published.
+type PublishOptions struct {
+	// Targets lists the publish targets; empty publishes to the API only.
+	Targets []string
+	// Dir is the directory of the file target.
+	Dir string
+	// S3 is the bucket of the s3 target.
+	S3 s3.Bucket
+	// KafkaBrokers and KafkaTopic configure the kafka target, which
+	// connects with TLS (under the CA file and TLS policy) if KafkaTLS.
+	KafkaBrokers []string
+	KafkaTopic   string
+	KafkaTLS     bool
+	// Stdout receives the stdout target's snapshots; nil uses os.Stdout.
+	Stdout io.Writer
+}
+
+// Validate checks that the targets are known and configured.
+func (p PublishOptions) Validate() error {
+	for _, target := range p.Targets {
+		switch target {
+		case PublishAPI, PublishStdout:
+		case PublishFile:
+			if p.Dir == "" {
+				return fmt.Errorf("publish target %q needs a directory", target)
+			}
+		case PublishS3:
+			if p.S3.URL == "" {
+				return fmt.Errorf("publish target %q needs a bucket URL", target)
+			}
+		case PublishKafka:
+			if len(p.KafkaBrokers) == 0 || p.KafkaTopic == "" {
+				return fmt.Errorf("publish target %q needs brokers and a topic", target)
+			}
+		default:
+			return fmt.Errorf("unknown publish target %q (valid: %v)", target, PublishTargets)
+		}
+	}
+	return nil
+}
+
+// UsesAPI reports whether snapshots are published to the inventory API.
+func (p PublishOptions) UsesAPI() bool {
+	return len(p.Targets) == 0 || slices.Contains(p.Targets, PublishAPI)
+}
+
+// APIOnly reports whether snapshots are published to the inventory API alone,
+// so that the reference a publish returns is the snapshot's UID.
+func (p PublishOptions) APIOnly() bool {
+	return len(p.Targets) == 0 || (len(p.Targets) == 1 && p.Targets[0] == PublishAPI)
+}
+
+// publisher returns the publisher of the targets. sdkClient is only used
+// by the API target.
+func (opts CollectOptions) publisher(sdkClient *fabricaclient.Client) (discovery.Publisher, error) {
+	p := opts.Publish
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if len(p.Targets) == 0 {
+		return discovery.APIPublisher{Client: sdkClient}, nil
+	}
+	var publishers discovery.Publishers
+	for _, target := range p.Targets {
+		switch target {
+		case PublishAPI:
+			publishers = append(publishers, discovery.APIPublisher{Client: sdkClient})
+		case PublishFile:
+			publishers = append(publishers, discovery.FilePublisher{Dir: p.Dir})
+		case PublishS3:
+			publishers = append(publishers, &discovery.S3Publisher{Bucket: p.S3})
+		case PublishKafka:
+			kafka := &discovery.KafkaPublisher{Brokers: p.KafkaBrokers, Topic: p.KafkaTopic}
+			if p.KafkaTLS {
+				pool, err := certPool(opts.CAFile)
+				if err != nil {
+					return nil, err
+				}
+				kafka.TLS = opts.TLSPolicy.Apply(&tls.Config{RootCAs: pool})
+			}
+			publishers = append(publishers, kafka)
+		case PublishStdout:
+			out := p.Stdout
+			if out == nil {
+				out = os.Stdout
+			}
+			publishers = append(publishers, &discovery.WriterPublisher{W: out})
+		}
+	}
+	if len(publishers) == 1 {
+		return publishers[0], nil
+	}
+	return publishers, nil
+}
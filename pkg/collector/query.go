@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// --- Redfish Query Parameters ---
+//
+// Chatty BMCs return every property of a resource, OEM blocks included, when
+// the mapper needs a handful. Services that list SelectQuery in the service
+// root's ProtocolFeaturesSupported are asked for just the fields the mapper's
+// struct decodes, with $select. A service that claims support but rejects the
+// query is read in full from then on.
+//
+// $filter support is recorded too, but not used: the mappers keep every
+// member of a collection, so there is nothing the BMC could filter out.
+
+// RedfishProtocolFeatures is the service root's ProtocolFeaturesSupported.
+type RedfishProtocolFeatures struct {
+	FilterQuery bool `json:"FilterQuery,omitempty"`
+	SelectQuery bool `json:"SelectQuery,omitempty"`
+}
+
+// selectFields caches the $select lists by struct type.
+var selectFields sync.Map
+
+// selectList returns the comma-separated top-level properties decoded by v,
+// a pointer to a struct; embedded structs contribute their fields.
+func selectList(v interface{}) string {
+	t := reflect.TypeOf(v)
+	if cached, ok := selectFields.Load(t); ok {
+		return cached.(string)
+	}
+	list := strings.Join(jsonFields(t.Elem()), ",")
+	selectFields.Store(t, list)
+	return list
+}
+
+// jsonFields returns the JSON names of a struct type's fields.
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFields(field.Type)...)
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		// Annotations such as @odata.id come with every resource
+		if strings.HasPrefix(name, "@") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// GetSelected reads the resource at path into the fields of v (a pointer to
+// a struct), asking the service for only those fields when it supports
+// $select. It returns the body read. The deep profile's raw capture must be
+// complete, so it always reads resources in full.
+func (c *RedfishClient) GetSelected(path string, v interface{}) ([]byte, error) {
+	if !c.Features.SelectQuery || c.capture != nil || strings.Contains(path, "?") {
+		return c.Get(path)
+	}
+	// Field names need no escaping, and some BMCs do not unescape "%24select"
+	body, err := c.get(path + "?$select=" + selectList(v))
+	if err == nil {
+		c.noteRead(path, nil)
+		return body, nil
+	}
+	body, err = c.Get(path)
+	if err == nil {
+		fmt.Printf("Warning: %s rejected $select despite listing SelectQuery support; reading resources in full\n", c.BaseURL)
+		c.Features.SelectQuery = false
+	}
+	return body, err
+}
@@ -0,0 +1,240 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Vendor Quirks ---
+
+// ServiceIdentity identifies the Redfish implementation behind a BMC.
+type ServiceIdentity struct {
+	Vendor   string // ServiceRoot.Vendor, or the manager's Manufacturer
+	Model    string // ServiceRoot.Product, or the manager's Model
+	Firmware string // the manager's FirmwareVersion
+}
+
+// Quirk describes a known deviation from the Redfish spec and its workaround.
+// Vendor, Model, and FirmwarePrefix select where it applies; empty fields
+// match anything. Vendor and Model match case-insensitive substrings.
+type Quirk struct {
+	Name           string
+	Description    string
+	Vendor         string
+	Model          string
+	FirmwarePrefix string
+
+	// TrailingSlash appends "/" to every request path.
+	TrailingSlash bool
+
+	// PaginateWithSkip fetches remaining collection members with $skip when
+	// Members@odata.count exceeds the members returned and no nextLink is given.
+	PaginateWithSkip bool
+
+	// MemoryPath is the Memory collection, relative to the system, read when
+	// a system does not link one.
+	MemoryPath string
+
+	// RelativeMembers resolves collection members whose @odata.id is relative
+	// against the collection's path.
+	RelativeMembers bool
+
+	// SessionAuth logs in with a Redfish session instead of basic auth, and
+	// logs in again when the session expires.
+	SessionAuth bool
+
+	// FixDevice rewrites a mapped device and reports whether it changed it.
+	FixDevice func(spec *device.DeviceSpec) bool
+
+	// FixDevices rewrites the mapped devices using further requests, and
+	// returns how many it changed.
+	FixDevices func(c *RedfishClient, specs []*device.DeviceSpec) int
+}
+
+// matches reports whether the quirk applies to the identified service.
+func (q *Quirk) matches(id ServiceIdentity) bool {
+	if q.Vendor != "" && !strings.Contains(strings.ToLower(id.Vendor), strings.ToLower(q.Vendor)) {
+		return false
+	}
+	if q.Model != "" && !strings.Contains(strings.ToLower(id.Model), strings.ToLower(q.Model)) {
+		return false
+	}
+	if q.FirmwarePrefix != "" && !strings.HasPrefix(id.Firmware, q.FirmwarePrefix) {
+		return false
+	}
+	return true
+}
+
+// quirkRegistry holds the known quirks, checked in order.
+var quirkRegistry = []*Quirk{
+	{
+		Name:          "trailing-slash-required",
+		Description:   "iLO 4 only serves resource paths that end in a slash",
+		Vendor:        "HPE",
+		Model:         "iLO 4",
+		TrailingSlash: true,
+	},
+	{
+		Name:        "dimm-partnumber-unreliable",
+		Description: "DIMM PartNumber repeats a placeholder; the module's Model is used instead",
+		Vendor:      "Supermicro",
+		FixDevice: func(spec *device.DeviceSpec) bool {
+			var model string
+			if spec.DeviceType != "DIMM" || !spec.GetProperty("model", &model) || model == "" || model == spec.PartNumber {
+				return false
+			}
+			spec.PartNumber = model
+			return true
+		},
+	},
+	{
+		Name:            "memory-collection-path",
+		Description:     "systems omit their Memory link, and Memory members are listed relative to the collection",
+		Vendor:          "Supermicro",
+		MemoryPath:      "Memory",
+		RelativeMembers: true,
+	},
+	{
+		Name:        "session-auth",
+		Description: "bmcweb may refuse basic auth; a Redfish session is used and renewed when it expires",
+		Vendor:      "OpenBMC",
+		SessionAuth: true,
+	},
+	{
+		Name:        "sparse-inventory",
+		Description: "empty D-Bus properties are omitted; blank identifiers are filled from the phosphor inventory",
+		Vendor:      "OpenBMC",
+		FixDevices:  fillFromOpenBMCInventory,
+	},
+	{
+		// Seen on several implementations, so it applies everywhere and only
+		// fires when a short page without a nextLink is actually returned.
+		Name:             "members-nextlink-missing",
+		Description:      "collections are paginated but omit Members@odata.nextLink",
+		PaginateWithSkip: true,
+	},
+}
+
+// RegisterQuirk adds a quirk to the registry. It applies to clients identified afterwards.
+func RegisterQuirk(q *Quirk) {
+	quirkRegistry = append(quirkRegistry, q)
+}
+
+// Identify reads the service root and first manager to identify the BMC, and
+// selects the quirks that apply to it. Failures leave the client quirk-free.
+func (c *RedfishClient) Identify() {
+	c.Quirks = nil
+	c.Features = RedfishProtocolFeatures{}
+	rootBody, err := c.Get("/")
+	if err != nil {
+		fmt.Printf("Warning: Failed to identify service: %v\n", err)
+		return
+	}
+	var root RedfishServiceRoot
+	if err := json.Unmarshal(rootBody, &root); err != nil {
+		fmt.Printf("Warning: Failed to decode service root: %v\n", err)
+		return
+	}
+	c.Identity = ServiceIdentity{Vendor: root.Vendor, Model: root.Product}
+	c.Features = root.ProtocolFeaturesSupported
+
+	// Services that refuse basic auth need a session before the manager is read
+	for _, q := range quirkRegistry {
+		if q.SessionAuth && q.matches(c.Identity) {
+			if err := c.login(); err != nil {
+				fmt.Printf("Warning: Failed to log in to %s, using basic auth: %v\n", c.BaseURL, err)
+			} else {
+				c.noteQuirk(q)
+			}
+			break
+		}
+	}
+
+	if root.Managers.ODataID != "" {
+		managerURIs, err := c.getMembersPage(strings.TrimPrefix(root.Managers.ODataID, "/redfish/v1"))
+		if err == nil && len(managerURIs.Members) > 0 {
+			managerURI := strings.TrimPrefix(managerURIs.Members[0].ODataID, "/redfish/v1")
+			if body, err := c.Get(managerURI); err == nil {
+				var manager RedfishManager
+				if json.Unmarshal(body, &manager) == nil {
+					c.Identity.Vendor = firstNonEmpty(c.Identity.Vendor, manager.Manufacturer)
+					c.Identity.Model = firstNonEmpty(c.Identity.Model, manager.Model)
+					c.Identity.Firmware = manager.FirmwareVersion
+				}
+			}
+		}
+	}
+
+	for _, q := range quirkRegistry {
+		if q.matches(c.Identity) {
+			c.Quirks = append(c.Quirks, q)
+		}
+	}
+	fmt.Printf("Identified BMC: vendor=%q model=%q firmware=%q (%d quirks active)\n",
+		c.Identity.Vendor, c.Identity.Model, c.Identity.Firmware, len(c.Quirks))
+}
+
+// hasQuirk returns the first active quirk for which want is true.
+func (c *RedfishClient) hasQuirk(want func(q *Quirk) bool) *Quirk {
+	for _, q := range c.Quirks {
+		if want(q) {
+			return q
+		}
+	}
+	return nil
+}
+
+// noteQuirk records that a quirk's workaround was applied, logging the first time.
+func (c *RedfishClient) noteQuirk(q *Quirk) {
+	if c.fired == nil {
+		c.fired = make(map[string]int)
+	}
+	if c.fired[q.Name] == 0 {
+		fmt.Printf("Quirk applied: %s (%s)\n", q.Name, q.Description)
+	}
+	c.fired[q.Name]++
+}
+
+// FiredQuirks returns how many times each quirk's workaround was applied.
+func (c *RedfishClient) FiredQuirks() map[string]int {
+	fired := make(map[string]int, len(c.fired))
+	for name, count := range c.fired {
+		fired[name] = count
+	}
+	return fired
+}
+
+// applyDeviceQuirks runs the FixDevice and FixDevices workarounds of active quirks over specs.
+func (c *RedfishClient) applyDeviceQuirks(specs []*device.DeviceSpec) {
+	for _, q := range c.Quirks {
+		if q.FixDevice != nil {
+			for _, spec := range specs {
+				if q.FixDevice(spec) {
+					c.noteQuirk(q)
+				}
+			}
+		}
+		if q.FixDevices != nil {
+			for n := q.FixDevices(c, specs); n > 0; n-- {
+				c.noteQuirk(q)
+			}
+		}
+	}
+}
+
+// printFiredQuirks writes a one-line summary of the quirks that fired.
+func (c *RedfishClient) printFiredQuirks() {
+	if len(c.fired) == 0 {
+		return
+	}
+	names := make([]string, 0, len(c.fired))
+	for name, count := range c.fired {
+		names = append(names, fmt.Sprintf("%s x%d", name, count))
+	}
+	sort.Strings(names)
+	fmt.Printf("Quirks fired: %s\n", strings.Join(names, ", "))
+}
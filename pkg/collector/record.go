@@ -0,0 +1,74 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/fixtures"
+	"github.com/example/inventory-v3/pkg/telemetry"
+)
+
+// RecordOptions configures recording the Redfish tree a collection reads as
+// a fixture, for the mock BMC and the quirk tests.
+type RecordOptions struct {
+	// Store receives the fixture; nil records nothing.
+	Store fixtures.Store
+	// Name names the fixture (default: vendor-model-firmware of the BMC).
+	Name string
+	// Anonymize replaces serial numbers, UUIDs and addresses with random
+	// stand-ins, besides stripping secrets.
+	Anonymize bool
+}
+
+// unsafeNameChars matches what a fixture name derived from the BMC's
+// identity may not hold.
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// record saves the responses read as a sanitized fixture and returns its
+// name.
+func (c *RedfishClient) record(ctx context.Context, opts RecordOptions) (string, error) {
+	name := opts.Name
+	if name == "" {
+		parts := []string{}
+		for _, part := range []string{c.Identity.Vendor, c.Identity.Model, c.Identity.Firmware} {
+			if part = strings.Trim(unsafeNameChars.ReplaceAllString(strings.ToLower(part), "-"), "-._"); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if name = strings.Join(parts, "-"); name == "" {
+			name = "bmc"
+		}
+	}
+	f, err := fixtures.New(name, c.capture)
+	if err != nil {
+		return "", err
+	}
+	f.Vendor, f.Model, f.Firmware = c.Identity.Vendor, c.Identity.Model, c.Identity.Firmware
+	f.Profile = c.Profile
+	f.RecordedAt = time.Now().UTC()
+	f.CollectorVersion = telemetry.Version()
+	for quirk := range c.fired {
+		f.Quirks = append(f.Quirks, quirk)
+	}
+	sort.Strings(f.Quirks)
+
+	steps := []fixtures.Step{fixtures.StripSecrets}
+	if opts.Anonymize {
+		step, err := fixtures.Anonymize("")
+		if err != nil {
+			return "", err
+		}
+		steps = append(steps, step)
+	}
+	if err := f.Sanitize(steps...); err != nil {
+		return "", err
+	}
+	if err := fixtures.Save(ctx, opts.Store, f); err != nil {
+		return "", fmt.Errorf("failed to save fixture %s: %w", name, err)
+	}
+	return name, nil
+}
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Secret Redaction ---
+//
+// Nothing the collector stores or prints may carry a credential. Responses
+// are masked as they are captured, errors leaving discovery are masked
+// with the BMC password, plugin output is masked on its way to the log, and
+// device properties are scrubbed before the snapshot is posted, since OEM
+// blocks and plugins can hand back community strings or bind passwords.
+// Beyond credentials, a site's property policy removes and hashes the data
+// it may not store.
+
+// redactSpecs strips the sensitive properties of the devices. It returns how
+// many it removed or masked.
+func redactSpecs(specs []*device.DeviceSpec) int {
+	stripped := 0
+	for _, spec := range specs {
+		stripped += redact.Properties(spec.Properties)
+	}
+	return stripped
+}
+
+// applyPolicy applies a site's property policy to the devices. It returns how
+// many properties and fields it removed or hashed.
+func applyPolicy(policy *redact.Policy, specs []*device.DeviceSpec) int {
+	changed := 0
+	for _, spec := range specs {
+		changed += policy.Apply(spec)
+	}
+	return changed
+}
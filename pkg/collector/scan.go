@@ -0,0 +1,135 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+// --- Network Scanning ---
+//
+// Large sites do not keep a list of their BMCs. The collector can find them
+// instead by scanning networks for Redfish service roots, then collect from
+// every address that answered as it would from a hosts file.
+
+// Scan defaults.
+const (
+	DefaultScanPort        = 443
+	DefaultScanConcurrency = 64
+	// MaxScanAddresses bounds the addresses one scan probes, a /16 of IPv4.
+	MaxScanAddresses = 1 << 16
+)
+
+// ScanOptions configures a network scan.
+type ScanOptions struct {
+	// Port is the HTTPS port probed; zero uses DefaultScanPort.
+	Port int
+	// Concurrency is how many addresses are probed at once; zero or less
+	// uses DefaultScanConcurrency.
+	Concurrency int
+	// Timeout bounds each probe; zero uses ProbeTimeout.
+	Timeout time.Duration
+}
+
+// ScanAddresses returns the addresses of the hosts of the networks (CIDR),
+// in order, as the collector takes them: bare for port 443, else with the
+// port. The network and broadcast addresses of IPv4 networks larger than a
+// /31 are left out.
+func ScanAddresses(networks []string, port int) ([]string, error) {
+	if port == 0 {
+		port = DefaultScanPort
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid scan port %d: must be 1-65535", port)
+	}
+	var addresses []string
+	for _, network := range networks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", network, err)
+		}
+		prefix = prefix.Masked()
+		hostBits := prefix.Addr().BitLen() - prefix.Bits()
+		if hostBits > 16 || len(addresses)+1<<hostBits > MaxScanAddresses {
+			return nil, fmt.Errorf("network %s is too large to scan: at most %d addresses are probed", network, MaxScanAddresses)
+		}
+		skipEnds := prefix.Addr().Is4() && hostBits > 1
+		last := 1<<hostBits - 1
+		addr := prefix.Addr()
+		for i := 0; i <= last; i, addr = i+1, addr.Next() {
+			if skipEnds && (i == 0 || i == last) {
+				continue
+			}
+			addresses = append(addresses, scanAddress(addr, port))
+		}
+	}
+	return addresses, nil
+}
+
+func scanAddress(addr netip.Addr, port int) string {
+	switch {
+	case port != DefaultScanPort:
+		return netip.AddrPortFrom(addr, uint16(port)).String()
+	case addr.Is6():
+		return "[" + addr.String() + "]"
+	}
+	return addr.String()
+}
+
+// Scan probes every host of the networks for a Redfish service root, as
+// protocol detection does, and returns the addresses that answered, in
+// order.
+func Scan(ctx context.Context, networks []string, opts CollectOptions, scan ScanOptions) ([]string, error) {
+	candidates, err := ScanAddresses(networks, scan.Port)
+	if err != nil {
+		return nil, err
+	}
+	concurrency := scan.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultScanConcurrency
+	}
+	timeout := scan.Timeout
+	if timeout <= 0 {
+		timeout = ProbeTimeout
+	}
+	port := scan.Port
+	if port == 0 {
+		port = DefaultScanPort
+	}
+	fmt.Printf("Scanning %d address(es) for Redfish on port %d...\n", len(candidates), port)
+
+	answered := make([]bool, len(candidates))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, address := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, address string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			answered[i] = probeRedfish(probeCtx, address, opts) == nil
+		}(i, address)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var found []string
+	for i, address := range candidates {
+		if answered[i] {
+			found = append(found, address)
+		}
+	}
+	fmt.Printf("Found %d Redfish endpoint(s)\n", len(found))
+	return found, nil
+}
@@ -0,0 +1,89 @@
+package collector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestScanAddresses lists the hosts of the networks as the collector takes
+// them, and refuses ports it cannot probe and networks too large to scan.
+func TestScanAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		networks []string
+		port     int
+		want     []string
+		wantLen  int
+		wantErr  string
+	}{
+		{
+			name:     "network and broadcast left out",
+			networks: []string{"10.0.0.0/30"},
+			want:     []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:     "host bits masked",
+			networks: []string{"10.0.0.7/29"},
+			want:     []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"},
+		},
+		{name: "point to point /31", networks: []string{"10.0.0.4/31"}, want: []string{"10.0.0.4", "10.0.0.5"}},
+		{name: "single host", networks: []string{"10.0.0.9/32"}, want: []string{"10.0.0.9"}},
+		{
+			name:     "networks in order",
+			networks: []string{"10.0.1.8/31", "10.0.0.8/31"},
+			want:     []string{"10.0.1.8", "10.0.1.9", "10.0.0.8", "10.0.0.9"},
+		},
+		{
+			name:     "IPv6 has no broadcast",
+			networks: []string{"fd00::/127", "fd00::8/126"},
+			want:     []string{"[fd00::]", "[fd00::1]", "[fd00::8]", "[fd00::9]", "[fd00::a]", "[fd00::b]"},
+		},
+		{
+			name:     "default port stays bare",
+			networks: []string{"10.0.0.9/32"},
+			port:     DefaultScanPort,
+			want:     []string{"10.0.0.9"},
+		},
+		{
+			name:     "other ports",
+			networks: []string{"10.0.0.9/32", "fd00::9/128"},
+			port:     8443,
+			want:     []string{"10.0.0.9:8443", "[fd00::9]:8443"},
+		},
+		{name: "a /16", networks: []string{"10.1.0.0/16"}, wantLen: MaxScanAddresses - 2},
+		{name: "larger than a /16", networks: []string{"10.0.0.0/15"}, wantErr: "too large to scan"},
+		{
+			name:     "more than MaxScanAddresses together",
+			networks: []string{"10.1.0.0/16", "10.2.0.0/30"},
+			wantErr:  "network 10.2.0.0/30 is too large to scan",
+		},
+		{name: "IPv6 larger than a /112", networks: []string{"fd00::/111"}, wantErr: "too large to scan"},
+		{name: "not a network", networks: []string{"10.0.0.1"}, wantErr: `invalid network "10.0.0.1"`},
+		{name: "port too large", networks: []string{"10.0.0.9/32"}, port: 65536, wantErr: "invalid scan port 65536"},
+		{name: "negative port", networks: []string{"10.0.0.9/32"}, port: -1, wantErr: "invalid scan port -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanAddresses(tt.networks, tt.port)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ScanAddresses error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ScanAddresses failed: %v", err)
+			}
+			if tt.want == nil {
+				if len(got) != tt.wantLen {
+					t.Errorf("ScanAddresses returned %d addresses, want %d", len(got), tt.wantLen)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScanAddresses = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
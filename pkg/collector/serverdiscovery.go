@@ -0,0 +1,125 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// --- Inventory API Discovery ---
+//
+// Collectors spread across management nodes find the inventory API without
+// per-host configuration: OpenCHAMI's cloud-init server hands every node the
+// meta-data of its group, so the API URL is set once, as the
+// inventory_api_url meta-data key, for all of them. The collector reads it
+// from the instance data cloud-init wrote at boot, or else asks the
+// metadata endpoint.
+
+// MetadataServerKey is the meta-data key holding the inventory API URL.
+const MetadataServerKey = "inventory_api_url"
+
+// DefaultInstanceDataFile is where cloud-init writes the instance data.
+const DefaultInstanceDataFile = "/run/cloud-init/instance-data.json"
+
+// DefaultMetadataURL is the well-known metadata endpoint, which serves the
+// node's meta-data as YAML or JSON.
+const DefaultMetadataURL = "http://169.254.169.254/cloud-init/meta-data"
+
+// metadataTimeout bounds the metadata request, since the endpoint is often
+// absent and its link-local address unroutable.
+const metadataTimeout = 2 * time.Second
+
+// DiscoverServer returns the inventory API URL given in the instance data
+// file or by the metadata endpoint (either may be ""), and which of them
+// gave it.
+func DiscoverServer(ctx context.Context, instanceDataFile, metadataURL string) (string, string, error) {
+	var errs []error
+	if instanceDataFile != "" {
+		server, err := serverFromInstanceData(instanceDataFile)
+		if err == nil {
+			return server, instanceDataFile, nil
+		}
+		errs = append(errs, err)
+	}
+	if metadataURL != "" {
+		server, err := serverFromMetadata(ctx, metadataURL)
+		if err == nil {
+			return server, metadataURL, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", "", errors.New("no instance data file or metadata endpoint to discover the inventory API from")
+	}
+	return "", "", errors.Join(errs...)
+}
+
+// serverFromInstanceData reads the API URL from the meta-data in
+// cloud-init's instance data.
+func serverFromInstanceData(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	var instance struct {
+		DS struct {
+			MetaData map[string]interface{} `json:"meta_data"`
+		} `json:"ds"`
+	}
+	if err := json.Unmarshal(data, &instance); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	server, _ := instance.DS.MetaData[MetadataServerKey].(string)
+	return checkServerURL(server, file)
+}
+
+// serverFromMetadata reads the API URL from the meta-data document at
+// metadataURL.
+func serverFromMetadata(ctx context.Context, metadataURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid metadata URL %q: %w", metadataURL, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read meta-data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata endpoint %s returned %s", metadataURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read meta-data: %w", err)
+	}
+	// YAML, of which JSON is a subset
+	metadata := viper.New()
+	metadata.SetConfigType("yaml")
+	if err := metadata.ReadConfig(bytes.NewReader(body)); err != nil {
+		return "", fmt.Errorf("failed to parse meta-data from %s: %w", metadataURL, err)
+	}
+	return checkServerURL(metadata.GetString(MetadataServerKey), metadataURL)
+}
+
+// checkServerURL checks that the API URL found in source is an http(s) URL.
+func checkServerURL(server, source string) (string, error) {
+	if server == "" {
+		return "", fmt.Errorf("%s has no %s", source, MetadataServerKey)
+	}
+	u, err := url.Parse(server)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%s in %s is not an http(s) URL: %q", MetadataServerKey, source, server)
+	}
+	return server, nil
+}
@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// --- Adaptive Discovery ---
+//
+// Most BMCs lack some of the subtrees the collector probes: no Storage
+// service, no CompositionService, a ThermalSubsystem link that answers 404.
+// Each collection from a registered BMCEndpoint records the subtrees the BMC
+// turned out not to have in the endpoint's status, and later collections skip
+// them. Every subtree is probed again once the recorded shape is older than
+// the re-probe interval, so firmware updates and new hardware are picked up.
+// The deep profile always probes everything.
+
+// DefaultReprobeInterval is how often collections probe the subtrees a BMC
+// is known to lack.
+const DefaultReprobeInterval = 24 * time.Hour
+
+// Subtrees whose absence is recorded.
+const (
+	subtreeProcessors       = "Processors"
+	subtreeMemory           = "Memory"
+	subtreeStorage          = "Storage"
+	subtreeSimpleStorage    = "SimpleStorage"
+	subtreeNetworkAdapters  = "NetworkAdapters"
+	subtreeThermalSubsystem = "ThermalSubsystem"
+	subtreePowerSubsystem   = "PowerSubsystem"
+	subtreeEnvironment      = "EnvironmentMetrics"
+	subtreeComposition      = "CompositionService"
+)
+
+// discoveryShape tracks the subtrees of one collection.
+type discoveryShape struct {
+	// skip holds the subtrees an earlier collection found absent.
+	skip map[string]bool
+	// present and absent hold the outcome of the probes made. A subtree is
+	// present once any system or chassis has it.
+	present map[string]bool
+	absent  map[string]bool
+}
+
+// useShape skips the subtrees recorded absent, unless a re-probe is due.
+// A reprobe interval of zero uses DefaultReprobeInterval; a negative one
+// always probes.
+func (c *RedfishClient) useShape(recorded *bmcendpoint.DiscoveryShape, reprobe time.Duration) {
+	if reprobe == 0 {
+		reprobe = DefaultReprobeInterval
+	}
+	if reprobe < 0 || c.Profile == ProfileDeep || recorded.ReprobeDue(reprobe, time.Now()) {
+		return
+	}
+	c.shape.skip = make(map[string]bool)
+	for _, subtree := range recorded.Absent {
+		c.shape.skip[subtree] = true
+	}
+	if len(recorded.Absent) > 0 {
+		fmt.Printf("Skipping subtrees absent since %s: %s\n", recorded.ProbedAt.Format(time.RFC3339), strings.Join(recorded.Absent, ", "))
+	}
+}
+
+// probeLink returns the path a subtree is linked at, or "" when the subtree
+// is skipped or not linked. A missing link marks the subtree absent.
+func (c *RedfishClient) probeLink(subtree string, link ODataLink) string {
+	if c.shape.skip[subtree] {
+		return ""
+	}
+	if link.ODataID == "" {
+		c.markSubtree(subtree, false)
+		return ""
+	}
+	return strings.TrimPrefix(link.ODataID, "/redfish/v1")
+}
+
+// noteSubtree records the outcome of reading a linked subtree. Only a
+// response saying the BMC has no such resource marks it absent; other
+// failures may be transient.
+func (c *RedfishClient) noteSubtree(subtree string, err error) {
+	c.markSubtree(subtree, !notImplemented(err))
+}
+
+// markSubtree records whether the BMC has subtree.
+func (c *RedfishClient) markSubtree(subtree string, present bool) {
+	if c.shape.present == nil {
+		c.shape.present = make(map[string]bool)
+		c.shape.absent = make(map[string]bool)
+	}
+	if present {
+		c.shape.present[subtree] = true
+		delete(c.shape.absent, subtree)
+	} else if !c.shape.present[subtree] {
+		c.shape.absent[subtree] = true
+	}
+}
+
+// discoveredShape returns the shape to record after a collection, or nil
+// when it probed nothing (plugins talk to their equipment themselves). The
+// skipped subtrees stay absent; the probe time only advances when the
+// collection probed every subtree.
+func (c *RedfishClient) discoveredShape(recorded *bmcendpoint.DiscoveryShape) *bmcendpoint.DiscoveryShape {
+	if c.shape.present == nil {
+		return nil
+	}
+	shape := &bmcendpoint.DiscoveryShape{Absent: []string{}}
+	for subtree := range c.shape.absent {
+		shape.Absent = append(shape.Absent, subtree)
+	}
+	for subtree := range c.shape.skip {
+		shape.Absent = append(shape.Absent, subtree)
+	}
+	sort.Strings(shape.Absent)
+
+	if len(c.shape.skip) == 0 && c.collectComponents() {
+		now := time.Now().UTC()
+		shape.ProbedAt = &now
+	} else if recorded != nil {
+		shape.ProbedAt = recorded.ProbedAt
+	}
+	return shape
+}
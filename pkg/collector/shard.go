@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// --- Agent Sharding ---
+//
+// Several agents can split a fleet: each is given the shard count and its own
+// index, and collects only the endpoints it owns. Ownership uses rendezvous
+// hashing, so changing the shard count moves only the endpoints of the shards
+// added or removed.
+
+// Shard is the part of the fleet an agent collects. The zero value (or a
+// Count of 1) is the whole fleet.
+type Shard struct {
+	Index int `json:"index"`
+	Count int `json:"count"`
+}
+
+// Validate reports an index outside [0, Count).
+func (s Shard) Validate() error {
+	if s.Count <= 1 {
+		return nil
+	}
+	if s.Index < 0 || s.Index >= s.Count {
+		return fmt.Errorf("shard index %d is outside 0..%d", s.Index, s.Count-1)
+	}
+	return nil
+}
+
+// Owns reports whether the shard collects from the endpoint address.
+func (s Shard) Owns(address string) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	return ShardOwner(address, s.Count) == s.Index
+}
+
+// String describes the shard, e.g. "2/5" (1-based for people).
+func (s Shard) String() string {
+	if s.Count <= 1 {
+		return "all"
+	}
+	return fmt.Sprintf("%d/%d", s.Index+1, s.Count)
+}
+
+// ShardOwner returns the index of the shard that owns address: the shard
+// with the highest hash of the address and its index.
+func ShardOwner(address string, count int) int {
+	owner := 0
+	var best uint64
+	for i := 0; i < count; i++ {
+		h := fnv.New64a()
+		h.Write([]byte(address))
+		h.Write([]byte{0})
+		h.Write([]byte(strconv.Itoa(i)))
+		if sum := mix64(h.Sum64()); i == 0 || sum > best {
+			owner, best = i, sum
+		}
+	}
+	return owner
+}
+
+// ShardIndexFromHostname returns the ordinal suffix of the host name, as
+// given to the pods of a Kubernetes StatefulSet ("collector-3" is 3).
+func ShardIndexFromHostname() (int, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the host name: %w", err)
+	}
+	i := strings.LastIndex(hostname, "-")
+	index, err := strconv.Atoi(hostname[i+1:])
+	if i < 0 || err != nil || index < 0 {
+		return 0, fmt.Errorf("host name %q does not end in a shard ordinal", hostname)
+	}
+	return index, nil
+}
+
+// mix64 spreads the bits of an FNV hash, whose high bits barely change with
+// the last byte; without it the shards get uneven shares.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
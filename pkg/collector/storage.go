@@ -0,0 +1,184 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Drive Discovery (including NVMe namespaces and wear) ---
+
+// oemWearKeys lists OEM fields that report drive wear, in the order they are
+// preferred, and whether the value is "percent used" or "percent remaining".
+var oemWearKeys = []struct {
+	key    string
+	isUsed bool
+}{
+	{"PercentageUsed", true},
+	{"PercentUsed", true},
+	{"RemainingRatedWriteEndurancePercent", false},
+	{"PredictedMediaLifeLeftPercent", false},
+}
+
+// getDriveDevices walks every Storage subsystem of a system and maps its drives.
+func getDriveDevices(c *RedfishClient, storageCollectionURI, parentURI, parentSerial string) ([]*device.DeviceSpec, error) {
+	storageURIs, err := getCollectionMembers(c, storageCollectionURI)
+	if err != nil {
+		return nil, err
+	}
+	var specs []*device.DeviceSpec
+	for _, storageURI := range storageURIs {
+		storageBody, err := c.Get(storageURI)
+		if err != nil {
+			fmt.Printf("Warning: Failed to get storage %s: %v\n", storageURI, err)
+			continue
+		}
+		var storage RedfishStorage
+		if err := json.Unmarshal(storageBody, &storage); err != nil {
+			fmt.Printf("Warning: Failed to decode storage %s: %v\n", storageURI, err)
+			continue
+		}
+		for _, driveLink := range storage.Drives {
+			driveURI := strings.TrimPrefix(driveLink.ODataID, "/redfish/v1")
+			spec, err := getDriveDevice(c, driveURI, parentURI, parentSerial)
+			if err != nil {
+				fmt.Printf("Warning: Failed to map drive %s: %v\n", driveLink.ODataID, err)
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+	return specs, nil
+}
+
+// getDriveDevice maps one Drive. NVMe drives additionally get namespace counts
+// and sizes (from their linked Volumes) and a percentage-used wear figure.
+func getDriveDevice(c *RedfishClient, driveURI, parentURI, parentSerial string) (*device.DeviceSpec, error) {
+	var drive RedfishDrive
+	body, err := c.GetSelected(driveURI, &drive)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &drive); err != nil {
+		return nil, fmt.Errorf("failed to decode drive: %w", err)
+	}
+
+	spec := mapCommonProperties(drive.CommonRedfishProperties, "Drive", driveURI, parentURI, parentSerial)
+	if drive.Protocol != "" {
+		setProperty(spec, "protocol", drive.Protocol)
+	}
+	if drive.MediaType != "" {
+		setProperty(spec, "media_type", drive.MediaType)
+	}
+	if drive.CapacityBytes > 0 {
+		setProperty(spec, "capacity_bytes", drive.CapacityBytes)
+	}
+	if drive.FormFactor != "" {
+		setProperty(spec, "form_factor", drive.FormFactor)
+	}
+	if drive.Revision != "" {
+		setProperty(spec, "firmware_version", drive.Revision)
+	}
+	if drive.PredictedMediaLifeLeftPercent != nil {
+		setProperty(spec, "predicted_life_left_percent", *drive.PredictedMediaLifeLeftPercent)
+	}
+
+	if !strings.EqualFold(drive.Protocol, "NVMe") {
+		return spec, nil
+	}
+
+	// NVMe namespaces are modeled as Volumes linked from the drive
+	namespaceCount := 0
+	var namespaceBytes int64
+	for _, volumeLink := range drive.Links.Volumes {
+		namespaceCount++
+		volumeBody, err := c.Get(strings.TrimPrefix(volumeLink.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to get namespace %s: %v\n", volumeLink.ODataID, err)
+			continue
+		}
+		var volume RedfishVolume
+		if err := json.Unmarshal(volumeBody, &volume); err != nil {
+			fmt.Printf("Warning: Failed to decode namespace %s: %v\n", volumeLink.ODataID, err)
+			continue
+		}
+		namespaceBytes += volume.CapacityBytes
+	}
+	setProperty(spec, "namespace_count", namespaceCount)
+	if namespaceBytes > 0 {
+		setProperty(spec, "namespace_capacity_bytes", namespaceBytes)
+	}
+
+	for key, value := range componentOemProperties(drive.Oem) {
+		setProperty(spec, key, value)
+	}
+	if used, source, ok := getDriveWear(c, drive); ok {
+		setProperty(spec, "percentage_used", used)
+		setProperty(spec, "wear_source", source)
+	}
+	return spec, nil
+}
+
+// getDriveWear returns the NVMe percentage-used figure, preferring DriveMetrics,
+// then the standard PredictedMediaLifeLeftPercent, then known OEM fields.
+func getDriveWear(c *RedfishClient, drive RedfishDrive) (float64, string, bool) {
+	if drive.Metrics.ODataID != "" {
+		metricsBody, err := c.Get(strings.TrimPrefix(drive.Metrics.ODataID, "/redfish/v1"))
+		if err != nil {
+			fmt.Printf("Warning: Failed to get drive metrics %s: %v\n", drive.Metrics.ODataID, err)
+		} else {
+			var metrics RedfishDriveMetrics
+			if err := json.Unmarshal(metricsBody, &metrics); err == nil && metrics.NVMeSMART.PercentageUsed != nil {
+				return *metrics.NVMeSMART.PercentageUsed, "DriveMetrics", true
+			}
+		}
+	}
+	if drive.PredictedMediaLifeLeftPercent != nil {
+		return 100 - *drive.PredictedMediaLifeLeftPercent, "Drive", true
+	}
+	if len(drive.Oem) > 0 {
+		var oem interface{}
+		if err := json.Unmarshal(drive.Oem, &oem); err == nil {
+			if used, ok := findOEMWear(oem); ok {
+				return used, "OEM", true
+			}
+		}
+	}
+	return 0, "", false
+}
+
+// findOEMWear searches an OEM blob for a known wear field, trying the fields
+// of each object before its children, which are visited in key order.
+func findOEMWear(doc interface{}) (float64, bool) {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for _, wear := range oemWearKeys {
+			if n, ok := v[wear.key].(float64); ok {
+				if wear.isUsed {
+					return n, true
+				}
+				return 100 - n, true
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if used, ok := findOEMWear(v[key]); ok {
+				return used, true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if used, ok := findOEMWear(child); ok {
+				return used, true
+			}
+		}
+	}
+	return 0, false
+}
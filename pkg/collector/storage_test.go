@@ -0,0 +1,56 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestFindOEMWear prefers the wear fields in oemWearKeys order, and nested
+// objects in key order, whatever the order of the blob.
+func TestFindOEMWear(t *testing.T) {
+	tests := []struct {
+		name   string
+		oem    string
+		want   float64
+		wantOK bool
+	}{
+		{name: "percent used", oem: `{"Vendor":{"PercentUsed":7}}`, want: 7, wantOK: true},
+		{name: "percent remaining", oem: `{"Vendor":{"PredictedMediaLifeLeftPercent":90}}`, want: 10, wantOK: true},
+		{
+			name:   "used before remaining",
+			oem:    `{"Vendor":{"RemainingRatedWriteEndurancePercent":80,"PercentUsed":5,"PercentageUsed":3}}`,
+			want:   3,
+			wantOK: true,
+		},
+		{
+			name:   "object fields before children",
+			oem:    `{"Vendor":{"Nested":{"PercentageUsed":40},"PredictedMediaLifeLeftPercent":99}}`,
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name:   "children in key order",
+			oem:    `{"Zeta":{"PercentageUsed":50},"Alpha":{"PercentageUsed":20}}`,
+			want:   20,
+			wantOK: true,
+		},
+		{name: "in a list", oem: `{"Vendor":[{},{"PercentageUsed":12}]}`, want: 12, wantOK: true},
+		{name: "not a number", oem: `{"Vendor":{"PercentageUsed":"12"}}`},
+		{name: "none", oem: `{"Vendor":{"Health":"OK"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var oem interface{}
+			if err := json.Unmarshal([]byte(tt.oem), &oem); err != nil {
+				t.Fatal(err)
+			}
+			// Maps iterate in a random order; a single run could pass by chance
+			for i := 0; i < 20; i++ {
+				got, ok := findOEMWear(oem)
+				if ok != tt.wantOK || got != tt.want {
+					t.Fatalf("findOEMWear = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// --- Streaming Decoding ---
+//
+// Collections can be large: hundreds of members, which some BMCs expand
+// inline whether asked to or not. Reading such a response whole and then
+// unmarshaling it holds the body and its decoded form at once. Collections
+// are instead decoded member by member off the connection, keeping only the
+// fields the collector uses, so that its memory stays bounded on small
+// management VMs. The deep profile still reads bodies whole, since the raw
+// capture keeps them anyway.
+
+// getStream reads the resource at path with decode, streaming the body. The
+// read is recorded in the client's coverage; a body that fails to decode
+// counts as a failed read, since it may have been cut short.
+func (c *RedfishClient) getStream(path string, decode func(*json.Decoder) error) error {
+	err := c.stream(path, decode)
+	c.noteRead(path, err)
+	return err
+}
+
+// stream performs the request for getStream.
+func (c *RedfishClient) stream(path string, decode func(*json.Decoder) error) error {
+	if c.capture != nil {
+		body, err := c.get(path)
+		if err != nil {
+			return err
+		}
+		return decode(json.NewDecoder(bytes.NewReader(body)))
+	}
+	resp, err := c.open(path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decode(json.NewDecoder(resp.Body))
+}
+
+// decodeCollection decodes a collection page, keeping only the link of each
+// member.
+func decodeCollection(dec *json.Decoder, collection *RedfishCollection) error {
+	return decodeObject(dec, func(key string) error {
+		switch key {
+		case "Members":
+			return decodeArray(dec, func() error {
+				var member struct {
+					ODataID string `json:"@odata.id"`
+				}
+				if err := dec.Decode(&member); err != nil {
+					return err
+				}
+				collection.Members = append(collection.Members, member)
+				return nil
+			})
+		case "Members@odata.count":
+			return dec.Decode(&collection.Count)
+		case "Members@odata.nextLink":
+			return dec.Decode(&collection.NextLink)
+		}
+		return skipValue(dec)
+	})
+}
+
+// decodeObject calls member for each key of the next JSON object, which must
+// consume the key's value. A null object has no keys.
+func decodeObject(dec *json.Decoder, member func(key string) error) error {
+	if open, err := dec.Token(); err != nil || open == nil {
+		return err
+	} else if open != json.Delim('{') {
+		return fmt.Errorf("expected an object, found %v", open)
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := member(token.(string)); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// decodeArray calls element for each element of the next JSON array, which
+// must consume the element. A null array has no elements.
+func decodeArray(dec *json.Decoder, element func() error) error {
+	if open, err := dec.Token(); err != nil || open == nil {
+		return err
+	} else if open != json.Delim('[') {
+		return fmt.Errorf("expected an array, found %v", open)
+	}
+	for dec.More() {
+		if err := element(); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+// skipValue discards the next JSON value without holding it whole.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Supermicro OEM Enrichment ---
+//
+// Supermicro systems often report a placeholder or chassis-level SerialNumber;
+// the motherboard's FRU serial, which Supermicro support asks for, is only in
+// the chassis' Oem.Supermicro block. The Memory collection quirks of their
+// BMCs are handled by the "memory-collection-path" quirk.
+
+// supermicroExtension maps Oem.Supermicro blocks.
+var supermicroExtension = &oemExtension{
+	Key:       "Supermicro",
+	System:    enrichSupermicroSystem,
+	Component: supermicroComponentProperties,
+}
+
+// supermicroChassis is the part of a Supermicro chassis' Oem block holding
+// the board FRU fields.
+type supermicroChassis struct {
+	Oem struct {
+		Supermicro map[string]json.RawMessage `json:"Supermicro"`
+	} `json:"Oem"`
+}
+
+// enrichSupermicroSystem sets the Oem.Supermicro attributes of a node and the
+// board FRU fields of its chassis.
+func enrichSupermicroSystem(c *RedfishClient, spec *device.DeviceSpec, system *RedfishSystem, oem json.RawMessage) {
+	for key, value := range supermicroComponentProperties(oem) {
+		setProperty(spec, key, value)
+	}
+
+	// The chassis needs another request, so the quick profile skips it
+	if !c.collectComponents() || len(system.Links.Chassis) == 0 {
+		return
+	}
+	chassisURI := strings.TrimPrefix(system.Links.Chassis[0].ODataID, "/redfish/v1")
+	body, err := c.Get(chassisURI)
+	if err != nil {
+		fmt.Printf("Warning: Failed to get chassis %s: %v\n", chassisURI, err)
+		return
+	}
+	var chassis supermicroChassis
+	if err := json.Unmarshal(body, &chassis); err != nil {
+		fmt.Printf("Warning: Failed to decode chassis %s: %v\n", chassisURI, err)
+		return
+	}
+	for key, value := range oemAttributeProperties("supermicro_chassis_", chassis.Oem.Supermicro) {
+		setProperty(spec, key, value)
+	}
+}
+
+// supermicroComponentProperties maps the attributes of an Oem.Supermicro
+// block to "supermicro_"-prefixed properties.
+func supermicroComponentProperties(oem json.RawMessage) map[string]interface{} {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(oem, &attributes); err != nil {
+		return nil
+	}
+	return oemAttributeProperties("supermicro_", attributes)
+}
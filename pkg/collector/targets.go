@@ -0,0 +1,368 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// --- Target Import ---
+//
+// Sites already know their BMCs: SMD (the OpenCHAMI State Management
+// Database) lists them as RedfishEndpoints, and the Kea DHCP server holds
+// their leases. Importing them creates the BMCEndpoints the agent collects
+// from, so the target list need not be typed in.
+
+// Target sources.
+const (
+	TargetSourceSMD = "smd"
+	TargetSourceKea = "kea"
+)
+
+// TargetSources lists the valid target sources.
+var TargetSources = []string{TargetSourceSMD, TargetSourceKea}
+
+// LabelImportedFrom is the label recording which source an imported
+// BMCEndpoint came from.
+const LabelImportedFrom = "imported-from"
+
+// Target is a BMC found in a target source.
+type Target struct {
+	// Name is the BMCEndpoint name: the xname in SMD, the lease host name in Kea.
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	// Disabled marks a BMC the source has disabled.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// SMDTargets lists the RedfishEndpoints of the SMD at smdURL (e.g.
+// "https://smd.example.com"). token, if set, is sent as a bearer token.
+func SMDTargets(ctx context.Context, client *http.Client, smdURL, token string) ([]Target, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(smdURL, "/")+"/hsm/v2/Inventory/RedfishEndpoints", nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMD URL %q: %w", smdURL, err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	var body struct {
+		RedfishEndpoints []struct {
+			ID        string `json:"ID"`
+			FQDN      string `json:"FQDN"`
+			Hostname  string `json:"Hostname"`
+			IPAddress string `json:"IPAddress"`
+			Enabled   *bool  `json:"Enabled"`
+		} `json:"RedfishEndpoints"`
+	}
+	if err := doJSON(client, req, &body); err != nil {
+		return nil, fmt.Errorf("failed to list SMD RedfishEndpoints: %w", err)
+	}
+	var targets []Target
+	for _, ep := range body.RedfishEndpoints {
+		address := firstNonEmpty(ep.IPAddress, ep.FQDN, ep.Hostname)
+		if address == "" {
+			continue
+		}
+		targets = append(targets, Target{
+			Name:     firstNonEmpty(ep.ID, address),
+			Address:  address,
+			Disabled: ep.Enabled != nil && !*ep.Enabled,
+		})
+	}
+	return targets, nil
+}
+
+// KeaFilter selects the leases of BMCs among a Kea server's.
+type KeaFilter struct {
+	// SubnetIDs keeps the leases of these subnets; empty keeps all.
+	SubnetIDs []int
+	// Hostname keeps the leases whose host name matches; nil keeps all.
+	Hostname *regexp.Regexp
+}
+
+// keaLease is the part of a Kea DHCPv4 lease used.
+type keaLease struct {
+	Address  string
+	Hostname string
+	SubnetID int
+	// State is 0 for a leased address; 1 and 2 are declined and expired.
+	State int
+	// Expire is when the lease ends.
+	Expire time.Time
+}
+
+// KeaTargets lists the active leases the filter keeps, asking the Kea
+// Control Agent at agentURL (e.g. "http://kea:8000") with lease4-get-all.
+func KeaTargets(ctx context.Context, client *http.Client, agentURL string, filter KeaFilter) ([]Target, error) {
+	command := map[string]interface{}{"command": "lease4-get-all", "service": []string{"dhcp4"}}
+	if len(filter.SubnetIDs) > 0 {
+		command["arguments"] = map[string]interface{}{"subnets": filter.SubnetIDs}
+	}
+	payload, err := json.Marshal(command)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, agentURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kea Control Agent URL %q: %w", agentURL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var responses []struct {
+		Result    int    `json:"result"`
+		Text      string `json:"text"`
+		Arguments struct {
+			Leases []struct {
+				IPAddress string `json:"ip-address"`
+				Hostname  string `json:"hostname"`
+				SubnetID  int    `json:"subnet-id"`
+				State     int    `json:"state"`
+				CLTT      int64  `json:"cltt"`
+				ValidLft  int64  `json:"valid-lft"`
+			} `json:"leases"`
+		} `json:"arguments"`
+	}
+	if err := doJSON(client, req, &responses); err != nil {
+		return nil, fmt.Errorf("failed to list Kea leases: %w", err)
+	}
+	var leases []keaLease
+	for _, resp := range responses {
+		// 3 means the server has no leases
+		if resp.Result != 0 && resp.Result != 3 {
+			return nil, fmt.Errorf("kea lease4-get-all failed: %s", resp.Text)
+		}
+		for _, l := range resp.Arguments.Leases {
+			leases = append(leases, keaLease{
+				Address:  l.IPAddress,
+				Hostname: l.Hostname,
+				SubnetID: l.SubnetID,
+				State:    l.State,
+				Expire:   time.Unix(l.CLTT+l.ValidLft, 0),
+			})
+		}
+	}
+	return filter.targets(leases, time.Now()), nil
+}
+
+// KeaLeaseFileTargets lists the active leases the filter keeps from a Kea
+// memfile lease file (e.g. /var/lib/kea/kea-leases4.csv). The file is
+// appended to as leases change, so the last line of an address wins.
+func KeaLeaseFileTargets(file string, filter KeaFilter) ([]Target, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Kea lease file %s: %w", file, err)
+	}
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		column[name] = i
+	}
+	for _, name := range []string{"address", "expire", "subnet_id", "hostname", "state"} {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("kea lease file %s has no %s column", file, name)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i := column[name]; i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	byAddress := make(map[string]int)
+	var leases []keaLease
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Kea lease file %s: %w", file, err)
+		}
+		expire, _ := strconv.ParseInt(field(record, "expire"), 10, 64)
+		subnetID, _ := strconv.Atoi(field(record, "subnet_id"))
+		state, _ := strconv.Atoi(field(record, "state"))
+		lease := keaLease{
+			Address:  field(record, "address"),
+			Hostname: field(record, "hostname"),
+			SubnetID: subnetID,
+			State:    state,
+			Expire:   time.Unix(expire, 0),
+		}
+		if i, ok := byAddress[lease.Address]; ok {
+			leases[i] = lease
+			continue
+		}
+		byAddress[lease.Address] = len(leases)
+		leases = append(leases, lease)
+	}
+	return filter.targets(leases, time.Now()), nil
+}
+
+// targets returns the targets of the active leases the filter keeps.
+func (f KeaFilter) targets(leases []keaLease, now time.Time) []Target {
+	subnets := make(map[int]bool, len(f.SubnetIDs))
+	for _, id := range f.SubnetIDs {
+		subnets[id] = true
+	}
+	var targets []Target
+	for _, lease := range leases {
+		if lease.Address == "" || lease.State != 0 || lease.Expire.Before(now) {
+			continue
+		}
+		if len(subnets) > 0 && !subnets[lease.SubnetID] {
+			continue
+		}
+		hostname := strings.TrimSuffix(lease.Hostname, ".")
+		if f.Hostname != nil && !f.Hostname.MatchString(hostname) {
+			continue
+		}
+		name, _, _ := strings.Cut(hostname, ".")
+		if name == "" {
+			name = "bmc-" + strings.ReplaceAll(lease.Address, ".", "-")
+		}
+		targets = append(targets, Target{Name: name, Address: lease.Address})
+	}
+	return targets
+}
+
+// ImportOptions selects the target source and how its BMCs are registered.
+type ImportOptions struct {
+	// From is the target source, one of TargetSources.
+	From string
+	// SMDURL and SMDToken locate and authenticate to SMD.
+	SMDURL   string
+	SMDToken string
+	// KeaURL is the Kea Control Agent; KeaLeaseFile, when set, is read
+	// instead.
+	KeaURL       string
+	KeaLeaseFile string
+	KeaFilter    KeaFilter
+	// Spec gives the profile and backend of the BMCEndpoints created.
+	Spec bmcendpoint.BMCEndpointSpec
+	// DryRun lists the BMCEndpoints that would be created without creating
+	// them.
+	DryRun bool
+}
+
+// ImportResult is the outcome of importing targets.
+type ImportResult struct {
+	From   string `json:"from"`
+	DryRun bool   `json:"dryRun"`
+	// Found counts the targets the source listed.
+	Found int `json:"found"`
+	// Created lists the targets registered (or, in a dry run, that would be).
+	Created []Target `json:"created"`
+	// Existing counts the targets whose address already has a BMCEndpoint.
+	Existing int      `json:"existing"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// ImportTargets lists the BMCs of the target source and creates a
+// BMCEndpoint for each whose address has none, labelled with the source.
+// The source is reached with the CA file and TLS policy of the API.
+func (opts CollectOptions) ImportTargets(ctx context.Context, imp ImportOptions) (*ImportResult, error) {
+	pool, err := certPool(opts.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{
+		Transport: opts.TLSPolicy.Transport(&tls.Config{RootCAs: pool}),
+		Timeout:   opts.APIRetry.Timeout,
+	}
+
+	var targets []Target
+	switch imp.From {
+	case TargetSourceSMD:
+		if imp.SMDURL == "" {
+			return nil, errors.New("importing from smd needs the SMD URL")
+		}
+		targets, err = SMDTargets(ctx, httpClient, imp.SMDURL, imp.SMDToken)
+	case TargetSourceKea:
+		switch {
+		case imp.KeaLeaseFile != "":
+			targets, err = KeaLeaseFileTargets(imp.KeaLeaseFile, imp.KeaFilter)
+		case imp.KeaURL != "":
+			targets, err = KeaTargets(ctx, httpClient, imp.KeaURL, imp.KeaFilter)
+		default:
+			return nil, errors.New("importing from kea needs the Control Agent URL or a lease file")
+		}
+	default:
+		return nil, fmt.Errorf("unknown target source %q (valid: %v)", imp.From, TargetSources)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sdkClient, err := opts.apiClient()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for endpoint, err := range fabricaclient.BMCEndpoints(sdkClient).All(ctx, fabricaclient.ListOptions{}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list BMC endpoints: %w", err)
+		}
+		known[endpoint.Spec.Address] = true
+	}
+
+	result := &ImportResult{From: imp.From, DryRun: imp.DryRun, Found: len(targets), Created: []Target{}}
+	writeOpts := fabricaclient.WriteOptions{Labels: map[string]string{LabelImportedFrom: imp.From}}
+	for _, target := range targets {
+		// A BMC listed twice is imported once
+		if known[target.Address] {
+			result.Existing++
+			continue
+		}
+		known[target.Address] = true
+		if !imp.DryRun {
+			spec := imp.Spec
+			spec.Address, spec.Disabled = target.Address, target.Disabled
+			if _, err := fabricaclient.BMCEndpoints(sdkClient).Create(ctx, target.Name, spec, writeOpts); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s (%s): %v", target.Name, target.Address, err))
+				continue
+			}
+		}
+		result.Created = append(result.Created, target)
+	}
+	return result, nil
+}
+
+// doJSON performs req and decodes the JSON response into v.
+func doJSON(client *http.Client, req *http.Request, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return redact.Error(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s", req.Method, redact.URL(req.URL.String()), resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Redfish Task Monitors ---
+//
+// A BMC that cannot answer a request in time (under load, or for slow OEM
+// resources that gather data from other controllers) may answer 202 Accepted
+// with a task monitor URI in its Location header instead. The task monitor
+// answers 202 while the task runs and the original response once it is
+// done. Some BMCs answer with the Task resource instead, in which case the
+// original request is sent again once the task completed.
+
+// DefaultTaskTimeout is how long the collector follows a task monitor.
+const DefaultTaskTimeout = 2 * time.Minute
+
+// Polling intervals of task monitors that do not say with Retry-After.
+var (
+	taskPollInterval    = time.Second
+	maxTaskPollInterval = 10 * time.Second
+)
+
+// redfishTask is the part of a Task resource a task monitor may answer with.
+type redfishTask struct {
+	ODataType string `json:"@odata.type"`
+	TaskState string `json:"TaskState"`
+	Messages  []struct {
+		Message string `json:"Message"`
+	} `json:"Messages"`
+}
+
+// followTask polls the task monitor of a 202 response to targetURL until the
+// task is done, and returns the response it ends with, which must be 200 OK.
+// The caller closes its body.
+func (c *RedfishClient) followTask(accepted *http.Response, targetURL string) (*http.Response, error) {
+	accepted.Body.Close()
+	monitor := accepted.Header.Get("Location")
+	if monitor == "" {
+		return nil, fmt.Errorf("%s answered 202 Accepted without a task monitor", targetURL)
+	}
+	monitorURL, err := c.resolve(monitor)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := c.TaskTimeout
+	if timeout <= 0 {
+		timeout = DefaultTaskTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	wait := retryAfter(accepted, taskPollInterval)
+	for {
+		if time.Now().Add(wait).After(deadline) {
+			return nil, fmt.Errorf("task for %s did not complete within %s (monitor %s)", targetURL, timeout, monitor)
+		}
+		time.Sleep(wait)
+		resp, err := c.do(http.MethodGet, monitorURL)
+		if err != nil {
+			return nil, err
+		}
+		switch resp.StatusCode {
+		case http.StatusAccepted:
+			resp.Body.Close()
+			wait = retryAfter(resp, min(2*wait, maxTaskPollInterval))
+			continue
+		case http.StatusOK:
+		default:
+			resp.Body.Close()
+			return nil, &statusError{Code: resp.StatusCode, URL: monitorURL}
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task monitor response: %w", err)
+		}
+		var task redfishTask
+		if json.Unmarshal(body, &task) != nil || !strings.HasPrefix(task.ODataType, "#Task.") {
+			// The monitor answers with the original response
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return resp, nil
+		}
+		switch task.TaskState {
+		case "Completed":
+			return c.redo(targetURL)
+		case "Exception", "Killed", "Cancelled":
+			var messages []string
+			for _, m := range task.Messages {
+				messages = append(messages, m.Message)
+			}
+			return nil, fmt.Errorf("task for %s ended %s: %s", targetURL, task.TaskState, strings.Join(messages, "; "))
+		}
+		// A running task the monitor reports with 200
+		wait = min(2*wait, maxTaskPollInterval)
+	}
+}
+
+// redo sends a request again once its task completed.
+func (c *RedfishClient) redo(targetURL string) (*http.Response, error) {
+	resp, err := c.do(http.MethodGet, targetURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &statusError{Code: resp.StatusCode, URL: targetURL}
+	}
+	return resp, nil
+}
+
+// resolve returns the URL of a URI a BMC returned, which is usually a path
+// from the service's root (which BaseURL may prefix, through a proxy). The
+// request carries the BMC's credentials, so a URI on another scheme or host
+// is refused.
+func (c *RedfishClient) resolve(uri string) (string, error) {
+	if strings.HasPrefix(uri, "/") && !strings.HasPrefix(uri, "//") {
+		return strings.TrimSuffix(c.BaseURL, "/redfish/v1") + uri, nil
+	}
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid task monitor %q: %w", uri, err)
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != base.Scheme || !strings.EqualFold(resolved.Host, base.Host) {
+		return "", fmt.Errorf("task monitor %q is not on the BMC %s", uri, base.Host)
+	}
+	return resolved.String(), nil
+}
+
+// retryAfter returns the wait a response asks for in seconds with
+// Retry-After, or def.
+func retryAfter(resp *http.Response, def time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return min(time.Duration(seconds)*time.Second, maxTaskPollInterval)
+	}
+	return def
+}
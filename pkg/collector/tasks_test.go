@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+)
+
+// TestFollowTask follows task monitors on the BMC, and refuses those on
+// another host without sending it the BMC's credentials.
+func TestFollowTask(t *testing.T) {
+	defer func(interval time.Duration) { taskPollInterval = interval }(taskPollInterval)
+	taskPollInterval = time.Millisecond
+
+	var offHost atomic.Int32
+	rogue := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offHost.Add(1)
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer rogue.Close()
+
+	tests := []struct {
+		name string
+		// taskHost returns the host of the task monitors of the BMC at url
+		taskHost func(url string) string
+		wantErr  string
+	}{
+		{name: "path", taskHost: func(string) string { return "" }},
+		{name: "URL on the BMC", taskHost: func(url string) string { return url }},
+		{name: "another host", taskHost: func(string) string { return rogue.URL }, wantErr: "is not on the BMC"},
+		{
+			name:     "network-path reference",
+			taskHost: func(string) string { return strings.TrimPrefix(rogue.URL, "https:") },
+			wantErr:  "is not on the BMC",
+		},
+		{
+			name:     "the BMC over HTTP",
+			taskHost: func(url string) string { return "http:" + strings.TrimPrefix(url, "https:") },
+			wantErr:  "is not on the BMC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offHost.Store(0)
+			mock, err := redfishmock.New(redfishmock.Fixture(redfishmock.DefaultNode("TASK0001")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			mock.Username, mock.Password = "admin", "admin"
+			server := httptest.NewTLSServer(mock)
+			defer server.Close()
+			mock.SetFaults(redfishmock.Faults{
+				TaskRate:  1,
+				TaskPolls: 1,
+				TaskHost:  tt.taskHost(server.URL),
+				Paths:     []string{redfishmock.Root + "/Systems"},
+			})
+
+			c, err := NewRedfishClient(strings.TrimPrefix(server.URL, "https://"), "admin", "admin")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = c.Get("/Systems")
+			if mock.Stats().Tasks == 0 {
+				t.Fatal("the BMC answered without a task")
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Get error = %v, want one containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if n := offHost.Load(); n != 0 {
+				t.Errorf("the other host was sent %d request(s)", n)
+			}
+		})
+	}
+}
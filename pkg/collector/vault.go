@@ -0,0 +1,226 @@
+package collector
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/tlspolicy"
+)
+
+// --- Vault Credentials ---
+//
+// Sites whose compliance rules forbid BMC passwords in files and environment
+// variables keep them in HashiCorp Vault. The collector reads each BMC's
+// secret at collection time, from a path templated by its address, and
+// keeps nothing: the secret's username and password fields are the login.
+// Both KV version 1 and version 2 engines are read; a version 2 path has
+// "data" after the mount, as in DefaultVaultPath.
+
+// DefaultVaultPath is the secret path template of a BMC. It is a Go template
+// over the BMC's Address (as given), Host (without the port), IP and
+// Hostname; IP and Hostname are looked up in DNS when the address is the
+// other.
+const DefaultVaultPath = "secret/data/bmc/{{.Host}}"
+
+// vaultTimeout bounds each secret read.
+const vaultTimeout = 30 * time.Second
+
+// VaultOptions configures the Vault store. Empty fields are taken from the
+// environment variables the vault CLI reads.
+type VaultOptions struct {
+	// Addr is the Vault URL (VAULT_ADDR).
+	Addr string
+	// Token authenticates the reads (VAULT_TOKEN, else the vault CLI's
+	// ~/.vault-token).
+	Token string
+	// Namespace is the Vault Enterprise namespace (VAULT_NAMESPACE).
+	Namespace string
+	// CAFile holds the CA certificates of Vault's certificate (VAULT_CACERT),
+	// trusted besides the system trust store.
+	CAFile string
+	// Path is the secret path template (default DefaultVaultPath).
+	Path      string
+	TLSPolicy tlspolicy.Policy
+}
+
+// VaultCredentials reads each BMC's credential from a Vault secret.
+type VaultCredentials struct {
+	addr      string
+	token     string
+	namespace string
+	path      *template.Template
+	client    *http.Client
+}
+
+// NewVaultCredentials returns the Vault store opts describes, completed from
+// the environment. It fails without a Vault URL or token, or with a path
+// template that does not parse.
+func NewVaultCredentials(opts VaultOptions) (*VaultCredentials, error) {
+	addr := firstNonEmpty(opts.Addr, os.Getenv("VAULT_ADDR"))
+	if addr == "" {
+		return nil, errors.New("no Vault URL; give --vault-addr or VAULT_ADDR")
+	}
+	token := firstNonEmpty(opts.Token, os.Getenv("VAULT_TOKEN"))
+	if token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				token = strings.TrimSpace(string(data))
+			}
+		}
+	}
+	if token == "" {
+		return nil, errors.New("no Vault token; set VAULT_TOKEN or log in with the vault CLI")
+	}
+	path, err := template.New("vault-path").Option("missingkey=error").Parse(firstNonEmpty(opts.Path, DefaultVaultPath))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Vault path template: %w", err)
+	}
+	pool, err := certPool(firstNonEmpty(opts.CAFile, os.Getenv("VAULT_CACERT")))
+	if err != nil {
+		return nil, err
+	}
+	return &VaultCredentials{
+		addr:      strings.TrimSuffix(addr, "/"),
+		token:     token,
+		namespace: firstNonEmpty(opts.Namespace, os.Getenv("VAULT_NAMESPACE")),
+		path:      path,
+		client: &http.Client{
+			Transport: opts.TLSPolicy.Transport(&tls.Config{RootCAs: pool}),
+			Timeout:   vaultTimeout,
+		},
+	}, nil
+}
+
+// Lookup reads the secret of the address. A missing secret fails the
+// lookup, as Vault is the only store of the BMCs it serves.
+func (v *VaultCredentials) Lookup(address string) (Credential, error) {
+	var path bytes.Buffer
+	if err := v.path.Execute(&path, newVaultTarget(address)); err != nil {
+		return Credential{}, fmt.Errorf("failed to template the Vault path: %w", err)
+	}
+	secretPath := strings.Trim(path.String(), "/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.addr+"/v1/"+secretPath, nil)
+	if err != nil {
+		return Credential{}, fmt.Errorf("invalid Vault URL: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return Credential{}, fmt.Errorf("failed to reach Vault: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return Credential{}, fmt.Errorf("failed to read Vault secret %s: %w", secretPath, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Credential{}, fmt.Errorf("no Vault secret at %s", secretPath)
+	case resp.StatusCode != http.StatusOK:
+		return Credential{}, fmt.Errorf("Vault refused to read %s: %s%s", secretPath, resp.Status, vaultErrors(body))
+	}
+	return parseVaultSecret(body, secretPath)
+}
+
+// parseVaultSecret returns the login of a KV version 1 or 2 secret.
+func parseVaultSecret(body []byte, secretPath string) (Credential, error) {
+	var secret struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return Credential{}, fmt.Errorf("failed to parse Vault secret %s: %w", secretPath, err)
+	}
+	// Version 2 wraps the fields in data.data, next to data.metadata
+	var versioned struct {
+		Data     *Credential     `json:"data"`
+		Metadata json.RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(secret.Data, &versioned); err == nil && versioned.Data != nil && versioned.Metadata != nil {
+		return completeVaultSecret(*versioned.Data, secretPath)
+	}
+	var cred Credential
+	if err := json.Unmarshal(secret.Data, &cred); err != nil {
+		return Credential{}, fmt.Errorf("failed to parse Vault secret %s: %w", secretPath, err)
+	}
+	return completeVaultSecret(cred, secretPath)
+}
+
+// completeVaultSecret checks that a secret holds a password.
+func completeVaultSecret(cred Credential, secretPath string) (Credential, error) {
+	if cred.Password == "" {
+		return Credential{}, fmt.Errorf("Vault secret %s has no password field", secretPath)
+	}
+	return cred, nil
+}
+
+// vaultErrors returns the errors of a Vault error response, for messages.
+func vaultErrors(body []byte) string {
+	var resp struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) != nil || len(resp.Errors) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(resp.Errors, "; ")
+}
+
+// vaultTarget is what a Vault path template sees of a BMC.
+type vaultTarget struct {
+	Address string
+	Host    string
+}
+
+func newVaultTarget(address string) vaultTarget {
+	host := address
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		host = h
+	}
+	return vaultTarget{Address: address, Host: host}
+}
+
+// IP returns the BMC's IP address, looking up a host name.
+func (t vaultTarget) IP() (string, error) {
+	if net.ParseIP(t.Host) != nil {
+		return t.Host, nil
+	}
+	ips, err := net.LookupIP(t.Host)
+	if err != nil || len(ips) == 0 {
+		return "", fmt.Errorf("failed to look up the IP address of %s: %v", t.Host, err)
+	}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip.String(), nil
+		}
+	}
+	return ips[0].String(), nil
+}
+
+// Hostname returns the BMC's host name, looking up an IP address.
+func (t vaultTarget) Hostname() (string, error) {
+	if net.ParseIP(t.Host) == nil {
+		return t.Host, nil
+	}
+	names, err := net.LookupAddr(t.Host)
+	if err != nil || len(names) == 0 {
+		return "", fmt.Errorf("failed to look up the host name of %s: %v", t.Host, err)
+	}
+	return strings.TrimSuffix(names[0], "."), nil
+}
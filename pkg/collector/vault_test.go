@@ -0,0 +1,175 @@
+package collector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestParseVaultSecret reads the logins of KV version 1 and 2 secrets.
+func TestParseVaultSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    Credential
+		wantErr string
+	}{
+		{
+			name: "version 2",
+			body: `{"data":{"data":{"username":"admin","password":"s3cret"},"metadata":{"version":3}}}`,
+			want: Credential{Username: "admin", Password: "s3cret"},
+		},
+		{
+			name: "version 1",
+			body: `{"lease_duration":2764800,"data":{"username":"admin","password":"s3cret"}}`,
+			want: Credential{Username: "admin", Password: "s3cret"},
+		},
+		{
+			name: "version 1 without a username",
+			body: `{"data":{"password":"s3cret"}}`,
+			want: Credential{Password: "s3cret"},
+		},
+		{
+			name: "version 1 with a field named data",
+			body: `{"data":{"data":"x","username":"admin","password":"s3cret"}}`,
+			want: Credential{Username: "admin", Password: "s3cret"},
+		},
+		{
+			name:    "version 2 without a password",
+			body:    `{"data":{"data":{"username":"admin"},"metadata":{"version":1}}}`,
+			wantErr: "no password field",
+		},
+		{
+			name:    "deleted version 2 secret",
+			body:    `{"data":{"data":null,"metadata":{"deletion_time":"2026-01-01T00:00:00Z"}}}`,
+			wantErr: "no password field",
+		},
+		{name: "not JSON", body: `<html>`, wantErr: "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVaultSecret([]byte(tt.body), "secret/data/bmc/10.0.0.5")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseVaultSecret error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseVaultSecret failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseVaultSecret = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVaultLookup reads secrets from a stand-in Vault at templated paths.
+func TestVaultLookup(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("X-Vault-Token") != "token":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+		case r.URL.Path == "/v1/secret/data/bmc/10.0.0.5" && r.Header.Get("X-Vault-Namespace") == "ops":
+			w.Write([]byte(`{"data":{"data":{"username":"admin","password":"s3cret"},"metadata":{}}}`))
+		case r.URL.Path == "/v1/kv/bmc/10.0.0.6:8443":
+			w.Write([]byte(`{"data":{"username":"root","password":"other"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer vault.Close()
+
+	tests := []struct {
+		name    string
+		opts    VaultOptions
+		address string
+		want    Credential
+		wantErr string
+	}{
+		{
+			name: "host of the address", address: "10.0.0.5:443",
+			opts: VaultOptions{Token: "token", Namespace: "ops"},
+			want: Credential{Username: "admin", Password: "s3cret"},
+		},
+		{
+			name: "whole address", address: "10.0.0.6:8443",
+			opts: VaultOptions{Token: "token", Path: "kv/bmc/{{.Address}}"},
+			want: Credential{Username: "root", Password: "other"},
+		},
+		{
+			name: "missing secret", address: "10.0.0.7",
+			opts:    VaultOptions{Token: "token"},
+			wantErr: "no Vault secret at secret/data/bmc/10.0.0.7",
+		},
+		{
+			name: "refused token", address: "10.0.0.5",
+			opts:    VaultOptions{Token: "wrong"},
+			wantErr: "403 Forbidden: permission denied",
+		},
+		{
+			name: "unknown template field", address: "10.0.0.5",
+			opts:    VaultOptions{Token: "token", Path: "kv/{{.Rack}}"},
+			wantErr: "failed to template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Addr = vault.URL
+			store, err := NewVaultCredentials(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := store.Lookup(tt.address)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Lookup error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lookup failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Lookup = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVaultStoreRefusesPlaintext checks that the vault store neither takes
+// nor falls back to passwords from the configuration or the environment.
+func TestVaultStoreRefusesPlaintext(t *testing.T) {
+	vault := httptest.NewServer(http.NotFoundHandler())
+	defer vault.Close()
+	opts := VaultOptions{Addr: vault.URL, Token: "token"}
+	t.Setenv("COLLECTOR_BMC_PASSWORD", "")
+	t.Setenv("INVENTORY_BMC_PASSWORD", "")
+
+	for name, config := range map[string]ConfigCredentials{
+		"default":     {Default: Credential{Username: "root", Password: "plain"}},
+		"per address": {Addresses: map[string]Credential{"10.0.0.5": {Password: "plain"}}},
+	} {
+		if _, err := NewCredentialStore(CredentialStoreVault, config, opts); err == nil {
+			t.Errorf("a %s password in the configuration was accepted", name)
+		}
+	}
+	t.Setenv("COLLECTOR_BMC_PASSWORD", "plain")
+	if _, err := NewCredentialStore(CredentialStoreVault, ConfigCredentials{}, opts); err == nil {
+		t.Error("COLLECTOR_BMC_PASSWORD was accepted")
+	}
+	t.Setenv("COLLECTOR_BMC_PASSWORD", "")
+
+	// A username alone is no secret
+	store, err := NewCredentialStore(CredentialStoreVault, ConfigCredentials{Default: Credential{Username: "root"}}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Lookup("10.0.0.5"); err == nil {
+		t.Error("a BMC without a Vault secret was given a login")
+	}
+}
@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// DefaultWaitTimeout is how long Follow waits for the reconciler by default.
+const DefaultWaitTimeout = 10 * time.Minute
+
+// waitInterval is how often Follow reads the snapshot's status.
+var waitInterval = time.Second
+
+// ErrSnapshotFailed is returned by Follow when the reconciler rejected the
+// snapshot, held it for approval or failed to process it.
+var ErrSnapshotFailed = errors.New("the reconciler did not apply the snapshot")
+
+// finishedPhases are the phases a snapshot ends in; the reconciler does not
+// process it again once it reaches one, unless an operator approves a held
+// snapshot.
+var finishedPhases = []string{"Completed", "Rejected", "Error", discoverysnapshot.PhaseHeld}
+
+// Follow waits for the server's reconciler to finish with the snapshot of
+// summary, for at most timeout, printing each status it moves through. It
+// records the final phase and message on summary, and returns an error
+// wrapping ErrSnapshotFailed unless the snapshot was Completed. It needs the
+// snapshot to have been published to the inventory API alone.
+func Follow(summary *RunSummary, opts CollectOptions, timeout time.Duration) error {
+	if !opts.Publish.APIOnly() {
+		return fmt.Errorf("waiting for the reconciler needs the %s publish target alone", PublishAPI)
+	}
+	sdkClient, err := opts.apiClient()
+	if err != nil {
+		return fmt.Errorf("failed to create fabrica client: %w", err)
+	}
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	ctx, cancel := context.WithTimeout(fabricaclient.WithRunID(context.Background(), summary.RunID), timeout)
+	defer cancel()
+
+	fmt.Printf("Waiting for the reconciler to process snapshot %s...\n", summary.Snapshot)
+	var last discoverysnapshot.DiscoverySnapshotStatus
+	ticker := time.NewTicker(waitInterval)
+	defer ticker.Stop()
+	for {
+		snapshot, err := sdkClient.GetDiscoverySnapshot(ctx, summary.Snapshot)
+		switch {
+		case err == nil:
+			status := snapshot.Status
+			if status.Phase != last.Phase || status.Message != last.Message {
+				printSnapshotStatus(status)
+				last = status
+			}
+		case ctx.Err() == nil:
+			fmt.Printf("Warning: Failed to read snapshot %s: %v\n", summary.Snapshot, err)
+		}
+		summary.Phase, summary.Message = last.Phase, last.Message
+		if slices.Contains(finishedPhases, last.Phase) {
+			if last.Phase != "Completed" {
+				return fmt.Errorf("%w: snapshot %s ended %s: %s", ErrSnapshotFailed, summary.Snapshot, last.Phase, last.Message)
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			phase := last.Phase
+			if phase == "" {
+				phase = "queued"
+			}
+			return fmt.Errorf("timed out after %s waiting for snapshot %s, still %s", timeout, summary.Snapshot, phase)
+		case <-ticker.C:
+		}
+	}
+}
+
+// printSnapshotStatus prints a status Follow saw the snapshot move to.
+func printSnapshotStatus(status discoverysnapshot.DiscoverySnapshotStatus) {
+	switch {
+	case status.Phase == "":
+		fmt.Println("Snapshot queued for reconciliation.")
+	case status.Message == "":
+		fmt.Printf("Snapshot %s.\n", status.Phase)
+	default:
+		fmt.Printf("Snapshot %s: %s\n", status.Phase, status.Message)
+	}
+}
@@ -0,0 +1,99 @@
+// Package completeness scores how much inventory data a node's BMC reports,
+// so that operators can tell which BMCs and firmware leave gaps.
+package completeness
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// ExpectedCategories are the component types every node should report at
+// least one of. GPUs, DPUs and the like are optional and not expected.
+var ExpectedCategories = []string{"CPU", "DIMM", "Drive", "NIC"}
+
+// commonFields are checked on the node and every component.
+var commonFields = []string{"manufacturer", "partNumber", "serialNumber", "model"}
+
+// typeFields are the properties checked on components of a type, besides
+// the common fields.
+var typeFields = map[string][]string{
+	"CPU":   {"total_cores", "total_threads"},
+	"DIMM":  {"capacity_mib"},
+	"Drive": {"capacity_bytes"},
+	"NIC":   {"mac_addresses"},
+}
+
+// Score rates a node from its direct children: the share of the expected
+// categories it has components in, and the share of checked fields that
+// hold a value, averaged into a score from 0 to 100.
+func Score(node *device.Device, children []*device.Device) *device.Completeness {
+	present := make(map[string]bool)
+	result := &device.Completeness{}
+	countFields(result, node)
+	for _, child := range children {
+		present[child.Spec.DeviceType] = true
+		countFields(result, child)
+	}
+	for _, category := range ExpectedCategories {
+		if present[category] {
+			result.Categories++
+		} else {
+			result.MissingCategories = append(result.MissingCategories, category)
+		}
+	}
+
+	categoryShare := float64(result.Categories) / float64(len(ExpectedCategories))
+	fieldShare := 1.0
+	if result.Fields > 0 {
+		fieldShare = float64(result.Fields-result.EmptyFields) / float64(result.Fields)
+	}
+	result.Score = math.Round((categoryShare+fieldShare)/2*1000) / 10
+	return result
+}
+
+// countFields adds the fields checked on d, and those that are empty.
+func countFields(result *device.Completeness, d *device.Device) {
+	for _, field := range commonFields {
+		result.Fields++
+		if isEmpty(d, field) {
+			result.EmptyFields++
+		}
+	}
+	for _, field := range typeFields[d.Spec.DeviceType] {
+		result.Fields++
+		if isEmpty(d, field) {
+			result.EmptyFields++
+		}
+	}
+}
+
+// emptyValues are the JSON encodings of a property without data.
+var emptyValues = [][]byte{[]byte(`null`), []byte(`""`), []byte(`[]`), []byte(`{}`), []byte(`0`)}
+
+// isEmpty reports whether a spec field or property of d has no value.
+func isEmpty(d *device.Device, field string) bool {
+	switch field {
+	case "manufacturer":
+		return d.Spec.Manufacturer == ""
+	case "partNumber":
+		return d.Spec.PartNumber == ""
+	case "serialNumber":
+		return d.Spec.SerialNumber == ""
+	}
+	raw, ok := d.Spec.Properties[field]
+	if !ok {
+		return true
+	}
+	raw = bytes.TrimSpace(raw)
+	for _, empty := range emptyValues {
+		if bytes.Equal(raw, empty) {
+			return true
+		}
+	}
+	var s string
+	return json.Unmarshal(raw, &s) == nil && strings.TrimSpace(s) == ""
+}
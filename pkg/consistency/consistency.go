@@ -0,0 +1,207 @@
+// Package consistency verifies fleet-wide invariants that no single snapshot
+// reconcile can check on its own.
+package consistency
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/maintenance"
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// Invariant names, used in violations and metric labels.
+const (
+	InvariantParentResolvable = "parent-resolvable"
+	InvariantUniqueRedfishURI = "unique-redfish-uri"
+	InvariantRecentSnapshot   = "recent-snapshot"
+)
+
+// Invariants lists every invariant the checker verifies.
+var Invariants = []string{InvariantParentResolvable, InvariantUniqueRedfishURI, InvariantRecentSnapshot}
+
+// DefaultSnapshotMaxAge is how old an endpoint's latest successful snapshot
+// may be before it is reported.
+const DefaultSnapshotMaxAge = 7 * 24 * time.Hour
+
+// rootDeviceTypes may legitimately have no parent: nodes, racks, and the
+// equipment and composition roots that are emitted unracked when the BMC
+// reports no location.
+var rootDeviceTypes = map[string]bool{
+	"Node":          true,
+	"Rack":          true,
+	"PDU":           true,
+	"CDU":           true,
+	"ResourceBlock": true,
+}
+
+// Violation is one broken invariant.
+type Violation struct {
+	Invariant string `json:"invariant"`
+	// Subject is the UID of the offending device or endpoint.
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// Report is the result of one consistency check.
+type Report struct {
+	CheckedAt      time.Time `json:"checkedAt"`
+	DurationMillis int64     `json:"durationMillis"`
+	Devices        int       `json:"devices"`
+	Endpoints      int       `json:"endpoints"`
+	Snapshots      int       `json:"snapshots"`
+
+	// SnapshotMaxAge is the age limit used for the recent-snapshot invariant.
+	SnapshotMaxAge string `json:"snapshotMaxAge"`
+	// InMaintenance counts the endpoints whose snapshots were not checked
+	// because a maintenance window covers them.
+	InMaintenance int `json:"inMaintenance"`
+
+	// Counts holds the number of violations per invariant, including zeroes.
+	Counts     map[string]int `json:"counts"`
+	Violations []Violation    `json:"violations"`
+}
+
+// Check verifies every invariant against the given inventory. A maxAge of
+// zero or less uses DefaultSnapshotMaxAge. Endpoints the blackout covers are
+// not collected, so their snapshots are not checked; blackout may be nil.
+func Check(devices []*device.Device, endpoints []*bmcendpoint.BMCEndpoint, snapshots []*discoverysnapshot.DiscoverySnapshot, blackout *maintenance.Blackout, maxAge time.Duration, now time.Time) *Report {
+	if maxAge <= 0 {
+		maxAge = DefaultSnapshotMaxAge
+	}
+	report := &Report{
+		CheckedAt:      now,
+		Devices:        len(devices),
+		Endpoints:      len(endpoints),
+		Snapshots:      len(snapshots),
+		SnapshotMaxAge: maxAge.String(),
+		Counts:         make(map[string]int),
+		Violations:     []Violation{},
+	}
+	for _, invariant := range Invariants {
+		report.Counts[invariant] = 0
+	}
+
+	checkParents(report, devices)
+	checkRedfishURIs(report, devices)
+	checkSnapshots(report, endpoints, snapshots, blackout, maxAge, now)
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		if report.Violations[i].Invariant != report.Violations[j].Invariant {
+			return report.Violations[i].Invariant < report.Violations[j].Invariant
+		}
+		return report.Violations[i].Subject < report.Violations[j].Subject
+	})
+	return report
+}
+
+func (r *Report) add(invariant, subject, format string, args ...interface{}) {
+	r.Counts[invariant]++
+	r.Violations = append(r.Violations, Violation{Invariant: invariant, Subject: subject, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkParents requires every non-root device to have a ParentID naming an
+// existing device, and a root device that names a parent to have it resolved.
+// Devices still expected from a manifest are not placed yet and are skipped.
+func checkParents(report *Report, devices []*device.Device) {
+	byUID := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = true
+	}
+	for _, d := range devices {
+		if manifest.IsExpected(d) {
+			continue
+		}
+		if rootDeviceTypes[d.Spec.DeviceType] && d.Spec.ParentID == "" && d.Spec.ParentSerialNumber == "" {
+			continue
+		}
+		switch {
+		case d.Spec.ParentID == "":
+			report.add(InvariantParentResolvable, d.GetUID(), "%s %s has no parent (parent serial %q)", d.Spec.DeviceType, d.GetName(), d.Spec.ParentSerialNumber)
+		case !byUID[d.Spec.ParentID]:
+			report.add(InvariantParentResolvable, d.GetUID(), "%s %s references missing parent %s", d.Spec.DeviceType, d.GetName(), d.Spec.ParentID)
+		}
+	}
+}
+
+// checkRedfishURIs requires redfish_uri to be unique across devices.
+func checkRedfishURIs(report *Report, devices []*device.Device) {
+	byURI := make(map[string][]string)
+	for _, d := range devices {
+		var uri string
+		if d.Spec.GetProperty("redfish_uri", &uri) && uri != "" {
+			byURI[uri] = append(byURI[uri], d.GetUID())
+		}
+	}
+	for uri, uids := range byURI {
+		if len(uids) < 2 {
+			continue
+		}
+		sort.Strings(uids)
+		for _, uid := range uids {
+			report.add(InvariantUniqueRedfishURI, uid, "redfish_uri %s is shared by %v", uri, uids)
+		}
+	}
+}
+
+// checkSnapshots requires every collected endpoint outside maintenance to have
+// a completed snapshot created within maxAge.
+func checkSnapshots(report *Report, endpoints []*bmcendpoint.BMCEndpoint, snapshots []*discoverysnapshot.DiscoverySnapshot, blackout *maintenance.Blackout, maxAge time.Duration, now time.Time) {
+	latest := make(map[string]time.Time)
+	for _, s := range snapshots {
+		if s.Status.Phase != "Completed" {
+			continue
+		}
+		address := discoverysnapshot.Address(s)
+		if s.Metadata.CreatedAt.After(latest[address]) {
+			latest[address] = s.Metadata.CreatedAt
+		}
+	}
+	for _, e := range endpoints {
+		if !e.Spec.Collected() {
+			continue
+		}
+		if blackout.CoversAddress(e.Spec.Address) {
+			report.InMaintenance++
+			continue
+		}
+		last, ok := latest[e.Spec.Address]
+		switch {
+		case !ok:
+			report.add(InvariantRecentSnapshot, e.GetUID(), "endpoint %s (%s) has no successful snapshot", e.GetName(), e.Spec.Address)
+		case now.Sub(last) > maxAge:
+			report.add(InvariantRecentSnapshot, e.GetUID(), "endpoint %s (%s) last succeeded at %s", e.GetName(), e.Spec.Address, last.Format(time.RFC3339))
+		}
+	}
+}
+
+// MarshalMetrics renders the report in the Prometheus text exposition format.
+func (r *Report) MarshalMetrics() []byte {
+	var out []byte
+	out = append(out, "# HELP inventory_consistency_violations Violations found by the last consistency check.\n"...)
+	out = append(out, "# TYPE inventory_consistency_violations gauge\n"...)
+	for _, invariant := range Invariants {
+		out = append(out, fmt.Sprintf("inventory_consistency_violations{invariant=%s} %d\n", quote(invariant), r.Counts[invariant])...)
+	}
+	out = append(out, "# HELP inventory_consistency_last_check_timestamp_seconds Unix time of the last consistency check.\n"...)
+	out = append(out, "# TYPE inventory_consistency_last_check_timestamp_seconds gauge\n"...)
+	out = append(out, fmt.Sprintf("inventory_consistency_last_check_timestamp_seconds %d\n", r.CheckedAt.Unix())...)
+	out = append(out, "# HELP inventory_consistency_last_check_duration_seconds Duration of the last consistency check.\n"...)
+	out = append(out, "# TYPE inventory_consistency_last_check_duration_seconds gauge\n"...)
+	out = append(out, fmt.Sprintf("inventory_consistency_last_check_duration_seconds %g\n", float64(r.DurationMillis)/1000)...)
+	out = append(out, "# HELP inventory_devices Devices in the inventory at the last consistency check.\n"...)
+	out = append(out, "# TYPE inventory_devices gauge\n"...)
+	out = append(out, fmt.Sprintf("inventory_devices %d\n", r.Devices)...)
+	return out
+}
+
+// quote returns a Prometheus label value.
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
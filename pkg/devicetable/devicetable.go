@@ -0,0 +1,270 @@
+// Package devicetable lays out device lists as tables of named columns, e.g.
+// serial, type, firmware and location, and sorts them by those columns, for
+// the CLI's table output and the server's sorted lists.
+package devicetable
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/lookup"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Column is a named column of a device table.
+type Column struct {
+	Name   string
+	Header string
+	value  func(d *device.Device, getParent func(uid string) *device.Device) string
+}
+
+// Value returns the column's cell for d. getParent finds a device's parent
+// by UID, for the location of a device in a rack; it may return nil.
+func (c Column) Value(d *device.Device, getParent func(uid string) *device.Device) string {
+	if getParent == nil {
+		getParent = func(string) *device.Device { return nil }
+	}
+	return c.value(d, getParent)
+}
+
+// Default and Wide are the columns of the table and wide output.
+var (
+	Default = []string{"uid", "name", "type", "serial", "phase"}
+	Wide    = []string{"uid", "name", "type", "manufacturer", "part", "serial", "firmware", "location", "lastSeen", "phase"}
+)
+
+// columns are the named columns, besides labels.KEY and properties.KEY.
+var columns = []Column{
+	{Name: "uid", Header: "UID", value: func(d *device.Device, _ func(string) *device.Device) string { return d.GetUID() }},
+	{Name: "name", Header: "NAME", value: func(d *device.Device, _ func(string) *device.Device) string { return d.GetName() }},
+	{Name: "type", Header: "TYPE", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Spec.DeviceType }},
+	{Name: "manufacturer", Header: "MANUFACTURER", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Spec.Manufacturer }},
+	{Name: "part", Header: "PART", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Spec.PartNumber }},
+	{Name: "serial", Header: "SERIAL", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Spec.SerialNumber }},
+	{Name: "firmware", Header: "FIRMWARE", value: firmware},
+	{Name: "location", Header: "LOCATION", value: location},
+	{Name: "lastSeen", Header: "LAST SEEN", value: lastSeen},
+	{Name: "phase", Header: "PHASE", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Status.Phase }},
+	{Name: "parent", Header: "PARENT", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Spec.ParentID }},
+	{Name: "source", Header: "SOURCE", value: func(d *device.Device, _ func(string) *device.Device) string { return d.Status.Source }},
+}
+
+// Names returns the names of the columns, besides labels.KEY and
+// properties.KEY.
+func Names() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Lookup returns the column named name, ignoring case, or labels.KEY or
+// properties.KEY for a label or property.
+func Lookup(name string) (Column, error) {
+	name = strings.TrimSpace(name)
+	if key, ok := cutPrefixFold(name, "labels."); ok {
+		return Column{Name: name, Header: strings.ToUpper(key), value: func(d *device.Device, _ func(string) *device.Device) string {
+			return d.Metadata.Labels[key]
+		}}, nil
+	}
+	if key, ok := cutPrefixFold(name, "properties."); ok {
+		return Column{Name: name, Header: strings.ToUpper(key), value: func(d *device.Device, _ func(string) *device.Device) string {
+			return property(d, key)
+		}}, nil
+	}
+	for _, c := range columns {
+		if strings.EqualFold(c.Name, name) {
+			return c, nil
+		}
+	}
+	return Column{}, fmt.Errorf("unknown column %q: use %s, labels.KEY or properties.KEY", name, strings.Join(Names(), ", "))
+}
+
+// Parse returns the columns of a comma-separated list of names.
+func Parse(list string) ([]Column, error) {
+	var result []Column
+	for _, name := range strings.Split(list, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		c, err := Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no columns in %q", list)
+	}
+	return result, nil
+}
+
+// Sort orders devices by a comma-separated list of columns, each descending
+// when prefixed with "-", e.g. "type,-lastSeen". Cells holding numbers
+// compare numerically, others lexically; empty cells sort last either way,
+// and ties keep the devices' order.
+func Sort(devices []*device.Device, order string, getParent func(uid string) *device.Device) error {
+	type key struct {
+		column     Column
+		descending bool
+	}
+	var keys []key
+	for _, name := range strings.Split(order, ",") {
+		name = strings.TrimSpace(name)
+		descending := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		if name == "" {
+			continue
+		}
+		c, err := Lookup(name)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key{c, descending})
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no columns to sort by in %q", order)
+	}
+
+	cells := make(map[*device.Device][]string, len(devices))
+	for _, d := range devices {
+		row := make([]string, len(keys))
+		for i, k := range keys {
+			row[i] = k.column.Value(d, getParent)
+		}
+		cells[d] = row
+	}
+	sort.SliceStable(devices, func(i, j int) bool {
+		a, b := cells[devices[i]], cells[devices[j]]
+		for n, k := range keys {
+			c := compare(a[n], b[n])
+			if c == 0 {
+				continue
+			}
+			if k.descending && a[n] != "" && b[n] != "" {
+				c = -c
+			}
+			return c < 0
+		}
+		return false
+	})
+	return nil
+}
+
+// compare orders two cells, empty ones last.
+func compare(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX == nil && errY == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// Write prints the devices as a table of the columns, parents found among
+// the devices themselves.
+func Write(w io.Writer, devices []*device.Device, cols []Column) error {
+	byUID := make(map[string]*device.Device, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+	}
+	getParent := func(uid string) *device.Device { return byUID[uid] }
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	cells := make([]string, len(cols))
+	for i, c := range cols {
+		cells[i] = c.Header
+	}
+	fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	for _, d := range devices {
+		for i, c := range cols {
+			cells[i] = c.Value(d, getParent)
+			if cells[i] == "" {
+				cells[i] = "-"
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// firmware is the device's firmware version, or a node's BIOS version.
+func firmware(d *device.Device, _ func(string) *device.Device) string {
+	if v := property(d, "firmware_version"); v != "" {
+		return v
+	}
+	return property(d, "bios_version")
+}
+
+// location is the rack and offset of the device, or of its nearest ancestor
+// with them, e.g. "x1000 U17"; else its xname; else its slot.
+func location(d *device.Device, getParent func(string) *device.Device) string {
+	loc := lookup.NewSerialMatch(d, getParent).Location
+	var parts []string
+	if loc.Row != "" {
+		parts = append(parts, "row "+loc.Row)
+	}
+	if loc.Rack != "" {
+		parts = append(parts, loc.Rack)
+	}
+	if loc.RackOffset != nil {
+		parts = append(parts, fmt.Sprintf("U%d", *loc.RackOffset))
+	}
+	switch {
+	case len(parts) > 0:
+		return strings.Join(parts, " ")
+	case loc.Xname != "":
+		return loc.Xname
+	}
+	return property(d, "location")
+}
+
+// lastSeen is when discovery last reported the device, in UTC, so cells
+// sort in time order.
+func lastSeen(d *device.Device, _ func(string) *device.Device) string {
+	if d.Status.LastSeen == nil {
+		return ""
+	}
+	return d.Status.LastSeen.UTC().Format(time.RFC3339)
+}
+
+// property formats a property for a cell: strings as they are, other values
+// as JSON.
+func property(d *device.Device, key string) string {
+	raw, ok := d.Spec.Properties[key]
+	if !ok || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if d.Spec.GetProperty(key, &s) {
+		return s
+	}
+	return string(raw)
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return "", false
+}
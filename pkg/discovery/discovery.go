@@ -0,0 +1,189 @@
+// Package discovery defines the stages of a collection: discovering the
+// devices behind a BMC, mapping them to a DiscoverySnapshot, and publishing
+// the snapshot. Services that embed discovery use the stages they need; the
+// collector binary runs all three.
+//
+// This package provides the default Mapper and the Publishers, not a
+// Discoverer. A Discoverer returns DeviceSpecs, so reading the BMC and
+// turning its resources into devices are both its work: the Redfish one,
+// with its client, vendor quirks and the Redfish to DeviceSpec mapping, is
+// collector.RedfishDiscoverer in pkg/collector. The Mapper only packages a
+// Result as a snapshot.
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/attestation"
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/signing"
+)
+
+// Result is what discovering one BMC found.
+type Result struct {
+	// Address is the BMC discovered.
+	Address string
+	// Profile is the collection profile used (quick, full, deep).
+	Profile string
+	// Vendor is the BMC's vendor, when the discoverer identified it.
+	Vendor  string
+	Devices []*device.DeviceSpec
+	// Coverage records the reads made and those that failed.
+	Coverage *discoverysnapshot.Coverage
+	// RawCapture holds every resource read, keyed by path, for the deep
+	// profile; it is nil otherwise.
+	RawCapture map[string]json.RawMessage
+	// Shape records the subtrees the BMC lacks; nil when nothing was probed.
+	Shape *bmcendpoint.DiscoveryShape
+	// Decommissioned reports that the BMC was decommissioned: Devices is
+	// empty, and the snapshot retires what the BMC reported before.
+	Decommissioned bool
+	// StartedAt and FinishedAt bound the discovery; Run sets them.
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Discoverer finds the devices behind a BMC and describes them as
+// DeviceSpecs.
+type Discoverer interface {
+	Discover(ctx context.Context, address string) (*Result, error)
+}
+
+// Mapper turns a discovery result into the snapshot to publish. It does not
+// change the devices discovered.
+type Mapper interface {
+	Map(result *Result) (*discoverysnapshot.DiscoverySnapshotSpec, error)
+}
+
+// Publisher delivers a snapshot. It returns a reference to what it
+// published: the snapshot's UID in the inventory API, or where it was
+// written.
+type Publisher interface {
+	Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error)
+}
+
+// SnapshotMapper is the default Mapper: it records the devices, raw capture
+// and coverage of a result with the collection's provenance and attestation,
+// and signs both when it has a signer.
+type SnapshotMapper struct {
+	// RunID identifies the collection in the snapshot's provenance.
+	RunID string
+	// Collector names the host collecting; "" uses the hostname.
+	Collector string
+	// Signer signs the snapshots; nil leaves them unsigned.
+	Signer *signing.Signer
+	// Version is the collector's version, attested with each snapshot.
+	Version string
+	// Priority is the snapshots' reconciliation priority (see
+	// discoverysnapshot.Priorities); "" is scheduled.
+	Priority string
+}
+
+// Map implements Mapper.
+func (m SnapshotMapper) Map(result *Result) (*discoverysnapshot.DiscoverySnapshotSpec, error) {
+	if result.Decommissioned && len(result.Devices) > 0 {
+		return nil, errors.New("a decommissioned BMC reports no devices")
+	}
+	if len(result.Devices) == 0 && !result.Decommissioned {
+		return nil, errors.New("redfish discovery found no devices to post")
+	}
+	devices := result.Devices
+	if devices == nil {
+		devices = []*device.DeviceSpec{}
+	}
+	data, err := json.Marshal(devices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal device list into snapshot data: %w", err)
+	}
+	spec := &discoverysnapshot.DiscoverySnapshotSpec{
+		RawData:        json.RawMessage(data),
+		BMCAddress:     result.Address,
+		Profile:        result.Profile,
+		Priority:       m.Priority,
+		Provenance:     &discoverysnapshot.Provenance{RunID: m.RunID, Collector: m.Collector},
+		Coverage:       result.Coverage,
+		Decommissioned: result.Decommissioned,
+	}
+	if spec.Provenance.Collector == "" {
+		spec.Provenance.Collector, _ = os.Hostname()
+	}
+	if result.RawCapture != nil {
+		spec.RawCapture, err = json.Marshal(result.RawCapture)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal raw capture: %w", err)
+		}
+		fmt.Printf("Attaching raw capture of %d Redfish resources.\n", len(result.RawCapture))
+	}
+	if coverage := spec.Coverage; coverage.Partial() {
+		fmt.Printf("Warning: Snapshot is partial: %d of %d Redfish reads failed (confidence %.2f).\n", len(coverage.Failed), coverage.Reads, coverage.Confidence())
+	}
+	if m.Signer != nil {
+		if err := m.Signer.SignSnapshot(spec); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Signed snapshot with key %s.\n", m.Signer.KeyID())
+	}
+	// Results not discovered through Run are attested as of now
+	started, finished := result.StartedAt, result.FinishedAt
+	if finished.IsZero() {
+		finished = time.Now()
+	}
+	if started.IsZero() {
+		started = finished
+	}
+	collection := attestation.Collection{
+		Collector:      attestation.Collector{Host: spec.Provenance.Collector, Version: m.Version},
+		RunID:          m.RunID,
+		BMCAddress:     result.Address,
+		Profile:        result.Profile,
+		Vendor:         result.Vendor,
+		StartedOn:      started.UTC(),
+		FinishedOn:     finished.UTC(),
+		Devices:        len(result.Devices),
+		Decommissioned: result.Decommissioned,
+	}
+	if err := attestation.Attest(spec, collection, m.Signer); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// APIPublisher is the default Publisher: it creates a DiscoverySnapshot
+// through the inventory API, whose reconciler then processes it.
+type APIPublisher struct {
+	Client *fabricaclient.Client
+}
+
+// Publish implements Publisher.
+func (p APIPublisher) Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error) {
+	created, err := p.Client.CreateDiscoverySnapshot(ctx, CreateRequest(spec))
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	return created.Metadata.UID, nil
+}
+
+// Run discovers the BMC at address, maps the result and publishes it. It
+// returns the result, when discovery succeeded, along with any error.
+func Run(ctx context.Context, address string, d Discoverer, m Mapper, p Publisher) (*Result, string, error) {
+	start := time.Now()
+	result, err := d.Discover(ctx, address)
+	if err != nil {
+		return nil, "", err
+	}
+	result.StartedAt, result.FinishedAt = start, time.Now()
+	spec, err := m.Map(result)
+	if err != nil {
+		return result, "", err
+	}
+	uid, err := p.Publish(ctx, spec)
+	return result, uid, err
+}
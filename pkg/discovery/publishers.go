@@ -0,0 +1,170 @@
+package discovery
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/s3"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// --- Publishers ---
+//
+// Besides the inventory API, snapshots can be written where a collector
+// runs without a Fabrica server: to files, to stdout, to an S3 bucket, or to
+// a Kafka topic. Every target but the API writes the request that creates
+// the snapshot (see CreateRequest) as JSON, so that a record can be POSTed
+// to /discoverysnapshots once a server is up.
+
+// CreateRequest returns the API request creating spec, named after the BMC
+// and the time.
+func CreateRequest(spec *discoverysnapshot.DiscoverySnapshotSpec) fabricaclient.CreateDiscoverySnapshotRequest {
+	return fabricaclient.CreateDiscoverySnapshotRequest{
+		Name:                  fmt.Sprintf("snapshot-%s-%d", spec.BMCAddress, time.Now().Unix()),
+		DiscoverySnapshotSpec: *spec,
+	}
+}
+
+// FilePublisher writes each snapshot to Dir as <name>.json.
+type FilePublisher struct {
+	Dir string
+}
+
+// Publish implements Publisher. It returns the file written.
+func (p FilePublisher) Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error) {
+	req := CreateRequest(spec)
+	data, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.MkdirAll(p.Dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	// Written under a temporary name so readers never see a partial file
+	file := filepath.Join(p.Dir, req.Name+".json")
+	tmp, err := os.CreateTemp(p.Dir, "."+req.Name+"-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return file, nil
+}
+
+// WriterPublisher writes each snapshot to W as one line of JSON.
+type WriterPublisher struct {
+	W io.Writer
+
+	mu sync.Mutex
+}
+
+// Publish implements Publisher. It returns the snapshot's name.
+func (p *WriterPublisher) Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error) {
+	req := CreateRequest(spec)
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.W.Write(append(data, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return req.Name, nil
+}
+
+// S3Publisher writes each snapshot to Bucket as <name>.json.
+type S3Publisher struct {
+	Bucket s3.Bucket
+}
+
+// Publish implements Publisher. It returns the object's URL.
+func (p *S3Publisher) Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error) {
+	req := CreateRequest(spec)
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	key := req.Name + ".json"
+	if err := p.Bucket.Put(ctx, key, "application/json", data); err != nil {
+		return "", fmt.Errorf("failed to write snapshot to %s: %w", p.Bucket.Name(), err)
+	}
+	objectURL, _ := p.Bucket.ObjectURL(key)
+	return objectURL, nil
+}
+
+// KafkaPublisher produces each snapshot to Topic, keyed by the BMC address
+// so that one BMC's snapshots stay in order. A deep profile snapshot with
+// its raw capture can exceed the broker's default message.max.bytes.
+type KafkaPublisher struct {
+	Brokers []string
+	Topic   string
+	// TLS, when set, connects to the brokers with TLS.
+	TLS *tls.Config
+}
+
+// Publish implements Publisher. It returns the topic and snapshot name.
+func (p *KafkaPublisher) Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error) {
+	req := CreateRequest(spec)
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(p.Brokers...),
+		Topic:        p.Topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchBytes:   int64(len(data)) + 1024,
+		Transport:    &kafka.Transport{TLS: p.TLS},
+	}
+	defer writer.Close()
+	if err := writer.WriteMessages(ctx, kafka.Message{Key: []byte(spec.BMCAddress), Value: data}); err != nil {
+		return "", fmt.Errorf("failed to produce snapshot to Kafka topic %s: %w", p.Topic, err)
+	}
+	return fmt.Sprintf("kafka:%s/%s", p.Topic, req.Name), nil
+}
+
+// Publishers publishes each snapshot to every publisher, even when some
+// fail. It returns their references separated by commas.
+type Publishers []Publisher
+
+// Publish implements Publisher.
+func (ps Publishers) Publish(ctx context.Context, spec *discoverysnapshot.DiscoverySnapshotSpec) (string, error) {
+	var refs []string
+	var errs []error
+	for _, p := range ps {
+		ref, err := p.Publish(ctx, spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return strings.Join(refs, ", "), errors.Join(errs...)
+}
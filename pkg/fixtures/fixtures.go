@@ -0,0 +1,319 @@
+// Package fixtures stores recorded Redfish trees as fixtures, so that a
+// capture of a vendor's BMC can be contributed back and served by the mock
+// BMC (mockredfish --fixture) and collected by the quirk tests. A collector
+// records a fixture with --record: every resource it read, sanitized (see
+// Step) before it leaves the site.
+//
+// A store holds one <name>.json file per fixture and catalog.json, which
+// lists them with the BMC each was recorded from and the quirks the
+// collector applied collecting it.
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/s3"
+)
+
+// SchemaVersion is the version of the fixture and catalog formats.
+const SchemaVersion = 1
+
+// CatalogFile is the name of a store's catalog.
+const CatalogFile = "catalog.json"
+
+// redfishRoot prefixes the resource paths of a fixture.
+const redfishRoot = "/redfish/v1"
+
+// ErrNotExist is returned for a fixture or catalog a store does not hold.
+var ErrNotExist = errors.New("fixture does not exist")
+
+// validName matches the names fixtures may have, which name their files.
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Fixture is a recorded Redfish tree.
+type Fixture struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Name          string `json:"name"`
+	// Vendor, Model and Firmware identify the BMC, as the collector did.
+	Vendor   string `json:"vendor,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Firmware string `json:"firmware,omitempty"`
+	// Profile is the collection profile the tree was recorded with.
+	Profile          string    `json:"profile,omitempty"`
+	RecordedAt       time.Time `json:"recordedAt"`
+	CollectorVersion string    `json:"collectorVersion,omitempty"`
+	// Sanitized lists the sanitization steps applied, in order.
+	Sanitized []string `json:"sanitized"`
+	// Quirks are the quirks the collector applied when recording, which the
+	// quirk tests expect it to apply collecting the fixture.
+	Quirks []string `json:"quirks,omitempty"`
+	// Resources maps each resource path (e.g. "/redfish/v1/Systems/1") to
+	// its body.
+	Resources map[string]json.RawMessage `json:"resources"`
+}
+
+// Entry lists a fixture in a catalog.
+type Entry struct {
+	Name       string    `json:"name"`
+	File       string    `json:"file"`
+	Vendor     string    `json:"vendor,omitempty"`
+	Model      string    `json:"model,omitempty"`
+	Firmware   string    `json:"firmware,omitempty"`
+	Profile    string    `json:"profile,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
+	Sanitized  []string  `json:"sanitized"`
+	Quirks     []string  `json:"quirks,omitempty"`
+	Resources  int       `json:"resources"`
+}
+
+// Catalog lists the fixtures of a store, sorted by name.
+type Catalog struct {
+	SchemaVersion int     `json:"schemaVersion"`
+	Fixtures      []Entry `json:"fixtures"`
+}
+
+// New returns a fixture of the responses a collector read, keyed by the
+// paths it asked for under the service root ("/Systems/1"). The pages of a
+// paged collection are merged into the collection, as the mock BMC pages
+// collections itself; responses to other queries are left out.
+func New(name string, responses map[string]json.RawMessage) (*Fixture, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid fixture name %q: use lower case letters, digits, '.', '_' and '-'", name)
+	}
+	f := &Fixture{SchemaVersion: SchemaVersion, Name: name, Sanitized: []string{}, Resources: make(map[string]json.RawMessage)}
+	var pages []string
+	for path, body := range responses {
+		path, query, _ := strings.Cut(path, "?")
+		if query != "" {
+			if strings.HasPrefix(query, "$skip=") {
+				pages = append(pages, path+"?"+query)
+			}
+			continue
+		}
+		f.Resources[resourcePath(path)] = body
+	}
+	// Merge the pages in order, after the collections' first pages
+	sort.Slice(pages, func(i, j int) bool { return skipOf(pages[i]) < skipOf(pages[j]) })
+	for _, page := range pages {
+		path, _, _ := strings.Cut(page, "?")
+		if err := f.mergePage(resourcePath(path), responses[page]); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// mergePage appends the members of a collection page to the collection.
+func (f *Fixture) mergePage(path string, page json.RawMessage) error {
+	collection := make(map[string]json.RawMessage)
+	if body, ok := f.Resources[path]; ok {
+		if err := json.Unmarshal(body, &collection); err != nil {
+			return fmt.Errorf("failed to decode collection %s: %w", path, err)
+		}
+	}
+	var next map[string]json.RawMessage
+	if err := json.Unmarshal(page, &next); err != nil {
+		return fmt.Errorf("failed to decode page of %s: %w", path, err)
+	}
+	var members, more []json.RawMessage
+	json.Unmarshal(collection["Members"], &members)
+	json.Unmarshal(next["Members"], &more)
+	if len(collection) == 0 {
+		collection = next
+	}
+	collection["Members"], _ = json.Marshal(append(members, more...))
+	collection["Members@odata.count"], _ = json.Marshal(len(members) + len(more))
+	delete(collection, "Members@odata.nextLink")
+	body, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+	f.Resources[path] = body
+	return nil
+}
+
+// MockResources returns the fixture's tree as redfishmock.New takes it.
+func (f *Fixture) MockResources() map[string]interface{} {
+	resources := make(map[string]interface{}, len(f.Resources))
+	for path, body := range f.Resources {
+		resources[path] = body
+	}
+	return resources
+}
+
+func (f *Fixture) entry() Entry {
+	return Entry{
+		Name:       f.Name,
+		File:       f.Name + ".json",
+		Vendor:     f.Vendor,
+		Model:      f.Model,
+		Firmware:   f.Firmware,
+		Profile:    f.Profile,
+		RecordedAt: f.RecordedAt,
+		Sanitized:  f.Sanitized,
+		Quirks:     f.Quirks,
+		Resources:  len(f.Resources),
+	}
+}
+
+func resourcePath(path string) string {
+	return strings.TrimSuffix(redfishRoot+"/"+strings.Trim(path, "/"), "/")
+}
+
+func skipOf(page string) int {
+	var skip int
+	fmt.Sscanf(page[strings.Index(page, "$skip=")+len("$skip="):], "%d", &skip)
+	return skip
+}
+
+// --- Stores ---
+
+// Store holds fixture files by name.
+type Store interface {
+	// Get reads a file, or returns ErrNotExist.
+	Get(ctx context.Context, name string) ([]byte, error)
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+// NewStore returns the store at location: a bucket for an http(s) URL,
+// written with the credentials of bucket, else a directory.
+func NewStore(location string, bucket s3.Bucket) Store {
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		bucket.URL = location
+		return &BucketStore{Bucket: bucket}
+	}
+	return DirStore(location)
+}
+
+// DirStore is a directory of fixture files.
+type DirStore string
+
+// Get implements Store.
+func (d DirStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(string(d), name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotExist, name)
+	}
+	return data, err
+}
+
+// Put implements Store.
+func (d DirStore) Put(ctx context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(string(d), name), data, 0o644)
+}
+
+// BucketStore keeps fixture files in an S3 bucket.
+type BucketStore struct {
+	Bucket s3.Bucket
+}
+
+// Get implements Store.
+func (b *BucketStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, err := b.Bucket.Get(ctx, name)
+	if errors.Is(err, s3.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotExist, name)
+	}
+	return data, err
+}
+
+// Put implements Store.
+func (b *BucketStore) Put(ctx context.Context, name string, data []byte) error {
+	return b.Bucket.Put(ctx, name, "application/json", data)
+}
+
+// Save writes a fixture to the store and lists it in the catalog, replacing
+// a fixture of the same name.
+func Save(ctx context.Context, store Store, f *Fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := store.Put(ctx, f.Name+".json", append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write fixture %s: %w", f.Name, err)
+	}
+
+	catalog, err := LoadCatalog(ctx, store)
+	if err != nil {
+		return err
+	}
+	fixtures := []Entry{f.entry()}
+	for _, entry := range catalog.Fixtures {
+		if entry.Name != f.Name {
+			fixtures = append(fixtures, entry)
+		}
+	}
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].Name < fixtures[j].Name })
+	catalog.Fixtures = fixtures
+	if data, err = json.MarshalIndent(catalog, "", "  "); err != nil {
+		return fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	if err := store.Put(ctx, CatalogFile, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+	return nil
+}
+
+// LoadCatalog reads the store's catalog; a store without one has an empty
+// catalog.
+func LoadCatalog(ctx context.Context, store Store) (*Catalog, error) {
+	catalog := &Catalog{SchemaVersion: SchemaVersion, Fixtures: []Entry{}}
+	data, err := store.Get(ctx, CatalogFile)
+	if errors.Is(err, ErrNotExist) {
+		return catalog, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	if err := json.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to decode catalog: %w", err)
+	}
+	if catalog.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("catalog schema version %d is newer than %d", catalog.SchemaVersion, SchemaVersion)
+	}
+	return catalog, nil
+}
+
+// Load reads the named fixture from the store.
+func Load(ctx context.Context, store Store, name string) (*Fixture, error) {
+	data, err := store.Get(ctx, name+".json")
+	if err != nil {
+		return nil, err
+	}
+	return Decode(data)
+}
+
+// ReadFile reads a fixture file.
+func ReadFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	return Decode(data)
+}
+
+// Decode parses a fixture file.
+func Decode(data []byte) (*Fixture, error) {
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture: %w", err)
+	}
+	if f.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("fixture %s has schema version %d, newer than %d", f.Name, f.SchemaVersion, SchemaVersion)
+	}
+	if len(f.Resources) == 0 {
+		return nil, fmt.Errorf("fixture %s has no resources", f.Name)
+	}
+	return &f, nil
+}
@@ -0,0 +1,104 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/anonymize"
+	"github.com/example/inventory-v3/pkg/redact"
+)
+
+// --- Sanitization ---
+//
+// A fixture leaves the site it was recorded at, so it is sanitized by a
+// pipeline of steps first. StripSecrets always runs; Anonymize is optional,
+// for sites whose serial numbers and addresses must not be shared either.
+
+// Step is one stage of the sanitization pipeline, named in the fixture's
+// Sanitized list once applied.
+type Step struct {
+	Name  string
+	Apply func(resources map[string]json.RawMessage) (map[string]json.RawMessage, error)
+}
+
+// StripSecrets masks the values of sensitive fields (passwords, tokens,
+// keys) and leaves out the sessions open while recording, whose resources
+// identify their tokens.
+var StripSecrets = Step{Name: "strip-secrets", Apply: stripSecrets}
+
+func stripSecrets(resources map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	sessions := redfishRoot + "/SessionService/Sessions"
+	out := make(map[string]json.RawMessage, len(resources))
+	for path, body := range resources {
+		switch {
+		case strings.HasPrefix(path, sessions+"/"):
+			continue
+		case path == sessions:
+			var collection map[string]json.RawMessage
+			if json.Unmarshal(body, &collection) == nil {
+				collection["Members"] = json.RawMessage("[]")
+				collection["Members@odata.count"] = json.RawMessage("0")
+				body, _ = json.Marshal(collection)
+			}
+		}
+		out[path] = redact.JSON(body)
+	}
+	return out, nil
+}
+
+// Anonymize returns the step replacing serial numbers, asset tags, UUIDs,
+// MAC and IP addresses, in resource paths and bodies alike, with stand-ins
+// keyed with key. An empty key draws a random one, so that the stand-ins
+// cannot be matched with any other data of the site.
+func Anonymize(key string) (Step, error) {
+	if key == "" {
+		random := make([]byte, 32)
+		if _, err := rand.Read(random); err != nil {
+			return Step{}, fmt.Errorf("failed to draw an anonymization key: %w", err)
+		}
+		key = hex.EncodeToString(random)
+	}
+	a, err := anonymize.New(key)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Name: "anonymize", Apply: func(resources map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+		// One document, so that an identifier is replaced the same way
+		// wherever it appears
+		raw, err := json.Marshal(resources)
+		if err != nil {
+			return nil, err
+		}
+		var doc interface{}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, err
+		}
+		if raw, err = json.Marshal(a.Value(doc)); err != nil {
+			return nil, err
+		}
+		var out map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}}, nil
+}
+
+// Sanitize runs the steps over the fixture's resources in order.
+func (f *Fixture) Sanitize(steps ...Step) error {
+	for _, step := range steps {
+		resources, err := step.Apply(f.Resources)
+		if err != nil {
+			return fmt.Errorf("sanitization step %s failed: %w", step.Name, err)
+		}
+		f.Resources = resources
+		f.Sanitized = append(f.Sanitized, step.Name)
+	}
+	return nil
+}
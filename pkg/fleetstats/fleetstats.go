@@ -0,0 +1,209 @@
+// Package fleetstats keeps a daily series of the fleet's size and churn:
+// the active devices of each type, the nodes present and the changes
+// reconciled snapshots applied. One small record per day is kept for good,
+// long after the snapshots it was counted from have been pruned, to answer
+// how the fleet has grown and how much hardware churns per month.
+package fleetstats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// DateLayout is the layout of a Day's Date, which also keys its record.
+const DateLayout = "2006-01-02"
+
+// Periods the series can be rolled up by.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// Periods lists the valid periods.
+var Periods = []string{PeriodDay, PeriodWeek, PeriodMonth}
+
+// Changes counts the devices reconciled snapshots changed.
+type Changes struct {
+	// Snapshots counts the snapshots that changed devices.
+	Snapshots int `json:"snapshots"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Retired   int `json:"retired"`
+}
+
+// Total is the number of devices changed.
+func (c Changes) Total() int {
+	return c.Created + c.Updated + c.Retired
+}
+
+func (c *Changes) add(o Changes) {
+	c.Snapshots += o.Snapshots
+	c.Created += o.Created
+	c.Updated += o.Updated
+	c.Retired += o.Retired
+}
+
+// Day is the record of one day (UTC).
+type Day struct {
+	Date string `json:"date"`
+	// Devices counts the active devices by type, and Total all of them.
+	Devices map[string]int `json:"devices"`
+	Total   int            `json:"total"`
+	// Nodes counts the active nodes that were not missing.
+	Nodes   int     `json:"nodes"`
+	Changes Changes `json:"changes"`
+	// RecordedAt is when the record was last taken; the day's counts are
+	// as of then.
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Record counts the day of now: the fleet as it is now, and the changes of
+// the snapshots reconciled since the day began.
+func Record(devices []*device.Device, snapshots []*discoverysnapshot.DiscoverySnapshot, now time.Time) *Day {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := &Day{Date: start.Format(DateLayout), Devices: map[string]int{}, RecordedAt: now}
+
+	for _, d := range devices {
+		if prune.IsRetired(d) {
+			continue
+		}
+		day.Devices[d.Spec.DeviceType]++
+		day.Total++
+		if d.Spec.DeviceType == "Node" && d.Status.Phase != device.PhaseMissing {
+			day.Nodes++
+		}
+	}
+	day.Changes = CountChanges(snapshots, start, now)
+	return day
+}
+
+// CountChanges counts the changes of the snapshots reconciled from start up
+// to end.
+func CountChanges(snapshots []*discoverysnapshot.DiscoverySnapshot, start, end time.Time) Changes {
+	var total Changes
+	for _, s := range snapshots {
+		changed := s.Status.Devices
+		if changed == nil || s.Metadata.UpdatedAt.Before(start) || s.Metadata.UpdatedAt.After(end) {
+			continue
+		}
+		c := Changes{Created: len(changed.Created), Updated: len(changed.Updated), Retired: len(changed.Retired)}
+		if c.Total() > 0 {
+			c.Snapshots = 1
+			total.add(c)
+		}
+	}
+	return total
+}
+
+// Close recounts the changes of a day recorded before it ended, to include
+// those reconciled after its last record. The fleet's counts stay as last
+// recorded. It reports whether the day changed.
+func (d *Day) Close(snapshots []*discoverysnapshot.DiscoverySnapshot) bool {
+	start, err := time.Parse(DateLayout, d.Date)
+	if err != nil {
+		return false
+	}
+	end := start.AddDate(0, 0, 1)
+	if !d.RecordedAt.Before(end) {
+		return false
+	}
+	d.Changes = CountChanges(snapshots, start, end.Add(-time.Nanosecond))
+	d.RecordedAt = end
+	return true
+}
+
+// Point is the series over one period.
+type Point struct {
+	// Start is the first day of the period.
+	Start string `json:"start"`
+	// Days counts the days recorded in the period.
+	Days int `json:"days"`
+	// Devices, Total and Nodes are those of the last day recorded in the
+	// period, and Growth the change in Total from the period before.
+	Devices map[string]int `json:"devices"`
+	Total   int            `json:"total"`
+	Nodes   int            `json:"nodes"`
+	Growth  int            `json:"growth"`
+	// Changes sums the changes of the period's days.
+	Changes Changes `json:"changes"`
+}
+
+// Series is the fleet's history by period.
+type Series struct {
+	Period string  `json:"period"`
+	From   string  `json:"from,omitempty"`
+	To     string  `json:"to,omitempty"`
+	Points []Point `json:"points"`
+}
+
+// Rollup returns the days from from to to (dates; "" leaves the range open)
+// by period, oldest first. Weeks start on Monday.
+func Rollup(days []*Day, period, from, to string) (*Series, error) {
+	if period == "" {
+		period = PeriodDay
+	}
+	for _, bound := range []string{from, to} {
+		if bound == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, bound); err != nil {
+			return nil, fmt.Errorf("invalid date %q: use YYYY-MM-DD", bound)
+		}
+	}
+
+	sorted := make([]*Day, 0, len(days))
+	for _, d := range days {
+		if (from == "" || d.Date >= from) && (to == "" || d.Date <= to) {
+			sorted = append(sorted, d)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+
+	series := &Series{Period: period, From: from, To: to, Points: []Point{}}
+	previous := -1
+	for _, d := range sorted {
+		date, err := time.Parse(DateLayout, d.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid record date %q: %w", d.Date, err)
+		}
+		start, err := periodStart(date, period)
+		if err != nil {
+			return nil, err
+		}
+		key := start.Format(DateLayout)
+		if n := len(series.Points); n == 0 || series.Points[n-1].Start != key {
+			if n > 0 {
+				previous = series.Points[n-1].Total
+			}
+			series.Points = append(series.Points, Point{Start: key})
+		}
+		p := &series.Points[len(series.Points)-1]
+		p.Days++
+		p.Devices, p.Total, p.Nodes = d.Devices, d.Total, d.Nodes
+		if previous >= 0 {
+			p.Growth = d.Total - previous
+		}
+		p.Changes.add(d.Changes)
+	}
+	return series, nil
+}
+
+// periodStart returns the first day of the period date falls in.
+func periodStart(date time.Time, period string) (time.Time, error) {
+	switch period {
+	case PeriodDay:
+		return date, nil
+	case PeriodWeek:
+		return date.AddDate(0, 0, -(int(date.Weekday())+6)%7), nil
+	case PeriodMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("unknown period %q (valid: %v)", period, Periods)
+}
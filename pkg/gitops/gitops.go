@@ -0,0 +1,205 @@
+// Package gitops keeps the inventory's declarative resources in step with a
+// git repository of the YAML documents apply reads: planned devices, BMC
+// endpoints, cabling, groups and maintenance windows, reviewed and versioned
+// the way sites already manage their cluster configuration.
+//
+// Each sync pulls the repository, applies its documents, and reports drift:
+// the resources the inventory was missing or had changed, and those the
+// repository no longer has. Resources a sync applied carry the
+// SourceAnnotation, naming their file; only those are ever pruned.
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/gitopsstatus"
+)
+
+// SourceAnnotation records the repository file a resource was applied from.
+const SourceAnnotation = "gitops.source"
+
+// Repo is a git repository checked out in Dir.
+type Repo struct {
+	URL    string
+	Branch string
+	Dir    string
+}
+
+// Pull clones the repository, or fetches its branch again and checks it out,
+// and returns the commit checked out. Local changes in Dir are discarded.
+func (r Repo) Pull(ctx context.Context) (string, error) {
+	if _, err := os.Stat(filepath.Join(r.Dir, ".git")); err != nil {
+		args := []string{"clone", "--depth", "1"}
+		if r.Branch != "" {
+			args = append(args, "--branch", r.Branch)
+		}
+		if err := os.MkdirAll(filepath.Dir(r.Dir), 0o755); err != nil {
+			return "", err
+		}
+		if _, err := r.git(ctx, "", append(args, r.URL, r.Dir)...); err != nil {
+			return "", err
+		}
+	} else {
+		ref := r.Branch
+		if ref == "" {
+			ref = "HEAD"
+		}
+		if _, err := r.git(ctx, r.Dir, "fetch", "--depth", "1", r.URL, ref); err != nil {
+			return "", err
+		}
+		if _, err := r.git(ctx, r.Dir, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return "", err
+		}
+	}
+	return r.git(ctx, r.Dir, "rev-parse", "HEAD")
+}
+
+func (r Repo) git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Load reads the documents of the .yaml, .yml and .json files under dir, by
+// path, and annotates each with its file relative to dir. Hidden files and
+// directories are skipped.
+func Load(dir string) ([]client.Document, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var docs []client.Document
+	for _, file := range files {
+		read, err := loadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		source, _ := filepath.Rel(dir, file)
+		for _, doc := range read {
+			if doc.Metadata.Annotations == nil {
+				doc.Metadata.Annotations = map[string]string{}
+			}
+			doc.Metadata.Annotations[SourceAnnotation] = filepath.ToSlash(source)
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func loadFile(file string) ([]client.Document, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	docs, err := client.DecodeDocuments(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return docs, nil
+}
+
+// Sync applies docs to the inventory of c and returns the drift found. A
+// document that fails to apply is recorded in the result's errors, and the
+// rest are still applied; resources are then not pruned, as a failed
+// document's resource would be taken for a removed one.
+func Sync(ctx context.Context, c *client.Client, docs []client.Document, opts gitopsstatus.Options) (*gitopsstatus.Result, error) {
+	start := time.Now()
+	result := &gitopsstatus.Result{RanAt: start.UTC(), ReportOnly: opts.ReportOnly, Documents: len(docs)}
+	applier := c.NewApplier(opts.ReportOnly)
+	applied := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		source := doc.Metadata.Annotations[SourceAnnotation]
+		applyResult, err := applier.Apply(ctx, doc)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s %s/%s: %v", source, doc.Kind, doc.Metadata.Name, err))
+			continue
+		}
+		applied[applyResult.Kind+"/"+applyResult.UID] = true
+		switch applyResult.Action {
+		case client.ApplyUnchanged:
+			result.Unchanged++
+			continue
+		case client.ApplyCreated:
+			result.Created++
+		case client.ApplyConfigured:
+			result.Configured++
+		}
+		result.Drift = append(result.Drift, gitopsstatus.Drift{
+			Kind: applyResult.Kind, Name: applyResult.Name, UID: applyResult.UID,
+			Source: source, Action: applyResult.Action,
+		})
+	}
+
+	if len(result.Errors) == 0 {
+		if err := prune(ctx, c, applied, opts, result); err != nil {
+			return nil, err
+		}
+	}
+	result.DurationMillis = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// prune finds the resources a sync applied whose documents are gone, and
+// deletes them when opts.Prune is set.
+func prune(ctx context.Context, c *client.Client, applied map[string]bool, opts gitopsstatus.Options, result *gitopsstatus.Result) error {
+	existing, err := c.Export(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, doc := range existing {
+		source, managed := doc.Metadata.Annotations[SourceAnnotation]
+		if !managed || applied[doc.Kind+"/"+doc.Metadata.UID] {
+			continue
+		}
+		drift := gitopsstatus.Drift{Kind: doc.Kind, Name: doc.Metadata.Name, UID: doc.Metadata.UID, Source: source, Action: gitopsstatus.DriftOrphaned}
+		if opts.Prune {
+			drift.Action = gitopsstatus.DriftPruned
+			if !opts.ReportOnly {
+				if err := c.DeleteDocument(ctx, doc.Kind, doc.Metadata.UID); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("%s %s/%s: %v", source, doc.Kind, doc.Metadata.Name, err))
+					continue
+				}
+			}
+			result.Pruned++
+		}
+		result.Drift = append(result.Drift, drift)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package gitopsstatus describes the server's GitOps syncs, as the admin
+// endpoint reports them.
+package gitopsstatus
+
+import "time"
+
+// Drift actions, besides created and configured as apply reports them.
+const (
+	// DriftPruned resources were deleted, as their documents are gone.
+	DriftPruned = "pruned"
+	// DriftOrphaned resources lost their documents, and are kept as pruning
+	// is off.
+	DriftOrphaned = "orphaned"
+)
+
+// Options control a sync.
+type Options struct {
+	// ReportOnly reports drift without correcting it.
+	ReportOnly bool `json:"reportOnly"`
+	// Prune deletes resources whose documents were removed.
+	Prune bool `json:"prune"`
+}
+
+// Drift is a resource the inventory had out of step with the repository.
+type Drift struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	UID    string `json:"uid,omitempty"`
+	Source string `json:"source,omitempty"`
+	// Action is created, configured, pruned or orphaned.
+	Action string `json:"action"`
+}
+
+// Result is the outcome of a sync.
+type Result struct {
+	RanAt          time.Time `json:"ranAt"`
+	DurationMillis int64     `json:"durationMillis"`
+	// Commit is the repository commit synced.
+	Commit     string `json:"commit,omitempty"`
+	ReportOnly bool   `json:"reportOnly,omitempty"`
+	// Documents counts the documents read; Created, Configured, Unchanged and
+	// Pruned what was done with them (or would be, when ReportOnly).
+	Documents  int      `json:"documents"`
+	Created    int      `json:"created"`
+	Configured int      `json:"configured"`
+	Unchanged  int      `json:"unchanged"`
+	Pruned     int      `json:"pruned"`
+	Drift      []Drift  `json:"drift,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Status is the sync configuration and the last sync's result.
+type Status struct {
+	Repository string  `json:"repository"`
+	Branch     string  `json:"branch,omitempty"`
+	Path       string  `json:"path,omitempty"`
+	Options    Options `json:"options"`
+	// IntervalSeconds is 0 when syncs only run on request.
+	IntervalSeconds int     `json:"intervalSeconds"`
+	Last            *Result `json:"last,omitempty"`
+	LastError       string  `json:"lastError,omitempty"`
+}
@@ -0,0 +1,370 @@
+// Package grafana answers the queries of Grafana's JSON datasource (and the
+// Infinity datasource pointed at the same URLs), so that sites can chart the
+// inventory in the Grafana they already run: device counts, tables of
+// devices and time series of the fleet's size and of the changes applied to
+// it.
+package grafana
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/devicetable"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// Metrics a target may name. Devices and changes take an optional device
+// type after a colon, e.g. "devices:Node".
+const (
+	// MetricDevices is the number of active devices: the fleet's size over
+	// time as a time series, the devices themselves as a table.
+	MetricDevices = "devices"
+	// MetricDeviceTypes is the number of active devices of each type: a
+	// series per type, or a table of the counts at the end of the range.
+	MetricDeviceTypes = "device-types"
+	// MetricChanges is the number of devices reconciled snapshots created,
+	// updated or retired, per interval, or the snapshots as a table.
+	MetricChanges = "changes"
+)
+
+// Target types of the JSON datasource.
+const (
+	TypeTimeSeries = "timeserie"
+	TypeTable      = "table"
+)
+
+// MaxDataPoints bounds the points of one series.
+const MaxDataPoints = 10000
+
+// Range is the time range of a query.
+type Range struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Target is one query of a request.
+type Target struct {
+	RefID  string `json:"refId"`
+	Target string `json:"target"`
+	// Type is TypeTimeSeries (the default) or TypeTable.
+	Type string `json:"type"`
+}
+
+// QueryRequest is the body Grafana POSTs to /query.
+type QueryRequest struct {
+	Range         Range    `json:"range"`
+	IntervalMs    int64    `json:"intervalMs"`
+	MaxDataPoints int      `json:"maxDataPoints"`
+	Targets       []Target `json:"targets"`
+}
+
+// TimeSeries is a series of [value, unix milliseconds] points.
+type TimeSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// Column is a column of a table.
+type Column struct {
+	Text string `json:"text"`
+	// Type is "string", "number" or "time".
+	Type string `json:"type"`
+}
+
+// Table is a table response.
+type Table struct {
+	Type    string          `json:"type"`
+	Columns []Column        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// MetricOption is a metric as the /metrics endpoint lists it.
+type MetricOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Source is the inventory queries are answered from.
+type Source struct {
+	Devices   []*device.Device
+	Snapshots []*discoverysnapshot.DiscoverySnapshot
+}
+
+// Metrics returns the metrics a target may name that contain search, for
+// the datasource's metric picker: the plain metrics, then devices and
+// changes of each device type in the inventory.
+func Metrics(devices []*device.Device, search string) []string {
+	types := make(map[string]bool)
+	for _, d := range devices {
+		types[d.Spec.DeviceType] = true
+	}
+	names := []string{MetricDevices, MetricDeviceTypes, MetricChanges}
+	for _, t := range sortedKeys(types) {
+		names = append(names, MetricDevices+":"+t, MetricChanges+":"+t)
+	}
+	var matched []string
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(name), strings.ToLower(search)) {
+			matched = append(matched, name)
+		}
+	}
+	return matched
+}
+
+// Query answers each target of the request with a TimeSeries or a Table;
+// device-types answers with a series per type.
+func Query(req *QueryRequest, src *Source) ([]interface{}, error) {
+	if req.Range.To.IsZero() {
+		req.Range.To = time.Now()
+	}
+	if req.Range.From.IsZero() || !req.Range.From.Before(req.Range.To) {
+		return nil, fmt.Errorf("invalid range: from must be before to")
+	}
+	step := interval(req)
+
+	results := []interface{}{}
+	for _, t := range req.Targets {
+		metric, deviceType, _ := strings.Cut(t.Target, ":")
+		table := t.Type == TypeTable
+		switch {
+		case t.Target == "":
+			// Grafana sends targets the user has not filled in yet
+		case metric == MetricDevices && table:
+			results = append(results, deviceTable(src.Devices, deviceType))
+		case metric == MetricDevices:
+			results = append(results, fleetSize(t.Target, src.Devices, deviceType, req.Range, step))
+		case metric == MetricDeviceTypes && deviceType == "" && table:
+			results = append(results, typeTable(src.Devices, req.Range.To))
+		case metric == MetricDeviceTypes && deviceType == "":
+			byType := make(map[string][]*device.Device)
+			for _, d := range src.Devices {
+				byType[d.Spec.DeviceType] = append(byType[d.Spec.DeviceType], d)
+			}
+			for _, typ := range sortedKeys(byType) {
+				results = append(results, fleetSize(typ, byType[typ], "", req.Range, step))
+			}
+		case metric == MetricChanges && table:
+			results = append(results, changeTable(src, deviceType, req.Range))
+		case metric == MetricChanges:
+			results = append(results, changeRate(t.Target, src, deviceType, req.Range, step))
+		default:
+			return nil, fmt.Errorf("unknown target %q: use %s[:TYPE], %s or %s[:TYPE]", t.Target, MetricDevices, MetricDeviceTypes, MetricChanges)
+		}
+	}
+	return results, nil
+}
+
+// interval returns the step between points: the request's interval, else
+// the range over its data points, at least a minute and within
+// MaxDataPoints.
+func interval(req *QueryRequest) time.Duration {
+	span := req.Range.To.Sub(req.Range.From)
+	step := time.Duration(req.IntervalMs) * time.Millisecond
+	if step <= 0 && req.MaxDataPoints > 0 {
+		step = span / time.Duration(req.MaxDataPoints)
+	}
+	if step < time.Minute {
+		step = time.Minute
+	}
+	if least := span / MaxDataPoints; step < least {
+		step = least
+	}
+	return step
+}
+
+// retiredAt returns when a device left the fleet. Retiring is the last
+// change made to a retired device, so it is its update time.
+func retiredAt(d *device.Device) (time.Time, bool) {
+	if !prune.IsRetired(d) {
+		return time.Time{}, false
+	}
+	return d.Metadata.UpdatedAt, true
+}
+
+// activeAt reports whether the device was in the fleet at t.
+func activeAt(d *device.Device, t time.Time) bool {
+	if d.Metadata.CreatedAt.After(t) {
+		return false
+	}
+	retired, ok := retiredAt(d)
+	return !ok || retired.After(t)
+}
+
+// fleetSize counts the devices (of deviceType, if set) in the fleet at each
+// step of the range.
+func fleetSize(name string, devices []*device.Device, deviceType string, r Range, step time.Duration) TimeSeries {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	var events []event
+	for _, d := range devices {
+		if deviceType != "" && d.Spec.DeviceType != deviceType {
+			continue
+		}
+		events = append(events, event{d.Metadata.CreatedAt, 1})
+		if retired, ok := retiredAt(d); ok {
+			events = append(events, event{retired, -1})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	series := TimeSeries{Target: name, Datapoints: [][2]float64{}}
+	count, next := 0, 0
+	for t := r.From; !t.After(r.To); t = t.Add(step) {
+		for next < len(events) && !events[next].at.After(t) {
+			count += events[next].delta
+			next++
+		}
+		series.Datapoints = append(series.Datapoints, [2]float64{float64(count), float64(t.UnixMilli())})
+	}
+	return series
+}
+
+// deviceTable lists the active devices (of deviceType, if set) with the
+// columns of the wide device table.
+func deviceTable(devices []*device.Device, deviceType string) Table {
+	byUID := make(map[string]*device.Device, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+	}
+	getParent := func(uid string) *device.Device { return byUID[uid] }
+
+	var cols []devicetable.Column
+	table := Table{Type: TypeTable, Rows: [][]interface{}{}}
+	for _, name := range devicetable.Wide {
+		c, _ := devicetable.Lookup(name)
+		cols = append(cols, c)
+		table.Columns = append(table.Columns, Column{Text: c.Header, Type: "string"})
+	}
+	for _, d := range devices {
+		if prune.IsRetired(d) || (deviceType != "" && d.Spec.DeviceType != deviceType) {
+			continue
+		}
+		row := make([]interface{}, len(cols))
+		for i, c := range cols {
+			row[i] = c.Value(d, getParent)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// typeTable counts the devices of each type in the fleet at t.
+func typeTable(devices []*device.Device, t time.Time) Table {
+	counts := make(map[string]int)
+	for _, d := range devices {
+		if activeAt(d, t) {
+			counts[d.Spec.DeviceType]++
+		}
+	}
+	table := Table{
+		Type:    TypeTable,
+		Columns: []Column{{Text: "TYPE", Type: "string"}, {Text: "DEVICES", Type: "number"}},
+		Rows:    [][]interface{}{},
+	}
+	for _, typ := range sortedKeys(counts) {
+		table.Rows = append(table.Rows, []interface{}{typ, counts[typ]})
+	}
+	return table
+}
+
+// snapshotChanges are the changes one reconciled snapshot applied.
+type snapshotChanges struct {
+	snapshot                  *discoverysnapshot.DiscoverySnapshot
+	at                        time.Time
+	created, updated, retired int
+}
+
+// changes returns the changes of the snapshots reconciled in the range,
+// counting only devices of deviceType if it is set, by reconciliation time.
+func changes(src *Source, deviceType string, r Range) []snapshotChanges {
+	typeOf := make(map[string]string, len(src.Devices))
+	for _, d := range src.Devices {
+		typeOf[d.GetUID()] = d.Spec.DeviceType
+	}
+	count := func(uids []string) int {
+		if deviceType == "" {
+			return len(uids)
+		}
+		n := 0
+		for _, uid := range uids {
+			if typeOf[uid] == deviceType {
+				n++
+			}
+		}
+		return n
+	}
+
+	var result []snapshotChanges
+	for _, s := range src.Snapshots {
+		at := s.Metadata.UpdatedAt
+		if s.Status.Devices == nil || at.Before(r.From) || at.After(r.To) {
+			continue
+		}
+		c := snapshotChanges{
+			snapshot: s,
+			at:       at,
+			created:  count(s.Status.Devices.Created),
+			updated:  count(s.Status.Devices.Updated),
+			retired:  count(s.Status.Devices.Retired),
+		}
+		if c.created+c.updated+c.retired > 0 {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].at.Before(result[j].at) })
+	return result
+}
+
+// changeRate sums the devices changed in each step of the range, each point
+// covering the step that ends at it.
+func changeRate(name string, src *Source, deviceType string, r Range, step time.Duration) TimeSeries {
+	series := TimeSeries{Target: name, Datapoints: [][2]float64{}}
+	all := changes(src, deviceType, r)
+	next := 0
+	for t := r.From; !t.After(r.To); t = t.Add(step) {
+		sum := 0
+		for next < len(all) && !all[next].at.After(t) {
+			sum += all[next].created + all[next].updated + all[next].retired
+			next++
+		}
+		series.Datapoints = append(series.Datapoints, [2]float64{float64(sum), float64(t.UnixMilli())})
+	}
+	return series
+}
+
+// changeTable lists the snapshots that changed devices in the range.
+func changeTable(src *Source, deviceType string, r Range) Table {
+	table := Table{
+		Type: TypeTable,
+		Columns: []Column{
+			{Text: "TIME", Type: "time"},
+			{Text: "SNAPSHOT", Type: "string"},
+			{Text: "BMC", Type: "string"},
+			{Text: "CREATED", Type: "number"},
+			{Text: "UPDATED", Type: "number"},
+			{Text: "RETIRED", Type: "number"},
+		},
+		Rows: [][]interface{}{},
+	}
+	for _, c := range changes(src, deviceType, r) {
+		table.Rows = append(table.Rows, []interface{}{
+			c.at.UnixMilli(), c.snapshot.GetName(), discoverysnapshot.Address(c.snapshot), c.created, c.updated, c.retired,
+		})
+	}
+	return table
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,92 @@
+// Package groups resolves Groups to the devices they cover.
+package groups
+
+import (
+	"sort"
+
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/group"
+)
+
+// Membership is the devices a group covers.
+type Membership struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	// Count is the number of devices covered.
+	Count   int           `json:"count"`
+	Devices []prune.Entry `json:"devices"`
+	// Unresolved lists the members that name no device.
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// Find returns the group whose UID or name is id, or nil.
+func Find(groups []*group.Group, id string) *group.Group {
+	for _, g := range groups {
+		if g.GetUID() == id {
+			return g
+		}
+	}
+	for _, g := range groups {
+		if g.GetName() == id {
+			return g
+		}
+	}
+	return nil
+}
+
+// Members returns the devices the group covers, sorted by UID, and the
+// members that name no device. Members are matched by UID or name; a name
+// shared by several devices covers them all.
+func Members(g *group.Group, devices []*device.Device) ([]*device.Device, []string) {
+	byID := make(map[string][]*device.Device, 2*len(devices))
+	for _, d := range devices {
+		byID[d.GetUID()] = append(byID[d.GetUID()], d)
+		if d.GetName() != d.GetUID() {
+			byID[d.GetName()] = append(byID[d.GetName()], d)
+		}
+	}
+
+	selected := make(map[string]*device.Device)
+	var unresolved []string
+	for _, member := range g.Spec.Members {
+		found := byID[member]
+		if len(found) == 0 {
+			unresolved = append(unresolved, member)
+		}
+		for _, d := range found {
+			selected[d.GetUID()] = d
+		}
+	}
+	for _, d := range devices {
+		if g.SelectorMatches(d.Spec.DeviceType, d.Metadata.Labels) {
+			selected[d.GetUID()] = d
+		}
+	}
+
+	result := make([]*device.Device, 0, len(selected))
+	for _, d := range selected {
+		result = append(result, d)
+	}
+	if g.Spec.IncludeChildren {
+		return prune.WithDescendants(devices, result), unresolved
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetUID() < result[j].GetUID() })
+	return result, unresolved
+}
+
+// Resolve describes the devices the group covers.
+func Resolve(g *group.Group, devices []*device.Device) *Membership {
+	members, unresolved := Members(g, devices)
+	m := &Membership{
+		UID:        g.GetUID(),
+		Name:       g.GetName(),
+		Count:      len(members),
+		Devices:    make([]prune.Entry, 0, len(members)),
+		Unresolved: unresolved,
+	}
+	for _, d := range members {
+		m.Devices = append(m.Devices, prune.NewEntry(d))
+	}
+	return m
+}
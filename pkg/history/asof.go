@@ -0,0 +1,205 @@
+// Package history reconstructs past inventory from stored discovery
+// snapshots. Every snapshot keeps the full device list its BMC reported, so
+// the inventory at a time is the latest snapshot of each BMC before it.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// Query selects the past inventory to reconstruct.
+type Query struct {
+	At time.Time
+	// NodeSerial limits the result to a node and its descendants.
+	NodeSerial string
+	DeviceType string
+}
+
+// Source is the snapshot a BMC's devices were taken from.
+type Source struct {
+	BMC         string    `json:"bmc"`
+	Snapshot    string    `json:"snapshot"`
+	Profile     string    `json:"profile,omitempty"`
+	CollectedAt time.Time `json:"collectedAt"`
+	// Overlays names later quick-profile snapshots applied on top; they
+	// refresh nodes and NICs only.
+	Overlays []string `json:"overlays,omitempty"`
+}
+
+// Device is a device as reported at the time.
+type Device struct {
+	RedfishURI         string                     `json:"redfishURI"`
+	DeviceType         string                     `json:"deviceType"`
+	Manufacturer       string                     `json:"manufacturer,omitempty"`
+	PartNumber         string                     `json:"partNumber,omitempty"`
+	SerialNumber       string                     `json:"serialNumber"`
+	ParentSerialNumber string                     `json:"parentSerialNumber,omitempty"`
+	Properties         map[string]json.RawMessage `json:"properties,omitempty"`
+	// BMC is the address of the BMC that reported the device.
+	BMC string `json:"bmc"`
+	// CurrentUID is the device now at the same redfish_uri, if there is one.
+	// Its serial number differs when the part has since been replaced.
+	CurrentUID string `json:"currentUID,omitempty"`
+}
+
+// Inventory is the reconstructed inventory.
+type Inventory struct {
+	At         time.Time `json:"at"`
+	NodeSerial string    `json:"nodeSerial,omitempty"`
+	DeviceType string    `json:"deviceType,omitempty"`
+	Sources    []Source  `json:"sources"`
+	Total      int       `json:"total"`
+	// Devices is sorted by BMC and redfish_uri.
+	Devices []Device `json:"devices"`
+}
+
+// AsOf reconstructs the inventory at q.At from the completed snapshots. For
+// each BMC the latest full or deep snapshot taken at or before q.At is the
+// base; quick snapshots taken after it update its nodes and NICs. currentByURI
+// maps a redfish_uri to the UID of the device there today.
+func AsOf(snapshots []*discoverysnapshot.DiscoverySnapshot, q Query, currentByURI map[string]string) (*Inventory, error) {
+	byBMC := make(map[string][]*discoverysnapshot.DiscoverySnapshot)
+	for _, s := range snapshots {
+		if s.Status.Phase != "Completed" || s.Metadata.CreatedAt.After(q.At) {
+			continue
+		}
+		address := discoverysnapshot.Address(s)
+		byBMC[address] = append(byBMC[address], s)
+	}
+
+	inventory := &Inventory{
+		At:         q.At,
+		NodeSerial: q.NodeSerial,
+		DeviceType: q.DeviceType,
+		Sources:    []Source{},
+		Devices:    []Device{},
+	}
+	for address, list := range byBMC {
+		sort.Slice(list, func(i, j int) bool { return list[i].Metadata.CreatedAt.Before(list[j].Metadata.CreatedAt) })
+
+		base := len(list) - 1
+		for i := len(list) - 1; i >= 0; i-- {
+			if list[i].Spec.Profile != bmcendpoint.ProfileQuick {
+				base = i
+				break
+			}
+		}
+		source := Source{
+			BMC:         address,
+			Snapshot:    list[base].GetName(),
+			Profile:     list[base].Spec.Profile,
+			CollectedAt: list[base].Metadata.CreatedAt,
+		}
+		devices, order, err := decode(list[base])
+		if err != nil {
+			return nil, err
+		}
+		for _, overlay := range list[base+1:] {
+			specs, overlayOrder, err := decode(overlay)
+			if err != nil {
+				return nil, err
+			}
+			for _, uri := range overlayOrder {
+				if _, ok := devices[uri]; !ok {
+					order = append(order, uri)
+				}
+				devices[uri] = specs[uri]
+			}
+			source.Overlays = append(source.Overlays, overlay.GetName())
+		}
+
+		subtree := selectSubtree(devices, q.NodeSerial)
+		if q.NodeSerial != "" && len(subtree) == 0 {
+			continue
+		}
+		inventory.Sources = append(inventory.Sources, source)
+		for _, uri := range order {
+			spec := devices[uri]
+			if (q.NodeSerial != "" && !subtree[spec.SerialNumber]) || (q.DeviceType != "" && spec.DeviceType != q.DeviceType) {
+				continue
+			}
+			inventory.Devices = append(inventory.Devices, Device{
+				RedfishURI:         uri,
+				DeviceType:         spec.DeviceType,
+				Manufacturer:       spec.Manufacturer,
+				PartNumber:         spec.PartNumber,
+				SerialNumber:       spec.SerialNumber,
+				ParentSerialNumber: spec.ParentSerialNumber,
+				Properties:         spec.Properties,
+				BMC:                address,
+				CurrentUID:         currentByURI[uri],
+			})
+		}
+	}
+
+	sort.Slice(inventory.Sources, func(i, j int) bool { return inventory.Sources[i].BMC < inventory.Sources[j].BMC })
+	sort.SliceStable(inventory.Devices, func(i, j int) bool {
+		if inventory.Devices[i].BMC != inventory.Devices[j].BMC {
+			return inventory.Devices[i].BMC < inventory.Devices[j].BMC
+		}
+		return inventory.Devices[i].RedfishURI < inventory.Devices[j].RedfishURI
+	})
+	inventory.Total = len(inventory.Devices)
+	return inventory, nil
+}
+
+// decode returns a snapshot's devices keyed by redfish_uri, and the URIs in
+// payload order.
+func decode(s *discoverysnapshot.DiscoverySnapshot) (map[string]device.DeviceSpec, []string, error) {
+	var specs []device.DeviceSpec
+	if err := json.Unmarshal(s.Spec.RawData, &specs); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse snapshot %s: %w", s.GetName(), err)
+	}
+	devices := make(map[string]device.DeviceSpec, len(specs))
+	order := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		var uri string
+		if !spec.GetProperty("redfish_uri", &uri) || uri == "" {
+			continue
+		}
+		if _, ok := devices[uri]; !ok {
+			order = append(order, uri)
+		}
+		devices[uri] = spec
+	}
+	return devices, order, nil
+}
+
+// selectSubtree returns the serial numbers of the node and its descendants,
+// linked by parent serial number. It returns nil when nodeSerial is empty.
+func selectSubtree(devices map[string]device.DeviceSpec, nodeSerial string) map[string]bool {
+	if nodeSerial == "" {
+		return nil
+	}
+	children := make(map[string][]string)
+	found := false
+	for _, spec := range devices {
+		children[spec.ParentSerialNumber] = append(children[spec.ParentSerialNumber], spec.SerialNumber)
+		if spec.SerialNumber == nodeSerial {
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	subtree := map[string]bool{nodeSerial: true}
+	queue := []string{nodeSerial}
+	for len(queue) > 0 {
+		serial := queue[0]
+		queue = queue[1:]
+		for _, child := range children[serial] {
+			if child != "" && !subtree[child] {
+				subtree[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return subtree
+}
@@ -0,0 +1,216 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// SnapshotSummary identifies one side of a snapshot diff.
+type SnapshotSummary struct {
+	UID         string    `json:"uid"`
+	Name        string    `json:"name"`
+	BMC         string    `json:"bmc"`
+	Profile     string    `json:"profile,omitempty"`
+	CollectedAt time.Time `json:"collectedAt"`
+	Devices     int       `json:"devices"`
+	Partial     bool      `json:"partial,omitempty"`
+}
+
+// Change is one field of a device that differs between two snapshots. Field
+// is a DeviceSpec field (serialNumber, partNumber, ...) or "properties.<key>";
+// From or To is empty when the property is only in one snapshot.
+type Change struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from,omitempty"`
+	To    json.RawMessage `json:"to,omitempty"`
+}
+
+// DeviceChange is a device both snapshots report at the same redfish_uri,
+// with the fields that differ.
+type DeviceChange struct {
+	RedfishURI   string `json:"redfishURI"`
+	DeviceType   string `json:"deviceType"`
+	SerialNumber string `json:"serialNumber"`
+	// Replaced is set when the serial number changed: another part now sits
+	// at the same location.
+	Replaced bool     `json:"replaced,omitempty"`
+	Changes  []Change `json:"changes"`
+}
+
+// SnapshotDiff compares the device lists of two snapshots, matching devices
+// by redfish_uri.
+type SnapshotDiff struct {
+	From SnapshotSummary `json:"from"`
+	To   SnapshotSummary `json:"to"`
+	// Added and Removed are the devices only in To and only in From.
+	Added   []Device `json:"added"`
+	Removed []Device `json:"removed"`
+	// Uncollected are the devices only in one snapshot that the other did not
+	// collect, because it was a quick snapshot or failed to read their
+	// subtree. They are neither added nor removed.
+	Uncollected []Device       `json:"uncollected,omitempty"`
+	Changed     []DeviceChange `json:"changed"`
+	Unchanged   int            `json:"unchanged"`
+}
+
+// Diff compares the device lists of two snapshots, whether or not they have
+// been reconciled. Each list is sorted by redfish_uri.
+func Diff(from, to *discoverysnapshot.DiscoverySnapshot) (*SnapshotDiff, error) {
+	before, _, err := decode(from)
+	if err != nil {
+		return nil, err
+	}
+	after, _, err := decode(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SnapshotDiff{
+		From:    summarize(from, len(before)),
+		To:      summarize(to, len(after)),
+		Added:   []Device{},
+		Removed: []Device{},
+		Changed: []DeviceChange{},
+	}
+	for uri, spec := range before {
+		next, ok := after[uri]
+		switch {
+		case !ok && collects(to, spec.DeviceType, uri):
+			diff.Removed = append(diff.Removed, diffDevice(uri, spec, from))
+		case !ok:
+			diff.Uncollected = append(diff.Uncollected, diffDevice(uri, spec, from))
+		default:
+			changes := compare(spec, next)
+			if len(changes) == 0 {
+				diff.Unchanged++
+				continue
+			}
+			diff.Changed = append(diff.Changed, DeviceChange{
+				RedfishURI:   uri,
+				DeviceType:   next.DeviceType,
+				SerialNumber: next.SerialNumber,
+				Replaced:     spec.SerialNumber != next.SerialNumber,
+				Changes:      changes,
+			})
+		}
+	}
+	for uri, spec := range after {
+		if _, ok := before[uri]; ok {
+			continue
+		}
+		if collects(from, spec.DeviceType, uri) {
+			diff.Added = append(diff.Added, diffDevice(uri, spec, to))
+		} else {
+			diff.Uncollected = append(diff.Uncollected, diffDevice(uri, spec, to))
+		}
+	}
+
+	for _, list := range [][]Device{diff.Added, diff.Removed, diff.Uncollected} {
+		sort.Slice(list, func(i, j int) bool { return list[i].RedfishURI < list[j].RedfishURI })
+	}
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].RedfishURI < diff.Changed[j].RedfishURI })
+	return diff, nil
+}
+
+// quickTypes are the device types a quick snapshot collects: nodes and NICs,
+// which the collector reports as DPUs when they are.
+var quickTypes = map[string]bool{"Node": true, "NIC": true, "DPU": true}
+
+func summarize(s *discoverysnapshot.DiscoverySnapshot, devices int) SnapshotSummary {
+	return SnapshotSummary{
+		UID:         s.GetUID(),
+		Name:        s.GetName(),
+		BMC:         discoverysnapshot.Address(s),
+		Profile:     s.Spec.Profile,
+		CollectedAt: s.Metadata.CreatedAt,
+		Devices:     devices,
+		Partial:     s.Spec.Coverage.Partial(),
+	}
+}
+
+// collects reports whether snapshot s would have reported the device at uri
+// had it been there. Quick snapshots only collect nodes and NICs.
+func collects(s *discoverysnapshot.DiscoverySnapshot, deviceType, uri string) bool {
+	if s.Spec.Profile == bmcendpoint.ProfileQuick && !quickTypes[deviceType] {
+		return false
+	}
+	return s.Spec.Coverage.Covers(uri)
+}
+
+func diffDevice(uri string, spec device.DeviceSpec, s *discoverysnapshot.DiscoverySnapshot) Device {
+	return Device{
+		RedfishURI:         uri,
+		DeviceType:         spec.DeviceType,
+		Manufacturer:       spec.Manufacturer,
+		PartNumber:         spec.PartNumber,
+		SerialNumber:       spec.SerialNumber,
+		ParentSerialNumber: spec.ParentSerialNumber,
+		Properties:         spec.Properties,
+		BMC:                discoverysnapshot.Address(s),
+	}
+}
+
+// compare returns the fields that differ between two reports of a device,
+// the spec fields first and then the properties by key.
+func compare(from, to device.DeviceSpec) []Change {
+	var changes []Change
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"deviceType", from.DeviceType, to.DeviceType},
+		{"serialNumber", from.SerialNumber, to.SerialNumber},
+		{"manufacturer", from.Manufacturer, to.Manufacturer},
+		{"partNumber", from.PartNumber, to.PartNumber},
+		{"parentSerialNumber", from.ParentSerialNumber, to.ParentSerialNumber},
+	}
+	for _, f := range fields {
+		if f.from != f.to {
+			a, _ := json.Marshal(f.from)
+			b, _ := json.Marshal(f.to)
+			changes = append(changes, Change{Field: f.name, From: a, To: b})
+		}
+	}
+
+	keys := make([]string, 0, len(from.Properties)+len(to.Properties))
+	for key := range from.Properties {
+		keys = append(keys, key)
+	}
+	for key := range to.Properties {
+		if _, ok := from.Properties[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		a, b := from.Properties[key], to.Properties[key]
+		if !sameJSON(a, b) {
+			changes = append(changes, Change{Field: "properties." + key, From: a, To: b})
+		}
+	}
+	return changes
+}
+
+// sameJSON reports whether two JSON values are equal, ignoring formatting and
+// object key order.
+func sameJSON(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	x1, _ := json.Marshal(x)
+	y1, _ := json.Marshal(y)
+	return bytes.Equal(x1, y1)
+}
@@ -0,0 +1,80 @@
+package history
+
+import (
+	"errors"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// ErrNotReconciled is returned by Produced for a snapshot the reconciler has
+// not recorded the devices of.
+var ErrNotReconciled = errors.New("the snapshot has not been reconciled")
+
+// Device changes a snapshot made.
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeRetired = "retired"
+)
+
+// SnapshotDevices are the devices reconciling a snapshot changed.
+type SnapshotDevices struct {
+	Snapshot SnapshotSummary  `json:"snapshot"`
+	Devices  []ProducedDevice `json:"devices"`
+	// Deleted lists the UIDs of devices the snapshot changed that have been
+	// deleted since.
+	Deleted []string `json:"deleted,omitempty"`
+}
+
+// ProducedDevice is a device a snapshot changed, as it is now.
+type ProducedDevice struct {
+	// Change is created, updated or retired.
+	Change       string `json:"change"`
+	UID          string `json:"uid"`
+	Name         string `json:"name"`
+	DeviceType   string `json:"deviceType"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	RedfishURI   string `json:"redfishURI,omitempty"`
+	// Phase is the device's phase now, e.g. Missing when a later snapshot
+	// no longer reported it.
+	Phase string `json:"phase,omitempty"`
+}
+
+// Produced lists the devices reconciling s changed, looking each up with
+// load, which returns a nil device for one that no longer exists.
+func Produced(s *discoverysnapshot.DiscoverySnapshot, load func(uid string) (*device.Device, error)) (*SnapshotDevices, error) {
+	changes := s.Status.Devices
+	if changes == nil {
+		return nil, ErrNotReconciled
+	}
+	result := &SnapshotDevices{Devices: []ProducedDevice{}}
+	for _, list := range []struct {
+		change string
+		uids   []string
+	}{{ChangeCreated, changes.Created}, {ChangeUpdated, changes.Updated}, {ChangeRetired, changes.Retired}} {
+		for _, uid := range list.uids {
+			dev, err := load(uid)
+			if err != nil {
+				return nil, err
+			}
+			if dev == nil {
+				result.Deleted = append(result.Deleted, uid)
+				continue
+			}
+			var uri string
+			dev.Spec.GetProperty("redfish_uri", &uri)
+			result.Devices = append(result.Devices, ProducedDevice{
+				Change:       list.change,
+				UID:          uid,
+				Name:         dev.GetName(),
+				DeviceType:   dev.Spec.DeviceType,
+				SerialNumber: dev.Spec.SerialNumber,
+				RedfishURI:   uri,
+				Phase:        dev.Status.Phase,
+			})
+		}
+	}
+	result.Snapshot = summarize(s, len(changes.Created)+len(changes.Updated)+len(changes.Retired))
+	return result, nil
+}
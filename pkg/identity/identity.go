@@ -0,0 +1,129 @@
+// Package identity decides which Device record a discovered component is.
+//
+// Devices are keyed by Redfish URI, but firmware upgrades change URIs in
+// ways that do not move the component: letter case, the /redfish/v1 prefix,
+// trailing or doubled slashes. URIs are compared in a canonical form that
+// drops those differences. A component whose Id was renamed outright is
+// recognized by its serial number instead, or by its composite key when it
+// has none; see Moved.
+package identity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// PropertyCanonicalURI is the device property holding the canonical form of
+// its redfish_uri, which storage backends index.
+const PropertyCanonicalURI = "canonical_uri"
+
+// PropertyCompositeKey is the device property holding the composite key of
+// a serial-less component, which storage backends index.
+const PropertyCompositeKey = "composite_key"
+
+// locationProperties are the properties naming a component's slot, in order
+// of preference.
+var locationProperties = []string{"location", "device_locator", "socket"}
+
+// redfishRoot is the service root every Redfish URI starts with.
+const redfishRoot = "/redfish/v1"
+
+// CanonicalURI returns the form of a Redfish URI that identifies a
+// component: without scheme, host or query, without the /redfish/v1 prefix,
+// unescaped, with single slashes and no trailing slash, and in lower case.
+// Fragments are kept, since legacy Thermal and Power members are only told
+// apart by them ("/Chassis/1/Thermal#/Fans/0"). It returns "" for "".
+func CanonicalURI(uri string) string {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return ""
+	}
+	if _, rest, ok := strings.Cut(uri, "://"); ok {
+		uri = "/"
+		if i := strings.Index(rest, "/"); i >= 0 {
+			uri = rest[i:]
+		}
+	}
+	path, fragment, hasFragment := strings.Cut(uri, "#")
+	path, _, _ = strings.Cut(path, "?")
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	path = strings.ToLower(path)
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	path = strings.TrimSuffix(path, "/")
+	if path == redfishRoot {
+		path = ""
+	}
+	path = strings.TrimPrefix(path, redfishRoot+"/")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if hasFragment {
+		path += "#" + strings.TrimSuffix(strings.ToLower(fragment), "/")
+	}
+	return path
+}
+
+// CompositeKey identifies a component that has no serial number by where it
+// sits: its parent's serial number, its type, its slot (from the location,
+// device_locator or socket property) and a hash of its part number, so that
+// a different part fitted in the slot is a different component. It returns
+// "" for components with a serial number, and for those lacking a parent
+// serial or a slot.
+func CompositeKey(spec device.DeviceSpec) string {
+	if spec.SerialNumber != "" || spec.ParentSerialNumber == "" {
+		return ""
+	}
+	var location string
+	for _, key := range locationProperties {
+		if spec.GetProperty(key, &location) && strings.TrimSpace(location) != "" {
+			break
+		}
+		location = ""
+	}
+	if location == "" {
+		return ""
+	}
+	part := sha256.Sum256([]byte(strings.TrimSpace(spec.PartNumber)))
+	return strings.Join([]string{
+		spec.ParentSerialNumber,
+		strings.ToLower(spec.DeviceType),
+		strings.ToLower(strings.Join(strings.Fields(location), " ")),
+		hex.EncodeToString(part[:4]),
+	}, "/")
+}
+
+// Moved reports whether candidate, found by the serial number (or composite
+// key) of a component reported at a URI no device has, is that component
+// having moved there, e.g. after firmware renamed its Id. The candidate must
+// be of the same type, last reported by the same BMC (source), and at a URI
+// the BMC no longer reports (reported holds the canonical URIs of the
+// snapshot), so that placeholder serial numbers shared by several components
+// never match.
+func Moved(spec device.DeviceSpec, candidate *device.Device, source string, reported map[string]bool) bool {
+	if candidate == nil {
+		return false
+	}
+	if spec.SerialNumber != "" {
+		if candidate.Spec.SerialNumber != spec.SerialNumber {
+			return false
+		}
+	} else if key := CompositeKey(spec); key == "" || CompositeKey(candidate.Spec) != key {
+		return false
+	}
+	if candidate.Spec.DeviceType != spec.DeviceType || source == "" || candidate.Status.Source != source {
+		return false
+	}
+	var uri string
+	if !candidate.Spec.GetProperty("redfish_uri", &uri) || uri == "" {
+		return false
+	}
+	return !reported[CanonicalURI(uri)]
+}
@@ -0,0 +1,94 @@
+// Package lookup answers "which device owns this address?" queries.
+package lookup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// NormalizeMAC returns mac in the form the collector stores it: lowercase,
+// colon-separated octets. It accepts colon, dash and dot separators or none.
+func NormalizeMAC(mac string) (string, error) {
+	hex := strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.ToLower(strings.TrimSpace(mac)))
+	if len(hex) != 12 {
+		return "", fmt.Errorf("invalid MAC address %q", mac)
+	}
+	octets := make([]string, 0, 6)
+	for i := 0; i < 12; i += 2 {
+		octet := hex[i : i+2]
+		for _, c := range octet {
+			if !strings.ContainsRune("0123456789abcdef", c) {
+				return "", fmt.Errorf("invalid MAC address %q", mac)
+			}
+		}
+		octets = append(octets, octet)
+	}
+	return strings.Join(octets, ":"), nil
+}
+
+// HasMAC reports whether the device lists mac (normalized) in its
+// "mac_addresses" property.
+func HasMAC(d *device.Device, mac string) bool {
+	var macs []string
+	d.Spec.GetProperty("mac_addresses", &macs)
+	for _, m := range macs {
+		if strings.EqualFold(m, mac) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeviceRef identifies a device in a lookup result.
+type DeviceRef struct {
+	UID          string `json:"uid"`
+	Name         string `json:"name"`
+	DeviceType   string `json:"deviceType"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	Phase        string `json:"phase,omitempty"`
+}
+
+// NewDeviceRef describes d.
+func NewDeviceRef(d *device.Device) DeviceRef {
+	return DeviceRef{
+		UID:          d.GetUID(),
+		Name:         d.GetName(),
+		DeviceType:   d.Spec.DeviceType,
+		SerialNumber: d.Spec.SerialNumber,
+		Phase:        d.Status.Phase,
+	}
+}
+
+// MACMatch is one device that reports the MAC.
+type MACMatch struct {
+	// Device reports the MAC, usually a NetworkAdapter.
+	Device DeviceRef `json:"device"`
+	// Node is the Node the device belongs to, if it has one. It is the device
+	// itself when a node reports the MAC directly.
+	Node *DeviceRef `json:"node,omitempty"`
+}
+
+// MACResult is the response of a MAC lookup.
+type MACResult struct {
+	MAC     string     `json:"mac"`
+	Matches []MACMatch `json:"matches"`
+}
+
+// OwningNode walks up the ParentID chain from d to the first Node. getParent
+// returns the device with a UID, or nil. It returns nil when there is none.
+func OwningNode(d *device.Device, getParent func(uid string) *device.Device) *device.Device {
+	seen := make(map[string]bool)
+	for d != nil && !seen[d.GetUID()] {
+		if d.Spec.DeviceType == "Node" {
+			return d
+		}
+		seen[d.GetUID()] = true
+		if d.Spec.ParentID == "" {
+			return nil
+		}
+		d = getParent(d.Spec.ParentID)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package lookup
+
+import (
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Location is where a device is, gathered from the device and its ancestors;
+// the nearest value wins.
+type Location struct {
+	Xname           string `json:"xname,omitempty"`
+	Row             string `json:"row,omitempty"`
+	Rack            string `json:"rack,omitempty"`
+	RackOffset      *int   `json:"rackOffset,omitempty"`
+	RackOffsetUnits string `json:"rackOffsetUnits,omitempty"`
+	// Slot is the device's redfish_uri.
+	Slot string `json:"slot,omitempty"`
+	// BMC is the address of the BMC that last reported the device.
+	BMC string `json:"bmc,omitempty"`
+}
+
+// SerialMatch is one device with the serial number.
+type SerialMatch struct {
+	Device *device.Device `json:"device"`
+	// Ancestors lists the device's parents, nearest first, up to the root.
+	Ancestors []DeviceRef `json:"ancestors"`
+	// Node is the Node the device belongs to, or the device itself.
+	Node     *DeviceRef `json:"node,omitempty"`
+	Location Location   `json:"location"`
+}
+
+// SerialResult is the response of a serial number lookup.
+type SerialResult struct {
+	SerialNumber string        `json:"serialNumber"`
+	Matches      []SerialMatch `json:"matches"`
+}
+
+// Ancestors returns the parents of d, nearest first, up to the root.
+func Ancestors(d *device.Device, getParent func(uid string) *device.Device) []*device.Device {
+	var chain []*device.Device
+	seen := map[string]bool{d.GetUID(): true}
+	for d.Spec.ParentID != "" {
+		parent := getParent(d.Spec.ParentID)
+		if parent == nil || seen[parent.GetUID()] {
+			break
+		}
+		seen[parent.GetUID()] = true
+		chain = append(chain, parent)
+		d = parent
+	}
+	return chain
+}
+
+// NewSerialMatch describes d, its ancestry and its location.
+func NewSerialMatch(d *device.Device, getParent func(uid string) *device.Device) SerialMatch {
+	ancestors := Ancestors(d, getParent)
+	match := SerialMatch{
+		Device:    d,
+		Ancestors: make([]DeviceRef, 0, len(ancestors)),
+	}
+	match.Location.BMC = d.Status.Source
+	d.Spec.GetProperty("redfish_uri", &match.Location.Slot)
+
+	for _, dev := range append([]*device.Device{d}, ancestors...) {
+		if dev != d {
+			match.Ancestors = append(match.Ancestors, NewDeviceRef(dev))
+		}
+		if match.Node == nil && dev.Spec.DeviceType == "Node" {
+			ref := NewDeviceRef(dev)
+			match.Node = &ref
+		}
+		setIfEmpty(dev, "xname", &match.Location.Xname)
+		setIfEmpty(dev, "row", &match.Location.Row)
+		setIfEmpty(dev, "rack", &match.Location.Rack)
+		setIfEmpty(dev, "rack_offset_units", &match.Location.RackOffsetUnits)
+		if match.Location.RackOffset == nil {
+			var offset int
+			if dev.Spec.GetProperty("rack_offset", &offset) {
+				match.Location.RackOffset = &offset
+			}
+		}
+	}
+	return match
+}
+
+// setIfEmpty reads a string property into *dst unless it is already set.
+func setIfEmpty(d *device.Device, key string, dst *string) {
+	if *dst == "" {
+		d.Spec.GetProperty(key, dst)
+	}
+}
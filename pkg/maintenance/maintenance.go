@@ -0,0 +1,129 @@
+// Package maintenance resolves the active maintenance windows to the BMC
+// endpoints and devices they cover.
+package maintenance
+
+import (
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+)
+
+// Blackout is what the maintenance windows active at one time cover.
+type Blackout struct {
+	At time.Time `json:"at"`
+	// Windows names the active windows.
+	Windows []string `json:"windows"`
+	// Global is set when an active window covers the whole fleet.
+	Global bool `json:"global"`
+	// Addresses lists the BMC addresses covered, directly or through a rack.
+	Addresses []string `json:"addresses"`
+
+	addresses map[string]bool
+	devices   map[string]bool
+}
+
+// Resolve returns the blackout of the windows active at now. Endpoints are
+// matched by UID or name; a rack covers every device under it and the BMCs
+// that report them.
+func Resolve(windows []*maintenancewindow.MaintenanceWindow, endpoints []*bmcendpoint.BMCEndpoint, devices []*device.Device, now time.Time) *Blackout {
+	b := &Blackout{
+		At:        now,
+		Windows:   []string{},
+		Addresses: []string{},
+		addresses: make(map[string]bool),
+		devices:   make(map[string]bool),
+	}
+	endpointIDs := make(map[string]bool)
+	rackIDs := make(map[string]bool)
+	for _, w := range windows {
+		if !w.ActiveAt(now) {
+			continue
+		}
+		b.Windows = append(b.Windows, w.GetName())
+		if w.Global() {
+			b.Global = true
+		}
+		for _, id := range w.Spec.EndpointIDs {
+			endpointIDs[id] = true
+		}
+		for _, id := range w.Spec.RackIDs {
+			rackIDs[id] = true
+		}
+	}
+	sort.Strings(b.Windows)
+
+	for _, e := range endpoints {
+		if endpointIDs[e.GetUID()] || endpointIDs[e.GetName()] {
+			b.addresses[e.Spec.Address] = true
+		}
+	}
+
+	children := make(map[string][]*device.Device)
+	for _, d := range devices {
+		if d.Spec.ParentID != "" {
+			children[d.Spec.ParentID] = append(children[d.Spec.ParentID], d)
+		}
+	}
+	var queue []*device.Device
+	for _, d := range devices {
+		if rackIDs[d.GetUID()] {
+			queue = append(queue, d)
+		}
+	}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if b.devices[d.GetUID()] {
+			continue
+		}
+		b.devices[d.GetUID()] = true
+		if d.Status.Source != "" {
+			b.addresses[d.Status.Source] = true
+		}
+		queue = append(queue, children[d.GetUID()]...)
+	}
+
+	for address := range b.addresses {
+		b.Addresses = append(b.Addresses, address)
+	}
+	sort.Strings(b.Addresses)
+	return b
+}
+
+// Active reports whether any window is active.
+func (b *Blackout) Active() bool {
+	return b != nil && len(b.Windows) > 0
+}
+
+// CoversAddress reports whether the BMC at address is in maintenance. It
+// works on a blackout decoded from JSON too.
+func (b *Blackout) CoversAddress(address string) bool {
+	if b == nil {
+		return false
+	}
+	if b.Global {
+		return true
+	}
+	if b.addresses != nil {
+		return b.addresses[address]
+	}
+	for _, a := range b.Addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+// CoversDevice reports whether the device is in maintenance: it is under a
+// covered rack or was last reported by a covered BMC. A nil blackout covers
+// nothing.
+func (b *Blackout) CoversDevice(d *device.Device) bool {
+	if b == nil {
+		return false
+	}
+	return b.Global || b.devices[d.GetUID()] || (d.Status.Source != "" && b.CoversAddress(d.Status.Source))
+}
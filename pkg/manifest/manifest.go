@@ -0,0 +1,180 @@
+// Package manifest imports vendor shipping manifests as expected devices, so
+// that deliveries can be verified as discovery finds the shipped hardware.
+package manifest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/lookup"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Delivery phases of a device imported from a manifest.
+const (
+	// PhaseExpected marks a device listed on a manifest but not yet discovered.
+	PhaseExpected = "Expected"
+	// PhaseReceived marks an expected device that discovery has found but not
+	// yet linked to its parent.
+	PhaseReceived = "Received"
+	// PhaseInstalled marks an expected device that discovery has found in place.
+	PhaseInstalled = "Installed"
+)
+
+// IsExpected reports whether the device is still awaiting delivery. Expected
+// devices have never been reported by a BMC, so fleet checks skip them.
+func IsExpected(d *device.Device) bool {
+	return d.Status.Phase == PhaseExpected
+}
+
+// Properties set on imported devices.
+const (
+	// PropertyManifest names the manifest a device was imported from.
+	PropertyManifest = "manifest"
+)
+
+// Row is one shipped part.
+type Row struct {
+	// Line is the CSV line number, for error messages.
+	Line         int      `json:"line"`
+	SerialNumber string   `json:"serialNumber"`
+	PartNumber   string   `json:"partNumber,omitempty"`
+	Manufacturer string   `json:"manufacturer,omitempty"`
+	DeviceType   string   `json:"deviceType,omitempty"`
+	MACAddresses []string `json:"macAddresses,omitempty"`
+}
+
+// columns maps accepted header names (lowercase, without spaces, dashes or
+// underscores) to Row fields.
+var columns = map[string]string{
+	"serial":       "serial",
+	"serialnumber": "serial",
+	"sn":           "serial",
+	"partnumber":   "part",
+	"part":         "part",
+	"pn":           "part",
+	"manufacturer": "manufacturer",
+	"vendor":       "manufacturer",
+	"devicetype":   "type",
+	"type":         "type",
+	"mac":          "mac",
+	"macaddress":   "mac",
+	"macaddresses": "mac",
+}
+
+// Parse reads a manifest CSV. The first line is a header naming the columns;
+// a serial number column is required and unknown columns are ignored. A MAC
+// column may hold several addresses separated by spaces or semicolons.
+func Parse(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("manifest is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest header: %w", err)
+	}
+	index := make(map[string]int)
+	for i, name := range header {
+		key := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+		if field, ok := columns[key]; ok {
+			if _, dup := index[field]; !dup {
+				index[field] = i
+			}
+		}
+	}
+	if _, ok := index["serial"]; !ok {
+		return nil, fmt.Errorf("manifest header %v has no serial number column", header)
+	}
+
+	get := func(record []string, field string) string {
+		i, ok := index[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := Row{
+			Line:         line,
+			SerialNumber: get(record, "serial"),
+			PartNumber:   get(record, "part"),
+			Manufacturer: get(record, "manufacturer"),
+			DeviceType:   get(record, "type"),
+		}
+		for _, mac := range strings.FieldsFunc(get(record, "mac"), func(r rune) bool { return r == ';' || r == ' ' }) {
+			normalized, err := lookup.NormalizeMAC(mac)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			row.MACAddresses = append(row.MACAddresses, normalized)
+		}
+		if row.SerialNumber == "" {
+			if strings.Join(record, "") == "" {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: missing serial number", line)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Spec returns the spec of the expected device for a row. defaultType is used
+// when the row has no device type.
+func (row Row) Spec(manifestName, defaultType string) device.DeviceSpec {
+	spec := device.DeviceSpec{
+		DeviceType:   row.DeviceType,
+		Manufacturer: row.Manufacturer,
+		PartNumber:   row.PartNumber,
+		SerialNumber: row.SerialNumber,
+	}
+	if spec.DeviceType == "" {
+		spec.DeviceType = defaultType
+	}
+	if manifestName != "" {
+		spec.SetProperty(PropertyManifest, manifestName)
+	}
+	if len(row.MACAddresses) > 0 {
+		spec.SetProperty("mac_addresses", row.MACAddresses)
+	}
+	return spec
+}
+
+// Entry describes one imported row.
+type Entry struct {
+	Line         int    `json:"line"`
+	SerialNumber string `json:"serialNumber"`
+	DeviceType   string `json:"deviceType"`
+	// UID is the created device, or the existing device with the same serial.
+	UID string `json:"uid,omitempty"`
+	// Existing is set when a device with the serial is already in the inventory.
+	Existing bool `json:"existing,omitempty"`
+}
+
+// ImportResult is the response of a manifest import.
+type ImportResult struct {
+	Manifest string   `json:"manifest"`
+	DryRun   bool     `json:"dryRun"`
+	Rows     int      `json:"rows"`
+	Created  int      `json:"created"`
+	Existing int      `json:"existing"`
+	Entries  []Entry  `json:"entries"`
+	Errors   []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,211 @@
+// Package naming derives device names (Metadata.Name) from device specs.
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Naming strategies.
+const (
+	// StrategyURI names devices by their Redfish URI. It is the default.
+	StrategyURI = "uri"
+	// StrategyXname names devices by their xname, where one was derived.
+	StrategyXname = "xname"
+	// StrategySerial names a node by its serial number and a component by
+	// its parent's serial, type and slot, e.g. "J30219-dimm-A1".
+	StrategySerial = "serial"
+	// StrategyTemplate names devices with a text/template over Fields.
+	StrategyTemplate = "template"
+)
+
+// Strategies lists the valid naming strategies.
+var Strategies = []string{StrategyURI, StrategyXname, StrategySerial, StrategyTemplate}
+
+// Fields are the device fields available to name templates, e.g.
+// "{{.ParentSerialNumber}}-{{lower .DeviceType}}-{{.Slot}}".
+type Fields struct {
+	DeviceType         string
+	Manufacturer       string
+	PartNumber         string
+	SerialNumber       string
+	ParentSerialNumber string
+	RedfishURI         string
+	// ID is the last segment of the Redfish URI.
+	ID string
+	// Slot is the component's locator (e.g. "A1" for "DIMM A1"), or its ID.
+	Slot  string
+	Xname string
+}
+
+// Namer names devices by a strategy. Devices lacking the fields the
+// strategy needs are named by their Redfish URI.
+type Namer struct {
+	strategy string
+	tmpl     *template.Template
+}
+
+// New returns a namer for strategy ("" selects StrategyURI). The template
+// strategy needs text, a text/template over Fields.
+func New(strategy, text string) (*Namer, error) {
+	if strategy == "" {
+		strategy = StrategyURI
+	}
+	n := &Namer{strategy: strategy}
+	switch strategy {
+	case StrategyURI, StrategyXname, StrategySerial:
+	case StrategyTemplate:
+		if text == "" {
+			return nil, fmt.Errorf("naming strategy %q needs a template", strategy)
+		}
+		funcs := template.FuncMap{"lower": strings.ToLower, "upper": strings.ToUpper}
+		tmpl, err := template.New("name").Funcs(funcs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name template: %w", err)
+		}
+		n.tmpl = tmpl
+	default:
+		return nil, fmt.Errorf("unknown naming strategy %q (valid: %v)", strategy, Strategies)
+	}
+	return n, nil
+}
+
+// String returns the namer's strategy.
+func (n *Namer) String() string {
+	return n.strategy
+}
+
+// Name returns the name the strategy gives a device, or "" when the device
+// has no Redfish URI to fall back on.
+func (n *Namer) Name(spec device.DeviceSpec) string {
+	fields := NewFields(spec)
+	var name string
+	switch n.strategy {
+	case StrategyXname:
+		name = fields.Xname
+	case StrategySerial:
+		if spec.DeviceType == "Node" {
+			name = fields.SerialNumber
+		} else if fields.ParentSerialNumber != "" && fields.Slot != "" {
+			name = fields.ParentSerialNumber + "-" + strings.ToLower(fields.DeviceType) + "-" + fields.Slot
+		}
+	case StrategyTemplate:
+		var buf bytes.Buffer
+		if err := n.tmpl.Execute(&buf, fields); err == nil {
+			name = buf.String()
+		}
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		return fields.RedfishURI
+	}
+	return name
+}
+
+// NewFields returns the naming fields of a device.
+func NewFields(spec device.DeviceSpec) Fields {
+	fields := Fields{
+		DeviceType:         spec.DeviceType,
+		Manufacturer:       spec.Manufacturer,
+		PartNumber:         spec.PartNumber,
+		SerialNumber:       spec.SerialNumber,
+		ParentSerialNumber: spec.ParentSerialNumber,
+	}
+	spec.GetProperty("redfish_uri", &fields.RedfishURI)
+	spec.GetProperty("xname", &fields.Xname)
+	fields.ID = fields.RedfishURI[strings.LastIndex(fields.RedfishURI, "/")+1:]
+
+	locator := fields.ID
+	for _, key := range []string{"device_locator", "socket"} {
+		var value string
+		if spec.GetProperty(key, &value) && strings.TrimSpace(value) != "" {
+			locator = value
+			break
+		}
+	}
+	fields.Slot = slot(locator, spec.DeviceType)
+	return fields
+}
+
+// slot shortens a locator by the device type it starts with ("DIMM A1",
+// "DIMM_A1" -> "A1"; "CPU 1" -> "1") and replaces the spaces left.
+func slot(locator, deviceType string) string {
+	locator = strings.TrimSpace(locator)
+	if deviceType != "" && len(locator) > len(deviceType) && strings.EqualFold(locator[:len(deviceType)], deviceType) {
+		if rest := strings.TrimLeft(locator[len(deviceType):], " _-."); rest != "" {
+			locator = rest
+		}
+	}
+	return strings.Join(strings.Fields(locator), "_")
+}
+
+// Unique disambiguates name for the device with the given UID, for when
+// another device already has the name.
+func Unique(name, uid string) string {
+	return name + "-" + uid[strings.LastIndex(uid, "-")+1:]
+}
+
+// Rename is a name change of one device.
+type Rename struct {
+	UID        string `json:"uid"`
+	DeviceType string `json:"deviceType"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+// RenameResult is the response of a rename call.
+type RenameResult struct {
+	DryRun   bool     `json:"dryRun"`
+	Strategy string   `json:"strategy"`
+	Renames  []Rename `json:"renames"`
+	// Applied counts the renames saved.
+	Applied int      `json:"applied"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// Plan returns the renames that bring existing devices in line with the
+// namer, keeping names unique: a device that already has its name keeps it,
+// and the others are given theirs in UID order, disambiguated with Unique
+// when taken.
+func (n *Namer) Plan(devices []*device.Device) []Rename {
+	sorted := make([]*device.Device, 0, len(devices))
+	for _, d := range devices {
+		if d != nil {
+			sorted = append(sorted, d)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetUID() < sorted[j].GetUID() })
+
+	wanted := make(map[string]string, len(sorted))
+	taken := make(map[string]string, len(sorted))
+	for _, d := range sorted {
+		name := n.Name(d.Spec)
+		if name == "" {
+			name = d.GetName()
+		}
+		wanted[d.GetUID()] = name
+		if name == d.GetName() {
+			taken[name] = d.GetUID()
+		}
+	}
+
+	renames := []Rename{}
+	for _, d := range sorted {
+		name := wanted[d.GetUID()]
+		if taken[name] == d.GetUID() {
+			continue
+		}
+		if _, ok := taken[name]; ok {
+			name = Unique(name, d.GetUID())
+		}
+		taken[name] = d.GetUID()
+		if name != d.GetName() {
+			renames = append(renames, Rename{UID: d.GetUID(), DeviceType: d.Spec.DeviceType, From: d.GetName(), To: name})
+		}
+	}
+	return renames
+}
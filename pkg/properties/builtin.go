@@ -0,0 +1,152 @@
+package properties
+
+// Device types the built-in properties are grouped by.
+var (
+	nodeTypes  = []string{"Node", "DPU"}
+	nicTypes   = []string{"NIC", "DPU"}
+	powerTypes = []string{"PDU", "PDUBranch", "PDUOutlet"}
+	bmcTypes   = []string{"BMC"}
+)
+
+// builtin describes the properties the collector, the built-in plugins and
+// manifests write.
+var builtin = []Schema{
+	// Identity and location
+	{Key: "redfish_uri", Type: TypeString, Description: "Redfish URI the device was reported at; the key snapshots match devices by"},
+	{Key: "redfish_parent_uri", Type: TypeString, Description: "Redfish URI of the parent device, empty for top-level devices"},
+	{Key: "canonical_uri", Type: TypeString, Description: "Canonical form of redfish_uri, indexed by the storage backends"},
+	{Key: "composite_key", Type: TypeString, Description: "Identity of a component without a serial number, from its parent, slot and part number; indexed by the storage backends"},
+	{Key: "name", Type: TypeString, Description: "Name the BMC reports for the device"},
+	{Key: "model", Type: TypeString, Description: "Model name the BMC reports"},
+	{Key: "sku", Type: TypeString, Description: "Stock keeping unit"},
+	{Key: "location", Type: TypeString, Description: "Service label of the device's slot, e.g. \"CPU 1\" or \"DIMM A1\""},
+	{Key: "device_locator", Type: TypeString, Description: "Slot of a DIMM as the BMC names it", DeviceTypes: []string{"DIMM"}},
+	{Key: "socket", Type: TypeString, Description: "Socket of a processor", DeviceTypes: []string{"CPU", "GPU"}},
+	{Key: "xname", Type: TypeString, Description: "HPE Cray EX component name, derived from the BMC's xname"},
+	{Key: "row", Type: TypeString, Description: "Data center row"},
+	{Key: "rack", Type: TypeString, Description: "Rack the device is installed in"},
+	{Key: "rack_offset", Type: TypeInteger, Unit: "rack units", Description: "Position of the device in its rack"},
+	{Key: "rack_offset_units", Type: TypeString, Description: "How rack_offset counts, e.g. \"EIA_310\""},
+	{Key: "asset_tag", Type: TypeString, Description: "Asset tag the site labels the device with"},
+	{Key: "manifest", Type: TypeString, Description: "Name of the manifest the device was imported from"},
+	{Key: "schema_source", Type: TypeString, Description: "Redfish schema the device was read from, when a BMC offers several (e.g. Power or PowerSubsystem)"},
+	{Key: "openbmc_inventory_path", Type: TypeString, Description: "OpenBMC D-Bus inventory object the device's data was completed from"},
+
+	// Nodes
+	{Key: "bmc_uri", Type: TypeString, Description: "Redfish URI of the manager (BMC) of the system", DeviceTypes: nodeTypes},
+	{Key: "system_type", Type: TypeString, Description: "Redfish SystemType, e.g. \"Physical\" or \"Composed\"", DeviceTypes: nodeTypes},
+	{Key: "power_state", Type: TypeString, Description: "Power state when the snapshot was taken, e.g. \"On\" or \"Off\"", DeviceTypes: []string{"Node", "DPU", "PDUOutlet"}},
+	{Key: "bios_version", Type: TypeString, Description: "BIOS (system firmware) version", DeviceTypes: nodeTypes},
+	{Key: "bios_attributes", Type: TypeObject, Description: "BIOS settings, collected by the full profile", DeviceTypes: nodeTypes},
+	{Key: "firmware_inventory", Type: TypeArray, Description: "Firmware components and versions, collected by the full profile", DeviceTypes: nodeTypes},
+	{Key: "resource_blocks", Type: TypeArray, Description: "Redfish URIs of the resource blocks a composed system was built from", DeviceTypes: []string{"Node"}},
+	{Key: "cpu_count", Type: TypeInteger, Description: "Processors installed", DeviceTypes: []string{"Node"}},
+	{Key: "gpu_count", Type: TypeInteger, Description: "GPUs installed", DeviceTypes: []string{"Node"}},
+	{Key: "dimm_count", Type: TypeInteger, Description: "DIMMs installed", DeviceTypes: []string{"Node"}},
+	{Key: "drive_count", Type: TypeInteger, Description: "Drives installed", DeviceTypes: []string{"Node"}},
+	{Key: "nic_count", Type: TypeInteger, Description: "Network adapters and DPUs installed", DeviceTypes: []string{"Node"}},
+	{Key: "memory_total_mib", Type: TypeInteger, Unit: "MiB", Description: "Total capacity of the node's DIMMs", DeviceTypes: []string{"Node"}},
+	{Key: "storage_total_bytes", Type: TypeInteger, Unit: "bytes", Description: "Total capacity of the node's drives", DeviceTypes: []string{"Node"}},
+	{Key: "service_tag", Type: TypeString, Description: "Dell service tag", DeviceTypes: []string{"Node"}},
+	{Key: "express_service_code", Type: TypeString, Description: "Dell express service code, the service tag in base 10", DeviceTypes: []string{"Node"}},
+	{Key: "chassis_service_tag", Type: TypeString, Description: "Dell service tag of the enclosing chassis", DeviceTypes: []string{"Node"}},
+	{Key: "dell_system_generation", Type: TypeString, Description: "Dell PowerEdge generation, e.g. \"16G Monolithic\"", DeviceTypes: []string{"Node"}},
+	{Key: "idrac_license", Type: TypeString, Description: "Highest iDRAC license installed, e.g. \"Enterprise\"", DeviceTypes: []string{"Node"}},
+	{Key: "idrac_licenses", Type: TypeArray, Description: "Descriptions of the iDRAC licenses installed", DeviceTypes: []string{"Node"}},
+	{Key: "machine_type_model", Type: TypeString, Description: "Lenovo machine type and model, e.g. \"7Z70CTO1WW\"", DeviceTypes: []string{"Node"}},
+	{Key: "machine_type", Type: TypeString, Description: "Lenovo machine type, the first four characters of machine_type_model", DeviceTypes: []string{"Node"}},
+
+	// BMCs
+	{Key: "hostname", Type: TypeString, Description: "Host name of the BMC's management interface", DeviceTypes: bmcTypes},
+	{Key: "fqdn", Type: TypeString, Description: "Fully qualified domain name of the BMC's management interface", DeviceTypes: bmcTypes},
+	{Key: "ipv4_addresses", Type: TypeArray, Description: "IPv4 addresses of the BMC's enabled management interfaces", DeviceTypes: bmcTypes},
+	{Key: "address_origin", Type: TypeString, Description: "How the BMC's addresses were assigned, e.g. \"DHCP\" or \"Static\"; several are comma-separated", DeviceTypes: bmcTypes},
+	{Key: "ntp_enabled", Type: TypeBoolean, Description: "Whether the BMC sets its clock by NTP", DeviceTypes: bmcTypes},
+	{Key: "ntp_servers", Type: TypeArray, Description: "NTP servers the BMC is configured with", DeviceTypes: bmcTypes},
+	{Key: "clock_skew_seconds", Type: TypeNumber, Unit: "s", Description: "How far the BMC's clock was ahead of the collector's (negative: behind)", DeviceTypes: bmcTypes},
+	{Key: "clock_skewed", Type: TypeBoolean, Description: "Whether the BMC's clock was off by more than the collector's --max-clock-skew", DeviceTypes: bmcTypes},
+
+	// Processors and memory
+	{Key: "processor_type", Type: TypeString, Description: "Redfish ProcessorType, e.g. \"CPU\" or \"GPU\"", DeviceTypes: []string{"CPU", "GPU"}},
+	{Key: "total_cores", Type: TypeInteger, Description: "Cores of the processor", DeviceTypes: []string{"CPU", "GPU"}},
+	{Key: "total_threads", Type: TypeInteger, Description: "Hardware threads of the processor", DeviceTypes: []string{"CPU", "GPU"}},
+	{Key: "capacity_mib", Type: TypeInteger, Unit: "MiB", Description: "Capacity of the DIMM", DeviceTypes: []string{"DIMM"}},
+	{Key: "capacity_gib", Type: TypeNumber, Unit: "GiB", Description: "Capacity of the DIMM, derived from capacity_mib", DeviceTypes: []string{"DIMM"}},
+	{Key: "memory_device_type", Type: TypeString, Description: "Memory technology, e.g. \"DDR5\"", DeviceTypes: []string{"DIMM"}},
+	{Key: "operating_speed_mhz", Type: TypeInteger, Unit: "MT/s", Description: "Data rate of the DIMM, from Redfish's OperatingSpeedMhz, which most BMCs report in MT/s", DeviceTypes: []string{"DIMM"}},
+
+	// FRU data, from vendor OEM blocks
+	{Key: "fru_part_number", Type: TypeString, Description: "FRU part number"},
+	{Key: "fru_serial_number", Type: TypeString, Description: "FRU serial number"},
+	{Key: "board_part_number", Type: TypeString, Description: "Part number of the board"},
+	{Key: "board_serial_number", Type: TypeString, Description: "Serial number of the board"},
+	{Key: "board_manufacturer", Type: TypeString, Description: "Manufacturer of the board"},
+	{Key: "product_part_number", Type: TypeString, Description: "Part number of the product"},
+	{Key: "product_serial_number", Type: TypeString, Description: "Serial number of the product"},
+	{Key: "manufacture_date", Type: TypeString, Description: "Date of manufacture as the FRU records it"},
+
+	// Drives
+	{Key: "capacity_bytes", Type: TypeInteger, Unit: "bytes", Description: "Capacity of the drive (or DIMM, derived from capacity_mib)", DeviceTypes: []string{"Drive", "DIMM"}},
+	{Key: "protocol", Type: TypeString, Description: "Interface of the drive, e.g. \"NVMe\" or \"SAS\"", DeviceTypes: []string{"Drive"}},
+	{Key: "media_type", Type: TypeString, Description: "\"SSD\" or \"HDD\"", DeviceTypes: []string{"Drive"}},
+	{Key: "form_factor", Type: TypeString, Description: "Form factor of the drive, e.g. \"U2\" or \"M2\"", DeviceTypes: []string{"Drive"}},
+	{Key: "firmware_version", Type: TypeString, Description: "Firmware version of the device", DeviceTypes: []string{"Drive", "NIC", "DPU", "PDU", "CDU", "PowerSupply", "BMC"}},
+	{Key: "predicted_life_left_percent", Type: TypeNumber, Unit: "%", Description: "Media life the drive predicts it has left", DeviceTypes: []string{"Drive"}},
+	{Key: "percentage_used", Type: TypeNumber, Unit: "%", Description: "Share of the drive's rated endurance used; may exceed 100", DeviceTypes: []string{"Drive"}},
+	{Key: "wear_source", Type: TypeString, Description: "Where percentage_used was read from", DeviceTypes: []string{"Drive"}},
+	{Key: "namespace_count", Type: TypeInteger, Description: "NVMe namespaces on the drive", DeviceTypes: []string{"Drive"}},
+	{Key: "namespace_capacity_bytes", Type: TypeInteger, Unit: "bytes", Description: "Total capacity of the drive's NVMe namespaces", DeviceTypes: []string{"Drive"}},
+
+	// Network adapters and DPUs
+	{Key: "fabric", Type: TypeString, Description: "Network the adapter attaches to: \"Ethernet\", \"InfiniBand\" or \"Slingshot\"", DeviceTypes: nicTypes},
+	{Key: "link_technology", Type: TypeString, Description: "Link technology of the adapter's ports", DeviceTypes: nicTypes},
+	{Key: "mac_addresses", Type: TypeArray, Description: "MAC addresses of the adapter's ports", DeviceTypes: nicTypes},
+	{Key: "port_guids", Type: TypeArray, Description: "InfiniBand port GUIDs", DeviceTypes: nicTypes},
+	{Key: "node_guid", Type: TypeString, Description: "InfiniBand node GUID, the first port GUID", DeviceTypes: nicTypes},
+	{Key: "port_oem", Type: TypeObject, Description: "Vendor OEM blocks of the adapter's ports, by port", DeviceTypes: nicTypes},
+	{Key: "offload_system_uri", Type: TypeString, Description: "Redfish URI of the system a DPU runs", DeviceTypes: nicTypes},
+	{Key: "dpu_system_uri", Type: TypeString, Description: "Redfish URI of the DPU's own system", DeviceTypes: []string{"DPU"}},
+	{Key: "dpu_system_serial", Type: TypeString, Description: "Serial number of the DPU's own system", DeviceTypes: []string{"DPU"}},
+	{Key: "oem", Type: TypeObject, Description: "Vendor OEM block, kept as reported"},
+
+	// Switches and NICs, read by cabling inference
+	{Key: "lldp_neighbors", Type: TypeArray, Description: "LLDP neighbors the device's ports see: port, macAddress, chassisId, portId, portIdSubtype, systemName, managementAddress", DeviceTypes: append([]string{"Switch"}, nicTypes...)},
+	{Key: "chassis_id", Type: TypeString, Description: "LLDP chassis ID the switch advertises", DeviceTypes: []string{"Switch"}},
+	{Key: "system_name", Type: TypeString, Description: "LLDP system name the switch advertises", DeviceTypes: []string{"Switch"}},
+	{Key: "mac_table", Type: TypeArray, Description: "MAC addresses the switch's ports learned: port, macAddress, vlan", DeviceTypes: []string{"Switch"}},
+
+	// Power and cooling
+	{Key: "equipment_type", Type: TypeString, Description: "Redfish EquipmentType of a PDU or CDU", DeviceTypes: []string{"PDU", "CDU"}},
+	{Key: "circuit_type", Type: TypeString, Description: "Redfish CircuitType, e.g. \"Branch\"", DeviceTypes: powerTypes},
+	{Key: "breaker_state", Type: TypeString, Description: "State of the circuit breaker, e.g. \"Normal\" or \"Tripped\"", DeviceTypes: powerTypes},
+	{Key: "outlet_type", Type: TypeString, Description: "Socket type of the outlet, e.g. \"C13\"", DeviceTypes: []string{"PDUOutlet"}},
+	{Key: "user_label", Type: TypeString, Description: "Label the operator gave the outlet", DeviceTypes: []string{"PDUOutlet"}},
+	{Key: "phase_wiring_type", Type: TypeString, Description: "Phase wiring of the circuit, e.g. \"OnePhase3Wire\"", DeviceTypes: powerTypes},
+	{Key: "nominal_voltage", Type: TypeString, Description: "Redfish NominalVoltage, e.g. \"AC200To240V\"", DeviceTypes: powerTypes},
+	{Key: "rated_current_amps", Type: TypeNumber, Unit: "A", Description: "Rated current of the circuit", DeviceTypes: powerTypes},
+	{Key: "power_supply_type", Type: TypeString, Description: "\"AC\" or \"DC\"", DeviceTypes: []string{"PowerSupply"}},
+	{Key: "power_capacity_watts", Type: TypeNumber, Unit: "W", Description: "Rated output of the power supply, or the node's power capacity", DeviceTypes: []string{"PowerSupply", "Node"}},
+	{Key: "psu_rated_watts", Type: TypeNumber, Unit: "W", Description: "Total rated output of the node's power supplies", DeviceTypes: []string{"Node"}},
+	{Key: "power_allocated_watts", Type: TypeNumber, Unit: "W", Description: "Power the BMC has allocated to the node", DeviceTypes: []string{"Node"}},
+	{Key: "power_limit_watts", Type: TypeNumber, Unit: "W", Description: "Power limit set on the node", DeviceTypes: []string{"Node"}},
+	{Key: "power_limit_min_watts", Type: TypeNumber, Unit: "W", Description: "Lowest power limit the node accepts", DeviceTypes: []string{"Node"}},
+	{Key: "power_limit_max_watts", Type: TypeNumber, Unit: "W", Description: "Highest power limit the node accepts", DeviceTypes: []string{"Node"}},
+	{Key: "power_limit_enabled", Type: TypeBoolean, Description: "Whether the node's power limit is enforced", DeviceTypes: []string{"Node"}},
+	{Key: "power_consumed_watts", Type: TypeNumber, Unit: "W", Description: "Power the node drew when the snapshot was taken", DeviceTypes: []string{"Node"}},
+	{Key: "power_max_consumed_watts", Type: TypeNumber, Unit: "W", Description: "Most power the node was measured drawing", DeviceTypes: []string{"Node"}},
+	{Key: "power_budget_watts", Type: TypeNumber, Unit: "W", Description: "Power the rack is provisioned for, set by the site", DeviceTypes: []string{"Rack"}},
+	{Key: "coolant_type", Type: TypeString, Description: "Coolant of the CDU or loop, e.g. \"Water\"", DeviceTypes: []string{"CDU", "CoolingLoop"}},
+	{Key: "pump_type", Type: TypeString, Description: "Redfish PumpType", DeviceTypes: []string{"Pump"}},
+	{Key: "supply_equipment", Type: TypeArray, Description: "Equipment that supplies the cooling loop", DeviceTypes: []string{"CoolingLoop"}},
+	{Key: "consuming_equipment", Type: TypeArray, Description: "Equipment the cooling loop serves", DeviceTypes: []string{"CoolingLoop"}},
+
+	// Composability
+	{Key: "block_types", Type: TypeArray, Description: "Redfish ResourceBlockType of the block", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "composition_state", Type: TypeString, Description: "Redfish CompositionState, e.g. \"Unused\" or \"Composed\"", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "reserved", Type: TypeBoolean, Description: "Whether the block is reserved for a composition", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "sharing_capable", Type: TypeBoolean, Description: "Whether the block can be shared by compositions", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "number_of_compositions", Type: TypeInteger, Description: "Compositions the block is part of", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "zones", Type: TypeArray, Description: "Resource zones the block belongs to", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "composed_systems", Type: TypeArray, Description: "Redfish URIs of the systems composed from the block", DeviceTypes: []string{"ResourceBlock"}},
+	{Key: "contained_systems", Type: TypeArray, Description: "Redfish URIs of the systems the block contains", DeviceTypes: []string{"ResourceBlock"}},
+}
@@ -0,0 +1,289 @@
+// Package properties describes the keys of the device Properties map: the
+// type of each value, its unit, what it means and the device types that
+// carry it. The collector, plugins and manifests all write properties; the
+// registry keeps them consistent by documenting the known keys, checking
+// their values and telling apart keys nobody has described.
+//
+// Unknown keys are allowed, so that new sources are not rejected, but they
+// show in the property usage report until registered. Sites add their own
+// keys with Register or LoadFile.
+package properties
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Value types, named as in JSON Schema.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+)
+
+// Types lists the value types a schema may declare.
+var Types = []string{TypeString, TypeInteger, TypeNumber, TypeBoolean, TypeObject, TypeArray}
+
+// VendorPrefixes are the prefixes the collector stores vendor OEM attributes
+// under. Their keys vary by vendor and firmware and are not registered one
+// by one.
+var VendorPrefixes = []string{"dell_", "lenovo_", "supermicro_chassis_", "supermicro_"}
+
+// Schema describes one property key.
+type Schema struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	// Unit is the unit of a numeric value, e.g. "bytes", "MiB" or "W".
+	Unit        string `json:"unit,omitempty"`
+	Description string `json:"description"`
+	// DeviceTypes lists the device types that carry the property; empty
+	// means any.
+	DeviceTypes []string `json:"deviceTypes,omitempty"`
+}
+
+// AppliesTo reports whether devices of deviceType carry the property.
+func (s Schema) AppliesTo(deviceType string) bool {
+	if len(s.DeviceTypes) == 0 || deviceType == "" {
+		return true
+	}
+	for _, t := range s.DeviceTypes {
+		if strings.EqualFold(t, deviceType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error when raw is not a value of the schema's type. A
+// JSON null always passes.
+func (s Schema) Check(raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	var ok bool
+	switch s.Type {
+	case TypeString:
+		ok = raw[0] == '"'
+	case TypeObject:
+		ok = raw[0] == '{'
+	case TypeArray:
+		ok = raw[0] == '['
+	case TypeBoolean:
+		ok = bytes.Equal(raw, []byte("true")) || bytes.Equal(raw, []byte("false"))
+	case TypeNumber, TypeInteger:
+		var n json.Number
+		if json.Unmarshal(raw, &n) == nil && raw[0] != '"' {
+			ok = true
+			if s.Type == TypeInteger {
+				_, err := n.Int64()
+				ok = err == nil
+			}
+		}
+	default:
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("property %s must be %s %s, got %s", s.Key, article(s.Type), s.Type, kindOf(raw))
+	}
+	return nil
+}
+
+// Validate checks the values of the registered keys in props and returns one
+// error listing every mismatch, or nil. Unknown keys are not checked.
+func Validate(props map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var problems []string
+	for _, key := range keys {
+		if s, ok := Lookup(key); ok {
+			if err := s.Check(props[key]); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Property is a device property with its schema, for display.
+type Property struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+	// Display is the value with its unit, e.g. "65536 MiB".
+	Display string `json:"display"`
+	// Schema is nil for keys the registry does not describe.
+	Schema *Schema `json:"schema,omitempty"`
+	// Reported is the value as its source reported it, when it was
+	// converted to the canonical unit.
+	Reported string `json:"reported,omitempty"`
+	// Problem explains a value of the wrong type, or a key the registry does
+	// not expect on the device type.
+	Problem string `json:"problem,omitempty"`
+}
+
+// Describe pairs the properties of a device of deviceType with their
+// schemas, sorted by key.
+func Describe(deviceType string, props map[string]json.RawMessage) []Property {
+	described := make([]Property, 0, len(props))
+	for key, raw := range props {
+		p := Property{Key: key, Value: raw, Display: display(raw, "")}
+		if s, ok := Lookup(key); ok {
+			p.Schema = &s
+			p.Display = display(raw, s.Unit)
+			if err := s.Check(raw); err != nil {
+				p.Problem = err.Error()
+			} else if !s.AppliesTo(deviceType) {
+				p.Problem = fmt.Sprintf("property %s is not expected on %s devices", key, deviceType)
+			}
+		}
+		described = append(described, p)
+	}
+	sort.Slice(described, func(i, j int) bool { return described[i].Key < described[j].Key })
+	return described
+}
+
+// display renders a scalar value with its unit; other values are shown as
+// JSON.
+func display(raw json.RawMessage, unit string) string {
+	raw = bytes.TrimSpace(raw)
+	var str string
+	if len(raw) > 0 && raw[0] == '"' && json.Unmarshal(raw, &str) == nil {
+		return str
+	}
+	if unit == "" || len(raw) == 0 || kindOf(raw) != TypeNumber {
+		return string(raw)
+	}
+	if unit == "%" {
+		return string(raw) + unit
+	}
+	return string(raw) + " " + unit
+}
+
+// --- Registry ---
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Schema{}
+)
+
+func init() {
+	if err := Register(builtin...); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds schemas to the registry, replacing any with the same key.
+func Register(schemas ...Schema) error {
+	for _, s := range schemas {
+		if strings.TrimSpace(s.Key) == "" {
+			return errors.New("property schema without a key")
+		}
+		if !validType(s.Type) {
+			return fmt.Errorf("property %s: unknown type %q (want one of %s)", s.Key, s.Type, strings.Join(Types, ", "))
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, s := range schemas {
+		registry[s.Key] = s
+	}
+	return nil
+}
+
+// LoadFile registers the JSON array of Schema in path and returns how many
+// it held.
+func LoadFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read property schema: %w", err)
+	}
+	var schemas []Schema
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return 0, fmt.Errorf("failed to parse property schema %s: %w", path, err)
+	}
+	if err := Register(schemas...); err != nil {
+		return 0, fmt.Errorf("property schema %s: %w", path, err)
+	}
+	return len(schemas), nil
+}
+
+// Lookup returns the schema of key.
+func Lookup(key string) (Schema, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	s, ok := registry[key]
+	return s, ok
+}
+
+// List returns the schemas that apply to deviceType (all of them when it is
+// empty), sorted by key.
+func List(deviceType string) []Schema {
+	mu.RLock()
+	defer mu.RUnlock()
+	schemas := make([]Schema, 0, len(registry))
+	for _, s := range registry {
+		if s.AppliesTo(deviceType) {
+			schemas = append(schemas, s)
+		}
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Key < schemas[j].Key })
+	return schemas
+}
+
+// IsVendorKey reports whether key is a vendor OEM attribute.
+func IsVendorKey(key string) bool {
+	for _, prefix := range VendorPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func validType(t string) bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// kindOf names the JSON type of raw.
+func kindOf(raw json.RawMessage) string {
+	switch raw[0] {
+	case '"':
+		return TypeString
+	case '{':
+		return TypeObject
+	case '[':
+		return TypeArray
+	case 't', 'f':
+		return TypeBoolean
+	case 'n':
+		return "null"
+	}
+	return TypeNumber
+}
+
+func article(t string) string {
+	if t == TypeInteger || t == TypeObject || t == TypeArray {
+		return "an"
+	}
+	return "a"
+}
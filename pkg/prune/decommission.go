@@ -0,0 +1,25 @@
+package prune
+
+// DecommissionableTypes lists the device types that can be decommissioned.
+// Decommissioning retires the device and all its descendants.
+var DecommissionableTypes = map[string]bool{
+	"Node": true,
+	"Rack": true,
+}
+
+// DecommissionResult is the response of a decommission call.
+type DecommissionResult struct {
+	DryRun bool  `json:"dryRun"`
+	Root   Entry `json:"root"`
+	// Devices lists the root and its descendants.
+	Devices []Entry `json:"devices"`
+	// Retired counts the devices newly retired.
+	Retired int `json:"retired"`
+	// DetachedEndpoints lists the BMCEndpoints disabled because no active
+	// node they report remains.
+	DetachedEndpoints []string `json:"detachedEndpoints,omitempty"`
+	Errors            []string `json:"errors,omitempty"`
+	// PendingChange is the UID of the PendingChange holding the
+	// decommission for approval; nothing was retired yet.
+	PendingChange string `json:"pendingChange,omitempty"`
+}
@@ -0,0 +1,265 @@
+// Package prune selects devices for bulk deletion or retirement.
+package prune
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Prune actions.
+const (
+	// ActionDelete removes the matched devices from the inventory.
+	ActionDelete = "delete"
+	// ActionRetire keeps the matched devices but sets their phase to PhaseRetired.
+	ActionRetire = "retire"
+)
+
+// PhaseRetired is the status phase of a retired device. The reconciler
+// reinstates a retired device that is reported again.
+const PhaseRetired = "Retired"
+
+// IsRetired reports whether the device was retired or decommissioned. Retired
+// devices are left out of device lists and reports unless asked for.
+func IsRetired(d *device.Device) bool {
+	return d.Status.Phase == PhaseRetired
+}
+
+// Selector chooses devices. Every set field must match.
+type Selector struct {
+	DeviceType string
+	// Source is the BMC address that last reported the device.
+	Source string
+	// Endpoint is a BMCEndpoint name or UID; the server resolves it to Source.
+	Endpoint string
+	// ParentID matches the direct children of a device.
+	ParentID string
+	// MissingFor matches devices not seen for longer than the duration.
+	MissingFor time.Duration
+}
+
+// andSeparator matches the AND joining selector terms, in any case.
+var andSeparator = regexp.MustCompile(`(?i)\s+AND\s+`)
+
+// ParseSelector parses terms joined by "," or " AND " (in any case), e.g.
+// "deviceType=Drive AND missing>90d". Supported terms are deviceType=,
+// source=, endpoint=, parentID= and missing>DURATION, where DURATION is a
+// positive Go duration or number of days ("90d").
+func ParseSelector(s string) (Selector, error) {
+	var sel Selector
+	terms := strings.Split(andSeparator.ReplaceAllString(s, ","), ",")
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if value, ok := strings.CutPrefix(term, "missing>"); ok {
+			d, err := parseAge(value)
+			if err != nil {
+				return sel, fmt.Errorf("invalid missing duration %q: %w", value, err)
+			}
+			if d <= 0 {
+				return sel, fmt.Errorf("invalid missing duration %q: must be positive", value)
+			}
+			sel.MissingFor = d
+			continue
+		}
+		key, value, ok := strings.Cut(term, "=")
+		if !ok || value == "" {
+			return sel, fmt.Errorf("invalid selector term %q", term)
+		}
+		switch key {
+		case "deviceType":
+			sel.DeviceType = value
+		case "source":
+			sel.Source = value
+		case "endpoint":
+			sel.Endpoint = value
+		case "parentID":
+			sel.ParentID = value
+		default:
+			return sel, fmt.Errorf("unknown selector key %q", key)
+		}
+	}
+	if sel == (Selector{}) {
+		return sel, fmt.Errorf("selector must contain at least one term")
+	}
+	return sel, nil
+}
+
+// parseAge accepts a Go duration or whole days ("90d").
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// LastSeen returns when the device was last reported. Devices reconciled
+// before LastSeen was recorded fall back to their last update time.
+func LastSeen(d *device.Device) time.Time {
+	if d.Status.LastSeen != nil {
+		return *d.Status.LastSeen
+	}
+	return d.Metadata.UpdatedAt
+}
+
+// Matches reports whether the device is selected. Endpoint must already be
+// resolved into Source.
+func (s Selector) Matches(d *device.Device, now time.Time) bool {
+	switch {
+	case s.DeviceType != "" && d.Spec.DeviceType != s.DeviceType:
+		return false
+	case s.Source != "" && d.Status.Source != s.Source:
+		return false
+	case s.ParentID != "" && d.Spec.ParentID != s.ParentID:
+		return false
+	case s.MissingFor > 0 && now.Sub(LastSeen(d)) <= s.MissingFor:
+		return false
+	}
+	return true
+}
+
+// Select returns the selected devices, plus all their descendants when
+// includeChildren is set, sorted by UID.
+func Select(devices []*device.Device, sel Selector, includeChildren bool, now time.Time) []*device.Device {
+	selected := make(map[string]*device.Device)
+	for _, d := range devices {
+		if sel.Matches(d, now) {
+			selected[d.GetUID()] = d
+		}
+	}
+
+	if includeChildren {
+		addDescendants(devices, selected)
+	}
+
+	result := make([]*device.Device, 0, len(selected))
+	for _, d := range selected {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetUID() < result[j].GetUID() })
+	return result
+}
+
+// Subtree returns root and all its descendants, sorted by UID.
+func Subtree(devices []*device.Device, root *device.Device) []*device.Device {
+	return WithDescendants(devices, []*device.Device{root})
+}
+
+// WithDescendants returns the selected devices and all their descendants,
+// sorted by UID.
+func WithDescendants(devices, selected []*device.Device) []*device.Device {
+	set := make(map[string]*device.Device, len(selected))
+	for _, d := range selected {
+		set[d.GetUID()] = d
+	}
+	addDescendants(devices, set)
+	result := make([]*device.Device, 0, len(set))
+	for _, d := range set {
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].GetUID() < result[j].GetUID() })
+	return result
+}
+
+// addDescendants adds every descendant (by ParentID) of the selected devices.
+func addDescendants(devices []*device.Device, selected map[string]*device.Device) {
+	children := make(map[string][]*device.Device)
+	for _, d := range devices {
+		if d.Spec.ParentID != "" {
+			children[d.Spec.ParentID] = append(children[d.Spec.ParentID], d)
+		}
+	}
+	queue := make([]string, 0, len(selected))
+	for uid := range selected {
+		queue = append(queue, uid)
+	}
+	for len(queue) > 0 {
+		uid := queue[0]
+		queue = queue[1:]
+		for _, child := range children[uid] {
+			if _, ok := selected[child.GetUID()]; !ok {
+				selected[child.GetUID()] = child
+				queue = append(queue, child.GetUID())
+			}
+		}
+	}
+}
+
+// PreviewToken identifies a dry-run result. Applying a prune requires the
+// token of a preview with the same action and the same matched devices.
+func PreviewToken(action string, devices []*device.Device) string {
+	h := sha256.New()
+	h.Write([]byte(action))
+	for _, d := range devices {
+		h.Write([]byte{0})
+		h.Write([]byte(d.GetUID()))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Request is the body of a prune call.
+type Request struct {
+	// Selector uses the ParseSelector syntax.
+	Selector string `json:"selector"`
+	// Action is ActionDelete or ActionRetire.
+	Action          string `json:"action"`
+	IncludeChildren bool   `json:"includeChildren,omitempty"`
+	// DryRun only previews. Requests without PreviewToken are always dry runs.
+	DryRun bool `json:"dryRun"`
+	// PreviewToken is the token returned by the dry run being applied.
+	PreviewToken string `json:"previewToken,omitempty"`
+}
+
+// Entry describes one selected device.
+type Entry struct {
+	UID          string     `json:"uid"`
+	Name         string     `json:"name"`
+	DeviceType   string     `json:"deviceType"`
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	Source       string     `json:"source,omitempty"`
+	LastSeen     *time.Time `json:"lastSeen,omitempty"`
+}
+
+// Result is the response of a prune call.
+type Result struct {
+	DryRun       bool    `json:"dryRun"`
+	Action       string  `json:"action"`
+	Selector     string  `json:"selector"`
+	Matched      int     `json:"matched"`
+	PreviewToken string  `json:"previewToken"`
+	Devices      []Entry `json:"devices"`
+	// Applied counts the devices deleted or retired; Errors lists failures.
+	Applied int      `json:"applied"`
+	Errors  []string `json:"errors,omitempty"`
+	// Error explains a rejected apply.
+	Error string `json:"error,omitempty"`
+	// PendingChange is the UID of the PendingChange holding the prune for
+	// approval; nothing was applied yet.
+	PendingChange string `json:"pendingChange,omitempty"`
+}
+
+// NewEntry describes a selected device.
+func NewEntry(d *device.Device) Entry {
+	lastSeen := LastSeen(d)
+	return Entry{
+		UID:          d.GetUID(),
+		Name:         d.GetName(),
+		DeviceType:   d.Spec.DeviceType,
+		SerialNumber: d.Spec.SerialNumber,
+		Source:       d.Status.Source,
+		LastSeen:     &lastSeen,
+	}
+}
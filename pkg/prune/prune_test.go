@@ -0,0 +1,64 @@
+package prune
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseSelector parses the terms of a selector, joined by commas or AND
+// in any case, and rejects selectors that would match more than they say.
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     Selector
+		wantErr  string
+	}{
+		{selector: "deviceType=Drive", want: Selector{DeviceType: "Drive"}},
+		{
+			selector: "deviceType=Drive AND missing>90d",
+			want:     Selector{DeviceType: "Drive", MissingFor: 90 * 24 * time.Hour},
+		},
+		{
+			selector: "deviceType=Drive and missing>90d",
+			want:     Selector{DeviceType: "Drive", MissingFor: 90 * 24 * time.Hour},
+		},
+		{
+			selector: "deviceType=Drive And source=10.0.0.5",
+			want:     Selector{DeviceType: "Drive", Source: "10.0.0.5"},
+		},
+		{
+			selector: "source=10.0.0.5, endpoint=rack1-bmc,parentID=dev-1",
+			want:     Selector{Source: "10.0.0.5", Endpoint: "rack1-bmc", ParentID: "dev-1"},
+		},
+		{selector: "deviceType=Brand", want: Selector{DeviceType: "Brand"}},
+		{selector: "missing>36h", want: Selector{MissingFor: 36 * time.Hour}},
+		{selector: "missing>-5d", wantErr: "must be positive"},
+		{selector: "missing>0d", wantErr: "must be positive"},
+		{selector: "missing>-1h", wantErr: "must be positive"},
+		{selector: "missing>soon", wantErr: `invalid missing duration "soon"`},
+		{selector: "missing>5x", wantErr: `invalid missing duration "5x"`},
+		{selector: "deviceType", wantErr: `invalid selector term "deviceType"`},
+		{selector: "deviceType=", wantErr: `invalid selector term "deviceType="`},
+		{selector: "serial=X1", wantErr: `unknown selector key "serial"`},
+		{selector: " , AND ", wantErr: "at least one term"},
+		{selector: "", wantErr: "at least one term"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := ParseSelector(tt.selector)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSelector error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSelector = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokNumber
+	tokString
+	tokBool
+	tokOp
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos is the byte offset of the token in the expression.
+	pos int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex splits an expression into tokens, ending with tokEOF.
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case strings.HasPrefix(s[i:], "&&"):
+			tokens = append(tokens, token{tokAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, token{tokOr, "||", i})
+			i += 2
+		case strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="),
+			strings.HasPrefix(s[i:], "<="), strings.HasPrefix(s[i:], ">="):
+			tokens = append(tokens, token{tokOp, s[i : i+2], i})
+			i += 2
+		case c == '<' || c == '>' || c == '=':
+			tokens = append(tokens, token{tokOp, s[i : i+1], i})
+			i++
+		case c == '!':
+			tokens = append(tokens, token{tokNot, "!", i})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, token{tokString, s[i+1 : i+1+end], i})
+			i += end + 2
+		case c >= '0' && c <= '9' || (c == '-' || c == '.') && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9':
+			start := i
+			i++
+			for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == 'e' || s[i] == 'E' ||
+				(s[i] == '-' || s[i] == '+') && (s[i-1] == 'e' || s[i-1] == 'E')) {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, s[start:i], start})
+		case isNameStart(rune(c)):
+			start := i
+			for i < len(s) && isNamePart(rune(s[i])) {
+				i++
+			}
+			text := s[start:i]
+			switch strings.ToUpper(text) {
+			case "AND":
+				tokens = append(tokens, token{tokAnd, text, start})
+			case "OR":
+				tokens = append(tokens, token{tokOr, text, start})
+			case "NOT":
+				tokens = append(tokens, token{tokNot, text, start})
+			case "TRUE", "FALSE":
+				tokens = append(tokens, token{tokBool, text, start})
+			default:
+				tokens = append(tokens, token{tokName, text, start})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(s)}), nil
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || r < unicode.MaxASCII && unicode.IsLetter(r)
+}
+
+// isNamePart allows dots, for labels.KEY and properties.KEY, and dashes,
+// common in label keys.
+func isNamePart(r rune) bool {
+	return isNameStart(r) || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '/'
+}
@@ -0,0 +1,443 @@
+// Package query evaluates filter expressions against devices, such as
+// "CapacityMiB >= 65536" for large DIMMs or "deviceType == 'Node' AND
+// gpuCount == 0" for nodes without GPUs.
+//
+// An expression compares names with values using ==, =, !=, <, <=, > and
+// >=, and combines comparisons with AND, OR, NOT (or &&, ||, !) and
+// parentheses. A comparison has the name on the left and the value on the
+// right: a number, true, false, or a string, quoted or, as in prune
+// selectors, a bare word ("deviceType = Node").
+//
+// A name is looked up, ignoring case, underscores and dashes, among the
+// device's fields (name, uid, deviceType, manufacturer, partNumber,
+// serialNumber, parentID, phase, source, sourceEndpoint, sourceCollector),
+// its node summary (totalMemoryGiB, cpuSockets, cpuCores, cpuThreads,
+// gpuCount) and completeness score (completeness), then its properties, so
+// CapacityMiB finds capacity_mib.
+// "labels.KEY" and "properties.KEY" name a label or property explicitly.
+//
+// Values keep their type: numbers compare numerically (a string property
+// holding a number counts as one), strings lexically and booleans by
+// equality. A comparison with a name the device lacks, or between values of
+// different types, is false.
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// MaxLength bounds the length of an expression.
+const MaxLength = 1024
+
+// maxDepth bounds the nesting of an expression.
+const maxDepth = 32
+
+// Expr is a parsed expression.
+type Expr struct {
+	source string
+	root   node
+}
+
+// Parse parses an expression.
+func Parse(s string) (*Expr, error) {
+	if len(s) > MaxLength {
+		return nil, fmt.Errorf("expression longer than %d characters", MaxLength)
+	}
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+	}
+	return &Expr{source: s, root: root}, nil
+}
+
+// String returns the expression as written.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Matches reports whether the device satisfies the expression.
+func (e *Expr) Matches(d *device.Device) bool {
+	return e.root.eval(deviceEnv{d}).truthy()
+}
+
+// Filter returns the devices that satisfy the expression.
+func (e *Expr) Filter(devices []*device.Device) []*device.Device {
+	matched := make([]*device.Device, 0, len(devices))
+	for _, d := range devices {
+		if e.Matches(d) {
+			matched = append(matched, d)
+		}
+	}
+	return matched
+}
+
+// --- Values ---
+
+type valueKind int
+
+const (
+	kindMissing valueKind = iota
+	kindNumber
+	kindString
+	kindBool
+)
+
+type value struct {
+	kind valueKind
+	num  float64
+	str  string
+	b    bool
+}
+
+func (v value) truthy() bool {
+	return v.kind == kindBool && v.b
+}
+
+func boolValue(b bool) value {
+	return value{kind: kindBool, b: b}
+}
+
+// asNumber returns the value as a number, parsing strings that hold one.
+func (v value) asNumber() (float64, bool) {
+	switch v.kind {
+	case kindNumber:
+		return v.num, true
+	case kindString:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v.str), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// compare applies op to a and b; values of different types never compare.
+func compare(op string, a, b value) bool {
+	if a.kind == kindMissing || b.kind == kindMissing {
+		return false
+	}
+	var c int
+	switch {
+	case a.kind == kindNumber || b.kind == kindNumber:
+		x, ok1 := a.asNumber()
+		y, ok2 := b.asNumber()
+		if !ok1 || !ok2 {
+			return false
+		}
+		switch {
+		case x < y:
+			c = -1
+		case x > y:
+			c = 1
+		}
+	case a.kind == kindString && b.kind == kindString:
+		c = strings.Compare(a.str, b.str)
+	case a.kind == kindBool && b.kind == kindBool:
+		if op != "==" && op != "!=" {
+			return false
+		}
+		if a.b != b.b {
+			c = 1
+		}
+	default:
+		return false
+	}
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// fromJSON converts a property value; objects, arrays and null are missing.
+func fromJSON(raw json.RawMessage) value {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return value{}
+	}
+	switch v := v.(type) {
+	case float64:
+		return value{kind: kindNumber, num: v}
+	case string:
+		return value{kind: kindString, str: v}
+	case bool:
+		return boolValue(v)
+	}
+	return value{}
+}
+
+// --- Names ---
+
+// normalize folds case, underscores and dashes out of a name.
+func normalize(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+type deviceEnv struct {
+	d *device.Device
+}
+
+// lookup resolves a name on the device.
+func (env deviceEnv) lookup(name string) value {
+	d := env.d
+	if key, ok := cutPrefixFold(name, "labels."); ok {
+		if v, ok := d.Metadata.Labels[key]; ok {
+			return value{kind: kindString, str: v}
+		}
+		return value{}
+	}
+	if key, ok := cutPrefixFold(name, "properties."); ok {
+		return env.property(key)
+	}
+
+	str := func(s string) value { return value{kind: kindString, str: s} }
+	num := func(n float64) value { return value{kind: kindNumber, num: n} }
+	switch normalize(name) {
+	case "name":
+		return str(d.GetName())
+	case "uid":
+		return str(d.GetUID())
+	case "devicetype":
+		return str(d.Spec.DeviceType)
+	case "manufacturer":
+		return str(d.Spec.Manufacturer)
+	case "partnumber":
+		return str(d.Spec.PartNumber)
+	case "serialnumber":
+		return str(d.Spec.SerialNumber)
+	case "parentid":
+		return str(d.Spec.ParentID)
+	case "phase":
+		return str(d.Status.Phase)
+	case "source":
+		return str(d.Status.Source)
+	case "sourceendpoint":
+		return str(d.Status.SourceEndpoint)
+	case "sourcecollector":
+		return str(d.Status.SourceCollector)
+	case "completeness":
+		if c := d.Status.Completeness; c != nil {
+			return num(c.Score)
+		}
+		return value{}
+	}
+	if s := d.Status.Summary; s != nil {
+		switch normalize(name) {
+		case "totalmemorygib":
+			return num(s.TotalMemoryGiB)
+		case "cpusockets":
+			return num(float64(s.CPUSockets))
+		case "cpucores":
+			return num(float64(s.CPUCores))
+		case "cputhreads":
+			return num(float64(s.CPUThreads))
+		case "gpucount":
+			return num(float64(s.GPUCount))
+		}
+	}
+	return env.property(name)
+}
+
+// property resolves a property by its exact key, else by its normalized key.
+func (env deviceEnv) property(name string) value {
+	props := env.d.Spec.Properties
+	if raw, ok := props[name]; ok {
+		return fromJSON(raw)
+	}
+	want := normalize(name)
+	for key, raw := range props {
+		if normalize(key) == want {
+			return fromJSON(raw)
+		}
+	}
+	return value{}
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return "", false
+}
+
+// --- Syntax tree ---
+
+type node interface {
+	eval(env deviceEnv) value
+}
+
+type literal struct{ v value }
+
+func (n literal) eval(deviceEnv) value { return n.v }
+
+// ident is a name, looked up on the device.
+type ident struct{ name string }
+
+func (n ident) eval(env deviceEnv) value { return env.lookup(n.name) }
+
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (n comparison) eval(env deviceEnv) value {
+	return boolValue(compare(n.op, n.left.eval(env), n.right.eval(env)))
+}
+
+type and struct{ left, right node }
+
+func (n and) eval(env deviceEnv) value {
+	return boolValue(n.left.eval(env).truthy() && n.right.eval(env).truthy())
+}
+
+type or struct{ left, right node }
+
+func (n or) eval(env deviceEnv) value {
+	return boolValue(n.left.eval(env).truthy() || n.right.eval(env).truthy())
+}
+
+type not struct{ operand node }
+
+func (n not) eval(env deviceEnv) value {
+	return boolValue(!n.operand.eval(env).truthy())
+}
+
+// --- Parser ---
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseOr parses: and { OR and }
+func (p *parser) parseOr(depth int) (node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary { AND unary }
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+// parseUnary parses: NOT unary | "(" or ")" | comparison
+func (p *parser) parseUnary(depth int) (node, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("expression nested deeper than %d", maxDepth)
+	}
+	switch t := p.peek(); t.kind {
+	case tokNot:
+		p.next()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return not{operand}, nil
+	case tokLParen:
+		p.next()
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, fmt.Errorf("expected ) at offset %d, found %s", t.pos, t)
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses: name op value, or a lone name, which is true
+// when it holds the boolean true.
+func (p *parser) parseComparison() (node, error) {
+	t := p.next()
+	if t.kind != tokName {
+		return nil, fmt.Errorf("expected a name at offset %d, found %s", t.pos, t)
+	}
+	left := ident{t.text}
+	if p.peek().kind != tokOp {
+		return left, nil
+	}
+	op := p.next().text
+	if op == "=" {
+		op = "=="
+	}
+	right, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return comparison{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseValue() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokName, tokString:
+		return literal{value{kind: kindString, str: t.text}}, nil
+	case tokNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", t.text, t.pos)
+		}
+		return literal{value{kind: kindNumber, num: n}}, nil
+	case tokBool:
+		return literal{boolValue(strings.EqualFold(t.text, "true"))}, nil
+	}
+	return nil, fmt.Errorf("expected a value at offset %d, found %s", t.pos, t)
+}
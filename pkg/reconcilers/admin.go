@@ -0,0 +1,176 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It tracks what each reconciler is doing, for the admin endpoint, and lets
+// operators pause and resume reconcilers.
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/reconcilestatus"
+	"github.com/openchami/fabrica/pkg/reconcile"
+)
+
+// ErrUnknownReconciler is returned for a kind with no registered reconciler.
+var ErrUnknownReconciler = errors.New("no reconciler is registered for the kind")
+
+// trackers are the registered reconcilers by kind.
+var trackers = map[string]*tracked{}
+
+// tracked records what a reconciler does, and holds back the resources it is
+// asked to reconcile while disabled.
+type tracked struct {
+	inner   reconcile.Reconciler
+	requeue func(uid string, after time.Duration)
+
+	mu             sync.Mutex
+	enabled        bool
+	reconciled     int64
+	failed         int64
+	paused         map[string]bool
+	inFlight       map[string]time.Time
+	lastReconciled time.Time
+	lastError      *reconcilestatus.LastError
+}
+
+// track registers inner as the reconciler of its kind.
+func track(inner reconcile.Reconciler, requeue func(uid string, after time.Duration)) *tracked {
+	t := &tracked{inner: inner, requeue: requeue, enabled: true, paused: map[string]bool{}, inFlight: map[string]time.Time{}}
+	trackers[inner.GetResourceKind()] = t
+	return t
+}
+
+// GetResourceKind implements reconcile.Reconciler.
+func (t *tracked) GetResourceKind() string {
+	return t.inner.GetResourceKind()
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (t *tracked) Reconcile(ctx context.Context, resource interface{}) (reconcile.Result, error) {
+	uid := resourceUID(resource)
+	t.mu.Lock()
+	if !t.enabled {
+		t.paused[uid] = true
+		t.mu.Unlock()
+		return reconcile.Result{}, nil
+	}
+	t.inFlight[uid] = time.Now()
+	t.mu.Unlock()
+
+	result, err := t.inner.Reconcile(ctx, resource)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.inFlight, uid)
+	t.lastReconciled = time.Now()
+	if err != nil {
+		t.failed++
+		t.lastError = &reconcilestatus.LastError{UID: uid, Message: err.Error(), At: t.lastReconciled}
+	} else {
+		t.reconciled++
+	}
+	return result, err
+}
+
+// setEnabled pauses or resumes the reconciler. Resuming requeues the
+// resources that changed while it was paused.
+func (t *tracked) setEnabled(enabled bool) {
+	t.mu.Lock()
+	t.enabled = enabled
+	var paused []string
+	if enabled {
+		for uid := range t.paused {
+			paused = append(paused, uid)
+		}
+		t.paused = map[string]bool{}
+	}
+	t.mu.Unlock()
+	for _, uid := range paused {
+		t.requeue(uid, 0)
+	}
+}
+
+// status reports what the reconciler has done.
+func (t *tracked) status() reconcilestatus.Reconciler {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := reconcilestatus.Reconciler{
+		Kind:       t.inner.GetResourceKind(),
+		Enabled:    t.enabled,
+		Reconciled: t.reconciled,
+		Failed:     t.failed,
+		Paused:     len(t.paused),
+	}
+	for uid, since := range t.inFlight {
+		s.InFlight = append(s.InFlight, reconcilestatus.InFlight{UID: uid, Since: since})
+	}
+	sort.Slice(s.InFlight, func(i, j int) bool { return s.InFlight[i].Since.Before(s.InFlight[j].Since) })
+	if !t.lastReconciled.IsZero() {
+		last := t.lastReconciled
+		s.LastReconciled = &last
+	}
+	if t.lastError != nil {
+		lastError := *t.lastError
+		s.LastError = &lastError
+	}
+	return s
+}
+
+// resourceUID returns the UID of the raw resource the controller loaded.
+func resourceUID(resource interface{}) string {
+	var meta struct {
+		Metadata struct {
+			UID string `json:"uid"`
+		} `json:"metadata"`
+	}
+	if raw, ok := resource.(json.RawMessage); ok {
+		json.Unmarshal(raw, &meta)
+	}
+	return meta.Metadata.UID
+}
+
+// GetStatus reports what the reconcilers and the snapshot lanes are doing.
+func GetStatus() reconcilestatus.Status {
+	status := reconcilestatus.Status{Reconcilers: []reconcilestatus.Reconciler{}, SnapshotQueue: map[string]int{}, SnapshotWorkers: snapshotWorkers}
+	for _, kind := range GetRegisteredReconcilers() {
+		if t, ok := trackers[kind]; ok {
+			status.Reconcilers = append(status.Reconcilers, t.status())
+		}
+	}
+	if snapshotQueue != nil {
+		for lane, depth := range snapshotQueue.depth() {
+			status.SnapshotQueue[laneNames[lane]] = depth
+		}
+	}
+	return status
+}
+
+// SetEnabled pauses or resumes the reconciler of kind. A paused reconciler
+// leaves the resources it is asked to reconcile alone until it is resumed.
+func SetEnabled(kind string, enabled bool) (reconcilestatus.Reconciler, error) {
+	t, ok := trackers[kind]
+	if !ok {
+		return reconcilestatus.Reconciler{}, ErrUnknownReconciler
+	}
+	t.setEnabled(enabled)
+	return t.status(), nil
+}
+
+// Requeue asks the reconciler of kind to reconcile the resource again, as
+// after an operator changed what it should do with it.
+func Requeue(kind, uid string) error {
+	t, ok := trackers[kind]
+	if !ok {
+		return ErrUnknownReconciler
+	}
+	t.requeue(uid, 0)
+	return nil
+}
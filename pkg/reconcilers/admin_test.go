@@ -0,0 +1,67 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/reconcile"
+)
+
+// failingReconciler fails to reconcile resources named "bad".
+type failingReconciler struct{}
+
+func (failingReconciler) GetResourceKind() string { return "Test" }
+
+func (failingReconciler) Reconcile(ctx context.Context, resource interface{}) (reconcile.Result, error) {
+	if resourceUID(resource) == "bad" {
+		return reconcile.Result{}, errors.New("broken")
+	}
+	return reconcile.Result{}, nil
+}
+
+// TestTrackedReconciler counts reconciliations and keeps the last error, and
+// reconciles what changed while paused once resumed.
+func TestTrackedReconciler(t *testing.T) {
+	var requeued []string
+	tr := track(failingReconciler{}, func(uid string, after time.Duration) { requeued = append(requeued, uid) })
+	ctx := context.Background()
+	tr.Reconcile(ctx, json.RawMessage(`{"metadata":{"uid":"good"}}`))
+	tr.Reconcile(ctx, json.RawMessage(`{"metadata":{"uid":"bad"}}`))
+
+	if _, err := SetEnabled("Test", false); err != nil {
+		t.Fatal(err)
+	}
+	tr.Reconcile(ctx, json.RawMessage(`{"metadata":{"uid":"later"}}`))
+	status := tr.status()
+	if status.Reconciled != 1 || status.Failed != 1 || status.Paused != 1 || status.LastError == nil || status.LastError.UID != "bad" {
+		t.Fatalf("status %+v", status)
+	}
+
+	if _, err := SetEnabled("Test", true); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(requeued, []string{"later"}) {
+		t.Fatalf("requeued %v, want [later]", requeued)
+	}
+	if _, err := SetEnabled("Missing", true); !errors.Is(err, ErrUnknownReconciler) {
+		t.Fatalf("enabling an unknown kind: %v", err)
+	}
+}
+
+// TestConstructors checks that Register can build every generated reconciler.
+func TestConstructors(t *testing.T) {
+	for _, kind := range GetRegisteredReconcilers() {
+		construct, ok := constructors[kind]
+		if !ok {
+			t.Errorf("no constructor for the %s reconciler", kind)
+			continue
+		}
+		if got := construct(nil, nil).GetResourceKind(); got != kind {
+			t.Errorf("the %s constructor built a %s reconciler", kind, got)
+		}
+	}
+}
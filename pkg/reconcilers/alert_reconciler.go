@@ -0,0 +1,24 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for Alert.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/example/inventory-v3/pkg/resources/alert"
+)
+
+// reconcileAlert marks the alert ready. The server's alert evaluator owns
+// the alert's state; an alert created through the API starts firing.
+func (r *AlertReconciler) reconcileAlert(ctx context.Context, res *alert.Alert) error {
+	if res.Status.State == "" {
+		res.Status.State = alert.StateFiring
+		res.Status.FiredAt = res.Metadata.CreatedAt
+	}
+	res.Status.Ready = true
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for BMCEndpoint.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// reconcileBMCEndpoint validates the endpoint's collection settings.
+// The collector reads the endpoint's profile and protocol when a run does not
+// specify them, and records each collection's outcome and the protocol it
+// detected; failing endpoints are not ready.
+func (r *BMCEndpointReconciler) reconcileBMCEndpoint(ctx context.Context, res *bmcendpoint.BMCEndpoint) error {
+	if err := res.Validate(ctx); err != nil {
+		res.Status.Phase = "Error"
+		res.Status.Message = fmt.Sprintf("Invalid collection settings: %v", err)
+		res.Status.Ready = false
+		return nil
+	}
+
+	if res.Spec.Disabled {
+		res.Status.Phase = "Disabled"
+		res.Status.Message = fmt.Sprintf("Endpoint %s is disabled and will not be collected.", res.Spec.Address)
+		res.Status.Ready = false
+		return nil
+	}
+
+	if res.Spec.Protocol == bmcendpoint.ProtocolNone {
+		res.Status.Phase = "Unmanaged"
+		res.Status.Message = fmt.Sprintf("Endpoint %s has no management protocol and will not be collected.", res.Spec.Address)
+		res.Status.Ready = false
+		return nil
+	}
+
+	profile := res.Spec.Profile
+	if profile == "" {
+		profile = bmcendpoint.ProfileFull
+	}
+	if res.Status.ConsecutiveFailures > 0 {
+		res.Status.Phase = "Failing"
+		res.Status.Message = fmt.Sprintf("The last %d collections from %s failed: %s", res.Status.ConsecutiveFailures, res.Spec.Address, res.Status.LastError)
+		res.Status.Ready = false
+		return nil
+	}
+
+	res.Status.Phase = "Ready"
+	protocol := res.Spec.Protocol
+	switch {
+	case protocol != "":
+	case res.Status.DetectedProtocol != "":
+		protocol = res.Status.DetectedProtocol + " (detected)"
+	default:
+		order := res.Spec.ProbeOrder
+		if len(order) == 0 {
+			order = bmcendpoint.DefaultProbeOrder
+		}
+		protocol = fmt.Sprintf("the first of %v it answers", order)
+	}
+	res.Status.Message = fmt.Sprintf("Endpoint %s will be collected with the %s profile over %s.", res.Spec.Address, profile, protocol)
+	res.Status.Ready = true
+	return nil
+}
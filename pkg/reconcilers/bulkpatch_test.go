@@ -0,0 +1,47 @@
+package reconcilers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/pkg/bulkpatch"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// TestBulkPatchedPropertiesKept bulk patches an asset tag and a rack onto a
+// discovered node, and checks that rediscovery keeps them while replacing
+// what the BMC reports.
+func TestBulkPatchedPropertiesKept(t *testing.T) {
+	client, r, payload := discoverFixture(t, contract.Fixtures[0], "bmc-a")
+
+	var node *device.Device
+	for _, dev := range client.devices {
+		if dev.Spec.DeviceType == "Node" {
+			node = dev
+			break
+		}
+	}
+	if node == nil {
+		t.Fatal("no node was discovered")
+	}
+	row := bulkpatch.Row{UID: node.GetUID(), Properties: map[string]json.RawMessage{
+		"asset_tag": json.RawMessage(`"AT-0042"`),
+		"rack":      json.RawMessage(`"x1000"`),
+	}}
+	if _, err := bulkpatch.Apply(node, row); err != nil {
+		t.Fatal(err)
+	}
+
+	reconcileFrom(t, r, "again", "bmc-a", payload)
+	node = client.devices[node.GetUID()]
+	for key, want := range map[string]string{"asset_tag": "AT-0042", "rack": "x1000"} {
+		var got string
+		if !node.Spec.GetProperty(key, &got) || got != want {
+			t.Errorf("%s after rediscovery is %q, want %q", key, got, want)
+		}
+	}
+	if !node.Spec.GetProperty("redfish_uri", new(string)) {
+		t.Error("rediscovery lost the reported redfish_uri")
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for Connection.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/resources/connection"
+)
+
+// reconcileConnection describes the cable. Cabling inference marks the
+// connections it no longer finds stale, and those recorded by hand that it
+// finds contradicted miscabled; other manual connections stay active.
+func (r *ConnectionReconciler) reconcileConnection(ctx context.Context, res *connection.Connection) error {
+	if res.Status.Phase == "" || res.Spec.Method == connection.MethodManual && res.Status.Phase != connection.PhaseMiscabled {
+		res.Status.Phase = connection.PhaseActive
+	}
+	nic := res.Spec.NICID
+	if nic == "" {
+		nic = "unknown NIC"
+	}
+	if res.Spec.MACAddress != "" {
+		nic += " (" + res.Spec.MACAddress + ")"
+	}
+	res.Status.Message = fmt.Sprintf("Port %s of switch %s is cabled to %s, found by %s.", res.Spec.SwitchPort, res.Spec.SwitchID, nic, res.Spec.Method)
+	switch res.Status.Phase {
+	case connection.PhaseStale:
+		res.Status.Message = fmt.Sprintf("Port %s of switch %s was last found cabled to %s by %s.", res.Spec.SwitchPort, res.Spec.SwitchID, nic, res.Spec.Method)
+	case connection.PhaseMiscabled:
+		res.Status.Message = fmt.Sprintf("Port %s of switch %s should be cabled to %s, but %s.", res.Spec.SwitchPort, res.Spec.SwitchID, nic, res.Status.Found)
+	}
+	res.Status.Ready = res.Status.Phase == connection.PhaseActive
+	return nil
+}
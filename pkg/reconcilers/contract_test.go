@@ -0,0 +1,176 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// memoryClient is a reconcile.ClientInterface keeping devices in memory; it
+// records other resources created without storing them.
+type memoryClient struct {
+	devices map[string]*device.Device
+	others  []interface{}
+}
+
+func (c *memoryClient) Get(ctx context.Context, kind, uid string) (interface{}, error) {
+	if dev, ok := c.devices[uid]; ok && kind == "Device" {
+		return dev, nil
+	}
+	return nil, fmt.Errorf("%s %s not found", kind, uid)
+}
+
+func (c *memoryClient) List(ctx context.Context, kind string) ([]interface{}, error) {
+	var items []interface{}
+	if kind == "Device" {
+		for _, dev := range c.devices {
+			items = append(items, dev)
+		}
+	}
+	return items, nil
+}
+
+func (c *memoryClient) Update(ctx context.Context, resource interface{}) error {
+	if dev, ok := resource.(*device.Device); ok {
+		c.devices[dev.GetUID()] = dev
+	}
+	return nil
+}
+
+func (c *memoryClient) Create(ctx context.Context, resource interface{}) error {
+	if dev, ok := resource.(*device.Device); ok {
+		c.devices[dev.GetUID()] = dev
+		return nil
+	}
+	c.others = append(c.others, resource)
+	return nil
+}
+
+func (c *memoryClient) Delete(ctx context.Context, kind, uid string) error {
+	delete(c.devices, uid)
+	return nil
+}
+
+// TestGoldenPayloads reconciles the collector's golden payloads into an
+// empty inventory, checking the parts of RawData the reconciler relies on:
+// every device is created under its redfish_uri, linked to its parent by
+// serial number and, reconciled again, updated rather than duplicated.
+func TestGoldenPayloads(t *testing.T) {
+	for _, fixture := range contract.Fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			payload, err := contract.Payload(fixture.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var specs []device.DeviceSpec
+			if err := json.Unmarshal(payload, &specs); err != nil {
+				t.Fatalf("golden payload does not parse as device specs: %v", err)
+			}
+			bySerial := map[string]device.DeviceSpec{}
+			for _, spec := range specs {
+				if _, err := getRedfishURI(spec); err != nil {
+					t.Fatalf("%s device %s: %v", spec.DeviceType, spec.SerialNumber, err)
+				}
+				bySerial[spec.SerialNumber] = spec
+			}
+
+			client, r := newSnapshotReconciler()
+			reconcileGolden(t, r, fixture.Name, payload)
+			if len(client.devices) != len(specs) {
+				t.Fatalf("reconciled %d devices from a payload of %d", len(client.devices), len(specs))
+			}
+
+			byURI := map[string]*device.Device{}
+			for _, dev := range client.devices {
+				uri, err := getRedfishURI(dev.Spec)
+				if err != nil {
+					t.Fatalf("device %s: %v", dev.GetUID(), err)
+				}
+				byURI[uri] = dev
+			}
+			for _, spec := range specs {
+				uri, _ := getRedfishURI(spec)
+				dev, ok := byURI[uri]
+				if !ok {
+					t.Errorf("no device created for %s", uri)
+					continue
+				}
+				if dev.Spec.DeviceType != spec.DeviceType || dev.Spec.SerialNumber != spec.SerialNumber {
+					t.Errorf("%s: got %s %s, want %s %s", uri, dev.Spec.DeviceType, dev.Spec.SerialNumber, spec.DeviceType, spec.SerialNumber)
+				}
+				if spec.ParentSerialNumber == "" {
+					// The fixtures' nodes hold every other device
+					if spec.DeviceType != "Node" {
+						t.Errorf("%s: %s has no parent serial number", uri, spec.DeviceType)
+					}
+					continue
+				}
+				if _, ok := bySerial[spec.ParentSerialNumber]; !ok {
+					t.Errorf("%s: parent serial %s is not in the payload", uri, spec.ParentSerialNumber)
+					continue
+				}
+				parent, ok := client.devices[dev.Spec.ParentID]
+				if !ok || parent.Spec.SerialNumber != spec.ParentSerialNumber {
+					t.Errorf("%s: not linked to its parent %s", uri, spec.ParentSerialNumber)
+				}
+			}
+			for _, dev := range client.devices {
+				if dev.Spec.DeviceType == "Node" && dev.Status.Summary == nil {
+					t.Errorf("node %s has no summary", dev.Spec.SerialNumber)
+				}
+			}
+
+			reconcileGolden(t, r, fixture.Name, payload)
+			if len(client.devices) != len(specs) {
+				t.Errorf("reconciling again left %d devices, want %d", len(client.devices), len(specs))
+			}
+		})
+	}
+}
+
+// newSnapshotReconciler returns a snapshot reconciler of an empty inventory,
+// and the client holding it.
+func newSnapshotReconciler() (*memoryClient, *DiscoverySnapshotReconciler) {
+	client := &memoryClient{devices: map[string]*device.Device{}}
+	return client, NewDefaultDiscoverySnapshotReconciler(client, nil)
+}
+
+// discoverFixture reconciles the golden payload of fixture, as reported by
+// the BMC at address, into an empty inventory. It returns the client holding
+// the inventory, the reconciler and the payload.
+func discoverFixture(t *testing.T, fixture contract.Fixture, address string) (*memoryClient, *DiscoverySnapshotReconciler, []byte) {
+	t.Helper()
+	payload, err := contract.Payload(fixture.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, r := newSnapshotReconciler()
+	reconcileFrom(t, r, fixture.Name, address, payload)
+	return client, r, payload
+}
+
+// reconcileGolden reconciles a snapshot of payload and checks it completed.
+func reconcileGolden(t *testing.T, r *DiscoverySnapshotReconciler, name string, payload []byte) {
+	t.Helper()
+	reconcileFrom(t, r, name, "bmc", payload)
+}
+
+// reconcileFrom is reconcileGolden for a snapshot of the BMC at address.
+func reconcileFrom(t *testing.T, r *DiscoverySnapshotReconciler, name, address string, payload []byte) {
+	t.Helper()
+	snapshot := &discoverysnapshot.DiscoverySnapshot{
+		Spec: discoverysnapshot.DiscoverySnapshotSpec{RawData: json.RawMessage(payload), BMCAddress: address},
+	}
+	snapshot.Metadata.Name = name
+	if err := r.reconcileDiscoverySnapshot(context.Background(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.Phase != "Completed" {
+		t.Fatalf("snapshot ended %s: %s", snapshot.Status.Phase, snapshot.Status.Message)
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It contains the device lookups used by the DiscoverySnapshot reconciler.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/identity"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// deviceLookup finds existing devices by redfish_uri (the get-or-create key),
+// by serial number (the parent link key), and by name. URIs are compared in
+// their canonical form, so a device keeps its record when firmware changes
+// how its URI is spelled.
+//
+// Every BMC reports the same URIs ("/Systems/1"), so a lookup is scoped to
+// the source (BMC address) of the snapshot being reconciled: ByURI finds only
+// the devices last reported by that source, or devices recorded without a
+// source, which the first BMC reporting them adopts.
+//
+// A lookup that fails returns its error rather than reporting the device
+// missing, which would have the reconciler create a duplicate of it.
+type deviceLookup interface {
+	ByURI(ctx context.Context, uri string) (*device.Device, bool, error)
+	BySerial(ctx context.Context, serial string) (*device.Device, bool, error)
+	// ByCompositeKey finds a serial-less component by identity.CompositeKey.
+	ByCompositeKey(ctx context.Context, key string) (*device.Device, bool, error)
+	// ByName returns the devices named name. Devices given the name during
+	// this reconcile may be missing.
+	ByName(ctx context.Context, name string) ([]*device.Device, error)
+	// BySource returns the devices last reported by the BMC at address.
+	BySource(ctx context.Context, address string) ([]*device.Device, error)
+	// Add records a device created during the reconcile.
+	Add(dev *device.Device)
+}
+
+// indexedClient is implemented by storage clients whose backend can query
+// devices directly.
+type indexedClient interface {
+	DeviceIndex() (storage.DeviceIndex, bool)
+}
+
+// newDeviceLookup uses the backend's device index when it has one, and
+// otherwise loads every device into maps.
+func (r *DiscoverySnapshotReconciler) newDeviceLookup(ctx context.Context, source string) (deviceLookup, error) {
+	if client, ok := r.Client.(indexedClient); ok {
+		if index, ok := client.DeviceIndex(); ok {
+			return &indexDeviceLookup{source: source, index: index, byURI: map[string]*device.Device{}, bySerial: map[string]*device.Device{}, byKey: map[string]*device.Device{}}, nil
+		}
+	}
+
+	byURI, err := r.buildDeviceMapByURI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bySerial, err := r.buildDeviceMapBySerial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l := &mapDeviceLookup{source: source, byURI: byURI, bySerial: bySerial, byKey: map[string]*device.Device{}, byName: map[string][]*device.Device{}}
+	for _, dev := range byURI {
+		l.addName(dev)
+		if key := identity.CompositeKey(dev.Spec); key != "" {
+			l.byKey[key] = dev
+		}
+	}
+	for _, dev := range bySerial {
+		if key, err := deviceKey(dev); err != nil || byURI[key] != dev {
+			l.addName(dev)
+		}
+	}
+	return l, nil
+}
+
+// mapDeviceLookup serves lookups from maps of the whole inventory.
+type mapDeviceLookup struct {
+	source string
+	// byURI is keyed by deviceKey.
+	byURI    map[string]*device.Device
+	bySerial map[string]*device.Device
+	byKey    map[string]*device.Device
+	// byName may hold devices renamed since; ByName checks.
+	byName map[string][]*device.Device
+}
+
+func (l *mapDeviceLookup) ByURI(ctx context.Context, uri string) (*device.Device, bool, error) {
+	uri = identity.CanonicalURI(uri)
+	if dev, ok := l.byURI[sourceKey(l.source, uri)]; ok {
+		return dev, true, nil
+	}
+	dev, ok := l.byURI[sourceKey("", uri)]
+	return dev, ok, nil
+}
+
+func (l *mapDeviceLookup) BySerial(ctx context.Context, serial string) (*device.Device, bool, error) {
+	dev, ok := l.bySerial[serial]
+	return dev, ok, nil
+}
+
+func (l *mapDeviceLookup) ByCompositeKey(ctx context.Context, key string) (*device.Device, bool, error) {
+	dev, ok := l.byKey[key]
+	return dev, ok, nil
+}
+
+func (l *mapDeviceLookup) ByName(ctx context.Context, name string) ([]*device.Device, error) {
+	var devices []*device.Device
+	for _, dev := range l.byName[name] {
+		if dev.GetName() == name {
+			devices = append(devices, dev)
+		}
+	}
+	return devices, nil
+}
+
+func (l *mapDeviceLookup) BySource(ctx context.Context, address string) ([]*device.Device, error) {
+	var devices []*device.Device
+	for _, dev := range l.byURI {
+		if dev.Status.Source == address {
+			devices = append(devices, dev)
+		}
+	}
+	return devices, nil
+}
+
+func (l *mapDeviceLookup) Add(dev *device.Device) {
+	if key, err := deviceKey(dev); err == nil {
+		l.byURI[key] = dev
+	}
+	if dev.Spec.SerialNumber != "" {
+		l.bySerial[dev.Spec.SerialNumber] = dev
+	}
+	if key := identity.CompositeKey(dev.Spec); key != "" {
+		l.byKey[key] = dev
+	}
+	l.addName(dev)
+}
+
+func (l *mapDeviceLookup) addName(dev *device.Device) {
+	for _, known := range l.byName[dev.GetName()] {
+		if known == dev {
+			return
+		}
+	}
+	l.byName[dev.GetName()] = append(l.byName[dev.GetName()], dev)
+}
+
+// indexDeviceLookup queries the storage index and caches the results, so a
+// device is read at most once per reconcile and sees this reconcile's changes.
+type indexDeviceLookup struct {
+	source string
+	index  storage.DeviceIndex
+	// byURI is keyed by deviceKey.
+	byURI    map[string]*device.Device
+	bySerial map[string]*device.Device
+	byKey    map[string]*device.Device
+}
+
+func (l *indexDeviceLookup) ByURI(ctx context.Context, uri string) (*device.Device, bool, error) {
+	canonical := identity.CanonicalURI(uri)
+	for _, source := range []string{l.source, ""} {
+		if dev, ok := l.byURI[sourceKey(source, canonical)]; ok {
+			return dev, true, nil
+		}
+	}
+	devices, err := l.index.FindDevicesByCanonicalURI(ctx, canonical)
+	if err == nil && len(devices) == 0 {
+		// Devices saved before canonical_uri was recorded
+		devices, err = l.index.FindDevicesByRedfishURI(ctx, uri)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up device by redfish_uri %s: %w", uri, err)
+	}
+	// Other BMCs' devices at the same URI are not this one's
+	var unsourced *device.Device
+	for _, dev := range devices {
+		switch dev.Status.Source {
+		case l.source:
+			l.Add(dev)
+			return dev, true, nil
+		case "":
+			if unsourced == nil {
+				unsourced = dev
+			}
+		}
+	}
+	if unsourced == nil {
+		return nil, false, nil
+	}
+	l.Add(unsourced)
+	return unsourced, true, nil
+}
+
+func (l *indexDeviceLookup) BySerial(ctx context.Context, serial string) (*device.Device, bool, error) {
+	if dev, ok := l.bySerial[serial]; ok {
+		return dev, true, nil
+	}
+	devices, err := l.index.FindDevicesBySerial(ctx, serial)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up device by serial %s: %w", serial, err)
+	}
+	if len(devices) == 0 {
+		return nil, false, nil
+	}
+	// Prefer a device already loaded under its URI so updates are shared.
+	dev := devices[0]
+	if key, err := deviceKey(dev); err == nil {
+		if cached, ok := l.byURI[key]; ok {
+			dev = cached
+		}
+	}
+	l.Add(dev)
+	return dev, true, nil
+}
+
+func (l *indexDeviceLookup) ByCompositeKey(ctx context.Context, key string) (*device.Device, bool, error) {
+	if dev, ok := l.byKey[key]; ok {
+		return dev, true, nil
+	}
+	devices, err := l.index.FindDevicesByCompositeKey(ctx, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up device by composite key %s: %w", key, err)
+	}
+	if len(devices) == 0 {
+		return nil, false, nil
+	}
+	// Prefer a device already loaded under its URI so updates are shared.
+	dev := devices[0]
+	if key, err := deviceKey(dev); err == nil {
+		if cached, ok := l.byURI[key]; ok {
+			dev = cached
+		}
+	}
+	l.Add(dev)
+	return dev, true, nil
+}
+
+func (l *indexDeviceLookup) ByName(ctx context.Context, name string) ([]*device.Device, error) {
+	found, err := l.index.FindDevicesByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up devices named %s: %w", name, err)
+	}
+	// Cached devices may have been renamed since
+	var devices []*device.Device
+	for _, dev := range found {
+		if key, err := deviceKey(dev); err == nil {
+			if cached, ok := l.byURI[key]; ok {
+				dev = cached
+			}
+		}
+		if dev.GetName() == name {
+			devices = append(devices, dev)
+		}
+	}
+	return devices, nil
+}
+
+func (l *indexDeviceLookup) BySource(ctx context.Context, address string) ([]*device.Device, error) {
+	found, err := l.index.FindDevicesBySource(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up devices reported by %s: %w", address, err)
+	}
+	// Prefer the devices already loaded, which carry this reconcile's changes.
+	devices := make([]*device.Device, 0, len(found))
+	for _, dev := range found {
+		if key, err := deviceKey(dev); err == nil {
+			if cached, ok := l.byURI[key]; ok {
+				dev = cached
+			}
+		}
+		devices = append(devices, dev)
+	}
+	return devices, nil
+}
+
+func (l *indexDeviceLookup) Add(dev *device.Device) {
+	if key, err := deviceKey(dev); err == nil {
+		l.byURI[key] = dev
+	}
+	if dev.Spec.SerialNumber != "" {
+		l.bySerial[dev.Spec.SerialNumber] = dev
+	}
+	if key := identity.CompositeKey(dev.Spec); key != "" {
+		l.byKey[key] = dev
+	}
+}
+
+// uriKey returns the key devices are looked up by: the canonical form of
+// their redfish_uri.
+func uriKey(spec device.DeviceSpec) (string, error) {
+	uri, err := getRedfishURI(spec)
+	if err != nil {
+		return "", err
+	}
+	return identity.CanonicalURI(uri), nil
+}
+
+// deviceKey returns the key a device is cached by in a lookup: its source
+// and the canonical form of its redfish_uri.
+func deviceKey(dev *device.Device) (string, error) {
+	uri, err := uriKey(dev.Spec)
+	if err != nil {
+		return "", err
+	}
+	return sourceKey(dev.Status.Source, uri), nil
+}
+
+// sourceKey joins a source and a canonical URI into a deviceKey.
+func sourceKey(source, uri string) string {
+	return source + " " + uri
+}
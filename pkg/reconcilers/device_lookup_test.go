@@ -0,0 +1,169 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/internal/storage"
+	"github.com/example/inventory-v3/pkg/identity"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// memoryIndex is a storage.DeviceIndex over a memoryClient's devices; while
+// err is set, every query fails with it.
+type memoryIndex struct {
+	client *memoryClient
+	err    error
+}
+
+func (x *memoryIndex) find(match func(*device.Device) bool) ([]*device.Device, error) {
+	if x.err != nil {
+		return nil, x.err
+	}
+	var devices []*device.Device
+	for _, dev := range x.client.devices {
+		if match(dev) {
+			devices = append(devices, dev)
+		}
+	}
+	return devices, nil
+}
+
+func (x *memoryIndex) property(key, value string) ([]*device.Device, error) {
+	return x.find(func(dev *device.Device) bool {
+		var got string
+		return dev.Spec.GetProperty(key, &got) && got == value
+	})
+}
+
+func (x *memoryIndex) FindDevicesBySerial(ctx context.Context, serial string) ([]*device.Device, error) {
+	return x.find(func(dev *device.Device) bool { return dev.Spec.SerialNumber == serial })
+}
+
+func (x *memoryIndex) FindDevicesByRedfishURI(ctx context.Context, uri string) ([]*device.Device, error) {
+	return x.property("redfish_uri", uri)
+}
+
+func (x *memoryIndex) FindDevicesByCanonicalURI(ctx context.Context, uri string) ([]*device.Device, error) {
+	return x.property(identity.PropertyCanonicalURI, uri)
+}
+
+func (x *memoryIndex) FindDevicesByCompositeKey(ctx context.Context, key string) ([]*device.Device, error) {
+	return x.property(identity.PropertyCompositeKey, key)
+}
+
+func (x *memoryIndex) FindDevicesByType(ctx context.Context, deviceType string) ([]*device.Device, error) {
+	return x.find(func(dev *device.Device) bool { return dev.Spec.DeviceType == deviceType })
+}
+
+func (x *memoryIndex) FindDevicesByName(ctx context.Context, name string) ([]*device.Device, error) {
+	return x.find(func(dev *device.Device) bool { return dev.GetName() == name })
+}
+
+func (x *memoryIndex) FindDevicesBySource(ctx context.Context, address string) ([]*device.Device, error) {
+	return x.find(func(dev *device.Device) bool { return dev.Status.Source == address })
+}
+
+func (x *memoryIndex) FindDevicesByMAC(ctx context.Context, mac string) ([]*device.Device, error) {
+	return x.find(func(dev *device.Device) bool { return false })
+}
+
+// indexedMemoryClient is a memoryClient whose devices are queried through a
+// memoryIndex, as those of a PostgreSQL backend are.
+type indexedMemoryClient struct {
+	*memoryClient
+	index *memoryIndex
+}
+
+func (c *indexedMemoryClient) DeviceIndex() (storage.DeviceIndex, bool) {
+	return c.index, true
+}
+
+func newIndexedMemoryClient() *indexedMemoryClient {
+	client := &memoryClient{devices: map[string]*device.Device{}}
+	return &indexedMemoryClient{memoryClient: client, index: &memoryIndex{client: client}}
+}
+
+// TestIndexDeviceLookup reconciles a golden payload twice through a device
+// index, which finds the devices of the first reconcile again.
+func TestIndexDeviceLookup(t *testing.T) {
+	payload, err := contract.Payload(contract.Fixtures[0].Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var specs []device.DeviceSpec
+	if err := json.Unmarshal(payload, &specs); err != nil {
+		t.Fatal(err)
+	}
+	client := newIndexedMemoryClient()
+	r := NewDefaultDiscoverySnapshotReconciler(client, nil)
+	reconcileGolden(t, r, "first", payload)
+	reconcileGolden(t, r, "again", payload)
+	if len(client.devices) != len(specs) {
+		t.Errorf("reconciled %d devices from a payload of %d", len(client.devices), len(specs))
+	}
+}
+
+// TestIndexDeviceLookupErrors fails the reconcile of a snapshot when the
+// index cannot be queried, rather than creating the devices it did not find.
+func TestIndexDeviceLookupErrors(t *testing.T) {
+	payload, err := contract.Payload(contract.Fixtures[0].Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newIndexedMemoryClient()
+	r := NewDefaultDiscoverySnapshotReconciler(client, nil)
+	reconcileGolden(t, r, "first", payload)
+	discovered := len(client.devices)
+
+	failure := errors.New("connection reset by peer")
+	client.index.err = failure
+	snapshot := &discoverysnapshot.DiscoverySnapshot{
+		Spec: discoverysnapshot.DiscoverySnapshotSpec{RawData: json.RawMessage(payload), BMCAddress: "bmc"},
+	}
+	snapshot.Metadata.Name = "again"
+	if err := r.reconcileDiscoverySnapshot(context.Background(), snapshot); !errors.Is(err, failure) {
+		t.Fatalf("reconcile error = %v, want %v", err, failure)
+	}
+	if snapshot.Status.Phase == "Completed" {
+		t.Error("snapshot completed although its devices could not be looked up")
+	}
+	if len(client.devices) != discovered {
+		t.Errorf("reconcile left %d devices, want the %d discovered", len(client.devices), discovered)
+	}
+
+	// Each lookup reports the failure, not a device missing
+	lookup := &indexDeviceLookup{source: "bmc", index: client.index, byURI: map[string]*device.Device{}, bySerial: map[string]*device.Device{}, byKey: map[string]*device.Device{}}
+	ctx := context.Background()
+	lookups := map[string]func() error{
+		"ByURI": func() error {
+			_, _, err := lookup.ByURI(ctx, "/redfish/v1/Systems/1")
+			return err
+		},
+		"BySerial": func() error {
+			_, _, err := lookup.BySerial(ctx, "GOLD0001")
+			return err
+		},
+		"ByCompositeKey": func() error {
+			_, _, err := lookup.ByCompositeKey(ctx, "key")
+			return err
+		},
+		"ByName": func() error {
+			_, err := lookup.ByName(ctx, "node")
+			return err
+		},
+		"BySource": func() error {
+			_, err := lookup.BySource(ctx, "bmc")
+			return err
+		},
+	}
+	for name, find := range lookups {
+		if err := find(); !errors.Is(err, failure) {
+			t.Errorf("%s error = %v, want %v", name, err, failure)
+		}
+	}
+}
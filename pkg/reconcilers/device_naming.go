@@ -0,0 +1,66 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It contains the device naming plug-in.
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/example/inventory-v3/pkg/naming"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// deviceNamer names the devices of every snapshot; see SetDeviceNamer.
+var deviceNamer, _ = naming.New(naming.StrategyURI, "")
+
+// SetDeviceNamer sets the strategy devices are named by. Devices are renamed
+// as snapshots report them. Call it before the reconcilers run.
+func SetDeviceNamer(n *naming.Namer) {
+	deviceNamer = n
+}
+
+// applyDeviceName names a device by the naming strategy. A name another
+// device has already is disambiguated with the device's UID.
+func applyDeviceName(ctx context.Context, pc *PluginContext, dev *device.Device) (bool, error) {
+	name := deviceNamer.Name(dev.Spec)
+	if name == "" {
+		return false, nil
+	}
+	taken, err := pc.nameTaken(ctx, name, dev)
+	if err != nil {
+		return false, err
+	}
+	if taken {
+		name = naming.Unique(name, dev.GetUID())
+	}
+	if name == dev.GetName() {
+		return false, nil
+	}
+	if pc.names == nil {
+		pc.names = make(map[string]string)
+	}
+	pc.names[name] = dev.GetUID()
+	dev.Metadata.Name = name
+	return true, nil
+}
+
+// nameTaken reports whether a device other than dev has name, or was given
+// it during this reconcile.
+func (pc *PluginContext) nameTaken(ctx context.Context, name string, dev *device.Device) (bool, error) {
+	if uid, ok := pc.names[name]; ok {
+		return uid != dev.GetUID(), nil
+	}
+	others, err := pc.lookup.ByName(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	for _, other := range others {
+		if other.GetUID() != dev.GetUID() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It contains the per-device-type plug-in registry used by the DiscoverySnapshot reconciler.
+package reconcilers
+
+import (
+	"context"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// DevicePluginFunc post-processes one device. It returns true when it changed
+// the device, in which case the reconciler saves it.
+type DevicePluginFunc func(ctx context.Context, pc *PluginContext, dev *device.Device) (bool, error)
+
+// DevicePlugin is a type-specific post-processing step run after Pass 1.
+type DevicePlugin struct {
+	// Name identifies the plug-in in logs.
+	Name string
+	// DeviceType selects the devices passed to Process; "" matches every type.
+	DeviceType string
+	Process    DevicePluginFunc
+}
+
+// PluginContext gives plug-ins the devices touched by the snapshot being reconciled.
+type PluginContext struct {
+	Snapshot *discoverysnapshot.DiscoverySnapshot
+	// Devices holds the snapshot's devices, keyed by redfish_uri.
+	Devices map[string]*device.Device
+
+	lookup deviceLookup
+	// names maps the names given during this reconcile to device UIDs.
+	names map[string]string
+}
+
+// BySerial finds any known device by serial number.
+func (pc *PluginContext) BySerial(ctx context.Context, serial string) (*device.Device, bool, error) {
+	return pc.lookup.BySerial(ctx, serial)
+}
+
+// Children returns the snapshot devices whose parent serial is serial.
+func (pc *PluginContext) Children(serial string) []*device.Device {
+	var children []*device.Device
+	if serial == "" {
+		return children
+	}
+	for _, dev := range pc.Devices {
+		if dev.Spec.ParentSerialNumber == serial {
+			children = append(children, dev)
+		}
+	}
+	return children
+}
+
+// devicePlugins holds the registered plug-ins, run in registration order.
+var devicePlugins []DevicePlugin
+
+// RegisterDevicePlugin adds a plug-in to the registry.
+func RegisterDevicePlugin(p DevicePlugin) {
+	devicePlugins = append(devicePlugins, p)
+}
+
+// runDevicePlugins applies every registered plug-in to the matching snapshot
+// devices and saves the ones that changed. It returns the number saved.
+func (r *DiscoverySnapshotReconciler) runDevicePlugins(ctx context.Context, pc *PluginContext) int {
+	changed := make(map[string]*device.Device)
+	for _, plugin := range devicePlugins {
+		for uri, dev := range pc.Devices {
+			if plugin.DeviceType != "" && plugin.DeviceType != dev.Spec.DeviceType {
+				continue
+			}
+			updated, err := plugin.Process(ctx, pc, dev)
+			if err != nil {
+				r.Logger.Errorf("Reconciling %s (Plug-ins): %s failed on %s: %v", snapshotLogName(pc.Snapshot), plugin.Name, uri, err)
+				continue
+			}
+			if updated {
+				changed[uri] = dev
+			}
+		}
+	}
+
+	saved := 0
+	for uri, dev := range changed {
+		dev.Metadata.UpdatedAt = time.Now()
+		if err := r.Client.Update(ctx, dev); err != nil {
+			r.Logger.Errorf("Reconciling %s (Plug-ins): Failed to save %s: %v", snapshotLogName(pc.Snapshot), uri, err)
+			continue
+		}
+		saved++
+	}
+	return saved
+}
@@ -0,0 +1,98 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It contains the built-in device plug-ins.
+package reconcilers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+func init() {
+	RegisterDevicePlugin(DevicePlugin{Name: "dimm-capacity", DeviceType: "DIMM", Process: normalizeDIMMCapacity})
+	RegisterDevicePlugin(DevicePlugin{Name: "xname", Process: deriveXname})
+	// Naming runs after xname, which the xname strategy uses
+	RegisterDevicePlugin(DevicePlugin{Name: "naming", Process: applyDeviceName})
+}
+
+// normalizeDIMMCapacity derives capacity_bytes and capacity_gib from the
+// collector's capacity_mib.
+func normalizeDIMMCapacity(ctx context.Context, pc *PluginContext, dev *device.Device) (bool, error) {
+	var mib int64
+	if !dev.Spec.GetProperty("capacity_mib", &mib) || mib <= 0 {
+		return false, nil
+	}
+	changed := setPropertyIfChanged(&dev.Spec, "capacity_bytes", mib*1024*1024)
+	changed = setPropertyIfChanged(&dev.Spec, "capacity_gib", float64(mib)/1024) || changed
+	return changed, nil
+}
+
+// bmcXnamePattern matches an HPE Cray EX node BMC xname, e.g. x1000c0s7b0.
+var bmcXnamePattern = regexp.MustCompile(`^x\d+c\d+s\d+b\d+$`)
+
+// trailingDigits extracts the index at the end of a Redfish Id ("Node1" -> "1").
+var trailingDigits = regexp.MustCompile(`(\d+)$`)
+
+// deriveXname assigns xnames when the snapshot was collected from a BMC
+// addressed by its xname: nodes become <bmc>n<N>, and their processors and
+// DIMMs <node>p<N> and <node>d<N>, with N taken from the Redfish Id.
+func deriveXname(ctx context.Context, pc *PluginContext, dev *device.Device) (bool, error) {
+	bmc := discoverysnapshot.Address(pc.Snapshot)
+	if !bmcXnamePattern.MatchString(bmc) {
+		return false, nil
+	}
+
+	var uri string
+	dev.Spec.GetProperty("redfish_uri", &uri)
+	index := trailingDigits.FindString(uri[strings.LastIndex(uri, "/")+1:])
+	if index == "" {
+		return false, nil
+	}
+
+	switch dev.Spec.DeviceType {
+	case "Node":
+		return setPropertyIfChanged(&dev.Spec, "xname", bmc+"n"+index), nil
+	case "CPU", "DIMM":
+		parent, ok, err := pc.BySerial(ctx, dev.Spec.ParentSerialNumber)
+		if err != nil {
+			return false, err
+		}
+		if !ok || parent.Spec.DeviceType != "Node" {
+			return false, nil
+		}
+		var parentURI string
+		parent.Spec.GetProperty("redfish_uri", &parentURI)
+		nodeIndex := trailingDigits.FindString(parentURI[strings.LastIndex(parentURI, "/")+1:])
+		if nodeIndex == "" {
+			return false, nil
+		}
+		suffix := "p"
+		if dev.Spec.DeviceType == "DIMM" {
+			suffix = "d"
+		}
+		return setPropertyIfChanged(&dev.Spec, "xname", bmc+"n"+nodeIndex+suffix+index), nil
+	}
+	return false, nil
+}
+
+// setPropertyIfChanged stores value under key and reports whether it differed.
+func setPropertyIfChanged(spec *device.DeviceSpec, key string, value interface{}) bool {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	if existing, ok := spec.Properties[key]; ok && bytes.Equal(existing, raw) {
+		return false
+	}
+	spec.SetProperty(key, value)
+	return true
+}
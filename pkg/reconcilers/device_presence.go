@@ -0,0 +1,126 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It flags the devices a snapshot did not report.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// powerStateOn is the Redfish PowerState of a running system.
+const powerStateOn = "On"
+
+// flagUnreportedDevices marks the devices last reported by the snapshot's BMC
+// that the snapshot left out. A device under a node that is reported but not
+// powered on is PoweredOff, since BMCs often omit the components of a node
+// that is off; any other device is Missing. Quick snapshots only cover nodes
+// and NICs, so they flag nothing, and devices in a subtree a partial snapshot
+// failed to read are left alone. It returns how many devices it flagged, or
+// the error of looking up the devices of the BMC.
+func (r *DiscoverySnapshotReconciler) flagUnreportedDevices(ctx context.Context, lookup deviceLookup, snapshot *discoverysnapshot.DiscoverySnapshot, reported map[string]*device.Device, source string) (missing, poweredOff int, err error) {
+	if source == "" || snapshot.Spec.Profile == bmcendpoint.ProfileQuick {
+		return 0, 0, nil
+	}
+
+	reportedUIDs := make(map[string]bool, len(reported))
+	byUID := make(map[string]*device.Device)
+	for _, dev := range reported {
+		reportedUIDs[dev.GetUID()] = true
+		byUID[dev.GetUID()] = dev
+	}
+	previous, err := lookup.BySource(ctx, source)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, dev := range previous {
+		if _, ok := byUID[dev.GetUID()]; !ok {
+			byUID[dev.GetUID()] = dev
+		}
+	}
+
+	for _, dev := range previous {
+		if reportedUIDs[dev.GetUID()] || prune.IsRetired(dev) || manifest.IsExpected(dev) {
+			continue
+		}
+		node := owningNode(dev, byUID)
+		if !collected(snapshot.Spec.Coverage, dev, node) {
+			continue
+		}
+		lastSeen := prune.LastSeen(dev).Format(time.RFC3339)
+		phase := device.PhaseMissing
+		message := fmt.Sprintf("Not reported by %s since %s; it may have been removed.", source, lastSeen)
+		if node != nil && reportedUIDs[node.GetUID()] {
+			if powerState, off := nodePoweredOff(node); off {
+				phase = device.PhasePoweredOff
+				message = fmt.Sprintf("Not reported by %s since %s while node %s is %s.", source, lastSeen, node.GetName(), powerState)
+			}
+		}
+		if phase == device.PhaseMissing {
+			missing++
+		} else {
+			poweredOff++
+		}
+		if dev.Status.Phase == phase && dev.Status.Message == message {
+			continue
+		}
+		r.Logger.Infof("Reconciling %s: %s is %s", snapshotLogName(snapshot), dev.GetName(), phase)
+		dev.Status.Phase = phase
+		dev.Status.Message = message
+		dev.Metadata.UpdatedAt = time.Now()
+		if err := r.Client.Update(ctx, dev); err != nil {
+			r.Logger.Errorf("Reconciling %s: Failed to mark %s %s: %v", snapshotLogName(snapshot), dev.GetName(), phase, err)
+		}
+	}
+	return missing, poweredOff, nil
+}
+
+// nodePoweredOff returns the node's reported power state and whether it is
+// anything but on. Nodes without a power state count as on.
+func nodePoweredOff(node *device.Device) (string, bool) {
+	var powerState string
+	node.Spec.GetProperty("power_state", &powerState)
+	return powerState, powerState != "" && powerState != powerStateOn
+}
+
+// collected reports whether the snapshot's coverage includes the device and
+// its node; a component is reached through its node, so it is lost with it.
+func collected(coverage *discoverysnapshot.Coverage, dev, node *device.Device) bool {
+	for _, d := range []*device.Device{dev, node} {
+		var uri string
+		if d != nil && d.Spec.GetProperty("redfish_uri", &uri) && !coverage.Covers(uri) {
+			return false
+		}
+	}
+	return true
+}
+
+// owningNode returns the Node the device belongs to, or the device itself,
+// following ParentID through byUID.
+func owningNode(dev *device.Device, byUID map[string]*device.Device) *device.Device {
+	seen := make(map[string]bool)
+	for dev != nil && !seen[dev.GetUID()] {
+		if dev.Spec.DeviceType == "Node" {
+			return dev
+		}
+		seen[dev.GetUID()] = true
+		dev = byUID[dev.Spec.ParentID]
+	}
+	return nil
+}
+
+// isUnreported reports whether the device carries a phase set by
+// flagUnreportedDevices.
+func isUnreported(dev *device.Device) bool {
+	return dev.Status.Phase == device.PhaseMissing || dev.Status.Phase == device.PhasePoweredOff
+}
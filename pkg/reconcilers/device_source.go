@@ -0,0 +1,52 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It records where the devices of a snapshot come from.
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// deviceOrigin is where a snapshot's devices come from: the BMC address
+// (the source lookups are scoped to), the BMCEndpoint registered for it, and
+// the collector that posted the snapshot.
+type deviceOrigin struct {
+	Address   string
+	Endpoint  string
+	Collector string
+}
+
+// snapshotOrigin returns the origin of a snapshot's devices. The endpoint is
+// left empty for BMCs collected without a BMCEndpoint.
+func (r *DiscoverySnapshotReconciler) snapshotOrigin(ctx context.Context, snapshot *discoverysnapshot.DiscoverySnapshot) deviceOrigin {
+	origin := deviceOrigin{Address: discoverysnapshot.Address(snapshot)}
+	if provenance := snapshot.Spec.Provenance; provenance != nil {
+		origin.Collector = provenance.Collector
+	}
+	endpoints, err := r.Client.List(ctx, "BMCEndpoint")
+	if err != nil {
+		r.Logger.Warnf("Reconciling %s: Failed to load BMC endpoints: %v", snapshotLogName(snapshot), err)
+		return origin
+	}
+	for _, item := range endpoints {
+		if endpoint, ok := item.(*bmcendpoint.BMCEndpoint); ok && endpoint.Spec.Address == origin.Address {
+			origin.Endpoint = endpoint.GetUID()
+			break
+		}
+	}
+	return origin
+}
+
+// stamp records the origin on a device reported by the snapshot.
+func (o deviceOrigin) stamp(dev *device.Device) {
+	dev.Status.Source = o.Address
+	dev.Status.SourceEndpoint = o.Endpoint
+	dev.Status.SourceCollector = o.Collector
+}
@@ -0,0 +1,53 @@
+package reconcilers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// TestSnapshotsScopedToSource reconciles the same payload from two BMCs,
+// whose devices share Redfish URIs, and checks that each BMC keeps its own
+// devices and that one BMC's snapshots leave the other's alone.
+func TestSnapshotsScopedToSource(t *testing.T) {
+	client, r, payload := discoverFixture(t, contract.Fixtures[0], "bmc-a")
+	var specs []device.DeviceSpec
+	if err := json.Unmarshal(payload, &specs); err != nil {
+		t.Fatal(err)
+	}
+	// The second node has other serial numbers
+	reconcileFrom(t, r, "b", "bmc-b", []byte(strings.ReplaceAll(string(payload), `erialNumber": "`, `erialNumber": "B-`)))
+
+	bySource := map[string]int{}
+	for _, dev := range client.devices {
+		bySource[dev.Status.Source]++
+	}
+	if bySource["bmc-a"] != len(specs) || bySource["bmc-b"] != len(specs) {
+		t.Fatalf("devices by source: %v, want %d each", bySource, len(specs))
+	}
+
+	// A snapshot of bmc-b without its components flags only bmc-b's devices
+	var nodes []device.DeviceSpec
+	for _, spec := range specs {
+		if spec.DeviceType == "Node" {
+			spec.SerialNumber = "B-" + spec.SerialNumber
+			nodes = append(nodes, spec)
+		}
+	}
+	nodesOnly, err := json.Marshal(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reconcileFrom(t, r, "b", "bmc-b", nodesOnly)
+	for _, dev := range client.devices {
+		if dev.Status.Source == "bmc-a" && dev.Status.Phase != "" {
+			t.Errorf("%s of bmc-a is %s after a snapshot of bmc-b", dev.GetName(), dev.Status.Phase)
+		}
+		if dev.Status.Source == "bmc-b" && dev.Spec.DeviceType != "Node" && dev.Status.Phase != device.PhaseMissing {
+			t.Errorf("%s of bmc-b is %q, want %s", dev.GetName(), dev.Status.Phase, device.PhaseMissing)
+		}
+	}
+}
@@ -10,25 +10,60 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
+	"github.com/example/inventory-v3/pkg/bulkpatch"
+	"github.com/example/inventory-v3/pkg/completeness"
+	"github.com/example/inventory-v3/pkg/identity"
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/properties"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/redact"
 	"github.com/example/inventory-v3/pkg/resources/device"
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+	"github.com/example/inventory-v3/pkg/units"
 	fabResource "github.com/openchami/fabrica/pkg/resource"
 )
 
 // reconcileDiscoverySnapshot is the core reconciliation logic for DiscoverySnapshot.
 func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Context, snapshot *discoverysnapshot.DiscoverySnapshot) error {
+	logName := snapshotLogName(snapshot)
 	if snapshot.Status.Phase == "Completed" {
-		r.Logger.Infof("Reconciling %s: Already completed, skipping.", snapshot.GetName())
+		r.Logger.Infof("Reconciling %s: Already completed, skipping.", logName)
+		return nil
+	}
+	if snapshot.Status.Phase == discoverysnapshot.PhaseHeld {
+		r.Logger.Infof("Reconciling %s: Held for approval, skipping.", logName)
+		return nil
+	}
+	if review := snapshot.Status.Review; review != nil && !review.Approved {
+		r.Logger.Infof("Reconciling %s: Rejected by an operator, skipping.", logName)
 		return nil
 	}
 
-	r.Logger.Infof("Reconciling %s: Starting reconciliation", snapshot.GetName())
+	r.Logger.Infof("Reconciling %s: Starting reconciliation", logName)
 	snapshot.Status.Phase = "Processing"
 	snapshot.Status.Message = "Reconciler has started processing the snapshot."
 	snapshot.Status.Ready = false
 
+	// Only trusted collectors may change the inventory
+	signedBy, err := verifySnapshot(snapshot)
+	if err != nil {
+		r.Logger.Warnf("Reconciling %s: Rejected: %v", logName, err)
+		snapshot.Status.Phase = "Rejected"
+		snapshot.Status.Message = fmt.Sprintf("Snapshot rejected: %v", err)
+		return nil
+	}
+	snapshot.Status.SignedBy = signedBy
+
+	if snapshot.Spec.Decommissioned {
+		return r.reconcileDecommission(ctx, snapshot)
+	}
+
 	var payloadSpecs []device.DeviceSpec
 	if err := json.Unmarshal(snapshot.Spec.RawData, &payloadSpecs); err != nil {
 		snapshot.Status.Phase = "Error"
@@ -36,116 +71,345 @@ func (r *DiscoverySnapshotReconciler) reconcileDiscoverySnapshot(ctx context.Con
 		return nil
 	}
 
-	// --- CHANGE: We now build TWO maps ---
-	// 1. A map by Redfish URI, used as the primary key for get-or-create
-	deviceMapByURI, err := r.buildDeviceMapByURI(ctx)
+	// Existing devices are found by Redfish URI (the get-or-create key) among
+	// those of the snapshot's source, and by serial number (used ONLY for
+	// parent linking in Pass 2). Backends with a device index answer these
+	// with queries; otherwise every device is loaded.
+	origin := r.snapshotOrigin(ctx, snapshot)
+	source := origin.Address
+	lookup, err := r.newDeviceLookup(ctx, source)
 	if err != nil {
-		return fmt.Errorf("failed to build device map by URI: %w", err)
+		return fmt.Errorf("failed to load existing devices: %w", err)
 	}
-	// 2. A map by Serial Number, used ONLY for parent linking in Pass 2
-	deviceMapBySerial, err := r.buildDeviceMapBySerial(ctx)
+
+	// A snapshot that would change an unusual share of the inventory waits
+	// for an operator to approve it
+	hold, err := r.holdSnapshot(ctx, snapshot, lookup, payloadSpecs, source)
 	if err != nil {
-		return fmt.Errorf("failed to build device map by Serial: %w", err)
+		return err
+	}
+	if hold != nil {
+		r.hold(snapshot, hold, source)
+		return nil
 	}
-	// --- END CHANGE ---
 
-	r.Logger.Infof("Reconciling %s: Loaded %d devices by URI and %d by Serial", snapshot.GetName(), len(deviceMapByURI), len(deviceMapBySerial))
 	snapshotDeviceMap := make(map[string]*device.Device)
+	changes := &discoverysnapshot.DeviceChanges{}
 	processedCount := 0
+	replacementsDetected := 0
+	rewritesHeld := 0
+	var received []*device.Device
+	seenAt := time.Now()
+
+	// Canonical URIs the snapshot reports, for recognizing moved devices
+	reportedURIs := make(map[string]bool, len(payloadSpecs))
+	for _, spec := range payloadSpecs {
+		if key, err := uriKey(spec); err == nil {
+			reportedURIs[key] = true
+		}
+	}
 
 	// --- PASS 1: CREATE AND UPDATE DEVICES (USING REDFISH URI) ---
 	for _, spec := range payloadSpecs {
+		// Older collectors and plugins may post secrets; never store them
+		if stripped := redact.Properties(spec.Properties); stripped > 0 {
+			r.Logger.Warnf("Reconciling %s: Stripped %d sensitive properties from a device", logName, stripped)
+		}
+		// The site's policy holds for collectors that did not apply it
+		if changed := propertyPolicy.Apply(&spec); changed > 0 {
+			r.Logger.Debugf("Reconciling %s: Property policy removed or hashed %d device fields", logName, changed)
+		}
 		// --- CHANGE: Use redfish_uri as the primary key ---
 		uri, err := getRedfishURI(spec)
 		if err != nil {
-			r.Logger.Errorf("Reconciling %s: Skipping device, missing redfish_uri", snapshot.GetName())
+			r.Logger.Errorf("Reconciling %s: Skipping device, missing redfish_uri", logName)
 			continue
 		}
+		if converted := units.Normalize(&spec); len(converted) > 0 {
+			r.Logger.Debugf("Reconciling %s: Converted %s of %s to canonical units", logName, strings.Join(converted, ", "), uri)
+		}
+		// Mismatched properties are stored anyway, but flagged for the source to fix
+		if err := properties.Validate(spec.Properties); err != nil {
+			r.Logger.Warnf("Reconciling %s: Device %s reports mismatched properties: %v", logName, uri, err)
+		}
 		// --- END CHANGE ---
+		spec.SetProperty(identity.PropertyCanonicalURI, identity.CanonicalURI(uri))
+		compositeKey := identity.CompositeKey(spec)
+		if compositeKey != "" {
+			spec.SetProperty(identity.PropertyCompositeKey, compositeKey)
+		}
 
-		existingDevice, found := deviceMapByURI[uri]
+		existingDevice, found, err := lookup.ByURI(ctx, uri)
+		if err != nil {
+			return fmt.Errorf("reconciling %s: %w", uri, err)
+		}
+		if !found && spec.SerialNumber != "" {
+			// A device expected from a shipping manifest has no redfish_uri yet;
+			// adopt it by serial number instead of creating a duplicate.
+			expected, ok, err := lookup.BySerial(ctx, spec.SerialNumber)
+			if err != nil {
+				return fmt.Errorf("reconciling %s: %w", uri, err)
+			}
+			if ok && manifest.IsExpected(expected) {
+				if err := r.receiveExpectedDevice(ctx, expected, spec, uri, seenAt, origin); err != nil {
+					r.Logger.Errorf("Reconciling %s (Pass 1): Failed to receive expected device %s: %v", logName, spec.SerialNumber, err)
+					continue
+				}
+				r.Logger.Infof("Reconciling %s (Pass 1): Received expected device %s at %s (UID: %s)", logName, spec.SerialNumber, uri, expected.GetUID())
+				snapshotDeviceMap[uri] = expected
+				changes.Updated = append(changes.Updated, expected.GetUID())
+				lookup.Add(expected)
+				received = append(received, expected)
+				processedCount++
+				continue
+			}
+		}
+		moved := false
+		if !found && spec.SerialNumber != "" {
+			// Firmware may rename a component's Id outright; keep its record.
+			candidate, ok, err := lookup.BySerial(ctx, spec.SerialNumber)
+			if err != nil {
+				return fmt.Errorf("reconciling %s: %w", uri, err)
+			}
+			if ok && identity.Moved(spec, candidate, source, reportedURIs) {
+				previousURI, _ := getRedfishURI(candidate.Spec)
+				r.Logger.Infof("Reconciling %s (Pass 1): Device %s moved from %s to %s (UID: %s)", logName, spec.SerialNumber, previousURI, uri, candidate.GetUID())
+				existingDevice, found, moved = candidate, true, true
+			}
+		}
+		if !found && compositeKey != "" {
+			// Serial-less parts are recognized by parent, slot and part number.
+			candidate, ok, err := lookup.ByCompositeKey(ctx, compositeKey)
+			if err != nil {
+				return fmt.Errorf("reconciling %s: %w", uri, err)
+			}
+			if ok && identity.Moved(spec, candidate, source, reportedURIs) {
+				previousURI, _ := getRedfishURI(candidate.Spec)
+				r.Logger.Infof("Reconciling %s (Pass 1): Device %s moved from %s to %s (UID: %s)", logName, compositeKey, previousURI, uri, candidate.GetUID())
+				existingDevice, found, moved = candidate, true, true
+			}
+		}
 		if !found {
 			// --- CREATE NEW DEVICE ---
-			r.Logger.Infof("Reconciling %s (Pass 1): Creating new device: %s", snapshot.GetName(), uri)
+			r.Logger.Infof("Reconciling %s (Pass 1): Creating new device: %s", logName, uri)
 			// --- CHANGE: Pass URI to be used as the 'Name' ---
-			newDevice, err := r.createNewDevice(ctx, spec, uri)
+			newDevice, err := r.createNewDevice(ctx, spec, uri, seenAt, origin)
 			if err != nil {
-				r.Logger.Errorf("Reconciling %s (Pass 1): Failed to create device %s: %v", snapshot.GetName(), uri, err)
+				r.Logger.Errorf("Reconciling %s (Pass 1): Failed to create device %s: %v", logName, uri, err)
 				continue
 			}
 			snapshotDeviceMap[uri] = newDevice
-			deviceMapByURI[uri] = newDevice // Add to maps
-			if newDevice.Spec.SerialNumber != "" {
-				deviceMapBySerial[newDevice.Spec.SerialNumber] = newDevice
-			}
+			changes.Created = append(changes.Created, newDevice.GetUID())
+			lookup.Add(newDevice)
 
 		} else {
 			// --- UPDATE EXISTING DEVICE ---
-			r.Logger.Infof("Reconciling %s (Pass 1): Updating existing device: %s (UID: %s)", snapshot.GetName(), uri, existingDevice.GetUID())
+			r.Logger.Infof("Reconciling %s (Pass 1): Updating existing device: %s (UID: %s)", logName, uri, existingDevice.GetUID())
+
+			// A new serial number in a known slot means the part was replaced.
+			oldSerial := existingDevice.Spec.SerialNumber
+			if serialReplaced(oldSerial, spec.SerialNumber) {
+				r.Logger.Warnf("Reconciling %s (Pass 1): Serial number of %s changed from %s to %s",
+					logName, uri, oldSerial, spec.SerialNumber)
+				if changePolicy.SerialRewrites {
+					// The site signs off serial rewrites; keep the recorded
+					// serial number until the change is approved
+					rewrite := pendingchange.SerialRewrite{
+						DeviceID:        existingDevice.GetUID(),
+						Slot:            uri,
+						OldSerialNumber: oldSerial,
+						NewSerialNumber: spec.SerialNumber,
+						Snapshot:        snapshot.GetName(),
+						RunID:           discoverysnapshot.RunID(snapshot),
+						DetectedAt:      seenAt,
+					}
+					if change, err := r.holdSerialRewrite(ctx, rewrite); err != nil {
+						r.Logger.Errorf("Reconciling %s (Pass 1): Failed to hold the new serial number of %s: %v", logName, uri, err)
+					} else {
+						r.Logger.Infof("Reconciling %s (Pass 1): New serial number of %s waits for approval of %s", logName, uri, change.GetUID())
+						rewritesHeld++
+					}
+					spec.SerialNumber = oldSerial
+				} else {
+					replacement := serviceevent.Replacement{
+						Slot:            uri,
+						OldSerialNumber: oldSerial,
+						NewSerialNumber: spec.SerialNumber,
+						DetectedAt:      seenAt,
+						Snapshot:        snapshot.GetName(),
+						RunID:           discoverysnapshot.RunID(snapshot),
+					}
+					if _, err := createReplacementStub(ctx, r.Client, existingDevice.GetUID(), replacement); err != nil {
+						r.Logger.Errorf("Reconciling %s (Pass 1): Failed to record replacement of %s: %v", logName, uri, err)
+					} else {
+						replacementsDetected++
+					}
+				}
+			}
 
 			spec.ParentID = existingDevice.Spec.ParentID
+			bulkpatch.KeepManualProperties(existingDevice, &spec)
 			existingDevice.Spec = spec
 			existingDevice.Metadata.UpdatedAt = time.Now()
+			if existingDevice.Status.Phase == prune.PhaseRetired {
+				r.Logger.Warnf("Reconciling %s (Pass 1): Retired device %s was reported again, reinstating it", logName, uri)
+				existingDevice.Status.Phase = ""
+				existingDevice.Status.Message = ""
+			}
+			if isUnreported(existingDevice) {
+				r.Logger.Infof("Reconciling %s (Pass 1): %s device %s was reported again", logName, existingDevice.Status.Phase, uri)
+				existingDevice.Status.Phase = ""
+				existingDevice.Status.Message = ""
+			}
+			existingDevice.Status.LastSeen = &seenAt
+			adopted := existingDevice.Status.Source != source
+			origin.stamp(existingDevice)
 
 			if err := r.Client.Update(ctx, existingDevice); err != nil {
-				r.Logger.Errorf("Reconciling %s (Pass 1): Failed to update device %s: %v", snapshot.GetName(), uri, err)
+				r.Logger.Errorf("Reconciling %s (Pass 1): Failed to update device %s: %v", logName, uri, err)
 				continue
 			}
 			snapshotDeviceMap[uri] = existingDevice
+			changes.Updated = append(changes.Updated, existingDevice.GetUID())
+			if moved || adopted {
+				lookup.Add(existingDevice)
+			}
+			if existingDevice.Status.Phase == manifest.PhaseReceived {
+				received = append(received, existingDevice)
+			}
 		}
 		processedCount++
 	}
 
+	// --- PLUG-INS: TYPE-SPECIFIC POST-PROCESSING ---
+	pluginContext := &PluginContext{Snapshot: snapshot, Devices: snapshotDeviceMap, lookup: lookup}
+	pluginUpdates := r.runDevicePlugins(ctx, pluginContext)
+	r.Logger.Infof("Reconciling %s (Plug-ins): %d devices updated", logName, pluginUpdates)
+
 	// --- PASS 2: LINK PARENT IDs (USING SERIAL NUMBER) ---
-	// This logic is unchanged, as it relies on the serial number map
-	r.Logger.Infof("Reconciling %s (Pass 2): Linking parent relationships...", snapshot.GetName())
+	r.Logger.Infof("Reconciling %s (Pass 2): Linking parent relationships...", logName)
 	linksUpdated := 0
 	for _, dev := range snapshotDeviceMap {
 		parentSerial := dev.Spec.ParentSerialNumber
 		if parentSerial == "" {
 			continue
 		}
-		parentDevice, found := deviceMapBySerial[parentSerial]
+		parentDevice, found, err := lookup.BySerial(ctx, parentSerial)
+		if err != nil {
+			return fmt.Errorf("linking %s to its parent: %w", dev.GetName(), err)
+		}
 		if !found {
-			r.Logger.Errorf("Reconciling %s (Pass 2): Parent device with serial %s not found for child %s", snapshot.GetName(), parentSerial, dev.Spec.SerialNumber)
+			r.Logger.Errorf("Reconciling %s (Pass 2): Parent device with serial %s not found for child %s", logName, parentSerial, dev.Spec.SerialNumber)
 			continue
 		}
 		if dev.Spec.ParentID == parentDevice.GetUID() {
 			continue
 		}
 		r.Logger.Infof("Reconciling %s (Pass 2): Linking %s (UID: %s) to parent %s (UID: %s)",
-			snapshot.GetName(), dev.GetName(), dev.GetUID(), parentDevice.GetName(), parentDevice.GetUID())
+			logName, dev.GetName(), dev.GetUID(), parentDevice.GetName(), parentDevice.GetUID())
 
 		dev.Spec.ParentID = parentDevice.GetUID()
 		dev.Metadata.UpdatedAt = time.Now()
 
 		if err := r.Client.Update(ctx, dev); err != nil {
-			r.Logger.Errorf("Reconciling %s (Pass 2): Failed to update parent link for %s: %v", snapshot.GetName(), dev.GetName(), err)
+			r.Logger.Errorf("Reconciling %s (Pass 2): Failed to update parent link for %s: %v", logName, dev.GetName(), err)
 		} else {
 			linksUpdated++
 		}
 	}
 
-	// 4. Set phase to "Completed"
+	// Devices received from a manifest are installed once they are linked to
+	// their parent, or at once when they have none.
+	installed := 0
+	for _, dev := range received {
+		if dev.Spec.ParentSerialNumber != "" && dev.Spec.ParentID == "" {
+			continue
+		}
+		dev.Status.Phase = manifest.PhaseInstalled
+		dev.Status.Message = fmt.Sprintf("Installed at %s, found by %s.", dev.GetName(), snapshot.GetName())
+		dev.Metadata.UpdatedAt = time.Now()
+		if err := r.Client.Update(ctx, dev); err != nil {
+			r.Logger.Errorf("Reconciling %s: Failed to mark %s installed: %v", logName, dev.GetName(), err)
+		} else {
+			installed++
+		}
+	}
+
+	// --- PASS 3: SUMMARIZE NODES ---
+	r.Logger.Infof("Reconciling %s (Pass 3): Summarizing nodes...", logName)
+	summariesUpdated := 0
+	for _, dev := range snapshotDeviceMap {
+		if dev.Spec.DeviceType != "Node" {
+			continue
+		}
+		// A node that is off reports few components; keep the last totals
+		if _, off := nodePoweredOff(dev); off {
+			continue
+		}
+		children := childrenOf(dev, snapshotDeviceMap)
+		changed := applyNodeTotals(dev, children)
+		score := completeness.Score(dev, children)
+		if !changed && reflect.DeepEqual(dev.Status.Completeness, score) {
+			continue
+		}
+		dev.Status.Completeness = score
+		dev.Metadata.UpdatedAt = time.Now()
+		if err := r.Client.Update(ctx, dev); err != nil {
+			r.Logger.Errorf("Reconciling %s (Pass 3): Failed to update summary for %s: %v", logName, dev.GetName(), err)
+		} else {
+			summariesUpdated++
+		}
+	}
+
+	// --- PASS 4: FLAG DEVICES THE SNAPSHOT LEFT OUT ---
+	missing, poweredOff, err := r.flagUnreportedDevices(ctx, lookup, snapshot, snapshotDeviceMap, source)
+	if err != nil {
+		return err
+	}
+
+	// 5. Set phase to "Completed"
 	snapshot.Status.Phase = "Completed"
-	snapshot.Status.Message = fmt.Sprintf("Snapshot processed. %d devices created/updated. %d parent links updated.", processedCount, linksUpdated)
+	snapshot.Status.Message = fmt.Sprintf("Snapshot processed. %d devices created/updated. %d parent links updated. %d node summaries updated. %d replacements detected. %d expected devices received, %d installed. %d devices missing, %d unreported while powered off.", processedCount, linksUpdated, summariesUpdated, replacementsDetected, len(received), installed, missing, poweredOff)
+	if rewritesHeld > 0 {
+		snapshot.Status.Message += fmt.Sprintf(" %d new serial numbers wait for approval.", rewritesHeld)
+	}
 	snapshot.Status.Ready = true
+	snapshot.Status.Devices = changes
+	coverage := snapshot.Spec.Coverage
+	confidence := coverage.Confidence()
+	snapshot.Status.Partial = coverage.Partial()
+	snapshot.Status.Confidence = &confidence
+	if snapshot.Status.Partial {
+		snapshot.Status.Message += fmt.Sprintf(" Partial: %d Redfish subtrees were not collected (confidence %.2f); their devices were not flagged.", len(coverage.Failed), confidence)
+	}
 
-	r.Logger.Infof("Reconciling %s: Successfully reconciled", snapshot.GetName())
+	r.Logger.Infof("Reconciling %s: Successfully reconciled", logName)
 	return nil
 }
 
-// --- THIS HELPER IS UPDATED ---
-// It now takes the redfishURI to use as the Metadata.Name
-func (r *DiscoverySnapshotReconciler) createNewDevice(ctx context.Context, spec device.DeviceSpec, redfishURI string) (*device.Device, error) {
+// snapshotLogName names a snapshot in log lines, with the collection run
+// that produced it so the lines can be matched with the collector's.
+func snapshotLogName(snapshot *discoverysnapshot.DiscoverySnapshot) string {
+	if runID := discoverysnapshot.RunID(snapshot); runID != "" {
+		return fmt.Sprintf("%s [run %s]", snapshot.GetName(), runID)
+	}
+	return snapshot.GetName()
+}
+
+// createNewDevice creates a device named by its redfishURI, recording when and
+// from which BMC it was first seen. The naming plug-in then renames it by the
+// configured strategy.
+func (r *DiscoverySnapshotReconciler) createNewDevice(ctx context.Context, spec device.DeviceSpec, redfishURI string, seenAt time.Time, origin deviceOrigin) (*device.Device, error) {
 	newDevice := &device.Device{
 		Resource: fabResource.Resource{
 			APIVersion:    "v1",
 			Kind:          "Device",
 			SchemaVersion: "v1",
 		},
-		Spec: spec,
+		Spec:   spec,
+		Status: device.DeviceStatus{LastSeen: &seenAt},
 	}
+	origin.stamp(newDevice)
 
 	uid, err := fabResource.GenerateUIDForResource("Device")
 	if err != nil {
@@ -163,8 +427,28 @@ func (r *DiscoverySnapshotReconciler) createNewDevice(ctx context.Context, spec
 	return newDevice, nil
 }
 
-// --- THIS HELPER IS UNCHANGED ---
-// We still need it for Pass 2
+// receiveExpectedDevice replaces the manifest spec of an expected device with
+// the discovered one, keeping the manifest name, any ParentID and any bulk
+// patched properties, and renames the device to its redfishURI (and then by
+// the naming strategy).
+func (r *DiscoverySnapshotReconciler) receiveExpectedDevice(ctx context.Context, dev *device.Device, spec device.DeviceSpec, redfishURI string, seenAt time.Time, origin deviceOrigin) error {
+	var manifestName string
+	if dev.Spec.GetProperty(manifest.PropertyManifest, &manifestName) {
+		spec.SetProperty(manifest.PropertyManifest, manifestName)
+	}
+	spec.ParentID = dev.Spec.ParentID
+	bulkpatch.KeepManualProperties(dev, &spec)
+	dev.Spec = spec
+	dev.Metadata.Name = redfishURI
+	dev.Metadata.UpdatedAt = time.Now()
+	dev.Status.Phase = manifest.PhaseReceived
+	dev.Status.Message = fmt.Sprintf("Found by discovery at %s.", redfishURI)
+	dev.Status.LastSeen = &seenAt
+	origin.stamp(dev)
+	return r.Client.Update(ctx, dev)
+}
+
+// buildDeviceMapBySerial fetches all devices and creates a map of [SerialNumber] -> *Device
 func (r *DiscoverySnapshotReconciler) buildDeviceMapBySerial(ctx context.Context) (map[string]*device.Device, error) {
 	resourceList, err := r.Client.List(ctx, "Device")
 	if err != nil {
@@ -184,8 +468,7 @@ func (r *DiscoverySnapshotReconciler) buildDeviceMapBySerial(ctx context.Context
 	return deviceMap, nil
 }
 
-// --- NEW HELPER FUNCTION ---
-// buildDeviceMapByURI fetches all devices and creates a map of [RedfishURI] -> *Device
+// buildDeviceMapByURI fetches all devices and creates a map of [source and canonical RedfishURI (deviceKey)] -> *Device
 func (r *DiscoverySnapshotReconciler) buildDeviceMapByURI(ctx context.Context) (map[string]*device.Device, error) {
 	resourceList, err := r.Client.List(ctx, "Device")
 	if err != nil {
@@ -198,12 +481,12 @@ func (r *DiscoverySnapshotReconciler) buildDeviceMapByURI(ctx context.Context) (
 			r.Logger.Errorf("Reconciling: Found non-device item in storage, skipping.")
 			continue
 		}
-		uri, err := getRedfishURI(dev.Spec)
+		key, err := deviceKey(dev)
 		if err != nil {
 			r.Logger.Warnf("Reconciling: Device %s has no redfish_uri, skipping from URI map.", dev.GetUID())
 			continue
 		}
-		deviceMap[uri] = dev
+		deviceMap[key] = dev
 	}
 	return deviceMap, nil
 }
@@ -228,4 +511,15 @@ func getRedfishURI(spec device.DeviceSpec) (string, error) {
 	}
 
 	return uri, nil
-}
\ No newline at end of file
+}
+
+// childrenOf returns the devices whose parent is node.
+func childrenOf(node *device.Device, devices map[string]*device.Device) []*device.Device {
+	var children []*device.Device
+	for _, child := range devices {
+		if child.Spec.ParentID == node.GetUID() {
+			children = append(children, child)
+		}
+	}
+	return children
+}
@@ -0,0 +1,46 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for Group.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/group"
+)
+
+// reconcileGroup describes how the group picks its devices. Membership is
+// resolved when it is asked for, so devices added or relabelled since never
+// leave it stale.
+func (r *GroupReconciler) reconcileGroup(ctx context.Context, res *group.Group) error {
+	var parts []string
+	if n := len(res.Spec.Members); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d listed member(s)", n))
+	}
+	if len(res.Spec.Selector) > 0 {
+		terms := make([]string, 0, len(res.Spec.Selector))
+		for key, value := range res.Spec.Selector {
+			terms = append(terms, key+"="+value)
+		}
+		sort.Strings(terms)
+		selector := "devices labelled " + strings.Join(terms, ",")
+		if res.Spec.DeviceType != "" {
+			selector = fmt.Sprintf("%s devices labelled %s", res.Spec.DeviceType, strings.Join(terms, ","))
+		}
+		parts = append(parts, selector)
+	}
+	res.Status.Message = "Covers " + strings.Join(parts, " and ")
+	if res.Spec.IncludeChildren {
+		res.Status.Message += ", with their children"
+	}
+	res.Status.Message += "."
+	res.Status.Phase = "Ready"
+	res.Status.Ready = true
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for MaintenanceWindow.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+)
+
+// reconcileMaintenanceWindow derives the window's phase at the time of the
+// reconcile and describes what it covers. Schedulers check the window itself,
+// so a stale phase never affects collection.
+func (r *MaintenanceWindowReconciler) reconcileMaintenanceWindow(ctx context.Context, res *maintenancewindow.MaintenanceWindow) error {
+	scope := "the whole fleet"
+	if !res.Global() {
+		scope = fmt.Sprintf("%d endpoint(s) and %d rack(s)", len(res.Spec.EndpointIDs), len(res.Spec.RackIDs))
+	}
+
+	now := time.Now()
+	switch {
+	case res.ActiveAt(now):
+		res.Status.Phase = maintenancewindow.PhaseActive
+		res.Status.Message = fmt.Sprintf("In maintenance: %s.", scope)
+	case res.Spec.Repeat == "" && !now.Before(res.Spec.End):
+		res.Status.Phase = maintenancewindow.PhaseEnded
+		res.Status.Message = fmt.Sprintf("Ended at %s.", res.Spec.End.Format(time.RFC3339))
+	default:
+		res.Status.Phase = maintenancewindow.PhaseScheduled
+		res.Status.Message = fmt.Sprintf("Covers %s from %s.", scope, res.Spec.Start.Format(time.RFC3339))
+		if res.Spec.Repeat != "" {
+			res.Status.Message = fmt.Sprintf("Covers %s %s from %s.", scope, res.Spec.Repeat, res.Spec.Start.Format(time.RFC3339))
+		}
+	}
+	res.Status.Ready = true
+	return nil
+}
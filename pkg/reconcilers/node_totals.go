@@ -0,0 +1,111 @@
+package reconcilers
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// nodeTotals aggregates a node's components. Both the node's total
+// properties (cpu_count, memory_total_mib, ...) and its Status.Summary are
+// taken from it, so that they always agree.
+type nodeTotals struct {
+	cpus, cores, threads int
+	gpus, dimms, drives  int
+	nics                 int
+	memoryMiB            int64
+	storageBytes         int64
+	macs                 map[string]bool
+}
+
+// aggregateNode adds up the components among children.
+func aggregateNode(children []*device.Device) nodeTotals {
+	totals := nodeTotals{macs: make(map[string]bool)}
+	for _, child := range children {
+		switch child.Spec.DeviceType {
+		case "CPU":
+			totals.cpus++
+			var cores, threads int
+			if child.Spec.GetProperty("total_cores", &cores) {
+				totals.cores += cores
+			}
+			if child.Spec.GetProperty("total_threads", &threads) {
+				totals.threads += threads
+			}
+		case "GPU":
+			totals.gpus++
+		case "DIMM":
+			totals.dimms++
+			var mib int64
+			if child.Spec.GetProperty("capacity_mib", &mib) {
+				totals.memoryMiB += mib
+			}
+		case "Drive":
+			totals.drives++
+			var capacity int64
+			if child.Spec.GetProperty("capacity_bytes", &capacity) {
+				totals.storageBytes += capacity
+			}
+		case "NIC", "DPU":
+			totals.nics++
+			var macs []string
+			child.Spec.GetProperty("mac_addresses", &macs)
+			for _, mac := range macs {
+				totals.macs[mac] = true
+			}
+		}
+	}
+	return totals
+}
+
+// properties returns the node properties of the totals; those of component
+// types the node has none of are left out.
+func (t nodeTotals) properties() map[string]int64 {
+	props := map[string]int64{
+		"cpu_count":           int64(t.cpus),
+		"gpu_count":           int64(t.gpus),
+		"dimm_count":          int64(t.dimms),
+		"memory_total_mib":    t.memoryMiB,
+		"drive_count":         int64(t.drives),
+		"storage_total_bytes": t.storageBytes,
+		"nic_count":           int64(t.nics),
+	}
+	for key, value := range props {
+		if value == 0 {
+			delete(props, key)
+		}
+	}
+	return props
+}
+
+// summary returns the NodeSummary of the totals.
+func (t nodeTotals) summary() *device.NodeSummary {
+	summary := &device.NodeSummary{
+		TotalMemoryGiB: float64(t.memoryMiB) / 1024,
+		CPUSockets:     t.cpus,
+		CPUCores:       t.cores,
+		CPUThreads:     t.threads,
+		GPUCount:       t.gpus,
+	}
+	for mac := range t.macs {
+		summary.NICMACs = append(summary.NICMACs, mac)
+	}
+	sort.Strings(summary.NICMACs)
+	return summary
+}
+
+// applyNodeTotals records the totals of the node's children on the node,
+// and reports whether that changed it.
+func applyNodeTotals(node *device.Device, children []*device.Device) bool {
+	totals := aggregateNode(children)
+	changed := false
+	for key, value := range totals.properties() {
+		changed = setPropertyIfChanged(&node.Spec, key, value) || changed
+	}
+	if summary := totals.summary(); !reflect.DeepEqual(node.Status.Summary, summary) {
+		node.Status.Summary = summary
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,40 @@
+package reconcilers
+
+import (
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+)
+
+// TestNodeTotals records the same totals of each node's components in its
+// properties and its summary.
+func TestNodeTotals(t *testing.T) {
+	for _, fixture := range contract.Fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			client, _, _ := discoverFixture(t, fixture, "bmc")
+			nodes := 0
+			for _, node := range client.devices {
+				if node.Spec.DeviceType != "Node" {
+					continue
+				}
+				nodes++
+				summary := node.Status.Summary
+				if summary == nil {
+					t.Fatalf("node %s has no summary", node.GetName())
+				}
+				var cpus, memoryMiB int64
+				node.Spec.GetProperty("cpu_count", &cpus)
+				node.Spec.GetProperty("memory_total_mib", &memoryMiB)
+				if int(cpus) != summary.CPUSockets {
+					t.Errorf("node %s: cpu_count %d, summary %d sockets", node.GetName(), cpus, summary.CPUSockets)
+				}
+				if float64(memoryMiB)/1024 != summary.TotalMemoryGiB {
+					t.Errorf("node %s: memory_total_mib %d, summary %g GiB", node.GetName(), memoryMiB, summary.TotalMemoryGiB)
+				}
+			}
+			if nodes == 0 {
+				t.Fatal("no nodes reconciled")
+			}
+		})
+	}
+}
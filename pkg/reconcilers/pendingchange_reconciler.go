@@ -0,0 +1,204 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for PendingChange.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/changecontrol"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+)
+
+// changePolicy is the site's change-control policy; the zero value holds
+// nothing for approval.
+var changePolicy changecontrol.Policy
+
+// SetChangePolicy sets the change-control policy. Call it before the
+// reconcilers run.
+func SetChangePolicy(p changecontrol.Policy) {
+	changePolicy = p
+}
+
+// reconcilePendingChange applies an approved change. Changes waiting for
+// approval, and those applied or rejected, are left as they are.
+func (r *PendingChangeReconciler) reconcilePendingChange(ctx context.Context, res *pendingchange.PendingChange) error {
+	switch res.Status.Phase {
+	case "":
+		res.Status.Phase = pendingchange.PhasePending
+		res.Status.Message = "Waiting for approval: " + res.Spec.Summary
+	case pendingchange.PhaseApproved:
+		r.Logger.Infof("Applying approved change %s: %s", res.GetUID(), res.Spec.Summary)
+		res.Status.Applied, res.Status.Errors = 0, nil
+		switch res.Spec.Action {
+		case pendingchange.ActionRetire:
+			r.retireDevices(ctx, res)
+		case pendingchange.ActionDelete:
+			r.deleteDevices(ctx, res)
+		case pendingchange.ActionSerialRewrite:
+			r.rewriteSerial(ctx, res)
+		}
+		now := time.Now()
+		res.Status.AppliedAt = &now
+		res.Status.Phase = pendingchange.PhaseApplied
+		res.Status.Message = fmt.Sprintf("Applied: %s. %d devices changed.", res.Spec.Summary, res.Status.Applied)
+		if len(res.Status.Errors) > 0 {
+			res.Status.Phase = pendingchange.PhaseFailed
+			res.Status.Message += fmt.Sprintf(" %d changes failed.", len(res.Status.Errors))
+		}
+	}
+	res.Status.Ready = res.Status.Phase == pendingchange.PhaseApplied
+	return nil
+}
+
+// fail records a part of the change that could not be applied.
+func (r *PendingChangeReconciler) fail(res *pendingchange.PendingChange, uid string, err error) {
+	r.Logger.Errorf("Applying approved change %s: %s: %v", res.GetUID(), uid, err)
+	res.Status.Errors = append(res.Status.Errors, fmt.Sprintf("%s: %v", uid, err))
+}
+
+// loadDevice returns the device with the UID.
+func (r *PendingChangeReconciler) loadDevice(ctx context.Context, uid string) (*device.Device, error) {
+	item, err := r.Client.Get(ctx, "Device", uid)
+	if err != nil {
+		return nil, err
+	}
+	dev, ok := item.(*device.Device)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %T", item)
+	}
+	return dev, nil
+}
+
+// retireDevices retires the change's devices, and disables its BMCEndpoints.
+func (r *PendingChangeReconciler) retireDevices(ctx context.Context, res *pendingchange.PendingChange) {
+	now := time.Now()
+	for _, uid := range res.Spec.Devices {
+		dev, err := r.loadDevice(ctx, uid)
+		if err != nil {
+			r.fail(res, uid, err)
+			continue
+		}
+		if prune.IsRetired(dev) {
+			continue
+		}
+		dev.Status.Phase = prune.PhaseRetired
+		dev.Status.Message = fmt.Sprintf("Retired by approved change %s (%s) at %s", res.GetUID(), res.Spec.Summary, now.Format(time.RFC3339))
+		dev.Metadata.UpdatedAt = now
+		if err := r.Client.Update(ctx, dev); err != nil {
+			r.fail(res, uid, err)
+			continue
+		}
+		res.Status.Applied++
+	}
+	for _, uid := range res.Spec.DetachEndpoints {
+		item, err := r.Client.Get(ctx, "BMCEndpoint", uid)
+		if err != nil {
+			r.fail(res, uid, err)
+			continue
+		}
+		endpoint, ok := item.(*bmcendpoint.BMCEndpoint)
+		if !ok || endpoint.Spec.Disabled {
+			continue
+		}
+		endpoint.Spec.Disabled = true
+		endpoint.Metadata.UpdatedAt = now
+		if err := r.Client.Update(ctx, endpoint); err != nil {
+			r.fail(res, uid, err)
+		}
+	}
+}
+
+// deleteDevices deletes the change's devices.
+func (r *PendingChangeReconciler) deleteDevices(ctx context.Context, res *pendingchange.PendingChange) {
+	for _, uid := range res.Spec.Devices {
+		if err := r.Client.Delete(ctx, "Device", uid); err != nil {
+			r.fail(res, uid, err)
+			continue
+		}
+		res.Status.Applied++
+	}
+}
+
+// rewriteSerial records the device's new serial number, and the replacement
+// as a ServiceEvent stub, as the snapshot reconciler does without approval.
+// The device must still carry the serial number the change replaces.
+func (r *PendingChangeReconciler) rewriteSerial(ctx context.Context, res *pendingchange.PendingChange) {
+	rewrite := res.Spec.Serial
+	dev, err := r.loadDevice(ctx, rewrite.DeviceID)
+	if err != nil {
+		r.fail(res, rewrite.DeviceID, err)
+		return
+	}
+	if dev.Spec.SerialNumber != rewrite.OldSerialNumber {
+		r.fail(res, rewrite.DeviceID, fmt.Errorf("serial number is now %s, not %s", dev.Spec.SerialNumber, rewrite.OldSerialNumber))
+		return
+	}
+	dev.Spec.SerialNumber = rewrite.NewSerialNumber
+	dev.Metadata.UpdatedAt = time.Now()
+	if err := r.Client.Update(ctx, dev); err != nil {
+		r.fail(res, rewrite.DeviceID, err)
+		return
+	}
+	res.Status.Applied++
+	replacement := serviceevent.Replacement{
+		Slot:            rewrite.Slot,
+		OldSerialNumber: rewrite.OldSerialNumber,
+		NewSerialNumber: rewrite.NewSerialNumber,
+		DetectedAt:      rewrite.DetectedAt,
+		Snapshot:        rewrite.Snapshot,
+		RunID:           rewrite.RunID,
+	}
+	if _, err := createReplacementStub(ctx, r.Client, dev.GetUID(), replacement); err != nil {
+		r.fail(res, rewrite.DeviceID, err)
+	}
+}
+
+// holdSerialRewrite records a new serial number reported for a known device
+// as a PendingChange, unless one is already waiting for it. The snapshot
+// reconciler keeps the device's serial number until the change is approved.
+func (r *DiscoverySnapshotReconciler) holdSerialRewrite(ctx context.Context, rewrite pendingchange.SerialRewrite) (*pendingchange.PendingChange, error) {
+	pending, err := r.pendingSerialRewrites(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending changes: %w", err)
+	}
+	for _, change := range pending {
+		if s := change.Spec.Serial; s.DeviceID == rewrite.DeviceID && s.NewSerialNumber == rewrite.NewSerialNumber {
+			return change, nil
+		}
+	}
+	change, err := changecontrol.New(pendingchange.ActionSerialRewrite,
+		fmt.Sprintf("Serial number in %s changed from %s to %s", rewrite.Slot, rewrite.OldSerialNumber, rewrite.NewSerialNumber))
+	if err != nil {
+		return nil, err
+	}
+	change.Spec.Serial = &rewrite
+	if err := r.Client.Create(ctx, change); err != nil {
+		return nil, fmt.Errorf("failed to create pending change for %s: %w", rewrite.Slot, err)
+	}
+	return change, nil
+}
+
+// pendingSerialRewrites lists the serial rewrites waiting for approval.
+func (r *DiscoverySnapshotReconciler) pendingSerialRewrites(ctx context.Context) ([]*pendingchange.PendingChange, error) {
+	items, err := r.Client.List(ctx, "PendingChange")
+	if err != nil {
+		return nil, err
+	}
+	var pending []*pendingchange.PendingChange
+	for _, item := range items {
+		if change, ok := item.(*pendingchange.PendingChange); ok && change.Spec.Serial != nil && change.Status.Phase == pendingchange.PhasePending {
+			pending = append(pending, change)
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,115 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/pkg/changecontrol"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+)
+
+// TestSerialRewriteApproval holds a new serial number reported for a known
+// device as a PendingChange, and records it once the change is approved.
+func TestSerialRewriteApproval(t *testing.T) {
+	client, r, payload := discoverFixture(t, contract.Fixtures[0], "bmc")
+
+	// Report a new serial number for a component
+	var specs []map[string]interface{}
+	if err := json.Unmarshal(payload, &specs); err != nil {
+		t.Fatal(err)
+	}
+	var oldSerial string
+	for _, spec := range specs {
+		if serial, _ := spec["serialNumber"].(string); serial != "" && spec["deviceType"] != "Node" {
+			oldSerial = serial
+			spec["serialNumber"] = "REPLACED-1"
+			break
+		}
+	}
+	if oldSerial == "" {
+		t.Fatal("fixture has no component with a serial number")
+	}
+	replaced, err := json.Marshal(specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer SetChangePolicy(changePolicy)
+	SetChangePolicy(changecontrol.Policy{SerialRewrites: true})
+	reconcileGolden(t, r, "replaced", replaced)
+
+	var held *pendingchange.PendingChange
+	for _, other := range client.others {
+		if change, ok := other.(*pendingchange.PendingChange); ok {
+			held = change
+		}
+	}
+	if held == nil || held.Spec.Serial == nil || held.Spec.Serial.OldSerialNumber != oldSerial || held.Spec.Serial.NewSerialNumber != "REPLACED-1" {
+		t.Fatalf("pending change %+v, want the rewrite of %s", held, oldSerial)
+	}
+	dev := client.devices[held.Spec.Serial.DeviceID]
+	if dev == nil || dev.Spec.SerialNumber != oldSerial {
+		t.Fatalf("device serial rewritten before approval: %+v", dev)
+	}
+
+	changes := NewDefaultPendingChangeReconciler(client, nil)
+	held.Status.Phase = pendingchange.PhaseApproved
+	if err := changes.reconcilePendingChange(context.Background(), held); err != nil {
+		t.Fatal(err)
+	}
+	if held.Status.Phase != pendingchange.PhaseApplied {
+		t.Fatalf("change ended %s: %s %v", held.Status.Phase, held.Status.Message, held.Status.Errors)
+	}
+	if dev := client.devices[held.Spec.Serial.DeviceID]; dev.Spec.SerialNumber != "REPLACED-1" {
+		t.Errorf("device serial %s after approval, want REPLACED-1", dev.Spec.SerialNumber)
+	}
+	stubs := 0
+	for _, other := range client.others {
+		if _, ok := other.(*serviceevent.ServiceEvent); ok {
+			stubs++
+		}
+	}
+	if stubs != 1 {
+		t.Errorf("%d replacement stubs recorded, want 1", stubs)
+	}
+}
+
+// TestRetireApproval retires the devices of an approved change, and leaves
+// those of a pending one alone.
+func TestRetireApproval(t *testing.T) {
+	client, _, _ := discoverFixture(t, contract.Fixtures[0], "bmc")
+
+	change, err := changecontrol.New(pendingchange.ActionRetire, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for uid := range client.devices {
+		change.Spec.Devices = append(change.Spec.Devices, uid)
+	}
+	r := NewDefaultPendingChangeReconciler(client, nil)
+	if err := r.reconcilePendingChange(context.Background(), change); err != nil {
+		t.Fatal(err)
+	}
+	for _, dev := range client.devices {
+		if prune.IsRetired(dev) {
+			t.Fatalf("%s retired before approval", dev.GetName())
+		}
+	}
+
+	change.Status.Phase = pendingchange.PhaseApproved
+	if err := r.reconcilePendingChange(context.Background(), change); err != nil {
+		t.Fatal(err)
+	}
+	if change.Status.Phase != pendingchange.PhaseApplied || change.Status.Applied != len(client.devices) {
+		t.Fatalf("change ended %s with %d applied: %s", change.Status.Phase, change.Status.Applied, change.Status.Message)
+	}
+	for _, dev := range client.devices {
+		if !prune.IsRetired(dev) {
+			t.Errorf("%s is %q after an approved retirement", dev.GetName(), dev.Status.Phase)
+		}
+	}
+}
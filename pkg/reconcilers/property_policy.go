@@ -0,0 +1,29 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It applies the site's property policy before devices are stored.
+package reconcilers
+
+import (
+	"github.com/example/inventory-v3/pkg/redact"
+)
+
+// propertyPolicy removes and hashes the device data the site may not store;
+// nil keeps it all.
+var propertyPolicy *redact.Policy
+
+// SetPropertyPolicy sets the policy applied to every device a snapshot
+// reports, whether or not its collector applied it. Call it before the
+// reconcilers run.
+func SetPropertyPolicy(p *redact.Policy) {
+	propertyPolicy = p
+}
+
+// serialReplaced reports whether a device stored with oldSerial and now
+// reported with newSerial was replaced. A serial number stored before the
+// policy hashed serial numbers is the same part as its hash.
+func serialReplaced(oldSerial, newSerial string) bool {
+	return oldSerial != "" && newSerial != "" && oldSerial != newSerial && propertyPolicy.Hashed(oldSerial) != newSerial
+}
@@ -0,0 +1,72 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It registers the reconcilers the way the server runs them: each one
+// tracked for the admin endpoint, and the DiscoverySnapshot reconciler behind
+// its priority lanes.
+package reconcilers
+
+import (
+	"fmt"
+
+	"github.com/openchami/fabrica/pkg/events"
+	"github.com/openchami/fabrica/pkg/reconcile"
+)
+
+// constructors build the reconciler of each kind in GetRegisteredReconcilers.
+var constructors = map[string]func(reconcile.ClientInterface, events.EventBus) reconcile.Reconciler{
+	"Device": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultDeviceReconciler(c, b)
+	},
+	"DiscoverySnapshot": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultDiscoverySnapshotReconciler(c, b)
+	},
+	"BMCEndpoint": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultBMCEndpointReconciler(c, b)
+	},
+	"ServiceEvent": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultServiceEventReconciler(c, b)
+	},
+	"MaintenanceWindow": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultMaintenanceWindowReconciler(c, b)
+	},
+	"Group": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultGroupReconciler(c, b)
+	},
+	"Connection": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultConnectionReconciler(c, b)
+	},
+	"PendingChange": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultPendingChangeReconciler(c, b)
+	},
+	"Alert": func(c reconcile.ClientInterface, b events.EventBus) reconcile.Reconciler {
+		return NewDefaultAlertReconciler(c, b)
+	},
+}
+
+// Register registers the reconcilers with the controller in place of
+// RegisterReconcilers. Each one is tracked, so that the admin endpoint can
+// report and pause it, and DiscoverySnapshots are queued by priority before
+// they reach theirs.
+//
+// Call SetSnapshotWorkers before Register.
+func Register(controller *reconcile.Controller, client reconcile.ClientInterface, eventBus events.EventBus) error {
+	for _, kind := range GetRegisteredReconcilers() {
+		construct, ok := constructors[kind]
+		if !ok {
+			return fmt.Errorf("no constructor for the %s reconciler", kind)
+		}
+		requeue := controllerRequeue(controller, kind)
+		var reconciler reconcile.Reconciler = track(construct(client, eventBus), requeue)
+		if kind == "DiscoverySnapshot" {
+			snapshotQueue = newSnapshotLanes(reconciler, requeue, snapshotWorkers)
+			reconciler = snapshotQueue
+		}
+		if err := controller.RegisterReconciler(reconciler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -41,6 +41,41 @@ func RegisterReconcilers(controller *reconcile.Controller, client reconcile.Clie
 	if err := controller.RegisterReconciler(discoverysnapshotsReconciler); err != nil {
 		return err
 	}
+	// Register BMCEndpoint reconciler
+	bmcendpointsReconciler := NewDefaultBMCEndpointReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(bmcendpointsReconciler); err != nil {
+		return err
+	}
+	// Register ServiceEvent reconciler
+	serviceeventsReconciler := NewDefaultServiceEventReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(serviceeventsReconciler); err != nil {
+		return err
+	}
+	// Register MaintenanceWindow reconciler
+	maintenancewindowsReconciler := NewDefaultMaintenanceWindowReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(maintenancewindowsReconciler); err != nil {
+		return err
+	}
+	// Register Group reconciler
+	groupsReconciler := NewDefaultGroupReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(groupsReconciler); err != nil {
+		return err
+	}
+	// Register Connection reconciler
+	connectionsReconciler := NewDefaultConnectionReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(connectionsReconciler); err != nil {
+		return err
+	}
+	// Register PendingChange reconciler
+	pendingchangesReconciler := NewDefaultPendingChangeReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(pendingchangesReconciler); err != nil {
+		return err
+	}
+	// Register Alert reconciler
+	alertsReconciler := NewDefaultAlertReconciler(client, eventBus)
+	if err := controller.RegisterReconciler(alertsReconciler); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -52,5 +87,12 @@ func GetRegisteredReconcilers() []string {
 	return []string{
 		"Device",
 		"DiscoverySnapshot",
+		"BMCEndpoint",
+		"ServiceEvent",
+		"MaintenanceWindow",
+		"Group",
+		"Connection",
+		"PendingChange",
+		"Alert",
 	}
 }
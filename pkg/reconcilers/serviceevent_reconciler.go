@@ -0,0 +1,74 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+// This file contains user-customizable reconciliation logic for ServiceEvent.
+//
+// ⚠️ This file is safe to edit - it will NOT be overwritten by code generation.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+	"github.com/openchami/fabrica/pkg/reconcile"
+	fabResource "github.com/openchami/fabrica/pkg/resource"
+)
+
+// reconcileServiceEvent derives the event's phase: a stub until it has a
+// ticket, then open until it has a closing date.
+func (r *ServiceEventReconciler) reconcileServiceEvent(ctx context.Context, res *serviceevent.ServiceEvent) error {
+	switch {
+	case res.Spec.ClosedAt != nil:
+		res.Status.Phase = serviceevent.PhaseClosed
+		res.Status.Message = fmt.Sprintf("Closed at %s.", res.Spec.ClosedAt.Format(time.RFC3339))
+		res.Status.Ready = true
+	case res.Spec.TicketID == "":
+		res.Status.Phase = serviceevent.PhaseStub
+		res.Status.Message = "No service ticket is linked yet."
+		res.Status.Ready = false
+	default:
+		res.Status.Phase = serviceevent.PhaseOpen
+		res.Status.Message = fmt.Sprintf("Ticket %s is open for %d device(s).", res.Spec.TicketID, len(res.Spec.DeviceIDs))
+		res.Status.Ready = true
+	}
+	return nil
+}
+
+// createReplacementStub records a detected replacement as a ServiceEvent stub
+// linked to the device whose slot reported a new serial number.
+func createReplacementStub(ctx context.Context, client reconcile.ClientInterface, deviceUID string, replacement serviceevent.Replacement) (*serviceevent.ServiceEvent, error) {
+	event := &serviceevent.ServiceEvent{
+		Resource: fabResource.Resource{
+			APIVersion:    "v1",
+			Kind:          "ServiceEvent",
+			SchemaVersion: "v1",
+		},
+		Spec: serviceevent.ServiceEventSpec{
+			DeviceIDs:   []string{deviceUID},
+			Summary:     fmt.Sprintf("Serial number in %s changed from %s to %s", replacement.Slot, replacement.OldSerialNumber, replacement.NewSerialNumber),
+			OpenedAt:    &replacement.DetectedAt,
+			Replacement: &replacement,
+		},
+		Status: serviceevent.ServiceEventStatus{
+			Phase:   serviceevent.PhaseStub,
+			Message: "No service ticket is linked yet.",
+		},
+	}
+
+	uid, err := fabResource.GenerateUIDForResource("ServiceEvent")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate UID for service event: %w", err)
+	}
+	now := time.Now()
+	event.Metadata.UID = uid
+	event.Metadata.Name = uid
+	event.Metadata.CreatedAt = now
+	event.Metadata.UpdatedAt = now
+
+	if err := client.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to create service event for %s: %w", replacement.Slot, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It retires the devices of a decommissioned BMC.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// reconcileDecommission processes a deletion snapshot: its BMC was
+// decommissioned, so every device last reported by it is retired, as the
+// decommission API retires a node. A device reported again by a later
+// snapshot is reinstated.
+func (r *DiscoverySnapshotReconciler) reconcileDecommission(ctx context.Context, snapshot *discoverysnapshot.DiscoverySnapshot) error {
+	logName := snapshotLogName(snapshot)
+	source := snapshot.Spec.BMCAddress
+	if source == "" {
+		snapshot.Status.Phase = "Error"
+		snapshot.Status.Message = "Decommissioned snapshot does not name its BMC."
+		return nil
+	}
+
+	lookup, err := r.newDeviceLookup(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to load existing devices: %w", err)
+	}
+	hold, err := r.holdSnapshot(ctx, snapshot, lookup, nil, source)
+	if err != nil {
+		return err
+	}
+	if hold != nil {
+		r.hold(snapshot, hold, source)
+		return nil
+	}
+	now := time.Now()
+	changes := &discoverysnapshot.DeviceChanges{}
+	failed := 0
+	devices, err := lookup.BySource(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to load the devices of %s: %w", source, err)
+	}
+	for _, dev := range devices {
+		if prune.IsRetired(dev) {
+			continue
+		}
+		r.Logger.Infof("Reconciling %s: Retiring %s of decommissioned BMC %s", logName, dev.GetName(), source)
+		dev.Status.Phase = prune.PhaseRetired
+		dev.Status.Message = fmt.Sprintf("Retired when BMC %s was decommissioned (%s) at %s", source, snapshot.GetName(), now.Format(time.RFC3339))
+		dev.Metadata.UpdatedAt = now
+		if err := r.Client.Update(ctx, dev); err != nil {
+			r.Logger.Errorf("Reconciling %s: Failed to retire %s: %v", logName, dev.GetName(), err)
+			failed++
+			continue
+		}
+		changes.Retired = append(changes.Retired, dev.GetUID())
+	}
+
+	snapshot.Status.Phase = "Completed"
+	snapshot.Status.Message = fmt.Sprintf("BMC %s decommissioned. %d devices retired.", source, len(changes.Retired))
+	if failed > 0 {
+		snapshot.Status.Message += fmt.Sprintf(" %d devices failed to update.", failed)
+	}
+	snapshot.Status.Ready = true
+	snapshot.Status.Devices = changes
+	r.Logger.Infof("Reconciling %s: Successfully reconciled", logName)
+	return nil
+}
@@ -0,0 +1,60 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// TestDecommissionedSnapshot retires the devices of a BMC with a deletion
+// snapshot, and reinstates them when the BMC reports them again.
+func TestDecommissionedSnapshot(t *testing.T) {
+	client, r, payload := discoverFixture(t, contract.Fixtures[0], "bmc")
+
+	snapshot := &discoverysnapshot.DiscoverySnapshot{
+		Spec: discoverysnapshot.DiscoverySnapshotSpec{RawData: json.RawMessage("[]"), BMCAddress: "bmc", Decommissioned: true},
+	}
+	snapshot.Metadata.Name = "decommission"
+	if err := snapshot.Validate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.reconcileDiscoverySnapshot(context.Background(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.Phase != "Completed" {
+		t.Fatalf("snapshot ended %s: %s", snapshot.Status.Phase, snapshot.Status.Message)
+	}
+	for _, dev := range client.devices {
+		if !prune.IsRetired(dev) {
+			t.Errorf("%s is %q after its BMC was decommissioned", dev.GetName(), dev.Status.Phase)
+		}
+	}
+	if changes := snapshot.Status.Devices; changes == nil || len(changes.Retired) != len(client.devices) {
+		t.Errorf("snapshot records %+v as retired, want all %d devices", changes, len(client.devices))
+	}
+
+	reconcileGolden(t, r, "reported", payload)
+	for _, dev := range client.devices {
+		if prune.IsRetired(dev) {
+			t.Errorf("%s still retired after it was reported again", dev.GetName())
+		}
+	}
+}
+
+// TestDecommissionedSnapshotValidation rejects deletion snapshots that report
+// devices or no BMC.
+func TestDecommissionedSnapshotValidation(t *testing.T) {
+	for name, spec := range map[string]discoverysnapshot.DiscoverySnapshotSpec{
+		"devices":    {RawData: json.RawMessage(`[{"deviceType":"Node"}]`), BMCAddress: "bmc", Decommissioned: true},
+		"no address": {RawData: json.RawMessage("[]"), Decommissioned: true},
+	} {
+		snapshot := &discoverysnapshot.DiscoverySnapshot{Spec: spec}
+		if err := snapshot.Validate(context.Background()); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
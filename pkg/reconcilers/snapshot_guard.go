@@ -0,0 +1,157 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It holds snapshots that would change an unusual share of the inventory
+// until an operator approves them.
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// ChangeGuard bounds how much of the inventory one snapshot may retire or
+// replace before it is held for an operator's approval, guarding against a
+// buggy collector wiping good inventory data. A zero percentage disables
+// its check.
+type ChangeGuard struct {
+	// BMCPercent bounds the share of its BMC's devices a snapshot may leave
+	// out or replace. Deletion snapshots retire them all, and are exempt.
+	BMCPercent float64
+	// FleetPercent bounds the share of the fleet's devices.
+	FleetPercent float64
+	// MinDevices exempts BMCs, and fleets, with fewer active devices.
+	MinDevices int
+}
+
+// changeGuard is the guard snapshots are checked against; the zero value
+// applies every snapshot.
+var changeGuard ChangeGuard
+
+// SetChangeGuard sets the guard snapshots are checked against. Call it
+// before the reconcilers run.
+func SetChangeGuard(g ChangeGuard) {
+	changeGuard = g
+}
+
+// holdSnapshot checks the changes applying the snapshot would make against
+// the change guard, and returns the hold when they exceed it. Snapshots an
+// operator approved are not checked again.
+func (r *DiscoverySnapshotReconciler) holdSnapshot(ctx context.Context, snapshot *discoverysnapshot.DiscoverySnapshot, lookup deviceLookup, specs []device.DeviceSpec, source string) (*discoverysnapshot.Hold, error) {
+	g := changeGuard
+	if (g.BMCPercent <= 0 && g.FleetPercent <= 0) || source == "" || snapshot.Status.Review != nil {
+		return nil, nil
+	}
+	active, unreported, replaced, err := snapshotImpact(ctx, lookup, snapshot, specs, source)
+	if err != nil {
+		return nil, err
+	}
+	changed := unreported + replaced
+	if changed == 0 {
+		return nil, nil
+	}
+	hold := &discoverysnapshot.Hold{Unreported: unreported, Replaced: replaced}
+	if g.BMCPercent > 0 && !snapshot.Spec.Decommissioned && active >= g.MinDevices {
+		if percent := 100 * float64(changed) / float64(active); percent > g.BMCPercent {
+			hold.Scope, hold.Devices, hold.Percent, hold.Limit = "bmc", active, percent, g.BMCPercent
+			return hold, nil
+		}
+	}
+	if g.FleetPercent > 0 {
+		fleet, err := r.countActiveDevices(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count the fleet's devices: %w", err)
+		}
+		if fleet < g.MinDevices {
+			return nil, nil
+		}
+		if percent := 100 * float64(changed) / float64(fleet); percent > g.FleetPercent {
+			hold.Scope, hold.Devices, hold.Percent, hold.Limit = "fleet", fleet, percent, g.FleetPercent
+			return hold, nil
+		}
+	}
+	return nil, nil
+}
+
+// snapshotImpact counts the active devices last reported by source, and of
+// them those the snapshot would flag missing (or retire, for a deletion
+// snapshot) and those it reports with a new serial number.
+func snapshotImpact(ctx context.Context, lookup deviceLookup, snapshot *discoverysnapshot.DiscoverySnapshot, specs []device.DeviceSpec, source string) (active, unreported, replaced int, err error) {
+	reported := make(map[string]device.DeviceSpec, len(specs))
+	for _, spec := range specs {
+		if key, err := uriKey(spec); err == nil {
+			reported[key] = spec
+		}
+	}
+	previous, err := lookup.BySource(ctx, source)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	byUID := make(map[string]*device.Device, len(previous))
+	for _, dev := range previous {
+		byUID[dev.GetUID()] = dev
+	}
+	for _, dev := range previous {
+		if prune.IsRetired(dev) || manifest.IsExpected(dev) {
+			continue
+		}
+		active++
+		if snapshot.Spec.Decommissioned {
+			unreported++
+			continue
+		}
+		key, err := deviceKey(dev)
+		if err != nil {
+			continue
+		}
+		spec, ok := reported[key]
+		switch {
+		case ok && serialReplaced(dev.Spec.SerialNumber, spec.SerialNumber):
+			replaced++
+		case !ok && !isUnreported(dev) && snapshot.Spec.Profile != bmcendpoint.ProfileQuick && collected(snapshot.Spec.Coverage, dev, owningNode(dev, byUID)):
+			unreported++
+		}
+	}
+	return active, unreported, replaced, nil
+}
+
+// countActiveDevices counts the devices of the fleet that are not retired.
+func (r *DiscoverySnapshotReconciler) countActiveDevices(ctx context.Context) (int, error) {
+	items, err := r.Client.List(ctx, "Device")
+	if err != nil {
+		return 0, err
+	}
+	active := 0
+	for _, item := range items {
+		if dev, ok := item.(*device.Device); ok && !prune.IsRetired(dev) && !manifest.IsExpected(dev) {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// holdMessage explains a hold in the snapshot's status message.
+func holdMessage(hold *discoverysnapshot.Hold, source string) string {
+	scope := "the fleet's"
+	if hold.Scope == "bmc" {
+		scope = fmt.Sprintf("BMC %s's", source)
+	}
+	return fmt.Sprintf("Held for approval: the snapshot would flag missing or retire %d and replace %d of %s %d devices (%.0f%%, over the %.0f%% limit). Approve or reject it.",
+		hold.Unreported, hold.Replaced, scope, hold.Devices, hold.Percent, hold.Limit)
+}
+
+// hold leaves the snapshot unapplied, in phase Held, until it is reviewed.
+func (r *DiscoverySnapshotReconciler) hold(snapshot *discoverysnapshot.DiscoverySnapshot, hold *discoverysnapshot.Hold, source string) {
+	snapshot.Status.Phase = discoverysnapshot.PhaseHeld
+	snapshot.Status.Message = holdMessage(hold, source)
+	snapshot.Status.Hold = hold
+	r.Logger.Warnf("Reconciling %s: %s", snapshotLogName(snapshot), snapshot.Status.Message)
+}
@@ -0,0 +1,60 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/contract"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// TestChangeGuard holds a snapshot that leaves out every device of its BMC,
+// and applies it once it is approved.
+func TestChangeGuard(t *testing.T) {
+	defer SetChangeGuard(changeGuard)
+	SetChangeGuard(ChangeGuard{BMCPercent: 50, FleetPercent: 90, MinDevices: 1})
+	client, r, _ := discoverFixture(t, contract.Fixtures[0], "bmc")
+
+	snapshot := &discoverysnapshot.DiscoverySnapshot{
+		Spec: discoverysnapshot.DiscoverySnapshotSpec{RawData: json.RawMessage("[]"), BMCAddress: "bmc"},
+	}
+	snapshot.Metadata.Name = "empty"
+	if err := r.reconcileDiscoverySnapshot(context.Background(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.Phase != discoverysnapshot.PhaseHeld {
+		t.Fatalf("snapshot ended %s: %s", snapshot.Status.Phase, snapshot.Status.Message)
+	}
+	hold := snapshot.Status.Hold
+	if hold == nil || hold.Scope != "bmc" || hold.Unreported != len(client.devices) || hold.Devices != len(client.devices) {
+		t.Fatalf("hold %+v, want all %d devices of the BMC", hold, len(client.devices))
+	}
+	for _, dev := range client.devices {
+		if isUnreported(dev) {
+			t.Fatalf("%s flagged %s by a held snapshot", dev.GetName(), dev.Status.Phase)
+		}
+	}
+
+	// Held snapshots are left alone until they are reviewed
+	if err := r.reconcileDiscoverySnapshot(context.Background(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.Phase != discoverysnapshot.PhaseHeld {
+		t.Fatalf("held snapshot ended %s on requeue", snapshot.Status.Phase)
+	}
+
+	snapshot.Status.Phase = ""
+	snapshot.Status.Review = &discoverysnapshot.Review{Approved: true}
+	if err := r.reconcileDiscoverySnapshot(context.Background(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Status.Phase != "Completed" {
+		t.Fatalf("approved snapshot ended %s: %s", snapshot.Status.Phase, snapshot.Status.Message)
+	}
+	for _, dev := range client.devices {
+		if !isUnreported(dev) {
+			t.Errorf("%s is %q after an approved snapshot left it out", dev.GetName(), dev.Status.Phase)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+// This file is safe to edit.
+// It queues DiscoverySnapshots by priority, so that a snapshot an operator is
+// waiting on is reconciled ahead of a fleet-wide scheduled collection.
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/openchami/fabrica/pkg/reconcile"
+)
+
+// DefaultSnapshotWorkers is how many DiscoverySnapshots are reconciled at
+// once by default.
+const DefaultSnapshotWorkers = 4
+
+// snapshotWorkers is how many DiscoverySnapshots are reconciled at once.
+var snapshotWorkers = DefaultSnapshotWorkers
+
+// snapshotQueue is the queue of the registered DiscoverySnapshot reconciler.
+var snapshotQueue *snapshotLanes
+
+// SetSnapshotWorkers sets how many DiscoverySnapshots are reconciled at once.
+// Call it before Register.
+func SetSnapshotWorkers(n int) {
+	if n > 0 {
+		snapshotWorkers = n
+	}
+}
+
+// Snapshot lanes, in the order workers take from them.
+const (
+	laneInteractive = iota
+	laneScheduled
+	laneCount
+)
+
+// laneNames name the lanes in metrics.
+var laneNames = [laneCount]string{discoverysnapshot.PriorityInteractive, discoverysnapshot.PriorityScheduled}
+
+// snapshotLanes is the reconciler registered for DiscoverySnapshots. The
+// controller's work queue is first in, first out, so an interactive snapshot
+// posted during a fleet-wide collection would wait behind every scheduled
+// one. snapshotLanes takes snapshots off that queue as they arrive and queues
+// them in two lanes of its own. Its workers take interactive snapshots before
+// scheduled ones, and when there are several workers one of them takes only
+// interactive snapshots, so that one is free whenever an operator posts one.
+type snapshotLanes struct {
+	inner reconcile.Reconciler
+	// requeue asks the controller to reconcile the snapshot again after the
+	// delay; the controller reloads it and hands it back to the lanes.
+	requeue func(uid string, after time.Duration)
+
+	mu      sync.Mutex
+	ready   *sync.Cond
+	lanes   [laneCount][]string
+	pending map[string]json.RawMessage // queued snapshots by UID
+	stopped bool
+}
+
+// newSnapshotLanes queues the snapshots for inner, reconciling them with
+// workers goroutines.
+func newSnapshotLanes(inner reconcile.Reconciler, requeue func(uid string, after time.Duration), workers int) *snapshotLanes {
+	q := &snapshotLanes{inner: inner, requeue: requeue, pending: map[string]json.RawMessage{}}
+	q.ready = sync.NewCond(&q.mu)
+	for i := 0; i < workers; i++ {
+		go q.work(workers > 1 && i == 0)
+	}
+	return q
+}
+
+// controllerRequeue requeues resources of kind through controller.
+func controllerRequeue(controller *reconcile.Controller, kind string) func(string, time.Duration) {
+	return func(uid string, after time.Duration) {
+		request := reconcile.ReconcileRequest{ResourceKind: kind, ResourceUID: uid, Reason: "requeue"}
+		if after <= 0 {
+			controller.Enqueue(request)
+			return
+		}
+		controller.EnqueueAfter(request, after)
+	}
+}
+
+// GetResourceKind implements reconcile.Reconciler.
+func (q *snapshotLanes) GetResourceKind() string {
+	return q.inner.GetResourceKind()
+}
+
+// Reconcile implements reconcile.Reconciler. It queues the snapshot in the
+// lane of its priority and returns at once. Completed and held snapshots
+// are left as they are, which is done straight away.
+func (q *snapshotLanes) Reconcile(ctx context.Context, resource interface{}) (reconcile.Result, error) {
+	raw, ok := resource.(json.RawMessage)
+	if !ok {
+		return q.inner.Reconcile(ctx, resource)
+	}
+	var snapshot discoverysnapshot.DiscoverySnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil || snapshot.Status.Phase == "Completed" || snapshot.Status.Phase == discoverysnapshot.PhaseHeld {
+		return q.inner.Reconcile(ctx, resource)
+	}
+	lane := laneScheduled
+	if snapshot.Spec.Priority == discoverysnapshot.PriorityInteractive {
+		lane = laneInteractive
+	}
+	q.add(snapshot.GetUID(), lane, raw)
+	return reconcile.Result{}, nil
+}
+
+// add queues a snapshot. A snapshot already queued keeps its place and is
+// reconciled as of its latest version.
+func (q *snapshotLanes) add(uid string, lane int, raw json.RawMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, queued := q.pending[uid]; !queued {
+		q.lanes[lane] = append(q.lanes[lane], uid)
+	}
+	q.pending[uid] = raw
+	q.ready.Broadcast()
+}
+
+// next waits for the next snapshot to reconcile: the oldest interactive one,
+// else, unless interactiveOnly, the oldest scheduled one. It returns false
+// once the lanes are stopped.
+func (q *snapshotLanes) next(interactiveOnly bool) (string, json.RawMessage, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.stopped {
+			return "", nil, false
+		}
+		for lane := range q.lanes {
+			if interactiveOnly && lane != laneInteractive {
+				break
+			}
+			if len(q.lanes[lane]) > 0 {
+				uid := q.lanes[lane][0]
+				q.lanes[lane] = q.lanes[lane][1:]
+				raw := q.pending[uid]
+				delete(q.pending, uid)
+				return uid, raw, true
+			}
+		}
+		q.ready.Wait()
+	}
+}
+
+// work reconciles queued snapshots until the lanes are stopped, requeuing
+// them as the controller would.
+func (q *snapshotLanes) work(interactiveOnly bool) {
+	for {
+		uid, raw, ok := q.next(interactiveOnly)
+		if !ok {
+			return
+		}
+		result, err := q.inner.Reconcile(context.Background(), raw)
+		switch {
+		case result.Requeue:
+			q.requeue(uid, 0)
+		case result.RequeueAfter > 0:
+			q.requeue(uid, result.RequeueAfter)
+		case err != nil:
+			q.requeue(uid, 30*time.Second)
+		}
+	}
+}
+
+// stop ends the workers once they finish the snapshots they are reconciling.
+func (q *snapshotLanes) stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stopped = true
+	q.ready.Broadcast()
+}
+
+// depth returns how many snapshots wait in each lane.
+func (q *snapshotLanes) depth() [laneCount]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var depth [laneCount]int
+	for lane := range q.lanes {
+		depth[lane] = len(q.lanes[lane])
+	}
+	return depth
+}
+
+// SnapshotQueueMetrics renders how many DiscoverySnapshots wait in each lane
+// in the Prometheus text format. It is empty until Register runs.
+func SnapshotQueueMetrics() []byte {
+	if snapshotQueue == nil {
+		return nil
+	}
+	var out []byte
+	out = append(out, "# HELP inventory_snapshot_queue_depth DiscoverySnapshots waiting to be reconciled, by priority lane.\n"...)
+	out = append(out, "# TYPE inventory_snapshot_queue_depth gauge\n"...)
+	for lane, depth := range snapshotQueue.depth() {
+		out = append(out, fmt.Sprintf("inventory_snapshot_queue_depth{lane=%q} %d\n", laneNames[lane], depth)...)
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/openchami/fabrica/pkg/reconcile"
+)
+
+// laneRecorder records the snapshots it reconciles, holding each until it
+// is released.
+type laneRecorder struct {
+	started chan string
+	release chan struct{}
+}
+
+func (r *laneRecorder) GetResourceKind() string { return "DiscoverySnapshot" }
+
+func (r *laneRecorder) Reconcile(ctx context.Context, resource interface{}) (reconcile.Result, error) {
+	var snapshot discoverysnapshot.DiscoverySnapshot
+	if err := json.Unmarshal(resource.(json.RawMessage), &snapshot); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.started <- snapshot.GetUID()
+	<-r.release
+	return reconcile.Result{}, nil
+}
+
+// laneSnapshot encodes a snapshot of the priority.
+func laneSnapshot(uid, priority string) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"metadata":{"uid":%q},"spec":{"rawData":[],"priority":%q}}`, uid, priority))
+}
+
+// awaitStart returns the next snapshot the recorder starts.
+func (r *laneRecorder) awaitStart(t *testing.T) string {
+	t.Helper()
+	select {
+	case uid := <-r.started:
+		return uid
+	case <-time.After(5 * time.Second):
+		t.Fatal("no snapshot was reconciled")
+		return ""
+	}
+}
+
+// TestSnapshotLanesOrder reconciles interactive snapshots before the
+// scheduled ones queued ahead of them, and each snapshot queued twice once.
+func TestSnapshotLanesOrder(t *testing.T) {
+	recorder := &laneRecorder{started: make(chan string, 10), release: make(chan struct{})}
+	q := newSnapshotLanes(recorder, func(string, time.Duration) {}, 1)
+	defer q.stop()
+
+	ctx := context.Background()
+	q.Reconcile(ctx, laneSnapshot("s1", ""))
+	if uid := recorder.awaitStart(t); uid != "s1" {
+		t.Fatalf("started %s, want s1", uid)
+	}
+	q.Reconcile(ctx, laneSnapshot("s2", discoverysnapshot.PriorityScheduled))
+	q.Reconcile(ctx, laneSnapshot("s3", ""))
+	q.Reconcile(ctx, laneSnapshot("s2", discoverysnapshot.PriorityScheduled))
+	q.Reconcile(ctx, laneSnapshot("i1", discoverysnapshot.PriorityInteractive))
+	if depth := q.depth(); depth != [laneCount]int{1, 2} {
+		t.Fatalf("lane depth %v, want [1 2]", depth)
+	}
+
+	var order []string
+	for range 3 {
+		recorder.release <- struct{}{}
+		order = append(order, recorder.awaitStart(t))
+	}
+	recorder.release <- struct{}{}
+	if want := []string{"i1", "s2", "s3"}; !slices.Equal(order, want) {
+		t.Fatalf("reconciled %v, want %v", order, want)
+	}
+}
+
+// TestSnapshotLanesReserve keeps a worker for interactive snapshots while
+// the others are busy with scheduled ones.
+func TestSnapshotLanesReserve(t *testing.T) {
+	recorder := &laneRecorder{started: make(chan string, 10), release: make(chan struct{})}
+	q := newSnapshotLanes(recorder, func(string, time.Duration) {}, 2)
+	defer q.stop()
+
+	ctx := context.Background()
+	q.Reconcile(ctx, laneSnapshot("s1", ""))
+	q.Reconcile(ctx, laneSnapshot("s2", ""))
+	if uid := recorder.awaitStart(t); uid != "s1" {
+		t.Fatalf("started %s, want s1", uid)
+	}
+	q.Reconcile(ctx, laneSnapshot("i1", discoverysnapshot.PriorityInteractive))
+	if uid := recorder.awaitStart(t); uid != "i1" {
+		t.Fatalf("started %s while s1 was reconciled, want i1", uid)
+	}
+	recorder.release <- struct{}{}
+	recorder.release <- struct{}{}
+	if uid := recorder.awaitStart(t); uid != "s2" {
+		t.Fatalf("started %s, want s2", uid)
+	}
+	recorder.release <- struct{}{}
+}
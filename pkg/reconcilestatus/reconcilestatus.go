@@ -0,0 +1,42 @@
+// Package reconcilestatus describes what the server's reconcilers are doing,
+// as the admin endpoint reports it.
+package reconcilestatus
+
+import "time"
+
+// Status is the state of background processing: every reconciler, and the
+// DiscoverySnapshot priority lanes.
+type Status struct {
+	Reconcilers []Reconciler `json:"reconcilers"`
+	// SnapshotQueue counts the snapshots waiting in each priority lane.
+	SnapshotQueue   map[string]int `json:"snapshotQueue"`
+	SnapshotWorkers int            `json:"snapshotWorkers"`
+}
+
+// Reconciler is what the reconciler of a kind has done.
+type Reconciler struct {
+	Kind    string `json:"kind"`
+	Enabled bool   `json:"enabled"`
+	// Reconciled and Failed count the reconciliations since the server started.
+	Reconciled int64 `json:"reconciled"`
+	Failed     int64 `json:"failed"`
+	// Paused counts the resources that changed while the reconciler was
+	// disabled; they are reconciled when it is enabled again.
+	Paused         int        `json:"paused,omitempty"`
+	InFlight       []InFlight `json:"inFlight,omitempty"`
+	LastReconciled *time.Time `json:"lastReconciled,omitempty"`
+	LastError      *LastError `json:"lastError,omitempty"`
+}
+
+// InFlight is a resource being reconciled.
+type InFlight struct {
+	UID   string    `json:"uid"`
+	Since time.Time `json:"since"`
+}
+
+// LastError is the latest failed reconciliation of a kind.
+type LastError struct {
+	UID     string    `json:"uid"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
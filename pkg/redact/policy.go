@@ -0,0 +1,235 @@
+package redact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// --- Site Property Policy ---
+//
+// Besides credentials, which are always stripped, a site may prohibit
+// storing other device data: host serial numbers it considers sensitive, or
+// OEM blocks with network details. A Policy lists what to keep, drop or
+// hash. The collector applies it before posting a snapshot and the server
+// before storing devices, so that a collector without the policy cannot
+// bring the data in.
+
+// HashPrefix starts every hashed value, so that hashing is not repeated and
+// readers can tell a hash from a reported value.
+const HashPrefix = "hmac-sha256:"
+
+// Fields of a device spec a Policy can hash or deny, besides its properties.
+// Hashing FieldSerialNumber hashes the parent serial number too, so that
+// parents are still linked; it cannot be denied.
+const (
+	FieldSerialNumber = "serialNumber"
+	FieldPartNumber   = "partNumber"
+	FieldManufacturer = "manufacturer"
+)
+
+// policyFields are the spec fields a Policy names.
+var policyFields = []string{FieldSerialNumber, FieldPartNumber, FieldManufacturer}
+
+// requiredProperties are the properties devices are matched and linked by;
+// a Policy never removes or hashes them.
+var requiredProperties = []string{"redfish_uri", "redfish_parent_uri"}
+
+// Policy is a site's rules for the device data it may store. Patterns are
+// path.Match globs over property keys (e.g. "oem_*"); Deny and Hash may also
+// name the spec fields serialNumber, partNumber and manufacturer. Deny wins
+// over Hash, and Hash over Allow.
+type Policy struct {
+	// Allow, when not empty, keeps only the properties it matches (and
+	// redfish_uri and redfish_parent_uri, which are always kept).
+	Allow []string `json:"allow,omitempty"`
+	// Deny removes the properties and fields it matches.
+	Deny []string `json:"deny,omitempty"`
+	// Hash replaces the values of the properties and fields it matches by
+	// their HMAC-SHA256 under HashKey, so that they still tell devices apart
+	// and match across snapshots without being stored.
+	Hash []string `json:"hash,omitempty"`
+	// HashKey keys the hashes; the collector and server must share it for
+	// their hashes to match. Keep it out of the inventory.
+	HashKey string `json:"hashKey,omitempty"`
+}
+
+// LoadPolicy reads a JSON Policy from path.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read property policy: %w", err)
+	}
+	var p Policy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse property policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("property policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Validate checks the patterns and that hashing has a key.
+func (p *Policy) Validate() error {
+	for _, patterns := range [][]string{p.Allow, p.Deny, p.Hash} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	if len(p.Hash) > 0 && p.HashKey == "" {
+		return fmt.Errorf("hash needs a hashKey")
+	}
+	// Devices need a serial number, and parents are linked by it
+	if matchAny(p.Deny, FieldSerialNumber) {
+		return fmt.Errorf("%s cannot be denied; hash it instead", FieldSerialNumber)
+	}
+	return nil
+}
+
+// Empty reports whether the policy changes nothing.
+func (p *Policy) Empty() bool {
+	return p == nil || (len(p.Allow) == 0 && len(p.Deny) == 0 && len(p.Hash) == 0)
+}
+
+// Apply removes and hashes what the policy says in spec, and returns how
+// many properties and fields it changed. Applying it again changes nothing.
+func (p *Policy) Apply(spec *device.DeviceSpec) int {
+	if p.Empty() {
+		return 0
+	}
+	changed := 0
+	for _, field := range policyFields {
+		values := p.fieldValues(spec, field)
+		switch {
+		case matchAny(p.Deny, field):
+			for _, v := range values {
+				if *v != "" {
+					*v = ""
+					changed++
+				}
+			}
+		case matchAny(p.Hash, field):
+			for _, v := range values {
+				if *v != "" && !strings.HasPrefix(*v, HashPrefix) {
+					*v = p.hash([]byte(*v))
+					changed++
+				}
+			}
+		}
+	}
+
+	for key, value := range spec.Properties {
+		if isRequired(key) {
+			continue
+		}
+		switch {
+		case matchAny(p.Deny, key), len(p.Allow) > 0 && !matchAny(p.Allow, key) && !matchAny(p.Hash, key):
+			delete(spec.Properties, key)
+			changed++
+		case matchAny(p.Hash, key):
+			if hashed, ok := p.hashValue(value); ok {
+				spec.Properties[key] = hashed
+				changed++
+			}
+		default:
+			continue
+		}
+		// The value as reported would give away what was removed or hashed
+		delete(spec.Provenance, key)
+	}
+	return changed
+}
+
+// fieldValues returns the spec fields a policy field name stands for.
+func (p *Policy) fieldValues(spec *device.DeviceSpec, field string) []*string {
+	switch field {
+	case FieldSerialNumber:
+		return []*string{&spec.SerialNumber, &spec.ParentSerialNumber}
+	case FieldPartNumber:
+		return []*string{&spec.PartNumber}
+	case FieldManufacturer:
+		return []*string{&spec.Manufacturer}
+	}
+	return nil
+}
+
+// hashValue hashes a property value: a string, each string of a list, or
+// the JSON of anything else. It reports false when the value is hashed
+// already.
+func (p *Policy) hashValue(raw json.RawMessage) (json.RawMessage, bool) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if strings.HasPrefix(s, HashPrefix) {
+			return raw, false
+		}
+		hashed, _ := json.Marshal(p.hash([]byte(s)))
+		return hashed, true
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		changed := false
+		for i, s := range list {
+			if !strings.HasPrefix(s, HashPrefix) {
+				list[i] = p.hash([]byte(s))
+				changed = true
+			}
+		}
+		hashed, _ := json.Marshal(list)
+		return hashed, changed
+	}
+	var compact bytes.Buffer
+	if json.Compact(&compact, raw) != nil {
+		compact.Reset()
+		compact.Write(raw)
+	}
+	hashed, _ := json.Marshal(p.hash(compact.Bytes()))
+	return hashed, true
+}
+
+// Hashed returns a field value as the policy would hash it, e.g. to tell
+// a serial number stored before hashing was enabled from a replacement. It
+// returns value itself when it is hashed already or the policy hashes
+// nothing.
+func (p *Policy) Hashed(value string) string {
+	if p == nil || p.HashKey == "" || value == "" || strings.HasPrefix(value, HashPrefix) {
+		return value
+	}
+	return p.hash([]byte(value))
+}
+
+func (p *Policy) hash(value []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.HashKey))
+	mac.Write(value)
+	return HashPrefix + hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isRequired(key string) bool {
+	for _, required := range requiredProperties {
+		if key == required {
+			return true
+		}
+	}
+	return false
+}
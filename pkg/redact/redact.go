@@ -0,0 +1,250 @@
+// Package redact keeps credentials out of logs, error messages and stored
+// payloads. BMCs echo more than they should: OEM blocks carry SNMP community
+// strings and LDAP bind passwords, and HTTP errors can quote headers.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Mask replaces every redacted value.
+const Mask = "REDACTED"
+
+// sensitiveKeys are the fragments of field names that hold secrets, compared
+// in lower case without separators.
+var sensitiveKeys = []string{
+	"password",
+	"passwd",
+	"passphrase",
+	"secret",
+	"token",
+	"authorization",
+	"apikey",
+	"privatekey",
+	"credential",
+	"cookie",
+	"community",
+}
+
+// IsSensitiveKey reports whether a field named key holds a secret, whatever
+// its case or separators ("Password", "bind_password", "X-Auth-Token").
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	key = strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(key)
+	for _, fragment := range sensitiveKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON returns the document with the string values of sensitive fields
+// masked, at any depth. A document without any, or that does not parse, is
+// returned unchanged.
+func JSON(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return raw
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return raw
+	}
+	doc, changed := Value(doc, false)
+	if !changed {
+		return raw
+	}
+	redacted, err := json.Marshal(doc)
+	if err != nil {
+		return raw
+	}
+	return redacted
+}
+
+// Properties strips the sensitive fields from a device property map and
+// masks those nested in the remaining values. It returns how many fields it
+// removed or masked.
+func Properties(props map[string]json.RawMessage) int {
+	stripped := 0
+	for key, value := range props {
+		if IsSensitiveKey(key) {
+			delete(props, key)
+			stripped++
+			continue
+		}
+		if masked := JSON(value); !bytes.Equal(masked, value) {
+			props[key] = masked
+			stripped++
+		}
+	}
+	return stripped
+}
+
+// Value masks the string values of sensitive fields in a decoded JSON value,
+// or every string when sensitive is set (the value sits under a sensitive
+// field). It reports whether anything was masked.
+func Value(v interface{}, sensitive bool) (interface{}, bool) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			masked, c := Value(child, sensitive || IsSensitiveKey(key))
+			if c {
+				v[key] = masked
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			masked, c := Value(child, sensitive)
+			if c {
+				v[i] = masked
+				changed = true
+			}
+		}
+	case string:
+		if sensitive && v != "" && v != Mask {
+			return Mask, true
+		}
+	}
+	return v, changed
+}
+
+// Patterns of secrets in free text.
+var (
+	// authHeaderPattern matches an Authorization header with its scheme.
+	authHeaderPattern = regexp.MustCompile(`(?i)\b((?:proxy-)?authorization\s*[:=]\s*)(?:(basic|bearer|digest|negotiate)\s+)?[^\s",;]+`)
+	// tokenHeaderPattern matches the session token and cookie headers.
+	tokenHeaderPattern = regexp.MustCompile(`(?i)\b((?:x-auth-token|set-cookie|cookie)\s*[:=]\s*)[^\s",;]+`)
+	// jsonFieldPattern matches a quoted sensitive field with a string value.
+	jsonFieldPattern = regexp.MustCompile(`(?i)("[\w.-]*(?:password|passwd|passphrase|secret|token|apikey|api_key|community)[\w.-]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// assignmentPattern matches key=value pairs, as in query strings.
+	assignmentPattern = regexp.MustCompile(`(?i)\b([\w.-]*(?:password|passwd|passphrase|secret|token|apikey|api_key)[\w.-]*=)[^\s&",;]+`)
+	// userinfoPattern matches the password in a URL's userinfo.
+	userinfoPattern = regexp.MustCompile(`(\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]*:)[^/\s@]+@`)
+)
+
+// String masks credentials in free text: authorization and token headers,
+// quoted sensitive fields, key=value pairs, URL passwords, and every
+// occurrence of the given secrets.
+func String(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Mask)
+		}
+	}
+	s = authHeaderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		parts := authHeaderPattern.FindStringSubmatch(m)
+		if parts[2] != "" {
+			return parts[1] + parts[2] + " " + Mask
+		}
+		return parts[1] + Mask
+	})
+	s = tokenHeaderPattern.ReplaceAllString(s, "${1}"+Mask)
+	s = jsonFieldPattern.ReplaceAllString(s, `${1}"`+Mask+`"`)
+	s = assignmentPattern.ReplaceAllString(s, "${1}"+Mask)
+	s = userinfoPattern.ReplaceAllString(s, "${1}"+Mask+"@")
+	return s
+}
+
+// Error returns err with its message passed through String, or nil. The
+// original error stays reachable through errors.Is and errors.As.
+func Error(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	message := String(err.Error(), secrets...)
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{message: message, err: err}
+}
+
+// redactedError carries a masked message for an error.
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string { return e.message }
+
+func (e *redactedError) Unwrap() error { return e.err }
+
+// URL returns a URL for display, without the userinfo password and with
+// sensitive query parameters masked. Text that is not a URL goes through
+// String.
+func URL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return String(raw)
+	}
+	query := u.Query()
+	masked := false
+	for key := range query {
+		if IsSensitiveKey(key) {
+			query.Set(key, Mask)
+			masked = true
+		}
+	}
+	if masked {
+		u.RawQuery = query.Encode()
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), Mask)
+	}
+	return u.String()
+}
+
+// Writer masks the secrets and credential patterns of everything written to
+// it before passing it on to w, a line at a time so that a secret split
+// across writes is still found. Close writes out the last partial line.
+type Writer struct {
+	w       io.Writer
+	secrets []string
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+// NewWriter returns a Writer masking secrets on their way to w.
+func NewWriter(w io.Writer, secrets ...string) *Writer {
+	return &Writer{w: w, secrets: secrets}
+}
+
+// Write buffers p and passes on every complete line.
+func (rw *Writer) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.buf.Write(p)
+	for {
+		line, err := rw.buf.ReadString('\n')
+		if err != nil {
+			// No newline yet; keep the partial line for the next write
+			rw.buf.Reset()
+			rw.buf.WriteString(line)
+			return len(p), nil
+		}
+		if _, err := io.WriteString(rw.w, String(line, rw.secrets...)); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Close passes on the buffered partial line, if any.
+func (rw *Writer) Close() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.buf.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(rw.w, String(rw.buf.String(), rw.secrets...))
+	rw.buf.Reset()
+	return err
+}
@@ -0,0 +1,446 @@
+// Package redfishproxy is a caching HTTP proxy for Redfish reads, shared by
+// the tools that talk to a site's BMCs (the collector, firmware tooling), so
+// that a fragile BMC answers each read once per time window however many
+// tools ask. Clients address a BMC through the proxy by prefixing its paths
+// with /bmc/<address>, e.g. GET /bmc/10.0.0.5/redfish/v1/Systems/1.
+//
+// Only GETs answered 200 OK are cached, and only for TTL. A cached response
+// is served to a client whose credentials (basic auth or session token) the
+// BMC accepted within the TTL, so that the cache does not let anyone read a
+// BMC they cannot log in to. Identical GETs in flight at once with the same
+// credentials are sent to the BMC once. Other methods (logins, logouts,
+// PATCHes) pass through, and a successful one other than a login or logout
+// drops the cache of its BMC.
+//
+// The URIs in responses are the BMC's own, not rewritten to the proxy:
+// clients prefix them as they do their own paths.
+package redfishproxy
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PathPrefix precedes the BMC address in the paths the proxy serves.
+const PathPrefix = "/bmc/"
+
+// Defaults of the Proxy settings.
+const (
+	DefaultTTL           = 30 * time.Second
+	DefaultMaxEntries    = 10000
+	DefaultMaxConcurrent = 4
+	// maxBody bounds the responses read from BMCs, and upstreamTimeout how
+	// long one may take.
+	maxBody         = 32 << 20
+	upstreamTimeout = 2 * time.Minute
+)
+
+// Headers forwarded from clients to BMCs and back.
+var (
+	requestHeaders  = []string{"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match", "OData-Version", "X-Auth-Token"}
+	responseHeaders = []string{"Content-Type", "ETag", "Location", "OData-Version", "Retry-After", "WWW-Authenticate", "X-Auth-Token"}
+)
+
+// Stats counts what a Proxy has done.
+type Stats struct {
+	// Hits were served from the cache, Coalesced shared the BMC response
+	// of an identical request in flight, and Misses were sent to the BMC.
+	Hits      int64 `json:"hits"`
+	Coalesced int64 `json:"coalesced"`
+	Misses    int64 `json:"misses"`
+	// Bypassed were GETs that asked not to be served from the cache.
+	Bypassed int64 `json:"bypassed"`
+	// PassedThrough were requests other than GETs.
+	PassedThrough int64 `json:"passedThrough"`
+	// Denied were requests for BMCs outside the allowed networks.
+	Denied         int64 `json:"denied"`
+	UpstreamErrors int64 `json:"upstreamErrors"`
+	Evictions      int64 `json:"evictions"`
+	Entries        int   `json:"entries"`
+	Bytes          int64 `json:"bytes"`
+}
+
+// Proxy is an http.Handler serving Redfish reads from BMCs through a cache.
+// Set its fields before serving.
+type Proxy struct {
+	// TTL is how long a response is served from the cache.
+	TTL time.Duration
+	// MaxEntries bounds the cache; the least recently used entries are
+	// evicted first.
+	MaxEntries int
+	// MaxConcurrent bounds the requests sent to one BMC at once.
+	MaxConcurrent int
+	// Allowed limits the BMCs to those whose IP address is in one of these
+	// networks; empty allows any. BMCs named by host name are denied when
+	// it is set.
+	Allowed []netip.Prefix
+
+	client *http.Client
+
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	lru       *list.List
+	bytes     int64
+	validated map[string]time.Time
+	inflight  map[string]*call
+	slots     map[string]chan struct{}
+
+	hits, coalesced, misses, bypassed, passedThrough, denied, upstreamErrors, evictions atomic.Int64
+}
+
+// entry is a cached response.
+type entry struct {
+	key      string
+	bmc      string
+	storedAt time.Time
+	response
+}
+
+// response is what the proxy keeps of a BMC response.
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// call is a GET in flight, whose response identical requests wait for.
+type call struct {
+	done chan struct{}
+	resp response
+	err  error
+}
+
+// New returns a proxy reaching BMCs over transport, which sets the TLS
+// verification of BMC certificates.
+func New(transport http.RoundTripper) *Proxy {
+	return &Proxy{
+		TTL:           DefaultTTL,
+		MaxEntries:    DefaultMaxEntries,
+		MaxConcurrent: DefaultMaxConcurrent,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   upstreamTimeout,
+			// Redirects are the client's to follow, through the proxy
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		entries:   make(map[string]*list.Element),
+		lru:       list.New(),
+		validated: make(map[string]time.Time),
+		inflight:  make(map[string]*call),
+		slots:     make(map[string]chan struct{}),
+	}
+}
+
+// Stats returns what the proxy has done so far.
+func (p *Proxy) Stats() Stats {
+	p.mu.Lock()
+	entries, bytes := p.lru.Len(), p.bytes
+	p.mu.Unlock()
+	return Stats{
+		Hits:           p.hits.Load(),
+		Coalesced:      p.coalesced.Load(),
+		Misses:         p.misses.Load(),
+		Bypassed:       p.bypassed.Load(),
+		PassedThrough:  p.passedThrough.Load(),
+		Denied:         p.denied.Load(),
+		UpstreamErrors: p.upstreamErrors.Load(),
+		Evictions:      p.evictions.Load(),
+		Entries:        entries,
+		Bytes:          bytes,
+	}
+}
+
+// ServeHTTP proxies a request under PathPrefix to its BMC.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bmc, path, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, PathPrefix), "/")
+	if !strings.HasPrefix(r.URL.Path, PathPrefix) || !ok || bmc == "" {
+		http.Error(w, "expected "+PathPrefix+"<bmc address>/<path>", http.StatusNotFound)
+		return
+	}
+	if !p.allowed(bmc) {
+		p.denied.Add(1)
+		http.Error(w, fmt.Sprintf("BMC %s is not in the allowed networks", bmc), http.StatusForbidden)
+		return
+	}
+	targetURL := "https://" + bmc + "/" + path
+	if r.URL.RawQuery != "" {
+		targetURL += "?" + r.URL.RawQuery
+	}
+
+	if r.Method != http.MethodGet {
+		p.passedThrough.Add(1)
+		resp, err := p.send(r.Context(), r, bmc, targetURL)
+		if err != nil {
+			p.fail(w, bmc, err)
+			return
+		}
+		// Logins and logouts change nothing the cache holds
+		if resp.status < 300 && !strings.Contains(path, "SessionService") {
+			p.drop(bmc)
+		}
+		write(w, resp, "")
+		return
+	}
+
+	identity := credentials(r)
+	key := bmc + " " + strings.TrimPrefix(targetURL, "https://"+bmc)
+	if bypass := strings.Contains(r.Header.Get("Cache-Control"), "no-cache"); bypass {
+		p.bypassed.Add(1)
+	} else if resp, ok := p.lookup(key, bmc, identity); ok {
+		p.hits.Add(1)
+		write(w, resp, "HIT")
+		return
+	}
+
+	resp, shared, err := p.fetch(r, bmc, identity, key, targetURL)
+	if err != nil {
+		p.fail(w, bmc, err)
+		return
+	}
+	if shared {
+		write(w, resp, "COALESCED")
+		return
+	}
+	write(w, resp, "MISS")
+}
+
+// fetch sends a GET to the BMC, or waits for the response of the identical
+// request in flight; shared reports which.
+func (p *Proxy) fetch(r *http.Request, bmc, identity, key, targetURL string) (resp response, shared bool, err error) {
+	flight := key + " " + identity
+	p.mu.Lock()
+	if c, ok := p.inflight[flight]; ok {
+		p.mu.Unlock()
+		p.coalesced.Add(1)
+		select {
+		case <-c.done:
+			return c.resp, true, c.err
+		case <-r.Context().Done():
+			return response{}, true, r.Context().Err()
+		}
+	}
+	c := &call{done: make(chan struct{})}
+	p.inflight[flight] = c
+	p.mu.Unlock()
+
+	p.misses.Add(1)
+	// The request is finished for the clients waiting on it even if the one
+	// that sent it goes away
+	c.resp, c.err = p.send(context.WithoutCancel(r.Context()), r, bmc, targetURL)
+	if c.err == nil {
+		p.store(key, bmc, identity, c.resp)
+	}
+	p.mu.Lock()
+	delete(p.inflight, flight)
+	p.mu.Unlock()
+	close(c.done)
+	return c.resp, false, c.err
+}
+
+// send forwards a request to the BMC, waiting for a free slot of the BMC.
+func (p *Proxy) send(ctx context.Context, r *http.Request, bmc, targetURL string) (response, error) {
+	slot := p.slot(bmc)
+	select {
+	case slot <- struct{}{}:
+		defer func() { <-slot }()
+	case <-ctx.Done():
+		return response{}, ctx.Err()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, targetURL, r.Body)
+	if err != nil {
+		return response{}, err
+	}
+	for _, name := range requestHeaders {
+		if value := r.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxBody))
+	if err != nil {
+		return response{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	header := make(http.Header)
+	for _, name := range responseHeaders {
+		if value := res.Header.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+	return response{status: res.StatusCode, header: header, body: body}, nil
+}
+
+// slot returns the semaphore bounding the requests to a BMC.
+func (p *Proxy) slot(bmc string) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	slot, ok := p.slots[bmc]
+	if !ok {
+		slot = make(chan struct{}, max(p.MaxConcurrent, 1))
+		p.slots[bmc] = slot
+	}
+	return slot
+}
+
+// lookup returns the cached response for key if it is fresh and the BMC
+// accepted the client's credentials within the TTL.
+func (p *Proxy) lookup(key, bmc, identity string) (response, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	if at, ok := p.validated[bmc+" "+identity]; !ok || now.Sub(at) > p.TTL {
+		return response{}, false
+	}
+	el, ok := p.entries[key]
+	if !ok {
+		return response{}, false
+	}
+	e := el.Value.(*entry)
+	if now.Sub(e.storedAt) > p.TTL {
+		p.remove(el)
+		return response{}, false
+	}
+	p.lru.MoveToFront(el)
+	return e.response, true
+}
+
+// store caches a 200 OK response and notes that the BMC accepted the
+// credentials it was read with.
+func (p *Proxy) store(key, bmc, identity string, resp response) {
+	if resp.status != http.StatusOK {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	p.validated[bmc+" "+identity] = now
+	if el, ok := p.entries[key]; ok {
+		p.remove(el)
+	}
+	p.entries[key] = p.lru.PushFront(&entry{key: key, bmc: bmc, storedAt: now, response: resp})
+	p.bytes += int64(len(resp.body))
+	for p.lru.Len() > max(p.MaxEntries, 1) {
+		p.remove(p.lru.Back())
+		p.evictions.Add(1)
+	}
+	// Forget the credentials not used within the TTL, so the map does not
+	// grow with every session ever opened
+	for id, at := range p.validated {
+		if now.Sub(at) > p.TTL {
+			delete(p.validated, id)
+		}
+	}
+}
+
+// drop forgets the cached responses of a BMC, whose state a request may
+// have changed.
+func (p *Proxy) drop(bmc string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, el := range p.entries {
+		if el.Value.(*entry).bmc == bmc {
+			p.remove(el)
+		}
+	}
+}
+
+// remove removes a cache entry; p.mu is held.
+func (p *Proxy) remove(el *list.Element) {
+	e := p.lru.Remove(el).(*entry)
+	delete(p.entries, e.key)
+	p.bytes -= int64(len(e.body))
+}
+
+// allowed reports whether the proxy may reach the BMC at address.
+func (p *Proxy) allowed(address string) bool {
+	if len(p.Allowed) == 0 {
+		return true
+	}
+	host := address
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		host = h
+	}
+	ip, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return false
+	}
+	for _, prefix := range p.Allowed {
+		if prefix.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proxy) fail(w http.ResponseWriter, bmc string, err error) {
+	p.upstreamErrors.Add(1)
+	http.Error(w, fmt.Sprintf("BMC %s: %v", bmc, err), http.StatusBadGateway)
+}
+
+// credentials identifies the credentials of a request without keeping them.
+func credentials(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.Header.Get("Authorization") + "\x00" + r.Header.Get("X-Auth-Token")))
+	return fmt.Sprintf("%x", sum[:16])
+}
+
+// write sends a BMC response to the client, marked with how it was served.
+func write(w http.ResponseWriter, resp response, cache string) {
+	for name, values := range resp.header {
+		w.Header()[name] = values
+	}
+	if cache != "" {
+		w.Header().Set("X-Cache", cache)
+	}
+	w.WriteHeader(resp.status)
+	io.Copy(w, bytes.NewReader(resp.body))
+}
+
+// MarshalMetrics returns the proxy's statistics in the Prometheus text
+// format.
+func (p *Proxy) MarshalMetrics() []byte {
+	s := p.Stats()
+	var out []byte
+	out = append(out, "# HELP inventory_redfish_proxy_requests_total Requests served by the Redfish proxy, by how they were served.\n"...)
+	out = append(out, "# TYPE inventory_redfish_proxy_requests_total counter\n"...)
+	for _, result := range []struct {
+		name  string
+		count int64
+	}{
+		{"hit", s.Hits},
+		{"coalesced", s.Coalesced},
+		{"miss", s.Misses},
+		{"bypass", s.Bypassed},
+		{"passthrough", s.PassedThrough},
+		{"denied", s.Denied},
+	} {
+		out = append(out, fmt.Sprintf("inventory_redfish_proxy_requests_total{result=%q} %d\n", result.name, result.count)...)
+	}
+	out = append(out, "# HELP inventory_redfish_proxy_upstream_errors_total Requests the proxy failed to get a BMC response to.\n"...)
+	out = append(out, "# TYPE inventory_redfish_proxy_upstream_errors_total counter\n"...)
+	out = append(out, fmt.Sprintf("inventory_redfish_proxy_upstream_errors_total %d\n", s.UpstreamErrors)...)
+	out = append(out, "# HELP inventory_redfish_proxy_evictions_total Cached responses evicted to stay within the maximum entries.\n"...)
+	out = append(out, "# TYPE inventory_redfish_proxy_evictions_total counter\n"...)
+	out = append(out, fmt.Sprintf("inventory_redfish_proxy_evictions_total %d\n", s.Evictions)...)
+	out = append(out, "# HELP inventory_redfish_proxy_cache_entries Responses in the cache.\n"...)
+	out = append(out, "# TYPE inventory_redfish_proxy_cache_entries gauge\n"...)
+	out = append(out, fmt.Sprintf("inventory_redfish_proxy_cache_entries %d\n", s.Entries)...)
+	out = append(out, "# HELP inventory_redfish_proxy_cache_bytes Size of the response bodies in the cache.\n"...)
+	out = append(out, "# TYPE inventory_redfish_proxy_cache_bytes gauge\n"...)
+	out = append(out, fmt.Sprintf("inventory_redfish_proxy_cache_bytes %d\n", s.Bytes)...)
+	return out
+}
@@ -0,0 +1,175 @@
+package render
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A step selects values from each value the previous step selected. The
+// supported JSONPath subset is
+//
+//	.name or ['name']   a field
+//	[n]                 an element; negative n counts from the end
+//	[*] or .*           every element or field value
+//	..name              the field at any depth
+//
+// with an optional leading $ and, as kubectl writes it, surrounding braces.
+type step struct {
+	field     string
+	index     int
+	wildcard  bool
+	recursive bool
+	isIndex   bool
+}
+
+func parsePath(expr string) ([]step, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "{") && strings.HasSuffix(expr, "}") {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	expr = strings.TrimPrefix(expr, "$")
+	if expr == "" || expr == "." {
+		return nil, nil
+	}
+
+	var steps []step
+	for len(expr) > 0 {
+		switch {
+		case strings.HasPrefix(expr, ".."):
+			name, rest := fieldName(expr[2:])
+			if name == "" {
+				return nil, fmt.Errorf("expected a field name after ..")
+			}
+			steps = append(steps, step{field: name, recursive: true})
+			expr = rest
+		case expr[0] == '.':
+			name, rest := fieldName(expr[1:])
+			switch name {
+			case "":
+				return nil, fmt.Errorf("expected a field name after .")
+			case "*":
+				steps = append(steps, step{wildcard: true})
+			default:
+				steps = append(steps, step{field: name})
+			}
+			expr = rest
+		case expr[0] == '[':
+			end := strings.IndexByte(expr, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [")
+			}
+			inner := strings.TrimSpace(expr[1:end])
+			expr = expr[end+1:]
+			switch {
+			case inner == "*":
+				steps = append(steps, step{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, step{field: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("unsupported subscript [%s]", inner)
+				}
+				steps = append(steps, step{index: n, isIndex: true})
+			}
+		default:
+			// A leading field without its dot, as in "items[0]"
+			name, rest := fieldName(expr)
+			if name == "" {
+				return nil, fmt.Errorf("unexpected %q", expr)
+			}
+			steps = append(steps, step{field: name})
+			expr = rest
+		}
+	}
+	return steps, nil
+}
+
+// fieldName splits a field name off the front of expr.
+func fieldName(expr string) (string, string) {
+	end := strings.IndexAny(expr, ".[")
+	if end < 0 {
+		end = len(expr)
+	}
+	return expr[:end], expr[end:]
+}
+
+// evaluate applies the steps to the value and returns what they select.
+func evaluate(steps []step, value interface{}) []interface{} {
+	current := []interface{}{value}
+	for _, s := range steps {
+		var next []interface{}
+		for _, v := range current {
+			next = append(next, s.apply(v)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func (s step) apply(v interface{}) []interface{} {
+	switch {
+	case s.recursive:
+		return descend(v, s.field)
+	case s.wildcard:
+		return children(v)
+	case s.isIndex:
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		i := s.index
+		if i < 0 {
+			i += len(list)
+		}
+		if i < 0 || i >= len(list) {
+			return nil
+		}
+		return []interface{}{list[i]}
+	}
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if field, ok := object[s.field]; ok {
+		return []interface{}{field}
+	}
+	return nil
+}
+
+// children returns the elements of a list or the field values of an object,
+// by field name.
+func children(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = v[key]
+		}
+		return values
+	}
+	return nil
+}
+
+// descend returns the values of the field name in v and every value below it.
+func descend(v interface{}, name string) []interface{} {
+	var found []interface{}
+	if object, ok := v.(map[string]interface{}); ok {
+		if field, ok := object[name]; ok {
+			found = append(found, field)
+		}
+	}
+	for _, child := range children(v) {
+		found = append(found, descend(child, name)...)
+	}
+	return found
+}
@@ -0,0 +1,119 @@
+// Package render prints command output through a Go text/template or a
+// JSONPath expression, for shell pipelines that need a few fields.
+//
+// Both see the value as its JSON encoding, so fields are named as in the
+// JSON output: {{.metadata.name}} in a template, .metadata.name in a path.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// Printer prints values with a template or a JSONPath expression.
+type Printer struct {
+	tmpl *template.Template
+	path []step
+}
+
+// New returns the printer for a template or a JSONPath expression, or nil
+// when both are empty.
+func New(tmpl, jsonPath string) (*Printer, error) {
+	switch {
+	case tmpl != "" && jsonPath != "":
+		return nil, errors.New("--template and --jsonpath cannot be combined")
+	case tmpl != "":
+		t, err := template.New("output").Funcs(funcs).Option("missingkey=zero").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return &Printer{tmpl: t}, nil
+	case jsonPath != "":
+		path, err := parsePath(jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSONPath %q: %w", jsonPath, err)
+		}
+		return &Printer{path: path}, nil
+	}
+	return nil, nil
+}
+
+// Print writes v to w. A template's output gets a trailing newline if it
+// lacks one; a JSONPath prints each value it selects on its own line, strings
+// unquoted and the rest as compact JSON.
+func (p *Printer) Print(w io.Writer, v interface{}) error {
+	generic, err := toJSON(v)
+	if err != nil {
+		return err
+	}
+	if p.tmpl != nil {
+		var buf bytes.Buffer
+		if err := p.tmpl.Execute(&buf, generic); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+	for _, value := range evaluate(p.path, generic) {
+		if _, err := fmt.Fprintln(w, scalar(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// funcs are the functions templates may call besides the built-in ones.
+var funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, v interface{}) string {
+		list, _ := v.([]interface{})
+		parts := make([]string, len(list))
+		for i, v := range list {
+			parts[i] = scalar(v)
+		}
+		return strings.Join(parts, sep)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// toJSON converts v to the maps, slices and scalars of its JSON encoding.
+func toJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to decode output: %w", err)
+	}
+	return generic, nil
+}
+
+// scalar formats a value for a line of output: strings as they are, null as
+// the empty string and anything else as compact JSON.
+func scalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,133 @@
+package reports
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/completeness"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// DefaultCompletenessThreshold is the completeness score below which a node
+// is counted as incomplete.
+const DefaultCompletenessThreshold = 80.0
+
+// CompletenessEntry rates the inventory data of one node.
+type CompletenessEntry struct {
+	DeviceEntry
+	// Platform is the node's manufacturer and model.
+	Platform     string              `json:"platform"`
+	Completeness device.Completeness `json:"completeness"`
+}
+
+// PlatformCompleteness aggregates the nodes of one platform. A platform that
+// scores low across the fleet points at its BMC firmware rather than at
+// individual nodes.
+type PlatformCompleteness struct {
+	Platform     string  `json:"platform"`
+	Nodes        int     `json:"nodes"`
+	AverageScore float64 `json:"averageScore"`
+	// MissingCategories counts the nodes missing each expected category.
+	MissingCategories map[string]int `json:"missingCategories,omitempty"`
+}
+
+// CompletenessReport rates the inventory data of every node.
+type CompletenessReport struct {
+	Total        int     `json:"total"`
+	AverageScore float64 `json:"averageScore"`
+	// Incomplete counts the nodes scoring below Threshold.
+	Incomplete int     `json:"incomplete"`
+	Threshold  float64 `json:"threshold"`
+	// Platforms is sorted by average score, lowest first.
+	Platforms []PlatformCompleteness `json:"platforms"`
+	// Nodes is sorted by score, least complete first.
+	Nodes []CompletenessEntry `json:"nodes"`
+}
+
+// Completeness builds the completeness report for every active node, scoring
+// it from its active children. A threshold of zero or less uses
+// DefaultCompletenessThreshold.
+func Completeness(devices []*device.Device, threshold float64) *CompletenessReport {
+	if threshold <= 0 {
+		threshold = DefaultCompletenessThreshold
+	}
+	report := &CompletenessReport{
+		Threshold: threshold,
+		Platforms: []PlatformCompleteness{},
+		Nodes:     []CompletenessEntry{},
+	}
+
+	children := make(map[string][]*device.Device)
+	for _, d := range devices {
+		if d != nil && d.Spec.ParentID != "" && !prune.IsRetired(d) {
+			children[d.Spec.ParentID] = append(children[d.Spec.ParentID], d)
+		}
+	}
+
+	platforms := make(map[string]*PlatformCompleteness)
+	var total float64
+	for _, d := range devices {
+		if d == nil || d.Spec.DeviceType != "Node" || prune.IsRetired(d) {
+			continue
+		}
+		entry := CompletenessEntry{
+			DeviceEntry:  newDeviceEntry(d),
+			Platform:     platformOf(d),
+			Completeness: *completeness.Score(d, children[d.GetUID()]),
+		}
+		score := entry.Completeness.Score
+		total += score
+		report.Total++
+		if score < threshold {
+			report.Incomplete++
+		}
+		report.Nodes = append(report.Nodes, entry)
+
+		platform, ok := platforms[entry.Platform]
+		if !ok {
+			platform = &PlatformCompleteness{Platform: entry.Platform, MissingCategories: make(map[string]int)}
+			platforms[entry.Platform] = platform
+		}
+		platform.Nodes++
+		platform.AverageScore += score
+		for _, category := range entry.Completeness.MissingCategories {
+			platform.MissingCategories[category]++
+		}
+	}
+
+	if report.Total > 0 {
+		report.AverageScore = round1(total / float64(report.Total))
+	}
+	for _, platform := range platforms {
+		platform.AverageScore = round1(platform.AverageScore / float64(platform.Nodes))
+		report.Platforms = append(report.Platforms, *platform)
+	}
+	sort.SliceStable(report.Platforms, func(i, j int) bool {
+		a, b := report.Platforms[i], report.Platforms[j]
+		if a.AverageScore != b.AverageScore {
+			return a.AverageScore < b.AverageScore
+		}
+		return a.Platform < b.Platform
+	})
+	sort.SliceStable(report.Nodes, func(i, j int) bool {
+		return report.Nodes[i].Completeness.Score < report.Nodes[j].Completeness.Score
+	})
+	return report
+}
+
+// platformOf names a node's platform by manufacturer and model, falling back
+// to the part number.
+func platformOf(d *device.Device) string {
+	platform := strings.TrimSpace(d.Spec.Manufacturer + " " + modelOf(d))
+	if platform == "" {
+		return "unknown"
+	}
+	return platform
+}
+
+// round1 rounds to one decimal place.
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,342 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/maintenance"
+	"github.com/example/inventory-v3/pkg/redact"
+	"github.com/example/inventory-v3/pkg/resources/device"
+	"github.com/example/inventory-v3/pkg/s3"
+)
+
+// Names of the reports that can be scheduled.
+const (
+	ReportNewHardware        = "new-hardware"
+	ReportMissingDevices     = "missing-devices"
+	ReportFirmwareCompliance = "firmware-compliance"
+	ReportCompleteness       = "completeness"
+)
+
+// ScheduledReports lists every report that can be scheduled.
+var ScheduledReports = []string{ReportNewHardware, ReportMissingDevices, ReportFirmwareCompliance, ReportCompleteness}
+
+// BundleOptions configures the reports in a Bundle.
+type BundleOptions struct {
+	// Reports names the reports to include; empty includes all of them.
+	Reports []string
+	// Period is how far back the new-hardware report looks.
+	Period       time.Duration
+	MissingAfter time.Duration
+	Baseline     FirmwareBaseline
+}
+
+// Bundle is one scheduled run: the selected reports and where they went.
+type Bundle struct {
+	GeneratedAt        time.Time                 `json:"generatedAt"`
+	NewHardware        *NewHardwareReport        `json:"newHardware,omitempty"`
+	MissingDevices     *MissingDevicesReport     `json:"missingDevices,omitempty"`
+	FirmwareCompliance *FirmwareComplianceReport `json:"firmwareCompliance,omitempty"`
+	Completeness       *CompletenessReport       `json:"completeness,omitempty"`
+
+	// Deliveries records the outcome for each destination.
+	Deliveries []Delivery `json:"deliveries,omitempty"`
+}
+
+// Delivery is the outcome of sending a bundle to one destination.
+type Delivery struct {
+	Destination string `json:"destination"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ValidReport reports whether name is a schedulable report.
+func ValidReport(name string) bool {
+	for _, r := range ScheduledReports {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildBundle renders the selected reports. The blackout lists what active
+// maintenance windows cover; it may be nil.
+func BuildBundle(devices []*device.Device, blackout *maintenance.Blackout, opts BundleOptions, now time.Time) *Bundle {
+	names := opts.Reports
+	if len(names) == 0 {
+		names = ScheduledReports
+	}
+	bundle := &Bundle{GeneratedAt: now}
+	for _, name := range names {
+		switch name {
+		case ReportNewHardware:
+			bundle.NewHardware = NewHardware(devices, now.Add(-opts.Period))
+		case ReportMissingDevices:
+			bundle.MissingDevices = MissingDevices(devices, blackout, opts.MissingAfter, now)
+		case ReportFirmwareCompliance:
+			bundle.FirmwareCompliance = FirmwareCompliance(devices, opts.Baseline)
+		case ReportCompleteness:
+			bundle.Completeness = Completeness(devices, 0)
+		}
+	}
+	return bundle
+}
+
+// Subject is the title used for emails and object names.
+func (b *Bundle) Subject() string {
+	return fmt.Sprintf("Inventory report %s", b.GeneratedAt.Format("2006-01-02"))
+}
+
+// Text renders the bundle as a plain-text summary.
+func (b *Bundle) Text() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%s\nGenerated at %s\n", b.Subject(), b.GeneratedAt.Format(time.RFC3339))
+
+	if r := b.NewHardware; r != nil {
+		fmt.Fprintf(&s, "\nNew hardware since %s: %d device(s)%s\n", r.Since.Format("2006-01-02"), r.Total, formatCounts(r.ByType))
+		for _, d := range r.Devices {
+			fmt.Fprintf(&s, "  %-16s %-24s %s (first seen %s)\n", d.DeviceType, d.SerialNumber, d.Name, d.FirstSeen.Format("2006-01-02"))
+		}
+	}
+	if r := b.MissingDevices; r != nil {
+		fmt.Fprintf(&s, "\nMissing for more than %s: %d device(s)%s\n", r.MissingAfter, r.Total, formatCounts(r.ByType))
+		for _, d := range r.Devices {
+			fmt.Fprintf(&s, "  %-16s %-24s %s (last seen %s)\n", d.DeviceType, d.SerialNumber, d.Name, d.LastSeen.Format("2006-01-02"))
+		}
+		if r.InMaintenance > 0 {
+			fmt.Fprintf(&s, "  (%d more unreported device(s) are in a maintenance window)\n", r.InMaintenance)
+		}
+		if r.PoweredOff > 0 {
+			fmt.Fprintf(&s, "  (%d more unreported device(s) belong to powered-off nodes)\n", r.PoweredOff)
+		}
+	}
+	if r := b.FirmwareCompliance; r != nil {
+		fmt.Fprintf(&s, "\nFirmware compliance (%d rules): %d checked, %d compliant, %d non-compliant, %d unknown\n",
+			r.Rules, r.Checked, r.Compliant, r.NonCompliant, r.Unknown)
+		for _, d := range r.Devices {
+			version := d.FirmwareVersion
+			if version == "" {
+				version = "unknown"
+			}
+			fmt.Fprintf(&s, "  %-16s %-24s %s (%s, expected %s)\n", d.DeviceType, d.SerialNumber, d.Name, version, d.ExpectedVersion)
+		}
+	}
+	if r := b.Completeness; r != nil {
+		fmt.Fprintf(&s, "\nInventory completeness: %d node(s), average score %.1f, %d below %.0f\n", r.Total, r.AverageScore, r.Incomplete, r.Threshold)
+		for _, p := range r.Platforms {
+			fmt.Fprintf(&s, "  %-40s %3d node(s), average %.1f%s\n", p.Platform, p.Nodes, p.AverageScore, formatCounts(p.MissingCategories))
+		}
+		for _, n := range r.Nodes {
+			if n.Completeness.Score >= r.Threshold {
+				break
+			}
+			fmt.Fprintf(&s, "  %-24s %-16s %s scores %.1f (%d of %d fields empty)\n", n.SerialNumber, n.Source, n.Name, n.Completeness.Score, n.Completeness.EmptyFields, n.Completeness.Fields)
+		}
+	}
+	return s.String()
+}
+
+// formatCounts renders per-type counts as " (Drive: 2, Node: 1)".
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s: %d", t, counts[t]))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// Destination receives scheduled report bundles.
+type Destination interface {
+	// Name identifies the destination in delivery results.
+	Name() string
+	Deliver(ctx context.Context, bundle *Bundle) error
+}
+
+// SMTPDestination emails the plain-text rendering of a bundle.
+type SMTPDestination struct {
+	// Addr is the SMTP server as host:port.
+	Addr     string
+	From     string
+	To       []string
+	Username string
+	Password string
+	// TLSConfig is used for STARTTLS; nil uses net/smtp's defaults.
+	TLSConfig *tls.Config
+}
+
+// Name implements Destination.
+func (d *SMTPDestination) Name() string {
+	return "smtp://" + d.Addr
+}
+
+// Deliver implements Destination.
+func (d *SMTPDestination) Deliver(ctx context.Context, bundle *Bundle) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", d.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(d.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", bundle.Subject())
+	fmt.Fprintf(&msg, "Date: %s\r\n", bundle.GeneratedAt.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(bundle.Text(), "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if d.Username != "" {
+		host, _, _ := strings.Cut(d.Addr, ":")
+		auth = smtp.PlainAuth("", d.Username, d.Password, host)
+	}
+	send := smtp.SendMail
+	if d.TLSConfig != nil {
+		send = d.sendMail
+	}
+	if err := send(d.Addr, auth, d.From, d.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send report email: %w", err)
+	}
+	return nil
+}
+
+// sendMail is smtp.SendMail with d.TLSConfig for STARTTLS.
+func (d *SMTPDestination) sendMail(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	c, err := smtp.Dial(addr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		config := d.TLSConfig.Clone()
+		if config.ServerName == "" {
+			config.ServerName, _, _ = strings.Cut(addr, ":")
+		}
+		if err := c.StartTLS(config); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// WebhookDestination POSTs the bundle as JSON.
+type WebhookDestination struct {
+	URL    string
+	Client *http.Client
+}
+
+// Name implements Destination.
+func (d *WebhookDestination) Name() string {
+	return redact.URL(d.URL)
+}
+
+// Deliver implements Destination.
+func (d *WebhookDestination) Deliver(ctx context.Context, bundle *Bundle) error {
+	body, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return PostJSON(ctx, d.Client, d.URL, body)
+}
+
+// PostJSON POSTs body to url as JSON and fails on a non-2xx response. Errors
+// do not quote url, which may carry a token.
+func PostJSON(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", redact.Error(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return send(client, req)
+}
+
+// S3Destination writes each bundle as a JSON object named
+// "inventory-report-YYYY-MM-DD.json" under URL, which is a bucket URL with an
+// optional key prefix, e.g. "https://bucket.s3.us-east-1.amazonaws.com/reports".
+// Requests are signed with AWS Signature Version 4, so S3-compatible stores
+// work as well.
+type S3Destination struct {
+	URL             string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Client          *http.Client
+}
+
+// Name implements Destination.
+func (d *S3Destination) Name() string {
+	return redact.URL(d.URL)
+}
+
+// Deliver implements Destination.
+func (d *S3Destination) Deliver(ctx context.Context, bundle *Bundle) error {
+	body, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	bucket := s3.Bucket{
+		URL:             d.URL,
+		Region:          d.Region,
+		AccessKeyID:     d.AccessKeyID,
+		SecretAccessKey: d.SecretAccessKey,
+		SessionToken:    d.SessionToken,
+		Client:          d.Client,
+	}
+	return bucket.Put(ctx, fmt.Sprintf("inventory-report-%s.json", bundle.GeneratedAt.Format("2006-01-02")), "application/json", body)
+}
+
+// send performs req and fails on a non-2xx response.
+func send(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// The error quotes the full URL, which may carry a token
+		return fmt.Errorf("request to %s failed: %w", req.URL.Host, redact.Error(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s", req.Method, redact.URL(req.URL.String()), resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package reports builds fleet-wide reports from the device inventory.
+package reports
+
+import (
+	"sort"
+
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// DefaultWornThreshold is the percentage-used value at or above which a drive
+// is counted as worn.
+const DefaultWornThreshold = 80.0
+
+// DriveEnduranceEntry describes the wear of a single drive.
+type DriveEnduranceEntry struct {
+	UID                    string   `json:"uid"`
+	SerialNumber           string   `json:"serialNumber"`
+	Manufacturer           string   `json:"manufacturer,omitempty"`
+	PartNumber             string   `json:"partNumber,omitempty"`
+	NodeSerialNumber       string   `json:"nodeSerialNumber,omitempty"`
+	Protocol               string   `json:"protocol,omitempty"`
+	FormFactor             string   `json:"formFactor,omitempty"`
+	CapacityBytes          int64    `json:"capacityBytes,omitempty"`
+	NamespaceCount         int      `json:"namespaceCount,omitempty"`
+	NamespaceCapacityBytes int64    `json:"namespaceCapacityBytes,omitempty"`
+	PercentageUsed         *float64 `json:"percentageUsed,omitempty"`
+	WearSource             string   `json:"wearSource,omitempty"`
+}
+
+// DriveEnduranceReport summarizes drive wear across the fleet.
+type DriveEnduranceReport struct {
+	TotalDrives   int `json:"totalDrives"`
+	ReportingWear int `json:"reportingWear"`
+	Worn          int `json:"worn"`
+
+	// WornThreshold is the percentage-used value used to count Worn drives.
+	WornThreshold float64 `json:"wornThreshold"`
+
+	// Buckets counts drives by percentage used: "0-25", "25-50", "50-75",
+	// "75-90", "90-100", "100+", and "unknown".
+	Buckets map[string]int `json:"buckets"`
+
+	// Drives is sorted by percentage used, most worn first.
+	Drives []DriveEnduranceEntry `json:"drives"`
+}
+
+// DriveEndurance builds the endurance report for every Drive device that has
+// not been retired.
+// A threshold of zero or less uses DefaultWornThreshold.
+func DriveEndurance(devices []*device.Device, wornThreshold float64) *DriveEnduranceReport {
+	if wornThreshold <= 0 {
+		wornThreshold = DefaultWornThreshold
+	}
+	report := &DriveEnduranceReport{
+		WornThreshold: wornThreshold,
+		Buckets:       make(map[string]int),
+		Drives:        []DriveEnduranceEntry{},
+	}
+
+	for _, d := range devices {
+		if d == nil || d.Spec.DeviceType != "Drive" || prune.IsRetired(d) {
+			continue
+		}
+		entry := DriveEnduranceEntry{
+			UID:              d.GetUID(),
+			SerialNumber:     d.Spec.SerialNumber,
+			Manufacturer:     d.Spec.Manufacturer,
+			PartNumber:       d.Spec.PartNumber,
+			NodeSerialNumber: d.Spec.ParentSerialNumber,
+		}
+		d.Spec.GetProperty("protocol", &entry.Protocol)
+		d.Spec.GetProperty("form_factor", &entry.FormFactor)
+		d.Spec.GetProperty("capacity_bytes", &entry.CapacityBytes)
+		d.Spec.GetProperty("namespace_count", &entry.NamespaceCount)
+		d.Spec.GetProperty("namespace_capacity_bytes", &entry.NamespaceCapacityBytes)
+		d.Spec.GetProperty("wear_source", &entry.WearSource)
+
+		var used float64
+		if d.Spec.GetProperty("percentage_used", &used) {
+			entry.PercentageUsed = &used
+			report.ReportingWear++
+			if used >= wornThreshold {
+				report.Worn++
+			}
+		}
+		report.Buckets[wearBucket(entry.PercentageUsed)]++
+		report.TotalDrives++
+		report.Drives = append(report.Drives, entry)
+	}
+
+	sort.SliceStable(report.Drives, func(i, j int) bool {
+		a, b := report.Drives[i].PercentageUsed, report.Drives[j].PercentageUsed
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a > *b
+	})
+	return report
+}
+
+// wearBucket returns the histogram bucket for a percentage-used value.
+// NVMe allows values above 100 once the rated endurance is exceeded.
+func wearBucket(used *float64) string {
+	switch {
+	case used == nil:
+		return "unknown"
+	case *used < 25:
+		return "0-25"
+	case *used < 50:
+		return "25-50"
+	case *used < 75:
+		return "50-75"
+	case *used < 90:
+		return "75-90"
+	case *used <= 100:
+		return "90-100"
+	default:
+		return "100+"
+	}
+}
@@ -0,0 +1,179 @@
+package reports
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Firmware names used for the firmware_version and bios_version properties;
+// node firmware_inventory entries are named as the BMC names them.
+const (
+	FirmwareDevice = "Firmware"
+	FirmwareBIOS   = "BIOS"
+)
+
+// VersionCount is the number of devices running one firmware version.
+type VersionCount struct {
+	Version string `json:"version"`
+	Count   int    `json:"count"`
+}
+
+// FirmwareDistribution counts the versions of one firmware across the
+// devices of one model.
+type FirmwareDistribution struct {
+	DeviceType   string `json:"deviceType"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Model is the device's model property, else its part number.
+	Model    string `json:"model,omitempty"`
+	Firmware string `json:"firmware"`
+	Devices  int    `json:"devices"`
+	// Versions is sorted by count, most common first.
+	Versions []VersionCount `json:"versions"`
+}
+
+// FirmwareVersionFilter narrows the firmware version report. Empty fields
+// match anything; the others match case-insensitively.
+type FirmwareVersionFilter struct {
+	DeviceType   string
+	Manufacturer string
+	Model        string
+	Firmware     string
+	// Version keeps the distributions that include the version.
+	Version string
+}
+
+func (f FirmwareVersionFilter) matches(d *FirmwareDistribution) bool {
+	fields := [][2]string{
+		{f.DeviceType, d.DeviceType},
+		{f.Manufacturer, d.Manufacturer},
+		{f.Model, d.Model},
+		{f.Firmware, d.Firmware},
+	}
+	for _, field := range fields {
+		if field[0] != "" && !strings.EqualFold(field[0], field[1]) {
+			return false
+		}
+	}
+	if f.Version == "" {
+		return true
+	}
+	for _, v := range d.Versions {
+		if strings.EqualFold(v.Version, f.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirmwareVersionReport is the distribution of firmware versions across the
+// fleet, by model.
+type FirmwareVersionReport struct {
+	// Devices counts the active devices reporting any firmware version.
+	Devices int `json:"devices"`
+	// Models is sorted by device type, manufacturer, model and firmware.
+	Models []FirmwareDistribution `json:"models"`
+}
+
+// FirmwareVersions builds the firmware version report for the active
+// devices, from their firmware_version and bios_version properties and the
+// firmware inventory of nodes.
+func FirmwareVersions(devices []*device.Device, filter FirmwareVersionFilter) *FirmwareVersionReport {
+	report := &FirmwareVersionReport{Models: []FirmwareDistribution{}}
+	type key struct{ deviceType, manufacturer, model, firmware string }
+	distributions := make(map[key]*FirmwareDistribution)
+	counts := make(map[key]map[string]int)
+	for _, d := range devices {
+		if d == nil || prune.IsRetired(d) || manifest.IsExpected(d) {
+			continue
+		}
+		versions := deviceFirmware(d)
+		if len(versions) == 0 {
+			continue
+		}
+		report.Devices++
+		model := modelOf(d)
+		for firmware, version := range versions {
+			k := key{d.Spec.DeviceType, d.Spec.Manufacturer, model, firmware}
+			dist := distributions[k]
+			if dist == nil {
+				dist = &FirmwareDistribution{DeviceType: k.deviceType, Manufacturer: k.manufacturer, Model: k.model, Firmware: k.firmware}
+				distributions[k] = dist
+				counts[k] = map[string]int{}
+			}
+			dist.Devices++
+			counts[k][version]++
+		}
+	}
+
+	for k, dist := range distributions {
+		for version, count := range counts[k] {
+			dist.Versions = append(dist.Versions, VersionCount{Version: version, Count: count})
+		}
+		sort.Slice(dist.Versions, func(i, j int) bool {
+			if dist.Versions[i].Count != dist.Versions[j].Count {
+				return dist.Versions[i].Count > dist.Versions[j].Count
+			}
+			return dist.Versions[i].Version < dist.Versions[j].Version
+		})
+		if filter.matches(dist) {
+			report.Models = append(report.Models, *dist)
+		}
+	}
+	sort.Slice(report.Models, func(i, j int) bool {
+		a, b := report.Models[i], report.Models[j]
+		switch {
+		case a.DeviceType != b.DeviceType:
+			return a.DeviceType < b.DeviceType
+		case a.Manufacturer != b.Manufacturer:
+			return a.Manufacturer < b.Manufacturer
+		case a.Model != b.Model:
+			return a.Model < b.Model
+		}
+		return a.Firmware < b.Firmware
+	})
+	return report
+}
+
+// deviceFirmware returns the firmware versions a device reports, by
+// firmware name.
+func deviceFirmware(d *device.Device) map[string]string {
+	versions := map[string]string{}
+	var version string
+	if d.Spec.GetProperty("firmware_version", &version) && version != "" {
+		versions[FirmwareDevice] = version
+	}
+	version = ""
+	if d.Spec.GetProperty("bios_version", &version) && version != "" {
+		versions[FirmwareBIOS] = version
+	}
+	var inventory []struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if d.Spec.GetProperty("firmware_inventory", &inventory) {
+		for _, entry := range inventory {
+			name := entry.Name
+			if name == "" {
+				name = entry.ID
+			}
+			if name != "" && entry.Version != "" {
+				versions[name] = entry.Version
+			}
+		}
+	}
+	return versions
+}
+
+// modelOf returns the device's model property, else its part number.
+func modelOf(d *device.Device) string {
+	var model string
+	if d.Spec.GetProperty("model", &model) && model != "" {
+		return model
+	}
+	return d.Spec.PartNumber
+}
@@ -0,0 +1,251 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/maintenance"
+	"github.com/example/inventory-v3/pkg/manifest"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// DefaultMissingAfter is how long a device may go unreported before the
+// missing-devices report lists it.
+const DefaultMissingAfter = 7 * 24 * time.Hour
+
+// DeviceEntry describes one device in an inventory report.
+type DeviceEntry struct {
+	UID          string     `json:"uid"`
+	Name         string     `json:"name"`
+	DeviceType   string     `json:"deviceType"`
+	Manufacturer string     `json:"manufacturer,omitempty"`
+	PartNumber   string     `json:"partNumber,omitempty"`
+	SerialNumber string     `json:"serialNumber,omitempty"`
+	Source       string     `json:"source,omitempty"`
+	FirstSeen    time.Time  `json:"firstSeen"`
+	LastSeen     *time.Time `json:"lastSeen,omitempty"`
+}
+
+func newDeviceEntry(d *device.Device) DeviceEntry {
+	lastSeen := prune.LastSeen(d)
+	return DeviceEntry{
+		UID:          d.GetUID(),
+		Name:         d.GetName(),
+		DeviceType:   d.Spec.DeviceType,
+		Manufacturer: d.Spec.Manufacturer,
+		PartNumber:   d.Spec.PartNumber,
+		SerialNumber: d.Spec.SerialNumber,
+		Source:       d.Status.Source,
+		FirstSeen:    d.Metadata.CreatedAt,
+		LastSeen:     &lastSeen,
+	}
+}
+
+// NewHardwareReport lists the devices first seen since a point in time.
+type NewHardwareReport struct {
+	Since  time.Time      `json:"since"`
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"byType"`
+	// Devices is sorted by first seen, newest first.
+	Devices []DeviceEntry `json:"devices"`
+}
+
+// NewHardware builds the report of active devices created at or after since.
+// Devices still expected from a manifest have not arrived and are left out.
+func NewHardware(devices []*device.Device, since time.Time) *NewHardwareReport {
+	report := &NewHardwareReport{
+		Since:   since,
+		ByType:  make(map[string]int),
+		Devices: []DeviceEntry{},
+	}
+	for _, d := range devices {
+		if d == nil || prune.IsRetired(d) || manifest.IsExpected(d) || d.Metadata.CreatedAt.Before(since) {
+			continue
+		}
+		report.ByType[d.Spec.DeviceType]++
+		report.Total++
+		report.Devices = append(report.Devices, newDeviceEntry(d))
+	}
+	sort.SliceStable(report.Devices, func(i, j int) bool {
+		return report.Devices[i].FirstSeen.After(report.Devices[j].FirstSeen)
+	})
+	return report
+}
+
+// MissingDevicesReport lists active devices that have not been reported for
+// longer than MissingAfter.
+type MissingDevicesReport struct {
+	MissingAfter string         `json:"missingAfter"`
+	Total        int            `json:"total"`
+	ByType       map[string]int `json:"byType"`
+	// InMaintenance counts the unreported devices left out because a
+	// maintenance window covers them.
+	InMaintenance int `json:"inMaintenance"`
+	// PoweredOff counts the unreported devices left out because their node
+	// is powered off.
+	PoweredOff int `json:"poweredOff"`
+	// Devices is sorted by last seen, longest missing first.
+	Devices []DeviceEntry `json:"devices"`
+}
+
+// MissingDevices builds the missing-devices report. A missingAfter of zero or
+// less uses DefaultMissingAfter. Retired devices are expected to be missing,
+// and devices expected from a manifest have never been seen; both are left out,
+// as are devices the blackout covers and devices of a powered-off node, which
+// are most likely still installed.
+func MissingDevices(devices []*device.Device, blackout *maintenance.Blackout, missingAfter time.Duration, now time.Time) *MissingDevicesReport {
+	if missingAfter <= 0 {
+		missingAfter = DefaultMissingAfter
+	}
+	report := &MissingDevicesReport{
+		MissingAfter: missingAfter.String(),
+		ByType:       make(map[string]int),
+		Devices:      []DeviceEntry{},
+	}
+	for _, d := range devices {
+		if d == nil || prune.IsRetired(d) || manifest.IsExpected(d) || now.Sub(prune.LastSeen(d)) <= missingAfter {
+			continue
+		}
+		if blackout.CoversDevice(d) {
+			report.InMaintenance++
+			continue
+		}
+		if d.Status.Phase == device.PhasePoweredOff {
+			report.PoweredOff++
+			continue
+		}
+		report.ByType[d.Spec.DeviceType]++
+		report.Total++
+		report.Devices = append(report.Devices, newDeviceEntry(d))
+	}
+	sort.SliceStable(report.Devices, func(i, j int) bool {
+		return report.Devices[i].LastSeen.Before(*report.Devices[j].LastSeen)
+	})
+	return report
+}
+
+// FirmwareRule sets the expected firmware version for the devices it matches.
+// Empty match fields match any device.
+type FirmwareRule struct {
+	DeviceType   string `json:"deviceType"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	PartNumber   string `json:"partNumber,omitempty"`
+	Version      string `json:"version"`
+}
+
+func (r FirmwareRule) matches(d *device.Device) bool {
+	switch {
+	case r.DeviceType != "" && d.Spec.DeviceType != r.DeviceType:
+		return false
+	case r.Manufacturer != "" && d.Spec.Manufacturer != r.Manufacturer:
+		return false
+	case r.PartNumber != "" && d.Spec.PartNumber != r.PartNumber:
+		return false
+	}
+	return true
+}
+
+// FirmwareBaseline is an ordered list of rules; the first matching rule applies.
+type FirmwareBaseline []FirmwareRule
+
+// LoadFirmwareBaseline reads a JSON array of FirmwareRule from path.
+func LoadFirmwareBaseline(path string) (FirmwareBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read firmware baseline: %w", err)
+	}
+	var baseline FirmwareBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse firmware baseline %s: %w", path, err)
+	}
+	for i, rule := range baseline {
+		if rule.Version == "" {
+			return nil, fmt.Errorf("firmware baseline %s: rule %d has no version", path, i)
+		}
+	}
+	return baseline, nil
+}
+
+// FirmwareEntry describes a device whose firmware does not match the baseline.
+type FirmwareEntry struct {
+	UID             string `json:"uid"`
+	Name            string `json:"name"`
+	DeviceType      string `json:"deviceType"`
+	Manufacturer    string `json:"manufacturer,omitempty"`
+	PartNumber      string `json:"partNumber,omitempty"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	ExpectedVersion string `json:"expectedVersion"`
+}
+
+// FirmwareComplianceReport compares device firmware with a baseline.
+type FirmwareComplianceReport struct {
+	Rules int `json:"rules"`
+	// Checked counts the active devices matched by a rule.
+	Checked      int `json:"checked"`
+	Compliant    int `json:"compliant"`
+	NonCompliant int `json:"nonCompliant"`
+	// Unknown counts checked devices that report no firmware version.
+	Unknown int `json:"unknown"`
+	// Devices lists the non-compliant and unknown devices, sorted by type and name.
+	Devices []FirmwareEntry `json:"devices"`
+}
+
+// FirmwareCompliance builds the firmware compliance report for the active
+// devices matched by the baseline, using their "firmware_version" property.
+// Devices still expected from a manifest report no firmware and are left out.
+func FirmwareCompliance(devices []*device.Device, baseline FirmwareBaseline) *FirmwareComplianceReport {
+	report := &FirmwareComplianceReport{
+		Rules:   len(baseline),
+		Devices: []FirmwareEntry{},
+	}
+	for _, d := range devices {
+		if d == nil || prune.IsRetired(d) || manifest.IsExpected(d) {
+			continue
+		}
+		var expected string
+		for _, rule := range baseline {
+			if rule.matches(d) {
+				expected = rule.Version
+				break
+			}
+		}
+		if expected == "" {
+			continue
+		}
+		report.Checked++
+
+		var version string
+		d.Spec.GetProperty("firmware_version", &version)
+		switch {
+		case version == expected:
+			report.Compliant++
+			continue
+		case version == "":
+			report.Unknown++
+		default:
+			report.NonCompliant++
+		}
+		report.Devices = append(report.Devices, FirmwareEntry{
+			UID:             d.GetUID(),
+			Name:            d.GetName(),
+			DeviceType:      d.Spec.DeviceType,
+			Manufacturer:    d.Spec.Manufacturer,
+			PartNumber:      d.Spec.PartNumber,
+			SerialNumber:    d.Spec.SerialNumber,
+			FirmwareVersion: version,
+			ExpectedVersion: expected,
+		})
+	}
+	sort.SliceStable(report.Devices, func(i, j int) bool {
+		if report.Devices[i].DeviceType != report.Devices[j].DeviceType {
+			return report.Devices[i].DeviceType < report.Devices[j].DeviceType
+		}
+		return report.Devices[i].Name < report.Devices[j].Name
+	})
+	return report
+}
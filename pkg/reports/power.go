@@ -0,0 +1,217 @@
+package reports
+
+import (
+	"sort"
+
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// UnrackedRack is the rack the power capacity report files nodes without a
+// known rack under.
+const UnrackedRack = "unracked"
+
+// Bases of a node's planned power, in the order they are preferred.
+const (
+	// PowerBasisLimit is an enforced power limit: the node cannot draw more.
+	PowerBasisLimit = "limit"
+	// PowerBasisCapacity is the power capacity the chassis reports.
+	PowerBasisCapacity = "capacity"
+	// PowerBasisMeasured is the most the node was measured drawing.
+	PowerBasisMeasured = "measured"
+	// PowerBasisRated is the rated output of its power supplies, which
+	// overstates what redundant supplies deliver.
+	PowerBasisRated = "rated"
+)
+
+// NodePower is the power capacity data of one node.
+type NodePower struct {
+	DeviceEntry
+	PSURatedWatts    *float64 `json:"psuRatedWatts,omitempty"`
+	CapacityWatts    *float64 `json:"capacityWatts,omitempty"`
+	LimitWatts       *float64 `json:"limitWatts,omitempty"`
+	LimitEnabled     bool     `json:"limitEnabled,omitempty"`
+	LimitMaxWatts    *float64 `json:"limitMaxWatts,omitempty"`
+	MaxConsumedWatts *float64 `json:"maxConsumedWatts,omitempty"`
+	ConsumedWatts    *float64 `json:"consumedWatts,omitempty"`
+	// PlannedWatts is what the node is budgeted to draw, taken from Basis
+	// (limit, capacity, measured or rated); nodes without power data have
+	// none.
+	PlannedWatts *float64 `json:"plannedWatts,omitempty"`
+	Basis        string   `json:"basis,omitempty"`
+}
+
+// RackPower sums the power capacity of the nodes in a rack.
+type RackPower struct {
+	// Rack is the rack's name, prefixed with its row when known ("B/12").
+	Rack  string `json:"rack"`
+	Nodes int    `json:"nodes"`
+	// Unknown counts the nodes without power data, whose draw the
+	// totals miss.
+	Unknown          int     `json:"unknown"`
+	PSURatedWatts    float64 `json:"psuRatedWatts"`
+	MaxConsumedWatts float64 `json:"maxConsumedWatts"`
+	ConsumedWatts    float64 `json:"consumedWatts"`
+	PlannedWatts     float64 `json:"plannedWatts"`
+	// BudgetWatts is the rack's power_budget_watts, and HeadroomWatts what
+	// is left of it after PlannedWatts.
+	BudgetWatts   *float64 `json:"budgetWatts,omitempty"`
+	HeadroomWatts *float64 `json:"headroomWatts,omitempty"`
+	OverBudget    bool     `json:"overBudget,omitempty"`
+	// NodeList is sorted by planned power, highest first.
+	NodeList []NodePower `json:"nodeList"`
+}
+
+// PowerCapacityReport is the power capacity of every rack.
+type PowerCapacityReport struct {
+	Racks        int     `json:"racks"`
+	Nodes        int     `json:"nodes"`
+	Unknown      int     `json:"unknown"`
+	PlannedWatts float64 `json:"plannedWatts"`
+	OverBudget   int     `json:"overBudget"`
+	// ByBasis counts the nodes by the basis of their planned power.
+	ByBasis map[string]int `json:"byBasis"`
+	// RackList is sorted by rack, with unracked nodes last.
+	RackList []RackPower `json:"rackList"`
+}
+
+// PowerCapacity builds the power capacity report of the active nodes, by the
+// rack each is in: its own rack property or that of the closest ancestor
+// with one. The budget of a rack is the power_budget_watts of its Rack
+// device.
+func PowerCapacity(devices []*device.Device) *PowerCapacityReport {
+	byUID := make(map[string]*device.Device, len(devices))
+	for _, d := range devices {
+		byUID[d.GetUID()] = d
+	}
+	budgets := make(map[string]float64)
+	for _, d := range devices {
+		var budget float64
+		if d.Spec.DeviceType == "Rack" && d.Spec.GetProperty("power_budget_watts", &budget) {
+			if rack := rackOf(d, byUID); rack != "" {
+				budgets[rack] = budget
+			}
+		}
+	}
+
+	racks := make(map[string]*RackPower)
+	report := &PowerCapacityReport{ByBasis: make(map[string]int), RackList: []RackPower{}}
+	for _, d := range devices {
+		if d.Spec.DeviceType != "Node" || prune.IsRetired(d) {
+			continue
+		}
+		name := rackOf(d, byUID)
+		if name == "" {
+			name = UnrackedRack
+		}
+		rack, ok := racks[name]
+		if !ok {
+			rack = &RackPower{Rack: name, NodeList: []NodePower{}}
+			racks[name] = rack
+		}
+
+		node := nodePower(d)
+		rack.Nodes++
+		report.Nodes++
+		rack.PSURatedWatts += watts(node.PSURatedWatts)
+		rack.MaxConsumedWatts += watts(node.MaxConsumedWatts)
+		rack.ConsumedWatts += watts(node.ConsumedWatts)
+		if node.PlannedWatts == nil {
+			rack.Unknown++
+			report.Unknown++
+		} else {
+			rack.PlannedWatts += *node.PlannedWatts
+			report.PlannedWatts += *node.PlannedWatts
+			report.ByBasis[node.Basis]++
+		}
+		rack.NodeList = append(rack.NodeList, node)
+	}
+
+	for name, rack := range racks {
+		if budget, ok := budgets[name]; ok {
+			headroom := budget - rack.PlannedWatts
+			rack.BudgetWatts, rack.HeadroomWatts = &budget, &headroom
+			rack.OverBudget = headroom < 0
+			if rack.OverBudget {
+				report.OverBudget++
+			}
+		}
+		sort.SliceStable(rack.NodeList, func(i, j int) bool {
+			return watts(rack.NodeList[i].PlannedWatts) > watts(rack.NodeList[j].PlannedWatts)
+		})
+		report.RackList = append(report.RackList, *rack)
+	}
+	sort.Slice(report.RackList, func(i, j int) bool {
+		a, b := report.RackList[i].Rack, report.RackList[j].Rack
+		if (a == UnrackedRack) != (b == UnrackedRack) {
+			return b == UnrackedRack
+		}
+		return a < b
+	})
+	report.Racks = len(report.RackList)
+	return report
+}
+
+// nodePower reads the power properties of a node and plans its draw.
+func nodePower(d *device.Device) NodePower {
+	node := NodePower{DeviceEntry: newDeviceEntry(d)}
+	for _, value := range []struct {
+		key   string
+		watts **float64
+	}{
+		{"psu_rated_watts", &node.PSURatedWatts},
+		{"power_capacity_watts", &node.CapacityWatts},
+		{"power_limit_watts", &node.LimitWatts},
+		{"power_limit_max_watts", &node.LimitMaxWatts},
+		{"power_max_consumed_watts", &node.MaxConsumedWatts},
+		{"power_consumed_watts", &node.ConsumedWatts},
+	} {
+		var w float64
+		if d.Spec.GetProperty(value.key, &w) && w > 0 {
+			*value.watts = &w
+		}
+	}
+	d.Spec.GetProperty("power_limit_enabled", &node.LimitEnabled)
+
+	for _, basis := range []struct {
+		name  string
+		watts *float64
+		use   bool
+	}{
+		{PowerBasisLimit, node.LimitWatts, node.LimitEnabled},
+		{PowerBasisCapacity, node.CapacityWatts, true},
+		{PowerBasisMeasured, node.MaxConsumedWatts, true},
+		{PowerBasisRated, node.PSURatedWatts, true},
+	} {
+		if basis.use && basis.watts != nil {
+			node.PlannedWatts, node.Basis = basis.watts, basis.name
+			break
+		}
+	}
+	return node
+}
+
+// rackOf returns the rack of a device, "row/rack" when its row is known, from
+// the device or its closest ancestor with a rack property, or "".
+func rackOf(d *device.Device, byUID map[string]*device.Device) string {
+	seen := make(map[string]bool)
+	for d != nil && !seen[d.GetUID()] {
+		var rack, row string
+		if d.Spec.GetProperty("rack", &rack) && rack != "" {
+			if d.Spec.GetProperty("row", &row) && row != "" {
+				return row + "/" + rack
+			}
+			return rack
+		}
+		seen[d.GetUID()] = true
+		d = byUID[d.Spec.ParentID]
+	}
+	return ""
+}
+
+func watts(w *float64) float64 {
+	if w == nil {
+		return 0
+	}
+	return *w
+}
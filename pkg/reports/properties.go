@@ -0,0 +1,103 @@
+package reports
+
+import (
+	"sort"
+
+	"github.com/example/inventory-v3/pkg/properties"
+	"github.com/example/inventory-v3/pkg/prune"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// PropertyUsage describes how one property key is used across the fleet.
+type PropertyUsage struct {
+	Key string `json:"key"`
+	// Registered is set for keys the property registry describes, Vendor for
+	// vendor OEM attributes; keys with neither are undocumented.
+	Registered bool   `json:"registered"`
+	Vendor     bool   `json:"vendor,omitempty"`
+	Type       string `json:"type,omitempty"`
+	// Devices counts the devices carrying the key, by device type.
+	Devices     int            `json:"devices"`
+	DeviceTypes map[string]int `json:"deviceTypes"`
+	// Mismatched counts the values that are not of the registered type.
+	Mismatched int `json:"mismatched,omitempty"`
+	// UnexpectedTypes lists the device types carrying a registered key the
+	// registry does not list for them.
+	UnexpectedTypes []string `json:"unexpectedTypes,omitempty"`
+}
+
+// PropertyUsageReport audits the property keys of every active device
+// against the property registry.
+type PropertyUsageReport struct {
+	Devices int `json:"devices"`
+	// Unregistered counts the keys that are neither registered nor vendor
+	// attributes; Mismatched the keys with values of the wrong type.
+	Unregistered int `json:"unregistered"`
+	Mismatched   int `json:"mismatched"`
+	// Keys is sorted with undocumented keys and type mismatches first.
+	Keys []PropertyUsage `json:"keys"`
+}
+
+// PropertyAudit builds the property usage report for every active device.
+func PropertyAudit(devices []*device.Device) *PropertyUsageReport {
+	report := &PropertyUsageReport{Keys: []PropertyUsage{}}
+	usage := make(map[string]*PropertyUsage)
+	for _, d := range devices {
+		if d == nil || prune.IsRetired(d) {
+			continue
+		}
+		report.Devices++
+		for key, raw := range d.Spec.Properties {
+			u := usage[key]
+			if u == nil {
+				u = &PropertyUsage{Key: key, DeviceTypes: map[string]int{}, Vendor: properties.IsVendorKey(key)}
+				if s, ok := properties.Lookup(key); ok {
+					u.Registered, u.Vendor, u.Type = true, false, s.Type
+				}
+				usage[key] = u
+			}
+			u.Devices++
+			u.DeviceTypes[d.Spec.DeviceType]++
+			if s, ok := properties.Lookup(key); ok && s.Check(raw) != nil {
+				u.Mismatched++
+			}
+		}
+	}
+
+	for key, u := range usage {
+		if s, ok := properties.Lookup(key); ok {
+			for deviceType := range u.DeviceTypes {
+				if !s.AppliesTo(deviceType) {
+					u.UnexpectedTypes = append(u.UnexpectedTypes, deviceType)
+				}
+			}
+			sort.Strings(u.UnexpectedTypes)
+		}
+		if !u.Registered && !u.Vendor {
+			report.Unregistered++
+		}
+		if u.Mismatched > 0 {
+			report.Mismatched++
+		}
+		report.Keys = append(report.Keys, *u)
+	}
+	rank := func(u PropertyUsage) int {
+		switch {
+		case u.Mismatched > 0:
+			return 0
+		case !u.Registered && !u.Vendor:
+			return 1
+		case len(u.UnexpectedTypes) > 0:
+			return 2
+		}
+		return 3
+	}
+	sort.Slice(report.Keys, func(i, j int) bool {
+		a, b := report.Keys[i], report.Keys[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		return a.Key < b.Key
+	})
+	return report
+}
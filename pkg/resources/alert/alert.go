@@ -0,0 +1,101 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Alert severities.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Severities lists the valid severities.
+var Severities = []string{SeverityInfo, SeverityWarning, SeverityCritical}
+
+// Alert states, set by the alert evaluator.
+const (
+	StateFiring   = "Firing"
+	StateResolved = "Resolved"
+)
+
+// Alert represents an Alert resource: the state of one alert rule for one
+// subject. The server's alert evaluator creates it when the rule's condition
+// first holds, and resolves it once the condition clears; a rule firing again
+// for the same subject reopens the same Alert.
+type Alert struct {
+	resource.Resource
+	Spec   AlertSpec   `json:"spec" validate:"required"`
+	Status AlertStatus `json:"status,omitempty"`
+}
+
+// AlertSpec defines the desired state of Alert
+type AlertSpec struct {
+	// Rule names the alert rule that fired.
+	Rule     string `json:"rule" validate:"required"`
+	Severity string `json:"severity,omitempty"`
+
+	// Subject is the UID of the device the alert is about, SubjectName its
+	// name when the alert fired.
+	Subject     string `json:"subject" validate:"required"`
+	SubjectName string `json:"subjectName,omitempty"`
+}
+
+// AlertStatus defines the observed state of Alert
+type AlertStatus struct {
+	// State is Firing or Resolved.
+	State   string `json:"state,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+
+	// FiredAt is when the alert last started firing.
+	FiredAt    time.Time  `json:"firedAt,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// Firing reports whether the alert's condition still holds.
+func (r *Alert) Firing() bool {
+	return r.Status.State == StateFiring
+}
+
+// Validate implements custom validation logic for Alert
+func (r *Alert) Validate(ctx context.Context) error {
+	if r.Spec.Severity == "" {
+		return nil
+	}
+	for _, s := range Severities {
+		if r.Spec.Severity == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q (valid: %v)", r.Spec.Severity, Severities)
+}
+
+// GetKind returns the kind of the resource
+func (r *Alert) GetKind() string {
+	return "Alert"
+}
+
+// GetName returns the name of the resource
+func (r *Alert) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *Alert) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("Alert", "alr")
+}
@@ -0,0 +1,227 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package bmcendpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Collection profiles control how much the collector gathers from a BMC.
+const (
+	// ProfileQuick collects the node, its serial numbers, and NIC MAC addresses.
+	ProfileQuick = "quick"
+	// ProfileFull collects every mapped component. It is the default.
+	ProfileFull = "full"
+	// ProfileDeep adds a raw capture of every resource read, BIOS attributes,
+	// the firmware inventory, and log entries.
+	ProfileDeep = "deep"
+)
+
+// Profiles lists the valid collection profiles.
+var Profiles = []string{ProfileQuick, ProfileFull, ProfileDeep}
+
+// ValidProfile reports whether p is a known profile ("" selects the default).
+func ValidProfile(p string) bool {
+	if p == "" {
+		return true
+	}
+	for _, known := range Profiles {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Management protocols an endpoint is collected over.
+const (
+	// ProtocolRedfish is collected by the built-in backends (or Backend).
+	ProtocolRedfish = "redfish"
+	// ProtocolIPMI and ProtocolSNMP are collected by the collector plugin of
+	// the same name.
+	ProtocolIPMI = "ipmi"
+	ProtocolSNMP = "snmp"
+	// ProtocolNone marks equipment without a management interface; it is kept
+	// in the inventory but never collected.
+	ProtocolNone = "none"
+)
+
+// Protocols lists the valid protocols.
+var Protocols = []string{ProtocolRedfish, ProtocolIPMI, ProtocolSNMP, ProtocolNone}
+
+// DefaultProbeOrder is the order protocols are probed in when an endpoint
+// names none.
+var DefaultProbeOrder = []string{ProtocolRedfish, ProtocolIPMI, ProtocolSNMP}
+
+// ValidProtocol reports whether p is a known protocol ("" auto-detects).
+func ValidProtocol(p string) bool {
+	if p == "" {
+		return true
+	}
+	for _, known := range Protocols {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// BMCEndpoint represents a BMCEndpoint resource
+type BMCEndpoint struct {
+	resource.Resource
+	Spec   BMCEndpointSpec   `json:"spec" validate:"required"`
+	Status BMCEndpointStatus `json:"status,omitempty"`
+}
+
+// BMCEndpointSpec defines the desired state of BMCEndpoint
+type BMCEndpointSpec struct {
+	// Address is the BMC host name or IP address the collector connects to.
+	Address string `json:"address" validate:"required"`
+
+	// Profile selects the collection profile: quick, full, or deep.
+	// Runs that do not ask for a profile use this one.
+	Profile string `json:"profile,omitempty"`
+
+	// Backend selects the collector backend (e.g. redfish, pdu, cdu, or a
+	// collector plugin).
+	Backend string `json:"backend,omitempty"`
+
+	// Protocol is the management protocol the endpoint speaks: redfish,
+	// ipmi, snmp, or none. When empty the collector probes the protocols of
+	// ProbeOrder and uses the first that answers.
+	Protocol string `json:"protocol,omitempty"`
+
+	// ProbeOrder lists the protocols to probe when Protocol is empty; empty
+	// uses DefaultProbeOrder.
+	ProbeOrder []string `json:"probeOrder,omitempty"`
+
+	// Disabled stops collection from the endpoint. Decommissioning the last
+	// node an endpoint reports sets it.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Collected reports whether collectors should collect from the endpoint: it
+// is enabled and has a management protocol.
+func (s BMCEndpointSpec) Collected() bool {
+	return !s.Disabled && s.Protocol != ProtocolNone
+}
+
+// BMCEndpointStatus defines the observed state of BMCEndpoint
+type BMCEndpointStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+
+	// The collector records the outcome of each collection from the endpoint.
+
+	// LastSuccessAt is when a collection last posted a snapshot.
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	// LastFailureAt is when a collection last failed.
+	LastFailureAt *time.Time `json:"lastFailureAt,omitempty"`
+	// LastError is the error of the last failed collection.
+	LastError string `json:"lastError,omitempty"`
+	// ConsecutiveFailures counts the collections that failed since the last
+	// success.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// Shape records the Redfish subtrees the BMC lacks, so that collections
+	// can skip probing them.
+	Shape *DiscoveryShape `json:"shape,omitempty"`
+
+	// DetectedProtocol is the protocol the collector found the endpoint to
+	// answer when the spec names none. Collections use it until one fails,
+	// and then probe again.
+	DetectedProtocol string `json:"detectedProtocol,omitempty"`
+
+	// Credential records the last time "collector bootstrap-creds" replaced
+	// the BMC's factory login with the site account.
+	Credential *CredentialRotation `json:"credential,omitempty"`
+}
+
+// CredentialRotation records a change of the collector's BMC login. The
+// password is kept only in the credential store, never here.
+type CredentialRotation struct {
+	// Account is the BMC user name the collector logs in with.
+	Account string `json:"account"`
+	// Store is the credential store the password was written to.
+	Store string `json:"store"`
+	// Created is set when the account was created rather than its
+	// password reset.
+	Created   bool      `json:"created"`
+	RotatedAt time.Time `json:"rotatedAt"`
+}
+
+// DiscoveryShape is the part of a BMC's Redfish tree known to be absent.
+type DiscoveryShape struct {
+	// Absent lists the subtrees (e.g. "Storage") the BMC does not implement.
+	Absent []string `json:"absent"`
+	// ProbedAt is when every subtree was last probed. Collections skip the
+	// absent subtrees until it is older than their re-probe interval.
+	ProbedAt *time.Time `json:"probedAt,omitempty"`
+}
+
+// RetryAfter returns when a scheduler collecting every interval should next
+// try the endpoint. After a failure the wait doubles with each further
+// consecutive failure, up to max, so a dead BMC is not polled every cycle.
+// It is the zero time when the last collection did not fail.
+func (s BMCEndpointStatus) RetryAfter(interval, max time.Duration) time.Time {
+	if s.ConsecutiveFailures == 0 || s.LastFailureAt == nil {
+		return time.Time{}
+	}
+	wait := interval
+	for i := 1; i < s.ConsecutiveFailures && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return s.LastFailureAt.Add(wait)
+}
+
+// ReprobeDue reports whether every subtree should be probed again, the shape
+// being older than reprobe or never fully probed.
+func (s *DiscoveryShape) ReprobeDue(reprobe time.Duration, now time.Time) bool {
+	return s == nil || s.ProbedAt == nil || !now.Before(s.ProbedAt.Add(reprobe))
+}
+
+// Validate implements custom validation logic for BMCEndpoint
+func (r *BMCEndpoint) Validate(ctx context.Context) error {
+	if !ValidProfile(r.Spec.Profile) {
+		return fmt.Errorf("unknown profile %q (valid: %v)", r.Spec.Profile, Profiles)
+	}
+	if !ValidProtocol(r.Spec.Protocol) {
+		return fmt.Errorf("unknown protocol %q (valid: %v)", r.Spec.Protocol, Protocols)
+	}
+	for _, p := range r.Spec.ProbeOrder {
+		if p == "" || p == ProtocolNone || !ValidProtocol(p) {
+			return fmt.Errorf("cannot probe protocol %q (valid: %v)", p, DefaultProbeOrder)
+		}
+	}
+	return nil
+}
+
+// GetKind returns the kind of the resource
+func (r *BMCEndpoint) GetKind() string {
+	return "BMCEndpoint"
+}
+
+// GetName returns the name of the resource
+func (r *BMCEndpoint) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *BMCEndpoint) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("BMCEndpoint", "bmc")
+}
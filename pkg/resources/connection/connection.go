@@ -0,0 +1,113 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// How a connection was found.
+const (
+	// MethodLLDP connections were reported by the switch's LLDP neighbors.
+	MethodLLDP = "lldp"
+	// MethodMACTable connections were inferred from the MAC address a switch
+	// port learned.
+	MethodMACTable = "mac-table"
+	// MethodManual connections were recorded by hand; cabling inference
+	// never changes them.
+	MethodManual = "manual"
+)
+
+// Methods lists the valid methods.
+var Methods = []string{MethodLLDP, MethodMACTable, MethodManual}
+
+// Connection phases.
+const (
+	// PhaseActive connections were seen by the last inference (or are manual).
+	PhaseActive = "Active"
+	// PhaseStale connections were inferred before but not by the last
+	// inference, e.g. because the cable was moved.
+	PhaseStale = "Stale"
+	// PhaseMiscabled manual connections were contradicted by the last
+	// inference: the switch port or the NIC was found cabled elsewhere.
+	PhaseMiscabled = "Miscabled"
+)
+
+// Connection represents a Connection resource: a cable between a switch
+// port and a node's NIC, which together make the fabric topology.
+type Connection struct {
+	resource.Resource
+	Spec   ConnectionSpec   `json:"spec" validate:"required"`
+	Status ConnectionStatus `json:"status,omitempty"`
+}
+
+// ConnectionSpec defines the desired state of Connection
+type ConnectionSpec struct {
+	// SwitchID is the UID of the switch device.
+	SwitchID string `json:"switchID" validate:"required"`
+	// SwitchPort is the port as the switch names it, e.g. "Ethernet1/12".
+	SwitchPort string `json:"switchPort" validate:"required"`
+
+	// NICID is the UID of the NIC device at the other end, and NodeID the
+	// UID of the node it is in.
+	NICID  string `json:"nicID,omitempty"`
+	NodeID string `json:"nodeID,omitempty"`
+	// MACAddress is the address of the NIC port cabled to the switch port.
+	MACAddress string `json:"macAddress,omitempty"`
+
+	// Method is how the connection was found: lldp, mac-table, or manual.
+	Method string `json:"method" validate:"required"`
+}
+
+// ConnectionStatus defines the observed state of Connection
+type ConnectionStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+
+	// LastSeen is when cabling inference last found the connection.
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+	// Found is what cabling inference found instead of a Miscabled
+	// connection.
+	Found string `json:"found,omitempty"`
+}
+
+// Validate implements custom validation logic for Connection
+func (r *Connection) Validate(ctx context.Context) error {
+	if r.Spec.NICID == "" && r.Spec.MACAddress == "" {
+		return errors.New("a connection needs the NIC's nicID or macAddress")
+	}
+	for _, method := range Methods {
+		if r.Spec.Method == method {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown method %q (valid: %v)", r.Spec.Method, Methods)
+}
+
+// GetKind returns the kind of the resource
+func (r *Connection) GetKind() string {
+	return "Connection"
+}
+
+// GetName returns the name of the resource
+func (r *Connection) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *Connection) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("Connection", "con")
+}
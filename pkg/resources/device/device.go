@@ -7,9 +7,24 @@ package device
 import (
 	"context"
 	"encoding/json"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/properties"
 	"github.com/openchami/fabrica/pkg/resource"
 )
 
+// Phases the snapshot reconciler sets on devices a full snapshot from their
+// BMC did not report. A device reported again loses them.
+const (
+	// PhaseMissing marks a device that is no longer reported while its node
+	// is on; it may have been removed.
+	PhaseMissing = "Missing"
+	// PhasePoweredOff marks a device not reported while its node was powered
+	// off. BMCs often omit the components of a node that is off, so the
+	// device is most likely still installed.
+	PhasePoweredOff = "PoweredOff"
+)
+
 // Device represents a Device resource
 type Device struct {
 	resource.Resource
@@ -34,6 +49,20 @@ DeviceType   string `json:"deviceType" validate:"required"`
 
 	// Properties is an arbitrary key-value map for non-standard attributes.
 	Properties map[string]json.RawMessage `json:"properties,omitempty"`
+
+	// Provenance holds, by property key, the value as its source reported it
+	// when it was converted to the property's canonical unit.
+	Provenance map[string]PropertyProvenance `json:"provenance,omitempty"`
+}
+
+// PropertyProvenance records the original value of a converted property.
+type PropertyProvenance struct {
+	Raw json.RawMessage `json:"raw"`
+	// Unit is the unit the raw value was read in.
+	Unit string `json:"unit"`
+	// Rule names the unit rule that told the unit, when the raw value did not,
+	// or that read its unit as another.
+	Rule string `json:"rule,omitempty"`
 }
 
 // DeviceStatus defines the observed state of Device
@@ -44,17 +73,58 @@ type DeviceStatus struct {
 	
 	// ChildrenDeviceIds is a read-only list of devices contained within this one.
 	ChildrenDeviceIds []string `json:"childrenDeviceIds,omitempty"`
+
+	// LastSeen is when the device last appeared in a reconciled snapshot.
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+
+	// Source is the BMC address of the snapshot that last reported the device.
+	// Snapshots only match, flag and retire the devices of their own source.
+	Source string `json:"source,omitempty"`
+
+	// SourceEndpoint is the UID of the BMCEndpoint registered for Source, and
+	// SourceCollector the host of the collector that posted the snapshot.
+	SourceEndpoint  string `json:"sourceEndpoint,omitempty"`
+	SourceCollector string `json:"sourceCollector,omitempty"`
+
+	// Summary aggregates a Node's children. It is computed by the reconciler
+	// and only set on Node devices.
+	Summary *NodeSummary `json:"summary,omitempty"`
+
+	// Completeness scores the inventory data reported for a Node. It is
+	// computed by the reconciler with the summary.
+	Completeness *Completeness `json:"completeness,omitempty"`
 }
 
-// Validate implements custom validation logic for Device
-func (r *Device) Validate(ctx context.Context) error {
-	// Add custom validation logic here
-	// Example:
-	// if r.Spec.Name == "forbidden" {
-	//     return errors.New("name 'forbidden' is not allowed")
-	// }
+// NodeSummary holds totals derived from a Node's child devices.
+type NodeSummary struct {
+	TotalMemoryGiB float64  `json:"totalMemoryGiB"`
+	CPUSockets     int      `json:"cpuSockets"`
+	CPUCores       int      `json:"cpuCores"`
+	CPUThreads     int      `json:"cpuThreads"`
+	GPUCount       int      `json:"gpuCount"`
+	NICMACs        []string `json:"nicMACs,omitempty"`
+}
 
-	return nil
+// Completeness rates how complete a Node's inventory data is.
+type Completeness struct {
+	// Score runs from 0 to 100: the mean of the share of expected component
+	// categories present and the share of checked fields with a value.
+	Score float64 `json:"score"`
+	// Categories counts the expected component categories (CPU, DIMM,
+	// Drive, NIC) the node has components in; MissingCategories lists the
+	// others.
+	Categories        int      `json:"categories"`
+	MissingCategories []string `json:"missingCategories,omitempty"`
+	// Fields counts the fields checked on the node and its components, and
+	// EmptyFields those without a value.
+	Fields      int `json:"fields"`
+	EmptyFields int `json:"emptyFields"`
+}
+
+// Validate implements custom validation logic for Device. Properties the
+// property registry describes must hold values of their registered type.
+func (r *Device) Validate(ctx context.Context) error {
+	return properties.Validate(r.Spec.Properties)
 }
 // GetKind returns the kind of the resource
 func (r *Device) GetKind() string {
@@ -75,3 +145,26 @@ func init() {
 	// Register resource type prefix for storage
 	resource.RegisterResourcePrefix("Device", "dev")
 }
+
+// GetProperty decodes the named property into v. It reports false when the
+// property is missing or does not decode into v.
+func (s *DeviceSpec) GetProperty(key string, v interface{}) bool {
+	raw, ok := s.Properties[key]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
+
+// SetProperty JSON-encodes value and stores it under key.
+func (s *DeviceSpec) SetProperty(key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if s.Properties == nil {
+		s.Properties = make(map[string]json.RawMessage)
+	}
+	s.Properties[key] = raw
+	return nil
+}
@@ -5,9 +5,13 @@
 package discoverysnapshot
 
 import (
+	"bytes"
 	"context"
 	"github.com/openchami/fabrica/pkg/resource"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // DiscoverySnapshot represents a DiscoverySnapshot resource
@@ -22,6 +26,153 @@ type DiscoverySnapshotSpec struct {
 	// RawData holds the complete, raw JSON payload from a discovery tool (e.g., the collector).
 	// The reconciler will parse this.
 	RawData json.RawMessage `json:"rawData" validate:"required"`
+
+	// BMCAddress is the address the collector read the snapshot from.
+	BMCAddress string `json:"bmcAddress,omitempty"`
+
+	// Profile is the collection profile (quick, full, deep) the collector ran with.
+	Profile string `json:"profile,omitempty"`
+
+	// Priority selects the reconciler's lane for the snapshot: interactive
+	// snapshots, from an operator's run, are reconciled before scheduled
+	// ones. "" is scheduled. It is not signed.
+	Priority string `json:"priority,omitempty"`
+
+	// Decommissioned marks a deletion snapshot: the BMC at BMCAddress was
+	// decommissioned, RawData is an empty list, and the reconciler retires
+	// every device last reported by that BMC.
+	Decommissioned bool `json:"decommissioned,omitempty"`
+
+	// RawCapture maps each Redfish path read during a deep collection to its
+	// response body. It is kept for auditing and is not reconciled.
+	RawCapture json.RawMessage `json:"rawCapture,omitempty"`
+
+	// Coverage records which Redfish subtrees the collector failed to read.
+	// A snapshot without it is taken as complete.
+	Coverage *Coverage `json:"coverage,omitempty"`
+
+	// Signature is the collector's signature over SignedContent, checked by
+	// the reconciler against the server's trusted keys.
+	Signature *Signature `json:"signature,omitempty"`
+
+	// Provenance traces the snapshot back to the collection run that
+	// produced it. It is not signed.
+	Provenance *Provenance `json:"provenance,omitempty"`
+
+	// Attestation is the in-toto attestation of the collection run, whose
+	// subject is the digest of SignedContent (see package attestation).
+	Attestation *Envelope `json:"attestation,omitempty"`
+}
+
+// Snapshot priorities select the reconciler's lane for a snapshot.
+const (
+	// PriorityInteractive is for runs an operator is waiting on, e.g. to
+	// verify a repair. They are reconciled ahead of scheduled snapshots.
+	PriorityInteractive = "interactive"
+	// PriorityScheduled is for bulk, scheduled collections. It is the default.
+	PriorityScheduled = "scheduled"
+)
+
+// Priorities lists the valid snapshot priorities.
+var Priorities = []string{PriorityInteractive, PriorityScheduled}
+
+// ValidPriority reports whether p is a known priority ("" is scheduled).
+func ValidPriority(p string) bool {
+	return p == "" || p == PriorityInteractive || p == PriorityScheduled
+}
+
+// Provenance identifies the collection run behind a snapshot.
+type Provenance struct {
+	// RunID identifies the run. The collector logs it and sends it in the
+	// X-Run-ID header of its API calls; the reconciler logs it with every
+	// line about the snapshot and records it on the events it creates.
+	RunID string `json:"runId,omitempty"`
+	// Collector is the host name of the collector.
+	Collector string `json:"collector,omitempty"`
+}
+
+// Signature signs a snapshot's SignedContent.
+type Signature struct {
+	// KeyID is the hex SHA-256 fingerprint of the signer's public key (PKIX DER).
+	KeyID string `json:"keyId"`
+	// Algorithm is ed25519, ecdsa-sha256 or rsa-sha256.
+	Algorithm string `json:"algorithm"`
+	Value     []byte `json:"value"`
+}
+
+// Envelope is a DSSE envelope: a payload and the signatures over its
+// pre-authentication encoding. Payload and Sig are base64 in JSON.
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     []byte              `json:"payload"`
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+// EnvelopeSignature is one signature of an Envelope.
+type EnvelopeSignature struct {
+	// KeyID is the signer's key ID, as in Signature.
+	KeyID string `json:"keyid"`
+	Sig   []byte `json:"sig"`
+}
+
+// SignedContent returns the bytes a snapshot signature covers: the BMC
+// address, profile, device list, coverage and whether it is a deletion
+// snapshot. The device list is re-encoded
+// canonically so that the server storing the snapshot does not invalidate the
+// signature. The raw capture is not reconciled and not signed.
+func (s *DiscoverySnapshotSpec) SignedContent() ([]byte, error) {
+	var devices interface{}
+	decoder := json.NewDecoder(bytes.NewReader(s.RawData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&devices); err != nil {
+		return nil, fmt.Errorf("failed to parse rawData: %w", err)
+	}
+	return json.Marshal(struct {
+		BMCAddress string      `json:"bmcAddress"`
+		Profile    string      `json:"profile"`
+		Devices    interface{} `json:"devices"`
+		Coverage   *Coverage   `json:"coverage"`
+		// Left out unless set, so that older signatures still verify
+		Decommissioned bool `json:"decommissioned,omitempty"`
+	}{s.BMCAddress, s.Profile, devices, s.Coverage, s.Decommissioned})
+}
+
+// Coverage counts the Redfish reads a collection attempted and maps each
+// path that failed (a server error, a timeout, a refused login) to its error.
+// Paths the BMC does not implement are neither reads nor failures.
+type Coverage struct {
+	Reads  int               `json:"reads"`
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// Partial reports whether some subtree could not be read.
+func (c *Coverage) Partial() bool {
+	return c != nil && len(c.Failed) > 0
+}
+
+// Confidence is the share of reads that succeeded, from 0 to 1. A snapshot
+// without coverage scores 1.
+func (c *Coverage) Confidence() float64 {
+	if c == nil || c.Reads == 0 {
+		return 1
+	}
+	return float64(c.Reads-len(c.Failed)) / float64(c.Reads)
+}
+
+// Covers reports whether the resource at uri was collected, i.e. neither it
+// nor a subtree above it failed. URIs may keep the /redfish/v1 prefix.
+func (c *Coverage) Covers(uri string) bool {
+	if !c.Partial() || uri == "" {
+		return true
+	}
+	uri = strings.TrimSuffix(strings.TrimPrefix(uri, "/redfish/v1"), "/")
+	for path := range c.Failed {
+		path = strings.TrimSuffix(path, "/")
+		if uri == path || strings.HasPrefix(uri, path+"/") {
+			return false
+		}
+	}
+	return true
 }
 
 // DiscoverySnapshotStatus defines the observed state of DiscoverySnapshot
@@ -29,16 +180,77 @@ type DiscoverySnapshotStatus struct {
 	Phase      string `json:"phase,omitempty"`
 	Message    string `json:"message,omitempty"`
 	Ready      bool   `json:"ready"`
+
+	// Partial is set when the collector failed to read some subtrees; devices
+	// under them are not flagged as missing. Confidence is Coverage.Confidence.
+	Partial    bool     `json:"partial,omitempty"`
+	Confidence *float64 `json:"confidence,omitempty"`
+
+	// SignedBy is the key ID of the verified signature, if the snapshot was signed.
+	SignedBy string `json:"signedBy,omitempty"`
+
+	// Devices lists the devices reconciling the snapshot changed.
+	Devices *DeviceChanges `json:"devices,omitempty"`
+
+	// Hold says why the reconciler held the snapshot (phase Held) instead of
+	// applying it, and Review records the operator's decision on it.
+	Hold   *Hold   `json:"hold,omitempty"`
+	Review *Review `json:"review,omitempty"`
+}
+
+// PhaseHeld is the phase of a snapshot that would retire or replace more of
+// the inventory than the server's change guard allows. It is applied once an
+// operator approves it.
+const PhaseHeld = "Held"
+
+// Hold is how much of the inventory a held snapshot would change.
+type Hold struct {
+	// Scope is "bmc" when the snapshot would change too many of its BMC's
+	// devices, "fleet" when too many of the fleet's.
+	Scope string `json:"scope"`
+	// Unreported counts the devices the snapshot leaves out, which would be
+	// flagged missing, or retires; Replaced those with a new serial number.
+	Unreported int `json:"unreported"`
+	Replaced   int `json:"replaced"`
+	// Devices counts the active devices of the scope.
+	Devices int     `json:"devices"`
+	Percent float64 `json:"percent"`
+	Limit   float64 `json:"limit"`
+}
+
+// Review is an operator's decision on a held snapshot.
+type Review struct {
+	Approved bool      `json:"approved"`
+	By       string    `json:"by,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// DeviceChanges lists, by UID, the devices reconciling a snapshot created,
+// updated and retired, in the order it reached them.
+type DeviceChanges struct {
+	Created []string `json:"created,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	// Retired lists the devices a deletion snapshot retired.
+	Retired []string `json:"retired,omitempty"`
 }
 
 // Validate implements custom validation logic for DiscoverySnapshot
 func (r *DiscoverySnapshot) Validate(ctx context.Context) error {
-	// Add custom validation logic here
-	// Example:
-	// if r.Spec.Name == "forbidden" {
-	//     return errors.New("name 'forbidden' is not allowed")
-	// }
-
+	if !ValidPriority(r.Spec.Priority) {
+		return fmt.Errorf("unknown priority %q (valid: %v)", r.Spec.Priority, Priorities)
+	}
+	if r.Spec.Decommissioned {
+		// A deletion snapshot retires everything its BMC reported, so it
+		// must name the BMC and may not report devices
+		if r.Spec.BMCAddress == "" {
+			return fmt.Errorf("a decommissioned snapshot needs a bmcAddress")
+		}
+		var devices []json.RawMessage
+		if err := json.Unmarshal(r.Spec.RawData, &devices); err != nil || len(devices) > 0 {
+			return fmt.Errorf("the rawData of a decommissioned snapshot must be an empty list")
+		}
+	}
 	return nil
 }
 // GetKind returns the kind of the resource
@@ -60,3 +272,26 @@ func init() {
 	// Register resource type prefix for storage
 	resource.RegisterResourcePrefix("DiscoverySnapshot", "dis")
 }
+
+// RunID returns the ID of the collection run that produced a snapshot, or ""
+// for snapshots from collectors that predate run IDs.
+func RunID(snapshot *DiscoverySnapshot) string {
+	if snapshot.Spec.Provenance == nil {
+		return ""
+	}
+	return snapshot.Spec.Provenance.RunID
+}
+
+// Address returns the BMC address a snapshot was collected from. Snapshots
+// that predate BMCAddress fall back to the collector's naming scheme,
+// "snapshot-<address>-<unix time>".
+func Address(snapshot *DiscoverySnapshot) string {
+	if snapshot.Spec.BMCAddress != "" {
+		return snapshot.Spec.BMCAddress
+	}
+	name := strings.TrimPrefix(snapshot.GetName(), "snapshot-")
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		return name[:i]
+	}
+	return name
+}
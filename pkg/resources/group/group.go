@@ -0,0 +1,101 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package group
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Group represents a Group resource: a named set of devices, such as "login
+// nodes" or "rack row 3", that device lists and reports can be scoped to.
+// Its members are listed, selected by label, or both.
+type Group struct {
+	resource.Resource
+	Spec   GroupSpec   `json:"spec" validate:"required"`
+	Status GroupStatus `json:"status,omitempty"`
+}
+
+// GroupSpec defines the desired state of Group
+type GroupSpec struct {
+	Description string `json:"description,omitempty"`
+
+	// Members lists the UIDs or names of member devices.
+	Members []string `json:"members,omitempty"`
+
+	// Selector adds the devices carrying all of these labels.
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// DeviceType, when set, limits the devices the selector adds to one
+	// type, e.g. Node.
+	DeviceType string `json:"deviceType,omitempty"`
+
+	// IncludeChildren adds every device contained in a member, so that a
+	// group of nodes also covers their CPUs, DIMMs and drives.
+	IncludeChildren bool `json:"includeChildren,omitempty"`
+}
+
+// GroupStatus defines the observed state of Group
+type GroupStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+}
+
+// SelectorMatches reports whether the selector adds a device with the given
+// type and labels. A group without a selector adds none.
+func (r *Group) SelectorMatches(deviceType string, labels map[string]string) bool {
+	if len(r.Spec.Selector) == 0 {
+		return false
+	}
+	if r.Spec.DeviceType != "" && deviceType != r.Spec.DeviceType {
+		return false
+	}
+	for key, value := range r.Spec.Selector {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate implements custom validation logic for Group
+func (r *Group) Validate(ctx context.Context) error {
+	if len(r.Spec.Members) == 0 && len(r.Spec.Selector) == 0 {
+		return errors.New("a group needs members or a selector")
+	}
+	if r.Spec.DeviceType != "" && len(r.Spec.Selector) == 0 {
+		return errors.New("deviceType narrows the selector and needs one")
+	}
+	for _, member := range r.Spec.Members {
+		if strings.TrimSpace(member) == "" {
+			return errors.New("members must not be empty")
+		}
+	}
+	return nil
+}
+
+// GetKind returns the kind of the resource
+func (r *Group) GetKind() string {
+	return "Group"
+}
+
+// GetName returns the name of the resource
+func (r *Group) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *Group) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("Group", "grp")
+}
@@ -0,0 +1,132 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package maintenancewindow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Repeat values of a window that recurs.
+const (
+	RepeatDaily  = "daily"
+	RepeatWeekly = "weekly"
+)
+
+// Repeats lists the valid repeat values ("" is a one-off window).
+var Repeats = []string{RepeatDaily, RepeatWeekly}
+
+// Maintenance window phases, set by the reconciler.
+const (
+	PhaseScheduled = "Scheduled"
+	PhaseActive    = "Active"
+	PhaseEnded     = "Ended"
+)
+
+// MaintenanceWindow represents a MaintenanceWindow resource: a period in
+// which hardware may be powered off for service. The collector agent does not
+// collect from the endpoints it covers, and the missing-devices report and
+// the consistency check leave them out.
+type MaintenanceWindow struct {
+	resource.Resource
+	Spec   MaintenanceWindowSpec   `json:"spec" validate:"required"`
+	Status MaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+// MaintenanceWindowSpec defines the desired state of MaintenanceWindow
+type MaintenanceWindowSpec struct {
+	Summary string `json:"summary,omitempty"`
+
+	// Start and End bound the window. A repeating window recurs from Start
+	// with the same length.
+	Start time.Time `json:"start" validate:"required"`
+	End   time.Time `json:"end" validate:"required"`
+
+	// Repeat makes the window recur: daily or weekly.
+	Repeat string `json:"repeat,omitempty"`
+
+	// EndpointIDs lists the UIDs or names of the BMCEndpoints covered.
+	EndpointIDs []string `json:"endpointIDs,omitempty"`
+
+	// RackIDs lists the UIDs of the racks covered, with everything in them.
+	RackIDs []string `json:"rackIDs,omitempty"`
+}
+
+// MaintenanceWindowStatus defines the observed state of MaintenanceWindow
+type MaintenanceWindowStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+}
+
+// Global reports whether the window covers the whole fleet: it names no
+// endpoints and no racks.
+func (r *MaintenanceWindow) Global() bool {
+	return len(r.Spec.EndpointIDs) == 0 && len(r.Spec.RackIDs) == 0
+}
+
+// ActiveAt reports whether t falls inside the window or one of its repeats.
+func (r *MaintenanceWindow) ActiveAt(t time.Time) bool {
+	if t.Before(r.Spec.Start) {
+		return false
+	}
+	period := repeatPeriod(r.Spec.Repeat)
+	if period == 0 {
+		return t.Before(r.Spec.End)
+	}
+	return t.Sub(r.Spec.Start)%period < r.Spec.End.Sub(r.Spec.Start)
+}
+
+// repeatPeriod returns how often a window repeats, or zero.
+func repeatPeriod(repeat string) time.Duration {
+	switch repeat {
+	case RepeatDaily:
+		return 24 * time.Hour
+	case RepeatWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Validate implements custom validation logic for MaintenanceWindow
+func (r *MaintenanceWindow) Validate(ctx context.Context) error {
+	if !r.Spec.End.After(r.Spec.Start) {
+		return errors.New("end must be after start")
+	}
+	if r.Spec.Repeat != "" {
+		period := repeatPeriod(r.Spec.Repeat)
+		if period == 0 {
+			return fmt.Errorf("unknown repeat %q (valid: %v)", r.Spec.Repeat, Repeats)
+		}
+		if r.Spec.End.Sub(r.Spec.Start) >= period {
+			return fmt.Errorf("a %s window must be shorter than its period", r.Spec.Repeat)
+		}
+	}
+	return nil
+}
+
+// GetKind returns the kind of the resource
+func (r *MaintenanceWindow) GetKind() string {
+	return "MaintenanceWindow"
+}
+
+// GetName returns the name of the resource
+func (r *MaintenanceWindow) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *MaintenanceWindow) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("MaintenanceWindow", "mnt")
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package pendingchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// What a pending change does to the inventory once approved.
+const (
+	// ActionRetire retires the devices, and disables the BMCEndpoints in
+	// DetachEndpoints.
+	ActionRetire = "retire"
+	// ActionDelete deletes the devices.
+	ActionDelete = "delete"
+	// ActionSerialRewrite records a device's new serial number.
+	ActionSerialRewrite = "serialRewrite"
+)
+
+// Actions lists the valid actions.
+var Actions = []string{ActionRetire, ActionDelete, ActionSerialRewrite}
+
+// PendingChange phases.
+const (
+	// PhasePending changes wait for an operator to approve or reject them.
+	PhasePending = "Pending"
+	// PhaseApproved changes are applied by the reconciler.
+	PhaseApproved = "Approved"
+	// PhaseApplied changes were applied, PhaseFailed ones not entirely; see
+	// Status.Errors.
+	PhaseApplied = "Applied"
+	PhaseFailed  = "Failed"
+	// PhaseRejected changes are never applied.
+	PhaseRejected = "Rejected"
+)
+
+// PendingChange represents a PendingChange resource: a destructive change to
+// the inventory, such as a mass retirement or a serial number rewrite, that
+// the site's change-control policy holds until an operator signs it off.
+type PendingChange struct {
+	resource.Resource
+	Spec   PendingChangeSpec   `json:"spec" validate:"required"`
+	Status PendingChangeStatus `json:"status,omitempty"`
+}
+
+// PendingChangeSpec defines the desired state of PendingChange
+type PendingChangeSpec struct {
+	// Action is retire, delete, or serialRewrite.
+	Action string `json:"action" validate:"required"`
+	// Summary says what the change does and what asked for it, e.g. the
+	// prune selector or the snapshot that reported the new serial number.
+	Summary string `json:"summary,omitempty"`
+
+	// Devices are the UIDs of the devices retired or deleted.
+	Devices []string `json:"devices,omitempty"`
+	// DetachEndpoints are the UIDs of the BMCEndpoints a decommission
+	// disables along with its devices.
+	DetachEndpoints []string `json:"detachEndpoints,omitempty"`
+
+	// Serial is the rewrite of a serialRewrite change.
+	Serial *SerialRewrite `json:"serial,omitempty"`
+}
+
+// SerialRewrite is a new serial number reported for a device.
+type SerialRewrite struct {
+	DeviceID        string `json:"deviceID"`
+	Slot            string `json:"slot,omitempty"`
+	OldSerialNumber string `json:"oldSerialNumber"`
+	NewSerialNumber string `json:"newSerialNumber"`
+	// Snapshot and RunID identify the collection that reported it.
+	Snapshot   string    `json:"snapshot,omitempty"`
+	RunID      string    `json:"runID,omitempty"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+// PendingChangeStatus defines the observed state of PendingChange
+type PendingChangeStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+
+	// Review records the operator's decision.
+	Review *Review `json:"review,omitempty"`
+	// Applied counts the devices changed; Errors lists failures.
+	Applied   int        `json:"applied,omitempty"`
+	Errors    []string   `json:"errors,omitempty"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// Review is an operator's decision on a pending change.
+type Review struct {
+	Approved bool      `json:"approved"`
+	By       string    `json:"by,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// Validate implements custom validation logic for PendingChange
+func (r *PendingChange) Validate(ctx context.Context) error {
+	switch r.Spec.Action {
+	case ActionRetire, ActionDelete:
+		if len(r.Spec.Devices) == 0 {
+			return fmt.Errorf("a %s change needs devices", r.Spec.Action)
+		}
+	case ActionSerialRewrite:
+		if r.Spec.Serial == nil || r.Spec.Serial.DeviceID == "" || r.Spec.Serial.NewSerialNumber == "" {
+			return errors.New("a serialRewrite change needs the serial's deviceID and newSerialNumber")
+		}
+	default:
+		return fmt.Errorf("unknown action %q (valid: %v)", r.Spec.Action, Actions)
+	}
+	return nil
+}
+
+// GetKind returns the kind of the resource
+func (r *PendingChange) GetKind() string {
+	return "PendingChange"
+}
+
+// GetName returns the name of the resource
+func (r *PendingChange) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *PendingChange) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("PendingChange", "pch")
+}
@@ -8,8 +8,15 @@ import (
 		"strings"
 
 	"github.com/openchami/fabrica/pkg/codegen"
+	"github.com/example/inventory-v3/pkg/resources/connection"
 	"github.com/example/inventory-v3/pkg/resources/device"
 	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+	"github.com/example/inventory-v3/pkg/resources/group"
+	"github.com/example/inventory-v3/pkg/resources/maintenancewindow"
+	"github.com/example/inventory-v3/pkg/resources/pendingchange"
+	"github.com/example/inventory-v3/pkg/resources/alert"
+	"github.com/example/inventory-v3/pkg/resources/serviceevent"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
 )
 
 // RegisterAllResources registers all discovered resources with the generator.
@@ -30,6 +37,55 @@ func RegisterAllResources(gen *codegen.Generator) error {
 		gen.SetResourceTag("DiscoverySnapshot", "versioning", "enabled")
 	}
 
+	if err := gen.RegisterResource(&bmcendpoint.BMCEndpoint{}); err != nil {
+		return fmt.Errorf("failed to register BMCEndpoint: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("BMCEndpoint") {
+		gen.SetResourceTag("BMCEndpoint", "versioning", "enabled")
+	}
+	if err := gen.RegisterResource(&serviceevent.ServiceEvent{}); err != nil {
+		return fmt.Errorf("failed to register ServiceEvent: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("ServiceEvent") {
+		gen.SetResourceTag("ServiceEvent", "versioning", "enabled")
+	}
+	if err := gen.RegisterResource(&maintenancewindow.MaintenanceWindow{}); err != nil {
+		return fmt.Errorf("failed to register MaintenanceWindow: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("MaintenanceWindow") {
+		gen.SetResourceTag("MaintenanceWindow", "versioning", "enabled")
+	}
+	if err := gen.RegisterResource(&group.Group{}); err != nil {
+		return fmt.Errorf("failed to register Group: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("Group") {
+		gen.SetResourceTag("Group", "versioning", "enabled")
+	}
+	if err := gen.RegisterResource(&connection.Connection{}); err != nil {
+		return fmt.Errorf("failed to register Connection: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("Connection") {
+		gen.SetResourceTag("Connection", "versioning", "enabled")
+	}
+	if err := gen.RegisterResource(&pendingchange.PendingChange{}); err != nil {
+		return fmt.Errorf("failed to register PendingChange: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("PendingChange") {
+		gen.SetResourceTag("PendingChange", "versioning", "enabled")
+	}
+	if err := gen.RegisterResource(&alert.Alert{}); err != nil {
+		return fmt.Errorf("failed to register Alert: %w", err)
+	}
+	// Set per-resource tags based on source markers
+	if hasVersioningMarker("Alert") {
+		gen.SetResourceTag("Alert", "versioning", "enabled")
+	}
 	return nil
 }
 
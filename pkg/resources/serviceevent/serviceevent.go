@@ -0,0 +1,100 @@
+// Copyright © 2025 OpenCHAMI a Series of LF Projects, LLC
+//
+// SPDX-License-Identifier: MIT
+
+package serviceevent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/openchami/fabrica/pkg/resource"
+)
+
+// Service event phases, set by the reconciler.
+const (
+	// PhaseStub marks an event without a ticket, e.g. one created when a
+	// replacement was detected.
+	PhaseStub = "Stub"
+	// PhaseOpen marks a ticketed event that has not been closed.
+	PhaseOpen = "Open"
+	// PhaseClosed marks an event with a closing date.
+	PhaseClosed = "Closed"
+)
+
+// ServiceEvent represents a ServiceEvent resource: an RMA or service ticket
+// linked to the devices it concerns.
+type ServiceEvent struct {
+	resource.Resource
+	Spec   ServiceEventSpec   `json:"spec" validate:"required"`
+	Status ServiceEventStatus `json:"status,omitempty"`
+}
+
+// ServiceEventSpec defines the desired state of ServiceEvent
+type ServiceEventSpec struct {
+	// TicketID is the site's service ticket. Stubs have none until it is filled in.
+	TicketID string `json:"ticketID,omitempty"`
+
+	// VendorCase is the vendor's RMA or support case number.
+	VendorCase string `json:"vendorCase,omitempty"`
+
+	// DeviceIDs lists the UIDs of the devices the event concerns.
+	DeviceIDs []string `json:"deviceIDs" validate:"required,min=1"`
+
+	Summary    string     `json:"summary,omitempty"`
+	OpenedAt   *time.Time `json:"openedAt,omitempty"`
+	ClosedAt   *time.Time `json:"closedAt,omitempty"`
+	Resolution string     `json:"resolution,omitempty"`
+
+	// Replacement is set on events created by replacement detection.
+	Replacement *Replacement `json:"replacement,omitempty"`
+}
+
+// Replacement records a serial number change detected in a slot.
+type Replacement struct {
+	// Slot is the redfish_uri of the device whose serial changed.
+	Slot            string    `json:"slot"`
+	OldSerialNumber string    `json:"oldSerialNumber"`
+	NewSerialNumber string    `json:"newSerialNumber"`
+	DetectedAt      time.Time `json:"detectedAt"`
+	// Snapshot is the name of the snapshot that reported the new serial.
+	Snapshot string `json:"snapshot,omitempty"`
+	// RunID is the collection run that produced the snapshot.
+	RunID string `json:"runId,omitempty"`
+}
+
+// ServiceEventStatus defines the observed state of ServiceEvent
+type ServiceEventStatus struct {
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	Ready   bool   `json:"ready"`
+}
+
+// Validate implements custom validation logic for ServiceEvent
+func (r *ServiceEvent) Validate(ctx context.Context) error {
+	if r.Spec.OpenedAt != nil && r.Spec.ClosedAt != nil && r.Spec.ClosedAt.Before(*r.Spec.OpenedAt) {
+		return errors.New("closedAt must not be before openedAt")
+	}
+	return nil
+}
+
+// GetKind returns the kind of the resource
+func (r *ServiceEvent) GetKind() string {
+	return "ServiceEvent"
+}
+
+// GetName returns the name of the resource
+func (r *ServiceEvent) GetName() string {
+	return r.Metadata.Name
+}
+
+// GetUID returns the UID of the resource
+func (r *ServiceEvent) GetUID() string {
+	return r.Metadata.UID
+}
+
+func init() {
+	// Register resource type prefix for storage
+	resource.RegisterResourcePrefix("ServiceEvent", "svc")
+}
@@ -0,0 +1,157 @@
+// Package s3 writes and reads objects of S3 and S3-compatible stores.
+// Requests are signed with AWS Signature Version 4.
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/redact"
+)
+
+// ErrNotFound is returned by Get for a missing object.
+var ErrNotFound = errors.New("no such object")
+
+// Bucket is a bucket URL with an optional key prefix, e.g.
+// "https://bucket.s3.us-east-1.amazonaws.com/reports", and the credentials
+// to write to it.
+type Bucket struct {
+	URL             string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Client          *http.Client
+}
+
+// Name returns the bucket URL with any credentials masked.
+func (b *Bucket) Name() string {
+	return redact.URL(b.URL)
+}
+
+// ObjectURL returns the URL of the object key under the bucket URL.
+func (b *Bucket) ObjectURL(key string) (string, error) {
+	u, err := url.Parse(b.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid S3 URL %q: %w", b.URL, err)
+	}
+	return u.JoinPath(key).String(), nil
+}
+
+// Put writes body as the object key under the bucket URL.
+func (b *Bucket) Put(ctx context.Context, key, contentType string, body []byte) error {
+	resp, err := b.do(ctx, http.MethodPut, key, contentType, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get reads the object key under the bucket URL, or returns ErrNotFound.
+func (b *Bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := b.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return body, nil
+}
+
+// do sends a signed request for the object key and returns the response,
+// which must be a success. The caller closes its body.
+func (b *Bucket) do(ctx context.Context, method, key, contentType string, body []byte) (*http.Response, error) {
+	objectURL, err := b.ObjectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	b.Sign(req, body, time.Now().UTC())
+
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// The error quotes the full URL, which may carry a token
+		return nil, fmt.Errorf("request to %s failed: %w", req.URL.Host, redact.Error(err))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound && method == http.MethodGet {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("%s %s returned %s", req.Method, redact.URL(req.URL.String()), resp.Status)
+	}
+	return resp, nil
+}
+
+// Sign adds an AWS Signature Version 4 Authorization header for S3.
+func (b *Bucket) Sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+	if b.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.SessionToken)
+		signed = append(signed, "x-amz-security-token")
+		canonicalHeaders += fmt.Sprintf("x-amz-security-token:%s\n", b.SessionToken)
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, b.Region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+b.SecretAccessKey), date)
+	key = hmacSHA256(key, b.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,297 @@
+// Package signing signs snapshot payloads at the collector and verifies them
+// at the server, so that only trusted collectors can change the inventory
+// through snapshots. A collector signs with a site key or the key of its mTLS
+// identity; the server trusts public keys, or the certificates holding them.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// Signature algorithms.
+const (
+	AlgorithmEd25519 = "ed25519"
+	AlgorithmECDSA   = "ecdsa-sha256"
+	AlgorithmRSA     = "rsa-sha256"
+)
+
+// Verification errors.
+var (
+	ErrUnsigned      = errors.New("snapshot is not signed")
+	ErrUntrustedKey  = errors.New("snapshot is signed by an untrusted key")
+	ErrBadSignature  = errors.New("snapshot signature does not match its content")
+	ErrExpiredSigner = errors.New("snapshot signer's certificate is not valid now")
+)
+
+// KeyID returns the hex SHA-256 fingerprint of a public key's PKIX encoding.
+func KeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// algorithmOf returns the signature algorithm for a key type.
+func algorithmOf(pub crypto.PublicKey) (string, error) {
+	switch pub.(type) {
+	case ed25519.PublicKey:
+		return AlgorithmEd25519, nil
+	case *ecdsa.PublicKey:
+		return AlgorithmECDSA, nil
+	case *rsa.PublicKey:
+		return AlgorithmRSA, nil
+	}
+	return "", fmt.Errorf("unsupported key type %T (valid: Ed25519, ECDSA, RSA)", pub)
+}
+
+// --- Signing ---
+
+// Signer signs snapshots with a private key.
+type Signer struct {
+	key       crypto.Signer
+	keyID     string
+	algorithm string
+}
+
+// NewSigner returns a signer for key.
+func NewSigner(key crypto.Signer) (*Signer, error) {
+	algorithm, err := algorithmOf(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := KeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key, keyID: keyID, algorithm: algorithm}, nil
+}
+
+// LoadSigner reads the first private key of a PEM file: PKCS #8, or SEC 1 EC
+// and PKCS #1 RSA keys. Other blocks, such as the certificate of an mTLS
+// identity kept in the same file, are skipped.
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		var key interface{}
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported signing key type %T in %s", key, path)
+		}
+		return NewSigner(signer)
+	}
+	return nil, fmt.Errorf("no private key found in %s", path)
+}
+
+// KeyID returns the fingerprint of the signer's public key.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign signs content, as returned by DiscoverySnapshotSpec.SignedContent.
+func (s *Signer) Sign(content []byte) (*discoverysnapshot.Signature, error) {
+	var value []byte
+	var err error
+	if s.algorithm == AlgorithmEd25519 {
+		value, err = s.key.Sign(rand.Reader, content, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(content)
+		value, err = s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign snapshot: %w", err)
+	}
+	return &discoverysnapshot.Signature{KeyID: s.keyID, Algorithm: s.algorithm, Value: value}, nil
+}
+
+// SignSnapshot signs the spec in place.
+func (s *Signer) SignSnapshot(spec *discoverysnapshot.DiscoverySnapshotSpec) error {
+	content, err := spec.SignedContent()
+	if err != nil {
+		return err
+	}
+	spec.Signature, err = s.Sign(content)
+	return err
+}
+
+// --- Verification ---
+
+// trustedKey is a public key the server accepts signatures from. Keys taken
+// from certificates are only trusted while the certificate is valid.
+type trustedKey struct {
+	pub       crypto.PublicKey
+	source    string
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// KeyRing holds the trusted public keys by key ID.
+type KeyRing struct {
+	keys map[string]trustedKey
+}
+
+// LoadKeyRing reads the PUBLIC KEY and CERTIFICATE blocks of a PEM file, or
+// of every .pem, .pub and .crt file in a directory.
+func LoadKeyRing(path string) (*KeyRing, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted keys: %w", err)
+	}
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trusted keys directory %s: %w", path, err)
+		}
+		files = nil
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".pem", ".pub", ".crt":
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(files)
+	}
+
+	ring := &KeyRing{keys: make(map[string]trustedKey)}
+	for _, file := range files {
+		if err := ring.addFile(file); err != nil {
+			return nil, err
+		}
+	}
+	if len(ring.keys) == 0 {
+		return nil, fmt.Errorf("no public keys or certificates found in %s", path)
+	}
+	return ring, nil
+}
+
+// addFile adds the keys of one PEM file.
+func (k *KeyRing) addFile(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read trusted key %s: %w", file, err)
+	}
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		key := trustedKey{source: file}
+		switch block.Type {
+		case "PUBLIC KEY":
+			if key.pub, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+				return fmt.Errorf("failed to parse public key in %s: %w", file, err)
+			}
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return fmt.Errorf("failed to parse certificate in %s: %w", file, err)
+			}
+			key.pub, key.notBefore, key.notAfter = cert.PublicKey, cert.NotBefore, cert.NotAfter
+		default:
+			continue
+		}
+		if err := k.add(key); err != nil {
+			return fmt.Errorf("trusted key in %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// add adds a key under its key ID.
+func (k *KeyRing) add(key trustedKey) error {
+	if _, err := algorithmOf(key.pub); err != nil {
+		return err
+	}
+	keyID, err := KeyID(key.pub)
+	if err != nil {
+		return err
+	}
+	k.keys[keyID] = key
+	return nil
+}
+
+// Len returns the number of trusted keys.
+func (k *KeyRing) Len() int {
+	if k == nil {
+		return 0
+	}
+	return len(k.keys)
+}
+
+// Verify checks sig over content against the trusted keys at time now.
+func (k *KeyRing) Verify(content []byte, sig *discoverysnapshot.Signature, now time.Time) error {
+	if sig == nil {
+		return ErrUnsigned
+	}
+	if k == nil {
+		return fmt.Errorf("%w (key %s)", ErrUntrustedKey, sig.KeyID)
+	}
+	key, ok := k.keys[sig.KeyID]
+	if !ok {
+		return fmt.Errorf("%w (key %s)", ErrUntrustedKey, sig.KeyID)
+	}
+	if !key.notAfter.IsZero() && (now.Before(key.notBefore) || now.After(key.notAfter)) {
+		return fmt.Errorf("%w (%s)", ErrExpiredSigner, key.source)
+	}
+	if algorithm, _ := algorithmOf(key.pub); algorithm != sig.Algorithm {
+		return fmt.Errorf("%w: algorithm %s does not match key type %s", ErrBadSignature, sig.Algorithm, algorithm)
+	}
+
+	digest := sha256.Sum256(content)
+	valid := false
+	switch pub := key.pub.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(pub, content, sig.Value)
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(pub, digest[:], sig.Value)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig.Value) == nil
+	}
+	if !valid {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// VerifySnapshot checks the signature of a snapshot spec.
+func (k *KeyRing) VerifySnapshot(spec *discoverysnapshot.DiscoverySnapshotSpec, now time.Time) error {
+	if spec.Signature == nil {
+		return ErrUnsigned
+	}
+	content, err := spec.SignedContent()
+	if err != nil {
+		return err
+	}
+	return k.Verify(content, spec.Signature, now)
+}
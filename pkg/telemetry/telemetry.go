@@ -0,0 +1,259 @@
+// Package telemetry reports anonymous usage statistics of the collector to
+// the maintainers, for sites that opt in by configuring where to send them.
+// A report counts collections by BMC vendor, protocol and failure class over
+// a period, with the collector's version and the size of the fleet rounded
+// to a bucket. It never holds addresses, names, serial numbers, credentials
+// or error messages, and vendors the maintainers do not know are counted as
+// "other", so that custom equipment names do not leave the site.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/example/inventory-v3/pkg/tlspolicy"
+)
+
+// SchemaVersion is the version of the Report format.
+const SchemaVersion = 1
+
+// DefaultInterval is how often a Reporter sends a report.
+const DefaultInterval = 24 * time.Hour
+
+// Report is what a collector sends for a period.
+type Report struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Version       string    `json:"version"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	PeriodStart   time.Time `json:"periodStart"`
+	PeriodEnd     time.Time `json:"periodEnd"`
+	// FleetSize is the bucket of the number of endpoints collected, e.g.
+	// "11-100".
+	FleetSize   string `json:"fleetSize"`
+	Collections int    `json:"collections"`
+	Failed      int    `json:"failed"`
+	// Vendors, Protocols and Failures count the collections by BMC vendor,
+	// management protocol, and failure class (see ClassifyError).
+	Vendors   map[string]int `json:"vendors,omitempty"`
+	Protocols map[string]int `json:"protocols,omitempty"`
+	Failures  map[string]int `json:"failures,omitempty"`
+}
+
+// Collection is the outcome of one collection as telemetry sees it.
+type Collection struct {
+	// Vendor is the BMC's vendor as identified, if it was.
+	Vendor   string
+	Protocol string
+	Err      error
+}
+
+// knownVendors maps a case-insensitive fragment of a vendor name to the name
+// reported.
+var knownVendors = []struct{ fragment, name string }{
+	{"dell", "Dell"},
+	{"hpe", "HPE"},
+	{"hewlett", "HPE"},
+	{"lenovo", "Lenovo"},
+	{"supermicro", "Supermicro"},
+	{"openbmc", "OpenBMC"},
+	{"gigabyte", "Gigabyte"},
+	{"fujitsu", "Fujitsu"},
+	{"cisco", "Cisco"},
+	{"inspur", "Inspur"},
+	{"asrock", "ASRock Rack"},
+	{"nvidia", "NVIDIA"},
+	{"ami", "AMI"},
+	{"intel", "Intel"},
+	{"quanta", "Quanta"},
+	{"wiwynn", "Wiwynn"},
+	{"huawei", "Huawei"},
+}
+
+// Vendor returns the name a vendor is reported under: a known vendor's, or
+// "other", or "unknown" when the BMC was not identified.
+func Vendor(vendor string) string {
+	if vendor == "" {
+		return "unknown"
+	}
+	lower := strings.ToLower(vendor)
+	for _, known := range knownVendors {
+		if strings.Contains(lower, known.fragment) {
+			return known.name
+		}
+	}
+	return "other"
+}
+
+// ClassifyError returns the class of a collection failure: auth, tls,
+// timeout, connection, http, plugin, config or other.
+func ClassifyError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	message := strings.ToLower(err.Error())
+	for _, class := range []struct {
+		name      string
+		fragments []string
+	}{
+		{"auth", []string{"401", "403", "unauthorized", "forbidden", "credential", "login"}},
+		{"tls", []string{"x509", "tls:", "certificate"}},
+		{"timeout", []string{"timeout", "timed out", "deadline exceeded"}},
+		{"connection", []string{"connection refused", "no route to host", "network is unreachable", "connection reset", "no such host", "eof"}},
+		{"plugin", []string{"plugin"}},
+		{"config", []string{"unknown", "invalid", "disabled", "not collected"}},
+		{"http", []string{"status", "api error"}},
+	} {
+		for _, fragment := range class.fragments {
+			if strings.Contains(message, fragment) {
+				return class.name
+			}
+		}
+	}
+	return "other"
+}
+
+// FleetBucket rounds a number of endpoints to its bucket.
+func FleetBucket(n int) string {
+	switch {
+	case n <= 0:
+		return "0"
+	case n == 1:
+		return "1"
+	case n <= 10:
+		return "2-10"
+	case n <= 100:
+		return "11-100"
+	case n <= 1000:
+		return "101-1000"
+	case n <= 10000:
+		return "1001-10000"
+	}
+	return ">10000"
+}
+
+// Version returns the collector's module version, or "dev" for builds
+// without one.
+func Version() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
+// Reporter counts collections and sends a report every Interval.
+type Reporter struct {
+	// Endpoint is the URL reports are POSTed to as JSON.
+	Endpoint string
+	// Interval is how often a report is sent; 0 uses DefaultInterval.
+	Interval time.Duration
+	// HTTPClient sends the reports; New sets one with a short timeout and
+	// nil uses http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu      sync.Mutex
+	current Report
+}
+
+// New returns a reporter sending to endpoint every interval, over
+// connections made under policy. The endpoint is verified against the
+// system's roots, as it is not a site service.
+func New(endpoint string, interval time.Duration, policy tlspolicy.Policy) *Reporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: policy.Transport(nil)}
+	return &Reporter{Endpoint: endpoint, Interval: interval, HTTPClient: client}
+}
+
+// Record counts a collection. A nil reporter records nothing.
+func (r *Reporter) Record(c Collection) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.start(time.Now())
+	r.current.Collections++
+	count(&r.current.Vendors, Vendor(c.Vendor))
+	if c.Protocol != "" {
+		count(&r.current.Protocols, c.Protocol)
+	}
+	if c.Err != nil {
+		r.current.Failed++
+		count(&r.current.Failures, ClassifyError(c.Err))
+	}
+}
+
+// Due reports whether the period has lasted an interval.
+func (r *Reporter) Due(now time.Time) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.current.PeriodStart.IsZero() && !now.Before(r.current.PeriodStart.Add(r.Interval))
+}
+
+// Flush ends the period and returns its report for a fleet of fleetSize
+// endpoints. The next period starts with the next collection.
+func (r *Reporter) Flush(fleetSize int) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := r.current
+	r.current = Report{}
+	report.SchemaVersion = SchemaVersion
+	report.Version = Version()
+	report.OS, report.Arch = runtime.GOOS, runtime.GOARCH
+	report.PeriodEnd = time.Now().UTC()
+	report.FleetSize = FleetBucket(fleetSize)
+	return report
+}
+
+// Send POSTs a report to the endpoint.
+func (r *Reporter) Send(ctx context.Context, report Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint answered %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *Reporter) start(now time.Time) {
+	if r.current.PeriodStart.IsZero() {
+		r.current.PeriodStart = now.UTC()
+	}
+}
+
+func count(counts *map[string]int, key string) {
+	if *counts == nil {
+		*counts = map[string]int{}
+	}
+	(*counts)[key]++
+}
@@ -0,0 +1,10 @@
+//go:build go1.24
+
+package tlspolicy
+
+import "crypto/fips140"
+
+// fipsEnabled reports whether the Go FIPS 140-3 module is in use.
+func fipsEnabled() bool {
+	return fips140.Enabled()
+}
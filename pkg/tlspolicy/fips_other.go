@@ -0,0 +1,9 @@
+//go:build !go1.24
+
+package tlspolicy
+
+// fipsEnabled reports whether the Go FIPS 140-3 module is in use. It arrived
+// in Go 1.24; older toolchains have none.
+func fipsEnabled() bool {
+	return false
+}
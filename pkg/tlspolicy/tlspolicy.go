@@ -0,0 +1,175 @@
+// Package tlspolicy applies a site TLS policy (minimum version, cipher suites,
+// FIPS-only mode) to outbound connections, so that the collector's Redfish
+// and API clients and the server's report deliveries negotiate alike.
+package tlspolicy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Policy is a TLS policy for outbound connections. The zero value keeps Go's
+// defaults, which already refuse anything older than TLS 1.2.
+type Policy struct {
+	// MinVersion is the oldest TLS version allowed: "1.2" or "1.3" ("" keeps
+	// the default, TLS 1.2).
+	MinVersion string
+	// CipherSuites lists the TLS 1.2 cipher suites allowed, by their IANA
+	// names, e.g. TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384. TLS 1.3 suites are
+	// not configurable in Go. Empty keeps the defaults.
+	CipherSuites []string
+	// FIPS restricts connections to FIPS 140-3 approved versions, suites and
+	// curves. It requires the Go FIPS 140-3 module (Go 1.24 or later, with
+	// GODEBUG=fips140=on).
+	FIPS bool
+}
+
+// versions maps the accepted MinVersion values to their TLS versions.
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// fipsCipherSuites are the TLS 1.2 suites approved for FIPS mode.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// fipsCurves are the key exchange curves approved for FIPS mode.
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// Parse builds a policy from configuration values; cipherSuites is a
+// comma-separated list of suite names.
+func Parse(minVersion, cipherSuites string, fips bool) (Policy, error) {
+	p := Policy{MinVersion: strings.TrimSpace(minVersion), FIPS: fips}
+	for _, name := range strings.Split(cipherSuites, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			p.CipherSuites = append(p.CipherSuites, name)
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return Policy{}, err
+	}
+	return p, nil
+}
+
+// Validate checks the version and suite names, and that FIPS mode has the
+// FIPS module and only approved suites to work with.
+func (p Policy) Validate() error {
+	if p.MinVersion != "" {
+		if _, ok := versions[p.MinVersion]; !ok {
+			return fmt.Errorf("unknown TLS minimum version %q (valid: 1.2, 1.3)", p.MinVersion)
+		}
+	}
+	if _, err := p.cipherSuiteIDs(); err != nil {
+		return err
+	}
+	if p.FIPS && !fipsEnabled() {
+		return fmt.Errorf("FIPS-only TLS requires the Go FIPS 140-3 module (Go 1.24 or later, run with GODEBUG=fips140=on)")
+	}
+	return nil
+}
+
+// cipherSuiteIDs resolves the suite names. In FIPS mode every suite must be
+// approved, and no names select all approved suites.
+func (p Policy) cipherSuiteIDs() ([]uint16, error) {
+	byName := make(map[string]uint16)
+	for _, suite := range tls12CipherSuites() {
+		byName[suite.Name] = suite.ID
+	}
+	approved := make(map[uint16]bool)
+	for _, id := range fipsCipherSuites {
+		approved[id] = true
+	}
+
+	var ids []uint16
+	for _, name := range p.CipherSuites {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure TLS cipher suite %q (valid: %s)", name, strings.Join(SecureCipherSuites(), ", "))
+		}
+		if p.FIPS && !approved[id] {
+			return nil, fmt.Errorf("TLS cipher suite %s is not approved in FIPS mode", name)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 && p.FIPS {
+		ids = fipsCipherSuites
+	}
+	return ids, nil
+}
+
+// SecureCipherSuites lists the names CipherSuites accepts.
+func SecureCipherSuites() []string {
+	var names []string
+	for _, suite := range tls12CipherSuites() {
+		names = append(names, suite.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tls12CipherSuites returns Go's secure suites that TLS 1.2 can negotiate.
+func tls12CipherSuites() []*tls.CipherSuite {
+	var suites []*tls.CipherSuite
+	for _, suite := range tls.CipherSuites() {
+		for _, version := range suite.SupportedVersions {
+			if version == tls.VersionTLS12 {
+				suites = append(suites, suite)
+				break
+			}
+		}
+	}
+	return suites
+}
+
+// Apply sets the policy on cfg, or on a new config when cfg is nil, and
+// returns it. Other settings (roots, verification) are kept. The policy must
+// be valid.
+func (p Policy) Apply(cfg *tls.Config) *tls.Config {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	}
+	cfg.MinVersion = tls.VersionTLS12
+	if version, ok := versions[p.MinVersion]; ok {
+		cfg.MinVersion = version
+	}
+	if ids, err := p.cipherSuiteIDs(); err == nil && len(ids) > 0 {
+		cfg.CipherSuites = ids
+	}
+	if p.FIPS {
+		cfg.CurvePreferences = fipsCurves
+	}
+	return cfg
+}
+
+// Transport returns an HTTP transport using the environment's proxy and the
+// policy, with tlsConfig's other settings.
+func (p Policy) Transport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: p.Apply(tlsConfig),
+	}
+}
+
+// String describes the policy for logs.
+func (p Policy) String() string {
+	version := p.MinVersion
+	if version == "" {
+		version = "1.2"
+	}
+	s := "TLS " + version + "+"
+	if len(p.CipherSuites) > 0 {
+		s += fmt.Sprintf(", %d cipher suites", len(p.CipherSuites))
+	}
+	if p.FIPS {
+		s += ", FIPS-only"
+	}
+	return s
+}
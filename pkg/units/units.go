@@ -0,0 +1,341 @@
+// Package units converts device property values to the canonical units the
+// property registry declares, so that totals and filters add up across
+// vendors: one BMC's "32 GB" and another's 32768 both end up in capacity_mib
+// as MiB.
+//
+// A value is converted when it is a string carrying its unit ("32 GB",
+// "4800 MT/s", "2.1GHz"), or a number a Rule says is in another unit. The
+// value as reported is kept in the device's Provenance.
+//
+// GB, MB and TB are decimal, but for the capacity of memory, which JEDEC
+// sizes in powers of two: a DIMM's "32 GB" is 32 GiB, 32768 MiB.
+package units
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/example/inventory-v3/pkg/properties"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// Dimensions of the known units. Only units of the same dimension convert,
+// but for frequency and data rate; see ClockMHz.
+const (
+	DimensionBytes     = "bytes"
+	DimensionFrequency = "frequency"
+	DimensionRate      = "rate"
+	DimensionPower     = "power"
+	DimensionCurrent   = "current"
+	DimensionPercent   = "percent"
+)
+
+// ClockMHz is the clock frequency of double data rate memory, which
+// transfers twice per cycle: 1600 MHz is 3200 MT/s.
+const ClockMHz = "MHz (DDR clock)"
+
+// Unit is a unit of measure.
+type Unit struct {
+	Name      string
+	Dimension string
+	// Factor converts a value to the dimension's base unit (bytes, Hz,
+	// transfers/s, W, A or %).
+	Factor float64
+}
+
+var unitList = []Unit{
+	{"bytes", DimensionBytes, 1},
+	{"KB", DimensionBytes, 1e3},
+	{"MB", DimensionBytes, 1e6},
+	{"GB", DimensionBytes, 1e9},
+	{"TB", DimensionBytes, 1e12},
+	{"KiB", DimensionBytes, 1 << 10},
+	{"MiB", DimensionBytes, 1 << 20},
+	{"GiB", DimensionBytes, 1 << 30},
+	{"TiB", DimensionBytes, 1 << 40},
+	{"Hz", DimensionFrequency, 1},
+	{"kHz", DimensionFrequency, 1e3},
+	{"MHz", DimensionFrequency, 1e6},
+	{"GHz", DimensionFrequency, 1e9},
+	{"MT/s", DimensionRate, 1e6},
+	{"GT/s", DimensionRate, 1e9},
+	{ClockMHz, DimensionRate, 2e6},
+	{"W", DimensionPower, 1},
+	{"kW", DimensionPower, 1e3},
+	{"A", DimensionCurrent, 1},
+	{"mA", DimensionCurrent, 1e-3},
+	{"%", DimensionPercent, 1},
+}
+
+// aliases maps other spellings, lower-cased, to unit names.
+var aliases = map[string]string{
+	"b": "bytes", "byte": "bytes",
+	"watts": "W", "watt": "W", "amps": "A", "amp": "A",
+}
+
+// Lookup returns the unit named name, ignoring case and the units' common
+// alternative spellings.
+func Lookup(name string) (Unit, bool) {
+	name = strings.TrimSpace(name)
+	if alias, ok := aliases[strings.ToLower(name)]; ok {
+		name = alias
+	}
+	for _, u := range unitList {
+		if strings.EqualFold(u.Name, name) {
+			return u, true
+		}
+	}
+	return Unit{}, false
+}
+
+// Convert converts value from one unit to another of the same dimension. A
+// frequency converts to a data rate one to one, as Redfish names MT/s values
+// Mhz; ClockMHz is the exception.
+func Convert(value float64, from, to Unit) (float64, error) {
+	if from.Dimension != to.Dimension && !(from.Dimension == DimensionFrequency && to.Dimension == DimensionRate) {
+		return 0, fmt.Errorf("cannot convert %s to %s", from.Name, to.Name)
+	}
+	if from.Dimension == DimensionFrequency && to.Dimension == DimensionRate {
+		from = Unit{from.Name, DimensionRate, from.Factor}
+	}
+	return value * from.Factor / to.Factor, nil
+}
+
+// --- Rules ---
+
+// Rule tells the unit of a property's values that carry none, for the
+// devices it selects. DeviceType and Manufacturer select devices (empty
+// matches any; Manufacturer matches a case-insensitive substring), and Match,
+// when set, decides from the device and value.
+type Rule struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Key          string `json:"key"`
+	DeviceType   string `json:"deviceType,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	// Unit is the unit the values are in.
+	Unit string `json:"unit"`
+
+	Match func(spec *device.DeviceSpec, value float64) bool `json:"-"`
+}
+
+func (r *Rule) matches(spec *device.DeviceSpec, key string, value float64) bool {
+	if r.Key != key {
+		return false
+	}
+	if r.DeviceType != "" && !strings.EqualFold(r.DeviceType, spec.DeviceType) {
+		return false
+	}
+	if r.Manufacturer != "" && !strings.Contains(strings.ToLower(spec.Manufacturer), strings.ToLower(r.Manufacturer)) {
+		return false
+	}
+	return r.Match == nil || r.Match(spec, value)
+}
+
+// MemoryCapacityRule names the conversion of the decimal byte units of
+// memory capacities to their binary counterparts.
+const MemoryCapacityRule = "jedec-memory-capacity"
+
+// memoryCapacityKeys are the capacity keys of DIMMs; memory_total_mib, the
+// sum of a node's, is one whatever the device.
+var memoryCapacityKeys = map[string]bool{"capacity_mib": true, "capacity_gib": true, "capacity_bytes": true}
+
+func isMemoryCapacity(spec *device.DeviceSpec, key string) bool {
+	return key == "memory_total_mib" || (memoryCapacityKeys[key] && strings.EqualFold(spec.DeviceType, "DIMM"))
+}
+
+// binaryUnit returns the binary unit of memory a decimal byte unit names.
+func binaryUnit(u Unit) (Unit, bool) {
+	switch u.Name {
+	case "KB", "MB", "GB", "TB":
+		return Lookup(u.Name[:1] + "iB")
+	}
+	return Unit{}, false
+}
+
+// ddrMinRates are the lowest JEDEC data rates of each DDR generation, in MT/s.
+var ddrMinRates = map[string]float64{"DDR3": 800, "DDR4": 1600, "DDR5": 3200}
+
+var (
+	mu    sync.RWMutex
+	rules = []*Rule{
+		{
+			Name:        "ddr-clock-speed",
+			Description: "some BMCs report a DIMM's clock, half its data rate; a speed below the lowest data rate of its DDR generation is a clock",
+			Key:         "operating_speed_mhz",
+			DeviceType:  "DIMM",
+			Unit:        ClockMHz,
+			Match: func(spec *device.DeviceSpec, value float64) bool {
+				var memoryType string
+				spec.GetProperty("memory_device_type", &memoryType)
+				memoryType = strings.TrimSuffix(strings.ToUpper(memoryType), "_SDRAM")
+				min, ok := ddrMinRates[memoryType]
+				return ok && value > 0 && value < min
+			},
+		},
+	}
+)
+
+// RegisterRule adds rules, checked after those registered before them.
+func RegisterRule(add ...*Rule) error {
+	for _, r := range add {
+		if r.Name == "" || r.Key == "" {
+			return fmt.Errorf("unit rule needs a name and a key")
+		}
+		if _, ok := Lookup(r.Unit); !ok {
+			return fmt.Errorf("unit rule %s: unknown unit %q", r.Name, r.Unit)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	rules = append(rules, add...)
+	return nil
+}
+
+// LoadRules registers the JSON array of Rule in path and returns how many
+// it held.
+func LoadRules(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read unit rules: %w", err)
+	}
+	var loaded []*Rule
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return 0, fmt.Errorf("failed to parse unit rules %s: %w", path, err)
+	}
+	if err := RegisterRule(loaded...); err != nil {
+		return 0, fmt.Errorf("unit rules %s: %w", path, err)
+	}
+	return len(loaded), nil
+}
+
+func ruleFor(spec *device.DeviceSpec, key string, value float64) *Rule {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, r := range rules {
+		if r.matches(spec, key, value) {
+			return r
+		}
+	}
+	return nil
+}
+
+// --- Normalization ---
+
+// quantity matches a number with an optional unit, e.g. "32 GB" or "2.1GHz".
+var quantity = regexp.MustCompile(`^\s*([-+]?[0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)\s*([A-Za-z%/() ]*?)\s*$`)
+
+// Normalize converts the registered numeric properties of spec that are not
+// in their canonical unit, records what was reported in spec.Provenance,
+// and returns the converted keys. Values it cannot read are left for
+// validation to report. Normalizing a normalized spec changes nothing.
+func Normalize(spec *device.DeviceSpec) []string {
+	var converted []string
+	for key, raw := range spec.Properties {
+		schema, ok := properties.Lookup(key)
+		if !ok || schema.Unit == "" || (schema.Type != properties.TypeInteger && schema.Type != properties.TypeNumber) {
+			continue
+		}
+		canonical, ok := Lookup(schema.Unit)
+		if !ok {
+			continue
+		}
+		// A value converted before is kept, so that updates do not convert twice
+		if prov, ok := spec.Provenance[key]; ok {
+			if value, _, ok := normalizeValue(spec, key, prov.Raw, schema, canonical); ok && bytes.Equal(value, raw) {
+				continue
+			}
+		}
+		value, prov, ok := normalizeValue(spec, key, raw, schema, canonical)
+		if !ok {
+			if spec.Provenance != nil {
+				delete(spec.Provenance, key)
+			}
+			continue
+		}
+		spec.Properties[key] = value
+		if spec.Provenance == nil {
+			spec.Provenance = map[string]device.PropertyProvenance{}
+		}
+		spec.Provenance[key] = prov
+		converted = append(converted, key)
+	}
+	sort.Strings(converted)
+	return converted
+}
+
+// normalizeValue converts raw to the canonical unit, or returns false when
+// it needs no conversion or cannot be read.
+func normalizeValue(spec *device.DeviceSpec, key string, raw json.RawMessage, schema properties.Schema, canonical Unit) (json.RawMessage, device.PropertyProvenance, bool) {
+	prov := device.PropertyProvenance{Raw: raw}
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, prov, false
+	}
+
+	var number float64
+	var unitName string
+	isString := trimmed[0] == '"'
+	if isString {
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return nil, prov, false
+		}
+		m := quantity.FindStringSubmatch(s)
+		if m == nil {
+			return nil, prov, false
+		}
+		number, _ = strconv.ParseFloat(m[1], 64)
+		unitName = m[2]
+	} else if err := json.Unmarshal(trimmed, &number); err != nil {
+		return nil, prov, false
+	}
+
+	from := canonical
+	if unitName != "" {
+		u, ok := Lookup(unitName)
+		if !ok {
+			return nil, prov, false
+		}
+		from = u
+		if binary, ok := binaryUnit(from); ok && isMemoryCapacity(spec, key) {
+			from = binary
+			prov.Rule = MemoryCapacityRule
+		}
+	}
+	// Rules tell the unit of bare numbers, and of the frequencies Redfish
+	// uses for data rates.
+	if unitName == "" || (from.Dimension == DimensionFrequency && canonical.Dimension == DimensionRate) {
+		if r := ruleFor(spec, key, number); r != nil {
+			from, _ = Lookup(r.Unit)
+			prov.Rule = r.Name
+		}
+	}
+	prov.Unit = from.Name
+	if from == canonical && !isString {
+		return nil, prov, false
+	}
+
+	value, err := Convert(number, from, canonical)
+	if err != nil {
+		return nil, prov, false
+	}
+	var encoded []byte
+	if schema.Type == properties.TypeInteger {
+		encoded, err = json.Marshal(int64(math.Round(value)))
+	} else {
+		encoded, err = json.Marshal(value)
+	}
+	if err != nil {
+		return nil, prov, false
+	}
+	return encoded, prov, true
+}
@@ -0,0 +1,85 @@
+package units
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// TestNormalize converts properties to their canonical units, reading the
+// decimal byte units of memory capacities as binary.
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		deviceType string
+		key        string
+		raw        string
+		want       string
+		wantUnit   string
+		wantRule   string
+	}{
+		{name: "DIMM in GB", deviceType: "DIMM", key: "capacity_mib", raw: `"32 GB"`, want: "32768", wantUnit: "GiB", wantRule: MemoryCapacityRule},
+		{name: "DIMM in GiB", deviceType: "DIMM", key: "capacity_mib", raw: `"32 GiB"`, want: "32768", wantUnit: "GiB"},
+		{name: "DIMM in MB", deviceType: "DIMM", key: "capacity_mib", raw: `"16384MB"`, want: "16384", wantUnit: "MiB", wantRule: MemoryCapacityRule},
+		{name: "DIMM in MiB", deviceType: "DIMM", key: "capacity_mib", raw: `32768`, want: "32768"},
+		{name: "DIMM in TB", deviceType: "DIMM", key: "capacity_gib", raw: `"1 TB"`, want: "1024", wantUnit: "TiB", wantRule: MemoryCapacityRule},
+		{name: "DIMM bytes in GB", deviceType: "DIMM", key: "capacity_bytes", raw: `"32 GB"`, want: "34359738368", wantUnit: "GiB", wantRule: MemoryCapacityRule},
+		{name: "node memory in GB", deviceType: "Node", key: "memory_total_mib", raw: `"512 GB"`, want: "524288", wantUnit: "GiB", wantRule: MemoryCapacityRule},
+		{name: "drive in GB", deviceType: "Drive", key: "capacity_bytes", raw: `"960 GB"`, want: "960000000000", wantUnit: "GB"},
+		{name: "drive in TiB", deviceType: "Drive", key: "capacity_bytes", raw: `"1 TiB"`, want: "1099511627776", wantUnit: "TiB"},
+		{name: "data rate", deviceType: "DIMM", key: "operating_speed_mhz", raw: `"4800 MT/s"`, want: "4800", wantUnit: "MT/s"},
+		{name: "frequency as data rate", deviceType: "DIMM", key: "operating_speed_mhz", raw: `"4.8 GHz"`, want: "4800", wantUnit: "GHz"},
+		{name: "power", deviceType: "PowerSupply", key: "power_capacity_watts", raw: `"1.6 kW"`, want: "1600", wantUnit: "kW"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &device.DeviceSpec{
+				DeviceType: tt.deviceType,
+				Properties: map[string]json.RawMessage{tt.key: json.RawMessage(tt.raw)},
+			}
+			converted := Normalize(spec)
+			if got := string(spec.Properties[tt.key]); got != tt.want {
+				t.Errorf("%s = %s, want %s", tt.key, got, tt.want)
+			}
+			if tt.wantUnit == "" {
+				if len(converted) != 0 {
+					t.Errorf("Normalize converted %v, want nothing", converted)
+				}
+				return
+			}
+			prov, ok := spec.Provenance[tt.key]
+			if !ok {
+				t.Fatalf("no provenance for %s", tt.key)
+			}
+			if string(prov.Raw) != tt.raw || prov.Unit != tt.wantUnit || prov.Rule != tt.wantRule {
+				t.Errorf("provenance = {%s %s %s}, want {%s %s %s}", prov.Raw, prov.Unit, prov.Rule, tt.raw, tt.wantUnit, tt.wantRule)
+			}
+
+			// Normalizing again changes nothing
+			Normalize(spec)
+			if got := string(spec.Properties[tt.key]); got != tt.want {
+				t.Errorf("normalized again, %s = %s, want %s", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNormalizeClockRule reads a DIMM speed below its generation's lowest
+// data rate as the DDR clock.
+func TestNormalizeClockRule(t *testing.T) {
+	spec := &device.DeviceSpec{
+		DeviceType: "DIMM",
+		Properties: map[string]json.RawMessage{
+			"operating_speed_mhz": json.RawMessage(`1600`),
+			"memory_device_type":  json.RawMessage(`"DDR5"`),
+		},
+	}
+	Normalize(spec)
+	if got := string(spec.Properties["operating_speed_mhz"]); got != "3200" {
+		t.Errorf("operating_speed_mhz = %s, want 3200", got)
+	}
+	if rule := spec.Provenance["operating_speed_mhz"].Rule; rule != "ddr-clock-speed" {
+		t.Errorf("provenance rule = %q, want ddr-clock-speed", rule)
+	}
+}
@@ -0,0 +1,230 @@
+// Package integration runs the whole pipeline: the collector binary reads a
+// mock BMC and posts its snapshot to the inventory API, whose reconciler
+// turns it into Devices. The tests build the server and collector from this
+// tree and run them as separate processes. To test an API already running
+// elsewhere, e.g. in a container, set INVENTORY_IT_SERVER_URL to its URL;
+// its inventory should start empty.
+//
+// The tests are skipped with -short.
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/resources/discoverysnapshot"
+)
+
+// bin holds the binaries TestMain builds.
+var bin string
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	flag.Parse()
+	var err error
+	if bin, err = os.MkdirTemp("", "inventory-it-"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(bin)
+	if !testing.Short() {
+		for _, name := range []string{"server", "collector"} {
+			build := exec.Command("go", "build", "-o", filepath.Join(bin, name), "../../cmd/"+name)
+			if out, err := build.CombinedOutput(); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to build %s: %v\n%s", name, err, out)
+				return 1
+			}
+		}
+	}
+	return m.Run()
+}
+
+// harness is one test's inventory API and the collector posting to it.
+type harness struct {
+	t      *testing.T
+	url    string
+	client *fabricaclient.Client
+}
+
+// newHarness starts an inventory API with an empty data directory, or uses
+// the one at INVENTORY_IT_SERVER_URL.
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("integration test")
+	}
+	url := os.Getenv("INVENTORY_IT_SERVER_URL")
+	if url == "" {
+		url = startServer(t)
+	}
+	client, err := fabricaclient.NewClient(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &harness{t: t, url: url, client: client}
+}
+
+// startServer runs the server binary on a free port until the test ends.
+func startServer(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	var logs bytes.Buffer
+	cmd := exec.Command(filepath.Join(bin, "server"), "serve",
+		"--host", "127.0.0.1", "--port", fmt.Sprint(port),
+		"--data-dir", t.TempDir(), "--consistency-interval", "0", "--report-interval", "0")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(), "HOME="+cmd.Dir)
+	cmd.Stdout, cmd.Stderr = &logs, &logs
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+		if t.Failed() {
+			t.Logf("server log:\n%s", logs.String())
+		}
+	})
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		resp, err := http.Get(url + "/health")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return url
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not become healthy: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// bmc is a mock BMC whose hardware a test can change.
+type bmc struct {
+	address string
+	// conns counts the connections open to the BMC.
+	conns atomic.Int64
+	mu    sync.Mutex
+	mock  *redfishmock.Server
+}
+
+// startBMC serves a mock BMC for node over TLS until the test ends.
+func startBMC(t *testing.T, node redfishmock.Node) *bmc {
+	t.Helper()
+	b := &bmc{}
+	b.serve(t, node)
+	server := httptest.NewUnstartedServer(b)
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			b.conns.Add(1)
+		case http.StateClosed, http.StateHijacked:
+			b.conns.Add(-1)
+		}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	b.address = strings.TrimPrefix(server.URL, "https://")
+	return b
+}
+
+// serve replaces the BMC's hardware with node.
+func (b *bmc) serve(t *testing.T, node redfishmock.Node) *redfishmock.Server {
+	t.Helper()
+	mock, err := redfishmock.New(redfishmock.Fixture(node))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.Username, mock.Password = "admin", "admin"
+	b.mu.Lock()
+	b.mock = mock
+	b.mu.Unlock()
+	return mock
+}
+
+func (b *bmc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	mock := b.mock
+	b.mu.Unlock()
+	mock.ServeHTTP(w, r)
+}
+
+// collect runs the collector binary against the BMC at address and returns
+// its run summary.
+func (h *harness) collect(address string, args ...string) *collector.RunSummary {
+	h.t.Helper()
+	home := h.t.TempDir()
+	args = append([]string{"--ip", address, "--server", h.url, "--discover-server=false", "--template", "{{json .}}"}, args...)
+	cmd := exec.Command(filepath.Join(bin, "collector"), args...)
+	cmd.Dir = home
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + home,
+		"INVENTORY_COLLECTOR_USERNAME=admin",
+		"INVENTORY_COLLECTOR_PASSWORD=admin",
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		h.t.Fatalf("collector failed: %v\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
+	}
+	// The summary is the last line; progress comes before it
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	var summary collector.RunSummary
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		h.t.Fatalf("collector printed no run summary: %v\n%s", err, stdout.String())
+	}
+	return &summary
+}
+
+// waitReconciled waits for the reconciler to finish the snapshot.
+func (h *harness) waitReconciled(uid string) *discoverysnapshot.DiscoverySnapshot {
+	h.t.Helper()
+	deadline := time.Now().Add(20 * time.Second)
+	for {
+		snapshot, err := h.client.GetDiscoverySnapshot(context.Background(), uid)
+		if err != nil {
+			h.t.Fatalf("failed to get snapshot %s: %v", uid, err)
+		}
+		switch snapshot.Status.Phase {
+		case "Completed":
+			return snapshot
+		case "Error", "Rejected":
+			h.t.Fatalf("snapshot %s ended %s: %s", uid, snapshot.Status.Phase, snapshot.Status.Message)
+		}
+		if time.Now().After(deadline) {
+			h.t.Fatalf("snapshot %s was not reconciled; phase %q", uid, snapshot.Status.Phase)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+	"github.com/example/inventory-v3/pkg/resources/device"
+)
+
+// devicesOf returns the devices last reported by the BMC at address, by
+// serial number.
+func (h *harness) devicesOf(address string) map[string]device.Device {
+	h.t.Helper()
+	devices, err := h.client.GetDevices(context.Background())
+	if err != nil {
+		h.t.Fatalf("failed to list devices: %v", err)
+	}
+	bySerial := map[string]device.Device{}
+	for _, dev := range devices {
+		if dev.Status.Source == address {
+			bySerial[dev.Spec.SerialNumber] = dev
+		}
+	}
+	return bySerial
+}
+
+func countTypes(devices map[string]device.Device) map[string]int {
+	counts := map[string]int{}
+	for _, dev := range devices {
+		counts[dev.Spec.DeviceType]++
+	}
+	return counts
+}
+
+func TestCollectCreatesDevices(t *testing.T) {
+	h := newHarness(t)
+	node := redfishmock.DefaultNode("IT0001")
+	address := startBMC(t, node).address
+
+	summary := h.collect(address)
+	if summary.Partial {
+		t.Fatalf("collection was partial: %v", summary.Failed)
+	}
+	snapshot := h.waitReconciled(summary.Snapshot)
+	if snapshot.Status.Partial {
+		t.Errorf("snapshot is partial")
+	}
+
+	devices := h.devicesOf(address)
+	want := map[string]int{"Node": 1, "CPU": node.CPUs, "DIMM": node.DIMMs, "Drive": node.Drives, "NIC": node.NICs}
+	got := countTypes(devices)
+	for deviceType, n := range want {
+		if got[deviceType] != n {
+			t.Errorf("got %d %s devices, want %d", got[deviceType], deviceType, n)
+		}
+	}
+	if len(devices) != summary.Devices {
+		t.Errorf("got %d devices, the collector reported %d", len(devices), summary.Devices)
+	}
+
+	nodeDevice, ok := devices[node.Serial]
+	if !ok {
+		t.Fatalf("no device for node %s", node.Serial)
+	}
+	for serial, dev := range devices {
+		if serial != node.Serial && dev.Spec.ParentID != nodeDevice.Metadata.UID {
+			t.Errorf("%s %s is not linked to the node", dev.Spec.DeviceType, serial)
+		}
+	}
+	if s := nodeDevice.Status.Summary; s == nil || s.CPUSockets != node.CPUs || s.TotalMemoryGiB != float64(node.DIMMs*32) {
+		t.Errorf("node summary %+v does not match the fixture", s)
+	}
+}
+
+func TestRecollectUpdatesDevices(t *testing.T) {
+	h := newHarness(t)
+	address := startBMC(t, redfishmock.DefaultNode("IT0002")).address
+
+	h.waitReconciled(h.collect(address).Snapshot)
+	first := h.devicesOf(address)
+	h.waitReconciled(h.collect(address).Snapshot)
+	second := h.devicesOf(address)
+
+	if len(second) != len(first) {
+		t.Fatalf("got %d devices after collecting again, want %d", len(second), len(first))
+	}
+	for serial, dev := range first {
+		if second[serial].Metadata.UID != dev.Metadata.UID {
+			t.Errorf("%s changed UID from %s to %s", serial, dev.Metadata.UID, second[serial].Metadata.UID)
+		}
+	}
+}
+
+func TestCollectWaitsForReconciler(t *testing.T) {
+	h := newHarness(t)
+	node := redfishmock.DefaultNode("IT0005")
+	address := startBMC(t, node).address
+
+	summary := h.collect(address, "--wait")
+	if summary.Phase != "Completed" {
+		t.Fatalf("collector returned with the snapshot %q: %s", summary.Phase, summary.Message)
+	}
+	// The devices are there as soon as the collector returns
+	if devices := h.devicesOf(address); len(devices) != summary.Devices {
+		t.Errorf("got %d devices, the collector reported %d", len(devices), summary.Devices)
+	}
+}
+
+func TestRemovedDeviceIsFlaggedMissing(t *testing.T) {
+	h := newHarness(t)
+	node := redfishmock.DefaultNode("IT0003")
+	b := startBMC(t, node)
+	h.waitReconciled(h.collect(b.address).Snapshot)
+
+	node.DIMMs--
+	b.serve(t, node)
+	h.waitReconciled(h.collect(b.address).Snapshot)
+
+	devices := h.devicesOf(b.address)
+	removed := node.Serial + "-DIMM8"
+	if dev, ok := devices[removed]; !ok || dev.Status.Phase != device.PhaseMissing {
+		t.Errorf("removed DIMM %s has phase %q, want %s", removed, dev.Status.Phase, device.PhaseMissing)
+	}
+}
+
+func TestPartialCollectionKeepsDevices(t *testing.T) {
+	h := newHarness(t)
+	b := startBMC(t, redfishmock.DefaultNode("IT0004"))
+	address := b.address
+	h.waitReconciled(h.collect(address).Snapshot)
+
+	b.mock.SetFaults(redfishmock.Faults{ErrorRate: 1, Paths: []string{redfishmock.Root + "/Systems/1/Memory"}})
+	summary := h.collect(address)
+	if !summary.Partial {
+		t.Fatalf("collection with the memory failing was not partial")
+	}
+	snapshot := h.waitReconciled(summary.Snapshot)
+	if !snapshot.Status.Partial {
+		t.Errorf("snapshot of a partial collection is not partial")
+	}
+
+	for serial, dev := range h.devicesOf(address) {
+		if dev.Spec.DeviceType == "DIMM" && dev.Status.Phase == device.PhaseMissing {
+			t.Errorf("DIMM %s was flagged missing by a partial snapshot", serial)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/example/inventory-v3/internal/redfishmock"
+	fabricaclient "github.com/example/inventory-v3/pkg/client"
+	"github.com/example/inventory-v3/pkg/collector"
+	"github.com/example/inventory-v3/pkg/resources/bmcendpoint"
+)
+
+// The soak test runs the collector agent, in this process, against a fleet
+// of mock BMCs for as long as -soak says, e.g.
+//
+//	go test ./test/integration/ -run TestSoak -soak 4h -timeout 5h
+//
+// After a warm-up it takes a baseline of the process's heap, goroutines and
+// open files, and fails when a later sample outgrows the baseline by more
+// than the thresholds, or when more connections stay open to the BMCs
+// between runs than the agent collects from at once.
+var (
+	soakDuration       = flag.Duration("soak", 0, "run the soak test for this long (0 skips it)")
+	soakBMCs           = flag.Int("soak-bmcs", 16, "mock BMCs the soak test's agent collects from")
+	soakInterval       = flag.Duration("soak-interval", 10*time.Second, "interval between the soak test's agent runs")
+	soakErrorRate      = flag.Float64("soak-error-rate", 0.02, "share of Redfish reads failing during the soak test")
+	soakMaxHeapMiB     = flag.Float64("soak-max-heap-growth", 32, "MiB the heap may grow by over the soak test")
+	soakMaxGoroutines  = flag.Int("soak-max-goroutine-growth", 16, "goroutines the soak test may leave running")
+	soakMaxOpenFiles   = flag.Int("soak-max-fd-growth", 32, "file descriptors (Linux) the soak test may leave open")
+	soakSampleInterval = flag.Duration("soak-sample-interval", time.Minute, "how often the soak test samples the process")
+)
+
+// soakSample is the state of the process at one point of the soak test.
+type soakSample struct {
+	at         time.Time
+	heapMiB    float64
+	goroutines int
+	// openFiles is -1 where /proc/self/fd cannot be read.
+	openFiles int
+	// bmcConns counts connections open to the BMCs.
+	bmcConns int64
+}
+
+func (s soakSample) String() string {
+	return fmt.Sprintf("heap %.1f MiB, %d goroutines, %d open files, %d BMC connections", s.heapMiB, s.goroutines, s.openFiles, s.bmcConns)
+}
+
+func TestSoak(t *testing.T) {
+	if *soakDuration == 0 {
+		t.Skip("soak test; run with -soak <duration>")
+	}
+	h := newHarness(t)
+	ctx := context.Background()
+
+	fleet := make([]*bmc, *soakBMCs)
+	for i := range fleet {
+		fleet[i] = startBMC(t, redfishmock.DefaultNode(fmt.Sprintf("SOAK%04d", i)))
+		fleet[i].mock.SetFaults(redfishmock.Faults{ErrorRate: *soakErrorRate, Seed: int64(i)})
+		req := fabricaclient.CreateBMCEndpointRequest{
+			Name:            fmt.Sprintf("soak-%d", i),
+			BMCEndpointSpec: bmcendpoint.BMCEndpointSpec{Address: fleet[i].address, Profile: bmcendpoint.ProfileFull},
+		}
+		if _, err := h.client.CreateBMCEndpoint(ctx, req); err != nil {
+			t.Fatalf("failed to register BMC %s: %v", fleet[i].address, err)
+		}
+	}
+
+	opts := collector.CollectOptions{
+		Server:      h.url,
+		Credentials: collector.ConfigCredentials{Default: collector.Credential{Username: "admin", Password: "admin"}},
+	}
+	// As the agent command does
+	opts, err := opts.ShareAPIClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := collector.NewAgent(opts, *soakInterval, 0, collector.Shard{})
+	// Endpoints failing now and then should be retried next run, not backed off
+	agent.MaxBackoff = *soakInterval
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- agent.Run(runCtx, time.Minute) }()
+	defer func() {
+		stop()
+		if err := <-done; err != nil {
+			t.Errorf("agent did not stop: %v", err)
+		}
+	}()
+
+	sample := func() soakSample {
+		// Sample between runs, so that collections in progress do not count
+		for agent.Status().Running {
+			time.Sleep(100 * time.Millisecond)
+		}
+		runtime.GC()
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		s := soakSample{at: time.Now(), heapMiB: float64(mem.HeapAlloc) / (1 << 20), goroutines: runtime.NumGoroutine(), openFiles: -1}
+		if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+			s.openFiles = len(fds)
+		}
+		for _, b := range fleet {
+			s.bmcConns += b.conns.Load()
+		}
+		return s
+	}
+
+	// Let the first runs fill the caches and connection pools
+	warmUp := min(*soakDuration/10, 5**soakInterval)
+	time.Sleep(warmUp)
+	baseline := sample()
+	t.Logf("baseline after %s: %s", warmUp, baseline)
+
+	deadline := baseline.at.Add(*soakDuration - warmUp)
+	var peak soakSample
+	for time.Now().Before(deadline) {
+		time.Sleep(min(*soakSampleInterval, time.Until(deadline)))
+		s := sample()
+		status := agent.Status()
+		t.Logf("%s: %s; last run %d succeeded, %d failed", s.at.Sub(baseline.at).Round(time.Second), s, status.Succeeded, status.Failed)
+		if status.Succeeded == 0 {
+			t.Errorf("the last run collected from no BMC: %s", status.LastFailure)
+		}
+		peak.heapMiB = max(peak.heapMiB, s.heapMiB)
+		peak.goroutines = max(peak.goroutines, s.goroutines)
+		peak.openFiles = max(peak.openFiles, s.openFiles)
+		peak.bmcConns = max(peak.bmcConns, s.bmcConns)
+
+		if growth := s.heapMiB - baseline.heapMiB; growth > *soakMaxHeapMiB {
+			t.Fatalf("heap grew by %.1f MiB, more than %.1f", growth, *soakMaxHeapMiB)
+		}
+		if growth := s.goroutines - baseline.goroutines; growth > *soakMaxGoroutines {
+			t.Fatalf("goroutines grew by %d, more than %d", growth, *soakMaxGoroutines)
+		}
+		if growth := s.openFiles - baseline.openFiles; s.openFiles >= 0 && growth > *soakMaxOpenFiles {
+			t.Fatalf("open files grew by %d, more than %d", growth, *soakMaxOpenFiles)
+		}
+		if s.bmcConns > int64(agent.Concurrency) {
+			t.Fatalf("%d connections stayed open to the BMCs between runs; the agent collects from %d at once", s.bmcConns, agent.Concurrency)
+		}
+	}
+	t.Logf("peak: %s", peak)
+}